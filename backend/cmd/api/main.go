@@ -6,11 +6,11 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
-	"strings"
 	"syscall"
 	"time"
 
 	"github.com/PlatifyX/platifyx-core/internal/config"
+	"github.com/PlatifyX/platifyx-core/internal/domain"
 	"github.com/PlatifyX/platifyx-core/internal/handler"
 	"github.com/PlatifyX/platifyx-core/internal/middleware"
 	"github.com/PlatifyX/platifyx-core/internal/repository"
@@ -49,37 +49,21 @@ func main() {
 	log.Info("Migrations completed successfully")
 
 	orgRepo := repository.NewOrganizationRepository(db)
-	
-	platifyxOrgUUID := "ebb73e53-aa9e-4a9c-bc0c-531934c519e6"
-	platifyxOrg, err := orgRepo.GetByUUID(platifyxOrgUUID)
-	if err == nil && platifyxOrg != nil {
-		nodeDB, err := database.NewPostgresConnection(platifyxOrg.DatabaseAddressWrite)
-		if err == nil {
-			defer nodeDB.Close()
-			orgService := service.NewOrganizationService(orgRepo, db, log)
-			schemaName := strings.ReplaceAll(platifyxOrgUUID, "-", "_")
-			var count int
-			err = nodeDB.QueryRow(`
-				SELECT COUNT(*) 
-				FROM information_schema.schemata 
-				WHERE schema_name = $1
-			`, schemaName).Scan(&count)
-			if err == nil && count == 0 {
-				err = orgService.CreateSchemaInNodeDB(nodeDB, platifyxOrgUUID)
-				if err != nil {
-					log.Warnw("Failed to create schema for PlatifyX organization", "error", err)
-				} else {
-					log.Info("Created schema for PlatifyX organization in node database")
-				}
-			} else if err == nil && count > 0 {
-				log.Info("Schema for PlatifyX organization already exists")
-			}
-		}
-	}
 
 	serviceManager := service.NewServiceManager(cfg, log, db)
 	handlerManager := handler.NewHandlerManager(serviceManager, log)
 
+	// Tenant migration track: a failing organization pauses the run (the remaining ones are
+	// skipped) without preventing the API from starting
+	if cfg.TenantMigrationsOnStartup {
+		report, err := serviceManager.TenantMigrationService.MigrateAll(domain.TenantMigrationOptions{StopOnError: true})
+		if err != nil {
+			log.Warnw("Failed to run tenant migrations", "error", err)
+		} else if report.Failed > 0 {
+			log.Warnw("Tenant migrations did not complete", "failed", report.Failed, "skipped", report.Skipped)
+		}
+	}
+
 	userOrgRepo := repository.NewUserOrganizationRepository(db)
 
 	router := setupRouter(cfg, handlerManager, serviceManager, log, orgRepo, userOrgRepo)
@@ -111,6 +95,7 @@ func main() {
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Fatal("Server forced to shutdown", "error", err)
 	}
+	serviceManager.Close()
 
 	log.Info("Server exited")
 }
@@ -126,7 +111,14 @@ func setupRouter(cfg *config.Config, handlers *handler.HandlerManager, services
 	router.Use(middleware.Recovery(log))
 	router.Use(middleware.CORS(cfg.AllowedOrigins))
 
+	routePolicy := middleware.NewRoutePolicy(publicRoutes, routePermissions)
+	authorize := middleware.Authorize(routePolicy, services.UserService)
+
 	v1 := router.Group("/api/v1")
+	// Authentication runs first so that OrganizationMiddleware always checks the membership of the caller
+	v1.Use(middleware.Authenticate(routePolicy, services.AuthService))
+	// Every authenticated mutation (POST/PUT/PATCH/DELETE) is written to the audit trail
+	v1.Use(middleware.AuditTrail(routePolicy, services.AuditService))
 	{
 		v1.GET("/health", handlers.HealthHandler.Check)
 		v1.GET("/ready", handlers.HealthHandler.Ready)
@@ -134,6 +126,7 @@ func setupRouter(cfg *config.Config, handlers *handler.HandlerManager, services
 		// Service Catalog (discovered from Kubernetes)
 		serviceCatalog := v1.Group("/service-catalog")
 		serviceCatalog.Use(middleware.OrganizationMiddleware(orgRepo, userOrgRepo, log))
+		serviceCatalog.Use(authorize)
 		{
 			serviceCatalog.POST("/sync", handlers.ServiceCatalogHandler.SyncServices)
 			serviceCatalog.GET("", handlers.ServiceCatalogHandler.ListServices)
@@ -142,13 +135,66 @@ func setupRouter(cfg *config.Config, handlers *handler.HandlerManager, services
 		}
 
 		metrics := v1.Group("/metrics")
+		metrics.Use(middleware.OrganizationMiddleware(orgRepo, userOrgRepo, log))
+		metrics.Use(authorize)
 		{
 			metrics.GET("/dashboard", handlers.MetricsHandler.GetDashboard)
 			metrics.GET("/dora", handlers.MetricsHandler.GetDORA)
-		}
+			metrics.POST("/dora/refresh", handlers.MetricsHandler.RefreshDORASnapshots)
+		}
+
+		incidents := v1.Group("/incidents")
+		incidents.Use(middleware.OrganizationMiddleware(orgRepo, userOrgRepo, log))
+		incidents.Use(authorize)
+		{
+			incidents.GET("", handlers.IncidentHandler.ListIncidents)
+			incidents.POST("", handlers.IncidentHandler.CreateIncident)
+			incidents.GET("/metrics", handlers.IncidentHandler.GetMetrics)
+			incidents.GET("/:id", handlers.IncidentHandler.GetIncident)
+			incidents.PATCH("/:id", handlers.IncidentHandler.UpdateIncident)
+			incidents.POST("/:id/acknowledge", handlers.IncidentHandler.AcknowledgeIncident)
+			incidents.POST("/:id/resolve", handlers.IncidentHandler.ResolveIncident)
+			incidents.POST("/:id/reopen", handlers.IncidentHandler.ReopenIncident)
+			incidents.POST("/:id/notes", handlers.IncidentHandler.AddNote)
+		}
+
+		alerts := v1.Group("/alerts")
+		alerts.Use(middleware.OrganizationMiddleware(orgRepo, userOrgRepo, log))
+		alerts.Use(authorize)
+		{
+			alerts.GET("", handlers.AlertHandler.ListAlerts)
+			alerts.GET("/services/:service/timeline", handlers.AlertHandler.GetServiceTimeline)
+			alerts.GET("/webhook", handlers.AlertHandler.GetWebhookToken)
+			alerts.POST("/webhook/token", handlers.AlertHandler.GenerateWebhookToken)
+		}
+
+		notifications := v1.Group("/notifications")
+		notifications.Use(middleware.OrganizationMiddleware(orgRepo, userOrgRepo, log))
+		notifications.Use(authorize)
+		{
+			notifications.GET("/channels", handlers.NotificationHandler.ListChannels)
+			notifications.POST("/channels", handlers.NotificationHandler.CreateChannel)
+			notifications.PUT("/channels/:id", handlers.NotificationHandler.UpdateChannel)
+			notifications.DELETE("/channels/:id", handlers.NotificationHandler.DeleteChannel)
+			notifications.POST("/channels/:id/test", handlers.NotificationHandler.TestChannel)
+			notifications.GET("/rules", handlers.NotificationHandler.ListRules)
+			notifications.POST("/rules", handlers.NotificationHandler.CreateRule)
+			notifications.PUT("/rules/:id", handlers.NotificationHandler.UpdateRule)
+			notifications.DELETE("/rules/:id", handlers.NotificationHandler.DeleteRule)
+			notifications.GET("/deliveries", handlers.NotificationHandler.ListDeliveries)
+			notifications.POST("/deliveries/:id/retry", handlers.NotificationHandler.RetryDelivery)
+			notifications.POST("/events", handlers.NotificationHandler.PublishEvent)
+		}
+
+		// Slack slash commands, authenticated by the signing secret of the organization's Slack integration
+		v1.POST("/webhooks/slack/:organization/commands", handlers.IncidentHandler.HandleSlackCommand)
+		// Alertmanager and Grafana webhooks, authenticated by the alert webhook token of the organization
+		v1.POST("/webhooks/alerts/:organization/alertmanager", handlers.AlertHandler.ReceiveAlertmanager)
+		v1.POST("/webhooks/alerts/:organization/grafana", handlers.AlertHandler.ReceiveGrafana)
 
 		kubernetes := v1.Group("/kubernetes")
 		kubernetes.Use(middleware.OrganizationMiddleware(orgRepo, userOrgRepo, log))
+		kubernetes.Use(authorize)
 		{
 			kubernetes.GET("/cluster", handlers.KubernetesHandler.GetClusterInfo)
 			kubernetes.GET("/pods", handlers.KubernetesHandler.ListPods)
@@ -161,6 +207,7 @@ func setupRouter(cfg *config.Config, handlers *handler.HandlerManager, services
 
 		ci := v1.Group("/ci")
 		ci.Use(middleware.OrganizationMiddleware(orgRepo, userOrgRepo, log))
+		ci.Use(authorize)
 		{
 			ci.GET("/stats", handlers.AzureDevOpsHandler.GetStats)
 
@@ -183,6 +230,7 @@ func setupRouter(cfg *config.Config, handlers *handler.HandlerManager, services
 
 		quality := v1.Group("/quality")
 		quality.Use(middleware.OrganizationMiddleware(orgRepo, userOrgRepo, log))
+		quality.Use(authorize)
 		{
 			quality.GET("/stats", handlers.SonarQubeHandler.GetStats)
 
@@ -194,6 +242,7 @@ func setupRouter(cfg *config.Config, handlers *handler.HandlerManager, services
 
 		finops := v1.Group("/finops")
 		finops.Use(middleware.OrganizationMiddleware(orgRepo, userOrgRepo, log))
+		finops.Use(authorize)
 		{
 			finops.GET("/stats", handlers.FinOpsHandler.GetStats)
 			finops.GET("/costs", handlers.FinOpsHandler.ListCosts)
@@ -208,6 +257,7 @@ func setupRouter(cfg *config.Config, handlers *handler.HandlerManager, services
 
 		observability := v1.Group("/observability")
 		observability.Use(middleware.OrganizationMiddleware(orgRepo, userOrgRepo, log))
+		observability.Use(authorize)
 		{
 			observability.GET("/stats", handlers.GrafanaHandler.GetStats)
 			observability.GET("/health", handlers.GrafanaHandler.GetHealth)
@@ -241,6 +291,7 @@ func setupRouter(cfg *config.Config, handlers *handler.HandlerManager, services
 		// Grafana endpoints (alias for some observability endpoints)
 		grafana := v1.Group("/grafana")
 		grafana.Use(middleware.OrganizationMiddleware(orgRepo, userOrgRepo, log))
+		grafana.Use(authorize)
 		{
 			grafana.GET("/stats", handlers.GrafanaHandler.GetStats)
 			grafana.GET("/config", handlers.GrafanaHandler.GetConfig)
@@ -250,6 +301,7 @@ func setupRouter(cfg *config.Config, handlers *handler.HandlerManager, services
 
 		code := v1.Group("/code")
 		code.Use(middleware.OrganizationMiddleware(orgRepo, userOrgRepo, log))
+		code.Use(authorize)
 		{
 			code.GET("/stats", handlers.GitHubHandler.GetStats)
 			code.GET("/user", handlers.GitHubHandler.GetAuthenticatedUser)
@@ -264,14 +316,31 @@ func setupRouter(cfg *config.Config, handlers *handler.HandlerManager, services
 			code.GET("/repositories/:owner/:repo/actions/runs", handlers.GitHubHandler.ListWorkflowRuns)
 
 			code.GET("/organizations/:org", handlers.GitHubHandler.GetOrganization)
+
+			code.GET("/gitlab/stats", handlers.GitLabHandler.GetStats)
+			code.GET("/gitlab/user", handlers.GitLabHandler.GetAuthenticatedUser)
+			code.GET("/gitlab/projects", handlers.GitLabHandler.ListProjects)
+			code.GET("/gitlab/projects/:id", handlers.GitLabHandler.GetProject)
+			code.GET("/gitlab/projects/:id/commits", handlers.GitLabHandler.ListCommits)
+			code.GET("/gitlab/projects/:id/merge_requests", handlers.GitLabHandler.ListMergeRequests)
+			code.GET("/gitlab/projects/:id/issues", handlers.GitLabHandler.ListIssues)
+			code.GET("/gitlab/projects/:id/branches", handlers.GitLabHandler.ListBranches)
+			code.GET("/gitlab/projects/:id/pipelines", handlers.GitLabHandler.ListPipelines)
+			code.GET("/gitlab/groups/:group", handlers.GitLabHandler.GetGroup)
 		}
 
 		techdocs := v1.Group("/techdocs")
+		techdocs.Use(middleware.OrganizationMiddleware(orgRepo, userOrgRepo, log))
+		techdocs.Use(authorize)
 		{
 			techdocs.GET("/tree", handlers.TechDocsHandler.GetTree)
 			techdocs.GET("/document", handlers.TechDocsHandler.GetDocument)
 			techdocs.POST("/document", handlers.TechDocsHandler.SaveDocument)
 			techdocs.DELETE("/document", handlers.TechDocsHandler.DeleteDocument)
+			techdocs.GET("/document/history", handlers.TechDocsHandler.GetDocumentHistory)
+			techdocs.GET("/document/revision", handlers.TechDocsHandler.GetDocumentRevision)
+			techdocs.GET("/document/diff", handlers.TechDocsHandler.DiffDocument)
+			techdocs.POST("/document/restore", handlers.TechDocsHandler.RestoreDocument)
 			techdocs.POST("/folder", handlers.TechDocsHandler.CreateFolder)
 			techdocs.GET("/list", handlers.TechDocsHandler.ListDocuments)
 
@@ -280,88 +349,132 @@ func setupRouter(cfg *config.Config, handlers *handler.HandlerManager, services
 			techdocs.GET("/progress/:id", handlers.TechDocsHandler.GetProgress)
 			techdocs.POST("/improve", handlers.TechDocsHandler.ImproveDocumentation)
 			techdocs.POST("/chat", handlers.TechDocsHandler.ChatAboutDocumentation)
+			techdocs.GET("/store", handlers.TechDocsHandler.GetStoreStatus)
+			techdocs.GET("/index", handlers.TechDocsHandler.GetIndexStatus)
+			techdocs.POST("/index/sync", handlers.TechDocsHandler.SyncIndex)
 			techdocs.POST("/diagram", handlers.TechDocsHandler.GenerateDiagram)
 		}
 
 		ai := v1.Group("/ai")
 		ai.Use(middleware.OrganizationMiddleware(orgRepo, userOrgRepo, log))
+		ai.Use(authorize)
 		{
 			ai.GET("/providers", handlers.AIHandler.GetProviders)
+			ai.GET("/routing", handlers.AIHandler.GetRoutingPolicy)
+			ai.PUT("/routing", handlers.AIHandler.UpdateRoutingPolicy)
+			ai.GET("/usage", handlers.AIHandler.GetUsage)
+			ai.GET("/budget", handlers.AIHandler.GetBudget)
+			ai.PUT("/budget", handlers.AIHandler.UpdateBudget)
 		}
 
 		autonomous := v1.Group("/autonomous")
+		autonomous.Use(middleware.OrganizationMiddleware(orgRepo, userOrgRepo, log))
+		autonomous.Use(authorize)
 		{
 			autonomous.GET("/recommendations", handlers.AutonomousHandler.GetRecommendations)
 			autonomous.POST("/troubleshoot", handlers.AutonomousHandler.Troubleshoot)
+			autonomous.GET("/actions", handlers.AutonomousHandler.ListActions)
 			autonomous.POST("/actions/execute", handlers.AutonomousHandler.ExecuteAction)
 			autonomous.GET("/actions/config", handlers.AutonomousHandler.GetConfig)
 			autonomous.PUT("/actions/config", handlers.AutonomousHandler.UpdateConfig)
+			autonomous.GET("/actions/:id", handlers.AutonomousHandler.GetAction)
+			autonomous.POST("/actions/:id/approve", handlers.AutonomousHandler.ApproveAction)
+			autonomous.POST("/actions/:id/reject", handlers.AutonomousHandler.RejectAction)
+			autonomous.POST("/actions/:id/cancel", handlers.AutonomousHandler.CancelAction)
+			autonomous.POST("/actions/:id/undo", handlers.AutonomousHandler.UndoAction)
 		}
 
 		maturity := v1.Group("/maturity")
+		maturity.Use(middleware.OrganizationMiddleware(orgRepo, userOrgRepo, log))
+		maturity.Use(authorize)
 		{
 			maturity.GET("/service/metrics", handlers.MaturityHandler.GetServiceMetrics)
 			maturity.GET("/team/:team/scorecard", handlers.MaturityHandler.GetTeamScorecard)
 			maturity.GET("/teams/scorecards", handlers.MaturityHandler.GetAllTeamScorecards)
+			maturity.GET("/service/:service/scorecard", handlers.MaturityHandler.GetServiceScorecard)
+			maturity.GET("/scorecards/definition", handlers.MaturityHandler.GetScorecardDefinition)
+			maturity.PUT("/scorecards/definition", handlers.MaturityHandler.UpdateScorecardDefinition)
+			maturity.POST("/scorecards/evaluate", handlers.MaturityHandler.EvaluateScorecards)
 		}
 
 		autodocs := v1.Group("/autodocs")
+		autodocs.Use(middleware.OrganizationMiddleware(orgRepo, userOrgRepo, log))
+		autodocs.Use(authorize)
 		{
 			autodocs.POST("/generate", handlers.AutoDocsHandler.GenerateAutoDocs)
 			autodocs.GET("/progress/:id", handlers.AutoDocsHandler.GetProgress)
 		}
 
 		playbook := v1.Group("/playbook")
+		playbook.Use(middleware.OrganizationMiddleware(orgRepo, userOrgRepo, log))
+		playbook.Use(authorize)
 		{
 			playbook.POST("/service/create", handlers.ServicePlaybookHandler.CreateService)
 			playbook.GET("/service/progress/:id", handlers.ServicePlaybookHandler.GetProgress)
 		}
 
 		boards := v1.Group("/boards")
+		boards.Use(middleware.OptionalOrganizationMiddleware(orgRepo, userOrgRepo, log))
+		boards.Use(authorize)
 		{
 			boards.GET("/unified", handlers.BoardsHandler.GetUnifiedBoard)
 			boards.GET("/source/:source", handlers.BoardsHandler.GetBoardBySource)
 		}
 
 		organizations := v1.Group("/organizations")
+		organizations.Use(authorize)
 		{
 			organizations.GET("", handlers.OrganizationHandler.List)
-			organizations.GET("/:uuid", handlers.OrganizationHandler.GetByUUID)
+			organizations.GET("/migrations", handlers.TenantMigrationHandler.Status)
+			organizations.POST("/migrations/run", handlers.TenantMigrationHandler.Run)
 			organizations.POST("", handlers.OrganizationHandler.Create)
-			organizations.PUT("/:uuid", handlers.OrganizationHandler.Update)
-			organizations.DELETE("/:uuid", handlers.OrganizationHandler.Delete)
-
-			organizations.GET("/:uuid/users", handlers.UserOrganizationHandler.GetOrganizationUsers)
-			organizations.POST("/:uuid/users", handlers.UserOrganizationHandler.AddUserToOrganization)
-			organizations.PUT("/:uuid/users/:userId/role", handlers.UserOrganizationHandler.UpdateUserRole)
-			organizations.DELETE("/:uuid/users/:userId", handlers.UserOrganizationHandler.RemoveUserFromOrganization)
-
-			orgUsers := organizations.Group("/:uuid/node-users")
-			orgUsers.Use(middleware.OrganizationMiddleware(orgRepo, userOrgRepo, log))
-			{
-				orgUsers.GET("", handlers.OrganizationUserHandler.ListUsers)
-				orgUsers.GET("/:userId", handlers.OrganizationUserHandler.GetUser)
-				orgUsers.POST("", handlers.OrganizationUserHandler.CreateUser)
-				orgUsers.PUT("/:userId", handlers.OrganizationUserHandler.UpdateUser)
-				orgUsers.DELETE("/:userId", handlers.OrganizationUserHandler.DeleteUser)
-			}
 		}
 
+		// Routes of a single organization: the organization comes from :uuid, the caller must be a member
+		// and permissions are checked against the roles bound to them in that organization
+		organization := v1.Group("/organizations/:uuid")
+		organization.Use(middleware.OrganizationPathMiddleware(orgRepo, userOrgRepo, services.UserService, log))
+		organization.Use(authorize)
+		{
+			organization.GET("", handlers.OrganizationHandler.GetByUUID)
+			organization.GET("/database/health", handlers.OrganizationHandler.NodeDatabaseHealth)
+			organization.GET("/migrations", handlers.TenantMigrationHandler.OrganizationStatus)
+			organization.POST("/migrations/run", handlers.TenantMigrationHandler.RunOrganization)
+			organization.PUT("", handlers.OrganizationHandler.Update)
+			organization.DELETE("", handlers.OrganizationHandler.Delete)
+
+			organization.GET("/users", handlers.UserOrganizationHandler.GetOrganizationUsers)
+			organization.POST("/users", handlers.UserOrganizationHandler.AddUserToOrganization)
+			organization.PUT("/users/:userId/role", handlers.UserOrganizationHandler.UpdateUserRole)
+			organization.DELETE("/users/:userId", handlers.UserOrganizationHandler.RemoveUserFromOrganization)
+
+			organization.GET("/node-users", handlers.OrganizationUserHandler.ListUsers)
+			organization.GET("/node-users/:userId", handlers.OrganizationUserHandler.GetUser)
+			organization.POST("/node-users", handlers.OrganizationUserHandler.CreateUser)
+			organization.PUT("/node-users/:userId", handlers.OrganizationUserHandler.UpdateUser)
+			organization.DELETE("/node-users/:userId", handlers.OrganizationUserHandler.DeleteUser)
+		}
+
+		// Platform-wide groups (users, me, templates, settings) are not tied to an organization:
+		// authorize checks them against the caller's global roles only
 		users := v1.Group("/users")
+		users.Use(authorize)
 		{
 			users.GET("/:userId/organizations", handlers.UserOrganizationHandler.GetUserOrganizations)
 		}
 
 		me := v1.Group("/me")
-		me.Use(middleware.AuthMiddleware(services.AuthService))
+		me.Use(authorize)
 		{
 			me.GET("/organizations", handlers.UserOrganizationHandler.GetMyOrganizations)
 		}
 
 		integrations := v1.Group("/integrations")
 		integrations.Use(middleware.OrganizationMiddleware(orgRepo, userOrgRepo, log))
+		integrations.Use(authorize)
 		{
 			integrations.GET("", handlers.IntegrationHandler.List)
+			integrations.GET("/clients/stats", handlers.IntegrationHandler.ClientStats)
 			integrations.GET("/:id", handlers.IntegrationHandler.GetByID)
 			integrations.POST("", handlers.IntegrationHandler.Create)
 			integrations.PUT("/:id", handlers.IntegrationHandler.Update)
@@ -375,6 +488,7 @@ func setupRouter(cfg *config.Config, handlers *handler.HandlerManager, services
 			integrations.POST("/test/kubernetes", handlers.IntegrationHandler.TestKubernetes)
 			integrations.POST("/test/grafana", handlers.IntegrationHandler.TestGrafana)
 			integrations.POST("/test/github", handlers.IntegrationHandler.TestGitHub)
+			integrations.POST("/test/gitlab", handlers.IntegrationHandler.TestGitLab)
 			integrations.POST("/test/openai", handlers.IntegrationHandler.TestOpenAI)
 			integrations.POST("/test/gemini", handlers.IntegrationHandler.TestGemini)
 			integrations.POST("/test/claude", handlers.IntegrationHandler.TestClaude)
@@ -392,6 +506,7 @@ func setupRouter(cfg *config.Config, handlers *handler.HandlerManager, services
 
 		jira := v1.Group("/jira")
 		jira.Use(middleware.OrganizationMiddleware(orgRepo, userOrgRepo, log))
+		jira.Use(authorize)
 		{
 			jira.GET("/stats", handlers.JiraHandler.GetStats)
 			jira.GET("/user", handlers.JiraHandler.GetCurrentUser)
@@ -404,6 +519,7 @@ func setupRouter(cfg *config.Config, handlers *handler.HandlerManager, services
 
 		slack := v1.Group("/slack")
 		slack.Use(middleware.OrganizationMiddleware(orgRepo, userOrgRepo, log))
+		slack.Use(authorize)
 		{
 			slack.POST("/message", handlers.SlackHandler.SendMessage)
 			slack.POST("/simple", handlers.SlackHandler.SendSimpleMessage)
@@ -412,6 +528,7 @@ func setupRouter(cfg *config.Config, handlers *handler.HandlerManager, services
 
 		openvpn := v1.Group("/openvpn")
 		openvpn.Use(middleware.OrganizationMiddleware(orgRepo, userOrgRepo, log))
+		openvpn.Use(authorize)
 		{
 			openvpn.GET("/users", handlers.OpenVPNHandler.ListUsers)
 			openvpn.GET("/users/:username", handlers.OpenVPNHandler.GetUser)
@@ -422,6 +539,7 @@ func setupRouter(cfg *config.Config, handlers *handler.HandlerManager, services
 
 		teams := v1.Group("/teams")
 		teams.Use(middleware.OrganizationMiddleware(orgRepo, userOrgRepo, log))
+		teams.Use(authorize)
 		{
 			teams.POST("/message", handlers.TeamsHandler.SendMessage)
 			teams.POST("/simple", handlers.TeamsHandler.SendSimpleMessage)
@@ -430,6 +548,7 @@ func setupRouter(cfg *config.Config, handlers *handler.HandlerManager, services
 
 		argocd := v1.Group("/argocd")
 		argocd.Use(middleware.OrganizationMiddleware(orgRepo, userOrgRepo, log))
+		argocd.Use(authorize)
 		{
 			argocd.GET("/stats", handlers.ArgoCDHandler.GetStats)
 			argocd.GET("/applications", handlers.ArgoCDHandler.GetApplications)
@@ -445,6 +564,7 @@ func setupRouter(cfg *config.Config, handlers *handler.HandlerManager, services
 
 		prometheus := v1.Group("/prometheus")
 		prometheus.Use(middleware.OrganizationMiddleware(orgRepo, userOrgRepo, log))
+		prometheus.Use(authorize)
 		{
 			prometheus.GET("/stats", handlers.PrometheusHandler.GetStats)
 			prometheus.GET("/query", handlers.PrometheusHandler.Query)
@@ -460,6 +580,7 @@ func setupRouter(cfg *config.Config, handlers *handler.HandlerManager, services
 
 		vault := v1.Group("/vault")
 		vault.Use(middleware.OrganizationMiddleware(orgRepo, userOrgRepo, log))
+		vault.Use(authorize)
 		{
 			vault.GET("/stats", handlers.VaultHandler.GetStats)
 			vault.GET("/health", handlers.VaultHandler.GetHealth)
@@ -471,6 +592,7 @@ func setupRouter(cfg *config.Config, handlers *handler.HandlerManager, services
 
 		awssecrets := v1.Group("/awssecrets")
 		awssecrets.Use(middleware.OrganizationMiddleware(orgRepo, userOrgRepo, log))
+		awssecrets.Use(authorize)
 		{
 			awssecrets.GET("/stats", handlers.AWSSecretsHandler.GetStats)
 			awssecrets.GET("/list", handlers.AWSSecretsHandler.ListSecrets)
@@ -482,6 +604,7 @@ func setupRouter(cfg *config.Config, handlers *handler.HandlerManager, services
 		}
 
 		templates := v1.Group("/templates")
+		templates.Use(authorize)
 		{
 			templates.GET("", handlers.ServiceTemplateHandler.GetAllTemplates)
 			templates.GET("/:id", handlers.ServiceTemplateHandler.GetTemplateByID)
@@ -490,6 +613,7 @@ func setupRouter(cfg *config.Config, handlers *handler.HandlerManager, services
 		}
 
 		serviceTemplates := v1.Group("/service-templates")
+		serviceTemplates.Use(authorize)
 		{
 			serviceTemplates.GET("", handlers.ServiceTemplateHandler.GetAllServices)
 			serviceTemplates.GET("/:id", handlers.ServiceTemplateHandler.GetServiceByID)
@@ -498,6 +622,7 @@ func setupRouter(cfg *config.Config, handlers *handler.HandlerManager, services
 
 		// Infrastructure templates (Backstage-style)
 		infraTemplates := v1.Group("/infrastructure-templates")
+		infraTemplates.Use(authorize)
 		{
 			infraTemplates.GET("", handlers.TemplateHandler.ListTemplates)
 			infraTemplates.POST("/generate", handlers.TemplateHandler.GenerateTemplate)
@@ -506,6 +631,7 @@ func setupRouter(cfg *config.Config, handlers *handler.HandlerManager, services
 
 		// Settings - User Management System
 		settings := v1.Group("/settings")
+		settings.Use(authorize)
 		{
 			// Users
 			settings.GET("/users", handlers.SettingsHandler.ListUsers)
@@ -515,13 +641,6 @@ func setupRouter(cfg *config.Config, handlers *handler.HandlerManager, services
 			settings.PUT("/users/:id", handlers.SettingsHandler.UpdateUser)
 			settings.DELETE("/users/:id", handlers.SettingsHandler.DeleteUser)
 
-			// Roles
-			settings.GET("/roles", handlers.SettingsHandler.ListRoles)
-			settings.GET("/roles/:id", handlers.SettingsHandler.GetRole)
-			settings.POST("/roles", handlers.SettingsHandler.CreateRole)
-			settings.PUT("/roles/:id", handlers.SettingsHandler.UpdateRole)
-			settings.DELETE("/roles/:id", handlers.SettingsHandler.DeleteRole)
-
 			// Permissions
 			settings.GET("/permissions", handlers.SettingsHandler.ListPermissions)
 
@@ -543,10 +662,29 @@ func setupRouter(cfg *config.Config, handlers *handler.HandlerManager, services
 			// Audit
 			settings.GET("/audit", handlers.SettingsHandler.ListAuditLogs)
 			settings.GET("/audit/stats", handlers.SettingsHandler.GetAuditStats)
+			settings.GET("/audit/verify", handlers.AuditHandler.VerifyChain)
+			settings.GET("/audit/export", handlers.AuditHandler.Export)
+			settings.GET("/audit/export/manifest", handlers.AuditHandler.ExportManifest)
+		}
+
+		// Roles - escopo da organização quando X-Organization-UUID é informado
+		roles := v1.Group("/settings/roles")
+		roles.Use(middleware.OptionalOrganizationMiddleware(orgRepo, userOrgRepo, log))
+		roles.Use(authorize)
+		{
+			roles.GET("", handlers.SettingsHandler.ListRoles)
+			roles.GET("/bindings", handlers.SettingsHandler.ListRoleBindings)
+			roles.GET("/:id", handlers.SettingsHandler.GetRole)
+			roles.POST("", handlers.SettingsHandler.CreateRole)
+			roles.PUT("/:id", handlers.SettingsHandler.UpdateRole)
+			roles.DELETE("/:id", handlers.SettingsHandler.DeleteRole)
+			roles.POST("/:id/bindings", handlers.SettingsHandler.CreateRoleBinding)
+			roles.DELETE("/:id/bindings/:userId", handlers.SettingsHandler.DeleteRoleBinding)
 		}
 
 		// Authentication
 		auth := v1.Group("/auth")
+		auth.Use(authorize)
 		{
 			// Login endpoint com rate limiting apenas em produção
 			if cfg.Environment == "production" {
@@ -591,5 +729,14 @@ func setupRouter(cfg *config.Config, handlers *handler.HandlerManager, services
 		}
 	}
 
+	// Every route must be declared public or mapped to a permission
+	unprotected, stale := routePolicy.Uncovered(router.Routes())
+	if len(stale) > 0 {
+		log.Warnw("Access policy declares routes that are not registered", "routes", stale)
+	}
+	if len(unprotected) > 0 {
+		log.Fatalw("Routes are not covered by the access policy", "routes", unprotected)
+	}
+
 	return router
 }
@@ -0,0 +1,404 @@
+package main
+
+import "github.com/PlatifyX/platifyx-core/internal/middleware"
+
+// publicRoutes can be called without a token
+var publicRoutes = []string{
+	"GET /api/v1/health",
+	"GET /api/v1/ready",
+
+	"POST /api/v1/auth/login",
+	"POST /api/v1/auth/refresh",
+	"POST /api/v1/auth/forgot-password",
+	"POST /api/v1/auth/reset-password",
+	"GET /api/v1/auth/sso/:provider",
+	"GET /api/v1/auth/callback/:provider",
+
+	// Signed by Slack
+	"POST /api/v1/webhooks/slack/:organization/commands",
+	// Authenticated by the alert webhook token of the organization
+	"POST /api/v1/webhooks/alerts/:organization/alertmanager",
+	"POST /api/v1/webhooks/alerts/:organization/grafana",
+}
+
+func perm(resource, action string) middleware.RoutePermission {
+	return middleware.RoutePermission{Resource: resource, Action: action}
+}
+
+// routePermissions maps every protected route to the permission (seeded in the permissions table) it requires.
+// Every route registered in setupRouter must be listed here or in publicRoutes, otherwise the API refuses to start.
+var routePermissions = map[string]middleware.RoutePermission{
+	// Service Catalog
+	"POST /api/v1/service-catalog/sync":        perm("projects", "update"),
+	"GET /api/v1/service-catalog":              perm("projects", "view"),
+	"GET /api/v1/service-catalog/:name/status": perm("projects", "view"),
+	"POST /api/v1/service-catalog/metrics":     perm("projects", "view"),
+
+	// Metrics
+	"GET /api/v1/metrics/dashboard":     perm("observability", "view"),
+	"GET /api/v1/metrics/dora":          perm("observability", "view"),
+	"POST /api/v1/metrics/dora/refresh": perm("observability", "view"),
+
+	// Alerts received by webhook
+	"GET /api/v1/alerts":                            perm("observability", "view"),
+	"GET /api/v1/alerts/services/:service/timeline": perm("observability", "view"),
+	"GET /api/v1/alerts/webhook":                    perm("integrations", "view"),
+	"POST /api/v1/alerts/webhook/token":             perm("integrations", "manage"),
+
+	// Notification engine: channels, routing rules, delivery log and events
+	"GET /api/v1/notifications/channels":              perm("notifications", "view"),
+	"POST /api/v1/notifications/channels":             perm("notifications", "manage"),
+	"PUT /api/v1/notifications/channels/:id":          perm("notifications", "manage"),
+	"DELETE /api/v1/notifications/channels/:id":       perm("notifications", "manage"),
+	"POST /api/v1/notifications/channels/:id/test":    perm("notifications", "manage"),
+	"GET /api/v1/notifications/rules":                 perm("notifications", "view"),
+	"POST /api/v1/notifications/rules":                perm("notifications", "manage"),
+	"PUT /api/v1/notifications/rules/:id":             perm("notifications", "manage"),
+	"DELETE /api/v1/notifications/rules/:id":          perm("notifications", "manage"),
+	"GET /api/v1/notifications/deliveries":            perm("notifications", "view"),
+	"POST /api/v1/notifications/deliveries/:id/retry": perm("notifications", "manage"),
+	"POST /api/v1/notifications/events":               perm("notifications", "manage"),
+
+	// Incidents
+	"GET /api/v1/incidents":                  perm("incidents", "view"),
+	"POST /api/v1/incidents":                 perm("incidents", "manage"),
+	"GET /api/v1/incidents/metrics":          perm("incidents", "view"),
+	"GET /api/v1/incidents/:id":              perm("incidents", "view"),
+	"PATCH /api/v1/incidents/:id":            perm("incidents", "manage"),
+	"POST /api/v1/incidents/:id/acknowledge": perm("incidents", "manage"),
+	"POST /api/v1/incidents/:id/resolve":     perm("incidents", "manage"),
+	"POST /api/v1/incidents/:id/reopen":      perm("incidents", "manage"),
+	"POST /api/v1/incidents/:id/notes":       perm("incidents", "manage"),
+
+	// Kubernetes
+	"GET /api/v1/kubernetes/cluster":            perm("environments", "view"),
+	"GET /api/v1/kubernetes/pods":               perm("environments", "view"),
+	"GET /api/v1/kubernetes/pods/:podName/logs": perm("environments", "view"),
+	"GET /api/v1/kubernetes/deployments":        perm("environments", "view"),
+	"GET /api/v1/kubernetes/services":           perm("environments", "view"),
+	"GET /api/v1/kubernetes/namespaces":         perm("environments", "view"),
+	"GET /api/v1/kubernetes/nodes":              perm("environments", "view"),
+
+	// CI (Azure DevOps)
+	"GET /api/v1/ci/stats":              perm("pipelines", "view"),
+	"GET /api/v1/ci/pipelines":          perm("pipelines", "view"),
+	"GET /api/v1/ci/pipelines/:id/runs": perm("pipelines", "view"),
+	"GET /api/v1/ci/builds":             perm("pipelines", "view"),
+	"GET /api/v1/ci/builds/:id":         perm("pipelines", "view"),
+	"GET /api/v1/ci/builds/:id/logs":    perm("pipelines", "view"),
+	"POST /api/v1/ci/builds":            perm("pipelines", "execute"),
+	"GET /api/v1/ci/releases":           perm("deployments", "view"),
+	"GET /api/v1/ci/releases/:id":       perm("deployments", "view"),
+	"POST /api/v1/ci/releases/approve":  perm("deployments", "approve"),
+	"POST /api/v1/ci/releases/reject":   perm("deployments", "approve"),
+	"GET /api/v1/ci/repositories":       perm("projects", "view"),
+	"GET /api/v1/ci/repositories/stats": perm("projects", "view"),
+
+	// Quality (SonarQube)
+	"GET /api/v1/quality/stats":         perm("projects", "view"),
+	"GET /api/v1/quality/projects":      perm("projects", "view"),
+	"GET /api/v1/quality/projects/:key": perm("projects", "view"),
+	"GET /api/v1/quality/issues":        perm("projects", "view"),
+
+	// FinOps
+	"GET /api/v1/finops/stats":                         perm("finops", "view"),
+	"GET /api/v1/finops/costs":                         perm("finops", "view"),
+	"GET /api/v1/finops/resources":                     perm("finops", "view"),
+	"GET /api/v1/finops/aws/monthly":                   perm("finops", "view"),
+	"GET /api/v1/finops/aws/by-service":                perm("finops", "view"),
+	"GET /api/v1/finops/aws/forecast":                  perm("finops", "view"),
+	"GET /api/v1/finops/aws/by-tag":                    perm("finops", "view"),
+	"GET /api/v1/finops/aws/reservation-utilization":   perm("finops", "view"),
+	"GET /api/v1/finops/aws/savings-plans-utilization": perm("finops", "view"),
+
+	// Observability (Grafana, Loki)
+	"GET /api/v1/observability/stats":                     perm("observability", "view"),
+	"GET /api/v1/observability/health":                    perm("observability", "view"),
+	"GET /api/v1/observability/dashboards":                perm("observability", "view"),
+	"GET /api/v1/observability/dashboards/:uid":           perm("observability", "view"),
+	"GET /api/v1/observability/alerts":                    perm("observability", "view"),
+	"GET /api/v1/observability/datasources":               perm("observability", "view"),
+	"GET /api/v1/observability/datasources/:id":           perm("observability", "view"),
+	"GET /api/v1/observability/organizations":             perm("observability", "view"),
+	"GET /api/v1/observability/organization":              perm("observability", "view"),
+	"GET /api/v1/observability/users":                     perm("observability", "view"),
+	"GET /api/v1/observability/folders":                   perm("observability", "view"),
+	"GET /api/v1/observability/folders/:uid":              perm("observability", "view"),
+	"GET /api/v1/observability/annotations":               perm("observability", "view"),
+	"GET /api/v1/observability/logs/labels":               perm("observability", "view"),
+	"GET /api/v1/observability/logs/labels/:label/values": perm("observability", "view"),
+	"GET /api/v1/observability/logs/apps":                 perm("observability", "view"),
+	"GET /api/v1/observability/logs/query":                perm("observability", "view"),
+	"GET /api/v1/observability/logs/apps/:app":            perm("observability", "view"),
+
+	// Grafana
+	"GET /api/v1/grafana/stats":           perm("observability", "view"),
+	"GET /api/v1/grafana/config":          perm("observability", "view"),
+	"GET /api/v1/grafana/dashboards":      perm("observability", "view"),
+	"GET /api/v1/grafana/dashboards/:uid": perm("observability", "view"),
+
+	// Code (GitHub)
+	"GET /api/v1/code/stats":                                  perm("projects", "view"),
+	"GET /api/v1/code/user":                                   perm("projects", "view"),
+	"GET /api/v1/code/repositories":                           perm("projects", "view"),
+	"GET /api/v1/code/repositories/:owner/:repo":              perm("projects", "view"),
+	"GET /api/v1/code/repositories/:owner/:repo/commits":      perm("projects", "view"),
+	"GET /api/v1/code/repositories/:owner/:repo/pulls":        perm("projects", "view"),
+	"GET /api/v1/code/repositories/:owner/:repo/issues":       perm("projects", "view"),
+	"GET /api/v1/code/repositories/:owner/:repo/branches":     perm("projects", "view"),
+	"GET /api/v1/code/repositories/:owner/:repo/actions/runs": perm("projects", "view"),
+	"GET /api/v1/code/organizations/:org":                     perm("projects", "view"),
+
+	// Code (GitLab)
+	"GET /api/v1/code/gitlab/stats":                       perm("projects", "view"),
+	"GET /api/v1/code/gitlab/user":                        perm("projects", "view"),
+	"GET /api/v1/code/gitlab/projects":                    perm("projects", "view"),
+	"GET /api/v1/code/gitlab/projects/:id":                perm("projects", "view"),
+	"GET /api/v1/code/gitlab/projects/:id/commits":        perm("projects", "view"),
+	"GET /api/v1/code/gitlab/projects/:id/merge_requests": perm("projects", "view"),
+	"GET /api/v1/code/gitlab/projects/:id/issues":         perm("projects", "view"),
+	"GET /api/v1/code/gitlab/projects/:id/branches":       perm("projects", "view"),
+	"GET /api/v1/code/gitlab/projects/:id/pipelines":      perm("projects", "view"),
+	"GET /api/v1/code/gitlab/groups/:group":               perm("projects", "view"),
+
+	// TechDocs
+	"GET /api/v1/techdocs/tree":              perm("docs", "view"),
+	"GET /api/v1/techdocs/document":          perm("docs", "view"),
+	"POST /api/v1/techdocs/document":         perm("docs", "update"),
+	"DELETE /api/v1/techdocs/document":       perm("docs", "delete"),
+	"GET /api/v1/techdocs/document/history":  perm("docs", "view"),
+	"GET /api/v1/techdocs/document/revision": perm("docs", "view"),
+	"GET /api/v1/techdocs/document/diff":     perm("docs", "view"),
+	"POST /api/v1/techdocs/document/restore": perm("docs", "update"),
+	"POST /api/v1/techdocs/folder":           perm("docs", "update"),
+	"GET /api/v1/techdocs/list":              perm("docs", "view"),
+	"POST /api/v1/techdocs/generate":         perm("docs", "update"),
+	"GET /api/v1/techdocs/progress/:id":      perm("docs", "view"),
+	"POST /api/v1/techdocs/improve":          perm("docs", "update"),
+	"POST /api/v1/techdocs/chat":             perm("docs", "view"),
+	"GET /api/v1/techdocs/store":             perm("docs", "view"),
+	"GET /api/v1/techdocs/index":             perm("docs", "view"),
+	"POST /api/v1/techdocs/index/sync":       perm("docs", "update"),
+	"POST /api/v1/techdocs/diagram":          perm("docs", "update"),
+
+	// AI
+	"GET /api/v1/ai/providers": perm("settings", "view"),
+	"GET /api/v1/ai/routing":   perm("settings", "view"),
+	"PUT /api/v1/ai/routing":   perm("settings", "manage"),
+	"GET /api/v1/ai/usage":     perm("settings", "view"),
+	"GET /api/v1/ai/budget":    perm("settings", "view"),
+	"PUT /api/v1/ai/budget":    perm("settings", "manage"),
+
+	// Autonomous engineering
+	"GET /api/v1/autonomous/recommendations":      perm("autonomous_actions", "view"),
+	"POST /api/v1/autonomous/troubleshoot":        perm("autonomous_actions", "view"),
+	"GET /api/v1/autonomous/actions":              perm("autonomous_actions", "view"),
+	"POST /api/v1/autonomous/actions/execute":     perm("autonomous_actions", "execute"),
+	"GET /api/v1/autonomous/actions/config":       perm("autonomous_actions", "view"),
+	"PUT /api/v1/autonomous/actions/config":       perm("autonomous_actions", "manage"),
+	"GET /api/v1/autonomous/actions/:id":          perm("autonomous_actions", "view"),
+	"POST /api/v1/autonomous/actions/:id/approve": perm("autonomous_actions", "approve"),
+	"POST /api/v1/autonomous/actions/:id/reject":  perm("autonomous_actions", "approve"),
+	"POST /api/v1/autonomous/actions/:id/cancel":  perm("autonomous_actions", "execute"),
+	"POST /api/v1/autonomous/actions/:id/undo":    perm("autonomous_actions", "execute"),
+
+	// Maturity
+	"GET /api/v1/maturity/service/metrics":            perm("projects", "view"),
+	"GET /api/v1/maturity/team/:team/scorecard":       perm("projects", "view"),
+	"GET /api/v1/maturity/teams/scorecards":           perm("projects", "view"),
+	"GET /api/v1/maturity/service/:service/scorecard": perm("projects", "view"),
+	"GET /api/v1/maturity/scorecards/definition":      perm("projects", "view"),
+	"PUT /api/v1/maturity/scorecards/definition":      perm("projects", "update"),
+	"POST /api/v1/maturity/scorecards/evaluate":       perm("projects", "update"),
+
+	// AutoDocs
+	"POST /api/v1/autodocs/generate":    perm("docs", "update"),
+	"GET /api/v1/autodocs/progress/:id": perm("docs", "view"),
+
+	// Playbook
+	"POST /api/v1/playbook/service/create":      perm("projects", "create"),
+	"GET /api/v1/playbook/service/progress/:id": perm("projects", "view"),
+
+	// Boards
+	"GET /api/v1/boards/unified":        perm("projects", "view"),
+	"GET /api/v1/boards/source/:source": perm("projects", "view"),
+
+	// Organizations (the list is filtered to the caller's memberships)
+	"GET /api/v1/organizations":                             middleware.Authenticated,
+	"GET /api/v1/organizations/:uuid":                       perm("organizations", "view"),
+	"GET /api/v1/organizations/:uuid/database/health":       perm("organizations", "update"),
+	"GET /api/v1/organizations/migrations":                  perm("organizations", "view"),
+	"POST /api/v1/organizations/migrations/run":             middleware.PlatformAdmin,
+	"GET /api/v1/organizations/:uuid/migrations":            perm("organizations", "view"),
+	"POST /api/v1/organizations/:uuid/migrations/run":       perm("organizations", "update"),
+	"POST /api/v1/organizations":                            perm("organizations", "create"),
+	"PUT /api/v1/organizations/:uuid":                       perm("organizations", "update"),
+	"DELETE /api/v1/organizations/:uuid":                    perm("organizations", "delete"),
+	"GET /api/v1/organizations/:uuid/users":                 perm("organizations", "view"),
+	"POST /api/v1/organizations/:uuid/users":                perm("organizations", "manage_members"),
+	"PUT /api/v1/organizations/:uuid/users/:userId/role":    perm("organizations", "manage_members"),
+	"DELETE /api/v1/organizations/:uuid/users/:userId":      perm("organizations", "manage_members"),
+	"GET /api/v1/organizations/:uuid/node-users":            perm("organizations", "view"),
+	"GET /api/v1/organizations/:uuid/node-users/:userId":    perm("organizations", "view"),
+	"POST /api/v1/organizations/:uuid/node-users":           perm("organizations", "manage_members"),
+	"PUT /api/v1/organizations/:uuid/node-users/:userId":    perm("organizations", "manage_members"),
+	"DELETE /api/v1/organizations/:uuid/node-users/:userId": perm("organizations", "manage_members"),
+
+	// Users and current user
+	"GET /api/v1/users/:userId/organizations": perm("users", "view"),
+	"GET /api/v1/me/organizations":            middleware.Authenticated,
+
+	// Integrations
+	"GET /api/v1/integrations":                      perm("integrations", "view"),
+	"GET /api/v1/integrations/clients/stats":        perm("integrations", "view"),
+	"GET /api/v1/integrations/:id":                  perm("integrations", "view"),
+	"POST /api/v1/integrations":                     perm("integrations", "manage"),
+	"PUT /api/v1/integrations/:id":                  perm("integrations", "manage"),
+	"DELETE /api/v1/integrations/:id":               perm("integrations", "manage"),
+	"POST /api/v1/integrations/request":             perm("integrations", "view"),
+	"POST /api/v1/integrations/test/azuredevops":    perm("integrations", "manage"),
+	"POST /api/v1/integrations/test/sonarqube":      perm("integrations", "manage"),
+	"POST /api/v1/integrations/test/azure":          perm("integrations", "manage"),
+	"POST /api/v1/integrations/test/gcp":            perm("integrations", "manage"),
+	"POST /api/v1/integrations/test/aws":            perm("integrations", "manage"),
+	"POST /api/v1/integrations/test/kubernetes":     perm("integrations", "manage"),
+	"POST /api/v1/integrations/test/grafana":        perm("integrations", "manage"),
+	"POST /api/v1/integrations/test/github":         perm("integrations", "manage"),
+	"POST /api/v1/integrations/test/gitlab":         perm("integrations", "manage"),
+	"POST /api/v1/integrations/test/openai":         perm("integrations", "manage"),
+	"POST /api/v1/integrations/test/gemini":         perm("integrations", "manage"),
+	"POST /api/v1/integrations/test/claude":         perm("integrations", "manage"),
+	"POST /api/v1/integrations/test/jira":           perm("integrations", "manage"),
+	"POST /api/v1/integrations/test/slack":          perm("integrations", "manage"),
+	"POST /api/v1/integrations/test/teams":          perm("integrations", "manage"),
+	"POST /api/v1/integrations/test/argocd":         perm("integrations", "manage"),
+	"POST /api/v1/integrations/test/prometheus":     perm("integrations", "manage"),
+	"POST /api/v1/integrations/test/loki":           perm("integrations", "manage"),
+	"POST /api/v1/integrations/test/vault":          perm("integrations", "manage"),
+	"POST /api/v1/integrations/test/awssecrets":     perm("integrations", "manage"),
+	"POST /api/v1/integrations/test/openvpn":        perm("integrations", "manage"),
+	"GET /api/v1/integrations/azuredevops/projects": perm("integrations", "view"),
+
+	// Jira
+	"GET /api/v1/jira/stats":                   perm("projects", "view"),
+	"GET /api/v1/jira/user":                    perm("projects", "view"),
+	"GET /api/v1/jira/projects":                perm("projects", "view"),
+	"GET /api/v1/jira/issues":                  perm("projects", "view"),
+	"GET /api/v1/jira/issues/:key":             perm("projects", "view"),
+	"GET /api/v1/jira/boards":                  perm("projects", "view"),
+	"GET /api/v1/jira/boards/:boardId/sprints": perm("projects", "view"),
+
+	// Slack and Teams notifications
+	"POST /api/v1/slack/message": perm("notifications", "send"),
+	"POST /api/v1/slack/simple":  perm("notifications", "send"),
+	"POST /api/v1/slack/alert":   perm("notifications", "send"),
+	"POST /api/v1/teams/message": perm("notifications", "send"),
+	"POST /api/v1/teams/simple":  perm("notifications", "send"),
+	"POST /api/v1/teams/alert":   perm("notifications", "send"),
+
+	// OpenVPN
+	"GET /api/v1/openvpn/users":              perm("vpn", "view"),
+	"GET /api/v1/openvpn/users/:username":    perm("vpn", "view"),
+	"POST /api/v1/openvpn/users":             perm("vpn", "manage"),
+	"PUT /api/v1/openvpn/users/:username":    perm("vpn", "manage"),
+	"DELETE /api/v1/openvpn/users/:username": perm("vpn", "manage"),
+
+	// ArgoCD
+	"GET /api/v1/argocd/stats":                        perm("deployments", "view"),
+	"GET /api/v1/argocd/applications":                 perm("deployments", "view"),
+	"GET /api/v1/argocd/applications/:name":           perm("deployments", "view"),
+	"POST /api/v1/argocd/applications/:name/sync":     perm("deployments", "create"),
+	"POST /api/v1/argocd/applications/:name/refresh":  perm("deployments", "view"),
+	"POST /api/v1/argocd/applications/:name/rollback": perm("deployments", "rollback"),
+	"DELETE /api/v1/argocd/applications/:name":        perm("deployments", "delete"),
+	"GET /api/v1/argocd/projects":                     perm("deployments", "view"),
+	"GET /api/v1/argocd/projects/:name":               perm("deployments", "view"),
+	"GET /api/v1/argocd/clusters":                     perm("deployments", "view"),
+
+	// Prometheus
+	"GET /api/v1/prometheus/stats":               perm("observability", "view"),
+	"GET /api/v1/prometheus/query":               perm("observability", "view"),
+	"GET /api/v1/prometheus/query_range":         perm("observability", "view"),
+	"GET /api/v1/prometheus/targets":             perm("observability", "view"),
+	"GET /api/v1/prometheus/alerts":              perm("observability", "view"),
+	"GET /api/v1/prometheus/rules":               perm("observability", "view"),
+	"GET /api/v1/prometheus/label/:label/values": perm("observability", "view"),
+	"GET /api/v1/prometheus/series":              perm("observability", "view"),
+	"GET /api/v1/prometheus/metadata":            perm("observability", "view"),
+	"GET /api/v1/prometheus/buildinfo":           perm("observability", "view"),
+
+	// Vault
+	"GET /api/v1/vault/stats":        perm("secrets", "view"),
+	"GET /api/v1/vault/health":       perm("secrets", "view"),
+	"GET /api/v1/vault/kv/read":      perm("secrets", "read"),
+	"GET /api/v1/vault/kv/list":      perm("secrets", "view"),
+	"POST /api/v1/vault/kv/write":    perm("secrets", "manage"),
+	"DELETE /api/v1/vault/kv/delete": perm("secrets", "manage"),
+
+	// AWS Secrets Manager
+	"GET /api/v1/awssecrets/stats":           perm("secrets", "view"),
+	"GET /api/v1/awssecrets/list":            perm("secrets", "view"),
+	"GET /api/v1/awssecrets/secret/:name":    perm("secrets", "read"),
+	"GET /api/v1/awssecrets/describe/:name":  perm("secrets", "view"),
+	"POST /api/v1/awssecrets/create":         perm("secrets", "manage"),
+	"PUT /api/v1/awssecrets/update/:name":    perm("secrets", "manage"),
+	"DELETE /api/v1/awssecrets/delete/:name": perm("secrets", "manage"),
+
+	// Templates
+	"GET /api/v1/templates":             perm("projects", "view"),
+	"GET /api/v1/templates/:id":         perm("projects", "view"),
+	"GET /api/v1/templates/stats":       perm("projects", "view"),
+	"POST /api/v1/templates/initialize": perm("settings", "manage"),
+
+	"GET /api/v1/service-templates":         perm("projects", "view"),
+	"GET /api/v1/service-templates/:id":     perm("projects", "view"),
+	"POST /api/v1/service-templates/create": perm("projects", "create"),
+
+	"GET /api/v1/infrastructure-templates":           perm("projects", "view"),
+	"POST /api/v1/infrastructure-templates/generate": perm("projects", "create"),
+	"POST /api/v1/infrastructure-templates/preview":  perm("projects", "view"),
+
+	// Settings - User Management System
+	"GET /api/v1/settings/users":        perm("users", "view"),
+	"GET /api/v1/settings/users/stats":  perm("users", "view"),
+	"GET /api/v1/settings/users/:id":    perm("users", "view"),
+	"POST /api/v1/settings/users":       perm("users", "create"),
+	"PUT /api/v1/settings/users/:id":    perm("users", "update"),
+	"DELETE /api/v1/settings/users/:id": perm("users", "delete"),
+
+	"GET /api/v1/settings/roles":        perm("roles", "view"),
+	"GET /api/v1/settings/roles/:id":    perm("roles", "view"),
+	"POST /api/v1/settings/roles":       perm("roles", "create"),
+	"PUT /api/v1/settings/roles/:id":    perm("roles", "update"),
+	"DELETE /api/v1/settings/roles/:id": perm("roles", "delete"),
+
+	"GET /api/v1/settings/roles/bindings":                perm("roles", "view"),
+	"POST /api/v1/settings/roles/:id/bindings":           perm("users", "manage_roles"),
+	"DELETE /api/v1/settings/roles/:id/bindings/:userId": perm("users", "manage_roles"),
+
+	"GET /api/v1/settings/permissions": perm("roles", "view"),
+
+	"GET /api/v1/settings/teams":                        perm("teams", "view"),
+	"GET /api/v1/settings/teams/:id":                    perm("teams", "view"),
+	"POST /api/v1/settings/teams":                       perm("teams", "create"),
+	"PUT /api/v1/settings/teams/:id":                    perm("teams", "update"),
+	"DELETE /api/v1/settings/teams/:id":                 perm("teams", "delete"),
+	"POST /api/v1/settings/teams/:id/members":           perm("teams", "manage_members"),
+	"DELETE /api/v1/settings/teams/:id/members/:userId": perm("teams", "manage_members"),
+
+	"GET /api/v1/settings/sso":              perm("sso", "view"),
+	"GET /api/v1/settings/sso/:provider":    perm("sso", "view"),
+	"POST /api/v1/settings/sso":             perm("sso", "manage"),
+	"DELETE /api/v1/settings/sso/:provider": perm("sso", "manage"),
+
+	"GET /api/v1/settings/audit":                 perm("audit", "view"),
+	"GET /api/v1/settings/audit/stats":           perm("audit", "view"),
+	"GET /api/v1/settings/audit/verify":          perm("audit", "view"),
+	"GET /api/v1/settings/audit/export":          perm("audit", "export"),
+	"GET /api/v1/settings/audit/export/manifest": perm("audit", "export"),
+
+	// Authentication (session endpoints)
+	"POST /api/v1/auth/logout":          middleware.Authenticated,
+	"GET /api/v1/auth/me":               middleware.Authenticated,
+	"POST /api/v1/auth/change-password": middleware.Authenticated,
+}
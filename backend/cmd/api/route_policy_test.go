@@ -0,0 +1,111 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/PlatifyX/platifyx-core/internal/config"
+	"github.com/PlatifyX/platifyx-core/internal/handler"
+	"github.com/PlatifyX/platifyx-core/internal/middleware"
+	"github.com/PlatifyX/platifyx-core/internal/service"
+	"github.com/PlatifyX/platifyx-core/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// seededPermissionPattern matches the (resource, action, 'resource.action', ...) tuples of the permission seeds
+var seededPermissionPattern = regexp.MustCompile(`\(\s*'([a-z_]+)',\s*'([a-z_]+)',\s*'([a-z_]+\.[a-z_]+)'`)
+
+// testRouter builds the real router with stub handlers and services. Handlers are only
+// registered, never called, so their nil receivers are never dereferenced.
+func testRouter(t *testing.T, environment string) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{Environment: environment}
+	return setupRouter(cfg, &handler.HandlerManager{}, &service.ServiceManager{}, logger.NewLogger("development"), nil, nil)
+}
+
+// seededPermissions reads every resource/action pair inserted into the permissions table by the migrations
+func seededPermissions(t *testing.T) map[middleware.RoutePermission]bool {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join("..", "..", "migrations", "*.sql"))
+	if err != nil {
+		t.Fatalf("listing migrations: %v", err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no migrations found")
+	}
+
+	seeded := make(map[middleware.RoutePermission]bool)
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("reading %s: %v", file, err)
+		}
+		for _, match := range seededPermissionPattern.FindAllStringSubmatch(string(content), -1) {
+			if match[3] != match[1]+"."+match[2] {
+				continue
+			}
+			seeded[perm(match[1], match[2])] = true
+		}
+	}
+	return seeded
+}
+
+func TestEveryRouteIsPublicOrProtected(t *testing.T) {
+	public := make(map[string]bool, len(publicRoutes))
+	for _, route := range publicRoutes {
+		public[route] = true
+	}
+
+	// Production registers the rate-limited variants of the auth routes
+	for _, environment := range []string{"development", "production"} {
+		t.Run(environment, func(t *testing.T) {
+			routes := testRouter(t, environment).Routes()
+			if len(routes) == 0 {
+				t.Fatal("router has no routes")
+			}
+
+			for _, route := range routes {
+				key := routeKey(route.Method, route.Path)
+				_, protected := routePermissions[key]
+				if public[key] && protected {
+					t.Errorf("%s is declared both public and protected", key)
+				}
+				if !public[key] && !protected {
+					t.Errorf("%s is neither public nor mapped to a permission", key)
+				}
+			}
+		})
+	}
+}
+
+func TestRoutePolicyHasNoStaleEntries(t *testing.T) {
+	router := testRouter(t, "development")
+	policy := middleware.NewRoutePolicy(publicRoutes, routePermissions)
+
+	_, stale := policy.Uncovered(router.Routes())
+	for _, key := range stale {
+		t.Errorf("%s is declared in the access policy but not registered", key)
+	}
+}
+
+func TestRoutePermissionsAreSeeded(t *testing.T) {
+	seeded := seededPermissions(t)
+
+	for key, permission := range routePermissions {
+		if permission == middleware.Authenticated || permission == middleware.PlatformAdmin {
+			continue
+		}
+		if !seeded[permission] {
+			t.Errorf("%s requires %s.%s, which no migration seeds", key, permission.Resource, permission.Action)
+		}
+	}
+}
+
+func routeKey(method, path string) string {
+	return method + " " + path
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/PlatifyX/platifyx-core/internal/config"
+	"github.com/PlatifyX/platifyx-core/internal/repository"
+	"github.com/PlatifyX/platifyx-core/internal/service"
+	"github.com/PlatifyX/platifyx-core/pkg/database"
+	"github.com/PlatifyX/platifyx-core/pkg/logger"
+)
+
+// rotate-keys rotates the encryption keys that protect integration credentials.
+//
+// By default a new data key is created for every organization and all of its
+// integration configs are re-encrypted with it. With -rewrap only the data keys
+// are re-wrapped with the active master key (after ENCRYPTION_ACTIVE_KEY_ID changes).
+func main() {
+	orgUUID := flag.String("org", "", "rotate only this organization UUID")
+	rewrap := flag.Bool("rewrap", false, "re-wrap data keys with the active master key instead of rotating data keys")
+	flag.Parse()
+
+	cfg := config.Load()
+
+	log := logger.NewLogger(cfg.Environment)
+	defer log.Sync()
+
+	db, err := database.NewPostgresConnection(cfg.DatabaseURL)
+	if err != nil {
+		log.Fatalw("Failed to connect to database", "error", err)
+	}
+	defer db.Close()
+
+	credentialService, err := service.NewCredentialServiceFromConfig(cfg, repository.NewDataKeyRepository(db), log)
+	if err != nil {
+		log.Fatalw("Failed to initialize credential encryption", "error", err)
+	}
+	if credentialService == nil {
+		log.Fatal("ENCRYPTION_MASTER_KEYS must be set to rotate keys")
+	}
+
+	if *rewrap {
+		count, err := credentialService.RewrapDataKeys()
+		if err != nil {
+			log.Fatalw("Failed to re-wrap data keys", "error", err, "rewrapped", count)
+		}
+		log.Infow("Data keys re-wrapped", "count", count)
+		return
+	}
+
+	integrationRepo := repository.NewIntegrationRepository(db)
+	integrationService := service.NewIntegrationService(integrationRepo, credentialService, nil, log)
+
+	organizationUUIDs := []string{*orgUUID}
+	if *orgUUID == "" {
+		organizationUUIDs, err = integrationRepo.GetOrganizationUUIDs()
+		if err != nil {
+			log.Fatalw("Failed to list organizations", "error", err)
+		}
+	}
+
+	for _, organizationUUID := range organizationUUIDs {
+		count, err := integrationService.RotateCredentials(organizationUUID)
+		if err != nil {
+			log.Fatalw("Failed to rotate credentials", "error", err, "organizationUUID", organizationUUID, "reencrypted", count)
+		}
+	}
+
+	log.Infow("Key rotation completed", "organizations", len(organizationUUIDs))
+}
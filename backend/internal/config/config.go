@@ -17,6 +17,14 @@ type Config struct {
 	// Database
 	DatabaseURL string
 
+	// Organization node databases (one pool per organization and role)
+	NodeDBMaxOpenConns    int
+	NodeDBMaxIdleConns    int
+	NodeDBConnMaxLifetime int // seconds
+
+	// Apply the tenant migration track to every organization at startup
+	TenantMigrationsOnStartup bool
+
 	// Redis
 	RedisEnabled bool
 	RedisHost    string
@@ -40,6 +48,26 @@ type Config struct {
 	// Authentication
 	JWTSecret      string
 	SessionTimeout int // seconds
+
+	// Integration credentials encryption
+	EncryptionMasterKeys  string // comma separated "id:base64key" pairs
+	EncryptionActiveKeyID string
+
+	// Audit export manifests signature (base64 Ed25519 seed), required
+	AuditSigningKey string
+
+	// Offline AI provider answering locally (development and tests)
+	AIFakeProvider bool
+
+	// TechDocs storage: "git" versions every change, "filesystem" keeps plain files
+	TechDocsStore string
+	// Optional remote the TechDocs commits are pushed to (GitHub, GitLab, Azure DevOps over HTTPS)
+	TechDocsGitRemoteURL string
+	TechDocsGitBranch    string
+	TechDocsGitToken     string
+	// Organization that receives the documents left in the shared docs root by versions
+	// before TechDocs were scoped to organizations (the seeded PlatifyX organization)
+	TechDocsLegacyOrganization string
 }
 
 func Load() *Config {
@@ -64,6 +92,13 @@ func Load() *Config {
 		// Database
 		DatabaseURL: getEnv("DATABASE_URL", ""),
 
+		// Organization node databases
+		NodeDBMaxOpenConns:    getEnvInt("NODE_DB_MAX_OPEN_CONNS", 10),
+		NodeDBMaxIdleConns:    getEnvInt("NODE_DB_MAX_IDLE_CONNS", 2),
+		NodeDBConnMaxLifetime: getEnvInt("NODE_DB_CONN_MAX_LIFETIME", 1800), // 30 minutes default
+
+		TenantMigrationsOnStartup: getEnvBool("TENANT_MIGRATIONS_ON_STARTUP", true),
+
 		// Redis
 		RedisEnabled: getEnvBool("REDIS_ENABLED", true),
 		RedisHost:    getEnv("REDIS_HOST", "localhost"),
@@ -87,6 +122,23 @@ func Load() *Config {
 		// Authentication
 		JWTSecret:      getEnv("JWT_SECRET", "your-secret-key-change-in-production"),
 		SessionTimeout: getEnvInt("SESSION_TIMEOUT", 86400), // 24 hours default
+
+		// Integration credentials encryption
+		EncryptionMasterKeys:  getEnv("ENCRYPTION_MASTER_KEYS", ""),
+		EncryptionActiveKeyID: getEnv("ENCRYPTION_ACTIVE_KEY_ID", ""),
+
+		// Audit
+		AuditSigningKey: getEnv("AUDIT_SIGNING_KEY", ""),
+
+		// AI
+		AIFakeProvider: getEnvBool("AI_FAKE_PROVIDER", false),
+
+		// TechDocs
+		TechDocsStore:              getEnv("TECHDOCS_STORE", "git"),
+		TechDocsGitRemoteURL:       getEnv("TECHDOCS_GIT_REMOTE_URL", ""),
+		TechDocsGitBranch:          getEnv("TECHDOCS_GIT_BRANCH", "main"),
+		TechDocsGitToken:           getEnv("TECHDOCS_GIT_TOKEN", ""),
+		TechDocsLegacyOrganization: getEnv("TECHDOCS_LEGACY_ORGANIZATION", "ebb73e53-aa9e-4a9c-bc0c-531934c519e6"),
 	}
 }
 
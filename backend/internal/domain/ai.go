@@ -1,11 +1,34 @@
 package domain
 
+import (
+	"fmt"
+	"time"
+)
+
 type AIProvider string
 
 const (
 	AIProviderOpenAI AIProvider = "openai"
 	AIProviderClaude AIProvider = "claude"
 	AIProviderGemini AIProvider = "gemini"
+	// AIProviderFake answers locally without any API (enabled by AI_FAKE_PROVIDER for offline tests)
+	AIProviderFake AIProvider = "fake"
+)
+
+// AIFeature identifies the platform feature calling the AI gateway, so each one can be routed
+// to its own provider and model
+type AIFeature string
+
+const (
+	AIFeatureDefault         AIFeature = "default"
+	AIFeatureTechDocs        AIFeature = "techdocs"
+	AIFeatureChat            AIFeature = "chat"
+	AIFeatureDiagram         AIFeature = "diagram"
+	AIFeatureAutoDocs        AIFeature = "autodocs"
+	AIFeatureTroubleshooting AIFeature = "troubleshooting"
+	AIFeatureRecommendations AIFeature = "recommendations"
+	// AIFeatureEmbeddings embeds the TechDocs for retrieval (only providers with embedding models)
+	AIFeatureEmbeddings AIFeature = "embeddings"
 )
 
 type AIGenerateDocRequest struct {
@@ -20,6 +43,8 @@ type AIGenerateDocRequest struct {
 	Model         string     `json:"model,omitempty"`
 	ReadFullRepo  bool       `json:"readFullRepo,omitempty"` // Read entire repository
 	SavePath      string     `json:"savePath,omitempty"` // Custom save path (e.g., "ia/reponame.md")
+	UserID        string     `json:"-"`
+	Author        TechDocAuthor `json:"-"` // Set by the handler, author of the saved document
 }
 
 type AIImproveDocRequest struct {
@@ -27,6 +52,7 @@ type AIImproveDocRequest struct {
 	Content        string     `json:"content"`
 	ImprovementType string    `json:"improvementType"` // "grammar", "clarity", "structure", "complete"
 	Model          string     `json:"model,omitempty"`
+	UserID         string     `json:"-"`
 }
 
 type AIChatRequest struct {
@@ -35,6 +61,7 @@ type AIChatRequest struct {
 	Context     string     `json:"context,omitempty"`     // Document content for context
 	Conversation []ChatMessage `json:"conversation,omitempty"` // Previous messages
 	Model       string     `json:"model,omitempty"`
+	UserID      string     `json:"-"`
 }
 
 type ChatMessage struct {
@@ -47,6 +74,16 @@ type AIResponse struct {
 	Model    string     `json:"model"`
 	Content  string     `json:"content"`
 	Usage    *AIUsage   `json:"usage,omitempty"`
+	// Sources are the documentation chunks the answer was grounded on (TechDocs chat)
+	Sources []TechDocSource `json:"sources,omitempty"`
+}
+
+// AIEmbeddings are the vectors of a batch of texts, in the order of the texts. Vectors of different
+// models are not comparable.
+type AIEmbeddings struct {
+	Provider AIProvider  `json:"provider"`
+	Model    string      `json:"model"`
+	Vectors  [][]float32 `json:"vectors"`
 }
 
 type AIUsage struct {
@@ -56,8 +93,116 @@ type AIUsage struct {
 }
 
 type AIProviderInfo struct {
-	Provider  AIProvider `json:"provider"`
-	Name      string     `json:"name"`
-	Available bool       `json:"available"`
-	Models    []string   `json:"models"`
+	Provider     AIProvider `json:"provider"`
+	Name         string     `json:"name"`
+	Available    bool       `json:"available"`
+	Models       []string   `json:"models"`
+	DefaultModel string     `json:"defaultModel"`
+}
+
+// AIRoute is the preferred provider and model of a feature (an empty model uses the provider default)
+type AIRoute struct {
+	Provider AIProvider `json:"provider"`
+	Model    string     `json:"model,omitempty"`
+}
+
+// AIRoutingPolicy is the per-organization routing of the AI gateway. Routes["default"] applies to
+// features without their own route. FallbackOrder lists the providers tried, in order, when the
+// preferred one keeps failing; providers that are not configured are skipped.
+type AIRoutingPolicy struct {
+	Routes        map[AIFeature]AIRoute `json:"routes"`
+	FallbackOrder []AIProvider          `json:"fallbackOrder"`
+	MaxRetries    int                   `json:"maxRetries"`
+	UpdatedBy     string                `json:"updatedBy,omitempty"`
+	UpdatedAt     *time.Time            `json:"updatedAt,omitempty"`
+}
+
+// AICaller identifies who calls the AI gateway, for routing and usage metering (UserID is empty
+// for background jobs). The requests of the AI features (docs, chat, diagrams, auto docs,
+// troubleshooting) carry the UserID of their AICaller in a field hidden from JSON, which the
+// handler sets from the authenticated user.
+type AICaller struct {
+	OrganizationUUID string
+	UserID           string
+	Feature          AIFeature
+}
+
+// AIUsageRecord is the metering of one AI gateway call
+type AIUsageRecord struct {
+	ID               int64      `json:"id"`
+	OrganizationUUID string     `json:"organizationUuid"`
+	UserID           string     `json:"userId,omitempty"`
+	Feature          AIFeature  `json:"feature"`
+	Provider         AIProvider `json:"provider"`
+	Model            string     `json:"model"`
+	InputTokens      int        `json:"inputTokens"`
+	OutputTokens     int        `json:"outputTokens"`
+	TotalTokens      int        `json:"totalTokens"`
+	// TokensEstimated is set when the provider did not report usage and tokens were estimated from the text size
+	TokensEstimated  bool      `json:"tokensEstimated"`
+	EstimatedCostUSD float64   `json:"estimatedCostUsd"`
+	CreatedAt        time.Time `json:"createdAt"`
+}
+
+// AIUsageTotals aggregates usage records (a period, a feature, a model or a user)
+type AIUsageTotals struct {
+	Key              string  `json:"key,omitempty"`
+	Requests         int     `json:"requests"`
+	InputTokens      int64   `json:"inputTokens"`
+	OutputTokens     int64   `json:"outputTokens"`
+	TotalTokens      int64   `json:"totalTokens"`
+	EstimatedCostUSD float64 `json:"estimatedCostUsd"`
+}
+
+// AIUsagePoint is the usage of one period of the series
+type AIUsagePoint struct {
+	Period time.Time `json:"period"`
+	AIUsageTotals
+}
+
+const (
+	AIUsageIntervalDay   = "day"
+	AIUsageIntervalMonth = "month"
+)
+
+// AIUsageReport is the AI usage of an organization between two dates
+type AIUsageReport struct {
+	From      time.Time       `json:"from"`
+	To        time.Time       `json:"to"`
+	Interval  string          `json:"interval"`
+	Totals    AIUsageTotals   `json:"totals"`
+	Series    []AIUsagePoint  `json:"series"`
+	ByFeature []AIUsageTotals `json:"byFeature"`
+	ByModel   []AIUsageTotals `json:"byModel"`
+	ByUser    []AIUsageTotals `json:"byUser"`
+}
+
+// AIBudget is the monthly AI spending limit of an organization. A zero limit means no limit;
+// WarningPercent is the share of the limit that triggers the Slack/Teams warning.
+type AIBudget struct {
+	MonthlyLimitUSD float64    `json:"monthlyLimitUsd"`
+	WarningPercent  int        `json:"warningPercent"`
+	UpdatedBy       string     `json:"updatedBy,omitempty"`
+	UpdatedAt       *time.Time `json:"updatedAt,omitempty"`
+}
+
+// AIBudgetStatus is the spending of the current month against the budget
+type AIBudgetStatus struct {
+	Budget       AIBudget  `json:"budget"`
+	Month        time.Time `json:"month"`
+	SpentUSD     float64   `json:"spentUsd"`
+	RemainingUSD *float64  `json:"remainingUsd,omitempty"`
+	PercentUsed  float64   `json:"percentUsed"`
+	Warning      bool      `json:"warning"`
+	Exceeded     bool      `json:"exceeded"`
+}
+
+// AIBudgetExceededError is returned by the AI gateway once the organization spent its monthly budget
+type AIBudgetExceededError struct {
+	LimitUSD float64
+	SpentUSD float64
+}
+
+func (e *AIBudgetExceededError) Error() string {
+	return fmt.Sprintf("monthly AI budget exceeded: spent $%.2f of $%.2f", e.SpentUSD, e.LimitUSD)
 }
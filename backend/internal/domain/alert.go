@@ -0,0 +1,110 @@
+package domain
+
+import "time"
+
+// Source of an alert received by webhook
+const (
+	AlertSourceAlertmanager = "alertmanager"
+	AlertSourceGrafana      = "grafana"
+)
+
+// Status of an alert
+const (
+	AlertStatusFiring   = "firing"
+	AlertStatusResolved = "resolved"
+)
+
+// Alert is one occurrence of an alert sent by Alertmanager or Grafana: repeated deliveries of the
+// same fingerprint and start time update it instead of creating a new one
+type Alert struct {
+	ID               int               `json:"id"`
+	OrganizationUUID string            `json:"organizationUuid"`
+	Source           string            `json:"source"`
+	Fingerprint      string            `json:"fingerprint"`
+	Status           string            `json:"status"`
+	Name             string            `json:"name"`
+	ServiceName      string            `json:"serviceName,omitempty"` // Catalog service matched by the labels
+	Squad            string            `json:"squad,omitempty"`
+	Severity         string            `json:"severity,omitempty"`
+	Summary          string            `json:"summary,omitempty"`
+	Description      string            `json:"description,omitempty"`
+	Labels           map[string]string `json:"labels"`
+	Annotations      map[string]string `json:"annotations"`
+	StartsAt         time.Time         `json:"startsAt"`
+	EndsAt           *time.Time        `json:"endsAt,omitempty"`
+	GeneratorURL     string            `json:"generatorUrl,omitempty"`
+	ReceivedCount    int               `json:"receivedCount"`
+	LastReceivedAt   time.Time         `json:"lastReceivedAt"`
+	CreatedAt        time.Time         `json:"createdAt"`
+	UpdatedAt        time.Time         `json:"updatedAt"`
+}
+
+// AlertWebhookPayload is the body Alertmanager sends to webhook receivers. Grafana unified alerting
+// sends the same fields plus its own (title, message, dashboard and panel URLs).
+type AlertWebhookPayload struct {
+	Version           string              `json:"version"`
+	GroupKey          string              `json:"groupKey"`
+	Status            string              `json:"status"`
+	Receiver          string              `json:"receiver"`
+	GroupLabels       map[string]string   `json:"groupLabels"`
+	CommonLabels      map[string]string   `json:"commonLabels"`
+	CommonAnnotations map[string]string   `json:"commonAnnotations"`
+	ExternalURL       string              `json:"externalURL"`
+	Alerts            []AlertWebhookAlert `json:"alerts"`
+	// Grafana
+	Title   string `json:"title,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type AlertWebhookAlert struct {
+	Status       string            `json:"status"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"` // Zero time (0001-01-01) while firing
+	GeneratorURL string            `json:"generatorURL"`
+	Fingerprint  string            `json:"fingerprint"`
+	// Grafana
+	DashboardURL string `json:"dashboardURL,omitempty"`
+	PanelURL     string `json:"panelURL,omitempty"`
+	ValueString  string `json:"valueString,omitempty"`
+}
+
+// AlertWebhookResult summarizes what a webhook delivery changed
+type AlertWebhookResult struct {
+	Received int `json:"received"`
+	Created  int `json:"created"`  // New occurrences
+	Resolved int `json:"resolved"` // Occurrences resolved by this delivery
+	Repeated int `json:"repeated"` // Deliveries of occurrences already stored with the same status
+}
+
+// AlertFilter filters the alerts of an organization
+type AlertFilter struct {
+	Status      string `form:"status"`
+	Source      string `form:"source"`
+	ServiceName string `form:"service"`
+	Page        int    `form:"page"`
+	Size        int    `form:"size"`
+}
+
+// AlertTimelineEntry is the firing or the resolution of an alert
+type AlertTimelineEntry struct {
+	AlertID     int       `json:"alertId"`
+	Status      string    `json:"status"`
+	At          time.Time `json:"at"`
+	Name        string    `json:"name"`
+	Source      string    `json:"source"`
+	ServiceName string    `json:"serviceName"`
+	Severity    string    `json:"severity,omitempty"`
+	Summary     string    `json:"summary,omitempty"`
+}
+
+// AlertWebhookToken describes the token the webhooks of an organization are authenticated with.
+// The token itself is only returned when it is generated.
+type AlertWebhookToken struct {
+	Configured  bool       `json:"configured"`
+	Token       string     `json:"token,omitempty"`
+	TokenPrefix string     `json:"tokenPrefix,omitempty"`
+	CreatedBy   string     `json:"createdBy,omitempty"`
+	CreatedAt   *time.Time `json:"createdAt,omitempty"`
+}
@@ -44,6 +44,16 @@ type ArgoCDApplicationStatus struct {
 	Health        ArgoCDHealthStatus `json:"health"`
 	Sync          ArgoCDSyncStatus   `json:"sync"`
 	OperationState *ArgoCDOperationState `json:"operationState,omitempty"`
+	History       []ArgoCDRevisionHistory `json:"history,omitempty"`
+}
+
+// ArgoCDRevisionHistory is one entry of the application's deployment history
+type ArgoCDRevisionHistory struct {
+	ID              int64        `json:"id"`
+	Revision        string       `json:"revision"`
+	DeployedAt      time.Time    `json:"deployedAt"`
+	DeployStartedAt *time.Time   `json:"deployStartedAt,omitempty"`
+	Source          ArgoCDSource `json:"source"`
 }
 
 type ArgoCDHealthStatus struct {
@@ -1,23 +1,77 @@
 package domain
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"time"
 )
 
 // AuditLog representa um registro de auditoria
 type AuditLog struct {
-	ID         string          `json:"id" db:"id"`
-	UserID     *string         `json:"user_id,omitempty" db:"user_id"`
-	UserEmail  string          `json:"user_email" db:"user_email"`
-	Action     string          `json:"action" db:"action"`
-	Resource   string          `json:"resource" db:"resource"`
-	ResourceID *string         `json:"resource_id,omitempty" db:"resource_id"`
-	Details    json.RawMessage `json:"details,omitempty" db:"details"`
-	IPAddress  *string         `json:"ip_address,omitempty" db:"ip_address"`
-	UserAgent  *string         `json:"user_agent,omitempty" db:"user_agent"`
-	Status     string          `json:"status" db:"status"` // 'success', 'failure'
-	CreatedAt  time.Time       `json:"created_at" db:"created_at"`
+	ID               string          `json:"id" db:"id"`
+	UserID           *string         `json:"user_id,omitempty" db:"user_id"`
+	UserEmail        string          `json:"user_email" db:"user_email"`
+	OrganizationUUID *string         `json:"organization_uuid,omitempty" db:"organization_uuid"`
+	Action           string          `json:"action" db:"action"`
+	Resource         string          `json:"resource" db:"resource"`
+	ResourceID       *string         `json:"resource_id,omitempty" db:"resource_id"`
+	Details          json.RawMessage `json:"details,omitempty" db:"details"`
+	IPAddress        *string         `json:"ip_address,omitempty" db:"ip_address"`
+	UserAgent        *string         `json:"user_agent,omitempty" db:"user_agent"`
+	Status           string          `json:"status" db:"status"` // 'success', 'failure'
+	CreatedAt        time.Time       `json:"created_at" db:"created_at"`
+
+	// Encadeamento (nil em registros anteriores à cadeia)
+	Sequence *int64  `json:"sequence,omitempty" db:"sequence"`
+	PrevHash *string `json:"prev_hash,omitempty" db:"prev_hash"`
+	Hash     *string `json:"hash,omitempty" db:"hash"`
+}
+
+// ComputeHash calcula o SHA-256 do registro encadeado ao hash anterior (PrevHash).
+// Details é normalizado para que o hash independa da formatação do JSONB no banco.
+func (l *AuditLog) ComputeHash() (string, error) {
+	var details interface{}
+	if len(l.Details) > 0 {
+		if err := json.Unmarshal(l.Details, &details); err != nil {
+			return "", err
+		}
+	}
+
+	var sequence int64
+	if l.Sequence != nil {
+		sequence = *l.Sequence
+	}
+
+	payload, err := json.Marshal([]interface{}{
+		stringValue(l.PrevHash),
+		sequence,
+		l.ID,
+		stringValue(l.UserID),
+		l.UserEmail,
+		stringValue(l.OrganizationUUID),
+		l.Action,
+		l.Resource,
+		stringValue(l.ResourceID),
+		details,
+		stringValue(l.IPAddress),
+		stringValue(l.UserAgent),
+		l.Status,
+		l.CreatedAt.UTC().Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
 }
 
 // CreateAuditLogRequest representa o request para criar um log de auditoria
@@ -43,26 +97,70 @@ type AuditLogListResponse struct {
 
 // AuditLogFilter representa os filtros para busca de logs
 type AuditLogFilter struct {
-	UserID     string    `form:"user_id"`
-	UserEmail  string    `form:"user_email"`
-	Action     string    `form:"action"`
-	Resource   string    `form:"resource"`
-	ResourceID string    `form:"resource_id"`
-	Status     string    `form:"status"`
-	StartDate  time.Time `form:"start_date"`
-	EndDate    time.Time `form:"end_date"`
-	Page       int       `form:"page"`
-	Size       int       `form:"size"`
+	UserID           string    `form:"user_id" json:"user_id,omitempty"`
+	UserEmail        string    `form:"user_email" json:"user_email,omitempty"`
+	OrganizationUUID string    `form:"organization_uuid" json:"organization_uuid,omitempty"`
+	Action           string    `form:"action" json:"action,omitempty"`
+	Resource         string    `form:"resource" json:"resource,omitempty"`
+	ResourceID       string    `form:"resource_id" json:"resource_id,omitempty"`
+	Status           string    `form:"status" json:"status,omitempty"`
+	StartDate        time.Time `form:"start_date" json:"start_date"`
+	EndDate          time.Time `form:"end_date" json:"end_date"`
+	Page             int       `form:"page" json:"-"`
+	Size             int       `form:"size" json:"-"`
+}
+
+// AuditChainVerification representa o resultado da verificação da cadeia de uma organização
+// (cadeia global quando OrganizationUUID é vazio)
+type AuditChainVerification struct {
+	OrganizationUUID string           `json:"organization_uuid,omitempty"`
+	Valid            bool             `json:"valid"`
+	Entries          int64            `json:"entries"`
+	UnchainedEntries int64            `json:"unchained_entries"`
+	HeadSequence     int64            `json:"head_sequence"`
+	HeadHash         string           `json:"head_hash,omitempty"`
+	Break            *AuditChainBreak `json:"break,omitempty"`
+	VerifiedAt       time.Time        `json:"verified_at"`
+}
+
+// AuditChainHead representa o último elo de uma cadeia e a âncora deixada pela retenção de logs
+type AuditChainHead struct {
+	Sequence       int64
+	Hash           string
+	AnchorSequence int64
+	AnchorHash     string
+}
+
+// AuditChainBreak descreve o primeiro ponto em que a cadeia não confere
+type AuditChainBreak struct {
+	Sequence int64  `json:"sequence"`
+	LogID    string `json:"log_id,omitempty"`
+	Reason   string `json:"reason"`
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+}
+
+// AuditExportManifest descreve uma exportação de logs de auditoria e é assinado (Ed25519)
+// para que auditores confiram a integridade do arquivo exportado
+type AuditExportManifest struct {
+	Format      string         `json:"format"`
+	Filter      AuditLogFilter `json:"filter"`
+	Entries     int64          `json:"entries"`
+	SHA256      string         `json:"sha256"`
+	GeneratedAt time.Time      `json:"generated_at"`
+	Algorithm   string         `json:"algorithm"`
+	PublicKey   string         `json:"public_key"`
+	Signature   string         `json:"signature,omitempty"`
 }
 
 // AuditStats representa estatísticas de auditoria
 type AuditStats struct {
-	TotalLogs       int                       `json:"total_logs"`
-	LogsByAction    map[string]int            `json:"logs_by_action"`
-	LogsByResource  map[string]int            `json:"logs_by_resource"`
-	LogsByStatus    map[string]int            `json:"logs_by_status"`
-	LogsByUser      []UserActivityStats       `json:"logs_by_user"`
-	RecentActivity  []AuditLog                `json:"recent_activity"`
+	TotalLogs      int                 `json:"total_logs"`
+	LogsByAction   map[string]int      `json:"logs_by_action"`
+	LogsByResource map[string]int      `json:"logs_by_resource"`
+	LogsByStatus   map[string]int      `json:"logs_by_status"`
+	LogsByUser     []UserActivityStats `json:"logs_by_user"`
+	RecentActivity []AuditLog          `json:"recent_activity"`
 }
 
 // UserActivityStats representa estatísticas de atividade por usuário
@@ -29,10 +29,13 @@ type AutoDocRequest struct {
 	DocTypes         []DocType       `json:"docTypes"` // Empty = generate all
 	ServiceName      string          `json:"serviceName"`
 	Branch            string          `json:"branch,omitempty"` // Default: main/master
+	UserID           string          `json:"-"`
+	Author           TechDocAuthor   `json:"-"` // Set by the handler, author of the saved documents
 }
 
 type AutoDocProgress struct {
 	ID          string                 `json:"id"`
+	OrganizationUUID string            `json:"organizationUuid"`
 	Status      string                 `json:"status"` // "pending", "analyzing", "generating", "completed", "failed"
 	Progress    float64                `json:"progress"` // 0-100
 	CurrentStep string                 `json:"currentStep"`
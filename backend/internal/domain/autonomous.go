@@ -42,6 +42,7 @@ type TroubleshootingRequest struct {
 	ServiceName string                 `json:"serviceName,omitempty"`
 	Deployment  string                 `json:"deployment,omitempty"`
 	Namespace   string                 `json:"namespace,omitempty"`
+	UserID      string                 `json:"-"`
 }
 
 type TroubleshootingResponse struct {
@@ -64,18 +65,65 @@ type RecommendedAction struct {
 	AutoExecute bool                   `json:"autoExecute"`
 }
 
+// Status of an autonomous action
+const (
+	AutonomousActionPendingApproval = "pending_approval"
+	AutonomousActionApproved        = "approved"
+	AutonomousActionExecuting       = "executing"
+	AutonomousActionCompleted       = "completed"
+	AutonomousActionFailed          = "failed"
+	AutonomousActionRejected        = "rejected"
+	AutonomousActionCancelled       = "cancelled"
+	AutonomousActionUndone          = "undone"
+)
+
 type AutonomousAction struct {
-	ID          string                 `json:"id"`
-	Type        string                 `json:"type"`
-	Status      string                 `json:"status"`
-	Description string                 `json:"description"`
-	Trigger     string                 `json:"trigger"`
-	Action      RecommendedAction     `json:"action"`
-	Result      map[string]interface{} `json:"result,omitempty"`
-	Error       string                 `json:"error,omitempty"`
-	CreatedAt   time.Time              `json:"createdAt"`
-	ExecutedAt  *time.Time             `json:"executedAt,omitempty"`
-	ExecutedBy  string                 `json:"executedBy"`
+	ID               string                 `json:"id"`
+	OrganizationUUID string                 `json:"organizationUuid"`
+	Type             string                 `json:"type"`
+	Status           string                 `json:"status"`
+	Description      string                 `json:"description"`
+	Trigger          string                 `json:"trigger"`
+	Action           RecommendedAction      `json:"action"`
+	Result           map[string]interface{} `json:"result,omitempty"`
+	Error            string                 `json:"error,omitempty"`
+	BeforeState      *AutonomousActionState `json:"beforeState,omitempty"`
+	AfterState       *AutonomousActionState `json:"afterState,omitempty"`
+	UndoOf           string                 `json:"undoOf,omitempty"`
+	RequestedBy      string                 `json:"requestedBy"`
+	DecidedBy        string                 `json:"decidedBy,omitempty"`
+	DecidedAt        *time.Time             `json:"decidedAt,omitempty"`
+	DecisionReason   string                 `json:"decisionReason,omitempty"`
+	ExecutedBy       string                 `json:"executedBy,omitempty"`
+	ExecutedAt       *time.Time             `json:"executedAt,omitempty"`
+	UndoneAt         *time.Time             `json:"undoneAt,omitempty"`
+	CreatedAt        time.Time              `json:"createdAt"`
+	UpdatedAt        time.Time              `json:"updatedAt"`
+}
+
+// AutonomousActionActor identifies who requested, decided or undid an action, for the audit log
+type AutonomousActionActor struct {
+	UserID    string
+	UserEmail string
+	IPAddress string
+	UserAgent string
+}
+
+// AutonomousActionFilter filters the list of actions of an organization
+type AutonomousActionFilter struct {
+	Status string `form:"status"`
+	Type   string `form:"type"`
+	Page   int    `form:"page"`
+	Size   int    `form:"size"`
+}
+
+// AutonomousActionState is a snapshot of the target of an action, taken before and after it
+// runs, with enough information to undo it
+type AutonomousActionState struct {
+	Deployment  *KubernetesDeploymentState `json:"deployment,omitempty"`
+	Application string                     `json:"application,omitempty"` // ArgoCD application, when managed by ArgoCD
+	HistoryID   int64                      `json:"historyId,omitempty"`   // ArgoCD history entry deployed
+	Revision    string                     `json:"revision,omitempty"`
 }
 
 type AutonomousConfig struct {
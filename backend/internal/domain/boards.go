@@ -8,6 +8,7 @@ const (
 	BoardSourceJira        BoardSource = "jira"
 	BoardSourceAzureDevOps BoardSource = "azuredevops"
 	BoardSourceGitHub      BoardSource = "github"
+	BoardSourceGitLab      BoardSource = "gitlab"
 )
 
 type BoardItem struct {
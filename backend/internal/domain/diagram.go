@@ -22,6 +22,7 @@ type GenerateDiagramRequest struct {
 	Description string      `json:"description,omitempty"`
 	Language    string      `json:"language,omitempty"`
 	Model       string      `json:"model,omitempty"`
+	UserID      string      `json:"-"`
 }
 
 type DiagramResponse struct {
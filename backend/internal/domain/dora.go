@@ -0,0 +1,84 @@
+package domain
+
+import "time"
+
+// DORA scopes
+const (
+	DORAScopeOrganization = "organization"
+	DORAScopeService      = "service"
+	DORAScopeSquad        = "squad"
+)
+
+// DORA performance levels
+const (
+	DORAClassificationElite  = "Elite"
+	DORAClassificationHigh   = "High"
+	DORAClassificationMedium = "Medium"
+	DORAClassificationLow    = "Low"
+)
+
+// DeploymentEvent is a production deployment collected from a CI/CD source
+type DeploymentEvent struct {
+	Service    string    `json:"service"`
+	Source     string    `json:"source"` // azuredevops, github, argocd
+	Revision   string    `json:"revision,omitempty"`
+	DeployedAt time.Time `json:"deployedAt"`
+	Succeeded  bool      `json:"succeeded"`
+	// LeadTimes holds the commit-to-deploy duration of every change shipped by this deployment
+	LeadTimes []time.Duration `json:"-"`
+}
+
+// RestoreEvent is a production failure (firing alert or incident) and the moment it was resolved
+type RestoreEvent struct {
+	Service    string    `json:"service"`
+	Source     string    `json:"source"` // prometheus
+	Name       string    `json:"name"`
+	StartedAt  time.Time `json:"startedAt"`
+	ResolvedAt time.Time `json:"resolvedAt"`
+}
+
+// DORASnapshot stores the raw DORA counters of one day for one scope,
+// so metrics for any time window can be aggregated from daily rows
+type DORASnapshot struct {
+	ID                   int       `json:"id"`
+	OrganizationUUID     string    `json:"organizationUuid"`
+	Scope                string    `json:"scope"`
+	ScopeKey             string    `json:"scopeKey"`
+	Date                 time.Time `json:"date"`
+	Deployments          int       `json:"deployments"`
+	FailedDeployments    int       `json:"failedDeployments"`
+	LeadTimeMinutesTotal float64   `json:"leadTimeMinutesTotal"`
+	LeadTimeSamples      int       `json:"leadTimeSamples"`
+	RestoreMinutesTotal  float64   `json:"restoreMinutesTotal"`
+	Restores             int       `json:"restores"`
+	CreatedAt            time.Time `json:"createdAt"`
+	UpdatedAt            time.Time `json:"updatedAt"`
+}
+
+type DORAMetric struct {
+	Value          float64 `json:"value"`
+	Unit           string  `json:"unit"`
+	Classification string  `json:"classification"`
+	Samples        int     `json:"samples"`
+}
+
+type DORATrendPoint struct {
+	Date                 string  `json:"date"`
+	Deployments          int     `json:"deployments"`
+	FailedDeployments    int     `json:"failedDeployments"`
+	LeadTimeMinutes      float64 `json:"leadTimeMinutes"`
+	TimeToRestoreMinutes float64 `json:"timeToRestoreMinutes"`
+}
+
+type DORAMetrics struct {
+	Scope               string           `json:"scope"`
+	ScopeKey            string           `json:"scopeKey"`
+	WindowStart         time.Time        `json:"windowStart"`
+	WindowEnd           time.Time        `json:"windowEnd"`
+	Days                int              `json:"days"`
+	DeploymentFrequency DORAMetric       `json:"deploymentFrequency"`
+	LeadTimeForChanges  DORAMetric       `json:"leadTimeForChanges"`
+	ChangeFailureRate   DORAMetric       `json:"changeFailureRate"`
+	TimeToRestore       DORAMetric       `json:"timeToRestore"`
+	Trend               []DORATrendPoint `json:"trend"`
+}
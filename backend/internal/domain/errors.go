@@ -51,3 +51,14 @@ type ForbiddenError struct {
 func (e *ForbiddenError) Error() string {
 	return fmt.Sprintf("forbidden: cannot %s %s", e.Action, e.Resource)
 }
+
+// InvalidStateError representa uma operação que não é permitida no estado atual do recurso
+type InvalidStateError struct {
+	Resource string
+	ID       string
+	State    string
+}
+
+func (e *InvalidStateError) Error() string {
+	return fmt.Sprintf("%s %s is %s", e.Resource, e.ID, e.State)
+}
@@ -2,9 +2,19 @@ package domain
 
 import "time"
 
+// GitHubConfig authenticates either with a personal access token or as a GitHub App
+// installation (AppID, PrivateKey and InstallationID)
 type GitHubConfig struct {
-	Token        string `json:"token"`
-	Organization string `json:"organization,omitempty"`
+	Token          string `json:"token"`
+	Organization   string `json:"organization,omitempty"`
+	AppID          int64  `json:"appId,omitempty"`
+	PrivateKey     string `json:"privateKey,omitempty"`
+	InstallationID int64  `json:"installationId,omitempty"`
+}
+
+// UsesApp reports whether the config authenticates as a GitHub App installation
+func (c GitHubConfig) UsesApp() bool {
+	return c.AppID != 0
 }
 
 type GitHubRepository struct {
@@ -183,6 +193,16 @@ type GitHubOrganization struct {
 	Followers   int    `json:"followers,omitempty"`
 }
 
+type GitHubInstallation struct {
+	ID                  int64             `json:"id"`
+	AppID               int64             `json:"appId"`
+	AppSlug             string            `json:"appSlug"`
+	Account             GitHubUser        `json:"account"`
+	TargetType          string            `json:"targetType"`
+	RepositorySelection string            `json:"repositorySelection"`
+	Permissions         map[string]string `json:"permissions,omitempty"`
+}
+
 type GitHubTeam struct {
 	ID           int64  `json:"id"`
 	Name         string `json:"name"`
@@ -0,0 +1,134 @@
+package domain
+
+import "time"
+
+// GitLabConfig points to GitLab.com or a self-managed instance. Group limits listings to a
+// group (and its subgroups); without it the projects the token is a member of are listed.
+type GitLabConfig struct {
+	URL   string `json:"url,omitempty"`
+	Token string `json:"token"`
+	Group string `json:"group,omitempty"`
+}
+
+type GitLabUser struct {
+	ID        int64  `json:"id"`
+	Username  string `json:"username"`
+	Name      string `json:"name"`
+	State     string `json:"state,omitempty"`
+	AvatarURL string `json:"avatarUrl"`
+	WebURL    string `json:"webUrl"`
+}
+
+type GitLabNamespace struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Kind     string `json:"kind"`
+	FullPath string `json:"fullPath"`
+}
+
+type GitLabProject struct {
+	ID                int64           `json:"id"`
+	Name              string          `json:"name"`
+	Path              string          `json:"path"`
+	PathWithNamespace string          `json:"pathWithNamespace"`
+	Description       string          `json:"description,omitempty"`
+	Visibility        string          `json:"visibility"`
+	WebURL            string          `json:"webUrl"`
+	HTTPURLToRepo     string          `json:"httpUrlToRepo"`
+	SSHURLToRepo      string          `json:"sshUrlToRepo"`
+	DefaultBranch     string          `json:"defaultBranch"`
+	StarCount         int             `json:"starCount"`
+	ForksCount        int             `json:"forksCount"`
+	OpenIssuesCount   int             `json:"openIssuesCount"`
+	Archived          bool            `json:"archived"`
+	Topics            []string        `json:"topics,omitempty"`
+	Namespace         GitLabNamespace `json:"namespace"`
+	CreatedAt         time.Time       `json:"createdAt"`
+	LastActivityAt    time.Time       `json:"lastActivityAt"`
+}
+
+type GitLabCommit struct {
+	ID             string    `json:"id"`
+	ShortID        string    `json:"shortId"`
+	Title          string    `json:"title"`
+	Message        string    `json:"message"`
+	AuthorName     string    `json:"authorName"`
+	AuthorEmail    string    `json:"authorEmail"`
+	AuthoredDate   time.Time `json:"authoredDate"`
+	CommitterName  string    `json:"committerName"`
+	CommitterEmail string    `json:"committerEmail"`
+	CommittedDate  time.Time `json:"committedDate"`
+	WebURL         string    `json:"webUrl"`
+}
+
+type GitLabMergeRequest struct {
+	ID           int64      `json:"id"`
+	IID          int64      `json:"iid"`
+	ProjectID    int64      `json:"projectId"`
+	Title        string     `json:"title"`
+	Description  string     `json:"description,omitempty"`
+	State        string     `json:"state"`
+	Draft        bool       `json:"draft"`
+	SourceBranch string     `json:"sourceBranch"`
+	TargetBranch string     `json:"targetBranch"`
+	Author       GitLabUser `json:"author"`
+	Labels       []string   `json:"labels,omitempty"`
+	WebURL       string     `json:"webUrl"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	UpdatedAt    time.Time  `json:"updatedAt"`
+	MergedAt     *time.Time `json:"mergedAt,omitempty"`
+	ClosedAt     *time.Time `json:"closedAt,omitempty"`
+}
+
+type GitLabIssue struct {
+	ID          int64        `json:"id"`
+	IID         int64        `json:"iid"`
+	ProjectID   int64        `json:"projectId"`
+	Title       string       `json:"title"`
+	Description string       `json:"description,omitempty"`
+	State       string       `json:"state"`
+	Author      GitLabUser   `json:"author"`
+	Assignees   []GitLabUser `json:"assignees,omitempty"`
+	Labels      []string     `json:"labels,omitempty"`
+	WebURL      string       `json:"webUrl"`
+	DueDate     string       `json:"dueDate,omitempty"`
+	CreatedAt   time.Time    `json:"createdAt"`
+	UpdatedAt   time.Time    `json:"updatedAt"`
+	ClosedAt    *time.Time   `json:"closedAt,omitempty"`
+}
+
+type GitLabBranch struct {
+	Name      string       `json:"name"`
+	Commit    GitLabCommit `json:"commit"`
+	Protected bool         `json:"protected"`
+	Default   bool         `json:"default"`
+	Merged    bool         `json:"merged"`
+	WebURL    string       `json:"webUrl"`
+}
+
+type GitLabPipeline struct {
+	ID         int64      `json:"id"`
+	IID        int64      `json:"iid"`
+	ProjectID  int64      `json:"projectId"`
+	Status     string     `json:"status"`
+	Source     string     `json:"source"`
+	Ref        string     `json:"ref"`
+	SHA        string     `json:"sha"`
+	WebURL     string     `json:"webUrl"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+	StartedAt  *time.Time `json:"startedAt,omitempty"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+}
+
+type GitLabGroup struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	FullPath    string `json:"fullPath"`
+	Description string `json:"description,omitempty"`
+	Visibility  string `json:"visibility"`
+	AvatarURL   string `json:"avatarUrl,omitempty"`
+	WebURL      string `json:"webUrl"`
+}
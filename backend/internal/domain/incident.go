@@ -0,0 +1,141 @@
+package domain
+
+import "time"
+
+// Status of an incident
+const (
+	IncidentStatusOpen         = "open"
+	IncidentStatusAcknowledged = "acknowledged"
+	IncidentStatusResolved     = "resolved"
+)
+
+// Severity of an incident
+const (
+	IncidentSeverityCritical = "critical"
+	IncidentSeverityHigh     = "high"
+	IncidentSeverityMedium   = "medium"
+	IncidentSeverityLow      = "low"
+)
+
+// Source an incident was opened from
+const (
+	IncidentSourceManual     = "manual"
+	IncidentSourceSlack      = "slack"
+	IncidentSourcePrometheus = "prometheus"
+	IncidentSourceGrafana    = "grafana"
+)
+
+// Type of an incident timeline entry
+const (
+	IncidentEntryOpened       = "opened"
+	IncidentEntryAcknowledged = "acknowledged"
+	IncidentEntryResolved     = "resolved"
+	IncidentEntryReopened     = "reopened"
+	IncidentEntryUpdated      = "updated"
+	IncidentEntryNote         = "note"
+	IncidentEntryAlert        = "alert"
+)
+
+// Incident is a production failure of a service of the catalog, tracked from the moment it was
+// opened until it was resolved
+type Incident struct {
+	ID               string                  `json:"id"`
+	OrganizationUUID string                  `json:"organizationUuid"`
+	Title            string                  `json:"title"`
+	Description      string                  `json:"description,omitempty"`
+	ServiceName      string                  `json:"serviceName,omitempty"`
+	Squad            string                  `json:"squad,omitempty"`
+	Severity         string                  `json:"severity"`
+	Status           string                  `json:"status"`
+	Source           string                  `json:"source"`
+	ExternalID       string                  `json:"externalId,omitempty"` // Alert fingerprint, for deduplication
+	OpenedBy         string                  `json:"openedBy"`
+	OpenedAt         time.Time               `json:"openedAt"`
+	AcknowledgedBy   string                  `json:"acknowledgedBy,omitempty"`
+	AcknowledgedAt   *time.Time              `json:"acknowledgedAt,omitempty"`
+	ResolvedBy       string                  `json:"resolvedBy,omitempty"`
+	ResolvedAt       *time.Time              `json:"resolvedAt,omitempty"`
+	Timeline         []IncidentTimelineEntry `json:"timeline,omitempty"`
+	CreatedAt        time.Time               `json:"createdAt"`
+	UpdatedAt        time.Time               `json:"updatedAt"`
+}
+
+// IncidentTimelineEntry is a status change, a note or an alert of an incident
+type IncidentTimelineEntry struct {
+	ID         int       `json:"id"`
+	IncidentID string    `json:"incidentId"`
+	Type       string    `json:"type"`
+	Message    string    `json:"message"`
+	Author     string    `json:"author"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// IncidentFilter filters the list of incidents of an organization. From and To (RFC 3339) bound the
+// opening time.
+type IncidentFilter struct {
+	Status      string     `form:"status"`
+	Severity    string     `form:"severity"`
+	ServiceName string     `form:"service"`
+	Squad       string     `form:"squad"`
+	From        *time.Time `form:"from"`
+	To          *time.Time `form:"to"`
+	Page        int        `form:"page"`
+	Size        int        `form:"size"`
+}
+
+type CreateIncidentRequest struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	ServiceName string `json:"serviceName"`
+	Severity    string `json:"severity"`
+	// Optional, to record an incident after the fact
+	OpenedAt *time.Time `json:"openedAt,omitempty"`
+}
+
+// UpdateIncidentRequest changes the fields that are set
+type UpdateIncidentRequest struct {
+	Title       *string `json:"title,omitempty"`
+	Description *string `json:"description,omitempty"`
+	ServiceName *string `json:"serviceName,omitempty"`
+	Severity    *string `json:"severity,omitempty"`
+}
+
+// IncidentTransitionRequest acknowledges, resolves or reopens an incident. At defaults to now.
+type IncidentTransitionRequest struct {
+	Message string     `json:"message,omitempty"`
+	At      *time.Time `json:"at,omitempty"`
+}
+
+type IncidentNoteRequest struct {
+	Message string `json:"message"`
+}
+
+// IncidentSignal is an alert that opens (or, once resolved, resolves) the incident with its
+// external ID. Signals of an external ID already open only add to the incident timeline.
+type IncidentSignal struct {
+	Source      string
+	ExternalID  string
+	Title       string
+	Description string
+	ServiceName string
+	Severity    string
+	StartedAt   time.Time
+	ResolvedAt  *time.Time
+}
+
+// IncidentMetrics summarizes the incidents of a scope over a window. MTTR and MTTA are in minutes,
+// over the incidents resolved (acknowledged) in the window.
+type IncidentMetrics struct {
+	Scope            string         `json:"scope"` // organization, service or squad
+	ScopeKey         string         `json:"scopeKey"`
+	Days             int            `json:"days"`
+	WindowStart      time.Time      `json:"windowStart"`
+	WindowEnd        time.Time      `json:"windowEnd"`
+	Opened           int            `json:"opened"`
+	Resolved         int            `json:"resolved"`
+	Open             int            `json:"open"`
+	MTTRMinutes      float64        `json:"mttrMinutes"`
+	MTTAMinutes      float64        `json:"mttaMinutes"`
+	Acknowledged     int            `json:"acknowledged"`
+	OpenedBySeverity map[string]int `json:"openedBySeverity"`
+}
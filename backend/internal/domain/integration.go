@@ -56,9 +56,22 @@ type GrafanaIntegrationConfig struct {
 	APIKey string `json:"apiKey"`
 }
 
+// GitHubIntegrationConfig holds a personal access token, or the App ID, private key (PEM)
+// and installation ID of a GitHub App
 type GitHubIntegrationConfig struct {
-	Token        string `json:"token"`
-	Organization string `json:"organization,omitempty"`
+	Token          string `json:"token,omitempty"`
+	Organization   string `json:"organization,omitempty"`
+	AppID          int64  `json:"appId,omitempty"`
+	PrivateKey     string `json:"privateKey,omitempty"`
+	InstallationID int64  `json:"installationId,omitempty"`
+}
+
+// GitLabIntegrationConfig holds the URL of a self-managed instance (empty for GitLab.com),
+// an access token and an optional group
+type GitLabIntegrationConfig struct {
+	URL   string `json:"url,omitempty"`
+	Token string `json:"token"`
+	Group string `json:"group,omitempty"`
 }
 
 type OpenAIIntegrationConfig struct {
@@ -81,8 +94,9 @@ type JiraIntegrationConfig struct {
 }
 
 type SlackIntegrationConfig struct {
-	WebhookURL string `json:"webhookUrl"`
-	BotToken   string `json:"botToken,omitempty"`
+	WebhookURL    string `json:"webhookUrl"`
+	BotToken      string `json:"botToken,omitempty"`
+	SigningSecret string `json:"signingSecret,omitempty"` // Verifies the /incident slash command requests
 }
 
 type TeamsIntegrationConfig struct {
@@ -151,3 +165,57 @@ const (
 	IntegrationTypeAWSSecrets  IntegrationType = "awssecrets"
 	IntegrationTypeOpenVPN     IntegrationType = "openvpn"
 )
+
+// MaskedSecretValue replaces secret config fields in API responses
+const MaskedSecretValue = "********"
+
+// IntegrationSecretFields lists the config fields of each integration type
+// that are encrypted at rest and masked in API responses
+var IntegrationSecretFields = map[IntegrationType][]string{
+	IntegrationTypeAzureDevOps: {"pat"},
+	IntegrationTypeGitHub:      {"token", "privateKey"},
+	IntegrationTypeGitLab:      {"token"},
+	IntegrationTypeSonarQube:   {"token"},
+	IntegrationTypeAzureCloud:  {"clientSecret"},
+	IntegrationTypeGCP:         {"serviceAccountJson"},
+	IntegrationTypeAWS:         {"secretAccessKey"},
+	IntegrationTypeKubernetes:  {"kubeconfig"},
+	IntegrationTypeGrafana:     {"apiKey"},
+	IntegrationTypeOpenAI:      {"apiKey"},
+	IntegrationTypeGemini:      {"apiKey"},
+	IntegrationTypeClaude:      {"apiKey"},
+	IntegrationTypeJira:        {"apiToken"},
+	IntegrationTypeSlack:       {"webhookUrl", "botToken", "signingSecret"},
+	IntegrationTypeTeams:       {"webhookUrl"},
+	IntegrationTypeArgoCD:      {"authToken"},
+	IntegrationTypePrometheus:  {"password"},
+	IntegrationTypeLoki:        {"password"},
+	IntegrationTypeVault:       {"token"},
+	IntegrationTypeAWSSecrets:  {"secretAccessKey", "sessionToken"},
+	IntegrationTypeOpenVPN:     {"password"},
+}
+
+// OrganizationDataKey is a per-organization data key wrapped by a master key
+type OrganizationDataKey struct {
+	ID               int       `json:"id"`
+	OrganizationUUID string    `json:"organizationUuid"`
+	Version          int       `json:"version"`
+	WrappedKey       []byte    `json:"-"`
+	MasterKeyID      string    `json:"masterKeyId"`
+	Active           bool      `json:"active"`
+	CreatedAt        time.Time `json:"createdAt"`
+}
+
+// IntegrationClientStats reports the counters of the integration client cache
+type IntegrationClientStats struct {
+	Entries             int     `json:"entries"`
+	OrganizationEntries int     `json:"organizationEntries"`
+	Hits                uint64  `json:"hits"`
+	Misses              uint64  `json:"misses"`
+	HitRatio            float64 `json:"hitRatio"`
+	Builds              uint64  `json:"builds"`
+	BuildErrors         uint64  `json:"buildErrors"`
+	Evictions           uint64  `json:"evictions"`
+	Invalidations       uint64  `json:"invalidations"`
+	IdleTTLSeconds      int64   `json:"idleTtlSeconds"`
+}
@@ -35,6 +35,18 @@ type KubernetesDeployment struct {
 	CreationTimestamp time.Time         `json:"creationTimestamp"`
 }
 
+// KubernetesDeploymentState is the part of a deployment that autonomous actions change and restore
+type KubernetesDeploymentState struct {
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace"`
+	Replicas    int32             `json:"replicas"`
+	Revision    int64             `json:"revision"`
+	RestartedAt string            `json:"restartedAt,omitempty"`
+	Images      []string          `json:"images,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
 type KubernetesService struct {
 	Name              string            `json:"name"`
 	Namespace         string            `json:"namespace"`
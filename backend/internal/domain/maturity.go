@@ -22,6 +22,7 @@ const (
 	MaturityCategoryFinOps           MaturityCategory = "finops"
 	MaturityCategorySecurity         MaturityCategory = "security"
 	MaturityCategoryDocumentation    MaturityCategory = "documentation"
+	MaturityCategoryDelivery         MaturityCategory = "delivery"
 )
 
 type ServiceMetric struct {
@@ -53,13 +54,19 @@ type TeamMaturityScorecard struct {
 	Rank         int                    `json:"rank,omitempty"`
 	LastUpdated  time.Time              `json:"lastUpdated"`
 	Trend        string                 `json:"trend"` // "improving", "stable", "declining"
+	Services     []string               `json:"services"`
+	History      []ScorecardTrendPoint  `json:"history"`
 }
 
 type ServiceMaturityScorecard struct {
 	ServiceName  string                 `json:"serviceName"`
+	Squad        string                 `json:"squad"`
 	Scores       []MaturityScore        `json:"scores"`
 	OverallScore float64                `json:"overallScore"`
+	Checks       []ScorecardCheckResult `json:"checks"`
 	LastUpdated  time.Time              `json:"lastUpdated"`
+	Trend        string                 `json:"trend"`
+	History      []ScorecardTrendPoint  `json:"history"`
 }
 
 type MaturityRecommendation struct {
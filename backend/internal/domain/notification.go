@@ -0,0 +1,178 @@
+package domain
+
+import "time"
+
+// Types of the events routed by the notification engine
+const (
+	NotificationEventDeployFailed          = "deploy.failed"
+	NotificationEventQualityGateFailed     = "quality_gate.failed"
+	NotificationEventCostAnomaly           = "cost.anomaly"
+	NotificationEventActionPendingApproval = "action.pending_approval"
+	NotificationEventIntegrationDown       = "integration.down"
+	NotificationEventAlertFiring           = "alert.firing"
+	NotificationEventAlertResolved         = "alert.resolved"
+)
+
+// NotificationEventTypes lists the event types notification rules can subscribe to
+var NotificationEventTypes = []string{
+	NotificationEventDeployFailed,
+	NotificationEventQualityGateFailed,
+	NotificationEventCostAnomaly,
+	NotificationEventActionPendingApproval,
+	NotificationEventIntegrationDown,
+	NotificationEventAlertFiring,
+	NotificationEventAlertResolved,
+}
+
+// Types of notification channel. Rules may also target slack and teams directly, which sends to
+// the Slack and Teams integrations of the organization.
+const (
+	NotificationChannelSlack   = "slack"
+	NotificationChannelTeams   = "teams"
+	NotificationChannelEmail   = "email"
+	NotificationChannelWebhook = "webhook"
+)
+
+// Status of a notification delivery
+const (
+	NotificationDeliveryPending = "pending"
+	NotificationDeliverySent    = "sent"
+	NotificationDeliveryFailed  = "failed"
+)
+
+// NotificationEvent is something that happened in an organization and may be notified
+type NotificationEvent struct {
+	Type        string            `json:"type"`
+	Severity    string            `json:"severity,omitempty"`
+	Title       string            `json:"title"`
+	Message     string            `json:"message,omitempty"`
+	ServiceName string            `json:"serviceName,omitempty"`
+	Squad       string            `json:"squad,omitempty"`
+	URL         string            `json:"url,omitempty"`
+	Fields      map[string]string `json:"fields,omitempty"` // Details shown in the message
+	Labels      map[string]string `json:"labels,omitempty"` // Matched by the rule matchers
+	// DedupKey identifies the repeats of an event; it defaults to its type, service and title
+	DedupKey string `json:"dedupKey,omitempty"`
+	// DedupWindowMinutes is the shortest window repeats are dropped in; rules may set a longer one
+	DedupWindowMinutes int `json:"dedupWindowMinutes,omitempty"`
+	// Channels receive the event besides the channels of the matching rules: names of channels
+	// of the organization, or slack and teams for its integrations
+	Channels   []string  `json:"channels,omitempty"`
+	OccurredAt time.Time `json:"occurredAt"`
+}
+
+// NotificationChannel is a destination of the notifications of an organization. Templates are
+// Go text/template strings rendered with the event; empty templates use the default of the type.
+type NotificationChannel struct {
+	ID               int                       `json:"id"`
+	OrganizationUUID string                    `json:"organizationUuid"`
+	Name             string                    `json:"name"`
+	Type             string                    `json:"type"` // slack, teams, email, webhook
+	Enabled          bool                      `json:"enabled"`
+	Config           NotificationChannelConfig `json:"config"`
+	TitleTemplate    string                    `json:"titleTemplate,omitempty"`
+	BodyTemplate     string                    `json:"bodyTemplate,omitempty"`
+	CreatedBy        string                    `json:"createdBy"`
+	CreatedAt        time.Time                 `json:"createdAt"`
+	UpdatedAt        time.Time                 `json:"updatedAt"`
+}
+
+type NotificationChannelConfig struct {
+	// Incoming webhook of slack and teams channels (the integration's when empty), URL of webhook channels
+	WebhookURL string   `json:"webhookUrl,omitempty"`
+	Recipients []string `json:"recipients,omitempty"` // email
+	// Secret signs the body of webhook deliveries (X-PlatifyX-Signature: sha256=<hmac>)
+	Secret string `json:"secret,omitempty"`
+}
+
+type NotificationChannelRequest struct {
+	Name          string                    `json:"name"`
+	Type          string                    `json:"type"`
+	Enabled       *bool                     `json:"enabled,omitempty"`
+	Config        NotificationChannelConfig `json:"config"`
+	TitleTemplate string                    `json:"titleTemplate"`
+	BodyTemplate  string                    `json:"bodyTemplate"`
+}
+
+// NotificationRule routes the events that match all its non-empty criteria to its channels.
+// Matchers are exact matches on the event labels (the labels of an alert); Services and Squads
+// match the catalog service of the event.
+type NotificationRule struct {
+	ID                 int                     `json:"id"`
+	OrganizationUUID   string                  `json:"organizationUuid"`
+	Name               string                  `json:"name"`
+	Enabled            bool                    `json:"enabled"`
+	EventTypes         []string                `json:"eventTypes"` // Every event type when empty
+	Matchers           map[string]string       `json:"matchers"`
+	Services           []string                `json:"services"`
+	Squads             []string                `json:"squads"`
+	Severities         []string                `json:"severities"`
+	Channels           []string                `json:"channels"`   // slack, teams: the integrations of the organization
+	ChannelIDs         []int                   `json:"channelIds"` // Notification channels of the organization
+	QuietHours         *NotificationQuietHours `json:"quietHours,omitempty"`
+	DedupWindowMinutes int                     `json:"dedupWindowMinutes"`
+	OpenIncident       bool                    `json:"openIncident"` // Open an incident while a matching alert fires
+	CreatedBy          string                  `json:"createdBy"`
+	CreatedAt          time.Time               `json:"createdAt"`
+	UpdatedAt          time.Time               `json:"updatedAt"`
+}
+
+// NotificationQuietHours holds back the notifications of a rule during a daily period; they are
+// delivered when it ends. Events of the bypass severities are delivered right away.
+type NotificationQuietHours struct {
+	Start            string   `json:"start"`              // HH:MM
+	End              string   `json:"end"`                // HH:MM, before Start when the period crosses midnight
+	Timezone         string   `json:"timezone,omitempty"` // IANA name, UTC when empty
+	BypassSeverities []string `json:"bypassSeverities,omitempty"`
+}
+
+type NotificationRuleRequest struct {
+	Name               string                  `json:"name"`
+	Enabled            *bool                   `json:"enabled,omitempty"`
+	EventTypes         []string                `json:"eventTypes"`
+	Matchers           map[string]string       `json:"matchers"`
+	Services           []string                `json:"services"`
+	Squads             []string                `json:"squads"`
+	Severities         []string                `json:"severities"`
+	Channels           []string                `json:"channels"`
+	ChannelIDs         []int                   `json:"channelIds"`
+	QuietHours         *NotificationQuietHours `json:"quietHours,omitempty"`
+	DedupWindowMinutes int                     `json:"dedupWindowMinutes"`
+	OpenIncident       bool                    `json:"openIncident"`
+}
+
+// NotificationDelivery is an event queued for a channel. Failed sends are retried with backoff
+// until the delivery is sent or runs out of attempts.
+type NotificationDelivery struct {
+	ID               int               `json:"id"`
+	OrganizationUUID string            `json:"organizationUuid"`
+	RuleID           *int              `json:"ruleId,omitempty"`
+	ChannelID        *int              `json:"channelId,omitempty"` // Empty for the Slack and Teams integrations
+	ChannelType      string            `json:"channelType"`
+	EventType        string            `json:"eventType"`
+	DedupKey         string            `json:"dedupKey"`
+	Event            NotificationEvent `json:"event"`
+	Status           string            `json:"status"`
+	Attempts         int               `json:"attempts"`
+	NextAttemptAt    time.Time         `json:"nextAttemptAt"`
+	LastError        string            `json:"lastError,omitempty"`
+	SentAt           *time.Time        `json:"sentAt,omitempty"`
+	CreatedAt        time.Time         `json:"createdAt"`
+	UpdatedAt        time.Time         `json:"updatedAt"`
+}
+
+// NotificationDeliveryFilter filters the deliveries of an organization
+type NotificationDeliveryFilter struct {
+	Status    string `form:"status"`
+	EventType string `form:"eventType"`
+	ChannelID int    `form:"channelId"`
+	Page      int    `form:"page"`
+	Size      int    `form:"size"`
+}
+
+// NotificationPublishResult summarizes the deliveries an event was queued as
+type NotificationPublishResult struct {
+	Queued       int `json:"queued"`
+	Deduplicated int `json:"deduplicated"` // Repeats within the dedup window, not queued
+	Deferred     int `json:"deferred"`     // Queued for the end of quiet hours
+}
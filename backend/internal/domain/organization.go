@@ -26,3 +26,22 @@ type UpdateOrganizationRequest struct {
 	DatabaseAddressRead  string `json:"databaseAddressRead"`
 }
 
+// NodeDatabaseHealth é o resultado da verificação dos pools do banco de dados de uma organização
+type NodeDatabaseHealth struct {
+	OrganizationUUID string                 `json:"organizationUuid"`
+	Healthy          bool                   `json:"healthy"`
+	Write            NodeDatabasePoolHealth `json:"write"`
+	Read             NodeDatabasePoolHealth `json:"read"`
+	SharedReadWrite  bool                   `json:"sharedReadWrite"`
+}
+
+// NodeDatabasePoolHealth descreve um pool de conexões (escrita ou réplica de leitura)
+type NodeDatabasePoolHealth struct {
+	Healthy         bool   `json:"healthy"`
+	Error           string `json:"error,omitempty"`
+	LatencyMs       int64  `json:"latencyMs"`
+	OpenConnections int    `json:"openConnections"`
+	InUse           int    `json:"inUse"`
+	Idle            int    `json:"idle"`
+	WaitCount       int64  `json:"waitCount"`
+}
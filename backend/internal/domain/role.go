@@ -2,20 +2,31 @@ package domain
 
 import "time"
 
+// SystemAdminRoleName é o nome do role admin semeado pelo sistema
+const SystemAdminRoleName = "admin"
+
 // Role representa um papel/perfil no sistema
 type Role struct {
-	ID          string    `json:"id" db:"id"`
-	Name        string    `json:"name" db:"name"`
-	DisplayName string    `json:"display_name" db:"display_name"`
-	Description *string   `json:"description,omitempty" db:"description"`
-	IsSystem    bool      `json:"is_system" db:"is_system"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+	ID          string  `json:"id" db:"id"`
+	Name        string  `json:"name" db:"name"`
+	DisplayName string  `json:"display_name" db:"display_name"`
+	Description *string `json:"description,omitempty" db:"description"`
+	IsSystem    bool    `json:"is_system" db:"is_system"`
+	// OrganizationUUID é nil para roles globais
+	OrganizationUUID *string   `json:"organization_uuid,omitempty" db:"organization_uuid"`
+	CreatedAt        time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at" db:"updated_at"`
 
 	// Relacionamentos
 	Permissions []Permission `json:"permissions,omitempty" db:"-"`
 }
 
+// IsSystemAdmin indica se é o role admin semeado pelo sistema (global e de sistema),
+// e não um role de organização que apenas tenha o mesmo nome
+func (r *Role) IsSystemAdmin() bool {
+	return r.IsSystem && r.OrganizationUUID == nil && r.Name == SystemAdminRoleName
+}
+
 // Permission representa uma permissão no sistema
 type Permission struct {
 	ID          string    `json:"id" db:"id"`
@@ -52,12 +63,41 @@ type PermissionListResponse struct {
 	Total       int          `json:"total"`
 }
 
+// RoleBinding representa a atribuição de um role a um usuário dentro de uma organização
+type RoleBinding struct {
+	ID               string    `json:"id" db:"id"`
+	OrganizationUUID string    `json:"organization_uuid" db:"organization_uuid"`
+	UserID           string    `json:"user_id" db:"user_id"`
+	UserEmail        string    `json:"user_email,omitempty" db:"-"`
+	UserName         string    `json:"user_name,omitempty" db:"-"`
+	RoleID           string    `json:"role_id" db:"role_id"`
+	RoleName         string    `json:"role_name,omitempty" db:"-"`
+	CreatedBy        *string   `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt        time.Time `json:"created_at" db:"created_at"`
+}
+
+// CreateRoleBindingRequest representa o request para vincular um role a um usuário na organização
+type CreateRoleBindingRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+}
+
+// RoleBindingListResponse representa a resposta de listagem de vínculos de roles
+type RoleBindingListResponse struct {
+	Bindings []RoleBinding `json:"bindings"`
+	Total    int           `json:"total"`
+}
+
 // UserPermissions representa as permissões efetivas de um usuário
+// (em uma organização, quando OrganizationUUID está preenchido)
 type UserPermissions struct {
-	UserID      string              `json:"user_id"`
-	Roles       []Role              `json:"roles"`
-	Permissions []Permission        `json:"permissions"`
-	PermissionMap map[string][]string `json:"permission_map"` // resource -> actions
+	UserID           string              `json:"user_id"`
+	OrganizationUUID string              `json:"organization_uuid,omitempty"`
+	Roles            []Role              `json:"roles"`
+	Permissions      []Permission        `json:"permissions"`
+	PermissionMap    map[string][]string `json:"permission_map"` // resource -> actions
+	// IsPlatformAdmin indica que o usuário tem o role admin do sistema globalmente,
+	// o que vale em qualquer organização
+	IsPlatformAdmin bool `json:"is_platform_admin"`
 }
 
 // HasPermission verifica se o usuário tem uma permissão específica
@@ -72,10 +112,13 @@ func (up *UserPermissions) HasPermission(resource, action string) bool {
 	return false
 }
 
-// IsAdmin verifica se o usuário tem o role de admin
+// IsAdmin verifica se o usuário é admin da plataforma ou tem o role admin do sistema no escopo
 func (up *UserPermissions) IsAdmin() bool {
+	if up.IsPlatformAdmin {
+		return true
+	}
 	for _, role := range up.Roles {
-		if role.Name == "admin" {
+		if role.IsSystemAdmin() {
 			return true
 		}
 	}
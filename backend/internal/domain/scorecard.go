@@ -0,0 +1,106 @@
+package domain
+
+import "time"
+
+// ScorecardCheckType is what a scorecard check verifies, and against which integration
+type ScorecardCheckType string
+
+const (
+	// Grafana has a dashboard with the tag (default: the service name)
+	ScorecardCheckGrafanaDashboard ScorecardCheckType = "grafana_dashboard"
+	// A SonarQube measure of the service project compared with a threshold
+	ScorecardCheckSonarQubeMetric ScorecardCheckType = "sonarqube_metric"
+	// The SonarQube quality gate of the service project passes
+	ScorecardCheckSonarQubeQualityGate ScorecardCheckType = "sonarqube_quality_gate"
+	// Prometheus has alerting rules whose label (default: service) is the service name
+	ScorecardCheckPrometheusAlertRules ScorecardCheckType = "prometheus_alert_rules"
+	// The TechDocs of the organization have the document at path
+	ScorecardCheckTechDocsDocument ScorecardCheckType = "techdocs_document"
+	// The ArgoCD application of the service has the sync (and optionally health) status
+	ScorecardCheckArgoCDStatus ScorecardCheckType = "argocd_status"
+	// A field of the service catalog is set
+	ScorecardCheckServiceField ScorecardCheckType = "service_field"
+	// An incident metric of the service over the last days compared with a threshold
+	ScorecardCheckIncidentMetric ScorecardCheckType = "incident_metric"
+)
+
+// Placeholder replaced by the service name in the string parameters of a check
+const ScorecardServicePlaceholder = "{service}"
+
+// ScorecardCheck is one rule of a scorecard. Passed checks add their weight to the score of their
+// category. The parameters used depend on the type.
+type ScorecardCheck struct {
+	ID          string             `json:"id" yaml:"id"`
+	Name        string             `json:"name" yaml:"name"`
+	Description string             `json:"description,omitempty" yaml:"description,omitempty"`
+	Category    MaturityCategory   `json:"category" yaml:"category"`
+	Type        ScorecardCheckType `json:"type" yaml:"type"`
+	Weight      float64            `json:"weight" yaml:"weight"`
+
+	// grafana_dashboard
+	Tag string `json:"tag,omitempty" yaml:"tag,omitempty"`
+	// sonarqube_metric: coverage, bugs, vulnerabilities, code_smells, duplications or security_hotspots
+	// incident_metric: mttr_minutes, mtta_minutes, opened or open
+	Metric    string  `json:"metric,omitempty" yaml:"metric,omitempty"`
+	Operator  string  `json:"operator,omitempty" yaml:"operator,omitempty"` // >=, >, <=, <, ==, !=
+	Threshold float64 `json:"threshold,omitempty" yaml:"threshold,omitempty"`
+	// incident_metric: window in days (default 30)
+	Days int `json:"days,omitempty" yaml:"days,omitempty"`
+	// prometheus_alert_rules
+	Label string `json:"label,omitempty" yaml:"label,omitempty"`
+	// techdocs_document
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	// argocd_status
+	Application string `json:"application,omitempty" yaml:"application,omitempty"`
+	SyncStatus  string `json:"syncStatus,omitempty" yaml:"syncStatus,omitempty"`
+	Health      string `json:"health,omitempty" yaml:"health,omitempty"`
+	// service_field: sonarqubeProject, namespace, repositoryUrl, testUnit, hasStage or hasProd
+	Field string `json:"field,omitempty" yaml:"field,omitempty"`
+}
+
+// ScorecardDefinition is the set of checks an organization evaluates its services with
+type ScorecardDefinition struct {
+	Checks    []ScorecardCheck `json:"checks" yaml:"checks"`
+	IsDefault bool             `json:"isDefault,omitempty" yaml:"-"`
+	UpdatedBy string           `json:"updatedBy,omitempty" yaml:"-"`
+	UpdatedAt *time.Time       `json:"updatedAt,omitempty" yaml:"-"`
+}
+
+type ScorecardCheckStatus string
+
+const (
+	ScorecardCheckPassed ScorecardCheckStatus = "passed"
+	ScorecardCheckFailed ScorecardCheckStatus = "failed"
+	// The integration could not be queried: the check does not count in the score
+	ScorecardCheckError ScorecardCheckStatus = "error"
+)
+
+// ScorecardCheckResult is the outcome of a check for a service
+type ScorecardCheckResult struct {
+	CheckID  string               `json:"checkId"`
+	Name     string               `json:"name"`
+	Category MaturityCategory     `json:"category"`
+	Weight   float64              `json:"weight"`
+	Status   ScorecardCheckStatus `json:"status"`
+	Message  string               `json:"message,omitempty"`
+}
+
+// ScorecardResult is the evaluation of a service on a day. Category scores range from 0 to 10;
+// categories without evaluated checks are absent.
+type ScorecardResult struct {
+	ID               int                          `json:"id"`
+	OrganizationUUID string                       `json:"organizationUuid"`
+	ServiceName      string                       `json:"serviceName"`
+	Squad            string                       `json:"squad"`
+	Date             time.Time                    `json:"date"`
+	CategoryScores   map[MaturityCategory]float64 `json:"categoryScores"`
+	OverallScore     float64                      `json:"overallScore"`
+	Checks           []ScorecardCheckResult       `json:"checks"`
+	EvaluatedAt      time.Time                    `json:"evaluatedAt"`
+}
+
+// ScorecardTrendPoint is the overall score of a service or team on a day
+type ScorecardTrendPoint struct {
+	Date  string  `json:"date"`
+	Score float64 `json:"score"`
+}
@@ -1,8 +1,9 @@
 package domain
 
 type SlackConfig struct {
-	WebhookURL string
-	BotToken   string
+	WebhookURL    string
+	BotToken      string
+	SigningSecret string
 }
 
 type SlackMessage struct {
@@ -52,3 +53,19 @@ type SlackUser struct {
 	RealName string `json:"real_name"`
 	Email    string `json:"email,omitempty"`
 }
+
+// SlackCommand is a slash command sent by Slack (application/x-www-form-urlencoded)
+type SlackCommand struct {
+	Command   string `form:"command"`
+	Text      string `form:"text"`
+	UserID    string `form:"user_id"`
+	UserName  string `form:"user_name"`
+	ChannelID string `form:"channel_id"`
+}
+
+// SlackCommandResponse is the reply to a slash command. ResponseType is "ephemeral" (only the
+// caller sees it) or "in_channel".
+type SlackCommandResponse struct {
+	ResponseType string `json:"response_type"`
+	Text         string `json:"text"`
+}
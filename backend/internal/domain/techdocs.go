@@ -24,3 +24,96 @@ type TechDocTreeNode struct {
 	IsDirectory bool              `json:"isDirectory"`
 	Children    []TechDocTreeNode `json:"children,omitempty"`
 }
+
+// TechDocAuthor identifies who changed a document, recorded as the author of its revision
+type TechDocAuthor struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// TechDocRevision is a version of a document in the history of the store
+type TechDocRevision struct {
+	Revision    string    `json:"revision"`
+	AuthorName  string    `json:"authorName"`
+	AuthorEmail string    `json:"authorEmail"`
+	Message     string    `json:"message"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// TechDocsStoreStatus describes where documents are stored and, for a git store with a remote,
+// how the last push went
+type TechDocsStoreStatus struct {
+	Kind          string     `json:"kind"`
+	Versioned     bool       `json:"versioned"`
+	Remote        bool       `json:"remote"`
+	LastPushAt    *time.Time `json:"lastPushAt,omitempty"`
+	LastAttemptAt *time.Time `json:"lastAttemptAt,omitempty"`
+	LastPushError string     `json:"lastPushError,omitempty"`
+	// Diverged is set when local revisions conflict with the remote branch and are not pushed
+	Diverged bool `json:"diverged"`
+}
+
+// TechDocRevisionContent is a document as it was at a revision
+type TechDocRevisionContent struct {
+	Path     string `json:"path"`
+	Revision string `json:"revision"`
+	Content  string `json:"content"`
+}
+
+// TechDocDiff is the unified diff of a document between two revisions
+type TechDocDiff struct {
+	Path string `json:"path"`
+	From string `json:"from"`
+	To   string `json:"to,omitempty"`
+	Diff string `json:"diff"`
+}
+
+// TechDocRestoreRequest restores a document to a previous revision
+type TechDocRestoreRequest struct {
+	Path     string `json:"path" binding:"required"`
+	Revision string `json:"revision" binding:"required"`
+}
+
+// TechDocChunk is a section of a document in the retrieval index, with its embedding
+type TechDocChunk struct {
+	Path           string    `json:"path"`
+	Index          int       `json:"index"`
+	Heading        string    `json:"heading,omitempty"`
+	Content        string    `json:"content"`
+	EmbeddingModel string    `json:"embeddingModel"`
+	Embedding      []float32 `json:"-"`
+}
+
+// TechDocIndexedDocument is a document of the retrieval index; ContentHash tells whether the
+// file changed since it was embedded
+type TechDocIndexedDocument struct {
+	Path           string    `json:"path"`
+	ContentHash    string    `json:"contentHash"`
+	EmbeddingModel string    `json:"embeddingModel"`
+	Chunks         int       `json:"chunks"`
+	IndexedAt      time.Time `json:"indexedAt"`
+}
+
+// TechDocSource is a chunk retrieved to answer a chat question
+type TechDocSource struct {
+	Path    string  `json:"path"`
+	Heading string  `json:"heading,omitempty"`
+	Score   float64 `json:"score"`
+}
+
+// TechDocsIndexStatus summarizes the retrieval index of an organization
+type TechDocsIndexStatus struct {
+	Documents      int        `json:"documents"`
+	Chunks         int        `json:"chunks"`
+	EmbeddingModel string     `json:"embeddingModel,omitempty"`
+	StaleDocuments int        `json:"staleDocuments"`
+	LastIndexedAt  *time.Time `json:"lastIndexedAt,omitempty"`
+}
+
+// TechDocsIndexSyncResult is the outcome of a synchronization of the index with the docs tree
+type TechDocsIndexSyncResult struct {
+	Indexed   int      `json:"indexed"`
+	Removed   int      `json:"removed"`
+	Unchanged int      `json:"unchanged"`
+	Failed    []string `json:"failed,omitempty"`
+}
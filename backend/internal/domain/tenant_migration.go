@@ -0,0 +1,43 @@
+package domain
+
+// Estados de uma organização na trilha de migrations de tenant
+const (
+	TenantMigrationUpToDate = "up_to_date"
+	TenantMigrationPending  = "pending"
+	TenantMigrationMigrated = "migrated"
+	TenantMigrationDryRun   = "dry_run"
+	TenantMigrationFailed   = "failed"
+	TenantMigrationSkipped  = "skipped"
+)
+
+// TenantMigrationOptions controla uma execução das migrations de tenant
+type TenantMigrationOptions struct {
+	// DryRun executa as migrations pendentes em uma transação que é desfeita ao final
+	DryRun bool `json:"dryRun"`
+	// StopOnError interrompe a execução na primeira organização que falhar
+	StopOnError bool `json:"stopOnError"`
+}
+
+// TenantMigrationStatus é a situação das migrations no schema de uma organização
+type TenantMigrationStatus struct {
+	OrganizationUUID string   `json:"organizationUuid"`
+	OrganizationName string   `json:"organizationName"`
+	State            string   `json:"state"`
+	Applied          []string `json:"applied"`
+	Pending          []string `json:"pending"`
+	Executed         []string `json:"executed,omitempty"`
+	FailedVersion    string   `json:"failedVersion,omitempty"`
+	Error            string   `json:"error,omitempty"`
+}
+
+// TenantMigrationReport agrega o resultado de uma execução em todas as organizações
+type TenantMigrationReport struct {
+	DryRun        bool                    `json:"dryRun"`
+	Stopped       bool                    `json:"stopped"`
+	Latest        string                  `json:"latest"`
+	Total         int                     `json:"total"`
+	Migrated      int                     `json:"migrated"`
+	Failed        int                     `json:"failed"`
+	Skipped       int                     `json:"skipped"`
+	Organizations []TenantMigrationStatus `json:"organizations"`
+}
@@ -1,23 +1,42 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"time"
 
+	"github.com/PlatifyX/platifyx-core/internal/domain"
 	"github.com/PlatifyX/platifyx-core/internal/service"
 	"github.com/PlatifyX/platifyx-core/pkg/logger"
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	defaultAIUsageWindowDays = 30
+	maxAIUsageWindowDays     = 366
+)
+
 type AIHandler struct {
-	service *service.AIService
-	log     *logger.Logger
+	service      *service.AIService
+	usageService *service.AIUsageService
+	log          *logger.Logger
 }
 
-func NewAIHandler(svc *service.AIService, log *logger.Logger) *AIHandler {
+func NewAIHandler(svc *service.AIService, usageService *service.AIUsageService, log *logger.Logger) *AIHandler {
 	return &AIHandler{
-		service: svc,
-		log:     log,
+		service:      svc,
+		usageService: usageService,
+		log:          log,
+	}
+}
+
+// aiErrorStatus is the HTTP status of a failed AI call: 429 once the monthly budget is spent
+func aiErrorStatus(err error) int {
+	var exceeded *domain.AIBudgetExceededError
+	if errors.As(err, &exceeded) {
+		return http.StatusTooManyRequests
 	}
+	return http.StatusInternalServerError
 }
 
 // GetProviders returns list of available AI providers
@@ -44,3 +63,193 @@ func (h *AIHandler) GetProviders(c *gin.Context) {
 		"total":     len(providers),
 	})
 }
+
+// GetRoutingPolicy returns the AI routing policy of the organization
+func (h *AIHandler) GetRoutingPolicy(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Organization UUID is required",
+		})
+		return
+	}
+
+	policy, err := h.service.GetRoutingPolicy(orgUUID)
+	if err != nil {
+		h.log.Errorw("Failed to get AI routing policy", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get AI routing policy",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// UpdateRoutingPolicy replaces the AI routing policy of the organization
+func (h *AIHandler) UpdateRoutingPolicy(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Organization UUID is required",
+		})
+		return
+	}
+
+	var policy domain.AIRoutingPolicy
+	if err := c.ShouldBindJSON(&policy); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	updatedBy := c.GetString("user_id")
+	if updatedBy == "" {
+		updatedBy = "system"
+	}
+
+	updated, err := h.service.UpdateRoutingPolicy(orgUUID, &policy, updatedBy)
+	if err != nil {
+		var validation *domain.ValidationError
+		if errors.As(err, &validation) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.log.Errorw("Failed to update AI routing policy", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update AI routing policy",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+// GetUsage returns the AI usage of the organization over time.
+// Query params: from and to (YYYY-MM-DD, inclusive; default the last 30 days), interval (day|month).
+func (h *AIHandler) GetUsage(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Organization UUID is required",
+		})
+		return
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	to := today
+	from := today.AddDate(0, 0, -(defaultAIUsageWindowDays - 1))
+
+	if toStr := c.Query("to"); toStr != "" {
+		parsed, err := time.Parse("2006-01-02", toStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid to parameter, expected YYYY-MM-DD",
+			})
+			return
+		}
+		to = parsed
+		from = to.AddDate(0, 0, -(defaultAIUsageWindowDays - 1))
+	}
+	if fromStr := c.Query("from"); fromStr != "" {
+		parsed, err := time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid from parameter, expected YYYY-MM-DD",
+			})
+			return
+		}
+		from = parsed
+	}
+
+	// to is inclusive
+	to = to.AddDate(0, 0, 1)
+	if !from.Before(to) || to.Sub(from) > maxAIUsageWindowDays*24*time.Hour {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid period, from must not be after to and the window is limited to 366 days",
+		})
+		return
+	}
+
+	interval := c.DefaultQuery("interval", domain.AIUsageIntervalDay)
+	if interval != domain.AIUsageIntervalDay && interval != domain.AIUsageIntervalMonth {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid interval, expected day or month",
+		})
+		return
+	}
+
+	report, err := h.usageService.GetUsageReport(orgUUID, from, to, interval)
+	if err != nil {
+		h.log.Errorw("Failed to get AI usage", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get AI usage",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetBudget returns the monthly AI budget of the organization and the spending of the month
+func (h *AIHandler) GetBudget(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Organization UUID is required",
+		})
+		return
+	}
+
+	status, err := h.usageService.GetBudgetStatus(orgUUID)
+	if err != nil {
+		h.log.Errorw("Failed to get AI budget", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get AI budget",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// UpdateBudget sets the monthly AI budget of the organization (a zero limit removes it)
+func (h *AIHandler) UpdateBudget(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Organization UUID is required",
+		})
+		return
+	}
+
+	var budget domain.AIBudget
+	if err := c.ShouldBindJSON(&budget); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	updatedBy := c.GetString("user_id")
+	if updatedBy == "" {
+		updatedBy = "system"
+	}
+
+	status, err := h.usageService.UpdateBudget(orgUUID, &budget, updatedBy)
+	if err != nil {
+		var validation *domain.ValidationError
+		if errors.As(err, &validation) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.log.Errorw("Failed to update AI budget", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update AI budget",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
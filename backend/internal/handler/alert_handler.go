@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/PlatifyX/platifyx-core/internal/service"
+	"github.com/PlatifyX/platifyx-core/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// maxAlertWebhookBytes limits the size of an Alertmanager or Grafana webhook delivery
+const maxAlertWebhookBytes = 1 << 20
+
+type AlertHandler struct {
+	service *service.AlertService
+	log     *logger.Logger
+}
+
+func NewAlertHandler(svc *service.AlertService, log *logger.Logger) *AlertHandler {
+	return &AlertHandler{
+		service: svc,
+		log:     log,
+	}
+}
+
+// ReceiveAlertmanager serves the webhook receiver Alertmanager is configured with
+func (h *AlertHandler) ReceiveAlertmanager(c *gin.Context) {
+	h.receive(c, domain.AlertSourceAlertmanager)
+}
+
+// ReceiveGrafana serves the webhook contact point of Grafana unified alerting
+func (h *AlertHandler) ReceiveGrafana(c *gin.Context) {
+	h.receive(c, domain.AlertSourceGrafana)
+}
+
+// receive stores a webhook delivery. It is a public route: requests are authenticated by the
+// webhook token of the organization, sent as a bearer token or as the basic auth password.
+func (h *AlertHandler) receive(c *gin.Context, source string) {
+	orgUUID := c.Param("organization")
+
+	if err := h.service.VerifyWebhookToken(orgUUID, webhookToken(c)); err != nil {
+		var unauthorized *domain.UnauthorizedError
+		if errors.As(err, &unauthorized) {
+			h.log.Warnw("Rejected alert webhook", "organizationUUID", orgUUID, "source", source, "reason", err.Error())
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		h.log.Errorw("Failed to verify alert webhook", "error", err, "organizationUUID", orgUUID)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify alert webhook"})
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxAlertWebhookBytes+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read request body"})
+		return
+	}
+	if len(body) > maxAlertWebhookBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Webhook payload is too large"})
+		return
+	}
+
+	var payload domain.AlertWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook payload: " + err.Error()})
+		return
+	}
+
+	result, err := h.service.ReceiveWebhook(orgUUID, source, payload)
+	if err != nil {
+		h.respondError(c, "Failed to store alerts", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// webhookToken returns the bearer token of the request, or its basic auth password
+func webhookToken(c *gin.Context) string {
+	if _, password, ok := c.Request.BasicAuth(); ok {
+		return password
+	}
+	header := c.GetHeader("Authorization")
+	if len(header) > 7 && strings.EqualFold(header[:7], "bearer ") {
+		return strings.TrimSpace(header[7:])
+	}
+	return ""
+}
+
+func (h *AlertHandler) ListAlerts(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization UUID is required"})
+		return
+	}
+
+	var filter domain.AlertFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Size < 1 || filter.Size > 100 {
+		filter.Size = 20
+	}
+
+	alerts, total, err := h.service.ListAlerts(orgUUID, filter)
+	if err != nil {
+		h.respondError(c, "Failed to list alerts", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"alerts": alerts,
+		"total":  total,
+		"page":   filter.Page,
+		"size":   filter.Size,
+	})
+}
+
+// GetServiceTimeline returns the alert firings and resolutions of a service (?days=7)
+func (h *AlertHandler) GetServiceTimeline(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization UUID is required"})
+		return
+	}
+
+	serviceName := c.Param("service")
+	days, err := strconv.Atoi(c.DefaultQuery("days", "7"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "days must be a number"})
+		return
+	}
+
+	timeline, err := h.service.GetServiceTimeline(orgUUID, serviceName, days)
+	if err != nil {
+		h.respondError(c, "Failed to get alert timeline", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"service":  serviceName,
+		"timeline": timeline,
+		"total":    len(timeline),
+	})
+}
+
+func (h *AlertHandler) GetWebhookToken(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization UUID is required"})
+		return
+	}
+
+	token, err := h.service.GetWebhookToken(orgUUID)
+	if err != nil {
+		h.respondError(c, "Failed to get alert webhook token", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"webhook": token,
+		"urls":    alertWebhookURLs(orgUUID),
+	})
+}
+
+// GenerateWebhookToken creates or rotates the webhook token; it is only shown in this response
+func (h *AlertHandler) GenerateWebhookToken(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization UUID is required"})
+		return
+	}
+
+	token, err := h.service.GenerateWebhookToken(orgUUID, c.GetString("user_id"))
+	if err != nil {
+		h.respondError(c, "Failed to generate alert webhook token", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"webhook": token,
+		"urls":    alertWebhookURLs(orgUUID),
+	})
+}
+
+func alertWebhookURLs(orgUUID string) gin.H {
+	return gin.H{
+		"alertmanager": "/api/v1/webhooks/alerts/" + orgUUID + "/alertmanager",
+		"grafana":      "/api/v1/webhooks/alerts/" + orgUUID + "/grafana",
+	}
+}
+
+func (h *AlertHandler) respondError(c *gin.Context, message string, err error) {
+	var validation *domain.ValidationError
+	var notFound *domain.NotFoundError
+	var conflict *domain.ConflictError
+
+	switch {
+	case errors.As(err, &validation):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.As(err, &notFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.As(err, &conflict):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.log.Errorw(message, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
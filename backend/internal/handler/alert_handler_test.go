@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/PlatifyX/platifyx-core/internal/service"
+	"github.com/PlatifyX/platifyx-core/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+const testAlertOrganization = "5f0c6f4e-1c2d-4f59-9b35-3f6c8a1d2e7b"
+
+// stubAlertTokens stores the webhook token hash of each organization; alerts are never recorded
+// by these tests
+type stubAlertTokens struct {
+	hashes map[string]string
+	err    error
+}
+
+func (s *stubAlertTokens) GetWebhookToken(organizationUUID string) (*domain.AlertWebhookToken, string, error) {
+	hash, ok := s.hashes[organizationUUID]
+	if s.err != nil || !ok {
+		return nil, "", s.err
+	}
+	return &domain.AlertWebhookToken{Configured: true}, hash, nil
+}
+
+func (s *stubAlertTokens) UpsertWebhookToken(organizationUUID, hash, _, _ string) (time.Time, error) {
+	s.hashes[organizationUUID] = hash
+	return time.Now(), nil
+}
+
+func (s *stubAlertTokens) Record(*domain.Alert) (bool, string, error) {
+	return false, "", errors.New("not stored")
+}
+
+func (s *stubAlertTokens) List(string, domain.AlertFilter) ([]domain.Alert, int, error) {
+	return nil, 0, nil
+}
+
+func (s *stubAlertTokens) ListByService(string, string, time.Time) ([]domain.Alert, error) {
+	return nil, nil
+}
+
+func TestAlertWebhookRejectsInvalidTokens(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	log := logger.NewLogger("development")
+
+	store := &stubAlertTokens{hashes: map[string]string{}}
+	svc := service.NewAlertService(store, nil, nil, nil, log)
+	generated, err := svc.GenerateWebhookToken(testAlertOrganization, "admin")
+	if err != nil {
+		t.Fatalf("GenerateWebhookToken: %v", err)
+	}
+
+	router := gin.New()
+	router.POST("/webhooks/alerts/:organization/alertmanager", NewAlertHandler(svc, log).ReceiveAlertmanager)
+
+	tests := []struct {
+		name         string
+		organization string
+		header       string
+		basic        string
+		storeErr     error
+		want         int
+	}{
+		{name: "no token", want: http.StatusUnauthorized},
+		{name: "wrong bearer token", header: "Bearer pfxa_wrong", want: http.StatusUnauthorized},
+		{name: "wrong basic auth password", basic: "pfxa_wrong", want: http.StatusUnauthorized},
+		{name: "unsupported scheme", header: "Token " + generated.Token, want: http.StatusUnauthorized},
+		{name: "token of another organization", organization: "0b7e2a56-8d4c-4e0b-a1f3-6c2d9e8f7a10", header: "Bearer " + generated.Token, want: http.StatusUnauthorized},
+		{name: "malformed organization", organization: "acme", header: "Bearer " + generated.Token, want: http.StatusUnauthorized},
+		{name: "token store failure", header: "Bearer " + generated.Token, storeErr: errors.New("connection refused"), want: http.StatusInternalServerError},
+		// Accepted tokens get as far as decoding the (here invalid) payload
+		{name: "valid bearer token", header: "Bearer " + generated.Token, want: http.StatusBadRequest},
+		{name: "valid lowercase bearer token", header: "bearer " + generated.Token, want: http.StatusBadRequest},
+		{name: "valid basic auth password", basic: generated.Token, want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store.err = tt.storeErr
+			if tt.organization == "" {
+				tt.organization = testAlertOrganization
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/webhooks/alerts/"+tt.organization+"/alertmanager", strings.NewReader("{"))
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			if tt.basic != "" {
+				req.SetBasicAuth("alertmanager", tt.basic)
+			}
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status is %d, want %d: %s", rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/PlatifyX/platifyx-core/internal/service"
+	"github.com/PlatifyX/platifyx-core/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// AuditHandler serves the integrity checks and exports of the audit trail
+type AuditHandler struct {
+	service        *service.AuditService
+	userService    *service.UserService
+	userOrgService *service.UserOrganizationService
+	log            *logger.Logger
+}
+
+func NewAuditHandler(svc *service.AuditService, userService *service.UserService, userOrgService *service.UserOrganizationService, log *logger.Logger) *AuditHandler {
+	return &AuditHandler{
+		service:        svc,
+		userService:    userService,
+		userOrgService: userOrgService,
+		log:            log,
+	}
+}
+
+// VerifyChain walks the hash chain of an organization (organization_uuid query parameter)
+// or the global chain, and reports the first break
+func (h *AuditHandler) VerifyChain(c *gin.Context) {
+	orgUUID := c.Query("organization_uuid")
+	if !auditScopeAllowed(c, h.userService, h.userOrgService, orgUUID) {
+		return
+	}
+
+	result, err := h.service.VerifyChain(orgUUID)
+	if err != nil {
+		h.log.Errorw("Failed to verify audit chain", "error", err, "organizationUUID", orgUUID)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify audit chain"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Export streams the audit logs matching the filters as NDJSON or CSV. The export is bounded by
+// end_date (now when not given, returned in X-Audit-Export-Until) so that the signed manifest
+// can be requested later for exactly the same content. The digest and signature are also sent
+// as HTTP trailers.
+func (h *AuditHandler) Export(c *gin.Context) {
+	filter, format, ok := h.bindExport(c)
+	if !ok {
+		return
+	}
+	if filter.EndDate.IsZero() {
+		filter.EndDate = time.Now().UTC().Truncate(time.Second)
+	}
+
+	contentType := "application/x-ndjson"
+	if format == service.AuditExportCSV {
+		contentType = "text/csv"
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="audit-%s.%s"`, filter.EndDate.Format("20060102T150405Z"), format))
+	c.Header("X-Audit-Export-Until", filter.EndDate.Format(time.RFC3339))
+	c.Header("Trailer", "X-Audit-Export-SHA256, X-Audit-Export-Signature")
+	c.Status(http.StatusOK)
+
+	manifest, err := h.service.Export(filter, format, c.Writer)
+	if err != nil {
+		// Headers are already sent: the missing trailers tell the client the export is incomplete
+		h.log.Errorw("Failed to export audit logs", "error", err)
+		return
+	}
+
+	c.Writer.Header().Set("X-Audit-Export-SHA256", manifest.SHA256)
+	c.Writer.Header().Set("X-Audit-Export-Signature", manifest.Signature)
+}
+
+// ExportManifest returns the signed manifest of an export. end_date is required so that the
+// manifest describes a reproducible export.
+func (h *AuditHandler) ExportManifest(c *gin.Context) {
+	filter, format, ok := h.bindExport(c)
+	if !ok {
+		return
+	}
+	if filter.EndDate.IsZero() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "end_date is required (use the X-Audit-Export-Until of the export)"})
+		return
+	}
+
+	manifest, err := h.service.Export(filter, format, io.Discard)
+	if err != nil {
+		h.log.Errorw("Failed to build audit export manifest", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build audit export manifest"})
+		return
+	}
+
+	c.JSON(http.StatusOK, manifest)
+}
+
+func (h *AuditHandler) bindExport(c *gin.Context) (domain.AuditLogFilter, string, bool) {
+	var filter domain.AuditLogFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return filter, "", false
+	}
+
+	format := c.DefaultQuery("format", service.AuditExportNDJSON)
+	if format != service.AuditExportNDJSON && format != service.AuditExportCSV {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be ndjson or csv"})
+		return filter, "", false
+	}
+
+	if !auditScopeAllowed(c, h.userService, h.userOrgService, filter.OrganizationUUID) {
+		return filter, "", false
+	}
+
+	return filter, format, true
+}
+
+// auditScopeAllowed checks the organization the audit trail is read for: platform admins can read
+// any organization and the trail across organizations, other callers only an organization they
+// belong to. Returns false with the request already answered when the caller is not allowed.
+func auditScopeAllowed(c *gin.Context, userService *service.UserService, userOrgService *service.UserOrganizationService, orgUUID string) bool {
+	userID := c.GetString("user_id")
+
+	permissions, err := userService.GetUserPermissions(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error checking permissions"})
+		return false
+	}
+	if permissions.IsPlatformAdmin {
+		return true
+	}
+
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "organization_uuid is required"})
+		return false
+	}
+
+	member, err := userOrgService.HasAccess(userID, orgUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify organization access"})
+		return false
+	}
+	if !member {
+		c.JSON(http.StatusForbidden, gin.H{"error": "User does not have access to this organization"})
+		return false
+	}
+
+	return true
+}
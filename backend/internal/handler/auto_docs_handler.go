@@ -47,6 +47,8 @@ func (h *AutoDocsHandler) GenerateAutoDocs(c *gin.Context) {
 		IntegrationID:    req.IntegrationID,
 		ServiceName:      req.ServiceName,
 		Branch:           req.Branch,
+		UserID:           c.GetString("user_id"),
+		Author:           techDocAuthor(c),
 	}
 
 	// Convert doc types
@@ -75,13 +77,21 @@ func (h *AutoDocsHandler) GenerateAutoDocs(c *gin.Context) {
 }
 
 func (h *AutoDocsHandler) GetProgress(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Organization UUID is required",
+		})
+		return
+	}
+
 	progressID := c.Param("id")
 	if progressID == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "progress id is required"})
 		return
 	}
 
-	progress, err := h.service.GetProgress(progressID)
+	progress, err := h.service.GetProgress(orgUUID, progressID)
 	if err != nil {
 		h.log.Errorw("Failed to get progress", "error", err, "progressId", progressID)
 		c.JSON(http.StatusNotFound, gin.H{"error": "Progress not found"})
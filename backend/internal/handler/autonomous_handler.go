@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"context"
+	"errors"
 	"net/http"
 
 	"github.com/PlatifyX/platifyx-core/internal/domain"
@@ -80,12 +82,28 @@ func (h *AutonomousHandler) Troubleshoot(c *gin.Context) {
 		ServiceName: req.ServiceName,
 		Deployment:  req.Deployment,
 		Namespace:   req.Namespace,
+		UserID:      c.GetString("user_id"),
+	}
+
+	// With ?stream=true (or Accept: text/event-stream) the analysis is streamed as it is generated
+	if wantsEventStream(c) {
+		streamEvents(c, h.log, func(ctx context.Context, onDelta func(delta string) error) (interface{}, error) {
+			response, usage, err := h.troubleshootingService.TroubleshootStream(ctx, orgUUID, troubleshootingReq, onDelta)
+			if err != nil {
+				return nil, err
+			}
+			return gin.H{
+				"response": response,
+				"usage":    usage,
+			}, nil
+		})
+		return
 	}
 
 	response, err := h.troubleshootingService.Troubleshoot(orgUUID, troubleshootingReq)
 	if err != nil {
 		h.log.Errorw("Failed to troubleshoot", "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(aiErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -93,6 +111,14 @@ func (h *AutonomousHandler) Troubleshoot(c *gin.Context) {
 }
 
 func (h *AutonomousHandler) ExecuteAction(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Organization UUID is required",
+		})
+		return
+	}
+
 	var action struct {
 		Type        string                 `json:"type"`
 		Description string                 `json:"description"`
@@ -107,11 +133,6 @@ func (h *AutonomousHandler) ExecuteAction(c *gin.Context) {
 		return
 	}
 
-	userID := c.GetString("user_id")
-	if userID == "" {
-		userID = "system"
-	}
-
 	recommendedAction := domain.RecommendedAction{
 		Type:        action.Type,
 		Description: action.Description,
@@ -121,7 +142,7 @@ func (h *AutonomousHandler) ExecuteAction(c *gin.Context) {
 		AutoExecute: action.AutoExecute,
 	}
 
-	result, err := h.actionsService.ExecuteAction(recommendedAction, userID)
+	result, err := h.actionsService.ExecuteAction(orgUUID, recommendedAction, actionActor(c))
 	if err != nil {
 		h.log.Errorw("Failed to execute action", "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -131,19 +152,195 @@ func (h *AutonomousHandler) ExecuteAction(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+func (h *AutonomousHandler) ListActions(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Organization UUID is required",
+		})
+		return
+	}
+
+	var filter domain.AutonomousActionFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Size < 1 || filter.Size > 100 {
+		filter.Size = 20
+	}
+
+	actions, total, err := h.actionsService.ListActions(orgUUID, filter)
+	if err != nil {
+		h.log.Errorw("Failed to list actions", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list actions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"actions": actions,
+		"total":   total,
+		"page":    filter.Page,
+		"size":    filter.Size,
+	})
+}
+
+func (h *AutonomousHandler) GetAction(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Organization UUID is required",
+		})
+		return
+	}
+
+	action, err := h.actionsService.GetAction(orgUUID, c.Param("id"))
+	if err != nil {
+		h.respondActionError(c, "Failed to get action", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, action)
+}
+
+func (h *AutonomousHandler) ApproveAction(c *gin.Context) {
+	h.decideAction(c, h.actionsService.ApproveAction, "Failed to approve action")
+}
+
+func (h *AutonomousHandler) RejectAction(c *gin.Context) {
+	h.decideAction(c, h.actionsService.RejectAction, "Failed to reject action")
+}
+
+func (h *AutonomousHandler) CancelAction(c *gin.Context) {
+	h.decideAction(c, h.actionsService.CancelAction, "Failed to cancel action")
+}
+
+// UndoAction reverts a completed action to the state recorded before it ran
+func (h *AutonomousHandler) UndoAction(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Organization UUID is required",
+		})
+		return
+	}
+
+	result, err := h.actionsService.UndoAction(orgUUID, c.Param("id"), actionActor(c))
+	if err != nil {
+		h.respondActionError(c, "Failed to undo action", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 func (h *AutonomousHandler) GetConfig(c *gin.Context) {
-	config := h.actionsService.GetConfig()
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Organization UUID is required",
+		})
+		return
+	}
+
+	config, err := h.actionsService.GetConfig(orgUUID)
+	if err != nil {
+		h.log.Errorw("Failed to get autonomous config", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get config"})
+		return
+	}
+
 	c.JSON(http.StatusOK, config)
 }
 
 func (h *AutonomousHandler) UpdateConfig(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Organization UUID is required",
+		})
+		return
+	}
+
 	var config domain.AutonomousConfig
 	if err := c.ShouldBindJSON(&config); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	h.actionsService.UpdateConfig(&config)
+	if err := h.actionsService.UpdateConfig(orgUUID, &config, actionActor(c).UserID); err != nil {
+		h.log.Errorw("Failed to update autonomous config", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update config"})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{"message": "Config updated successfully", "config": config})
 }
 
+func (h *AutonomousHandler) decideAction(c *gin.Context, decide func(string, string, domain.AutonomousActionActor, string) (*domain.AutonomousAction, error), failure string) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Organization UUID is required",
+		})
+		return
+	}
+
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	// The body is optional
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	action, err := decide(orgUUID, c.Param("id"), actionActor(c), req.Reason)
+	if err != nil {
+		h.respondActionError(c, failure, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, action)
+}
+
+func (h *AutonomousHandler) respondActionError(c *gin.Context, message string, err error) {
+	var notFound *domain.NotFoundError
+	var invalidState *domain.InvalidStateError
+	var forbidden *domain.ForbiddenError
+
+	switch {
+	case errors.As(err, &notFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.As(err, &invalidState):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.As(err, &forbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		h.log.Errorw(message, "error", err, "action", c.Param("id"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// actionActor identifies the caller for the action history and the audit log
+func actionActor(c *gin.Context) domain.AutonomousActionActor {
+	actor := domain.AutonomousActionActor{
+		UserID:    c.GetString("user_id"),
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+	if actor.UserID == "" {
+		actor.UserID = "system"
+	}
+	if user, ok := c.Get("user"); ok {
+		if u, ok := user.(*domain.User); ok {
+			actor.UserEmail = u.Email
+		}
+	}
+	return actor
+}
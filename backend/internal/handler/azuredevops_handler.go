@@ -25,14 +25,7 @@ func NewAzureDevOpsHandler(integrationService *service.IntegrationService, log *
 }
 
 func (h *AzureDevOpsHandler) getService(organizationUUID string) (*service.AzureDevOpsService, error) {
-	config, err := h.integrationService.GetAzureDevOpsConfig(organizationUUID)
-	if err != nil {
-		return nil, err
-	}
-	if config == nil {
-		return nil, nil
-	}
-	return service.NewAzureDevOpsService(*config, h.log), nil
+	return h.integrationService.GetAzureDevOpsService(organizationUUID)
 }
 
 func (h *AzureDevOpsHandler) ListPipelines(c *gin.Context) {
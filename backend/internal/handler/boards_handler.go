@@ -22,7 +22,7 @@ func NewBoardsHandler(svc *service.BoardsService, log *logger.Logger) *BoardsHan
 }
 
 func (h *BoardsHandler) GetUnifiedBoard(c *gin.Context) {
-	board, err := h.service.GetUnifiedBoard()
+	board, err := h.service.GetUnifiedBoard(c.GetString("organization_uuid"))
 	if err != nil {
 		h.log.Errorw("Failed to get unified board", "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -39,7 +39,7 @@ func (h *BoardsHandler) GetBoardBySource(c *gin.Context) {
 		return
 	}
 
-	board, err := h.service.GetBoardBySource(domain.BoardSource(source))
+	board, err := h.service.GetBoardBySource(c.GetString("organization_uuid"), domain.BoardSource(source))
 	if err != nil {
 		h.log.Errorw("Failed to get board by source", "error", err, "source", source)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -1,9 +1,14 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/PlatifyX/platifyx-core/internal/service"
+	"github.com/PlatifyX/platifyx-core/pkg/github"
 	"github.com/PlatifyX/platifyx-core/pkg/logger"
 	"github.com/gin-gonic/gin"
 )
@@ -23,23 +28,21 @@ func NewGitHubHandler(integrationSvc *service.IntegrationService, cache *service
 }
 
 func (h *GitHubHandler) getService(organizationUUID string, integrationName string) (*service.GitHubService, error) {
-	config, err := h.integrationService.GetGitHubConfigByName(integrationName, organizationUUID)
-	if err != nil {
-		return nil, err
-	}
-	if config == nil {
-		return nil, nil
-	}
-	return service.NewGitHubService(*config, h.log), nil
+	return h.integrationService.GetGitHubServiceByName(organizationUUID, integrationName)
 }
 
 func (h *GitHubHandler) GetStats(c *gin.Context) {
 	integrationName := c.Query("integration")
 	h.log.Infow("GetStats called", "integration", integrationName)
-	cacheKey := service.BuildKey("github", "stats")
-	if integrationName != "" {
-		cacheKey = service.BuildKey("github", "stats:"+integrationName)
+
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Organization UUID is required",
+		})
+		return
 	}
+	cacheKey := service.BuildKey("github", orgUUID+":stats:"+integrationName)
 
 	// Try cache first
 	if h.cache != nil {
@@ -52,14 +55,6 @@ func (h *GitHubHandler) GetStats(c *gin.Context) {
 	}
 
 	// Cache MISS
-	orgUUID := c.GetString("organization_uuid")
-	if orgUUID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Organization UUID is required",
-		})
-		return
-	}
-
 	svc, err := h.getService(orgUUID, integrationName)
 	if err != nil {
 		h.log.Errorw("Failed to get GitHub service", "error", err)
@@ -127,10 +122,19 @@ func (h *GitHubHandler) GetAuthenticatedUser(c *gin.Context) {
 
 func (h *GitHubHandler) ListRepositories(c *gin.Context) {
 	integrationName := c.Query("integration")
-	cacheKey := service.BuildKey("github", "repositories")
-	if integrationName != "" {
-		cacheKey = service.BuildKey("github", "repositories:"+integrationName)
+	opts, ok := githubListOptions(c)
+	if !ok {
+		return
+	}
+
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Organization UUID is required",
+		})
+		return
 	}
+	cacheKey := service.BuildKey("github", fmt.Sprintf("%s:repositories:%s:%d:%d:%s", orgUUID, integrationName, opts.PerPage, opts.MaxPages, opts.Cursor))
 
 	// Try cache first
 	if h.cache != nil {
@@ -143,14 +147,6 @@ func (h *GitHubHandler) ListRepositories(c *gin.Context) {
 	}
 
 	// Cache MISS
-	orgUUID := c.GetString("organization_uuid")
-	if orgUUID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Organization UUID is required",
-		})
-		return
-	}
-
 	svc, err := h.getService(orgUUID, integrationName)
 	if err != nil {
 		h.log.Errorw("Failed to get GitHub service", "error", err)
@@ -167,18 +163,19 @@ func (h *GitHubHandler) ListRepositories(c *gin.Context) {
 		return
 	}
 
-	repos, err := svc.ListRepositories()
+	repos, page, err := svc.ListRepositoriesPage(opts)
 	if err != nil {
 		h.log.Errorw("Failed to list repositories", "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		respondGitHubError(c, err)
 		return
 	}
 
+	// truncated tells the client that max_pages stopped the listing: next_cursor resumes it
 	result := gin.H{
 		"repositories": repos,
 		"total":        len(repos),
+		"truncated":    page.Truncated(),
+		"next_cursor":  page.NextCursor,
 	}
 
 	// Store in cache (10 minutes TTL)
@@ -260,18 +257,23 @@ func (h *GitHubHandler) ListCommits(c *gin.Context) {
 	repo := c.Param("repo")
 	branch := c.Query("branch")
 
-	commits, err := svc.ListCommits(owner, repo, branch)
+	opts, ok := githubListOptions(c)
+	if !ok {
+		return
+	}
+
+	commits, page, err := svc.ListCommits(owner, repo, branch, opts)
 	if err != nil {
 		h.log.Errorw("Failed to list commits", "error", err, "owner", owner, "repo", repo)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		respondGitHubError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"commits": commits,
-		"total":   len(commits),
+		"commits":     commits,
+		"total":       len(commits),
+		"truncated":   page.Truncated(),
+		"next_cursor": page.NextCursor,
 	})
 }
 
@@ -304,18 +306,23 @@ func (h *GitHubHandler) ListPullRequests(c *gin.Context) {
 	repo := c.Param("repo")
 	state := c.DefaultQuery("state", "open")
 
-	prs, err := svc.ListPullRequests(owner, repo, state)
+	opts, ok := githubListOptions(c)
+	if !ok {
+		return
+	}
+
+	prs, page, err := svc.ListPullRequests(owner, repo, state, opts)
 	if err != nil {
 		h.log.Errorw("Failed to list pull requests", "error", err, "owner", owner, "repo", repo)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		respondGitHubError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"pullRequests": prs,
 		"total":        len(prs),
+		"truncated":    page.Truncated(),
+		"next_cursor":  page.NextCursor,
 	})
 }
 
@@ -348,18 +355,23 @@ func (h *GitHubHandler) ListIssues(c *gin.Context) {
 	repo := c.Param("repo")
 	state := c.DefaultQuery("state", "open")
 
-	issues, err := svc.ListIssues(owner, repo, state)
+	opts, ok := githubListOptions(c)
+	if !ok {
+		return
+	}
+
+	issues, page, err := svc.ListIssues(owner, repo, state, opts)
 	if err != nil {
 		h.log.Errorw("Failed to list issues", "error", err, "owner", owner, "repo", repo)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		respondGitHubError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"issues": issues,
-		"total":  len(issues),
+		"issues":      issues,
+		"total":       len(issues),
+		"truncated":   page.Truncated(),
+		"next_cursor": page.NextCursor,
 	})
 }
 
@@ -391,18 +403,23 @@ func (h *GitHubHandler) ListBranches(c *gin.Context) {
 	owner := c.Param("owner")
 	repo := c.Param("repo")
 
-	branches, err := svc.ListBranches(owner, repo)
+	opts, ok := githubListOptions(c)
+	if !ok {
+		return
+	}
+
+	branches, page, err := svc.ListBranches(owner, repo, opts)
 	if err != nil {
 		h.log.Errorw("Failed to list branches", "error", err, "owner", owner, "repo", repo)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		respondGitHubError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"branches": branches,
-		"total":    len(branches),
+		"branches":    branches,
+		"total":       len(branches),
+		"truncated":   page.Truncated(),
+		"next_cursor": page.NextCursor,
 	})
 }
 
@@ -434,18 +451,23 @@ func (h *GitHubHandler) ListWorkflowRuns(c *gin.Context) {
 	owner := c.Param("owner")
 	repo := c.Param("repo")
 
-	runs, err := svc.ListWorkflowRuns(owner, repo)
+	opts, ok := githubListOptions(c)
+	if !ok {
+		return
+	}
+
+	runs, page, err := svc.ListWorkflowRuns(owner, repo, opts)
 	if err != nil {
 		h.log.Errorw("Failed to list workflow runs", "error", err, "owner", owner, "repo", repo)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		respondGitHubError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"workflowRuns": runs,
 		"total":        len(runs),
+		"truncated":    page.Truncated(),
+		"next_cursor":  page.NextCursor,
 	})
 }
 
@@ -487,3 +509,54 @@ func (h *GitHubHandler) GetOrganization(c *gin.Context) {
 
 	c.JSON(http.StatusOK, organization)
 }
+
+// githubListOptions reads the pagination of a list request: per_page, max_pages and cursor.
+// It answers 400 and returns false when per_page or max_pages is not a positive number.
+func githubListOptions(c *gin.Context) (github.ListOptions, bool) {
+	perPage, ok := positiveQueryInt(c, "per_page")
+	if !ok {
+		return github.ListOptions{}, false
+	}
+	maxPages, ok := positiveQueryInt(c, "max_pages")
+	if !ok {
+		return github.ListOptions{}, false
+	}
+	return github.ListOptions{PerPage: perPage, MaxPages: maxPages, Cursor: c.Query("cursor")}, true
+}
+
+// positiveQueryInt reads an optional positive integer query parameter (0 when absent)
+func positiveQueryInt(c *gin.Context, param string) (int, bool) {
+	raw := c.Query(param)
+	if raw == "" {
+		return 0, true
+	}
+
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": param + " must be a positive integer",
+		})
+		return 0, false
+	}
+	return n, true
+}
+
+// respondGitHubError answers 429 with Retry-After when the GitHub quota is exhausted
+func respondGitHubError(c *gin.Context, err error) {
+	var rateLimitErr *github.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		retryAfter := int(time.Until(rateLimitErr.Reset).Seconds()) + 1
+		if retryAfter < 1 {
+			retryAfter = 1
+		}
+		c.Header("Retry-After", strconv.Itoa(retryAfter))
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error": err.Error(),
+	})
+}
@@ -0,0 +1,330 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/service"
+	"github.com/PlatifyX/platifyx-core/pkg/gitlab"
+	"github.com/PlatifyX/platifyx-core/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// GitLabHandler serves the /code/gitlab routes. Projects are addressed by their numeric ID
+// (or URL-encoded full path), as in the GitLab API.
+type GitLabHandler struct {
+	integrationService *service.IntegrationService
+	cache              *service.CacheService
+	log                *logger.Logger
+}
+
+func NewGitLabHandler(integrationSvc *service.IntegrationService, cache *service.CacheService, log *logger.Logger) *GitLabHandler {
+	return &GitLabHandler{
+		integrationService: integrationSvc,
+		cache:              cache,
+		log:                log,
+	}
+}
+
+// getService resolves the GitLab client of the request organization, answering the request
+// itself when there is none
+func (h *GitLabHandler) getService(c *gin.Context) (*service.GitLabService, bool) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Organization UUID is required",
+		})
+		return nil, false
+	}
+
+	svc, err := h.integrationService.GetGitLabServiceByName(orgUUID, c.Query("integration"))
+	if err != nil {
+		h.log.Errorw("Failed to get GitLab service", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to initialize GitLab service",
+		})
+		return nil, false
+	}
+
+	if svc == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "GitLab integration not configured",
+		})
+		return nil, false
+	}
+
+	return svc, true
+}
+
+func (h *GitLabHandler) GetStats(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	cacheKey := service.BuildKey("gitlab", orgUUID+":stats:"+c.Query("integration"))
+
+	// Try cache first
+	if h.cache != nil && orgUUID != "" {
+		var cachedStats interface{}
+		if err := h.cache.GetJSON(cacheKey, &cachedStats); err == nil {
+			h.log.Debugw("Cache HIT", "key", cacheKey)
+			c.JSON(http.StatusOK, cachedStats)
+			return
+		}
+	}
+
+	svc, ok := h.getService(c)
+	if !ok {
+		return
+	}
+
+	stats := svc.GetStats()
+
+	// Store in cache (5 minutes TTL)
+	if h.cache != nil {
+		if err := h.cache.Set(cacheKey, stats, service.CacheDuration5Minutes); err != nil {
+			h.log.Warnw("Failed to cache GitLab stats", "error", err)
+		}
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+func (h *GitLabHandler) GetAuthenticatedUser(c *gin.Context) {
+	svc, ok := h.getService(c)
+	if !ok {
+		return
+	}
+
+	user, err := svc.GetAuthenticatedUser()
+	if err != nil {
+		respondGitLabError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *GitLabHandler) ListProjects(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	cacheKey := service.BuildKey("gitlab", orgUUID+":projects:"+c.Query("integration"))
+	cacheable := c.Query("per_page") == "" && c.Query("max_pages") == ""
+
+	// Try cache first
+	if h.cache != nil && orgUUID != "" && cacheable {
+		var cachedData map[string]interface{}
+		if err := h.cache.GetJSON(cacheKey, &cachedData); err == nil {
+			h.log.Debugw("Cache HIT", "key", cacheKey)
+			c.JSON(http.StatusOK, cachedData)
+			return
+		}
+	}
+
+	opts, ok := gitlabListOptions(c)
+	if !ok {
+		return
+	}
+
+	svc, ok := h.getService(c)
+	if !ok {
+		return
+	}
+
+	projects, err := svc.ListProjects(opts)
+	if err != nil {
+		respondGitLabError(c, err)
+		return
+	}
+
+	result := gin.H{
+		"projects": projects,
+		"total":    len(projects),
+	}
+
+	// Store in cache (10 minutes TTL)
+	if h.cache != nil && cacheable {
+		if err := h.cache.Set(cacheKey, result, service.CacheDuration10Minutes); err != nil {
+			h.log.Warnw("Failed to cache GitLab projects", "error", err)
+		}
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *GitLabHandler) GetProject(c *gin.Context) {
+	svc, ok := h.getService(c)
+	if !ok {
+		return
+	}
+
+	project, err := svc.GetProject(c.Param("id"))
+	if err != nil {
+		respondGitLabError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
+
+func (h *GitLabHandler) ListCommits(c *gin.Context) {
+	opts, ok := gitlabListOptions(c)
+	if !ok {
+		return
+	}
+
+	svc, ok := h.getService(c)
+	if !ok {
+		return
+	}
+
+	commits, err := svc.ListCommits(c.Param("id"), c.Query("branch"), opts)
+	if err != nil {
+		respondGitLabError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"commits": commits,
+		"total":   len(commits),
+	})
+}
+
+func (h *GitLabHandler) ListMergeRequests(c *gin.Context) {
+	opts, ok := gitlabListOptions(c)
+	if !ok {
+		return
+	}
+
+	svc, ok := h.getService(c)
+	if !ok {
+		return
+	}
+
+	mrs, err := svc.ListMergeRequests(c.Param("id"), c.DefaultQuery("state", "opened"), opts)
+	if err != nil {
+		respondGitLabError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"mergeRequests": mrs,
+		"total":         len(mrs),
+	})
+}
+
+func (h *GitLabHandler) ListIssues(c *gin.Context) {
+	opts, ok := gitlabListOptions(c)
+	if !ok {
+		return
+	}
+
+	svc, ok := h.getService(c)
+	if !ok {
+		return
+	}
+
+	issues, err := svc.ListIssues(c.Param("id"), c.DefaultQuery("state", "opened"), opts)
+	if err != nil {
+		respondGitLabError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"issues": issues,
+		"total":  len(issues),
+	})
+}
+
+func (h *GitLabHandler) ListBranches(c *gin.Context) {
+	opts, ok := gitlabListOptions(c)
+	if !ok {
+		return
+	}
+
+	svc, ok := h.getService(c)
+	if !ok {
+		return
+	}
+
+	branches, err := svc.ListBranches(c.Param("id"), opts)
+	if err != nil {
+		respondGitLabError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"branches": branches,
+		"total":    len(branches),
+	})
+}
+
+func (h *GitLabHandler) ListPipelines(c *gin.Context) {
+	opts, ok := gitlabListOptions(c)
+	if !ok {
+		return
+	}
+
+	svc, ok := h.getService(c)
+	if !ok {
+		return
+	}
+
+	pipelines, err := svc.ListPipelines(c.Param("id"), opts)
+	if err != nil {
+		respondGitLabError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pipelines": pipelines,
+		"total":     len(pipelines),
+	})
+}
+
+func (h *GitLabHandler) GetGroup(c *gin.Context) {
+	svc, ok := h.getService(c)
+	if !ok {
+		return
+	}
+
+	group, err := svc.GetGroup(c.Param("group"))
+	if err != nil {
+		respondGitLabError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, group)
+}
+
+// gitlabListOptions reads the pagination of a list request: per_page and max_pages.
+// It answers 400 and returns false when either is not a positive number.
+func gitlabListOptions(c *gin.Context) (gitlab.ListOptions, bool) {
+	perPage, ok := positiveQueryInt(c, "per_page")
+	if !ok {
+		return gitlab.ListOptions{}, false
+	}
+	maxPages, ok := positiveQueryInt(c, "max_pages")
+	if !ok {
+		return gitlab.ListOptions{}, false
+	}
+	return gitlab.ListOptions{PerPage: perPage, MaxPages: maxPages}, true
+}
+
+// respondGitLabError answers 429 with Retry-After when GitLab throttles the token
+func respondGitLabError(c *gin.Context, err error) {
+	var rateLimitErr *gitlab.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		retryAfter := int(time.Until(rateLimitErr.Reset).Seconds()) + 1
+		if retryAfter < 1 {
+			retryAfter = 1
+		}
+		c.Header("Retry-After", strconv.Itoa(retryAfter))
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error": err.Error(),
+	})
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PlatifyX/platifyx-core/pkg/gitlab"
+	"github.com/gin-gonic/gin"
+)
+
+func TestGitLabListOptions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		query  string
+		want   gitlab.ListOptions
+		wantOK bool
+	}{
+		{query: "", wantOK: true},
+		{query: "per_page=20&max_pages=3", want: gitlab.ListOptions{PerPage: 20, MaxPages: 3}, wantOK: true},
+		{query: "max_pages=-1"},
+		{query: "max_pages=0"},
+		{query: "per_page=abc"},
+		{query: "per_page=-5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rec)
+			c.Request = httptest.NewRequest(http.MethodGet, "/gitlab/projects?"+tt.query, nil)
+
+			opts, ok := gitlabListOptions(c)
+			if ok != tt.wantOK || opts != tt.want {
+				t.Fatalf("gitlabListOptions = %+v, %v; want %+v, %v", opts, ok, tt.want, tt.wantOK)
+			}
+			if !ok && rec.Code != http.StatusBadRequest {
+				t.Errorf("status is %d, want 400", rec.Code)
+			}
+		})
+	}
+}
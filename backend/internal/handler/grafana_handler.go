@@ -24,14 +24,7 @@ func NewGrafanaHandler(integrationSvc *service.IntegrationService, cache *servic
 }
 
 func (h *GrafanaHandler) getService(organizationUUID string) (*service.GrafanaService, error) {
-	config, err := h.integrationService.GetGrafanaConfig(organizationUUID)
-	if err != nil {
-		return nil, err
-	}
-	if config == nil {
-		return nil, nil
-	}
-	return service.NewGrafanaService(*config, h.log), nil
+	return h.integrationService.GetGrafanaService(organizationUUID)
 }
 
 func (h *GrafanaHandler) GetStats(c *gin.Context) {
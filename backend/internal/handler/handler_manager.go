@@ -8,6 +8,9 @@ import (
 type HandlerManager struct {
 	HealthHandler          *HealthHandler
 	MetricsHandler         *MetricsHandler
+	IncidentHandler        *IncidentHandler
+	AlertHandler           *AlertHandler
+	NotificationHandler    *NotificationHandler
 	KubernetesHandler      *KubernetesHandler
 	AzureDevOpsHandler     *AzureDevOpsHandler
 	SonarQubeHandler       *SonarQubeHandler
@@ -16,6 +19,7 @@ type HandlerManager struct {
 	FinOpsHandler          *FinOpsHandler
 	GrafanaHandler         *GrafanaHandler
 	GitHubHandler          *GitHubHandler
+	GitLabHandler          *GitLabHandler
 	TechDocsHandler        *TechDocsHandler
 	JiraHandler            *JiraHandler
 	SlackHandler           *SlackHandler
@@ -41,12 +45,17 @@ type HandlerManager struct {
 	OrganizationHandler    *OrganizationHandler
 	UserOrganizationHandler *UserOrganizationHandler
 	OrganizationUserHandler *OrganizationUserHandler
+	AuditHandler           *AuditHandler
+	TenantMigrationHandler *TenantMigrationHandler
 }
 
 func NewHandlerManager(services *service.ServiceManager, log *logger.Logger) *HandlerManager {
 	return &HandlerManager{
 		HealthHandler:          NewHealthHandler(),
 		MetricsHandler:         NewMetricsHandler(services.MetricsService, log),
+		IncidentHandler:        NewIncidentHandler(services.IncidentService, log),
+		AlertHandler:           NewAlertHandler(services.AlertService, log),
+		NotificationHandler:    NewNotificationHandler(services.NotificationService, log),
 		KubernetesHandler:      NewKubernetesHandler(services.IntegrationService, log),
 		AzureDevOpsHandler:     NewAzureDevOpsHandler(services.IntegrationService, log),
 		SonarQubeHandler:       NewSonarQubeHandler(services.IntegrationService, services.CacheService, log),
@@ -55,6 +64,7 @@ func NewHandlerManager(services *service.ServiceManager, log *logger.Logger) *Ha
 		FinOpsHandler:          NewFinOpsHandler(services.FinOpsService, services.CacheService, log),
 		GrafanaHandler:         NewGrafanaHandler(services.IntegrationService, services.CacheService, log),
 		GitHubHandler:          NewGitHubHandler(services.IntegrationService, services.CacheService, log),
+		GitLabHandler:          NewGitLabHandler(services.IntegrationService, services.CacheService, log),
 		TechDocsHandler:        NewTechDocsHandler(services.TechDocsService, log),
 		JiraHandler:            NewJiraHandler(services.IntegrationService, log),
 		SlackHandler:           NewSlackHandler(services.IntegrationService, log),
@@ -67,9 +77,9 @@ func NewHandlerManager(services *service.ServiceManager, log *logger.Logger) *Ha
 		OpenVPNHandler:         NewOpenVPNHandler(services.IntegrationService, log),
 		ServiceTemplateHandler: NewServiceTemplateHandler(services.ServiceTemplateService, log),
 		ServiceCatalogHandler:  NewServiceCatalogHandler(services.ServiceCatalogService, services.SonarQubeService, services.AzureDevOpsService, services.IntegrationService, log),
-		AIHandler:              NewAIHandler(services.AIService, log),
+		AIHandler:              NewAIHandler(services.AIService, services.AIUsageService, log),
 		TemplateHandler:        NewTemplateHandler(services.TemplateService, log),
-		SettingsHandler:        NewSettingsHandler(services.UserService, services.UserRepository, services.RoleRepository, services.TeamRepository, services.AuditRepository, services.SSORepository),
+		SettingsHandler:        NewSettingsHandler(services.UserService, services.UserOrganizationService, services.UserRepository, services.RoleRepository, services.TeamRepository, services.AuditRepository, services.SSORepository),
 		AuthHandler:            NewAuthHandler(services.AuthService, services.UserService),
 		SSOHandler:             NewSSOHandler(services.SSORepository, services.UserRepository, services.AuthService, services.CacheService),
 		AutonomousHandler:      NewAutonomousHandler(services.AutonomousRecommendationsService, services.TroubleshootingAssistantService, services.AutonomousActionsService, log),
@@ -77,8 +87,10 @@ func NewHandlerManager(services *service.ServiceManager, log *logger.Logger) *Ha
 		AutoDocsHandler:        NewAutoDocsHandler(services.AutoDocsService, log),
 		ServicePlaybookHandler: NewServicePlaybookHandler(services.ServicePlaybookService, log),
 		BoardsHandler:          NewBoardsHandler(services.BoardsService, log),
-		OrganizationHandler:    NewOrganizationHandler(services.OrganizationService, log),
+		OrganizationHandler:    NewOrganizationHandler(services.OrganizationService, services.UserService, services.UserOrganizationService, log),
 		UserOrganizationHandler: NewUserOrganizationHandler(services.UserOrganizationService, log),
 		OrganizationUserHandler: NewOrganizationUserHandler(services.OrganizationUserService, log),
+		AuditHandler:           NewAuditHandler(services.AuditService, services.UserService, services.UserOrganizationService, log),
+		TenantMigrationHandler: NewTenantMigrationHandler(services.TenantMigrationService, log),
 	}
 }
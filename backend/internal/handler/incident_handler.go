@@ -0,0 +1,301 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/PlatifyX/platifyx-core/internal/service"
+	"github.com/PlatifyX/platifyx-core/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// maxSlackCommandBytes limits the size of a Slack slash command request
+const maxSlackCommandBytes = 64 << 10
+
+type IncidentHandler struct {
+	service *service.IncidentService
+	log     *logger.Logger
+}
+
+func NewIncidentHandler(svc *service.IncidentService, log *logger.Logger) *IncidentHandler {
+	return &IncidentHandler{
+		service: svc,
+		log:     log,
+	}
+}
+
+func (h *IncidentHandler) ListIncidents(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization UUID is required"})
+		return
+	}
+
+	var filter domain.IncidentFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Size < 1 || filter.Size > 100 {
+		filter.Size = 20
+	}
+
+	incidents, total, err := h.service.ListIncidents(orgUUID, filter)
+	if err != nil {
+		h.respondError(c, "Failed to list incidents", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"incidents": incidents,
+		"total":     total,
+		"page":      filter.Page,
+		"size":      filter.Size,
+	})
+}
+
+func (h *IncidentHandler) CreateIncident(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization UUID is required"})
+		return
+	}
+
+	var req domain.CreateIncidentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	incident, err := h.service.CreateIncident(orgUUID, req, h.actor(c))
+	if err != nil {
+		h.respondError(c, "Failed to create incident", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, incident)
+}
+
+func (h *IncidentHandler) GetIncident(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization UUID is required"})
+		return
+	}
+
+	incident, err := h.service.GetIncident(orgUUID, c.Param("id"))
+	if err != nil {
+		h.respondError(c, "Failed to get incident", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, incident)
+}
+
+func (h *IncidentHandler) UpdateIncident(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization UUID is required"})
+		return
+	}
+
+	var req domain.UpdateIncidentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	incident, err := h.service.UpdateIncident(orgUUID, c.Param("id"), req, h.actor(c))
+	if err != nil {
+		h.respondError(c, "Failed to update incident", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, incident)
+}
+
+func (h *IncidentHandler) AcknowledgeIncident(c *gin.Context) {
+	h.transition(c, "Failed to acknowledge incident", h.service.AcknowledgeIncident)
+}
+
+func (h *IncidentHandler) ResolveIncident(c *gin.Context) {
+	h.transition(c, "Failed to resolve incident", h.service.ResolveIncident)
+}
+
+func (h *IncidentHandler) ReopenIncident(c *gin.Context) {
+	h.transition(c, "Failed to reopen incident", h.service.ReopenIncident)
+}
+
+func (h *IncidentHandler) transition(
+	c *gin.Context,
+	message string,
+	fn func(organizationUUID, id string, req domain.IncidentTransitionRequest, actor string) (*domain.Incident, error),
+) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization UUID is required"})
+		return
+	}
+
+	// The body is optional
+	var req domain.IncidentTransitionRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	incident, err := fn(orgUUID, c.Param("id"), req, h.actor(c))
+	if err != nil {
+		h.respondError(c, message, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, incident)
+}
+
+func (h *IncidentHandler) AddNote(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization UUID is required"})
+		return
+	}
+
+	var req domain.IncidentNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := h.service.AddNote(orgUUID, c.Param("id"), req.Message, h.actor(c))
+	if err != nil {
+		h.respondError(c, "Failed to add incident note", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+// GetMetrics returns MTTR, MTTA and incident counts of the organization, or of a service or squad
+// (?scope=service&key=<name>)
+func (h *IncidentHandler) GetMetrics(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization UUID is required"})
+		return
+	}
+
+	scope := c.DefaultQuery("scope", domain.DORAScopeOrganization)
+	key := c.Query("key")
+	switch scope {
+	case domain.DORAScopeOrganization:
+	case domain.DORAScopeService, domain.DORAScopeSquad:
+		if key == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "key is required for scope " + scope})
+			return
+		}
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "scope must be organization, service or squad"})
+		return
+	}
+
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil || days < 1 || days > 365 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "days must be between 1 and 365"})
+		return
+	}
+
+	metrics, err := h.service.GetMetrics(orgUUID, scope, key, days)
+	if err != nil {
+		h.respondError(c, "Failed to calculate incident metrics", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, metrics)
+}
+
+// HandleSlackCommand serves the /incident slash command of the organization's Slack app. It is a
+// public route: requests are authenticated by their Slack signature.
+func (h *IncidentHandler) HandleSlackCommand(c *gin.Context) {
+	orgUUID := c.Param("organization")
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxSlackCommandBytes))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read request body"})
+		return
+	}
+
+	err = h.service.VerifySlackRequest(orgUUID, c.GetHeader("X-Slack-Request-Timestamp"), c.GetHeader("X-Slack-Signature"), body)
+	if err != nil {
+		var unauthorized *domain.UnauthorizedError
+		if errors.As(err, &unauthorized) {
+			h.log.Warnw("Rejected Slack command", "organizationUUID", orgUUID, "reason", err.Error())
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		h.log.Errorw("Failed to verify Slack command", "error", err, "organizationUUID", orgUUID)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify Slack command"})
+		return
+	}
+
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Slack command"})
+		return
+	}
+	cmd := domain.SlackCommand{
+		Command:   values.Get("command"),
+		Text:      values.Get("text"),
+		UserID:    values.Get("user_id"),
+		UserName:  values.Get("user_name"),
+		ChannelID: values.Get("channel_id"),
+	}
+
+	response, err := h.service.HandleSlackCommand(orgUUID, cmd)
+	if err != nil {
+		h.log.Errorw("Failed to run Slack command", "error", err, "organizationUUID", orgUUID, "text", cmd.Text)
+		// Slack shows the reply to the caller; it must be a 200
+		c.JSON(http.StatusOK, domain.SlackCommandResponse{ResponseType: "ephemeral", Text: "Failed to run the command, please try again"})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *IncidentHandler) actor(c *gin.Context) string {
+	if user, ok := c.Get("user"); ok {
+		if u, ok := user.(*domain.User); ok && u.Email != "" {
+			return u.Email
+		}
+	}
+	if userID := c.GetString("user_id"); userID != "" {
+		return userID
+	}
+	return "system"
+}
+
+func (h *IncidentHandler) respondError(c *gin.Context, message string, err error) {
+	var validation *domain.ValidationError
+	var notFound *domain.NotFoundError
+	var invalidState *domain.InvalidStateError
+
+	switch {
+	case errors.As(err, &validation):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.As(err, &notFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.As(err, &invalidState):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.log.Errorw(message, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
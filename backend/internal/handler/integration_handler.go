@@ -14,9 +14,7 @@ import (
 	"github.com/PlatifyX/platifyx-core/pkg/jira"
 	"github.com/PlatifyX/platifyx-core/pkg/logger"
 	"github.com/PlatifyX/platifyx-core/pkg/openai"
-	"github.com/PlatifyX/platifyx-core/pkg/slack"
 	"github.com/PlatifyX/platifyx-core/pkg/sonarqube"
-	"github.com/PlatifyX/platifyx-core/pkg/teams"
 	"github.com/gin-gonic/gin"
 )
 
@@ -70,6 +68,10 @@ func (h *IntegrationHandler) List(c *gin.Context) {
 		return
 	}
 
+	for i := range integrations {
+		h.service.MaskSecrets(&integrations[i])
+	}
+
 	result := gin.H{
 		"integrations": integrations,
 		"total":        len(integrations),
@@ -111,9 +113,23 @@ func (h *IntegrationHandler) GetByID(c *gin.Context) {
 		return
 	}
 
+	h.service.MaskSecrets(integration)
 	c.JSON(http.StatusOK, integration)
 }
 
+// ClientStats reports the hit/miss counters of the cached integration clients
+func (h *IntegrationHandler) ClientStats(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Organization UUID is required",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.service.ClientStats(orgUUID))
+}
+
 func (h *IntegrationHandler) Update(c *gin.Context) {
 	orgUUID := c.GetString("organization_uuid")
 	if orgUUID == "" {
@@ -231,6 +247,7 @@ func (h *IntegrationHandler) Create(c *gin.Context) {
 		h.log.Debugw("Cache invalidated", "key", cacheKey)
 	}
 
+	h.service.MaskSecrets(integration)
 	c.JSON(http.StatusCreated, gin.H{
 		"message":     "Integration created successfully",
 		"integration": integration,
@@ -621,8 +638,11 @@ func (h *IntegrationHandler) TestGrafana(c *gin.Context) {
 
 func (h *IntegrationHandler) TestGitHub(c *gin.Context) {
 	var input struct {
-		Token        string `json:"token" binding:"required"`
-		Organization string `json:"organization"`
+		Token          string `json:"token"`
+		Organization   string `json:"organization"`
+		AppID          int64  `json:"appId"`
+		PrivateKey     string `json:"privateKey"`
+		InstallationID int64  `json:"installationId"`
 	}
 
 	if err := c.ShouldBindJSON(&input); err != nil {
@@ -632,14 +652,61 @@ func (h *IntegrationHandler) TestGitHub(c *gin.Context) {
 		return
 	}
 
+	// Either a personal access token or a GitHub App (appId, privateKey and installationId)
+	usesApp := input.AppID != 0 || input.PrivateKey != "" || input.InstallationID != 0
+	if usesApp && (input.AppID == 0 || input.PrivateKey == "" || input.InstallationID == 0) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "appId, privateKey and installationId are required for GitHub App authentication",
+		})
+		return
+	}
+	if !usesApp && input.Token == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "token or GitHub App credentials are required",
+		})
+		return
+	}
+
 	// Create temporary GitHub config
 	config := domain.GitHubConfig{
-		Token:        input.Token,
-		Organization: input.Organization,
+		Token:          input.Token,
+		Organization:   input.Organization,
+		AppID:          input.AppID,
+		PrivateKey:     input.PrivateKey,
+		InstallationID: input.InstallationID,
+	}
+	githubService := service.NewGitHubService(config, h.log)
+
+	if usesApp {
+		// Validate the App JWT, the installation and minting an installation token
+		installation, err := githubService.GetInstallation()
+		if err == nil {
+			err = githubService.TestConnection()
+		}
+		if err != nil {
+			h.log.Errorw("Failed to test GitHub App connection",
+				"error", err,
+				"appId", input.AppID,
+				"installationId", input.InstallationID,
+			)
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "Failed to connect to GitHub. Please check the GitHub App credentials.",
+				"details": err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message":      "Connection successful",
+			"success":      true,
+			"authMode":     "app",
+			"installation": installation,
+			"user":         installation.Account,
+		})
+		return
 	}
 
 	// Test connection by creating a client and fetching user info
-	githubService := service.NewGitHubService(config, h.log)
 	user, err := githubService.GetAuthenticatedUser()
 	if err != nil {
 		h.log.Errorw("Failed to test GitHub connection",
@@ -653,6 +720,55 @@ func (h *IntegrationHandler) TestGitHub(c *gin.Context) {
 		return
 	}
 
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Connection successful",
+		"success":  true,
+		"authMode": "token",
+		"user":     user,
+	})
+}
+
+func (h *IntegrationHandler) TestGitLab(c *gin.Context) {
+	var input struct {
+		URL   string `json:"url"`
+		Token string `json:"token" binding:"required"`
+		Group string `json:"group"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// Create temporary GitLab config (an empty URL targets GitLab.com)
+	config := domain.GitLabConfig{
+		URL:   input.URL,
+		Token: input.Token,
+		Group: input.Group,
+	}
+	gitlabService := service.NewGitLabService(config, h.log)
+	defer gitlabService.CloseIdleConnections()
+
+	// Test connection by fetching the token user, and the group when one is configured
+	user, err := gitlabService.GetAuthenticatedUser()
+	if err == nil && input.Group != "" {
+		_, err = gitlabService.GetGroup(input.Group)
+	}
+	if err != nil {
+		h.log.Errorw("Failed to test GitLab connection",
+			"error", err,
+			"url", input.URL,
+			"group", input.Group,
+		)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "Failed to connect to GitLab. Please check your credentials.",
+			"details": err.Error(),
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Connection successful",
 		"success": true,
@@ -821,8 +937,7 @@ func (h *IntegrationHandler) TestSlack(c *gin.Context) {
 	}
 
 	// Test connection by sending a test message
-	client := slack.NewClient(config)
-	if err := client.TestConnection(); err != nil {
+	if err := service.NewSlackService(config, h.log).TestConnection(); err != nil {
 		h.log.Errorw("Failed to test Slack connection",
 			"error", err,
 		)
@@ -857,8 +972,7 @@ func (h *IntegrationHandler) TestTeams(c *gin.Context) {
 	}
 
 	// Test connection by sending a test message
-	client := teams.NewClient(config)
-	if err := client.TestConnection(); err != nil {
+	if err := service.NewTeamsService(config, h.log).TestConnection(); err != nil {
 		h.log.Errorw("Failed to test Teams connection",
 			"error", err,
 		)
@@ -21,39 +21,12 @@ func NewKubernetesHandler(integrationService *service.IntegrationService, log *l
 }
 
 func (h *KubernetesHandler) getService(organizationUUID string) (*service.KubernetesService, error) {
-	config, err := h.integrationService.GetKubernetesConfig(organizationUUID)
-	if err != nil {
-		return nil, err
-	}
-	if config == nil {
-		return nil, nil
-	}
-	return service.NewKubernetesService(*config, h.log)
+	return h.integrationService.GetKubernetesService(organizationUUID)
 }
 
 func (h *KubernetesHandler) getServiceByIntegration(organizationUUID, integrationName string) (*service.KubernetesService, error) {
-	// Se nenhuma integração foi especificada, usa a primeira disponível
-	if integrationName == "" {
-		return h.getService(organizationUUID)
-	}
-
-	// Busca todas as integrações kubernetes
-	configs, err := h.integrationService.GetAllKubernetesConfigs(organizationUUID)
-	if err != nil {
-		return nil, err
-	}
-
-	if len(configs) == 0 {
-		return nil, nil
-	}
-
-	// Busca pela integração específica
-	config, exists := configs[integrationName]
-	if !exists {
-		return nil, nil
-	}
-
-	return service.NewKubernetesService(*config, h.log)
+	// Sem integração especificada, usa a primeira disponível
+	return h.integrationService.GetKubernetesServiceByName(organizationUUID, integrationName)
 }
 
 func (h *KubernetesHandler) GetClusterInfo(c *gin.Context) {
@@ -1,13 +1,20 @@
 package handler
 
 import (
+	"errors"
+	"io"
 	"net/http"
 
+	"github.com/PlatifyX/platifyx-core/internal/domain"
 	"github.com/PlatifyX/platifyx-core/internal/service"
 	"github.com/PlatifyX/platifyx-core/pkg/logger"
 	"github.com/gin-gonic/gin"
+	"gopkg.in/yaml.v3"
 )
 
+// maxScorecardDefinitionBytes limits the size of a YAML scorecard definition
+const maxScorecardDefinitionBytes = 1 << 20
+
 type MaturityHandler struct {
 	service *service.MaturityService
 	log     *logger.Logger
@@ -21,13 +28,19 @@ func NewMaturityHandler(svc *service.MaturityService, log *logger.Logger) *Matur
 }
 
 func (h *MaturityHandler) GetServiceMetrics(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization UUID is required"})
+		return
+	}
+
 	serviceName := c.Query("service")
 	if serviceName == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "service parameter is required"})
 		return
 	}
 
-	metrics, err := h.service.CalculateServiceMetrics(serviceName)
+	metrics, err := h.service.CalculateServiceMetrics(orgUUID, serviceName)
 	if err != nil {
 		h.log.Errorw("Failed to calculate service metrics", "error", err, "service", serviceName)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -42,6 +55,12 @@ func (h *MaturityHandler) GetServiceMetrics(c *gin.Context) {
 }
 
 func (h *MaturityHandler) GetTeamScorecard(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization UUID is required"})
+		return
+	}
+
 	teamName := c.Param("team")
 	if teamName == "" {
 		teamName = c.Query("team")
@@ -51,23 +70,152 @@ func (h *MaturityHandler) GetTeamScorecard(c *gin.Context) {
 		return
 	}
 
-	scorecard, err := h.service.CalculateTeamMaturityScorecard(teamName)
+	scorecard, err := h.service.CalculateTeamMaturityScorecard(orgUUID, teamName)
 	if err != nil {
-		h.log.Errorw("Failed to calculate team scorecard", "error", err, "team", teamName)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.respondScorecardError(c, "Failed to calculate team scorecard", err)
 		return
 	}
 
 	c.JSON(http.StatusOK, scorecard)
 }
 
+// GetAllTeamScorecards returns the scorecard of every squad of the catalog, ranked
 func (h *MaturityHandler) GetAllTeamScorecards(c *gin.Context) {
-	// This would fetch all teams and calculate scorecards
-	// For now, return a placeholder
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization UUID is required"})
+		return
+	}
+
+	scorecards, err := h.service.GetAllTeamScorecards(orgUUID)
+	if err != nil {
+		h.respondScorecardError(c, "Failed to calculate team scorecards", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"teams": scorecards,
+		"total": len(scorecards),
+	})
+}
+
+// GetServiceScorecard returns the scorecard of a service, with the result of each check
+func (h *MaturityHandler) GetServiceScorecard(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization UUID is required"})
+		return
+	}
+
+	scorecard, err := h.service.GetServiceScorecard(orgUUID, c.Param("service"))
+	if err != nil {
+		h.respondScorecardError(c, "Failed to calculate service scorecard", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, scorecard)
+}
+
+// GetScorecardDefinition returns the scorecard checks of the organization, as JSON or, with
+// format=yaml, as YAML
+func (h *MaturityHandler) GetScorecardDefinition(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization UUID is required"})
+		return
+	}
+
+	definition, err := h.service.GetScorecardDefinition(orgUUID)
+	if err != nil {
+		h.respondScorecardError(c, "Failed to get scorecard definition", err)
+		return
+	}
+
+	if c.Query("format") == "yaml" {
+		out, err := yaml.Marshal(definition)
+		if err != nil {
+			h.respondScorecardError(c, "Failed to encode scorecard definition", err)
+			return
+		}
+		c.Data(http.StatusOK, "application/yaml; charset=utf-8", out)
+		return
+	}
+
+	c.JSON(http.StatusOK, definition)
+}
+
+// UpdateScorecardDefinition replaces the scorecard checks of the organization. The body is JSON,
+// or YAML when the content type is application/yaml (or x-yaml, text/yaml).
+func (h *MaturityHandler) UpdateScorecardDefinition(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization UUID is required"})
+		return
+	}
+
+	var definition domain.ScorecardDefinition
+	switch c.ContentType() {
+	case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxScorecardDefinitionBytes))
+		if err == nil {
+			err = yaml.Unmarshal(body, &definition)
+		}
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid YAML body: " + err.Error()})
+			return
+		}
+	default:
+		if err := c.ShouldBindJSON(&definition); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+	}
+
+	updatedBy := c.GetString("user_id")
+	if updatedBy == "" {
+		updatedBy = "system"
+	}
+
+	updated, err := h.service.UpdateScorecardDefinition(orgUUID, &definition, updatedBy)
+	if err != nil {
+		h.respondScorecardError(c, "Failed to update scorecard definition", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+// EvaluateScorecards re-evaluates the checks for every service now
+func (h *MaturityHandler) EvaluateScorecards(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization UUID is required"})
+		return
+	}
+
+	results, err := h.service.EvaluateScorecards(orgUUID)
+	if err != nil {
+		h.respondScorecardError(c, "Failed to evaluate scorecards", err)
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"teams": []interface{}{},
-		"total": 0,
-		"message": "Feature coming soon - will list all teams with their scorecards",
+		"services": results,
+		"total":    len(results),
 	})
 }
 
+func (h *MaturityHandler) respondScorecardError(c *gin.Context, message string, err error) {
+	var validation *domain.ValidationError
+	var notFound *domain.NotFoundError
+
+	switch {
+	case errors.As(err, &validation):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.As(err, &notFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		h.log.Errorw(message, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -2,12 +2,19 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
 
+	"github.com/PlatifyX/platifyx-core/internal/domain"
 	"github.com/PlatifyX/platifyx-core/internal/service"
 	"github.com/PlatifyX/platifyx-core/pkg/logger"
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	defaultDORAWindowDays = 30
+	maxDORAWindowDays     = 365
+)
+
 type MetricsHandler struct {
 	service *service.MetricsService
 	log     *logger.Logger
@@ -21,13 +28,124 @@ func NewMetricsHandler(svc *service.MetricsService, log *logger.Logger) *Metrics
 }
 
 func (h *MetricsHandler) GetDashboard(c *gin.Context) {
-	metrics := h.service.GetDashboardMetrics()
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Organization UUID is required",
+		})
+		return
+	}
+
+	metrics, err := h.service.GetDashboardMetrics(orgUUID)
+	if err != nil {
+		h.log.Errorw("Failed to get dashboard metrics", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get dashboard metrics",
+		})
+		return
+	}
 
 	c.JSON(http.StatusOK, metrics)
 }
 
+// GetDORA returns DORA metrics for the organization, a service or a squad.
+// Query params: scope (organization|service|squad), key (service or squad name), days (window size).
+// When scope is service or squad and no key is given, every service/squad is returned.
 func (h *MetricsHandler) GetDORA(c *gin.Context) {
-	dora := h.service.GetDORAMetrics()
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Organization UUID is required",
+		})
+		return
+	}
+
+	days, ok := h.parseDays(c)
+	if !ok {
+		return
+	}
+
+	scope := c.DefaultQuery("scope", domain.DORAScopeOrganization)
+	key := c.Query("key")
+
+	switch scope {
+	case domain.DORAScopeOrganization:
+	case domain.DORAScopeService, domain.DORAScopeSquad:
+		if key == "" {
+			breakdown, err := h.service.GetDORABreakdown(orgUUID, scope, days)
+			if err != nil {
+				h.log.Errorw("Failed to get DORA breakdown", "error", err, "scope", scope)
+				c.JSON(http.StatusInternalServerError, gin.H{
+					"error": "Failed to get DORA metrics",
+				})
+				return
+			}
+
+			c.JSON(http.StatusOK, gin.H{
+				"scope": scope,
+				"days":  days,
+				"items": breakdown,
+				"total": len(breakdown),
+			})
+			return
+		}
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid scope, expected organization, service or squad",
+		})
+		return
+	}
+
+	metrics, err := h.service.GetDORAMetrics(orgUUID, scope, key, days)
+	if err != nil {
+		h.log.Errorw("Failed to get DORA metrics", "error", err, "scope", scope, "key", key)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get DORA metrics",
+		})
+		return
+	}
 
-	c.JSON(http.StatusOK, dora)
+	c.JSON(http.StatusOK, metrics)
+}
+
+// RefreshDORASnapshots re-collects the snapshots of the requested window from the integrations
+func (h *MetricsHandler) RefreshDORASnapshots(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Organization UUID is required",
+		})
+		return
+	}
+
+	days, ok := h.parseDays(c)
+	if !ok {
+		return
+	}
+
+	metrics, err := h.service.RefreshDORAWindow(orgUUID, days)
+	if err != nil {
+		h.log.Errorw("Failed to refresh DORA snapshots", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to refresh DORA snapshots",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, metrics)
+}
+
+func (h *MetricsHandler) parseDays(c *gin.Context) (int, bool) {
+	days := defaultDORAWindowDays
+	if daysStr := c.Query("days"); daysStr != "" {
+		parsed, err := strconv.Atoi(daysStr)
+		if err != nil || parsed < 1 || parsed > maxDORAWindowDays {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid days parameter, expected a value between 1 and 365",
+			})
+			return 0, false
+		}
+		days = parsed
+	}
+	return days, true
 }
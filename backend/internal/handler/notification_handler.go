@@ -0,0 +1,342 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/PlatifyX/platifyx-core/internal/service"
+	"github.com/PlatifyX/platifyx-core/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	service *service.NotificationService
+	log     *logger.Logger
+}
+
+func NewNotificationHandler(svc *service.NotificationService, log *logger.Logger) *NotificationHandler {
+	return &NotificationHandler{
+		service: svc,
+		log:     log,
+	}
+}
+
+// ListChannels returns the notification channels of the organization, with their secrets masked
+func (h *NotificationHandler) ListChannels(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization UUID is required"})
+		return
+	}
+
+	channels, err := h.service.ListChannels(orgUUID)
+	if err != nil {
+		h.respondError(c, "Failed to list notification channels", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"channels": channels,
+		"total":    len(channels),
+	})
+}
+
+func (h *NotificationHandler) CreateChannel(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization UUID is required"})
+		return
+	}
+
+	var req domain.NotificationChannelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	channel, err := h.service.CreateChannel(orgUUID, req, h.actor(c))
+	if err != nil {
+		h.respondError(c, "Failed to create notification channel", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, channel)
+}
+
+func (h *NotificationHandler) UpdateChannel(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization UUID is required"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid channel ID"})
+		return
+	}
+
+	var req domain.NotificationChannelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	channel, err := h.service.UpdateChannel(orgUUID, id, req)
+	if err != nil {
+		h.respondError(c, "Failed to update notification channel", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, channel)
+}
+
+func (h *NotificationHandler) DeleteChannel(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization UUID is required"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid channel ID"})
+		return
+	}
+
+	if err := h.service.DeleteChannel(orgUUID, id); err != nil {
+		h.respondError(c, "Failed to delete notification channel", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Notification channel deleted"})
+}
+
+// TestChannel sends a test message through a channel right away, bypassing rules and the queue
+func (h *NotificationHandler) TestChannel(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization UUID is required"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid channel ID"})
+		return
+	}
+
+	if err := h.service.TestChannel(orgUUID, id); err != nil {
+		if errors.Is(err, service.ErrNotificationSendFailed) {
+			c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to send test notification", "details": err.Error()})
+			return
+		}
+		h.respondError(c, "Failed to test notification channel", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Test notification sent"})
+}
+
+func (h *NotificationHandler) ListRules(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization UUID is required"})
+		return
+	}
+
+	rules, err := h.service.ListNotificationRules(orgUUID)
+	if err != nil {
+		h.respondError(c, "Failed to list notification rules", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rules": rules,
+		"total": len(rules),
+	})
+}
+
+func (h *NotificationHandler) CreateRule(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization UUID is required"})
+		return
+	}
+
+	var req domain.NotificationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule, err := h.service.CreateNotificationRule(orgUUID, req, h.actor(c))
+	if err != nil {
+		h.respondError(c, "Failed to create notification rule", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+func (h *NotificationHandler) UpdateRule(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization UUID is required"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rule ID"})
+		return
+	}
+
+	var req domain.NotificationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule, err := h.service.UpdateNotificationRule(orgUUID, id, req)
+	if err != nil {
+		h.respondError(c, "Failed to update notification rule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+func (h *NotificationHandler) DeleteRule(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization UUID is required"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rule ID"})
+		return
+	}
+
+	if err := h.service.DeleteNotificationRule(orgUUID, id); err != nil {
+		h.respondError(c, "Failed to delete notification rule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Notification rule deleted"})
+}
+
+// ListDeliveries returns the delivery log of the organization (?status=failed&eventType=&channelId=)
+func (h *NotificationHandler) ListDeliveries(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization UUID is required"})
+		return
+	}
+
+	var filter domain.NotificationDeliveryFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Size < 1 || filter.Size > 100 {
+		filter.Size = 20
+	}
+
+	deliveries, total, err := h.service.ListDeliveries(orgUUID, filter)
+	if err != nil {
+		h.respondError(c, "Failed to list notification deliveries", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"deliveries": deliveries,
+		"total":      total,
+		"page":       filter.Page,
+		"size":       filter.Size,
+	})
+}
+
+// RetryDelivery queues a failed delivery again
+func (h *NotificationHandler) RetryDelivery(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization UUID is required"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid delivery ID"})
+		return
+	}
+
+	delivery, err := h.service.RetryDelivery(orgUUID, id)
+	if err != nil {
+		h.respondError(c, "Failed to retry notification delivery", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, delivery)
+}
+
+// PublishEvent routes an event sent by an external system (a pipeline, a script) through the
+// notification rules of the organization
+func (h *NotificationHandler) PublishEvent(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization UUID is required"})
+		return
+	}
+
+	var event domain.NotificationEvent
+	if err := c.ShouldBindJSON(&event); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.service.Publish(orgUUID, event)
+	if err != nil {
+		h.respondError(c, "Failed to publish notification event", err)
+		return
+	}
+
+	status := http.StatusOK
+	if result.Queued > 0 {
+		status = http.StatusAccepted
+	}
+	c.JSON(status, result)
+}
+
+func (h *NotificationHandler) actor(c *gin.Context) string {
+	if userID := c.GetString("user_id"); userID != "" {
+		return userID
+	}
+	return "system"
+}
+
+func (h *NotificationHandler) respondError(c *gin.Context, message string, err error) {
+	var validation *domain.ValidationError
+	var notFound *domain.NotFoundError
+	var conflict *domain.ConflictError
+	var invalidState *domain.InvalidStateError
+
+	switch {
+	case errors.As(err, &validation):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.As(err, &notFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.As(err, &conflict), errors.As(err, &invalidState):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.log.Errorw(message, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"context"
 	"net/http"
+	"time"
 
 	"github.com/PlatifyX/platifyx-core/internal/domain"
 	"github.com/PlatifyX/platifyx-core/internal/service"
@@ -10,19 +12,24 @@ import (
 )
 
 type OrganizationHandler struct {
-	service *service.OrganizationService
-	log     *logger.Logger
+	service        *service.OrganizationService
+	userService    *service.UserService
+	userOrgService *service.UserOrganizationService
+	log            *logger.Logger
 }
 
-func NewOrganizationHandler(svc *service.OrganizationService, log *logger.Logger) *OrganizationHandler {
+func NewOrganizationHandler(svc *service.OrganizationService, userService *service.UserService, userOrgService *service.UserOrganizationService, log *logger.Logger) *OrganizationHandler {
 	return &OrganizationHandler{
-		service: svc,
-		log:     log,
+		service:        svc,
+		userService:    userService,
+		userOrgService: userOrgService,
+		log:            log,
 	}
 }
 
+// List retorna as organizações das quais o usuário é membro; o admin da plataforma vê todas
 func (h *OrganizationHandler) List(c *gin.Context) {
-	organizations, err := h.service.GetAll()
+	organizations, err := h.visibleOrganizations(c.GetString("user_id"))
 	if err != nil {
 		h.log.Errorw("Failed to list organizations", "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -37,6 +44,29 @@ func (h *OrganizationHandler) List(c *gin.Context) {
 	})
 }
 
+func (h *OrganizationHandler) visibleOrganizations(userID string) ([]domain.Organization, error) {
+	permissions, err := h.userService.GetUserPermissions(userID)
+	if err != nil {
+		return nil, err
+	}
+	if permissions.IsPlatformAdmin {
+		return h.service.GetAll()
+	}
+
+	memberships, err := h.userOrgService.GetUserOrganizations(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	organizations := make([]domain.Organization, 0, len(memberships))
+	for _, membership := range memberships {
+		if membership.Organization != nil {
+			organizations = append(organizations, *membership.Organization)
+		}
+	}
+	return organizations, nil
+}
+
 func (h *OrganizationHandler) GetByUUID(c *gin.Context) {
 	uuid := c.Param("uuid")
 
@@ -52,6 +82,31 @@ func (h *OrganizationHandler) GetByUUID(c *gin.Context) {
 	c.JSON(http.StatusOK, org)
 }
 
+// NodeDatabaseHealth verifica os pools de escrita e leitura do banco da organização.
+// Expõe estatísticas e erros dos pools, por isso exige organizations:update na organização.
+func (h *OrganizationHandler) NodeDatabaseHealth(c *gin.Context) {
+	uuid := c.Param("uuid")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	health, err := h.service.NodeDatabaseHealth(ctx, uuid)
+	if err != nil {
+		// The organization was already resolved by OrganizationPathMiddleware: this is a pool failure
+		h.log.Errorw("Failed to check organization node database", "error", err, "uuid", uuid)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to check organization database",
+		})
+		return
+	}
+
+	status := http.StatusOK
+	if !health.Healthy {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, health)
+}
+
 func (h *OrganizationHandler) Create(c *gin.Context) {
 	var req domain.CreateOrganizationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
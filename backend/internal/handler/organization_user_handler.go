@@ -190,15 +190,8 @@ func (h *OrganizationUserHandler) UpdateUser(c *gin.Context) {
 		return
 	}
 
-	updatedUser, err := h.service.GetUserByID(organization, userID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to fetch updated user",
-		})
-		return
-	}
-
-	c.JSON(http.StatusOK, updatedUser)
+	// Responde com o usuário atualizado em vez de relê-lo, pois a leitura pode ir para a réplica
+	c.JSON(http.StatusOK, existingUser)
 }
 
 func (h *OrganizationUserHandler) DeleteUser(c *gin.Context) {
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"log"
 	"net/http"
 	"regexp"
@@ -15,16 +16,18 @@ import (
 
 // SettingsHandler agrupa todos os handlers de configurações (users, roles, teams, audit, sso)
 type SettingsHandler struct {
-	userService *service.UserService
-	userRepo    *repository.UserRepository
-	roleRepo    *repository.RoleRepository
-	teamRepo    *repository.TeamRepository
-	auditRepo   *repository.AuditRepository
-	ssoRepo     *repository.SSORepository
+	userService    *service.UserService
+	userOrgService *service.UserOrganizationService
+	userRepo       *repository.UserRepository
+	roleRepo       *repository.RoleRepository
+	teamRepo       *repository.TeamRepository
+	auditRepo      *repository.AuditRepository
+	ssoRepo        *repository.SSORepository
 }
 
 func NewSettingsHandler(
 	userService *service.UserService,
+	userOrgService *service.UserOrganizationService,
 	userRepo *repository.UserRepository,
 	roleRepo *repository.RoleRepository,
 	teamRepo *repository.TeamRepository,
@@ -32,12 +35,13 @@ func NewSettingsHandler(
 	ssoRepo *repository.SSORepository,
 ) *SettingsHandler {
 	return &SettingsHandler{
-		userService: userService,
-		userRepo:    userRepo,
-		roleRepo:    roleRepo,
-		teamRepo:    teamRepo,
-		auditRepo:   auditRepo,
-		ssoRepo:     ssoRepo,
+		userService:    userService,
+		userOrgService: userOrgService,
+		userRepo:       userRepo,
+		roleRepo:       roleRepo,
+		teamRepo:       teamRepo,
+		auditRepo:      auditRepo,
+		ssoRepo:        ssoRepo,
 	}
 }
 
@@ -235,8 +239,18 @@ func (h *SettingsHandler) GetUserStats(c *gin.Context) {
 
 // ============= ROLES =============
 
+// Com uma organização no contexto (X-Organization-UUID), os roles são os globais mais os
+// da organização, e só os roles da própria organização podem ser alterados.
+// Sem organização, apenas os roles globais são visíveis e editáveis.
+
 func (h *SettingsHandler) ListRoles(c *gin.Context) {
-	roles, err := h.roleRepo.List()
+	var roles []domain.Role
+	var err error
+	if orgUUID := c.GetString("organization_uuid"); orgUUID != "" {
+		roles, err = h.roleRepo.ListForOrganization(orgUUID)
+	} else {
+		roles, err = h.roleRepo.List()
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -253,7 +267,7 @@ func (h *SettingsHandler) ListRoles(c *gin.Context) {
 func (h *SettingsHandler) GetRole(c *gin.Context) {
 	id := c.Param("id")
 	role, err := h.roleRepo.GetByID(id)
-	if err != nil {
+	if err != nil || !h.roleVisible(c, role) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Role not found"})
 		return
 	}
@@ -287,6 +301,10 @@ func (h *SettingsHandler) CreateRole(c *gin.Context) {
 	// Normalizar nome interno (snake_case)
 	req.Name = strings.ToLower(strings.ReplaceAll(req.Name, " ", "_"))
 
+	if h.rejectReservedRoleName(c, req.Name) {
+		return
+	}
+
 	_, actorEmail := h.getActor(c)
 	log.Printf("[INFO] CreateRole: Actor=%s creating role=%s", actorEmail, req.Name)
 
@@ -296,6 +314,9 @@ func (h *SettingsHandler) CreateRole(c *gin.Context) {
 		Description: req.Description,
 		IsSystem:    false,
 	}
+	if orgUUID := c.GetString("organization_uuid"); orgUUID != "" {
+		role.OrganizationUUID = &orgUUID
+	}
 
 	if err := h.roleRepo.Create(role); err != nil {
 		log.Printf("[ERROR] CreateRole: %v", err)
@@ -323,11 +344,21 @@ func (h *SettingsHandler) UpdateRole(c *gin.Context) {
 	}
 
 	role, err := h.roleRepo.GetByID(id)
-	if err != nil {
+	if err != nil || !h.roleVisible(c, role) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Role not found"})
 		return
 	}
 
+	if !h.roleEditable(c, role) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Role não pertence a esta organização"})
+		return
+	}
+
+	// Roles criados antes da reserva com o nome de um role de sistema não podem mais ser alterados
+	if !role.IsSystem && h.rejectReservedRoleName(c, role.Name) {
+		return
+	}
+
 	if req.DisplayName != nil {
 		role.DisplayName = *req.DisplayName
 	}
@@ -357,7 +388,7 @@ func (h *SettingsHandler) DeleteRole(c *gin.Context) {
 
 	// Verificar se é role de sistema
 	role, err := h.roleRepo.GetByID(id)
-	if err != nil {
+	if err != nil || !h.roleVisible(c, role) {
 		log.Printf("[ERROR] DeleteRole: Role not found: %v", err)
 		c.JSON(http.StatusNotFound, gin.H{"error": "Role não encontrado"})
 		return
@@ -368,6 +399,11 @@ func (h *SettingsHandler) DeleteRole(c *gin.Context) {
 		return
 	}
 
+	if !h.roleEditable(c, role) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Role não pertence a esta organização"})
+		return
+	}
+
 	_, actorEmail := h.getActor(c)
 	log.Printf("[INFO] DeleteRole: Actor=%s deleting role=%s (id=%s)", actorEmail, role.Name, id)
 
@@ -381,6 +417,109 @@ func (h *SettingsHandler) DeleteRole(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Role deletado com sucesso"})
 }
 
+// rejectReservedRoleName responde com erro quando o nome pertence a um role de sistema.
+// Retorna true quando a requisição já foi respondida.
+func (h *SettingsHandler) rejectReservedRoleName(c *gin.Context, name string) bool {
+	reserved, err := h.roleRepo.IsReservedName(name)
+	if err != nil {
+		log.Printf("[ERROR] Role name check failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao validar nome do role"})
+		return true
+	}
+	if reserved {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Nome reservado para um role de sistema"})
+		return true
+	}
+	return false
+}
+
+// roleVisible indica se o role é global ou pertence à organização do contexto
+func (h *SettingsHandler) roleVisible(c *gin.Context, role *domain.Role) bool {
+	return role.OrganizationUUID == nil || *role.OrganizationUUID == c.GetString("organization_uuid")
+}
+
+// roleEditable indica se o role pertence ao escopo do contexto: a organização, ou o escopo global sem organização
+func (h *SettingsHandler) roleEditable(c *gin.Context, role *domain.Role) bool {
+	orgUUID := c.GetString("organization_uuid")
+	if role.OrganizationUUID == nil {
+		return orgUUID == ""
+	}
+	return *role.OrganizationUUID == orgUUID
+}
+
+// ============= ROLE BINDINGS =============
+
+func (h *SettingsHandler) ListRoleBindings(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organização é obrigatória (header X-Organization-UUID)"})
+		return
+	}
+
+	bindings, err := h.userOrgService.ListRoleBindings(orgUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, domain.RoleBindingListResponse{Bindings: bindings, Total: len(bindings)})
+}
+
+func (h *SettingsHandler) CreateRoleBinding(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organização é obrigatória (header X-Organization-UUID)"})
+		return
+	}
+
+	var req domain.CreateRoleBindingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	_, actorEmail := h.getActor(c)
+	log.Printf("[INFO] CreateRoleBinding: Actor=%s binding role=%s to user=%s in org=%s", actorEmail, c.Param("id"), req.UserID, orgUUID)
+
+	binding, err := h.userOrgService.BindRole(orgUUID, c.Param("id"), req.UserID, actorEmail)
+	if err != nil {
+		h.respondRoleBindingError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, binding)
+}
+
+func (h *SettingsHandler) DeleteRoleBinding(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organização é obrigatória (header X-Organization-UUID)"})
+		return
+	}
+
+	_, actorEmail := h.getActor(c)
+	log.Printf("[INFO] DeleteRoleBinding: Actor=%s unbinding role=%s from user=%s in org=%s", actorEmail, c.Param("id"), c.Param("userId"), orgUUID)
+
+	if err := h.userOrgService.UnbindRole(orgUUID, c.Param("id"), c.Param("userId")); err != nil {
+		h.respondRoleBindingError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Vínculo removido com sucesso"})
+}
+
+func (h *SettingsHandler) respondRoleBindingError(c *gin.Context, err error) {
+	var notFound *domain.NotFoundError
+	var validation *domain.ValidationError
+
+	switch {
+	case errors.As(err, &notFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.As(err, &validation):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		log.Printf("[ERROR] RoleBinding: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
 func (h *SettingsHandler) ListPermissions(c *gin.Context) {
 	permissions, err := h.roleRepo.ListPermissions()
 	if err != nil {
@@ -540,6 +679,10 @@ func (h *SettingsHandler) ListAuditLogs(c *gin.Context) {
 		return
 	}
 
+	if !auditScopeAllowed(c, h.userService, h.userOrgService, filter.OrganizationUUID) {
+		return
+	}
+
 	logs, total, err := h.auditRepo.List(filter)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
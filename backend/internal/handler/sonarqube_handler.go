@@ -27,14 +27,7 @@ func NewSonarQubeHandler(integrationService *service.IntegrationService, cache *
 }
 
 func (h *SonarQubeHandler) getService(organizationUUID string) (*service.SonarQubeService, error) {
-	config, err := h.integrationService.GetSonarQubeConfig(organizationUUID)
-	if err != nil {
-		return nil, err
-	}
-	if config == nil {
-		return nil, nil
-	}
-	return service.NewSonarQubeService(*config, h.log), nil
+	return h.integrationService.GetSonarQubeService(organizationUUID)
 }
 
 func (h *SonarQubeHandler) ListProjects(c *gin.Context) {
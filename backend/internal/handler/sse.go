@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// wantsEventStream tells whether the client asked for a Server-Sent Events response,
+// with ?stream=true or an Accept: text/event-stream header
+func wantsEventStream(c *gin.Context) bool {
+	return c.Query("stream") == "true" || strings.Contains(c.GetHeader("Accept"), "text/event-stream")
+}
+
+// streamEvents answers the request with Server-Sent Events: a "delta" event per partial token
+// sent by run, then a "done" event with its result, or an "error" event. run receives the
+// request context, which is cancelled when the client disconnects.
+func streamEvents(c *gin.Context, log *logger.Logger, run func(ctx context.Context, onDelta func(delta string) error) (interface{}, error)) {
+	// The stream outlives the server write timeout
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		log.Warnw("Failed to clear write deadline for event stream", "error", err)
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	ctx := c.Request.Context()
+	onDelta := func(delta string) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		c.SSEvent("delta", gin.H{"content": delta})
+		c.Writer.Flush()
+		return nil
+	}
+
+	result, err := run(ctx, onDelta)
+	if err != nil {
+		if errors.Is(err, context.Canceled) || ctx.Err() != nil {
+			log.Infow("Event stream cancelled by client", "path", c.FullPath())
+			return
+		}
+		log.Errorw("Event stream failed", "error", err, "path", c.FullPath())
+		c.SSEvent("error", gin.H{"error": err.Error()})
+		c.Writer.Flush()
+		return
+	}
+
+	c.SSEvent("done", result)
+	c.Writer.Flush()
+}
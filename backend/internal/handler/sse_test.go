@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/pkg/logger"
+	"github.com/PlatifyX/platifyx-core/pkg/sse"
+	"github.com/gin-gonic/gin"
+)
+
+type streamRun func(ctx context.Context, onDelta func(delta string) error) (interface{}, error)
+
+// streamServer answers GET /stream with streamEvents over run
+func streamServer(t *testing.T, run streamRun) *httptest.Server {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	log := logger.NewLogger("development")
+
+	router := gin.New()
+	router.GET("/stream", func(c *gin.Context) {
+		streamEvents(c, log, run)
+	})
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestStreamEvents(t *testing.T) {
+	tests := []struct {
+		name string
+		run  streamRun
+		want []sse.Event
+	}{
+		{
+			name: "deltas then done",
+			run: func(ctx context.Context, onDelta func(string) error) (interface{}, error) {
+				for _, delta := range []string{"Hel", "lo\nworld"} {
+					if err := onDelta(delta); err != nil {
+						return nil, err
+					}
+				}
+				return gin.H{"content": "Hello\nworld"}, nil
+			},
+			want: []sse.Event{
+				{Name: "delta", Data: `{"content":"Hel"}`},
+				{Name: "delta", Data: `{"content":"lo\nworld"}`},
+				{Name: "done", Data: `{"content":"Hello\nworld"}`},
+			},
+		},
+		{
+			name: "failure after a delta",
+			run: func(ctx context.Context, onDelta func(string) error) (interface{}, error) {
+				onDelta("Hel")
+				return nil, errors.New("provider unavailable")
+			},
+			want: []sse.Event{
+				{Name: "delta", Data: `{"content":"Hel"}`},
+				{Name: "error", Data: `{"error":"provider unavailable"}`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := streamServer(t, tt.run)
+
+			resp, err := http.Get(srv.URL + "/stream")
+			if err != nil {
+				t.Fatalf("GET /stream: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+				t.Fatalf("answered %d with %q, want 200 with text/event-stream", resp.StatusCode, resp.Header.Get("Content-Type"))
+			}
+			if resp.Header.Get("X-Accel-Buffering") != "no" || resp.Header.Get("Cache-Control") != "no-cache" {
+				t.Errorf("stream may be buffered: headers are %v", resp.Header)
+			}
+
+			var events []sse.Event
+			reader := sse.NewReader(resp.Body)
+			for {
+				event, err := reader.Next()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("reading the stream: %v", err)
+				}
+				events = append(events, *event)
+			}
+			if !reflect.DeepEqual(events, tt.want) {
+				t.Errorf("events are %+v, want %+v", events, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamEventsStopsWhenClientDisconnects(t *testing.T) {
+	cancelled := make(chan error, 1)
+	srv := streamServer(t, func(ctx context.Context, onDelta func(string) error) (interface{}, error) {
+		if err := onDelta("first"); err != nil {
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(5 * time.Second):
+			cancelled <- errors.New("request context was not cancelled")
+			return nil, nil
+		}
+		// Deltas produced after the disconnect are refused
+		cancelled <- onDelta("late")
+		return nil, ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/stream", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET /stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	event, err := sse.NewReader(resp.Body).Next()
+	if err != nil || event.Name != "delta" {
+		t.Fatalf("first event is %+v (%v), want a delta", event, err)
+	}
+	cancel()
+
+	select {
+	case err := <-cancelled:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("delta after the disconnect returned %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("run did not return after the client disconnected")
+	}
+}
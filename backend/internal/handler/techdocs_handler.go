@@ -1,7 +1,10 @@
 package handler
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/PlatifyX/platifyx-core/internal/domain"
@@ -23,7 +26,7 @@ func NewTechDocsHandler(svc *service.TechDocsService, log *logger.Logger) *TechD
 }
 
 func (h *TechDocsHandler) GetTree(c *gin.Context) {
-	tree, err := h.service.GetDocumentTree()
+	tree, err := h.service.GetDocumentTree(c.GetString("organization_uuid"))
 	if err != nil {
 		h.log.Errorw("Failed to get document tree", "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -46,7 +49,7 @@ func (h *TechDocsHandler) GetDocument(c *gin.Context) {
 		return
 	}
 
-	doc, err := h.service.GetDocument(path)
+	doc, err := h.service.GetDocument(c.GetString("organization_uuid"), path)
 	if err != nil {
 		h.log.Errorw("Failed to get document", "error", err, "path", path)
 		if err.Error() == "document not found" {
@@ -77,7 +80,7 @@ func (h *TechDocsHandler) SaveDocument(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.SaveDocument(input.Path, input.Content); err != nil {
+	if err := h.service.SaveDocument(c.GetString("organization_uuid"), input.Path, input.Content, techDocAuthor(c)); err != nil {
 		h.log.Errorw("Failed to save document", "error", err, "path", input.Path)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": err.Error(),
@@ -99,7 +102,7 @@ func (h *TechDocsHandler) DeleteDocument(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.DeleteDocument(path); err != nil {
+	if err := h.service.DeleteDocument(c.GetString("organization_uuid"), path, techDocAuthor(c)); err != nil {
 		h.log.Errorw("Failed to delete document", "error", err, "path", path)
 		if err.Error() == "document not found" {
 			c.JSON(http.StatusNotFound, gin.H{
@@ -118,6 +121,128 @@ func (h *TechDocsHandler) DeleteDocument(c *gin.Context) {
 	})
 }
 
+// GetDocumentHistory lists the revisions of a document
+func (h *TechDocsHandler) GetDocumentHistory(c *gin.Context) {
+	path := c.Query("path")
+	if path == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "path parameter is required",
+		})
+		return
+	}
+
+	limit := 0
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "limit must be a positive integer",
+			})
+			return
+		}
+		limit = parsed
+	}
+
+	history, err := h.service.GetDocumentHistory(c.GetString("organization_uuid"), path, limit)
+	if err != nil {
+		h.respondStoreError(c, "Failed to get document history", path, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"path":    path,
+		"history": history,
+	})
+}
+
+// GetDocumentRevision returns a document as it was at a revision
+func (h *TechDocsHandler) GetDocumentRevision(c *gin.Context) {
+	path := c.Query("path")
+	revision := c.Query("revision")
+	if path == "" || revision == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "path and revision parameters are required",
+		})
+		return
+	}
+
+	doc, err := h.service.GetDocumentRevision(c.GetString("organization_uuid"), path, revision)
+	if err != nil {
+		h.respondStoreError(c, "Failed to get document revision", path, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, doc)
+}
+
+// DiffDocument returns the changes of a document between two revisions
+func (h *TechDocsHandler) DiffDocument(c *gin.Context) {
+	path := c.Query("path")
+	from := c.Query("from")
+	if path == "" || from == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "path and from parameters are required",
+		})
+		return
+	}
+
+	diff, err := h.service.DiffDocument(c.GetString("organization_uuid"), path, from, c.Query("to"))
+	if err != nil {
+		h.respondStoreError(c, "Failed to diff document", path, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// RestoreDocument saves a previous revision of a document as its latest version
+func (h *TechDocsHandler) RestoreDocument(c *gin.Context) {
+	var req domain.TechDocRestoreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	doc, err := h.service.RestoreDocument(c.GetString("organization_uuid"), req.Path, req.Revision, techDocAuthor(c))
+	if err != nil {
+		h.respondStoreError(c, "Failed to restore document", req.Path, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, doc)
+}
+
+func (h *TechDocsHandler) respondStoreError(c *gin.Context, message, path string, err error) {
+	switch {
+	case errors.Is(err, service.ErrTechDocRevisionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrTechDocsHistoryUnavailable):
+		c.JSON(http.StatusNotImplemented, gin.H{"error": err.Error()})
+	case err.Error() == "invalid path":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		h.log.Errorw(message, "error", err, "path", path)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// techDocAuthor is the authenticated user, recorded as the author of the documents they change
+func techDocAuthor(c *gin.Context) domain.TechDocAuthor {
+	author := domain.TechDocAuthor{}
+	if user, ok := c.Get("user"); ok {
+		if u, ok := user.(*domain.User); ok {
+			author.Name = u.Name
+			author.Email = u.Email
+		}
+	}
+	if author.Name == "" {
+		author.Name = author.Email
+	}
+	return author
+}
+
 func (h *TechDocsHandler) CreateFolder(c *gin.Context) {
 	var input struct {
 		Path string `json:"path" binding:"required"`
@@ -130,7 +255,7 @@ func (h *TechDocsHandler) CreateFolder(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.CreateFolder(input.Path); err != nil {
+	if err := h.service.CreateFolder(c.GetString("organization_uuid"), input.Path); err != nil {
 		h.log.Errorw("Failed to create folder", "error", err, "path", input.Path)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": err.Error(),
@@ -146,7 +271,7 @@ func (h *TechDocsHandler) CreateFolder(c *gin.Context) {
 func (h *TechDocsHandler) ListDocuments(c *gin.Context) {
 	path := c.DefaultQuery("path", "")
 
-	docs, err := h.service.ListDocuments(path)
+	docs, err := h.service.ListDocuments(c.GetString("organization_uuid"), path)
 	if err != nil {
 		h.log.Errorw("Failed to list documents", "error", err, "path", path)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -179,6 +304,8 @@ func (h *TechDocsHandler) GenerateDocumentation(c *gin.Context) {
 		})
 		return
 	}
+	req.UserID = c.GetString("user_id")
+	req.Author = techDocAuthor(c)
 
 	progress, err := h.service.GenerateDocumentation(orgUUID, req)
 	if err != nil {
@@ -203,7 +330,7 @@ func (h *TechDocsHandler) GetProgress(c *gin.Context) {
 		return
 	}
 
-	progress, err := h.service.GetDocumentationProgress(progressID)
+	progress, err := h.service.GetDocumentationProgress(c.GetString("organization_uuid"), progressID)
 	if err != nil {
 		h.log.Errorw("Failed to get documentation progress", "error", err, "progressId", progressID)
 		status := http.StatusInternalServerError
@@ -239,11 +366,12 @@ func (h *TechDocsHandler) ImproveDocumentation(c *gin.Context) {
 		})
 		return
 	}
+	req.UserID = c.GetString("user_id")
 
 	response, err := h.service.ImproveDocumentation(orgUUID, req)
 	if err != nil {
 		h.log.Errorw("Failed to improve documentation", "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(aiErrorStatus(err), gin.H{
 			"error": err.Error(),
 		})
 		return
@@ -270,11 +398,20 @@ func (h *TechDocsHandler) ChatAboutDocumentation(c *gin.Context) {
 		})
 		return
 	}
+	req.UserID = c.GetString("user_id")
+
+	// With ?stream=true (or Accept: text/event-stream) the answer is streamed as it is generated
+	if wantsEventStream(c) {
+		streamEvents(c, h.log, func(ctx context.Context, onDelta func(delta string) error) (interface{}, error) {
+			return h.service.ChatAboutDocumentationStream(ctx, orgUUID, req, onDelta)
+		})
+		return
+	}
 
 	response, err := h.service.ChatAboutDocumentation(orgUUID, req)
 	if err != nil {
 		h.log.Errorw("Failed to process chat", "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(aiErrorStatus(err), gin.H{
 			"error": err.Error(),
 		})
 		return
@@ -283,6 +420,55 @@ func (h *TechDocsHandler) ChatAboutDocumentation(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// GetStoreStatus returns the state of the document store: a diverged git store no longer pushes
+func (h *TechDocsHandler) GetStoreStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, h.service.GetStoreStatus())
+}
+
+// GetIndexStatus returns the state of the retrieval index used by the chat
+func (h *TechDocsHandler) GetIndexStatus(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Organization UUID is required",
+		})
+		return
+	}
+
+	status, err := h.service.GetIndexStatus(orgUUID)
+	if err != nil {
+		h.log.Errorw("Failed to get TechDocs index status", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// SyncIndex reindexes the documents that changed since they were embedded
+func (h *TechDocsHandler) SyncIndex(c *gin.Context) {
+	orgUUID := c.GetString("organization_uuid")
+	if orgUUID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Organization UUID is required",
+		})
+		return
+	}
+
+	result, err := h.service.SyncIndex(c.Request.Context(), orgUUID)
+	if err != nil {
+		h.log.Errorw("Failed to sync TechDocs index", "error", err)
+		c.JSON(aiErrorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 // GenerateDiagram generates a diagram using AI
 func (h *TechDocsHandler) GenerateDiagram(c *gin.Context) {
 	orgUUID := c.GetString("organization_uuid")
@@ -301,11 +487,12 @@ func (h *TechDocsHandler) GenerateDiagram(c *gin.Context) {
 		})
 		return
 	}
+	req.UserID = c.GetString("user_id")
 
 	response, err := h.service.GenerateDiagram(orgUUID, req)
 	if err != nil {
 		h.log.Errorw("Failed to generate diagram", "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(aiErrorStatus(err), gin.H{
 			"error": err.Error(),
 		})
 		return
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/PlatifyX/platifyx-core/internal/service"
+	"github.com/PlatifyX/platifyx-core/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// TenantMigrationHandler expõe o status e a execução das migrations de tenant
+type TenantMigrationHandler struct {
+	service *service.TenantMigrationService
+	log     *logger.Logger
+}
+
+func NewTenantMigrationHandler(svc *service.TenantMigrationService, log *logger.Logger) *TenantMigrationHandler {
+	return &TenantMigrationHandler{
+		service: svc,
+		log:     log,
+	}
+}
+
+func (h *TenantMigrationHandler) Status(c *gin.Context) {
+	report, err := h.service.Status()
+	if err != nil {
+		h.log.Errorw("Failed to get tenant migration status", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get tenant migration status",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// Run aplica as migrations pendentes em todas as organizações (dryRun e stopOnError no corpo)
+func (h *TenantMigrationHandler) Run(c *gin.Context) {
+	opts := domain.TenantMigrationOptions{StopOnError: true}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&opts); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid request body",
+				"details": err.Error(),
+			})
+			return
+		}
+	}
+
+	report, err := h.service.MigrateAll(opts)
+	if err != nil {
+		h.log.Errorw("Failed to run tenant migrations", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to run tenant migrations",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func (h *TenantMigrationHandler) OrganizationStatus(c *gin.Context) {
+	uuid := c.Param("uuid")
+
+	status, err := h.service.OrganizationStatus(uuid)
+	if err != nil {
+		h.log.Errorw("Failed to get tenant migration status", "error", err, "uuid", uuid)
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Organization not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// RunOrganization aplica as migrations pendentes em uma organização (?dryRun=true para simular)
+func (h *TenantMigrationHandler) RunOrganization(c *gin.Context) {
+	uuid := c.Param("uuid")
+	dryRun := c.Query("dryRun") == "true"
+
+	status, err := h.service.MigrateOrganization(uuid, dryRun)
+	if err != nil {
+		h.log.Errorw("Failed to run tenant migrations", "error", err, "uuid", uuid)
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Organization not found",
+		})
+		return
+	}
+
+	code := http.StatusOK
+	if status.State == domain.TenantMigrationFailed {
+		code = http.StatusUnprocessableEntity
+	}
+	c.JSON(code, status)
+}
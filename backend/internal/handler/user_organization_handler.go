@@ -135,22 +135,22 @@ func (h *UserOrganizationHandler) UpdateUserRole(c *gin.Context) {
 		return
 	}
 
-	var foundID string
-	for _, uo := range userOrg {
-		if uo.OrganizationUUID == orgUUID {
-			foundID = uo.ID
+	var found *domain.UserOrganization
+	for i := range userOrg {
+		if userOrg[i].OrganizationUUID == orgUUID {
+			found = &userOrg[i].UserOrganization
 			break
 		}
 	}
 
-	if foundID == "" {
+	if found == nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"error": "User is not associated with this organization",
 		})
 		return
 	}
 
-	err = h.service.UpdateUserRole(foundID, req.Role)
+	err = h.service.UpdateUserRole(*found, req.Role)
 	if err != nil {
 		h.log.Errorw("Failed to update user role", "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
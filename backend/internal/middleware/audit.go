@@ -0,0 +1,239 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/PlatifyX/platifyx-core/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// maxAuditBodySize caps how much of the request and response bodies is kept for the audit trail
+const maxAuditBodySize = 64 << 10
+
+// secretsResource is the RBAC resource of the routes that write secret values (Vault, AWS Secrets
+// Manager): their request body is never stored in the audit trail
+const secretsResource = "secrets"
+
+// auditResourceIDKey is the context key handlers use to override the audited resource ID
+const auditResourceIDKey = "audit_resource_id"
+
+// SetAuditResourceID sets the ID of the resource affected by the request when it can not be
+// taken from the route parameters or from the response (e.g. bulk or nested operations)
+func SetAuditResourceID(c *gin.Context, id string) {
+	c.Set(auditResourceIDKey, id)
+}
+
+// AuditTrail writes an audit log entry for every mutating request (POST, PUT, PATCH, DELETE)
+// with the actor, the organization, the resource and the redacted request body (only its size
+// for routes that write secrets).
+// Public routes are skipped: authentication events are audited by AuthService.
+func AuditTrail(policy *RoutePolicy, auditService *service.AuditService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if !isMutation(c.Request.Method) || route == "" || policy.IsPublic(c.Request.Method, route) {
+			c.Next()
+			return
+		}
+
+		body, truncated := captureRequestBody(c)
+		writer := &auditResponseWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		start := time.Now()
+
+		c.Next()
+
+		resource := auditResource(policy, c.Request.Method, route)
+		entry := &domain.AuditLog{
+			Action:   resource + "." + auditVerb(c.Request.Method, route),
+			Resource: resource,
+			Status:   "success",
+		}
+		if c.Writer.Status() >= http.StatusBadRequest {
+			entry.Status = "failure"
+		}
+
+		if userID := c.GetString("user_id"); isUUID(userID) {
+			entry.UserID = &userID
+		}
+		if user, ok := c.Get("user"); ok {
+			if u, ok := user.(*domain.User); ok {
+				entry.UserEmail = u.Email
+			}
+		}
+		if orgUUID := auditOrganization(c, route); orgUUID != "" {
+			entry.OrganizationUUID = &orgUUID
+		}
+		if resourceID := auditResourceID(c, writer); resourceID != "" {
+			entry.ResourceID = &resourceID
+		}
+		if ip := c.ClientIP(); ip != "" {
+			entry.IPAddress = &ip
+		}
+		if userAgent := c.Request.UserAgent(); userAgent != "" {
+			entry.UserAgent = &userAgent
+		}
+
+		auditService.RecordRequest(entry, service.AuditRequest{
+			Method:      c.Request.Method,
+			Route:       route,
+			Path:        c.Request.URL.Path,
+			Query:       c.Request.URL.RawQuery,
+			StatusCode:  c.Writer.Status(),
+			DurationMs:  time.Since(start).Milliseconds(),
+			ContentType: c.ContentType(),
+			Body:        body,
+			Truncated:   truncated,
+			SecretBody:  resource == secretsResource,
+		})
+	}
+}
+
+func isMutation(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// captureRequestBody reads up to maxAuditBodySize of the body and restores it for the handlers
+func captureRequestBody(c *gin.Context) ([]byte, bool) {
+	if c.Request.Body == nil {
+		return nil, false
+	}
+
+	captured, err := io.ReadAll(io.LimitReader(c.Request.Body, maxAuditBodySize+1))
+	if err != nil {
+		return nil, false
+	}
+	c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(captured), c.Request.Body))
+
+	if len(captured) > maxAuditBodySize {
+		return captured[:maxAuditBodySize], true
+	}
+	return captured, false
+}
+
+// auditResource names the audited resource after the RBAC resource of the route,
+// falling back to the first path segment after the API version
+func auditResource(policy *RoutePolicy, method, route string) string {
+	if permission, ok := policy.Permission(method, route); ok && permission.Resource != "" {
+		return permission.Resource
+	}
+
+	segments := routeSegments(route)
+	if len(segments) == 0 {
+		return "api"
+	}
+	return segments[0]
+}
+
+// auditVerb is the trailing static segment of action routes (".../:name/sync" -> "sync"),
+// or create/update/delete after the HTTP method
+func auditVerb(method, route string) string {
+	segments := routeSegments(route)
+	if n := len(segments); n >= 2 && !isRouteParam(segments[n-1]) && isRouteParam(segments[n-2]) {
+		return strings.ReplaceAll(segments[n-1], "-", "_")
+	}
+
+	switch method {
+	case http.MethodPost:
+		return "create"
+	case http.MethodDelete:
+		return "delete"
+	default:
+		return "update"
+	}
+}
+
+// auditOrganization returns the organization the request ran in: the one resolved by
+// OrganizationMiddleware or, for the organization routes themselves, the :uuid parameter
+func auditOrganization(c *gin.Context, route string) string {
+	if orgUUID := c.GetString("organization_uuid"); orgUUID != "" {
+		return orgUUID
+	}
+	if strings.HasPrefix(route, "/api/v1/organizations/:uuid") && isUUID(c.Param("uuid")) {
+		return c.Param("uuid")
+	}
+	return ""
+}
+
+// auditResourceID picks, in order: the ID set by the handler, the last route parameter,
+// and the "id"/"uuid" field of a successful JSON response (for creations)
+func auditResourceID(c *gin.Context, writer *auditResponseWriter) string {
+	if id := c.GetString(auditResourceIDKey); id != "" {
+		return id
+	}
+	if n := len(c.Params); n > 0 {
+		return c.Params[n-1].Value
+	}
+
+	if writer.Status() >= http.StatusBadRequest || writer.truncated {
+		return ""
+	}
+	var response map[string]interface{}
+	if err := json.Unmarshal(writer.body.Bytes(), &response); err != nil {
+		return ""
+	}
+	for _, field := range []string{"id", "uuid"} {
+		if id, ok := response[field].(string); ok && id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+func routeSegments(route string) []string {
+	route = strings.TrimPrefix(route, "/api/v1")
+	return strings.FieldsFunc(route, func(r rune) bool { return r == '/' })
+}
+
+func isRouteParam(segment string) bool {
+	return strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*")
+}
+
+func isUUID(value string) bool {
+	_, err := uuid.Parse(value)
+	return err == nil
+}
+
+// auditResponseWriter keeps the beginning of the response body so the ID of created
+// resources can be audited
+type auditResponseWriter struct {
+	gin.ResponseWriter
+	body      bytes.Buffer
+	truncated bool
+}
+
+func (w *auditResponseWriter) Write(data []byte) (int, error) {
+	w.capture(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *auditResponseWriter) WriteString(s string) (int, error) {
+	w.capture([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController (event streams clear the write deadline)
+func (w *auditResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *auditResponseWriter) capture(data []byte) {
+	if remaining := maxAuditBodySize - w.body.Len(); remaining < len(data) {
+		w.truncated = true
+		if remaining > 0 {
+			w.body.Write(data[:remaining])
+		}
+		return
+	}
+	w.body.Write(data)
+}
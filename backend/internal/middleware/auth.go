@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/PlatifyX/platifyx-core/internal/domain"
 	"github.com/PlatifyX/platifyx-core/internal/service"
 	"github.com/gin-gonic/gin"
 )
@@ -11,75 +12,129 @@ import (
 // AuthMiddleware middleware de autenticação JWT
 func AuthMiddleware(authService *service.AuthService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
-			c.Abort()
+		if !authenticate(c, authService) {
 			return
 		}
 
-		// Extrair token do header "Bearer <token>"
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format"})
-			c.Abort()
+		c.Next()
+	}
+}
+
+// RequirePermission middleware para verificar permissões RBAC
+func RequirePermission(userService *service.UserService, resource, action string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !checkPermission(c, userService, resource, action) {
 			return
 		}
 
-		token := parts[1]
+		c.Next()
+	}
+}
+
+// authenticate valida o token do header Authorization e armazena o usuário no contexto.
+// Retorna false (com a requisição já abortada) quando o token é inválido.
+func authenticate(c *gin.Context, authService *service.AuthService) bool {
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
+		c.Abort()
+		return false
+	}
 
-		// Validar token
-		userID, err := authService.ValidateToken(token)
-		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
-			c.Abort()
-			return
-		}
+	// Extrair token do header "Bearer <token>"
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format"})
+		c.Abort()
+		return false
+	}
 
-		// Buscar usuário
-		user, err := authService.GetUserFromToken(token)
-		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
-			c.Abort()
-			return
-		}
+	token := parts[1]
 
-		// Armazenar informações do usuário no contexto
-		c.Set("user_id", userID)
-		c.Set("user", user)
-		c.Set("token", token)
+	// Validar token
+	userID, err := authService.ValidateToken(token)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+		c.Abort()
+		return false
+	}
 
-		c.Next()
+	// Buscar usuário
+	user, err := authService.GetUserFromToken(token)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		c.Abort()
+		return false
 	}
+
+	// Armazenar informações do usuário no contexto
+	c.Set("user_id", userID)
+	c.Set("user", user)
+	c.Set("token", token)
+
+	return true
 }
 
-// RequirePermission middleware para verificar permissões RBAC
-func RequirePermission(userService *service.UserService, resource, action string) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		userID, exists := c.Get("user_id")
-		if !exists {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
-			c.Abort()
-			return
-		}
+// checkPermission verifica se o usuário autenticado tem a permissão (ou é admin).
+// Quando a requisição tem uma organização resolvida, valem só os roles vinculados ao usuário
+// nessa organização (além do admin da plataforma).
+// Retorna false (com a requisição já abortada) quando não tem.
+func checkPermission(c *gin.Context, userService *service.UserService, resource, action string) bool {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		c.Abort()
+		return false
+	}
 
-		// Buscar permissões do usuário
-		permissions, err := userService.GetUserPermissions(userID.(string))
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error checking permissions"})
-			c.Abort()
-			return
-		}
+	// Buscar permissões do usuário
+	var permissions *domain.UserPermissions
+	var err error
+	if orgUUID := c.GetString("organization_uuid"); orgUUID != "" {
+		permissions, err = userService.GetUserPermissionsInOrganization(userID.(string), orgUUID)
+	} else {
+		permissions, err = userService.GetUserPermissions(userID.(string))
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error checking permissions"})
+		c.Abort()
+		return false
+	}
 
-		// Verificar se tem a permissão
-		if !permissions.HasPermission(resource, action) && !permissions.IsAdmin() {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
-			c.Abort()
-			return
-		}
+	// Verificar se tem a permissão
+	if !permissions.HasPermission(resource, action) && !permissions.IsAdmin() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		c.Abort()
+		return false
+	}
 
-		c.Next()
+	return true
+}
+
+// checkPlatformAdmin requires the system admin role granted globally; roles held in an
+// organization do not count
+func checkPlatformAdmin(c *gin.Context, userService *service.UserService) bool {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		c.Abort()
+		return false
 	}
+
+	permissions, err := userService.GetUserPermissions(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error checking permissions"})
+		c.Abort()
+		return false
+	}
+
+	if !permissions.IsPlatformAdmin {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		c.Abort()
+		return false
+	}
+
+	return true
 }
 
 // OptionalAuth middleware que tenta autenticar mas não falha se não houver token
@@ -4,10 +4,13 @@ import (
 	"net/http"
 
 	"github.com/PlatifyX/platifyx-core/internal/repository"
+	"github.com/PlatifyX/platifyx-core/internal/service"
 	"github.com/PlatifyX/platifyx-core/pkg/logger"
 	"github.com/gin-gonic/gin"
 )
 
+// OrganizationMiddleware resolve a organização do header X-Organization-UUID (ou do query param
+// organization) e exige que o usuário autenticado seja membro dela.
 func OrganizationMiddleware(orgRepo *repository.OrganizationRepository, userOrgRepo *repository.UserOrganizationRepository, log *logger.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		orgUUID := c.GetHeader("X-Organization-UUID")
@@ -23,63 +26,88 @@ func OrganizationMiddleware(orgRepo *repository.OrganizationRepository, userOrgR
 			return
 		}
 
-		org, err := orgRepo.GetByUUID(orgUUID)
-		if err != nil {
-			log.Errorw("Organization not found", "uuid", orgUUID, "error", err)
-			c.JSON(http.StatusNotFound, gin.H{
-				"error": "Organization not found",
-			})
-			c.Abort()
+		if !resolveOrganization(c, orgUUID, orgRepo, userOrgRepo, nil, log) {
 			return
 		}
 
-		userID, exists := c.Get("user_id")
-		if exists {
-			userOrg, err := userOrgRepo.GetByUserAndOrganization(userID.(string), orgUUID)
-			if err != nil {
-				log.Errorw("Failed to check user organization access", "error", err)
-				c.JSON(http.StatusInternalServerError, gin.H{
-					"error": "Failed to verify organization access",
-				})
-				c.Abort()
-				return
-			}
-
-			if userOrg == nil {
-				c.JSON(http.StatusForbidden, gin.H{
-					"error": "User does not have access to this organization",
-				})
-				c.Abort()
-				return
-			}
+		c.Next()
+	}
+}
 
-			c.Set("organization_role", userOrg.Role)
+// OptionalOrganizationMiddleware resolve a organização quando informada, sem torná-la obrigatória.
+// Com usuário autenticado, a organização só é aceita se ele for membro dela.
+func OptionalOrganizationMiddleware(orgRepo *repository.OrganizationRepository, userOrgRepo *repository.UserOrganizationRepository, log *logger.Logger) gin.HandlerFunc {
+	required := OrganizationMiddleware(orgRepo, userOrgRepo, log)
+	return func(c *gin.Context) {
+		if c.GetHeader("X-Organization-UUID") == "" && c.Query("organization") == "" {
+			c.Next()
+			return
 		}
 
-		c.Set("organization_uuid", orgUUID)
-		c.Set("organization", org)
-
-		c.Next()
+		required(c)
 	}
 }
 
-func OptionalOrganizationMiddleware(orgRepo *repository.OrganizationRepository, log *logger.Logger) gin.HandlerFunc {
+// OrganizationPathMiddleware resolve a organização do parâmetro :uuid da rota, para que as permissões
+// sejam checadas contra os roles vinculados ao usuário nessa organização. Exige que o usuário seja
+// membro, exceto o admin da plataforma, que administra qualquer organização.
+func OrganizationPathMiddleware(orgRepo *repository.OrganizationRepository, userOrgRepo *repository.UserOrganizationRepository, userService *service.UserService, log *logger.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		orgUUID := c.GetHeader("X-Organization-UUID")
-		if orgUUID == "" {
-			orgUUID = c.Query("organization")
+		if !resolveOrganization(c, c.Param("uuid"), orgRepo, userOrgRepo, userService, log) {
+			return
 		}
 
-		if orgUUID != "" {
-			org, err := orgRepo.GetByUUID(orgUUID)
-			if err == nil && org != nil {
-				c.Set("organization_uuid", orgUUID)
-				c.Set("organization", org)
-			}
+		c.Next()
+	}
+}
+
+// resolveOrganization carrega a organização, verifica o acesso do usuário autenticado e a armazena no
+// contexto. Com userService informado, o admin da plataforma dispensa a membership.
+// Retorna false (com a requisição já abortada) quando a organização não existe ou o acesso é negado.
+func resolveOrganization(c *gin.Context, orgUUID string, orgRepo *repository.OrganizationRepository, userOrgRepo *repository.UserOrganizationRepository, userService *service.UserService, log *logger.Logger) bool {
+	org, err := orgRepo.GetByUUID(orgUUID)
+	if err != nil {
+		log.Errorw("Organization not found", "uuid", orgUUID, "error", err)
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Organization not found",
+		})
+		c.Abort()
+		return false
+	}
+
+	userID, exists := c.Get("user_id")
+	if exists {
+		userOrg, err := userOrgRepo.GetByUserAndOrganization(userID.(string), orgUUID)
+		if err != nil {
+			log.Errorw("Failed to check user organization access", "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to verify organization access",
+			})
+			c.Abort()
+			return false
 		}
 
-		c.Next()
+		if userOrg != nil {
+			c.Set("organization_role", userOrg.Role)
+		} else if !isPlatformAdmin(c, userService, userID.(string)) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "User does not have access to this organization",
+			})
+			c.Abort()
+			return false
+		}
 	}
+
+	c.Set("organization_uuid", orgUUID)
+	c.Set("organization", org)
+	return true
 }
 
+func isPlatformAdmin(c *gin.Context, userService *service.UserService, userID string) bool {
+	if userService == nil {
+		return false
+	}
 
+	permissions, err := userService.GetUserPermissions(userID)
+	return err == nil && permissions.IsPlatformAdmin
+}
@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/PlatifyX/platifyx-core/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// RoutePermission is the RBAC permission (resource/action of the permissions table) a route requires.
+// The zero value means the route only requires an authenticated user.
+type RoutePermission struct {
+	Resource string
+	Action   string
+}
+
+// Authenticated marks a route that any authenticated user can call
+var Authenticated = RoutePermission{}
+
+// PlatformAdmin marks a route reserved to the users holding the system admin role globally. These
+// routes act on every organization, so no permission within one is enough.
+var PlatformAdmin = RoutePermission{Resource: "platform", Action: "admin"}
+
+// RoutePolicy declares, for every route of the API, whether it is public or which permission it requires.
+// Routes are identified by "METHOD /full/path" using the path pattern registered in gin.
+type RoutePolicy struct {
+	public      map[string]bool
+	permissions map[string]RoutePermission
+}
+
+func NewRoutePolicy(public []string, permissions map[string]RoutePermission) *RoutePolicy {
+	policy := &RoutePolicy{
+		public:      make(map[string]bool, len(public)),
+		permissions: permissions,
+	}
+	for _, route := range public {
+		policy.public[route] = true
+	}
+	return policy
+}
+
+// IsPublic reports whether the route can be called without a token
+func (p *RoutePolicy) IsPublic(method, path string) bool {
+	return p.public[routeKey(method, path)]
+}
+
+// Permission returns the permission required by the route and whether the route is declared at all
+func (p *RoutePolicy) Permission(method, path string) (RoutePermission, bool) {
+	permission, ok := p.permissions[routeKey(method, path)]
+	return permission, ok
+}
+
+// Uncovered returns the registered routes that are neither public nor mapped to a permission,
+// and the declared routes that are not registered (stale entries)
+func (p *RoutePolicy) Uncovered(routes gin.RoutesInfo) (unprotected []string, stale []string) {
+	registered := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		key := routeKey(route.Method, route.Path)
+		registered[key] = true
+
+		if p.public[key] {
+			continue
+		}
+		if _, ok := p.permissions[key]; !ok {
+			unprotected = append(unprotected, key)
+		}
+	}
+
+	for key := range p.public {
+		if !registered[key] {
+			stale = append(stale, key)
+		}
+	}
+	for key := range p.permissions {
+		if !registered[key] {
+			stale = append(stale, key)
+		}
+	}
+
+	sort.Strings(unprotected)
+	sort.Strings(stale)
+	return unprotected, stale
+}
+
+// Authenticate requires a valid token on every route that the policy does not declare public.
+// It must run before OrganizationMiddleware so that organization membership is always checked.
+func Authenticate(policy *RoutePolicy, authService *service.AuthService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if policy.IsPublic(c.Request.Method, c.FullPath()) {
+			c.Next()
+			return
+		}
+
+		if !authenticate(c, authService) {
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// Authorize checks the permission that the policy declares for the route. Routes missing from the
+// policy are denied, so a new route cannot be exposed by accident.
+func Authorize(policy *RoutePolicy, userService *service.UserService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		method, path := c.Request.Method, c.FullPath()
+		if policy.IsPublic(method, path) {
+			c.Next()
+			return
+		}
+
+		permission, ok := policy.Permission(method, path)
+		if !ok {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Route is not covered by the access policy"})
+			c.Abort()
+			return
+		}
+
+		switch permission {
+		case Authenticated:
+		case PlatformAdmin:
+			if !checkPlatformAdmin(c, userService) {
+				return
+			}
+		default:
+			if !checkPermission(c, userService, permission.Resource, permission.Action) {
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+func routeKey(method, path string) string {
+	return fmt.Sprintf("%s %s", method, path)
+}
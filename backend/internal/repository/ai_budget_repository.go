@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+)
+
+type AIBudgetRepository struct {
+	db *sql.DB
+}
+
+func NewAIBudgetRepository(db *sql.DB) *AIBudgetRepository {
+	return &AIBudgetRepository{db: db}
+}
+
+// Avisos de orçamento enviados no máximo uma vez por mês (coluna de controle por tipo)
+var aiBudgetNotificationColumns = map[string]string{
+	"warning":  "warning_notified_month",
+	"exceeded": "exceeded_notified_month",
+}
+
+// Get retorna o orçamento de IA da organização (nil se nunca foi configurado)
+func (r *AIBudgetRepository) Get(organizationUUID string) (*domain.AIBudget, error) {
+	var budget domain.AIBudget
+	var updatedBy sql.NullString
+	var updatedAt time.Time
+
+	err := r.db.QueryRow(`
+		SELECT monthly_limit_usd, warning_percent, updated_by, updated_at
+		FROM ai_budgets
+		WHERE organization_uuid = $1
+	`, organizationUUID).Scan(
+		&budget.MonthlyLimitUSD,
+		&budget.WarningPercent,
+		&updatedBy,
+		&updatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	budget.UpdatedBy = updatedBy.String
+	budget.UpdatedAt = &updatedAt
+
+	return &budget, nil
+}
+
+// Upsert grava o orçamento de IA da organização; os avisos do mês voltam a ser enviados com o novo limite
+func (r *AIBudgetRepository) Upsert(organizationUUID string, budget *domain.AIBudget, updatedBy string) error {
+	_, err := r.db.Exec(`
+		INSERT INTO ai_budgets (organization_uuid, monthly_limit_usd, warning_percent, updated_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (organization_uuid) DO UPDATE SET
+			monthly_limit_usd = EXCLUDED.monthly_limit_usd,
+			warning_percent = EXCLUDED.warning_percent,
+			updated_by = EXCLUDED.updated_by,
+			warning_notified_month = NULL,
+			exceeded_notified_month = NULL,
+			updated_at = CURRENT_TIMESTAMP
+	`, organizationUUID, budget.MonthlyLimitUSD, budget.WarningPercent, updatedBy)
+
+	return err
+}
+
+// ClaimNotification marca o aviso (warning ou exceeded) como enviado no mês. Retorna false se já
+// foi enviado, de modo que só uma instância da API envia cada aviso.
+func (r *AIBudgetRepository) ClaimNotification(organizationUUID, kind string, month time.Time) (bool, error) {
+	column, ok := aiBudgetNotificationColumns[kind]
+	if !ok {
+		return false, fmt.Errorf("invalid budget notification: %s", kind)
+	}
+
+	result, err := r.db.Exec(fmt.Sprintf(`
+		UPDATE ai_budgets
+		SET %[1]s = $2
+		WHERE organization_uuid = $1 AND (%[1]s IS NULL OR %[1]s <> $2)
+	`, column), organizationUUID, month)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+)
+
+type AIRoutingPolicyRepository struct {
+	db *sql.DB
+}
+
+func NewAIRoutingPolicyRepository(db *sql.DB) *AIRoutingPolicyRepository {
+	return &AIRoutingPolicyRepository{db: db}
+}
+
+// Get retorna a política de roteamento de IA da organização (nil se nunca foi configurada)
+func (r *AIRoutingPolicyRepository) Get(organizationUUID string) (*domain.AIRoutingPolicy, error) {
+	var policy domain.AIRoutingPolicy
+	var routes, fallbackOrder []byte
+	var updatedBy sql.NullString
+	var updatedAt time.Time
+
+	err := r.db.QueryRow(`
+		SELECT routes, fallback_order, max_retries, updated_by, updated_at
+		FROM ai_routing_policies
+		WHERE organization_uuid = $1
+	`, organizationUUID).Scan(
+		&routes,
+		&fallbackOrder,
+		&policy.MaxRetries,
+		&updatedBy,
+		&updatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(routes, &policy.Routes); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(fallbackOrder, &policy.FallbackOrder); err != nil {
+		return nil, err
+	}
+	policy.UpdatedBy = updatedBy.String
+	policy.UpdatedAt = &updatedAt
+
+	return &policy, nil
+}
+
+// Upsert grava a política de roteamento de IA da organização
+func (r *AIRoutingPolicyRepository) Upsert(organizationUUID string, policy *domain.AIRoutingPolicy, updatedBy string) error {
+	routes := policy.Routes
+	if routes == nil {
+		routes = map[domain.AIFeature]domain.AIRoute{}
+	}
+	fallbackOrder := policy.FallbackOrder
+	if fallbackOrder == nil {
+		fallbackOrder = []domain.AIProvider{}
+	}
+
+	routesJSON, err := json.Marshal(routes)
+	if err != nil {
+		return err
+	}
+	fallbackOrderJSON, err := json.Marshal(fallbackOrder)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(`
+		INSERT INTO ai_routing_policies (organization_uuid, routes, fallback_order, max_retries, updated_by)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (organization_uuid) DO UPDATE SET
+			routes = EXCLUDED.routes,
+			fallback_order = EXCLUDED.fallback_order,
+			max_retries = EXCLUDED.max_retries,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = CURRENT_TIMESTAMP
+	`, organizationUUID, routesJSON, fallbackOrderJSON, policy.MaxRetries, updatedBy)
+
+	return err
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+)
+
+type AIUsageRepository struct {
+	db *sql.DB
+}
+
+func NewAIUsageRepository(db *sql.DB) *AIUsageRepository {
+	return &AIUsageRepository{db: db}
+}
+
+// Agrupamentos aceitos por Breakdown (coluna SQL por nome)
+var aiUsageBreakdownColumns = map[string]string{
+	"feature": "feature",
+	"model":   "provider || '/' || model",
+	"user":    "COALESCE(user_id, 'system')",
+}
+
+// Create grava o consumo de uma chamada do gateway de IA
+func (r *AIUsageRepository) Create(record *domain.AIUsageRecord) error {
+	var userID sql.NullString
+	if record.UserID != "" {
+		userID = sql.NullString{String: record.UserID, Valid: true}
+	}
+
+	return r.db.QueryRow(`
+		INSERT INTO ai_usage_records (
+			organization_uuid, user_id, feature, provider, model,
+			input_tokens, output_tokens, total_tokens, tokens_estimated, estimated_cost_usd
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, created_at
+	`,
+		record.OrganizationUUID,
+		userID,
+		record.Feature,
+		record.Provider,
+		record.Model,
+		record.InputTokens,
+		record.OutputTokens,
+		record.TotalTokens,
+		record.TokensEstimated,
+		record.EstimatedCostUSD,
+	).Scan(&record.ID, &record.CreatedAt)
+}
+
+// SumCost retorna o custo estimado da organização em [from, to)
+func (r *AIUsageRepository) SumCost(organizationUUID string, from, to time.Time) (float64, error) {
+	var cost float64
+	err := r.db.QueryRow(`
+		SELECT COALESCE(SUM(estimated_cost_usd), 0)
+		FROM ai_usage_records
+		WHERE organization_uuid = $1 AND created_at >= $2 AND created_at < $3
+	`, organizationUUID, from, to).Scan(&cost)
+	return cost, err
+}
+
+// Totals retorna o consumo total da organização em [from, to)
+func (r *AIUsageRepository) Totals(organizationUUID string, from, to time.Time) (*domain.AIUsageTotals, error) {
+	var totals domain.AIUsageTotals
+	err := r.db.QueryRow(`
+		SELECT COUNT(*),
+			COALESCE(SUM(input_tokens), 0),
+			COALESCE(SUM(output_tokens), 0),
+			COALESCE(SUM(total_tokens), 0),
+			COALESCE(SUM(estimated_cost_usd), 0)
+		FROM ai_usage_records
+		WHERE organization_uuid = $1 AND created_at >= $2 AND created_at < $3
+	`, organizationUUID, from, to).Scan(
+		&totals.Requests,
+		&totals.InputTokens,
+		&totals.OutputTokens,
+		&totals.TotalTokens,
+		&totals.EstimatedCostUSD,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &totals, nil
+}
+
+// Series retorna o consumo da organização em [from, to) agrupado por dia ou mês (apenas períodos com consumo)
+func (r *AIUsageRepository) Series(organizationUUID string, from, to time.Time, interval string) ([]domain.AIUsagePoint, error) {
+	if interval != domain.AIUsageIntervalDay && interval != domain.AIUsageIntervalMonth {
+		return nil, fmt.Errorf("invalid usage interval: %s", interval)
+	}
+
+	rows, err := r.db.Query(`
+		SELECT date_trunc($4, created_at) AS period,
+			COUNT(*),
+			SUM(input_tokens),
+			SUM(output_tokens),
+			SUM(total_tokens),
+			SUM(estimated_cost_usd)
+		FROM ai_usage_records
+		WHERE organization_uuid = $1 AND created_at >= $2 AND created_at < $3
+		GROUP BY period
+		ORDER BY period
+	`, organizationUUID, from, to, interval)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	points := []domain.AIUsagePoint{}
+	for rows.Next() {
+		var point domain.AIUsagePoint
+		if err := rows.Scan(
+			&point.Period,
+			&point.Requests,
+			&point.InputTokens,
+			&point.OutputTokens,
+			&point.TotalTokens,
+			&point.EstimatedCostUSD,
+		); err != nil {
+			return nil, err
+		}
+		points = append(points, point)
+	}
+
+	return points, rows.Err()
+}
+
+// Breakdown retorna o consumo da organização em [from, to) agrupado por feature, model ou user,
+// do maior para o menor custo
+func (r *AIUsageRepository) Breakdown(organizationUUID string, from, to time.Time, groupBy string) ([]domain.AIUsageTotals, error) {
+	column, ok := aiUsageBreakdownColumns[groupBy]
+	if !ok {
+		return nil, fmt.Errorf("invalid usage breakdown: %s", groupBy)
+	}
+
+	rows, err := r.db.Query(fmt.Sprintf(`
+		SELECT %s AS key,
+			COUNT(*),
+			SUM(input_tokens),
+			SUM(output_tokens),
+			SUM(total_tokens),
+			SUM(estimated_cost_usd) AS cost
+		FROM ai_usage_records
+		WHERE organization_uuid = $1 AND created_at >= $2 AND created_at < $3
+		GROUP BY key
+		ORDER BY cost DESC, key
+	`, column), organizationUUID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []domain.AIUsageTotals{}
+	for rows.Next() {
+		var item domain.AIUsageTotals
+		if err := rows.Scan(
+			&item.Key,
+			&item.Requests,
+			&item.InputTokens,
+			&item.OutputTokens,
+			&item.TotalTokens,
+			&item.EstimatedCostUSD,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
@@ -0,0 +1,292 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+)
+
+type AlertRepository struct {
+	db *sql.DB
+}
+
+func NewAlertRepository(db *sql.DB) *AlertRepository {
+	return &AlertRepository{db: db}
+}
+
+const alertColumns = `
+	id, organization_uuid, source, fingerprint, status, name, service_name, squad, severity, summary,
+	description, labels, annotations, starts_at, ends_at, generator_url, received_count, last_received_at,
+	created_at, updated_at
+`
+
+// GetWebhookToken retorna o token dos webhooks da organização e o hash para validação (nil se não existir)
+func (r *AlertRepository) GetWebhookToken(organizationUUID string) (*domain.AlertWebhookToken, string, error) {
+	var token domain.AlertWebhookToken
+	var hash string
+	var createdAt time.Time
+
+	err := r.db.QueryRow(`
+		SELECT token_hash, token_prefix, created_by, created_at
+		FROM alert_webhook_tokens
+		WHERE organization_uuid = $1
+	`, organizationUUID).Scan(&hash, &token.TokenPrefix, &token.CreatedBy, &createdAt)
+	if err == sql.ErrNoRows {
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	token.Configured = true
+	token.CreatedAt = &createdAt
+	return &token, hash, nil
+}
+
+// UpsertWebhookToken grava (ou substitui) o token dos webhooks da organização
+func (r *AlertRepository) UpsertWebhookToken(organizationUUID, hash, prefix, createdBy string) (time.Time, error) {
+	var createdAt time.Time
+	err := r.db.QueryRow(`
+		INSERT INTO alert_webhook_tokens (organization_uuid, token_hash, token_prefix, created_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (organization_uuid) DO UPDATE SET
+			token_hash = EXCLUDED.token_hash,
+			token_prefix = EXCLUDED.token_prefix,
+			created_by = EXCLUDED.created_by,
+			created_at = CURRENT_TIMESTAMP
+		RETURNING created_at
+	`, organizationUUID, hash, prefix, createdBy).Scan(&createdAt)
+	return createdAt, err
+}
+
+// Record grava uma entrega de alerta. Ocorrências novas (fingerprint e início ainda não vistos) são
+// criadas; as já existentes são atualizadas e o status anterior é retornado. Uma ocorrência resolvida
+// não volta a disparar: o Alertmanager dá um novo início a um alerta que dispara de novo.
+func (r *AlertRepository) Record(alert *domain.Alert) (created bool, previousStatus string, err error) {
+	labels, err := json.Marshal(alert.Labels)
+	if err != nil {
+		return false, "", err
+	}
+	annotations, err := json.Marshal(alert.Annotations)
+	if err != nil {
+		return false, "", err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, "", err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(`
+		INSERT INTO alerts (
+			organization_uuid, source, fingerprint, status, name, service_name, squad, severity, summary,
+			description, labels, annotations, starts_at, ends_at, generator_url
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		ON CONFLICT (organization_uuid, source, fingerprint, starts_at) DO NOTHING
+		RETURNING id, received_count, last_received_at, created_at, updated_at
+	`,
+		alert.OrganizationUUID,
+		alert.Source,
+		alert.Fingerprint,
+		alert.Status,
+		alert.Name,
+		nullString(alert.ServiceName),
+		nullString(alert.Squad),
+		nullString(alert.Severity),
+		nullString(alert.Summary),
+		nullString(alert.Description),
+		labels,
+		annotations,
+		alert.StartsAt,
+		alert.EndsAt,
+		nullString(alert.GeneratorURL),
+	).Scan(&alert.ID, &alert.ReceivedCount, &alert.LastReceivedAt, &alert.CreatedAt, &alert.UpdatedAt)
+	if err == nil {
+		return true, "", tx.Commit()
+	}
+	if err != sql.ErrNoRows {
+		return false, "", err
+	}
+
+	// Ocorrência já registrada: atualiza mantendo a resolução, se houver
+	var endsAt sql.NullTime
+	err = tx.QueryRow(`
+		UPDATE alerts a SET
+			status = CASE WHEN p.previous_status = 'resolved' THEN a.status ELSE $5 END,
+			ends_at = CASE WHEN p.previous_status = 'resolved' THEN a.ends_at ELSE $6 END,
+			name = $7,
+			service_name = $8,
+			squad = $9,
+			severity = $10,
+			summary = $11,
+			description = $12,
+			labels = $13,
+			annotations = $14,
+			generator_url = $15,
+			received_count = a.received_count + 1,
+			last_received_at = CURRENT_TIMESTAMP,
+			updated_at = CURRENT_TIMESTAMP
+		FROM (
+			SELECT id, status AS previous_status
+			FROM alerts
+			WHERE organization_uuid = $1 AND source = $2 AND fingerprint = $3 AND starts_at = $4
+			FOR UPDATE
+		) p
+		WHERE a.id = p.id
+		RETURNING a.id, p.previous_status, a.status, a.ends_at, a.received_count, a.last_received_at, a.created_at, a.updated_at
+	`,
+		alert.OrganizationUUID,
+		alert.Source,
+		alert.Fingerprint,
+		alert.StartsAt,
+		alert.Status,
+		alert.EndsAt,
+		alert.Name,
+		nullString(alert.ServiceName),
+		nullString(alert.Squad),
+		nullString(alert.Severity),
+		nullString(alert.Summary),
+		nullString(alert.Description),
+		labels,
+		annotations,
+		nullString(alert.GeneratorURL),
+	).Scan(&alert.ID, &previousStatus, &alert.Status, &endsAt, &alert.ReceivedCount, &alert.LastReceivedAt, &alert.CreatedAt, &alert.UpdatedAt)
+	if err != nil {
+		return false, "", err
+	}
+	alert.EndsAt = nil
+	if endsAt.Valid {
+		alert.EndsAt = &endsAt.Time
+	}
+
+	return false, previousStatus, tx.Commit()
+}
+
+// List retorna os alertas da organização, mais recentes primeiro, com o total para paginação
+func (r *AlertRepository) List(organizationUUID string, filter domain.AlertFilter) ([]domain.Alert, int, error) {
+	where := []string{"organization_uuid = $1"}
+	args := []interface{}{organizationUUID}
+	argCount := 2
+
+	add := func(condition string, value interface{}) {
+		where = append(where, fmt.Sprintf(condition, argCount))
+		args = append(args, value)
+		argCount++
+	}
+
+	if filter.Status != "" {
+		add("status = $%d", filter.Status)
+	}
+	if filter.Source != "" {
+		add("source = $%d", filter.Source)
+	}
+	if filter.ServiceName != "" {
+		add("service_name = $%d", filter.ServiceName)
+	}
+
+	whereClause := strings.Join(where, " AND ")
+
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM alerts WHERE "+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Size < 1 {
+		filter.Size = 20
+	}
+
+	query := fmt.Sprintf(`SELECT %s
+		FROM alerts
+		WHERE %s
+		ORDER BY starts_at DESC, id DESC
+		LIMIT $%d OFFSET $%d
+	`, alertColumns, whereClause, argCount, argCount+1)
+	args = append(args, filter.Size, (filter.Page-1)*filter.Size)
+
+	alerts, err := r.query(query, args...)
+	return alerts, total, err
+}
+
+// ListByService retorna os alertas de um serviço que dispararam ou foram resolvidos desde uma data,
+// além dos que ainda estão disparando
+func (r *AlertRepository) ListByService(organizationUUID, serviceName string, since time.Time) ([]domain.Alert, error) {
+	query := `SELECT ` + alertColumns + `
+		FROM alerts
+		WHERE organization_uuid = $1 AND service_name = $2
+			AND (starts_at >= $3 OR ends_at >= $3 OR status = 'firing')
+		ORDER BY starts_at DESC, id DESC
+	`
+
+	return r.query(query, organizationUUID, serviceName, since)
+}
+
+func (r *AlertRepository) query(query string, args ...interface{}) ([]domain.Alert, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	alerts := []domain.Alert{}
+	for rows.Next() {
+		var alert domain.Alert
+		var serviceName, squad, severity, summary, description, generatorURL sql.NullString
+		var labels, annotations []byte
+		var endsAt sql.NullTime
+
+		err := rows.Scan(
+			&alert.ID,
+			&alert.OrganizationUUID,
+			&alert.Source,
+			&alert.Fingerprint,
+			&alert.Status,
+			&alert.Name,
+			&serviceName,
+			&squad,
+			&severity,
+			&summary,
+			&description,
+			&labels,
+			&annotations,
+			&alert.StartsAt,
+			&endsAt,
+			&generatorURL,
+			&alert.ReceivedCount,
+			&alert.LastReceivedAt,
+			&alert.CreatedAt,
+			&alert.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(labels, &alert.Labels); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(annotations, &alert.Annotations); err != nil {
+			return nil, err
+		}
+		alert.ServiceName = serviceName.String
+		alert.Squad = squad.String
+		alert.Severity = severity.String
+		alert.Summary = summary.String
+		alert.Description = description.String
+		alert.GeneratorURL = generatorURL.String
+		if endsAt.Valid {
+			alert.EndsAt = &endsAt.Time
+		}
+
+		alerts = append(alerts, alert)
+	}
+
+	return alerts, rows.Err()
+}
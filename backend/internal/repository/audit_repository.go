@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/google/uuid"
 )
 
 type AuditRepository struct {
@@ -18,14 +19,16 @@ func NewAuditRepository(db *sql.DB) *AuditRepository {
 	return &AuditRepository{db: db}
 }
 
-// Create cria um novo log de auditoria
-func (r *AuditRepository) Create(log *domain.AuditLog) error {
-	query := `
-		INSERT INTO audit_logs (user_id, user_email, action, resource, resource_id, details, ip_address, user_agent, status)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-		RETURNING id, created_at
-	`
+// auditLogColumns são as colunas lidas por scanAuditLog
+const auditLogColumns = `id, user_id, user_email, organization_uuid, action, resource, resource_id, details,
+		       ip_address, user_agent, status, created_at, sequence, prev_hash, hash`
+
+// globalAuditChain é a chave da cadeia dos registros sem organização
+const globalAuditChain = "global"
 
+// Create cria um novo log de auditoria encadeado ao último registro da mesma organização.
+// O elo da cadeia é bloqueado durante a inserção, serializando os registros de cada organização.
+func (r *AuditRepository) Create(log *domain.AuditLog) error {
 	var detailsJSON []byte
 	var err error
 	if log.Details != nil {
@@ -35,10 +38,50 @@ func (r *AuditRepository) Create(log *domain.AuditLog) error {
 		}
 	}
 
-	return r.db.QueryRow(
-		query,
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	chainKey := auditChainKey(log.OrganizationUUID)
+	_, err = tx.Exec(`INSERT INTO audit_log_chain_heads (chain_key) VALUES ($1) ON CONFLICT (chain_key) DO NOTHING`, chainKey)
+	if err != nil {
+		return err
+	}
+
+	var sequence int64
+	var prevHash string
+	err = tx.QueryRow(
+		`SELECT sequence, hash FROM audit_log_chain_heads WHERE chain_key = $1 FOR UPDATE`,
+		chainKey,
+	).Scan(&sequence, &prevHash)
+	if err != nil {
+		return err
+	}
+
+	sequence++
+	log.ID = uuid.New().String()
+	log.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	log.Details = detailsJSON
+	log.Sequence = &sequence
+	log.PrevHash = &prevHash
+
+	hash, err := log.ComputeHash()
+	if err != nil {
+		return err
+	}
+	log.Hash = &hash
+
+	_, err = tx.Exec(`
+		INSERT INTO audit_logs (id, user_id, user_email, organization_uuid, action, resource, resource_id, details,
+		                        ip_address, user_agent, status, created_at, sequence, prev_hash, hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	`,
+		log.ID,
 		log.UserID,
 		log.UserEmail,
+		log.OrganizationUUID,
 		log.Action,
 		log.Resource,
 		log.ResourceID,
@@ -46,22 +89,31 @@ func (r *AuditRepository) Create(log *domain.AuditLog) error {
 		log.IPAddress,
 		log.UserAgent,
 		log.Status,
-	).Scan(&log.ID, &log.CreatedAt)
+		log.CreatedAt,
+		sequence,
+		prevHash,
+		hash,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE audit_log_chain_heads
+		SET sequence = $1, hash = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE chain_key = $3
+	`, sequence, hash, chainKey)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 // GetByID retorna um log por ID
 func (r *AuditRepository) GetByID(id string) (*domain.AuditLog, error) {
-	log := &domain.AuditLog{}
-	query := `
-		SELECT id, user_id, user_email, action, resource, resource_id, details,
-		       ip_address, user_agent, status, created_at
-		FROM audit_logs WHERE id = $1
-	`
-	err := r.db.QueryRow(query, id).Scan(
-		&log.ID, &log.UserID, &log.UserEmail, &log.Action, &log.Resource,
-		&log.ResourceID, &log.Details, &log.IPAddress, &log.UserAgent,
-		&log.Status, &log.CreatedAt,
-	)
+	query := fmt.Sprintf(`SELECT %s FROM audit_logs WHERE id = $1`, auditLogColumns)
+	log, err := scanAuditLog(r.db.QueryRow(query, id))
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("audit log not found")
 	}
@@ -70,59 +122,7 @@ func (r *AuditRepository) GetByID(id string) (*domain.AuditLog, error) {
 
 // List retorna uma lista de logs com filtros
 func (r *AuditRepository) List(filter domain.AuditLogFilter) ([]domain.AuditLog, int, error) {
-	where := []string{"1=1"}
-	args := []interface{}{}
-	argCount := 1
-
-	if filter.UserID != "" {
-		where = append(where, fmt.Sprintf("user_id = $%d", argCount))
-		args = append(args, filter.UserID)
-		argCount++
-	}
-
-	if filter.UserEmail != "" {
-		where = append(where, fmt.Sprintf("user_email ILIKE $%d", argCount))
-		args = append(args, "%"+filter.UserEmail+"%")
-		argCount++
-	}
-
-	if filter.Action != "" {
-		where = append(where, fmt.Sprintf("action = $%d", argCount))
-		args = append(args, filter.Action)
-		argCount++
-	}
-
-	if filter.Resource != "" {
-		where = append(where, fmt.Sprintf("resource = $%d", argCount))
-		args = append(args, filter.Resource)
-		argCount++
-	}
-
-	if filter.ResourceID != "" {
-		where = append(where, fmt.Sprintf("resource_id = $%d", argCount))
-		args = append(args, filter.ResourceID)
-		argCount++
-	}
-
-	if filter.Status != "" {
-		where = append(where, fmt.Sprintf("status = $%d", argCount))
-		args = append(args, filter.Status)
-		argCount++
-	}
-
-	if !filter.StartDate.IsZero() {
-		where = append(where, fmt.Sprintf("created_at >= $%d", argCount))
-		args = append(args, filter.StartDate)
-		argCount++
-	}
-
-	if !filter.EndDate.IsZero() {
-		where = append(where, fmt.Sprintf("created_at <= $%d", argCount))
-		args = append(args, filter.EndDate)
-		argCount++
-	}
-
-	whereClause := strings.Join(where, " AND ")
+	whereClause, args, argCount := auditFilterWhere(filter)
 
 	// Count total
 	var total int
@@ -144,13 +144,12 @@ func (r *AuditRepository) List(filter domain.AuditLogFilter) ([]domain.AuditLog,
 	args = append(args, filter.Size, offset)
 
 	query := fmt.Sprintf(`
-		SELECT id, user_id, user_email, action, resource, resource_id, details,
-		       ip_address, user_agent, status, created_at
+		SELECT %s
 		FROM audit_logs
 		WHERE %s
 		ORDER BY created_at DESC
 		LIMIT $%d OFFSET $%d
-	`, whereClause, argCount, argCount+1)
+	`, auditLogColumns, whereClause, argCount, argCount+1)
 
 	rows, err := r.db.Query(query, args...)
 	if err != nil {
@@ -160,16 +159,11 @@ func (r *AuditRepository) List(filter domain.AuditLogFilter) ([]domain.AuditLog,
 
 	logs := []domain.AuditLog{}
 	for rows.Next() {
-		log := domain.AuditLog{}
-		err := rows.Scan(
-			&log.ID, &log.UserID, &log.UserEmail, &log.Action, &log.Resource,
-			&log.ResourceID, &log.Details, &log.IPAddress, &log.UserAgent,
-			&log.Status, &log.CreatedAt,
-		)
+		log, err := scanAuditLog(rows)
 		if err != nil {
 			return nil, 0, err
 		}
-		logs = append(logs, log)
+		logs = append(logs, *log)
 	}
 
 	return logs, total, nil
@@ -295,13 +289,12 @@ func (r *AuditRepository) GetStats(startDate, endDate time.Time) (*domain.AuditS
 
 	// Atividade recente (últimos 20)
 	query := fmt.Sprintf(`
-		SELECT id, user_id, user_email, action, resource, resource_id, details,
-		       ip_address, user_agent, status, created_at
+		SELECT %s
 		FROM audit_logs
 		WHERE %s
 		ORDER BY created_at DESC
 		LIMIT 20
-	`, where)
+	`, auditLogColumns, where)
 
 	rows, err = r.db.Query(query, args...)
 	if err != nil {
@@ -311,27 +304,218 @@ func (r *AuditRepository) GetStats(startDate, endDate time.Time) (*domain.AuditS
 
 	stats.RecentActivity = []domain.AuditLog{}
 	for rows.Next() {
-		log := domain.AuditLog{}
-		err := rows.Scan(
-			&log.ID, &log.UserID, &log.UserEmail, &log.Action, &log.Resource,
-			&log.ResourceID, &log.Details, &log.IPAddress, &log.UserAgent,
-			&log.Status, &log.CreatedAt,
-		)
+		log, err := scanAuditLog(rows)
 		if err != nil {
 			return nil, err
 		}
-		stats.RecentActivity = append(stats.RecentActivity, log)
+		stats.RecentActivity = append(stats.RecentActivity, *log)
 	}
 
 	return stats, nil
 }
 
-// DeleteOldLogs deleta logs mais antigos que a data especificada
+// DeleteOldLogs deleta logs mais antigos que a data especificada.
+// O último registro removido de cada cadeia vira a âncora da verificação, para que a retenção não quebre a cadeia.
 func (r *AuditRepository) DeleteOldLogs(olderThan time.Time) (int64, error) {
-	query := `DELETE FROM audit_logs WHERE created_at < $1`
-	result, err := r.db.Exec(query, olderThan)
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		UPDATE audit_log_chain_heads h
+		SET anchor_sequence = pruned.sequence, anchor_hash = pruned.hash
+		FROM (
+			SELECT DISTINCT ON (chain_key) chain_key, sequence, hash
+			FROM (
+				SELECT COALESCE(organization_uuid::text, $2) AS chain_key, sequence, hash
+				FROM audit_logs
+				WHERE created_at < $1 AND sequence IS NOT NULL
+			) chained
+			ORDER BY chain_key, sequence DESC
+		) pruned
+		WHERE h.chain_key = pruned.chain_key AND pruned.sequence > h.anchor_sequence
+	`, olderThan, globalAuditChain)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := tx.Exec(`DELETE FROM audit_logs WHERE created_at < $1`, olderThan)
 	if err != nil {
 		return 0, err
 	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
 	return result.RowsAffected()
 }
+
+// Stream percorre, em ordem cronológica, todos os logs que atendem ao filtro (sem paginação)
+func (r *AuditRepository) Stream(filter domain.AuditLogFilter, fn func(*domain.AuditLog) error) error {
+	whereClause, args, _ := auditFilterWhere(filter)
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM audit_logs
+		WHERE %s
+		ORDER BY created_at, sequence, id
+	`, auditLogColumns, whereClause)
+
+	return r.each(query, args, fn)
+}
+
+// StreamChain percorre a cadeia de uma organização (global quando organizationUUID é vazio) em ordem de sequência
+func (r *AuditRepository) StreamChain(organizationUUID string, fn func(*domain.AuditLog) error) error {
+	where, args := auditChainWhere(organizationUUID)
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM audit_logs
+		WHERE %s AND sequence IS NOT NULL
+		ORDER BY sequence
+	`, auditLogColumns, where)
+
+	return r.each(query, args, fn)
+}
+
+// GetChainHead retorna o último elo registrado da cadeia (zerado quando a cadeia ainda não existe)
+func (r *AuditRepository) GetChainHead(organizationUUID string) (*domain.AuditChainHead, error) {
+	chainKey := globalAuditChain
+	if organizationUUID != "" {
+		chainKey = organizationUUID
+	}
+
+	head := &domain.AuditChainHead{}
+	err := r.db.QueryRow(
+		`SELECT sequence, hash, anchor_sequence, anchor_hash FROM audit_log_chain_heads WHERE chain_key = $1`,
+		chainKey,
+	).Scan(&head.Sequence, &head.Hash, &head.AnchorSequence, &head.AnchorHash)
+	if err == sql.ErrNoRows {
+		return head, nil
+	}
+	return head, err
+}
+
+// CountUnchained conta os registros legados (anteriores ao encadeamento) de uma organização
+func (r *AuditRepository) CountUnchained(organizationUUID string) (int64, error) {
+	where, args := auditChainWhere(organizationUUID)
+	var count int64
+	err := r.db.QueryRow(
+		fmt.Sprintf(`SELECT COUNT(*) FROM audit_logs WHERE %s AND sequence IS NULL`, where),
+		args...,
+	).Scan(&count)
+	return count, err
+}
+
+func (r *AuditRepository) each(query string, args []interface{}, fn func(*domain.AuditLog) error) error {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		log, err := scanAuditLog(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(log); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func auditFilterWhere(filter domain.AuditLogFilter) (string, []interface{}, int) {
+	where := []string{"1=1"}
+	args := []interface{}{}
+	argCount := 1
+
+	if filter.UserID != "" {
+		where = append(where, fmt.Sprintf("user_id = $%d", argCount))
+		args = append(args, filter.UserID)
+		argCount++
+	}
+
+	if filter.UserEmail != "" {
+		where = append(where, fmt.Sprintf("user_email ILIKE $%d", argCount))
+		args = append(args, "%"+filter.UserEmail+"%")
+		argCount++
+	}
+
+	if filter.OrganizationUUID != "" {
+		where = append(where, fmt.Sprintf("organization_uuid = $%d", argCount))
+		args = append(args, filter.OrganizationUUID)
+		argCount++
+	}
+
+	if filter.Action != "" {
+		where = append(where, fmt.Sprintf("action = $%d", argCount))
+		args = append(args, filter.Action)
+		argCount++
+	}
+
+	if filter.Resource != "" {
+		where = append(where, fmt.Sprintf("resource = $%d", argCount))
+		args = append(args, filter.Resource)
+		argCount++
+	}
+
+	if filter.ResourceID != "" {
+		where = append(where, fmt.Sprintf("resource_id = $%d", argCount))
+		args = append(args, filter.ResourceID)
+		argCount++
+	}
+
+	if filter.Status != "" {
+		where = append(where, fmt.Sprintf("status = $%d", argCount))
+		args = append(args, filter.Status)
+		argCount++
+	}
+
+	if !filter.StartDate.IsZero() {
+		where = append(where, fmt.Sprintf("created_at >= $%d", argCount))
+		args = append(args, filter.StartDate)
+		argCount++
+	}
+
+	if !filter.EndDate.IsZero() {
+		where = append(where, fmt.Sprintf("created_at <= $%d", argCount))
+		args = append(args, filter.EndDate)
+		argCount++
+	}
+
+	return strings.Join(where, " AND "), args, argCount
+}
+
+func auditChainWhere(organizationUUID string) (string, []interface{}) {
+	if organizationUUID == "" {
+		return "organization_uuid IS NULL", nil
+	}
+	return "organization_uuid = $1", []interface{}{organizationUUID}
+}
+
+func auditChainKey(organizationUUID *string) string {
+	if organizationUUID == nil || *organizationUUID == "" {
+		return globalAuditChain
+	}
+	return *organizationUUID
+}
+
+func scanAuditLog(row rowScanner) (*domain.AuditLog, error) {
+	log := &domain.AuditLog{}
+	var details []byte
+	err := row.Scan(
+		&log.ID, &log.UserID, &log.UserEmail, &log.OrganizationUUID, &log.Action, &log.Resource,
+		&log.ResourceID, &details, &log.IPAddress, &log.UserAgent,
+		&log.Status, &log.CreatedAt, &log.Sequence, &log.PrevHash, &log.Hash,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(details) > 0 {
+		log.Details = details
+	}
+	return log, nil
+}
@@ -0,0 +1,309 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+)
+
+type AutonomousActionRepository struct {
+	db *sql.DB
+}
+
+func NewAutonomousActionRepository(db *sql.DB) *AutonomousActionRepository {
+	return &AutonomousActionRepository{db: db}
+}
+
+const autonomousActionColumns = `
+	id, organization_uuid, type, status, description, trigger, action, result, error,
+	before_state, after_state, undo_of, requested_by, decided_by, decided_at, decision_reason,
+	executed_by, executed_at, undone_at, created_at, updated_at
+`
+
+// Create grava uma nova ação autônoma
+func (r *AutonomousActionRepository) Create(action *domain.AutonomousAction) error {
+	actionJSON, err := json.Marshal(action.Action)
+	if err != nil {
+		return err
+	}
+	result, beforeState, afterState, err := marshalActionState(action)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO autonomous_actions (
+			organization_uuid, type, status, description, trigger, action, result, error,
+			before_state, after_state, undo_of, requested_by, decided_by, decided_at, decision_reason,
+			executed_by, executed_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+		RETURNING id, created_at, updated_at
+	`
+
+	return r.db.QueryRow(
+		query,
+		action.OrganizationUUID,
+		action.Type,
+		action.Status,
+		action.Description,
+		action.Trigger,
+		actionJSON,
+		result,
+		nullString(action.Error),
+		beforeState,
+		afterState,
+		nullString(action.UndoOf),
+		action.RequestedBy,
+		nullString(action.DecidedBy),
+		action.DecidedAt,
+		nullString(action.DecisionReason),
+		nullString(action.ExecutedBy),
+		action.ExecutedAt,
+	).Scan(&action.ID, &action.CreatedAt, &action.UpdatedAt)
+}
+
+// GetByID retorna uma ação da organização (nil se não existir)
+func (r *AutonomousActionRepository) GetByID(organizationUUID, id string) (*domain.AutonomousAction, error) {
+	query := `SELECT ` + autonomousActionColumns + `
+		FROM autonomous_actions
+		WHERE organization_uuid = $1 AND id::text = $2
+	`
+
+	action, err := r.scan(r.db.QueryRow(query, organizationUUID, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return action, err
+}
+
+// List retorna as ações da organização, mais recentes primeiro, com o total para paginação
+func (r *AutonomousActionRepository) List(organizationUUID string, filter domain.AutonomousActionFilter) ([]domain.AutonomousAction, int, error) {
+	where := []string{"organization_uuid = $1"}
+	args := []interface{}{organizationUUID}
+	argCount := 2
+
+	if filter.Status != "" {
+		where = append(where, fmt.Sprintf("status = $%d", argCount))
+		args = append(args, filter.Status)
+		argCount++
+	}
+
+	if filter.Type != "" {
+		where = append(where, fmt.Sprintf("type = $%d", argCount))
+		args = append(args, filter.Type)
+		argCount++
+	}
+
+	whereClause := strings.Join(where, " AND ")
+
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM autonomous_actions WHERE "+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Size < 1 {
+		filter.Size = 20
+	}
+
+	query := fmt.Sprintf(`SELECT %s
+		FROM autonomous_actions
+		WHERE %s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d
+	`, autonomousActionColumns, whereClause, argCount, argCount+1)
+	args = append(args, filter.Size, (filter.Page-1)*filter.Size)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	actions := []domain.AutonomousAction{}
+	for rows.Next() {
+		action, err := r.scan(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		actions = append(actions, *action)
+	}
+
+	return actions, total, rows.Err()
+}
+
+// Update grava o estado de execução de uma ação
+func (r *AutonomousActionRepository) Update(action *domain.AutonomousAction) error {
+	result, beforeState, afterState, err := marshalActionState(action)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE autonomous_actions SET
+			status = $3,
+			result = $4,
+			error = $5,
+			before_state = $6,
+			after_state = $7,
+			executed_by = $8,
+			executed_at = $9,
+			undone_at = $10,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE organization_uuid = $1 AND id = $2
+		RETURNING updated_at
+	`
+
+	return r.db.QueryRow(
+		query,
+		action.OrganizationUUID,
+		action.ID,
+		action.Status,
+		result,
+		nullString(action.Error),
+		beforeState,
+		afterState,
+		nullString(action.ExecutedBy),
+		action.ExecutedAt,
+		action.UndoneAt,
+	).Scan(&action.UpdatedAt)
+}
+
+// Decide muda o status de uma ação registrando quem decidiu, apenas se ela ainda estiver no status esperado.
+// Retorna false quando a ação já foi decidida por outra requisição.
+func (r *AutonomousActionRepository) Decide(organizationUUID, id, fromStatus, toStatus, decidedBy, reason string) (bool, error) {
+	result, err := r.db.Exec(`
+		UPDATE autonomous_actions SET
+			status = $4,
+			decided_by = $5,
+			decided_at = CURRENT_TIMESTAMP,
+			decision_reason = $6,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE organization_uuid = $1 AND id::text = $2 AND status = $3
+	`, organizationUUID, id, fromStatus, toStatus, decidedBy, nullString(reason))
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// FailInterrupted marca como falhas as ações que estavam aprovadas ou em execução quando o processo parou
+func (r *AutonomousActionRepository) FailInterrupted() (int64, error) {
+	result, err := r.db.Exec(`
+		UPDATE autonomous_actions SET
+			status = 'failed',
+			error = 'execution interrupted before completion',
+			updated_at = CURRENT_TIMESTAMP
+		WHERE status IN ('approved', 'executing')
+	`)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (r *AutonomousActionRepository) scan(row rowScanner) (*domain.AutonomousAction, error) {
+	var action domain.AutonomousAction
+	var description, errorMessage, undoOf, decidedBy, decisionReason, executedBy sql.NullString
+	var actionJSON, result, beforeState, afterState []byte
+	var decidedAt, executedAt, undoneAt sql.NullTime
+
+	err := row.Scan(
+		&action.ID,
+		&action.OrganizationUUID,
+		&action.Type,
+		&action.Status,
+		&description,
+		&action.Trigger,
+		&actionJSON,
+		&result,
+		&errorMessage,
+		&beforeState,
+		&afterState,
+		&undoOf,
+		&action.RequestedBy,
+		&decidedBy,
+		&decidedAt,
+		&decisionReason,
+		&executedBy,
+		&executedAt,
+		&undoneAt,
+		&action.CreatedAt,
+		&action.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	action.Description = description.String
+	action.Error = errorMessage.String
+	action.UndoOf = undoOf.String
+	action.DecidedBy = decidedBy.String
+	action.DecisionReason = decisionReason.String
+	action.ExecutedBy = executedBy.String
+	if decidedAt.Valid {
+		action.DecidedAt = &decidedAt.Time
+	}
+	if executedAt.Valid {
+		action.ExecutedAt = &executedAt.Time
+	}
+	if undoneAt.Valid {
+		action.UndoneAt = &undoneAt.Time
+	}
+
+	if err := json.Unmarshal(actionJSON, &action.Action); err != nil {
+		return nil, fmt.Errorf("invalid action payload: %w", err)
+	}
+	if len(result) > 0 {
+		if err := json.Unmarshal(result, &action.Result); err != nil {
+			return nil, fmt.Errorf("invalid action result: %w", err)
+		}
+	}
+	if len(beforeState) > 0 {
+		if err := json.Unmarshal(beforeState, &action.BeforeState); err != nil {
+			return nil, fmt.Errorf("invalid action before state: %w", err)
+		}
+	}
+	if len(afterState) > 0 {
+		if err := json.Unmarshal(afterState, &action.AfterState); err != nil {
+			return nil, fmt.Errorf("invalid action after state: %w", err)
+		}
+	}
+
+	return &action, nil
+}
+
+// marshalActionState serializa os campos JSONB opcionais (nil vira NULL)
+func marshalActionState(action *domain.AutonomousAction) (result, beforeState, afterState []byte, err error) {
+	if action.Result != nil {
+		if result, err = json.Marshal(action.Result); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	if action.BeforeState != nil {
+		if beforeState, err = json.Marshal(action.BeforeState); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	if action.AfterState != nil {
+		if afterState, err = json.Marshal(action.AfterState); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	return result, beforeState, afterState, nil
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
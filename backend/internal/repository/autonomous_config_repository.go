@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+)
+
+type AutonomousConfigRepository struct {
+	db *sql.DB
+}
+
+func NewAutonomousConfigRepository(db *sql.DB) *AutonomousConfigRepository {
+	return &AutonomousConfigRepository{db: db}
+}
+
+// Get retorna a configuração de ações autônomas da organização (nil se nunca foi configurada)
+func (r *AutonomousConfigRepository) Get(organizationUUID string) (*domain.AutonomousConfig, error) {
+	var config domain.AutonomousConfig
+	var allowedActions, notificationChannels []byte
+
+	err := r.db.QueryRow(`
+		SELECT enabled, auto_execute, require_approval, allowed_actions, notification_channels
+		FROM autonomous_configs
+		WHERE organization_uuid = $1
+	`, organizationUUID).Scan(
+		&config.Enabled,
+		&config.AutoExecute,
+		&config.RequireApproval,
+		&allowedActions,
+		&notificationChannels,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(allowedActions, &config.AllowedActions); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(notificationChannels, &config.NotificationChannels); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+// Upsert grava a configuração de ações autônomas da organização
+func (r *AutonomousConfigRepository) Upsert(organizationUUID string, config *domain.AutonomousConfig, updatedBy string) error {
+	allowedActions := config.AllowedActions
+	if allowedActions == nil {
+		allowedActions = []string{}
+	}
+	notificationChannels := config.NotificationChannels
+	if notificationChannels == nil {
+		notificationChannels = []string{}
+	}
+
+	allowedActionsJSON, err := json.Marshal(allowedActions)
+	if err != nil {
+		return err
+	}
+	notificationChannelsJSON, err := json.Marshal(notificationChannels)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(`
+		INSERT INTO autonomous_configs (
+			organization_uuid, enabled, auto_execute, require_approval,
+			allowed_actions, notification_channels, updated_by
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (organization_uuid) DO UPDATE SET
+			enabled = EXCLUDED.enabled,
+			auto_execute = EXCLUDED.auto_execute,
+			require_approval = EXCLUDED.require_approval,
+			allowed_actions = EXCLUDED.allowed_actions,
+			notification_channels = EXCLUDED.notification_channels,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = CURRENT_TIMESTAMP
+	`, organizationUUID, config.Enabled, config.AutoExecute, config.RequireApproval,
+		allowedActionsJSON, notificationChannelsJSON, updatedBy)
+
+	return err
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+)
+
+type DataKeyRepository struct {
+	db *sql.DB
+}
+
+func NewDataKeyRepository(db *sql.DB) *DataKeyRepository {
+	return &DataKeyRepository{db: db}
+}
+
+// GetActive retorna a chave de dados ativa de uma organização
+func (r *DataKeyRepository) GetActive(organizationUUID string) (*domain.OrganizationDataKey, error) {
+	query := `
+		SELECT id, organization_uuid, version, wrapped_key, master_key_id, active, created_at
+		FROM organization_data_keys
+		WHERE organization_uuid = $1 AND active = true
+	`
+
+	key, err := r.scan(r.db.QueryRow(query, organizationUUID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return key, err
+}
+
+// GetByVersion retorna uma versão específica da chave de dados de uma organização
+func (r *DataKeyRepository) GetByVersion(organizationUUID string, version int) (*domain.OrganizationDataKey, error) {
+	query := `
+		SELECT id, organization_uuid, version, wrapped_key, master_key_id, active, created_at
+		FROM organization_data_keys
+		WHERE organization_uuid = $1 AND version = $2
+	`
+
+	key, err := r.scan(r.db.QueryRow(query, organizationUUID, version))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("data key version %d not found", version)
+	}
+	return key, err
+}
+
+// GetAll retorna todas as chaves de dados de todas as organizações
+func (r *DataKeyRepository) GetAll() ([]domain.OrganizationDataKey, error) {
+	query := `
+		SELECT id, organization_uuid, version, wrapped_key, master_key_id, active, created_at
+		FROM organization_data_keys
+		ORDER BY organization_uuid, version
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []domain.OrganizationDataKey
+	for rows.Next() {
+		key, err := r.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, *key)
+	}
+
+	return keys, rows.Err()
+}
+
+// Create adiciona uma nova versão da chave de dados e a torna a chave ativa
+func (r *DataKeyRepository) Create(organizationUUID string, wrappedKey []byte, masterKeyID string) (*domain.OrganizationDataKey, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Serializa rotações concorrentes da mesma organização
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtext($1))`, organizationUUID); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(`
+		UPDATE organization_data_keys SET active = false
+		WHERE organization_uuid = $1 AND active = true
+	`, organizationUUID); err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO organization_data_keys (organization_uuid, version, wrapped_key, master_key_id, active)
+		SELECT $1, COALESCE(MAX(version), 0) + 1, $2, $3, true
+		FROM organization_data_keys
+		WHERE organization_uuid = $1
+		RETURNING id, organization_uuid, version, wrapped_key, master_key_id, active, created_at
+	`
+
+	key, err := r.scan(tx.QueryRow(query, organizationUUID, wrappedKey, masterKeyID))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// UpdateWrappedKey substitui a chave criptografada após rotação da master key
+func (r *DataKeyRepository) UpdateWrappedKey(id int, wrappedKey []byte, masterKeyID string) error {
+	query := `
+		UPDATE organization_data_keys
+		SET wrapped_key = $1, master_key_id = $2
+		WHERE id = $3
+	`
+
+	result, err := r.db.Exec(query, wrappedKey, masterKeyID, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("data key not found")
+	}
+
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func (r *DataKeyRepository) scan(row rowScanner) (*domain.OrganizationDataKey, error) {
+	var key domain.OrganizationDataKey
+	err := row.Scan(
+		&key.ID,
+		&key.OrganizationUUID,
+		&key.Version,
+		&key.WrappedKey,
+		&key.MasterKeyID,
+		&key.Active,
+		&key.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
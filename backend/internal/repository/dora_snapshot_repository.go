@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+)
+
+type DORASnapshotRepository struct {
+	db *sql.DB
+}
+
+func NewDORASnapshotRepository(db *sql.DB) *DORASnapshotRepository {
+	return &DORASnapshotRepository{db: db}
+}
+
+// Upsert grava (ou substitui) o snapshot de um dia para um escopo
+func (r *DORASnapshotRepository) Upsert(snapshot *domain.DORASnapshot) error {
+	query := `
+		INSERT INTO dora_snapshots (
+			organization_uuid, scope, scope_key, snapshot_date,
+			deployments, failed_deployments, lead_time_minutes_total, lead_time_samples,
+			restore_minutes_total, restores
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (organization_uuid, scope, scope_key, snapshot_date) DO UPDATE SET
+			deployments = EXCLUDED.deployments,
+			failed_deployments = EXCLUDED.failed_deployments,
+			lead_time_minutes_total = EXCLUDED.lead_time_minutes_total,
+			lead_time_samples = EXCLUDED.lead_time_samples,
+			restore_minutes_total = EXCLUDED.restore_minutes_total,
+			restores = EXCLUDED.restores,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING id, created_at, updated_at
+	`
+
+	return r.db.QueryRow(
+		query,
+		snapshot.OrganizationUUID,
+		snapshot.Scope,
+		snapshot.ScopeKey,
+		snapshot.Date,
+		snapshot.Deployments,
+		snapshot.FailedDeployments,
+		snapshot.LeadTimeMinutesTotal,
+		snapshot.LeadTimeSamples,
+		snapshot.RestoreMinutesTotal,
+		snapshot.Restores,
+	).Scan(&snapshot.ID, &snapshot.CreatedAt, &snapshot.UpdatedAt)
+}
+
+// List retorna os snapshots de um escopo entre duas datas (inclusive), em ordem cronológica
+func (r *DORASnapshotRepository) List(organizationUUID, scope, scopeKey string, from, to time.Time) ([]domain.DORASnapshot, error) {
+	query := `
+		SELECT id, organization_uuid, scope, scope_key, snapshot_date,
+			deployments, failed_deployments, lead_time_minutes_total, lead_time_samples,
+			restore_minutes_total, restores, created_at, updated_at
+		FROM dora_snapshots
+		WHERE organization_uuid = $1 AND scope = $2 AND scope_key = $3
+			AND snapshot_date BETWEEN $4 AND $5
+		ORDER BY snapshot_date ASC
+	`
+
+	return r.query(query, organizationUUID, scope, scopeKey, from, to)
+}
+
+// ListByScope retorna os snapshots de todas as chaves de um escopo entre duas datas
+func (r *DORASnapshotRepository) ListByScope(organizationUUID, scope string, from, to time.Time) ([]domain.DORASnapshot, error) {
+	query := `
+		SELECT id, organization_uuid, scope, scope_key, snapshot_date,
+			deployments, failed_deployments, lead_time_minutes_total, lead_time_samples,
+			restore_minutes_total, restores, created_at, updated_at
+		FROM dora_snapshots
+		WHERE organization_uuid = $1 AND scope = $2
+			AND snapshot_date BETWEEN $3 AND $4
+		ORDER BY scope_key ASC, snapshot_date ASC
+	`
+
+	return r.query(query, organizationUUID, scope, from, to)
+}
+
+// GetLatestDate retorna a data do snapshot mais recente da organização
+func (r *DORASnapshotRepository) GetLatestDate(organizationUUID string) (*time.Time, error) {
+	var latest sql.NullTime
+	err := r.db.QueryRow(`
+		SELECT MAX(snapshot_date) FROM dora_snapshots WHERE organization_uuid = $1
+	`, organizationUUID).Scan(&latest)
+	if err != nil {
+		return nil, err
+	}
+	if !latest.Valid {
+		return nil, nil
+	}
+	return &latest.Time, nil
+}
+
+func (r *DORASnapshotRepository) query(query string, args ...interface{}) ([]domain.DORASnapshot, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var snapshots []domain.DORASnapshot
+	for rows.Next() {
+		var snapshot domain.DORASnapshot
+		err := rows.Scan(
+			&snapshot.ID,
+			&snapshot.OrganizationUUID,
+			&snapshot.Scope,
+			&snapshot.ScopeKey,
+			&snapshot.Date,
+			&snapshot.Deployments,
+			&snapshot.FailedDeployments,
+			&snapshot.LeadTimeMinutesTotal,
+			&snapshot.LeadTimeSamples,
+			&snapshot.RestoreMinutesTotal,
+			&snapshot.Restores,
+			&snapshot.CreatedAt,
+			&snapshot.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, rows.Err()
+}
@@ -0,0 +1,373 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/lib/pq"
+)
+
+type IncidentRepository struct {
+	db *sql.DB
+}
+
+func NewIncidentRepository(db *sql.DB) *IncidentRepository {
+	return &IncidentRepository{db: db}
+}
+
+const incidentColumns = `
+	id, organization_uuid, title, description, service_name, squad, severity, status, source,
+	external_id, opened_by, opened_at, acknowledged_by, acknowledged_at, resolved_by, resolved_at,
+	created_at, updated_at
+`
+
+// Create grava um novo incidente e a entrada de abertura da linha do tempo
+func (r *IncidentRepository) Create(incident *domain.Incident, entry *domain.IncidentTimelineEntry) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(`
+		INSERT INTO incidents (
+			organization_uuid, title, description, service_name, squad, severity, status, source,
+			external_id, opened_by, opened_at, acknowledged_by, acknowledged_at, resolved_by, resolved_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		RETURNING id, created_at, updated_at
+	`,
+		incident.OrganizationUUID,
+		incident.Title,
+		nullString(incident.Description),
+		nullString(incident.ServiceName),
+		nullString(incident.Squad),
+		incident.Severity,
+		incident.Status,
+		incident.Source,
+		nullString(incident.ExternalID),
+		incident.OpenedBy,
+		incident.OpenedAt,
+		nullString(incident.AcknowledgedBy),
+		incident.AcknowledgedAt,
+		nullString(incident.ResolvedBy),
+		incident.ResolvedAt,
+	).Scan(&incident.ID, &incident.CreatedAt, &incident.UpdatedAt)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		// Já existe um incidente não resolvido para o mesmo alerta
+		return &domain.ConflictError{Resource: "incident", Field: "externalId", Value: incident.ExternalID}
+	}
+	if err != nil {
+		return err
+	}
+
+	entry.IncidentID = incident.ID
+	if err := insertTimelineEntry(tx, entry); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetByID retorna um incidente da organização (nil se não existir)
+func (r *IncidentRepository) GetByID(organizationUUID, id string) (*domain.Incident, error) {
+	query := `SELECT ` + incidentColumns + `
+		FROM incidents
+		WHERE organization_uuid = $1 AND id::text = $2
+	`
+
+	incident, err := r.scan(r.db.QueryRow(query, organizationUUID, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return incident, err
+}
+
+// GetUnresolvedByExternalID retorna o incidente não resolvido aberto por um alerta (nil se não existir)
+func (r *IncidentRepository) GetUnresolvedByExternalID(organizationUUID, source, externalID string) (*domain.Incident, error) {
+	query := `SELECT ` + incidentColumns + `
+		FROM incidents
+		WHERE organization_uuid = $1 AND source = $2 AND external_id = $3 AND status <> 'resolved'
+	`
+
+	incident, err := r.scan(r.db.QueryRow(query, organizationUUID, source, externalID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return incident, err
+}
+
+// List retorna os incidentes da organização, mais recentes primeiro, com o total para paginação
+func (r *IncidentRepository) List(organizationUUID string, filter domain.IncidentFilter) ([]domain.Incident, int, error) {
+	where := []string{"organization_uuid = $1"}
+	args := []interface{}{organizationUUID}
+	argCount := 2
+
+	add := func(condition string, value interface{}) {
+		where = append(where, fmt.Sprintf(condition, argCount))
+		args = append(args, value)
+		argCount++
+	}
+
+	if filter.Status != "" {
+		add("status = $%d", filter.Status)
+	}
+	if filter.Severity != "" {
+		add("severity = $%d", filter.Severity)
+	}
+	if filter.ServiceName != "" {
+		add("service_name = $%d", filter.ServiceName)
+	}
+	if filter.Squad != "" {
+		add("squad = $%d", filter.Squad)
+	}
+	if filter.From != nil {
+		add("opened_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		add("opened_at <= $%d", *filter.To)
+	}
+
+	whereClause := strings.Join(where, " AND ")
+
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM incidents WHERE "+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Size < 1 {
+		filter.Size = 20
+	}
+
+	query := fmt.Sprintf(`SELECT %s
+		FROM incidents
+		WHERE %s
+		ORDER BY opened_at DESC
+		LIMIT $%d OFFSET $%d
+	`, incidentColumns, whereClause, argCount, argCount+1)
+	args = append(args, filter.Size, (filter.Page-1)*filter.Size)
+
+	incidents, err := r.query(query, args...)
+	return incidents, total, err
+}
+
+// ListActive retorna os incidentes abertos, resolvidos ou ainda não resolvidos entre duas datas
+func (r *IncidentRepository) ListActive(organizationUUID string, from, to time.Time) ([]domain.Incident, error) {
+	query := `SELECT ` + incidentColumns + `
+		FROM incidents
+		WHERE organization_uuid = $1
+			AND (opened_at BETWEEN $2 AND $3 OR resolved_at BETWEEN $2 AND $3 OR status <> 'resolved')
+		ORDER BY opened_at ASC
+	`
+
+	return r.query(query, organizationUUID, from, to)
+}
+
+// Update grava título, descrição, serviço e severidade de um incidente com a entrada da linha do tempo
+func (r *IncidentRepository) Update(incident *domain.Incident, entry *domain.IncidentTimelineEntry) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(`
+		UPDATE incidents SET
+			title = $3,
+			description = $4,
+			service_name = $5,
+			squad = $6,
+			severity = $7,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE organization_uuid = $1 AND id = $2
+		RETURNING updated_at
+	`,
+		incident.OrganizationUUID,
+		incident.ID,
+		incident.Title,
+		nullString(incident.Description),
+		nullString(incident.ServiceName),
+		nullString(incident.Squad),
+		incident.Severity,
+	).Scan(&incident.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	if err := insertTimelineEntry(tx, entry); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Transition grava o novo status (e quem reconheceu ou resolveu) de um incidente, apenas se ele
+// ainda estiver em um dos status esperados. Retorna false quando outra requisição mudou o status antes.
+func (r *IncidentRepository) Transition(incident *domain.Incident, fromStatuses []string, entry *domain.IncidentTimelineEntry) (bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(`
+		UPDATE incidents SET
+			status = $3,
+			acknowledged_by = $4,
+			acknowledged_at = $5,
+			resolved_by = $6,
+			resolved_at = $7,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE organization_uuid = $1 AND id = $2 AND status = ANY($8)
+		RETURNING updated_at
+	`,
+		incident.OrganizationUUID,
+		incident.ID,
+		incident.Status,
+		nullString(incident.AcknowledgedBy),
+		incident.AcknowledgedAt,
+		nullString(incident.ResolvedBy),
+		incident.ResolvedAt,
+		pq.Array(fromStatuses),
+	).Scan(&incident.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err := insertTimelineEntry(tx, entry); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+// AddTimelineEntry grava uma nota ou alerta na linha do tempo de um incidente
+func (r *IncidentRepository) AddTimelineEntry(entry *domain.IncidentTimelineEntry) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertTimelineEntry(tx, entry); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`UPDATE incidents SET updated_at = CURRENT_TIMESTAMP WHERE id = $1`, entry.IncidentID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ListTimeline retorna a linha do tempo de um incidente em ordem cronológica
+func (r *IncidentRepository) ListTimeline(incidentID string) ([]domain.IncidentTimelineEntry, error) {
+	rows, err := r.db.Query(`
+		SELECT id, incident_id, type, message, author, created_at
+		FROM incident_timeline
+		WHERE incident_id = $1
+		ORDER BY created_at ASC, id ASC
+	`, incidentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []domain.IncidentTimelineEntry{}
+	for rows.Next() {
+		var entry domain.IncidentTimelineEntry
+		if err := rows.Scan(&entry.ID, &entry.IncidentID, &entry.Type, &entry.Message, &entry.Author, &entry.CreatedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+func insertTimelineEntry(tx *sql.Tx, entry *domain.IncidentTimelineEntry) error {
+	createdAt := entry.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	return tx.QueryRow(`
+		INSERT INTO incident_timeline (incident_id, type, message, author, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`, entry.IncidentID, entry.Type, entry.Message, entry.Author, createdAt).Scan(&entry.ID, &entry.CreatedAt)
+}
+
+func (r *IncidentRepository) query(query string, args ...interface{}) ([]domain.Incident, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	incidents := []domain.Incident{}
+	for rows.Next() {
+		incident, err := r.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		incidents = append(incidents, *incident)
+	}
+
+	return incidents, rows.Err()
+}
+
+func (r *IncidentRepository) scan(row rowScanner) (*domain.Incident, error) {
+	var incident domain.Incident
+	var description, serviceName, squad, externalID, acknowledgedBy, resolvedBy sql.NullString
+	var acknowledgedAt, resolvedAt sql.NullTime
+
+	err := row.Scan(
+		&incident.ID,
+		&incident.OrganizationUUID,
+		&incident.Title,
+		&description,
+		&serviceName,
+		&squad,
+		&incident.Severity,
+		&incident.Status,
+		&incident.Source,
+		&externalID,
+		&incident.OpenedBy,
+		&incident.OpenedAt,
+		&acknowledgedBy,
+		&acknowledgedAt,
+		&resolvedBy,
+		&resolvedAt,
+		&incident.CreatedAt,
+		&incident.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	incident.Description = description.String
+	incident.ServiceName = serviceName.String
+	incident.Squad = squad.String
+	incident.ExternalID = externalID.String
+	incident.AcknowledgedBy = acknowledgedBy.String
+	incident.ResolvedBy = resolvedBy.String
+	if acknowledgedAt.Valid {
+		incident.AcknowledgedAt = &acknowledgedAt.Time
+	}
+	if resolvedAt.Valid {
+		incident.ResolvedAt = &resolvedAt.Time
+	}
+
+	return &incident, nil
+}
@@ -246,3 +246,29 @@ func (r *IntegrationRepository) Delete(id int, organizationUUID string) error {
 
 	return nil
 }
+
+func (r *IntegrationRepository) GetOrganizationUUIDs() ([]string, error) {
+	query := `
+		SELECT DISTINCT organization_uuid
+		FROM integrations
+		WHERE organization_uuid IS NOT NULL
+		ORDER BY organization_uuid
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var organizationUUIDs []string
+	for rows.Next() {
+		var organizationUUID string
+		if err := rows.Scan(&organizationUUID); err != nil {
+			return nil, err
+		}
+		organizationUUIDs = append(organizationUUIDs, organizationUUID)
+	}
+
+	return organizationUUIDs, nil
+}
@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/lib/pq"
+)
+
+type NotificationChannelRepository struct {
+	db *sql.DB
+}
+
+func NewNotificationChannelRepository(db *sql.DB) *NotificationChannelRepository {
+	return &NotificationChannelRepository{db: db}
+}
+
+const notificationChannelColumns = `
+	id, organization_uuid, name, type, enabled, config, title_template, body_template,
+	created_by, created_at, updated_at
+`
+
+// List retorna os canais de notificação da organização
+func (r *NotificationChannelRepository) List(organizationUUID string) ([]domain.NotificationChannel, error) {
+	rows, err := r.db.Query(`SELECT `+notificationChannelColumns+`
+		FROM notification_channels
+		WHERE organization_uuid = $1
+		ORDER BY name ASC
+	`, organizationUUID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	channels := []domain.NotificationChannel{}
+	for rows.Next() {
+		channel, err := r.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		channels = append(channels, *channel)
+	}
+
+	return channels, rows.Err()
+}
+
+// GetByID retorna um canal de notificação da organização (nil se não existir)
+func (r *NotificationChannelRepository) GetByID(organizationUUID string, id int) (*domain.NotificationChannel, error) {
+	channel, err := r.scan(r.db.QueryRow(`SELECT `+notificationChannelColumns+`
+		FROM notification_channels
+		WHERE organization_uuid = $1 AND id = $2
+	`, organizationUUID, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return channel, err
+}
+
+// Create grava um novo canal de notificação
+func (r *NotificationChannelRepository) Create(channel *domain.NotificationChannel) error {
+	config, err := json.Marshal(channel.Config)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.QueryRow(`
+		INSERT INTO notification_channels (
+			organization_uuid, name, type, enabled, config, title_template, body_template, created_by
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at, updated_at
+	`,
+		channel.OrganizationUUID,
+		channel.Name,
+		channel.Type,
+		channel.Enabled,
+		config,
+		nullString(channel.TitleTemplate),
+		nullString(channel.BodyTemplate),
+		channel.CreatedBy,
+	).Scan(&channel.ID, &channel.CreatedAt, &channel.UpdatedAt)
+
+	return mapNotificationChannelConflict(err, channel.Name)
+}
+
+// Update grava as alterações de um canal de notificação
+func (r *NotificationChannelRepository) Update(channel *domain.NotificationChannel) error {
+	config, err := json.Marshal(channel.Config)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.QueryRow(`
+		UPDATE notification_channels SET
+			name = $3,
+			type = $4,
+			enabled = $5,
+			config = $6,
+			title_template = $7,
+			body_template = $8,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE organization_uuid = $1 AND id = $2
+		RETURNING updated_at
+	`,
+		channel.OrganizationUUID,
+		channel.ID,
+		channel.Name,
+		channel.Type,
+		channel.Enabled,
+		config,
+		nullString(channel.TitleTemplate),
+		nullString(channel.BodyTemplate),
+	).Scan(&channel.UpdatedAt)
+
+	return mapNotificationChannelConflict(err, channel.Name)
+}
+
+// Delete remove um canal de notificação e o retira das regras que o usam; retorna false se ele não existir
+func (r *NotificationChannelRepository) Delete(organizationUUID string, id int) (bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`DELETE FROM notification_channels WHERE organization_uuid = $1 AND id = $2`, organizationUUID, id)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil || affected == 0 {
+		return false, err
+	}
+
+	if _, err := tx.Exec(`
+		UPDATE notification_rules
+		SET channel_ids = array_remove(channel_ids, $2), updated_at = CURRENT_TIMESTAMP
+		WHERE organization_uuid = $1 AND $2 = ANY(channel_ids)
+	`, organizationUUID, id); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+func (r *NotificationChannelRepository) scan(row rowScanner) (*domain.NotificationChannel, error) {
+	var channel domain.NotificationChannel
+	var config []byte
+	var titleTemplate, bodyTemplate sql.NullString
+
+	err := row.Scan(
+		&channel.ID,
+		&channel.OrganizationUUID,
+		&channel.Name,
+		&channel.Type,
+		&channel.Enabled,
+		&config,
+		&titleTemplate,
+		&bodyTemplate,
+		&channel.CreatedBy,
+		&channel.CreatedAt,
+		&channel.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(config, &channel.Config); err != nil {
+		return nil, err
+	}
+	channel.TitleTemplate = titleTemplate.String
+	channel.BodyTemplate = bodyTemplate.String
+
+	return &channel, nil
+}
+
+// mapNotificationChannelConflict converte a violação do nome único em ConflictError
+func mapNotificationChannelConflict(err error, name string) error {
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+		return &domain.ConflictError{Resource: "notification channel", Field: "name", Value: name}
+	}
+	return err
+}
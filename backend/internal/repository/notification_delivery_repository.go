@@ -0,0 +1,274 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+)
+
+type NotificationDeliveryRepository struct {
+	db *sql.DB
+}
+
+func NewNotificationDeliveryRepository(db *sql.DB) *NotificationDeliveryRepository {
+	return &NotificationDeliveryRepository{db: db}
+}
+
+const notificationDeliveryColumns = `
+	id, organization_uuid, rule_id, channel_id, channel_type, event_type, dedup_key, event, status,
+	attempts, next_attempt_at, last_error, sent_at, created_at, updated_at
+`
+
+// Enqueue grava uma entrega pendente. Se dedupSince for informado e o mesmo evento (dedup_key) já
+// tiver sido enfileirado para o mesmo canal desde então, nada é gravado e retorna false.
+func (r *NotificationDeliveryRepository) Enqueue(delivery *domain.NotificationDelivery, dedupSince *time.Time) (bool, error) {
+	event, err := json.Marshal(delivery.Event)
+	if err != nil {
+		return false, err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	// Serializa publicações concorrentes do mesmo evento para o mesmo canal (reenvios do
+	// Alertmanager, receptores em paralelo): sem a trava, as duas veriam NOT EXISTS e gravariam
+	if dedupSince != nil {
+		channelID := ""
+		if delivery.ChannelID != nil {
+			channelID = fmt.Sprint(*delivery.ChannelID)
+		}
+		lockKey := strings.Join([]string{delivery.OrganizationUUID, delivery.ChannelType, channelID, delivery.DedupKey}, "|")
+		if _, err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtext($1))`, lockKey); err != nil {
+			return false, err
+		}
+	}
+
+	err = tx.QueryRow(`
+		INSERT INTO notification_deliveries (
+			organization_uuid, rule_id, channel_id, channel_type, event_type, dedup_key, event, next_attempt_at
+		)
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8
+		WHERE $9::timestamp IS NULL OR NOT EXISTS (
+			SELECT 1 FROM notification_deliveries
+			WHERE organization_uuid = $1
+				AND channel_type = $4
+				AND channel_id IS NOT DISTINCT FROM $3
+				AND dedup_key = $6
+				AND created_at >= $9
+		)
+		RETURNING id, status, attempts, created_at, updated_at
+	`,
+		delivery.OrganizationUUID,
+		delivery.RuleID,
+		delivery.ChannelID,
+		delivery.ChannelType,
+		delivery.EventType,
+		delivery.DedupKey,
+		event,
+		delivery.NextAttemptAt,
+		dedupSince,
+	).Scan(&delivery.ID, &delivery.Status, &delivery.Attempts, &delivery.CreatedAt, &delivery.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ClaimDue reserva até limit entregas pendentes cuja tentativa já venceu, contando a tentativa.
+// A próxima tentativa é adiada por lease: se o processo cair durante o envio, a entrega volta à fila.
+func (r *NotificationDeliveryRepository) ClaimDue(limit int, lease time.Duration) ([]domain.NotificationDelivery, error) {
+	return r.query(`
+		UPDATE notification_deliveries SET
+			attempts = attempts + 1,
+			next_attempt_at = CURRENT_TIMESTAMP + $2 * INTERVAL '1 second',
+			updated_at = CURRENT_TIMESTAMP
+		FROM (
+			SELECT id AS due_id
+			FROM notification_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= CURRENT_TIMESTAMP
+			ORDER BY next_attempt_at ASC
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		) due
+		WHERE id = due.due_id
+		RETURNING `+notificationDeliveryColumns,
+		limit, int(lease.Seconds()),
+	)
+}
+
+// MarkSent registra o envio de uma entrega
+func (r *NotificationDeliveryRepository) MarkSent(id int) error {
+	_, err := r.db.Exec(`
+		UPDATE notification_deliveries SET
+			status = 'sent',
+			last_error = NULL,
+			sent_at = CURRENT_TIMESTAMP,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`, id)
+	return err
+}
+
+// MarkFailed registra a falha de uma tentativa. Com nextAttemptAt a entrega continua pendente até
+// a nova tentativa; sem ele, falha definitivamente.
+func (r *NotificationDeliveryRepository) MarkFailed(id int, lastError string, nextAttemptAt *time.Time) error {
+	status := domain.NotificationDeliveryFailed
+	if nextAttemptAt != nil {
+		status = domain.NotificationDeliveryPending
+	}
+
+	_, err := r.db.Exec(`
+		UPDATE notification_deliveries SET
+			status = $2,
+			last_error = $3,
+			next_attempt_at = COALESCE($4, next_attempt_at),
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`, id, status, lastError, nextAttemptAt)
+	return err
+}
+
+// Retry devolve à fila uma entrega que falhou definitivamente, com novas tentativas
+func (r *NotificationDeliveryRepository) Retry(organizationUUID string, id int) error {
+	_, err := r.db.Exec(`
+		UPDATE notification_deliveries SET
+			status = 'pending',
+			attempts = 0,
+			next_attempt_at = CURRENT_TIMESTAMP,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE organization_uuid = $1 AND id = $2 AND status = 'failed'
+	`, organizationUUID, id)
+	return err
+}
+
+// GetByID retorna uma entrega da organização (nil se não existir)
+func (r *NotificationDeliveryRepository) GetByID(organizationUUID string, id int) (*domain.NotificationDelivery, error) {
+	deliveries, err := r.query(`SELECT `+notificationDeliveryColumns+`
+		FROM notification_deliveries
+		WHERE organization_uuid = $1 AND id = $2
+	`, organizationUUID, id)
+	if err != nil || len(deliveries) == 0 {
+		return nil, err
+	}
+	return &deliveries[0], nil
+}
+
+// List retorna as entregas da organização, mais recentes primeiro, com o total para paginação
+func (r *NotificationDeliveryRepository) List(organizationUUID string, filter domain.NotificationDeliveryFilter) ([]domain.NotificationDelivery, int, error) {
+	where := []string{"organization_uuid = $1"}
+	args := []interface{}{organizationUUID}
+	argCount := 2
+
+	add := func(condition string, value interface{}) {
+		where = append(where, fmt.Sprintf(condition, argCount))
+		args = append(args, value)
+		argCount++
+	}
+
+	if filter.Status != "" {
+		add("status = $%d", filter.Status)
+	}
+	if filter.EventType != "" {
+		add("event_type = $%d", filter.EventType)
+	}
+	if filter.ChannelID > 0 {
+		add("channel_id = $%d", filter.ChannelID)
+	}
+
+	whereClause := strings.Join(where, " AND ")
+
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM notification_deliveries WHERE "+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Size < 1 {
+		filter.Size = 20
+	}
+
+	query := fmt.Sprintf(`SELECT %s
+		FROM notification_deliveries
+		WHERE %s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d OFFSET $%d
+	`, notificationDeliveryColumns, whereClause, argCount, argCount+1)
+	args = append(args, filter.Size, (filter.Page-1)*filter.Size)
+
+	deliveries, err := r.query(query, args...)
+	return deliveries, total, err
+}
+
+func (r *NotificationDeliveryRepository) query(query string, args ...interface{}) ([]domain.NotificationDelivery, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []domain.NotificationDelivery{}
+	for rows.Next() {
+		var delivery domain.NotificationDelivery
+		var ruleID, channelID sql.NullInt64
+		var event []byte
+		var lastError sql.NullString
+		var sentAt sql.NullTime
+
+		err := rows.Scan(
+			&delivery.ID,
+			&delivery.OrganizationUUID,
+			&ruleID,
+			&channelID,
+			&delivery.ChannelType,
+			&delivery.EventType,
+			&delivery.DedupKey,
+			&event,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.NextAttemptAt,
+			&lastError,
+			&sentAt,
+			&delivery.CreatedAt,
+			&delivery.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(event, &delivery.Event); err != nil {
+			return nil, err
+		}
+		if ruleID.Valid {
+			id := int(ruleID.Int64)
+			delivery.RuleID = &id
+		}
+		if channelID.Valid {
+			id := int(channelID.Int64)
+			delivery.ChannelID = &id
+		}
+		delivery.LastError = lastError.String
+		if sentAt.Valid {
+			delivery.SentAt = &sentAt.Time
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
@@ -0,0 +1,228 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/lib/pq"
+)
+
+type NotificationRuleRepository struct {
+	db *sql.DB
+}
+
+func NewNotificationRuleRepository(db *sql.DB) *NotificationRuleRepository {
+	return &NotificationRuleRepository{db: db}
+}
+
+const notificationRuleColumns = `
+	id, organization_uuid, name, enabled, event_types, matchers, services, squads, severities, channels,
+	channel_ids, quiet_hours, dedup_window_minutes, open_incident, created_by, created_at, updated_at
+`
+
+// List retorna as regras de notificação da organização; apenas as habilitadas se enabledOnly
+func (r *NotificationRuleRepository) List(organizationUUID string, enabledOnly bool) ([]domain.NotificationRule, error) {
+	query := `SELECT ` + notificationRuleColumns + `
+		FROM notification_rules
+		WHERE organization_uuid = $1 AND (enabled OR NOT $2)
+		ORDER BY name ASC
+	`
+
+	rows, err := r.db.Query(query, organizationUUID, enabledOnly)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := []domain.NotificationRule{}
+	for rows.Next() {
+		rule, err := r.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, *rule)
+	}
+
+	return rules, rows.Err()
+}
+
+// GetByID retorna uma regra de notificação da organização (nil se não existir)
+func (r *NotificationRuleRepository) GetByID(organizationUUID string, id int) (*domain.NotificationRule, error) {
+	query := `SELECT ` + notificationRuleColumns + `
+		FROM notification_rules
+		WHERE organization_uuid = $1 AND id = $2
+	`
+
+	rule, err := r.scan(r.db.QueryRow(query, organizationUUID, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return rule, err
+}
+
+// Create grava uma nova regra de notificação
+func (r *NotificationRuleRepository) Create(rule *domain.NotificationRule) error {
+	matchers, quietHours, err := marshalNotificationRule(rule)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.QueryRow(`
+		INSERT INTO notification_rules (
+			organization_uuid, name, enabled, event_types, matchers, services, squads, severities, channels,
+			channel_ids, quiet_hours, dedup_window_minutes, open_incident, created_by
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING id, created_at, updated_at
+	`,
+		rule.OrganizationUUID,
+		rule.Name,
+		rule.Enabled,
+		pq.Array(rule.EventTypes),
+		matchers,
+		pq.Array(rule.Services),
+		pq.Array(rule.Squads),
+		pq.Array(rule.Severities),
+		pq.Array(rule.Channels),
+		pq.Array(int64s(rule.ChannelIDs)),
+		quietHours,
+		rule.DedupWindowMinutes,
+		rule.OpenIncident,
+		rule.CreatedBy,
+	).Scan(&rule.ID, &rule.CreatedAt, &rule.UpdatedAt)
+
+	return mapNotificationRuleConflict(err, rule.Name)
+}
+
+// Update grava as alterações de uma regra de notificação
+func (r *NotificationRuleRepository) Update(rule *domain.NotificationRule) error {
+	matchers, quietHours, err := marshalNotificationRule(rule)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.QueryRow(`
+		UPDATE notification_rules SET
+			name = $3,
+			enabled = $4,
+			event_types = $5,
+			matchers = $6,
+			services = $7,
+			squads = $8,
+			severities = $9,
+			channels = $10,
+			channel_ids = $11,
+			quiet_hours = $12,
+			dedup_window_minutes = $13,
+			open_incident = $14,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE organization_uuid = $1 AND id = $2
+		RETURNING updated_at
+	`,
+		rule.OrganizationUUID,
+		rule.ID,
+		rule.Name,
+		rule.Enabled,
+		pq.Array(rule.EventTypes),
+		matchers,
+		pq.Array(rule.Services),
+		pq.Array(rule.Squads),
+		pq.Array(rule.Severities),
+		pq.Array(rule.Channels),
+		pq.Array(int64s(rule.ChannelIDs)),
+		quietHours,
+		rule.DedupWindowMinutes,
+		rule.OpenIncident,
+	).Scan(&rule.UpdatedAt)
+
+	return mapNotificationRuleConflict(err, rule.Name)
+}
+
+// Delete remove uma regra de notificação; retorna false se ela não existir
+func (r *NotificationRuleRepository) Delete(organizationUUID string, id int) (bool, error) {
+	result, err := r.db.Exec(`DELETE FROM notification_rules WHERE organization_uuid = $1 AND id = $2`, organizationUUID, id)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+func (r *NotificationRuleRepository) scan(row rowScanner) (*domain.NotificationRule, error) {
+	var rule domain.NotificationRule
+	var matchers, quietHours []byte
+	var channelIDs []int64
+
+	err := row.Scan(
+		&rule.ID,
+		&rule.OrganizationUUID,
+		&rule.Name,
+		&rule.Enabled,
+		pq.Array(&rule.EventTypes),
+		&matchers,
+		pq.Array(&rule.Services),
+		pq.Array(&rule.Squads),
+		pq.Array(&rule.Severities),
+		pq.Array(&rule.Channels),
+		pq.Array(&channelIDs),
+		&quietHours,
+		&rule.DedupWindowMinutes,
+		&rule.OpenIncident,
+		&rule.CreatedBy,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(matchers, &rule.Matchers); err != nil {
+		return nil, err
+	}
+	if quietHours != nil {
+		if err := json.Unmarshal(quietHours, &rule.QuietHours); err != nil {
+			return nil, err
+		}
+	}
+	rule.ChannelIDs = make([]int, 0, len(channelIDs))
+	for _, id := range channelIDs {
+		rule.ChannelIDs = append(rule.ChannelIDs, int(id))
+	}
+
+	return &rule, nil
+}
+
+// marshalNotificationRule serializa as colunas JSONB da regra; quiet_hours é NULL quando não definido
+func marshalNotificationRule(rule *domain.NotificationRule) ([]byte, interface{}, error) {
+	matchers, err := json.Marshal(rule.Matchers)
+	if err != nil {
+		return nil, nil, err
+	}
+	if rule.QuietHours == nil {
+		return matchers, nil, nil
+	}
+	quietHours, err := json.Marshal(rule.QuietHours)
+	if err != nil {
+		return nil, nil, err
+	}
+	return matchers, quietHours, nil
+}
+
+// int64s converte IDs para o tipo usado nos arrays INTEGER[] do Postgres
+func int64s(ids []int) []int64 {
+	result := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, int64(id))
+	}
+	return result
+}
+
+// mapNotificationRuleConflict converte a violação do nome único em ConflictError
+func mapNotificationRuleConflict(err error, name string) error {
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+		return &domain.ConflictError{Resource: "notification rule", Field: "name", Value: name}
+	}
+	return err
+}
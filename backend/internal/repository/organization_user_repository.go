@@ -10,30 +10,26 @@ import (
 )
 
 type OrganizationUserRepository struct {
-	coreDB *sql.DB
+	coreDB  *sql.DB
+	nodeDBs *database.TenantDBManager
 }
 
-func NewOrganizationUserRepository(coreDB *sql.DB) *OrganizationUserRepository {
-	return &OrganizationUserRepository{coreDB: coreDB}
-}
-
-func (r *OrganizationUserRepository) GetNodeDB(org *domain.Organization) (*sql.DB, error) {
-	return database.GetOrganizationNodeDB(org)
+func NewOrganizationUserRepository(coreDB *sql.DB, nodeDBs *database.TenantDBManager) *OrganizationUserRepository {
+	return &OrganizationUserRepository{coreDB: coreDB, nodeDBs: nodeDBs}
 }
 
 func (r *OrganizationUserRepository) GetAllUsers(org *domain.Organization) ([]domain.OrganizationUser, error) {
-	nodeDB, err := r.GetNodeDB(org)
+	nodeDB, err := r.nodeDBs.Reader(org)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to node database: %w", err)
 	}
-	defer nodeDB.Close()
 
-	schemaEscaped := database.EscapeSchemaName(org.UUID)
+	usersTable := database.OrganizationTable(org.UUID, "users")
 	query := fmt.Sprintf(`
 		SELECT id, email, name, avatar_url, password_hash, is_active, is_sso, sso_provider, sso_id, last_login_at, created_at, updated_at
-		FROM %s.users
+		FROM %s
 		ORDER BY created_at DESC
-	`, schemaEscaped)
+	`, usersTable)
 
 	rows, err := nodeDB.Query(query)
 	if err != nil {
@@ -91,18 +87,17 @@ func (r *OrganizationUserRepository) GetAllUsers(org *domain.Organization) ([]do
 }
 
 func (r *OrganizationUserRepository) GetUserByID(org *domain.Organization, userID string) (*domain.OrganizationUser, error) {
-	nodeDB, err := r.GetNodeDB(org)
+	nodeDB, err := r.nodeDBs.Reader(org)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to node database: %w", err)
 	}
-	defer nodeDB.Close()
 
-	schemaEscaped := database.EscapeSchemaName(org.UUID)
+	usersTable := database.OrganizationTable(org.UUID, "users")
 	query := fmt.Sprintf(`
 		SELECT id, email, name, avatar_url, password_hash, is_active, is_sso, sso_provider, sso_id, last_login_at, created_at, updated_at
-		FROM %s.users
+		FROM %s
 		WHERE id = $1
-	`, schemaEscaped)
+	`, usersTable)
 
 	var user domain.OrganizationUser
 	var passwordHash sql.NullString
@@ -153,18 +148,17 @@ func (r *OrganizationUserRepository) GetUserByID(org *domain.Organization, userI
 }
 
 func (r *OrganizationUserRepository) CreateUser(org *domain.Organization, user *domain.OrganizationUser) error {
-	nodeDB, err := r.GetNodeDB(org)
+	nodeDB, err := r.nodeDBs.Writer(org)
 	if err != nil {
 		return fmt.Errorf("failed to connect to node database: %w", err)
 	}
-	defer nodeDB.Close()
 
-	schemaEscaped := database.EscapeSchemaName(org.UUID)
+	usersTable := database.OrganizationTable(org.UUID, "users")
 	query := fmt.Sprintf(`
-		INSERT INTO %s.users (id, email, name, avatar_url, password_hash, is_active, is_sso, sso_provider, sso_id)
+		INSERT INTO %s (id, email, name, avatar_url, password_hash, is_active, is_sso, sso_provider, sso_id)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		RETURNING created_at, updated_at
-	`, schemaEscaped)
+	`, usersTable)
 
 	var passwordHash sql.NullString
 	var avatarURL sql.NullString
@@ -205,13 +199,12 @@ func (r *OrganizationUserRepository) CreateUser(org *domain.Organization, user *
 }
 
 func (r *OrganizationUserRepository) UpdateUser(org *domain.Organization, user *domain.OrganizationUser) error {
-	nodeDB, err := r.GetNodeDB(org)
+	nodeDB, err := r.nodeDBs.Writer(org)
 	if err != nil {
 		return fmt.Errorf("failed to connect to node database: %w", err)
 	}
-	defer nodeDB.Close()
 
-	schemaEscaped := database.EscapeSchemaName(org.UUID)
+	usersTable := database.OrganizationTable(org.UUID, "users")
 	
 	setParts := []string{}
 	args := []interface{}{}
@@ -243,24 +236,23 @@ func (r *OrganizationUserRepository) UpdateUser(org *domain.Organization, user *
 	args = append(args, user.ID)
 
 	query := fmt.Sprintf(`
-		UPDATE %s.users
+		UPDATE %s
 		SET %s
 		WHERE id = $%d
-	`, schemaEscaped, strings.Join(setParts, ", "), argIndex)
+		RETURNING updated_at
+	`, usersTable, strings.Join(setParts, ", "), argIndex)
 
-	_, err = nodeDB.Exec(query, args...)
-	return err
+	return nodeDB.QueryRow(query, args...).Scan(&user.UpdatedAt)
 }
 
 func (r *OrganizationUserRepository) DeleteUser(org *domain.Organization, userID string) error {
-	nodeDB, err := r.GetNodeDB(org)
+	nodeDB, err := r.nodeDBs.Writer(org)
 	if err != nil {
 		return fmt.Errorf("failed to connect to node database: %w", err)
 	}
-	defer nodeDB.Close()
 
-	schemaEscaped := database.EscapeSchemaName(org.UUID)
-	query := fmt.Sprintf(`DELETE FROM %s.users WHERE id = $1`, schemaEscaped)
+	usersTable := database.OrganizationTable(org.UUID, "users")
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, usersTable)
 
 	_, err = nodeDB.Exec(query, userID)
 	return err
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+)
+
+type RoleBindingRepository struct {
+	db *sql.DB
+}
+
+func NewRoleBindingRepository(db *sql.DB) *RoleBindingRepository {
+	return &RoleBindingRepository{db: db}
+}
+
+// List retorna os vínculos de roles de uma organização
+func (r *RoleBindingRepository) List(organizationUUID string) ([]domain.RoleBinding, error) {
+	query := `
+		SELECT b.id, b.organization_uuid, b.user_id, u.email, u.name, b.role_id, r.name, b.created_by, b.created_at
+		FROM organization_role_bindings b
+		INNER JOIN users u ON u.id = b.user_id
+		INNER JOIN roles r ON r.id = b.role_id
+		WHERE b.organization_uuid = $1
+		ORDER BY u.email, r.name
+	`
+
+	rows, err := r.db.Query(query, organizationUUID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bindings := []domain.RoleBinding{}
+	for rows.Next() {
+		binding := domain.RoleBinding{}
+		err := rows.Scan(
+			&binding.ID, &binding.OrganizationUUID, &binding.UserID, &binding.UserEmail, &binding.UserName,
+			&binding.RoleID, &binding.RoleName, &binding.CreatedBy, &binding.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		bindings = append(bindings, binding)
+	}
+
+	return bindings, rows.Err()
+}
+
+// Create vincula um role a um usuário na organização; vínculos existentes são mantidos
+func (r *RoleBindingRepository) Create(binding *domain.RoleBinding) error {
+	query := `
+		INSERT INTO organization_role_bindings (organization_uuid, user_id, role_id, created_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (organization_uuid, user_id, role_id)
+		DO UPDATE SET organization_uuid = EXCLUDED.organization_uuid
+		RETURNING id, created_by, created_at
+	`
+	return r.db.QueryRow(
+		query,
+		binding.OrganizationUUID,
+		binding.UserID,
+		binding.RoleID,
+		binding.CreatedBy,
+	).Scan(&binding.ID, &binding.CreatedBy, &binding.CreatedAt)
+}
+
+// Delete remove o vínculo de um role com um usuário na organização
+func (r *RoleBindingRepository) Delete(organizationUUID, roleID, userID string) error {
+	query := `
+		DELETE FROM organization_role_bindings
+		WHERE organization_uuid = $1 AND role_id = $2 AND user_id = $3
+	`
+	result, err := r.db.Exec(query, organizationUUID, roleID, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("role binding not found")
+	}
+
+	return nil
+}
+
+// DeleteByUser remove todos os vínculos de um usuário na organização
+func (r *RoleBindingRepository) DeleteByUser(organizationUUID, userID string) error {
+	query := `DELETE FROM organization_role_bindings WHERE organization_uuid = $1 AND user_id = $2`
+	_, err := r.db.Exec(query, organizationUUID, userID)
+	return err
+}
@@ -19,8 +19,8 @@ func NewRoleRepository(db *sql.DB) *RoleRepository {
 // Create cria um novo role
 func (r *RoleRepository) Create(role *domain.Role) error {
 	query := `
-		INSERT INTO roles (name, display_name, description, is_system)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO roles (name, display_name, description, is_system, organization_uuid)
+		VALUES ($1, $2, $3, $4, $5)
 		RETURNING id, created_at, updated_at
 	`
 	return r.db.QueryRow(
@@ -29,6 +29,7 @@ func (r *RoleRepository) Create(role *domain.Role) error {
 		role.DisplayName,
 		role.Description,
 		role.IsSystem,
+		role.OrganizationUUID,
 	).Scan(&role.ID, &role.CreatedAt, &role.UpdatedAt)
 }
 
@@ -36,12 +37,12 @@ func (r *RoleRepository) Create(role *domain.Role) error {
 func (r *RoleRepository) GetByID(id string) (*domain.Role, error) {
 	role := &domain.Role{}
 	query := `
-		SELECT id, name, display_name, description, is_system, created_at, updated_at
+		SELECT id, name, display_name, description, is_system, organization_uuid, created_at, updated_at
 		FROM roles WHERE id = $1
 	`
 	err := r.db.QueryRow(query, id).Scan(
 		&role.ID, &role.Name, &role.DisplayName, &role.Description,
-		&role.IsSystem, &role.CreatedAt, &role.UpdatedAt,
+		&role.IsSystem, &role.OrganizationUUID, &role.CreatedAt, &role.UpdatedAt,
 	)
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("role not found")
@@ -49,16 +50,16 @@ func (r *RoleRepository) GetByID(id string) (*domain.Role, error) {
 	return role, err
 }
 
-// GetByName retorna um role por nome
+// GetByName retorna um role global por nome
 func (r *RoleRepository) GetByName(name string) (*domain.Role, error) {
 	role := &domain.Role{}
 	query := `
-		SELECT id, name, display_name, description, is_system, created_at, updated_at
-		FROM roles WHERE name = $1
+		SELECT id, name, display_name, description, is_system, organization_uuid, created_at, updated_at
+		FROM roles WHERE name = $1 AND organization_uuid IS NULL
 	`
 	err := r.db.QueryRow(query, name).Scan(
 		&role.ID, &role.Name, &role.DisplayName, &role.Description,
-		&role.IsSystem, &role.CreatedAt, &role.UpdatedAt,
+		&role.IsSystem, &role.OrganizationUUID, &role.CreatedAt, &role.UpdatedAt,
 	)
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("role not found")
@@ -66,15 +67,40 @@ func (r *RoleRepository) GetByName(name string) (*domain.Role, error) {
 	return role, err
 }
 
-// List retorna todos os roles
+// IsReservedName indica se o nome pertence a um role de sistema global
+func (r *RoleRepository) IsReservedName(name string) (bool, error) {
+	var reserved bool
+	query := `SELECT EXISTS (SELECT 1 FROM roles WHERE name = $1 AND is_system = true AND organization_uuid IS NULL)`
+	err := r.db.QueryRow(query, name).Scan(&reserved)
+	return reserved, err
+}
+
+// List retorna os roles globais
 func (r *RoleRepository) List() ([]domain.Role, error) {
 	query := `
-		SELECT id, name, display_name, description, is_system, created_at, updated_at
+		SELECT id, name, display_name, description, is_system, organization_uuid, created_at, updated_at
 		FROM roles
+		WHERE organization_uuid IS NULL
 		ORDER BY name
 	`
 
-	rows, err := r.db.Query(query)
+	return r.queryRoles(query)
+}
+
+// ListForOrganization retorna os roles globais e os roles próprios de uma organização
+func (r *RoleRepository) ListForOrganization(organizationUUID string) ([]domain.Role, error) {
+	query := `
+		SELECT id, name, display_name, description, is_system, organization_uuid, created_at, updated_at
+		FROM roles
+		WHERE organization_uuid IS NULL OR organization_uuid = $1
+		ORDER BY organization_uuid NULLS FIRST, name
+	`
+
+	return r.queryRoles(query, organizationUUID)
+}
+
+func (r *RoleRepository) queryRoles(query string, args ...interface{}) ([]domain.Role, error) {
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +111,7 @@ func (r *RoleRepository) List() ([]domain.Role, error) {
 		role := domain.Role{}
 		err := rows.Scan(
 			&role.ID, &role.Name, &role.DisplayName, &role.Description,
-			&role.IsSystem, &role.CreatedAt, &role.UpdatedAt,
+			&role.IsSystem, &role.OrganizationUUID, &role.CreatedAt, &role.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+)
+
+type ScorecardRepository struct {
+	db *sql.DB
+}
+
+func NewScorecardRepository(db *sql.DB) *ScorecardRepository {
+	return &ScorecardRepository{db: db}
+}
+
+// GetDefinition retorna os checks de scorecard da organização (nil se nunca foram configurados)
+func (r *ScorecardRepository) GetDefinition(organizationUUID string) (*domain.ScorecardDefinition, error) {
+	var definition domain.ScorecardDefinition
+	var checks []byte
+	var updatedBy sql.NullString
+	var updatedAt time.Time
+
+	err := r.db.QueryRow(`
+		SELECT checks, updated_by, updated_at
+		FROM scorecard_definitions
+		WHERE organization_uuid = $1
+	`, organizationUUID).Scan(&checks, &updatedBy, &updatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(checks, &definition.Checks); err != nil {
+		return nil, err
+	}
+	definition.UpdatedBy = updatedBy.String
+	definition.UpdatedAt = &updatedAt
+
+	return &definition, nil
+}
+
+// UpsertDefinition grava os checks de scorecard da organização
+func (r *ScorecardRepository) UpsertDefinition(organizationUUID string, definition *domain.ScorecardDefinition, updatedBy string) error {
+	checks := definition.Checks
+	if checks == nil {
+		checks = []domain.ScorecardCheck{}
+	}
+
+	checksJSON, err := json.Marshal(checks)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(`
+		INSERT INTO scorecard_definitions (organization_uuid, checks, updated_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (organization_uuid) DO UPDATE SET
+			checks = EXCLUDED.checks,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = CURRENT_TIMESTAMP
+	`, organizationUUID, checksJSON, updatedBy)
+
+	return err
+}
+
+// UpsertResult grava (ou substitui) a avaliação do dia de um serviço
+func (r *ScorecardRepository) UpsertResult(result *domain.ScorecardResult) error {
+	categoryScores, err := json.Marshal(result.CategoryScores)
+	if err != nil {
+		return err
+	}
+	checks, err := json.Marshal(result.Checks)
+	if err != nil {
+		return err
+	}
+
+	return r.db.QueryRow(`
+		INSERT INTO scorecard_results (
+			organization_uuid, service_name, squad, result_date,
+			category_scores, overall_score, checks, evaluated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (organization_uuid, service_name, result_date) DO UPDATE SET
+			squad = EXCLUDED.squad,
+			category_scores = EXCLUDED.category_scores,
+			overall_score = EXCLUDED.overall_score,
+			checks = EXCLUDED.checks,
+			evaluated_at = EXCLUDED.evaluated_at
+		RETURNING id
+	`,
+		result.OrganizationUUID,
+		result.ServiceName,
+		result.Squad,
+		result.Date,
+		categoryScores,
+		result.OverallScore,
+		checks,
+		result.EvaluatedAt,
+	).Scan(&result.ID)
+}
+
+// ListResults retorna as avaliações da organização entre duas datas (inclusive), em ordem cronológica
+func (r *ScorecardRepository) ListResults(organizationUUID string, from, to time.Time) ([]domain.ScorecardResult, error) {
+	query := `
+		SELECT id, organization_uuid, service_name, squad, result_date,
+			category_scores, overall_score, checks, evaluated_at
+		FROM scorecard_results
+		WHERE organization_uuid = $1 AND result_date BETWEEN $2 AND $3
+		ORDER BY result_date ASC, service_name ASC
+	`
+
+	rows, err := r.db.Query(query, organizationUUID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []domain.ScorecardResult
+	for rows.Next() {
+		var result domain.ScorecardResult
+		var categoryScores, checks []byte
+		if err := rows.Scan(
+			&result.ID,
+			&result.OrganizationUUID,
+			&result.ServiceName,
+			&result.Squad,
+			&result.Date,
+			&categoryScores,
+			&result.OverallScore,
+			&checks,
+			&result.EvaluatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(categoryScores, &result.CategoryScores); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(checks, &result.Checks); err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	return results, rows.Err()
+}
+
+// GetLatestEvaluation retorna o horário da avaliação mais recente da organização
+func (r *ScorecardRepository) GetLatestEvaluation(organizationUUID string) (*time.Time, error) {
+	var latest sql.NullTime
+	err := r.db.QueryRow(`
+		SELECT MAX(evaluated_at) FROM scorecard_results WHERE organization_uuid = $1
+	`, organizationUUID).Scan(&latest)
+	if err != nil {
+		return nil, err
+	}
+	if !latest.Valid {
+		return nil, nil
+	}
+	return &latest.Time, nil
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/lib/pq"
+)
+
+type TechDocsIndexRepository struct {
+	db *sql.DB
+}
+
+func NewTechDocsIndexRepository(db *sql.DB) *TechDocsIndexRepository {
+	return &TechDocsIndexRepository{db: db}
+}
+
+// ListDocuments retorna os documentos indexados da organização, por caminho
+func (r *TechDocsIndexRepository) ListDocuments(organizationUUID string) (map[string]domain.TechDocIndexedDocument, error) {
+	rows, err := r.db.Query(`
+		SELECT doc_path, content_hash, embedding_model, chunks, indexed_at
+		FROM techdocs_index_documents
+		WHERE organization_uuid = $1
+	`, organizationUUID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	documents := map[string]domain.TechDocIndexedDocument{}
+	for rows.Next() {
+		var doc domain.TechDocIndexedDocument
+		if err := rows.Scan(&doc.Path, &doc.ContentHash, &doc.EmbeddingModel, &doc.Chunks, &doc.IndexedAt); err != nil {
+			return nil, err
+		}
+		documents[doc.Path] = doc
+	}
+
+	return documents, rows.Err()
+}
+
+// ReplaceDocument substitui, em uma transação, os trechos indexados de um documento
+func (r *TechDocsIndexRepository) ReplaceDocument(organizationUUID string, doc *domain.TechDocIndexedDocument, chunks []domain.TechDocChunk) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Os trechos antigos saem em cascata
+	if _, err := tx.Exec(`
+		DELETE FROM techdocs_index_documents WHERE organization_uuid = $1 AND doc_path = $2
+	`, organizationUUID, doc.Path); err != nil {
+		return err
+	}
+
+	if err := tx.QueryRow(`
+		INSERT INTO techdocs_index_documents (organization_uuid, doc_path, content_hash, embedding_model, chunks)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING indexed_at
+	`, organizationUUID, doc.Path, doc.ContentHash, doc.EmbeddingModel, len(chunks)).Scan(&doc.IndexedAt); err != nil {
+		return err
+	}
+	doc.Chunks = len(chunks)
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO techdocs_index_chunks (
+			organization_uuid, doc_path, chunk_index, heading, content, embedding_model, embedding
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, chunk := range chunks {
+		if _, err := stmt.Exec(
+			organizationUUID,
+			doc.Path,
+			chunk.Index,
+			chunk.Heading,
+			chunk.Content,
+			chunk.EmbeddingModel,
+			pq.Float32Array(chunk.Embedding),
+		); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// DeleteDocument remove um documento (e seus trechos) do índice
+func (r *TechDocsIndexRepository) DeleteDocument(organizationUUID, docPath string) error {
+	_, err := r.db.Exec(`
+		DELETE FROM techdocs_index_documents WHERE organization_uuid = $1 AND doc_path = $2
+	`, organizationUUID, docPath)
+	return err
+}
+
+// ListChunks retorna os trechos da organização gerados por um modelo de embedding, com os vetores
+func (r *TechDocsIndexRepository) ListChunks(organizationUUID, embeddingModel string) ([]domain.TechDocChunk, error) {
+	rows, err := r.db.Query(`
+		SELECT doc_path, chunk_index, COALESCE(heading, ''), content, embedding_model, embedding
+		FROM techdocs_index_chunks
+		WHERE organization_uuid = $1 AND embedding_model = $2
+	`, organizationUUID, embeddingModel)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	chunks := []domain.TechDocChunk{}
+	for rows.Next() {
+		var chunk domain.TechDocChunk
+		var embedding pq.Float32Array
+		if err := rows.Scan(
+			&chunk.Path,
+			&chunk.Index,
+			&chunk.Heading,
+			&chunk.Content,
+			&chunk.EmbeddingModel,
+			&embedding,
+		); err != nil {
+			return nil, err
+		}
+		chunk.Embedding = embedding
+		chunks = append(chunks, chunk)
+	}
+
+	return chunks, rows.Err()
+}
@@ -254,7 +254,7 @@ func (r *UserRepository) AssignRoles(userID string, roleIDs []string) error {
 // GetUserRoles retorna os roles de um usuário
 func (r *UserRepository) GetUserRoles(userID string) ([]domain.Role, error) {
 	query := `
-		SELECT r.id, r.name, r.display_name, r.description, r.is_system, r.created_at, r.updated_at
+		SELECT r.id, r.name, r.display_name, r.description, r.is_system, r.organization_uuid, r.created_at, r.updated_at
 		FROM roles r
 		INNER JOIN user_roles ur ON ur.role_id = r.id
 		WHERE ur.user_id = $1
@@ -272,7 +272,7 @@ func (r *UserRepository) GetUserRoles(userID string) ([]domain.Role, error) {
 		role := domain.Role{}
 		err := rows.Scan(
 			&role.ID, &role.Name, &role.DisplayName, &role.Description,
-			&role.IsSystem, &role.CreatedAt, &role.UpdatedAt,
+			&role.IsSystem, &role.OrganizationUUID, &role.CreatedAt, &role.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
@@ -323,6 +323,83 @@ func (r *UserRepository) GetUserPermissions(userID string) (*domain.UserPermissi
 		return nil, err
 	}
 
+	permissions, err := r.permissionsForRoles(userID, roles)
+	if err != nil {
+		return nil, err
+	}
+	permissions.IsPlatformAdmin = hasSystemAdminRole(roles)
+
+	return permissions, nil
+}
+
+// GetUserOrganizationRoles retorna os roles vinculados a um usuário dentro de uma organização
+func (r *UserRepository) GetUserOrganizationRoles(userID, organizationUUID string) ([]domain.Role, error) {
+	query := `
+		SELECT r.id, r.name, r.display_name, r.description, r.is_system, r.organization_uuid, r.created_at, r.updated_at
+		FROM roles r
+		INNER JOIN organization_role_bindings b ON b.role_id = r.id
+		WHERE b.user_id = $1 AND b.organization_uuid = $2
+		ORDER BY r.name
+	`
+
+	rows, err := r.db.Query(query, userID, organizationUUID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := []domain.Role{}
+	for rows.Next() {
+		role := domain.Role{}
+		err := rows.Scan(
+			&role.ID, &role.Name, &role.DisplayName, &role.Description,
+			&role.IsSystem, &role.OrganizationUUID, &role.CreatedAt, &role.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+
+	return roles, rows.Err()
+}
+
+// GetUserPermissionsInOrganization retorna as permissões efetivas de um usuário em uma organização.
+// Só os roles vinculados na organização contam; dos roles globais apenas o admin do sistema
+// é considerado, como o indicador explícito de admin da plataforma.
+func (r *UserRepository) GetUserPermissionsInOrganization(userID, organizationUUID string) (*domain.UserPermissions, error) {
+	orgRoles, err := r.GetUserOrganizationRoles(userID, organizationUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	globalRoles, err := r.GetUserRoles(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := r.permissionsForRoles(userID, orgRoles)
+	if err != nil {
+		return nil, err
+	}
+	permissions.OrganizationUUID = organizationUUID
+	permissions.IsPlatformAdmin = hasSystemAdminRole(globalRoles)
+
+	return permissions, nil
+}
+
+// hasSystemAdminRole indica se algum dos roles é o admin semeado pelo sistema
+func hasSystemAdminRole(roles []domain.Role) bool {
+	for i := range roles {
+		if roles[i].IsSystemAdmin() {
+			return true
+		}
+	}
+	return false
+}
+
+// permissionsForRoles monta as permissões efetivas a partir de um conjunto de roles
+func (r *UserRepository) permissionsForRoles(userID string, roles []domain.Role) (*domain.UserPermissions, error) {
 	if len(roles) == 0 {
 		return &domain.UserPermissions{
 			UserID:        userID,
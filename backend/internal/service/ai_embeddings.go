@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/PlatifyX/platifyx-core/pkg/openai"
+)
+
+// aiEmbeddingModels is the embedding model of each provider that has one (Claude has none)
+var aiEmbeddingModels = map[domain.AIProvider]string{
+	domain.AIProviderOpenAI: "text-embedding-3-small",
+	domain.AIProviderGemini: "text-embedding-004",
+	domain.AIProviderFake:   "fake-embedding",
+}
+
+// fakeEmbeddingDimensions is the size of the hashed bag-of-words vectors of the fake provider
+const fakeEmbeddingDimensions = 256
+
+// AIEmbeddingClient is implemented by the providers that can embed texts
+type AIEmbeddingClient interface {
+	Embed(ctx context.Context, model string, texts []string) ([][]float32, *domain.AIUsage, error)
+}
+
+// Embed embeds texts with the embedding provider of the organization: the embeddings route of the
+// policy, else the default route, else the fallback order, skipping providers without embedding
+// models. Callers must compare vectors of the same model only.
+func (s *AIService) Embed(ctx context.Context, caller domain.AICaller, texts []string) (*domain.AIEmbeddings, error) {
+	if err := s.checkBudget(caller); err != nil {
+		return nil, err
+	}
+
+	policy, err := s.GetRoutingPolicy(caller.OrganizationUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := s.embeddingCandidates(caller.OrganizationUUID, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only used to estimate the tokens when the provider does not report them
+	messages := make([]domain.ChatMessage, len(texts))
+	for i, text := range texts {
+		messages[i] = domain.ChatMessage{Role: "user", Content: text}
+	}
+
+	var vectors [][]float32
+	resp, err := s.tryCandidates(ctx, caller, policy, candidates, messages, false, func(ctx context.Context, client AIProviderClient, model string) (*domain.AIResponse, error) {
+		embedded, usage, err := client.(AIEmbeddingClient).Embed(ctx, model, texts)
+		if err != nil {
+			return nil, err
+		}
+		vectors = embedded
+		return &domain.AIResponse{Provider: client.Provider(), Model: model, Usage: usage}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.AIEmbeddings{
+		Provider: resp.Provider,
+		Model:    resp.Model,
+		Vectors:  vectors,
+	}, nil
+}
+
+// EmbeddingModel returns the model Embed uses for the organization when no provider fails
+// ("" when no provider with embeddings is configured)
+func (s *AIService) EmbeddingModel(organizationUUID string) (string, error) {
+	policy, err := s.GetRoutingPolicy(organizationUUID)
+	if err != nil {
+		return "", err
+	}
+
+	candidates, err := s.embeddingCandidates(organizationUUID, policy)
+	if err != nil || len(candidates) == 0 {
+		return "", err
+	}
+	return candidates[0].model, nil
+}
+
+func (s *AIService) embeddingCandidates(organizationUUID string, policy *domain.AIRoutingPolicy) ([]aiCandidate, error) {
+	order := []domain.AIProvider{}
+	if route, ok := policy.Routes[domain.AIFeatureEmbeddings]; ok {
+		order = append(order, route.Provider)
+	}
+	if route, ok := policy.Routes[domain.AIFeatureDefault]; ok {
+		order = append(order, route.Provider)
+	}
+	order = append(order, policy.FallbackOrder...)
+
+	var candidates []aiCandidate
+	seen := map[domain.AIProvider]bool{}
+	for _, p := range order {
+		model, ok := aiEmbeddingModels[p]
+		if !ok || seen[p] {
+			continue
+		}
+		seen[p] = true
+
+		client, err := s.providerClient(organizationUUID, p)
+		if err != nil {
+			s.log.Warnw("Skipping AI provider", "provider", p, "error", err, "organizationUUID", organizationUUID)
+			continue
+		}
+		if client == nil {
+			continue
+		}
+
+		candidates = append(candidates, aiCandidate{client: client, model: model})
+	}
+
+	return candidates, nil
+}
+
+func (p *openAIProvider) Embed(ctx context.Context, model string, texts []string) ([][]float32, *domain.AIUsage, error) {
+	resp, err := p.client.CreateEmbeddings(ctx, openai.EmbeddingRequest{
+		Model: model,
+		Input: texts,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("OpenAI API error: %w", err)
+	}
+
+	vectors := make([][]float32, len(resp.Data))
+	for i, embedding := range resp.Data {
+		vectors[i] = embedding.Embedding
+	}
+
+	var usage *domain.AIUsage
+	if resp.Usage != nil {
+		usage = &domain.AIUsage{
+			InputTokens: resp.Usage.PromptTokens,
+			TotalTokens: resp.Usage.TotalTokens,
+		}
+	}
+	return vectors, usage, nil
+}
+
+func (p *geminiProvider) Embed(ctx context.Context, model string, texts []string) ([][]float32, *domain.AIUsage, error) {
+	resp, err := p.client.BatchEmbedContents(ctx, model, texts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Gemini API error: %w", err)
+	}
+
+	vectors := make([][]float32, len(resp.Embeddings))
+	for i, embedding := range resp.Embeddings {
+		vectors[i] = embedding.Values
+	}
+	return vectors, nil, nil
+}
+
+// Embed hashes the words of each text into a normalized bag-of-words vector: texts sharing words
+// are close, which is enough to exercise retrieval offline
+func (p *fakeAIProvider) Embed(ctx context.Context, model string, texts []string) ([][]float32, *domain.AIUsage, error) {
+	vectors := make([][]float32, len(texts))
+	tokens := 0
+	for i, text := range texts {
+		vector := make([]float32, fakeEmbeddingDimensions)
+		words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		for _, word := range words {
+			h := fnv.New32a()
+			h.Write([]byte(word))
+			vector[h.Sum32()%fakeEmbeddingDimensions]++
+		}
+		tokens += len(words)
+
+		var norm float64
+		for _, v := range vector {
+			norm += float64(v) * float64(v)
+		}
+		if norm > 0 {
+			norm = math.Sqrt(norm)
+			for j := range vector {
+				vector[j] = float32(float64(vector[j]) / norm)
+			}
+		}
+		vectors[i] = vector
+	}
+
+	return vectors, &domain.AIUsage{InputTokens: tokens, TotalTokens: tokens}, nil
+}
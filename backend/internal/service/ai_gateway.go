@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"slices"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+)
+
+const (
+	defaultAIMaxRetries = 2
+	maxAIRetries        = 5
+
+	aiRetryBaseDelay = 500 * time.Millisecond
+	aiRetryMaxDelay  = 8 * time.Second
+	// Longest Retry-After honoured; beyond it the next provider is tried instead
+	aiRetryMaxRetryAfter = 30 * time.Second
+)
+
+// aiCall runs one request against a provider with the chosen model
+type aiCall func(ctx context.Context, client AIProviderClient, model string) (*domain.AIResponse, error)
+
+// aiCandidate is a provider the gateway may try, with the model to use on it
+type aiCandidate struct {
+	client AIProviderClient
+	model  string
+}
+
+// aiStreamInterruptedError is a stream that failed after part of the answer reached the client:
+// it can be neither retried nor sent to another provider
+type aiStreamInterruptedError struct {
+	err error
+}
+
+func (e *aiStreamInterruptedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *aiStreamInterruptedError) Unwrap() error {
+	return e.err
+}
+
+// route sends the request to the preferred provider of the feature, retrying transient failures
+// with backoff, then falls back to the next configured providers of the policy. Calls are refused
+// once the monthly budget is spent, and metered when they succeed.
+func (s *AIService) route(ctx context.Context, caller domain.AICaller, provider domain.AIProvider, model string, messages []domain.ChatMessage, stream bool, call aiCall) (*domain.AIResponse, error) {
+	if err := s.checkBudget(caller); err != nil {
+		return nil, err
+	}
+
+	policy, err := s.GetRoutingPolicy(caller.OrganizationUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := s.candidates(caller.OrganizationUUID, policy, caller.Feature, provider, model)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.tryCandidates(ctx, caller, policy, candidates, messages, stream, call)
+}
+
+// checkBudget refuses the call once the organization spent its monthly budget
+func (s *AIService) checkBudget(caller domain.AICaller) error {
+	if s.usageService == nil {
+		return nil
+	}
+
+	var exceeded *domain.AIBudgetExceededError
+	if err := s.usageService.CheckBudget(caller.OrganizationUUID); errors.As(err, &exceeded) {
+		s.log.Warnw("AI request refused, monthly budget exceeded", "feature", caller.Feature, "organizationUUID", caller.OrganizationUUID, "spentUsd", exceeded.SpentUSD)
+		return err
+	} else if err != nil {
+		// Metering outages must not take the AI features down
+		s.log.Warnw("Failed to check AI budget", "error", err, "organizationUUID", caller.OrganizationUUID)
+	}
+	return nil
+}
+
+// tryCandidates calls the candidates in order until one succeeds, and meters the successful call
+func (s *AIService) tryCandidates(ctx context.Context, caller domain.AICaller, policy *domain.AIRoutingPolicy, candidates []aiCandidate, messages []domain.ChatMessage, stream bool, call aiCall) (*domain.AIResponse, error) {
+	organizationUUID, feature := caller.OrganizationUUID, caller.Feature
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no AI provider configured")
+	}
+
+	var lastErr error
+	for i, candidate := range candidates {
+		resp, err := s.callWithRetries(ctx, policy.MaxRetries, candidate, call)
+		if err == nil {
+			if s.usageService != nil {
+				s.usageService.Record(caller, candidate.model, messages, resp)
+			}
+			if i > 0 {
+				s.log.Warnw("AI request served by fallback provider",
+					"feature", feature,
+					"provider", candidate.client.Provider(),
+					"model", candidate.model,
+					"organizationUUID", organizationUUID)
+			}
+			return resp, nil
+		}
+
+		var interrupted *aiStreamInterruptedError
+		if ctx.Err() != nil || errors.As(err, &interrupted) {
+			return nil, err
+		}
+
+		s.log.Warnw("AI provider failed",
+			"feature", feature,
+			"provider", candidate.client.Provider(),
+			"model", candidate.model,
+			"stream", stream,
+			"error", err,
+			"organizationUUID", organizationUUID)
+		lastErr = err
+	}
+
+	return nil, fmt.Errorf("all AI providers failed: %w", lastErr)
+}
+
+// candidates lists the providers to try in order: the one asked by the caller, else the route of
+// the feature, else the default route, followed by the fallback order. Providers that are not
+// configured for the organization are skipped.
+func (s *AIService) candidates(organizationUUID string, policy *domain.AIRoutingPolicy, feature domain.AIFeature, provider domain.AIProvider, model string) ([]aiCandidate, error) {
+	featureRoute, hasFeatureRoute := policy.Routes[feature]
+	defaultRoute, hasDefaultRoute := policy.Routes[domain.AIFeatureDefault]
+
+	order := []domain.AIProvider{}
+	if provider != "" {
+		order = append(order, provider)
+	}
+	if hasFeatureRoute {
+		order = append(order, featureRoute.Provider)
+	}
+	if hasDefaultRoute {
+		order = append(order, defaultRoute.Provider)
+	}
+	order = append(order, policy.FallbackOrder...)
+
+	var candidates []aiCandidate
+	seen := map[domain.AIProvider]bool{}
+	for _, p := range order {
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+
+		client, err := s.providerClient(organizationUUID, p)
+		if err != nil {
+			// A provider the caller asked for explicitly must exist
+			if p == provider {
+				return nil, err
+			}
+			s.log.Warnw("Skipping AI provider", "provider", p, "error", err, "organizationUUID", organizationUUID)
+			continue
+		}
+		if client == nil {
+			continue
+		}
+
+		candidates = append(candidates, aiCandidate{
+			client: client,
+			model:  routedModel(p, provider, model, featureRoute, defaultRoute, hasFeatureRoute, hasDefaultRoute),
+		})
+	}
+
+	return candidates, nil
+}
+
+// routedModel picks the model for a provider: a model pinned by the policy wins, then the model
+// asked by the caller for that provider (when the provider offers it), then the provider default
+func routedModel(p domain.AIProvider, requestedProvider domain.AIProvider, requestedModel string, featureRoute, defaultRoute domain.AIRoute, hasFeatureRoute, hasDefaultRoute bool) string {
+	if hasFeatureRoute && featureRoute.Provider == p && featureRoute.Model != "" {
+		return featureRoute.Model
+	}
+	if hasDefaultRoute && defaultRoute.Provider == p && defaultRoute.Model != "" {
+		return defaultRoute.Model
+	}
+
+	info := aiProviderCatalog[p]
+	if requestedModel != "" && (requestedProvider == "" || requestedProvider == p) && slices.Contains(info.Models, requestedModel) {
+		return requestedModel
+	}
+	return info.DefaultModel
+}
+
+func (s *AIService) callWithRetries(ctx context.Context, maxRetries int, candidate aiCandidate, call aiCall) (*domain.AIResponse, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := call(ctx, candidate.client, candidate.model)
+		if err == nil {
+			return resp, nil
+		}
+
+		if attempt >= maxRetries || !isTransientAIError(err) {
+			return nil, err
+		}
+
+		delay, ok := aiRetryDelay(err, attempt)
+		if !ok {
+			return nil, err
+		}
+
+		s.log.Infow("Retrying AI request",
+			"provider", candidate.client.Provider(),
+			"model", candidate.model,
+			"attempt", attempt+1,
+			"delay", delay,
+			"error", err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// isTransientAIError tells whether a failed request may succeed if sent again: rate limited,
+// provider server errors and network timeouts
+func isTransientAIError(err error) bool {
+	var interrupted *aiStreamInterruptedError
+	if errors.As(err, &interrupted) {
+		return false
+	}
+
+	var retryable interface{ Retryable() bool }
+	if errors.As(err, &retryable) {
+		return retryable.Retryable()
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// aiRetryDelay is the exponential backoff (with jitter) of an attempt, or the Retry-After asked
+// by the provider. It returns false when the provider asks to wait longer than worth waiting.
+func aiRetryDelay(err error, attempt int) (time.Duration, bool) {
+	var retryAfter interface{ RetryDelay() time.Duration }
+	if errors.As(err, &retryAfter) && retryAfter.RetryDelay() > 0 {
+		delay := retryAfter.RetryDelay()
+		return delay, delay <= aiRetryMaxRetryAfter
+	}
+
+	delay := aiRetryBaseDelay << attempt
+	if delay > aiRetryMaxDelay {
+		delay = aiRetryMaxDelay
+	}
+	delay += time.Duration(rand.Int63n(int64(delay) / 2))
+	return delay, true
+}
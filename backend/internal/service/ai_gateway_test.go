@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/PlatifyX/platifyx-core/pkg/logger"
+)
+
+// stubAIConfigs configures the providers that have an API key
+type stubAIConfigs map[domain.AIProvider]string
+
+func (c stubAIConfigs) GetOpenAIConfig(string) (*domain.OpenAIIntegrationConfig, error) {
+	if key, ok := c[domain.AIProviderOpenAI]; ok {
+		return &domain.OpenAIIntegrationConfig{APIKey: key}, nil
+	}
+	return nil, nil
+}
+
+func (c stubAIConfigs) GetClaudeConfig(string) (*domain.ClaudeIntegrationConfig, error) {
+	if key, ok := c[domain.AIProviderClaude]; ok {
+		return &domain.ClaudeIntegrationConfig{APIKey: key}, nil
+	}
+	return nil, nil
+}
+
+func (c stubAIConfigs) GetGeminiConfig(string) (*domain.GeminiIntegrationConfig, error) {
+	if key, ok := c[domain.AIProviderGemini]; ok {
+		return &domain.GeminiIntegrationConfig{APIKey: key}, nil
+	}
+	return nil, nil
+}
+
+// stubAIPolicies holds the routing policy of a single organization, nil meaning none saved
+type stubAIPolicies struct {
+	policy *domain.AIRoutingPolicy
+}
+
+func (s *stubAIPolicies) Get(string) (*domain.AIRoutingPolicy, error) {
+	return s.policy, nil
+}
+
+func (s *stubAIPolicies) Upsert(_ string, policy *domain.AIRoutingPolicy, _ string) error {
+	s.policy = policy
+	return nil
+}
+
+// retryableAIError is a rate limit that asks to retry almost immediately
+type retryableAIError struct{}
+
+func (e *retryableAIError) Error() string             { return "rate limited" }
+func (e *retryableAIError) Retryable() bool           { return true }
+func (e *retryableAIError) RetryDelay() time.Duration { return time.Millisecond }
+
+// scriptedAIProvider fails with the scripted errors, in order, then answers
+type scriptedAIProvider struct {
+	provider domain.AIProvider
+	errs     []error
+	calls    int
+}
+
+func (p *scriptedAIProvider) Provider() domain.AIProvider {
+	return p.provider
+}
+
+func (p *scriptedAIProvider) Chat(ctx context.Context, model string, messages []domain.ChatMessage) (*domain.AIResponse, error) {
+	p.calls++
+	if p.calls <= len(p.errs) {
+		return nil, p.errs[p.calls-1]
+	}
+	return &domain.AIResponse{Provider: p.provider, Model: model, Content: "ok"}, nil
+}
+
+func (p *scriptedAIProvider) ChatStream(ctx context.Context, model string, messages []domain.ChatMessage, onDelta func(delta string) error) (*domain.AIResponse, error) {
+	return p.Chat(ctx, model, messages)
+}
+
+func testAIService(configs stubAIConfigs, policy *domain.AIRoutingPolicy, fakeProvider bool) *AIService {
+	return NewAIService(configs, &stubAIPolicies{policy: policy}, nil, fakeProvider, logger.NewLogger("development"))
+}
+
+func chatCall(ctx context.Context, client AIProviderClient, model string) (*domain.AIResponse, error) {
+	return client.Chat(ctx, model, []domain.ChatMessage{{Role: "user", Content: "hello"}})
+}
+
+func TestCallWithRetries(t *testing.T) {
+	tests := []struct {
+		name       string
+		errs       []error
+		maxRetries int
+		wantCalls  int
+		wantErr    bool
+	}{
+		{name: "first attempt", maxRetries: 2, wantCalls: 1},
+		{name: "transient errors retried", errs: []error{&retryableAIError{}, &retryableAIError{}}, maxRetries: 2, wantCalls: 3},
+		{name: "retries exhausted", errs: []error{&retryableAIError{}, &retryableAIError{}}, maxRetries: 1, wantCalls: 2, wantErr: true},
+		{name: "permanent error not retried", errs: []error{errors.New("invalid request")}, maxRetries: 2, wantCalls: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := testAIService(nil, nil, false)
+			provider := &scriptedAIProvider{provider: domain.AIProviderOpenAI, errs: tt.errs}
+
+			_, err := s.callWithRetries(context.Background(), tt.maxRetries, aiCandidate{client: provider, model: "gpt-4o-mini"}, chatCall)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("callWithRetries returned %v, want error %v", err, tt.wantErr)
+			}
+			if provider.calls != tt.wantCalls {
+				t.Errorf("provider called %d times, want %d", provider.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestTryCandidatesFallsBackInOrder(t *testing.T) {
+	s := testAIService(nil, nil, false)
+	policy := &domain.AIRoutingPolicy{MaxRetries: 1}
+
+	claude := &scriptedAIProvider{provider: domain.AIProviderClaude, errs: []error{&retryableAIError{}, &retryableAIError{}}}
+	openAI := &scriptedAIProvider{provider: domain.AIProviderOpenAI, errs: []error{errors.New("invalid API key")}}
+	fake := &scriptedAIProvider{provider: domain.AIProviderFake}
+	candidates := []aiCandidate{{client: claude}, {client: openAI}, {client: fake}}
+
+	resp, err := s.tryCandidates(context.Background(), domain.AICaller{Feature: domain.AIFeatureChat}, policy, candidates, nil, false, chatCall)
+	if err != nil {
+		t.Fatalf("tryCandidates: %v", err)
+	}
+	if resp.Provider != domain.AIProviderFake {
+		t.Errorf("served by %s, want the last candidate", resp.Provider)
+	}
+	if claude.calls != 2 || openAI.calls != 1 || fake.calls != 1 {
+		t.Errorf("calls are claude %d, openai %d, fake %d; want 2, 1, 1", claude.calls, openAI.calls, fake.calls)
+	}
+
+	_, err = s.tryCandidates(context.Background(), domain.AICaller{}, policy, []aiCandidate{{client: &scriptedAIProvider{errs: []error{errors.New("down")}}}}, nil, false, chatCall)
+	if err == nil || !strings.Contains(err.Error(), "all AI providers failed") {
+		t.Errorf("failure of every candidate returned %v", err)
+	}
+
+	cancelled := &scriptedAIProvider{provider: domain.AIProviderClaude, errs: []error{&aiStreamInterruptedError{err: errors.New("reset")}}}
+	next := &scriptedAIProvider{provider: domain.AIProviderOpenAI}
+	if _, err := s.tryCandidates(context.Background(), domain.AICaller{}, policy, []aiCandidate{{client: cancelled}, {client: next}}, nil, true, chatCall); err == nil {
+		t.Error("an interrupted stream was sent to the next provider")
+	}
+	if next.calls != 0 {
+		t.Errorf("next provider called %d times after an interrupted stream", next.calls)
+	}
+}
+
+type routedCandidate struct {
+	provider domain.AIProvider
+	model    string
+}
+
+func TestCandidatesFollowThePolicy(t *testing.T) {
+	configured := stubAIConfigs{domain.AIProviderOpenAI: "sk-openai", domain.AIProviderClaude: "sk-claude"}
+	policy := &domain.AIRoutingPolicy{
+		Routes: map[domain.AIFeature]domain.AIRoute{
+			domain.AIFeatureDiagram: {Provider: domain.AIProviderOpenAI, Model: "gpt-4o"},
+			domain.AIFeatureDefault: {Provider: domain.AIProviderClaude},
+		},
+		FallbackOrder: []domain.AIProvider{domain.AIProviderGemini, domain.AIProviderOpenAI, domain.AIProviderClaude},
+	}
+
+	tests := []struct {
+		name     string
+		feature  domain.AIFeature
+		provider domain.AIProvider
+		model    string
+		want     []routedCandidate
+	}{
+		{
+			name:    "feature route first, with its pinned model",
+			feature: domain.AIFeatureDiagram,
+			want:    []routedCandidate{{domain.AIProviderOpenAI, "gpt-4o"}, {domain.AIProviderClaude, "claude-3-5-haiku-latest"}},
+		},
+		{
+			name:    "default route for features without one",
+			feature: domain.AIFeatureChat,
+			want:    []routedCandidate{{domain.AIProviderClaude, "claude-3-5-haiku-latest"}, {domain.AIProviderOpenAI, "gpt-4o-mini"}},
+		},
+		{
+			name:     "provider and model asked by the caller",
+			feature:  domain.AIFeatureChat,
+			provider: domain.AIProviderOpenAI,
+			model:    "gpt-4.1",
+			want:     []routedCandidate{{domain.AIProviderOpenAI, "gpt-4.1"}, {domain.AIProviderClaude, "claude-3-5-haiku-latest"}},
+		},
+		{
+			name:    "model asked without a provider goes to the provider offering it",
+			feature: domain.AIFeatureChat,
+			model:   "gpt-4.1",
+			want:    []routedCandidate{{domain.AIProviderClaude, "claude-3-5-haiku-latest"}, {domain.AIProviderOpenAI, "gpt-4.1"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := testAIService(configured, policy, false)
+
+			candidates, err := s.candidates("org", policy, tt.feature, tt.provider, tt.model)
+			if err != nil {
+				t.Fatalf("candidates: %v", err)
+			}
+
+			got := make([]routedCandidate, len(candidates))
+			for i, candidate := range candidates {
+				got[i] = routedCandidate{candidate.client.Provider(), candidate.model}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("candidates are %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("candidate %d is %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFakeProviderOnlyWhenEnabled(t *testing.T) {
+	caller := domain.AICaller{OrganizationUUID: "org", Feature: domain.AIFeatureChat}
+
+	enabled := testAIService(nil, nil, true)
+	resp, err := enabled.GenerateCompletion(caller, "", "what is a runbook", "")
+	if err != nil {
+		t.Fatalf("GenerateCompletion with the fake provider: %v", err)
+	}
+	if resp.Provider != domain.AIProviderFake || !strings.Contains(resp.Content, "what is a runbook") {
+		t.Errorf("fake provider answered %+v", resp)
+	}
+	if resp.Usage == nil || resp.Usage.TotalTokens == 0 {
+		t.Errorf("fake provider reported no usage: %+v", resp.Usage)
+	}
+
+	disabled := testAIService(nil, nil, false)
+	policy, err := disabled.GetRoutingPolicy("org")
+	if err != nil {
+		t.Fatalf("GetRoutingPolicy: %v", err)
+	}
+	for _, provider := range policy.FallbackOrder {
+		if provider == domain.AIProviderFake {
+			t.Errorf("default fallback order %v includes the disabled fake provider", policy.FallbackOrder)
+		}
+	}
+	if _, err := disabled.GenerateCompletion(caller, "", "hello", ""); err == nil {
+		t.Error("completion succeeded without any configured provider")
+	}
+	if _, err := disabled.GenerateCompletion(caller, domain.AIProviderFake, "hello", ""); err == nil {
+		t.Error("the disabled fake provider answered when asked explicitly")
+	}
+
+	route := &domain.AIRoutingPolicy{Routes: map[domain.AIFeature]domain.AIRoute{domain.AIFeatureChat: {Provider: domain.AIProviderFake}}}
+	if _, err := disabled.UpdateRoutingPolicy("org", route, "admin"); err == nil {
+		t.Error("a policy routing to the disabled fake provider was saved")
+	}
+	if _, err := enabled.UpdateRoutingPolicy("org", route, "admin"); err != nil {
+		t.Errorf("a policy routing to the enabled fake provider was refused: %v", err)
+	}
+}
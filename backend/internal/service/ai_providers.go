@@ -0,0 +1,317 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/PlatifyX/platifyx-core/pkg/claude"
+	"github.com/PlatifyX/platifyx-core/pkg/gemini"
+	"github.com/PlatifyX/platifyx-core/pkg/openai"
+)
+
+// AIProviderClient is a chat model provider behind the AI gateway
+type AIProviderClient interface {
+	Provider() domain.AIProvider
+	Chat(ctx context.Context, model string, messages []domain.ChatMessage) (*domain.AIResponse, error)
+	ChatStream(ctx context.Context, model string, messages []domain.ChatMessage, onDelta func(delta string) error) (*domain.AIResponse, error)
+}
+
+// aiProviderCatalog lists the models offered for each provider; the default model (the
+// cheapest) is used when neither the routing policy nor the request picks a listed model
+var aiProviderCatalog = map[domain.AIProvider]domain.AIProviderInfo{
+	domain.AIProviderOpenAI: {
+		Provider:     domain.AIProviderOpenAI,
+		Name:         "OpenAI",
+		Models:       []string{"gpt-4o-mini", "gpt-4.1-mini", "gpt-4o", "gpt-4.1"},
+		DefaultModel: "gpt-4o-mini",
+	},
+	domain.AIProviderClaude: {
+		Provider:     domain.AIProviderClaude,
+		Name:         "Claude (Anthropic)",
+		Models:       []string{"claude-3-5-haiku-latest", "claude-sonnet-4-0", "claude-opus-4-0"},
+		DefaultModel: "claude-3-5-haiku-latest",
+	},
+	domain.AIProviderGemini: {
+		Provider:     domain.AIProviderGemini,
+		Name:         "Google Gemini",
+		Models:       []string{"gemini-2.0-flash", "gemini-2.5-flash", "gemini-2.5-pro"},
+		DefaultModel: "gemini-2.0-flash",
+	},
+	domain.AIProviderFake: {
+		Provider:     domain.AIProviderFake,
+		Name:         "Fake (offline)",
+		Models:       []string{"fake"},
+		DefaultModel: "fake",
+	},
+}
+
+// aiModelPrice is the list price of a model, in USD per million tokens
+type aiModelPrice struct {
+	input  float64
+	output float64
+}
+
+// aiModelPricing prices the catalog models to estimate the cost of each call
+var aiModelPricing = map[string]aiModelPrice{
+	"gpt-4o-mini":             {input: 0.15, output: 0.60},
+	"gpt-4.1-mini":            {input: 0.40, output: 1.60},
+	"gpt-4o":                  {input: 2.50, output: 10.00},
+	"gpt-4.1":                 {input: 2.00, output: 8.00},
+	"claude-3-5-haiku-latest": {input: 0.80, output: 4.00},
+	"claude-sonnet-4-0":       {input: 3.00, output: 15.00},
+	"claude-opus-4-0":         {input: 15.00, output: 75.00},
+	"gemini-2.0-flash":        {input: 0.10, output: 0.40},
+	"gemini-2.5-flash":        {input: 0.30, output: 2.50},
+	"gemini-2.5-pro":          {input: 1.25, output: 10.00},
+	"text-embedding-3-small":  {input: 0.02},
+	"text-embedding-004":      {},
+	"fake":                    {},
+	"fake-embedding":          {},
+}
+
+// estimateAICost is the cost in USD of a call to a catalog model (0 for unknown models)
+func estimateAICost(model string, inputTokens, outputTokens int) float64 {
+	price := aiModelPricing[model]
+	return (float64(inputTokens)*price.input + float64(outputTokens)*price.output) / 1_000_000
+}
+
+// defaultAIFallbackOrder is used when the organization has no routing policy
+var defaultAIFallbackOrder = []domain.AIProvider{
+	domain.AIProviderClaude,
+	domain.AIProviderOpenAI,
+	domain.AIProviderGemini,
+}
+
+type openAIProvider struct {
+	client *openai.Client
+}
+
+func (p *openAIProvider) Provider() domain.AIProvider {
+	return domain.AIProviderOpenAI
+}
+
+func (p *openAIProvider) Chat(ctx context.Context, model string, messages []domain.ChatMessage) (*domain.AIResponse, error) {
+	resp, err := p.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+		Model:    model,
+		Messages: openAIMessagesOf(messages),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("OpenAI API error: %w", err)
+	}
+
+	if len(resp.Choices) == 0 {
+		return nil, fmt.Errorf("no response from OpenAI")
+	}
+
+	return openAIResponseOf(resp), nil
+}
+
+func (p *openAIProvider) ChatStream(ctx context.Context, model string, messages []domain.ChatMessage, onDelta func(delta string) error) (*domain.AIResponse, error) {
+	resp, err := p.client.CreateChatCompletionStream(ctx, openai.ChatCompletionRequest{
+		Model:    model,
+		Messages: openAIMessagesOf(messages),
+	}, onDelta)
+	if err != nil {
+		return nil, fmt.Errorf("OpenAI API error: %w", err)
+	}
+
+	return openAIResponseOf(resp), nil
+}
+
+func openAIResponseOf(resp *openai.ChatCompletionResponse) *domain.AIResponse {
+	response := &domain.AIResponse{
+		Provider: domain.AIProviderOpenAI,
+		Model:    resp.Model,
+		Content:  resp.Choices[0].Message.Content,
+	}
+	if resp.Usage != nil {
+		response.Usage = &domain.AIUsage{
+			InputTokens:  resp.Usage.PromptTokens,
+			OutputTokens: resp.Usage.CompletionTokens,
+			TotalTokens:  resp.Usage.TotalTokens,
+		}
+	}
+	return response
+}
+
+type claudeProvider struct {
+	client *claude.Client
+}
+
+func (p *claudeProvider) Provider() domain.AIProvider {
+	return domain.AIProviderClaude
+}
+
+func (p *claudeProvider) request(model string, messages []domain.ChatMessage) claude.MessageRequest {
+	system, claudeMessages := claudeMessagesOf(messages)
+	return claude.MessageRequest{
+		Model:     model,
+		System:    system,
+		MaxTokens: 4096,
+		Messages:  claudeMessages,
+	}
+}
+
+func (p *claudeProvider) Chat(ctx context.Context, model string, messages []domain.ChatMessage) (*domain.AIResponse, error) {
+	resp, err := p.client.CreateMessage(ctx, p.request(model, messages))
+	if err != nil {
+		return nil, fmt.Errorf("Claude API error: %w", err)
+	}
+
+	if len(resp.Content) == 0 {
+		return nil, fmt.Errorf("no response from Claude")
+	}
+
+	return claudeResponseOf(resp), nil
+}
+
+func (p *claudeProvider) ChatStream(ctx context.Context, model string, messages []domain.ChatMessage, onDelta func(delta string) error) (*domain.AIResponse, error) {
+	resp, err := p.client.CreateMessageStream(ctx, p.request(model, messages), onDelta)
+	if err != nil {
+		return nil, fmt.Errorf("Claude API error: %w", err)
+	}
+
+	return claudeResponseOf(resp), nil
+}
+
+func claudeResponseOf(resp *claude.MessageResponse) *domain.AIResponse {
+	return &domain.AIResponse{
+		Provider: domain.AIProviderClaude,
+		Model:    resp.Model,
+		Content:  resp.Content[0].Text,
+		Usage: &domain.AIUsage{
+			InputTokens:  resp.Usage.InputTokens,
+			OutputTokens: resp.Usage.OutputTokens,
+			TotalTokens:  resp.Usage.InputTokens + resp.Usage.OutputTokens,
+		},
+	}
+}
+
+type geminiProvider struct {
+	client *gemini.Client
+}
+
+func (p *geminiProvider) Provider() domain.AIProvider {
+	return domain.AIProviderGemini
+}
+
+func (p *geminiProvider) request(messages []domain.ChatMessage) gemini.GenerateContentRequest {
+	return gemini.GenerateContentRequest{
+		Contents: []gemini.Content{
+			{
+				Parts: []gemini.Part{
+					{Text: geminiChatPrompt(messages)},
+				},
+			},
+		},
+	}
+}
+
+func (p *geminiProvider) Chat(ctx context.Context, model string, messages []domain.ChatMessage) (*domain.AIResponse, error) {
+	resp, err := p.client.GenerateContent(ctx, model, p.request(messages))
+	if err != nil {
+		return nil, fmt.Errorf("Gemini API error: %w", err)
+	}
+
+	if len(resp.Candidates) == 0 || len(resp.Candidates[0].Content.Parts) == 0 {
+		return nil, fmt.Errorf("no response from Gemini")
+	}
+
+	return geminiResponseOf(model, resp), nil
+}
+
+func (p *geminiProvider) ChatStream(ctx context.Context, model string, messages []domain.ChatMessage, onDelta func(delta string) error) (*domain.AIResponse, error) {
+	resp, err := p.client.GenerateContentStream(ctx, model, p.request(messages), onDelta)
+	if err != nil {
+		return nil, fmt.Errorf("Gemini API error: %w", err)
+	}
+
+	return geminiResponseOf(model, resp), nil
+}
+
+func geminiResponseOf(model string, resp *gemini.GenerateContentResponse) *domain.AIResponse {
+	response := &domain.AIResponse{
+		Provider: domain.AIProviderGemini,
+		Model:    model,
+		Content:  resp.Candidates[0].Content.Parts[0].Text,
+	}
+	if resp.UsageMetadata != nil {
+		response.Usage = &domain.AIUsage{
+			InputTokens:  resp.UsageMetadata.PromptTokenCount,
+			OutputTokens: resp.UsageMetadata.CandidatesTokenCount,
+			TotalTokens:  resp.UsageMetadata.TotalTokenCount,
+		}
+	}
+	return response
+}
+
+// fakeAIProvider answers locally and deterministically, so AI features can be exercised
+// offline (development, CI). It echoes the last user message; usage counts words.
+type fakeAIProvider struct{}
+
+func (p *fakeAIProvider) Provider() domain.AIProvider {
+	return domain.AIProviderFake
+}
+
+func (p *fakeAIProvider) answer(messages []domain.ChatMessage) string {
+	question := ""
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == "user" {
+			question = messages[i].Content
+			break
+		}
+	}
+
+	const maxEcho = 200
+	if len(question) > maxEcho {
+		question = question[:maxEcho] + "..."
+	}
+	return fmt.Sprintf("[fake] Resposta gerada localmente para: %s", question)
+}
+
+func (p *fakeAIProvider) usage(messages []domain.ChatMessage, answer string) *domain.AIUsage {
+	input := 0
+	for _, msg := range messages {
+		input += len(strings.Fields(msg.Content))
+	}
+	output := len(strings.Fields(answer))
+	return &domain.AIUsage{
+		InputTokens:  input,
+		OutputTokens: output,
+		TotalTokens:  input + output,
+	}
+}
+
+func (p *fakeAIProvider) Chat(ctx context.Context, model string, messages []domain.ChatMessage) (*domain.AIResponse, error) {
+	answer := p.answer(messages)
+	return &domain.AIResponse{
+		Provider: domain.AIProviderFake,
+		Model:    model,
+		Content:  answer,
+		Usage:    p.usage(messages, answer),
+	}, nil
+}
+
+func (p *fakeAIProvider) ChatStream(ctx context.Context, model string, messages []domain.ChatMessage, onDelta func(delta string) error) (*domain.AIResponse, error) {
+	answer := p.answer(messages)
+	for i, word := range strings.Fields(answer) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			word = " " + word
+		}
+		if err := onDelta(word); err != nil {
+			return nil, err
+		}
+	}
+
+	return &domain.AIResponse{
+		Provider: domain.AIProviderFake,
+		Model:    model,
+		Content:  answer,
+		Usage:    p.usage(messages, answer),
+	}, nil
+}
@@ -1,7 +1,9 @@
 package service
 
 import (
+	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/PlatifyX/platifyx-core/internal/domain"
@@ -11,15 +13,38 @@ import (
 	"github.com/PlatifyX/platifyx-core/pkg/openai"
 )
 
+// AIRoutingPolicyStore persists the AI routing policy of each organization
+type AIRoutingPolicyStore interface {
+	Get(organizationUUID string) (*domain.AIRoutingPolicy, error)
+	Upsert(organizationUUID string, policy *domain.AIRoutingPolicy, updatedBy string) error
+}
+
+// AIProviderConfigs reads the AI provider integrations of an organization; a nil config means the
+// provider is not configured
+type AIProviderConfigs interface {
+	GetOpenAIConfig(organizationUUID string) (*domain.OpenAIIntegrationConfig, error)
+	GetClaudeConfig(organizationUUID string) (*domain.ClaudeIntegrationConfig, error)
+	GetGeminiConfig(organizationUUID string) (*domain.GeminiIntegrationConfig, error)
+}
+
 type AIService struct {
-	integrationService *IntegrationService
-	log                *logger.Logger
+	providerConfigs AIProviderConfigs
+	policyStore     AIRoutingPolicyStore
+	usageService    *AIUsageService
+	fakeProvider    bool
+	log             *logger.Logger
 }
 
-func NewAIService(integrationService *IntegrationService, log *logger.Logger) *AIService {
+// NewAIService creates the AI gateway. Every call is metered and checked against the monthly
+// budget by usageService. fakeProvider enables the offline provider, which answers locally so
+// the AI features can run without any API key; it is never used unless enabled.
+func NewAIService(providerConfigs AIProviderConfigs, policyStore AIRoutingPolicyStore, usageService *AIUsageService, fakeProvider bool, log *logger.Logger) *AIService {
 	return &AIService{
-		integrationService: integrationService,
-		log:                log,
+		providerConfigs: providerConfigs,
+		policyStore:     policyStore,
+		usageService:    usageService,
+		fakeProvider:    fakeProvider,
+		log:             log,
 	}
 }
 
@@ -27,352 +52,229 @@ func NewAIService(integrationService *IntegrationService, log *logger.Logger) *A
 func (s *AIService) GetAvailableProviders(organizationUUID string) ([]domain.AIProviderInfo, error) {
 	providers := []domain.AIProviderInfo{}
 
-	// Check OpenAI
-	openaiConfig, err := s.integrationService.GetOpenAIConfig(organizationUUID)
-	if err == nil && openaiConfig != nil && openaiConfig.APIKey != "" {
-		providers = append(providers, domain.AIProviderInfo{
-			Provider:  domain.AIProviderOpenAI,
-			Name:      "OpenAI",
-			Available: true,
-			Models:    []string{"gpt-4", "gpt-4-turbo-preview", "gpt-3.5-turbo"},
-		})
-	}
-
-	// Check Claude
-	claudeConfig, err := s.integrationService.GetClaudeConfig(organizationUUID)
-	if err == nil && claudeConfig != nil && claudeConfig.APIKey != "" {
-		providers = append(providers, domain.AIProviderInfo{
-			Provider:  domain.AIProviderClaude,
-			Name:      "Claude (Anthropic)",
-			Available: true,
-			Models:    []string{"claude-3-opus-20240229", "claude-3-sonnet-20240229", "claude-3-haiku-20240307"},
-		})
-	}
+	for _, provider := range []domain.AIProvider{domain.AIProviderOpenAI, domain.AIProviderClaude, domain.AIProviderGemini, domain.AIProviderFake} {
+		client, err := s.providerClient(organizationUUID, provider)
+		if err != nil {
+			s.log.Warnw("Failed to load AI provider config", "provider", provider, "error", err, "organizationUUID", organizationUUID)
+			continue
+		}
+		if client == nil {
+			continue
+		}
 
-	// Check Gemini
-	geminiConfig, err := s.integrationService.GetGeminiConfig(organizationUUID)
-	if err == nil && geminiConfig != nil && geminiConfig.APIKey != "" {
-		providers = append(providers, domain.AIProviderInfo{
-			Provider:  domain.AIProviderGemini,
-			Name:      "Google Gemini",
-			Available: true,
-			Models:    []string{"gemini-pro", "gemini-1.5-pro-latest"},
-		})
+		info := aiProviderCatalog[provider]
+		info.Available = true
+		providers = append(providers, info)
 	}
 
 	return providers, nil
 }
 
-// GenerateCompletion generates text completion using specified provider
-func (s *AIService) GenerateCompletion(organizationUUID string, provider domain.AIProvider, prompt string, model string) (*domain.AIResponse, error) {
-	s.log.Infow("Generating completion", "provider", provider, "model", model, "organizationUUID", organizationUUID)
-
-	switch provider {
-	case domain.AIProviderOpenAI:
-		return s.generateOpenAICompletion(organizationUUID, prompt, model)
-	case domain.AIProviderClaude:
-		return s.generateClaudeCompletion(organizationUUID, prompt, model)
-	case domain.AIProviderGemini:
-		return s.generateGeminiCompletion(organizationUUID, prompt, model)
-	default:
-		return nil, fmt.Errorf("unsupported AI provider: %s", provider)
-	}
+// GenerateCompletion generates text completion for the feature of the caller, routed by the
+// organization policy. provider and model are preferences of the caller; empty values leave the
+// choice to the policy.
+func (s *AIService) GenerateCompletion(caller domain.AICaller, provider domain.AIProvider, prompt string, model string) (*domain.AIResponse, error) {
+	return s.GenerateChat(caller, provider, []domain.ChatMessage{
+		{Role: "user", Content: prompt},
+	}, model)
 }
 
-// GenerateChat generates chat response using specified provider
-func (s *AIService) GenerateChat(organizationUUID string, provider domain.AIProvider, messages []domain.ChatMessage, model string) (*domain.AIResponse, error) {
-	s.log.Infow("Generating chat response", "provider", provider, "model", model, "messages", len(messages), "organizationUUID", organizationUUID)
+// GenerateChat generates chat response for the feature of the caller, routed by the organization policy
+func (s *AIService) GenerateChat(caller domain.AICaller, provider domain.AIProvider, messages []domain.ChatMessage, model string) (*domain.AIResponse, error) {
+	s.log.Infow("Generating chat response", "feature", caller.Feature, "provider", provider, "model", model, "messages", len(messages), "organizationUUID", caller.OrganizationUUID)
 
-	switch provider {
-	case domain.AIProviderOpenAI:
-		return s.generateOpenAIChat(organizationUUID, messages, model)
-	case domain.AIProviderClaude:
-		return s.generateClaudeChat(organizationUUID, messages, model)
-	case domain.AIProviderGemini:
-		return s.generateGeminiChat(organizationUUID, messages, model)
-	default:
-		return nil, fmt.Errorf("unsupported AI provider: %s", provider)
-	}
+	return s.route(context.Background(), caller, provider, model, messages, false, func(ctx context.Context, client AIProviderClient, model string) (*domain.AIResponse, error) {
+		return client.Chat(ctx, model, messages)
+	})
 }
 
-func (s *AIService) generateOpenAICompletion(organizationUUID string, prompt string, model string) (*domain.AIResponse, error) {
-	config, err := s.integrationService.GetOpenAIConfig(organizationUUID)
-	if err != nil {
-		return nil, fmt.Errorf("OpenAI not configured: %w", err)
-	}
-
-	client := openai.NewClient(config.APIKey, config.Organization)
-
-	// ALWAYS force cheapest model (gpt-3.5-turbo) to minimize costs
-	requestedModel := model
-	model = "gpt-3.5-turbo"
+// GenerateChatStream generates a chat response like GenerateChat, handing each partial token to
+// onDelta as the provider produces it. The returned response holds the whole content and the
+// usage. Cancelling ctx (e.g. the client disconnected) aborts the provider request.
+func (s *AIService) GenerateChatStream(ctx context.Context, caller domain.AICaller, provider domain.AIProvider, messages []domain.ChatMessage, model string, onDelta func(delta string) error) (*domain.AIResponse, error) {
+	s.log.Infow("Streaming chat response", "feature", caller.Feature, "provider", provider, "model", model, "messages", len(messages), "organizationUUID", caller.OrganizationUUID)
 
-	if requestedModel != "" && requestedModel != model {
-		s.log.Infow("Forcing cheapest OpenAI model",
-			"requested", requestedModel,
-			"forced", model)
+	// Once a token reached the client, a retry or another provider would repeat the answer
+	emitted := false
+	emit := func(delta string) error {
+		emitted = true
+		return onDelta(delta)
 	}
 
-	request := openai.ChatCompletionRequest{
-		Model: model,
-		Messages: []openai.ChatMessage{
-			{Role: "user", Content: prompt},
-		},
-	}
-
-	resp, err := client.CreateChatCompletion(request)
-	if err != nil {
-		return nil, fmt.Errorf("OpenAI API error: %w", err)
-	}
-
-	if len(resp.Choices) == 0 {
-		return nil, fmt.Errorf("no response from OpenAI")
-	}
-
-	return &domain.AIResponse{
-		Provider: domain.AIProviderOpenAI,
-		Model:    resp.Model,
-		Content:  resp.Choices[0].Message.Content,
-	}, nil
+	return s.route(ctx, caller, provider, model, messages, true, func(ctx context.Context, client AIProviderClient, model string) (*domain.AIResponse, error) {
+		resp, err := client.ChatStream(ctx, model, messages, emit)
+		if err != nil && emitted {
+			return nil, &aiStreamInterruptedError{err: err}
+		}
+		return resp, err
+	})
 }
 
-func (s *AIService) generateOpenAIChat(organizationUUID string, messages []domain.ChatMessage, model string) (*domain.AIResponse, error) {
-	config, err := s.integrationService.GetOpenAIConfig(organizationUUID)
+// GetRoutingPolicy returns the routing policy of the organization, or the default one
+func (s *AIService) GetRoutingPolicy(organizationUUID string) (*domain.AIRoutingPolicy, error) {
+	policy, err := s.policyStore.Get(organizationUUID)
 	if err != nil {
-		return nil, fmt.Errorf("OpenAI not configured: %w", err)
+		return nil, fmt.Errorf("failed to load AI routing policy: %w", err)
 	}
-
-	client := openai.NewClient(config.APIKey, config.Organization)
-
-	// ALWAYS force cheapest model (gpt-3.5-turbo) to minimize costs
-	requestedModel := model
-	model = "gpt-3.5-turbo"
-
-	if requestedModel != "" && requestedModel != model {
-		s.log.Infow("Forcing cheapest OpenAI model for chat",
-			"requested", requestedModel,
-			"forced", model)
+	if policy == nil {
+		return s.defaultRoutingPolicy(), nil
 	}
-
-	openaiMessages := make([]openai.ChatMessage, len(messages))
-	for i, msg := range messages {
-		openaiMessages[i] = openai.ChatMessage{
-			Role:    msg.Role,
-			Content: msg.Content,
-		}
+	if policy.Routes == nil {
+		policy.Routes = map[domain.AIFeature]domain.AIRoute{}
 	}
+	return policy, nil
+}
 
-	request := openai.ChatCompletionRequest{
-		Model:    model,
-		Messages: openaiMessages,
+// UpdateRoutingPolicy validates and saves the routing policy of the organization
+func (s *AIService) UpdateRoutingPolicy(organizationUUID string, policy *domain.AIRoutingPolicy, updatedBy string) (*domain.AIRoutingPolicy, error) {
+	if err := validateAIRoutingPolicy(policy, s.fakeProvider); err != nil {
+		return nil, err
 	}
 
-	resp, err := client.CreateChatCompletion(request)
-	if err != nil {
-		return nil, fmt.Errorf("OpenAI API error: %w", err)
+	if err := s.policyStore.Upsert(organizationUUID, policy, updatedBy); err != nil {
+		return nil, fmt.Errorf("failed to save AI routing policy: %w", err)
 	}
 
-	if len(resp.Choices) == 0 {
-		return nil, fmt.Errorf("no response from OpenAI")
-	}
+	s.log.Infow("AI routing policy updated", "organizationUUID", organizationUUID, "updatedBy", updatedBy, "routes", len(policy.Routes), "fallbackOrder", policy.FallbackOrder)
 
-	return &domain.AIResponse{
-		Provider: domain.AIProviderOpenAI,
-		Model:    resp.Model,
-		Content:  resp.Choices[0].Message.Content,
-	}, nil
+	return s.GetRoutingPolicy(organizationUUID)
 }
 
-func (s *AIService) generateClaudeCompletion(organizationUUID string, prompt string, model string) (*domain.AIResponse, error) {
-	config, err := s.integrationService.GetClaudeConfig(organizationUUID)
-	if err != nil {
-		return nil, fmt.Errorf("Claude not configured: %w", err)
+// defaultRoutingPolicy is the policy of organizations without one; the offline provider ends the
+// fallback order only when it is enabled
+func (s *AIService) defaultRoutingPolicy() *domain.AIRoutingPolicy {
+	fallbackOrder := append([]domain.AIProvider(nil), defaultAIFallbackOrder...)
+	if s.fakeProvider {
+		fallbackOrder = append(fallbackOrder, domain.AIProviderFake)
 	}
 
-	client := claude.NewClient(config.APIKey)
-
-	// ALWAYS force cheapest model (Haiku) to minimize costs
-	requestedModel := model
-	model = "claude-3-haiku-20240307"
-
-	if requestedModel != "" && requestedModel != model {
-		s.log.Infow("Forcing cheapest Claude model",
-			"requested", requestedModel,
-			"forced", model)
+	return &domain.AIRoutingPolicy{
+		Routes:        map[domain.AIFeature]domain.AIRoute{},
+		FallbackOrder: fallbackOrder,
+		MaxRetries:    defaultAIMaxRetries,
 	}
+}
 
-	request := claude.MessageRequest{
-		Model:     model,
-		MaxTokens: 4096,
-		Messages: []claude.Message{
-			{Role: "user", Content: prompt},
-		},
-	}
+var aiFeatures = map[domain.AIFeature]bool{
+	domain.AIFeatureDefault:         true,
+	domain.AIFeatureTechDocs:        true,
+	domain.AIFeatureChat:            true,
+	domain.AIFeatureDiagram:         true,
+	domain.AIFeatureAutoDocs:        true,
+	domain.AIFeatureTroubleshooting: true,
+	domain.AIFeatureRecommendations: true,
+	domain.AIFeatureEmbeddings:      true,
+}
 
-	resp, err := client.CreateMessage(request)
-	if err != nil {
-		return nil, fmt.Errorf("Claude API error: %w", err)
+// validateAIRoutingPolicy checks the policy against the catalog; the offline provider may only
+// be routed to when it is enabled
+func validateAIRoutingPolicy(policy *domain.AIRoutingPolicy, fakeProvider bool) error {
+	if policy.MaxRetries < 0 || policy.MaxRetries > maxAIRetries {
+		return &domain.ValidationError{Field: "maxRetries", Message: fmt.Sprintf("must be between 0 and %d", maxAIRetries)}
 	}
 
-	if len(resp.Content) == 0 {
-		return nil, fmt.Errorf("no response from Claude")
+	for feature, route := range policy.Routes {
+		if !aiFeatures[feature] {
+			return &domain.ValidationError{Field: "routes", Message: fmt.Sprintf("unknown feature %q", feature)}
+		}
+		info, ok := aiProviderCatalog[route.Provider]
+		if !ok || (route.Provider == domain.AIProviderFake && !fakeProvider) {
+			return &domain.ValidationError{Field: "routes." + string(feature), Message: fmt.Sprintf("unknown provider %q", route.Provider)}
+		}
+		if feature == domain.AIFeatureEmbeddings {
+			if _, ok := aiEmbeddingModels[route.Provider]; !ok {
+				return &domain.ValidationError{Field: "routes." + string(feature), Message: fmt.Sprintf("provider %s has no embedding model", route.Provider)}
+			}
+			if route.Model != "" {
+				return &domain.ValidationError{Field: "routes." + string(feature), Message: "the embedding model is fixed per provider"}
+			}
+			continue
+		}
+		if route.Model != "" && !slices.Contains(info.Models, route.Model) {
+			return &domain.ValidationError{Field: "routes." + string(feature), Message: fmt.Sprintf("unknown model %q for provider %s", route.Model, route.Provider)}
+		}
 	}
 
-	return &domain.AIResponse{
-		Provider: domain.AIProviderClaude,
-		Model:    resp.Model,
-		Content:  resp.Content[0].Text,
-		Usage: &domain.AIUsage{
-			InputTokens:  resp.Usage.InputTokens,
-			OutputTokens: resp.Usage.OutputTokens,
-			TotalTokens:  resp.Usage.InputTokens + resp.Usage.OutputTokens,
-		},
-	}, nil
-}
-
-func (s *AIService) generateClaudeChat(organizationUUID string, messages []domain.ChatMessage, model string) (*domain.AIResponse, error) {
-	config, err := s.integrationService.GetClaudeConfig(organizationUUID)
-	if err != nil {
-		return nil, fmt.Errorf("Claude not configured: %w", err)
+	seen := map[domain.AIProvider]bool{}
+	for _, provider := range policy.FallbackOrder {
+		if _, ok := aiProviderCatalog[provider]; !ok || (provider == domain.AIProviderFake && !fakeProvider) {
+			return &domain.ValidationError{Field: "fallbackOrder", Message: fmt.Sprintf("unknown provider %q", provider)}
+		}
+		if seen[provider] {
+			return &domain.ValidationError{Field: "fallbackOrder", Message: fmt.Sprintf("provider %q listed twice", provider)}
+		}
+		seen[provider] = true
 	}
 
-	client := claude.NewClient(config.APIKey)
-
-	// ALWAYS force cheapest model (Haiku) to minimize costs
-	requestedModel := model
-	model = "claude-3-haiku-20240307"
+	return nil
+}
 
-	if requestedModel != "" && requestedModel != model {
-		s.log.Infow("Forcing cheapest Claude model for chat",
-			"requested", requestedModel,
-			"forced", model)
+// providerClient returns the client of a provider for the organization, or nil when the
+// provider is not configured
+func (s *AIService) providerClient(organizationUUID string, provider domain.AIProvider) (AIProviderClient, error) {
+	switch provider {
+	case domain.AIProviderOpenAI:
+		config, err := s.providerConfigs.GetOpenAIConfig(organizationUUID)
+		if err != nil || config == nil || config.APIKey == "" {
+			return nil, err
+		}
+		return &openAIProvider{client: openai.NewClient(config.APIKey, config.Organization)}, nil
+	case domain.AIProviderClaude:
+		config, err := s.providerConfigs.GetClaudeConfig(organizationUUID)
+		if err != nil || config == nil || config.APIKey == "" {
+			return nil, err
+		}
+		return &claudeProvider{client: claude.NewClient(config.APIKey)}, nil
+	case domain.AIProviderGemini:
+		config, err := s.providerConfigs.GetGeminiConfig(organizationUUID)
+		if err != nil || config == nil || config.APIKey == "" {
+			return nil, err
+		}
+		return &geminiProvider{client: gemini.NewClient(config.APIKey)}, nil
+	case domain.AIProviderFake:
+		if !s.fakeProvider {
+			return nil, nil
+		}
+		return &fakeAIProvider{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported AI provider: %s", provider)
 	}
+}
 
-	claudeMessages := make([]claude.Message, len(messages))
+func openAIMessagesOf(messages []domain.ChatMessage) []openai.ChatMessage {
+	openaiMessages := make([]openai.ChatMessage, len(messages))
 	for i, msg := range messages {
-		claudeMessages[i] = claude.Message{
+		openaiMessages[i] = openai.ChatMessage{
 			Role:    msg.Role,
 			Content: msg.Content,
 		}
 	}
-
-	request := claude.MessageRequest{
-		Model:     model,
-		MaxTokens: 4096,
-		Messages:  claudeMessages,
-	}
-
-	resp, err := client.CreateMessage(request)
-	if err != nil {
-		return nil, fmt.Errorf("Claude API error: %w", err)
-	}
-
-	if len(resp.Content) == 0 {
-		return nil, fmt.Errorf("no response from Claude")
-	}
-
-	return &domain.AIResponse{
-		Provider: domain.AIProviderClaude,
-		Model:    resp.Model,
-		Content:  resp.Content[0].Text,
-		Usage: &domain.AIUsage{
-			InputTokens:  resp.Usage.InputTokens,
-			OutputTokens: resp.Usage.OutputTokens,
-			TotalTokens:  resp.Usage.InputTokens + resp.Usage.OutputTokens,
-		},
-	}, nil
+	return openaiMessages
 }
 
-func (s *AIService) generateGeminiCompletion(organizationUUID string, prompt string, model string) (*domain.AIResponse, error) {
-	config, err := s.integrationService.GetGeminiConfig(organizationUUID)
-	if err != nil {
-		return nil, fmt.Errorf("Gemini not configured: %w", err)
-	}
-
-	client := gemini.NewClient(config.APIKey)
-
-	// ALWAYS force cheapest model (gemini-pro) to minimize costs
-	requestedModel := model
-	model = "gemini-pro"
-
-	if requestedModel != "" && requestedModel != model {
-		s.log.Infow("Forcing cheapest Gemini model",
-			"requested", requestedModel,
-			"forced", model)
-	}
-
-	request := gemini.GenerateContentRequest{
-		Contents: []gemini.Content{
-			{
-				Parts: []gemini.Part{
-					{Text: prompt},
-				},
-			},
-		},
-	}
-
-	resp, err := client.GenerateContent(model, request)
-	if err != nil {
-		return nil, fmt.Errorf("Gemini API error: %w", err)
-	}
-
-	if len(resp.Candidates) == 0 || len(resp.Candidates[0].Content.Parts) == 0 {
-		return nil, fmt.Errorf("no response from Gemini")
+// claudeMessagesOf moves the system messages to the system prompt, which Claude takes apart
+// from the conversation
+func claudeMessagesOf(messages []domain.ChatMessage) (string, []claude.Message) {
+	var system []string
+	claudeMessages := make([]claude.Message, 0, len(messages))
+	for _, msg := range messages {
+		if msg.Role == "system" {
+			system = append(system, msg.Content)
+			continue
+		}
+		claudeMessages = append(claudeMessages, claude.Message{
+			Role:    msg.Role,
+			Content: msg.Content,
+		})
 	}
-
-	return &domain.AIResponse{
-		Provider: domain.AIProviderGemini,
-		Model:    model,
-		Content:  resp.Candidates[0].Content.Parts[0].Text,
-	}, nil
+	return strings.Join(system, "\n\n"), claudeMessages
 }
 
-func (s *AIService) generateGeminiChat(organizationUUID string, messages []domain.ChatMessage, model string) (*domain.AIResponse, error) {
-	config, err := s.integrationService.GetGeminiConfig(organizationUUID)
-	if err != nil {
-		return nil, fmt.Errorf("Gemini not configured: %w", err)
-	}
-
-	client := gemini.NewClient(config.APIKey)
-
-	// ALWAYS force cheapest model (gemini-pro) to minimize costs
-	requestedModel := model
-	model = "gemini-pro"
-
-	if requestedModel != "" && requestedModel != model {
-		s.log.Infow("Forcing cheapest Gemini model for chat",
-			"requested", requestedModel,
-			"forced", model)
-	}
-
-	// Combine messages into a single prompt for Gemini
+// geminiChatPrompt combines the messages into a single prompt for Gemini
+func geminiChatPrompt(messages []domain.ChatMessage) string {
 	var promptBuilder strings.Builder
 	for _, msg := range messages {
 		promptBuilder.WriteString(fmt.Sprintf("%s: %s\n", msg.Role, msg.Content))
 	}
-
-	request := gemini.GenerateContentRequest{
-		Contents: []gemini.Content{
-			{
-				Parts: []gemini.Part{
-					{Text: promptBuilder.String()},
-				},
-			},
-		},
-	}
-
-	resp, err := client.GenerateContent(model, request)
-	if err != nil {
-		return nil, fmt.Errorf("Gemini API error: %w", err)
-	}
-
-	if len(resp.Candidates) == 0 || len(resp.Candidates[0].Content.Parts) == 0 {
-		return nil, fmt.Errorf("no response from Gemini")
-	}
-
-	return &domain.AIResponse{
-		Provider: domain.AIProviderGemini,
-		Model:    model,
-		Content:  resp.Candidates[0].Content.Parts[0].Text,
-	}, nil
+	return promptBuilder.String()
 }
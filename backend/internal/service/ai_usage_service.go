@@ -0,0 +1,261 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/PlatifyX/platifyx-core/internal/repository"
+	"github.com/PlatifyX/platifyx-core/pkg/logger"
+)
+
+const (
+	defaultAIBudgetWarningPercent = 80
+
+	aiBudgetNotificationWarning  = "warning"
+	aiBudgetNotificationExceeded = "exceeded"
+)
+
+// AIUsageService meters the AI gateway calls, enforces the monthly budget of each organization
+// and reports usage over time
+type AIUsageService struct {
+	usageRepo          *repository.AIUsageRepository
+	budgetRepo         *repository.AIBudgetRepository
+	integrationService *IntegrationService
+	log                *logger.Logger
+}
+
+func NewAIUsageService(usageRepo *repository.AIUsageRepository, budgetRepo *repository.AIBudgetRepository, integrationService *IntegrationService, log *logger.Logger) *AIUsageService {
+	return &AIUsageService{
+		usageRepo:          usageRepo,
+		budgetRepo:         budgetRepo,
+		integrationService: integrationService,
+		log:                log,
+	}
+}
+
+// CheckBudget returns a *domain.AIBudgetExceededError once the organization spent its monthly budget
+func (s *AIUsageService) CheckBudget(organizationUUID string) error {
+	status, err := s.GetBudgetStatus(organizationUUID)
+	if err != nil {
+		return err
+	}
+
+	if status.Exceeded {
+		return &domain.AIBudgetExceededError{
+			LimitUSD: status.Budget.MonthlyLimitUSD,
+			SpentUSD: status.SpentUSD,
+		}
+	}
+	return nil
+}
+
+// Record meters a successful call. Tokens are estimated from the text size when the provider did
+// not report usage. Failing to record is only logged: the answer was already produced.
+func (s *AIUsageService) Record(caller domain.AICaller, model string, messages []domain.ChatMessage, resp *domain.AIResponse) {
+	record := &domain.AIUsageRecord{
+		OrganizationUUID: caller.OrganizationUUID,
+		UserID:           caller.UserID,
+		Feature:          caller.Feature,
+		Provider:         resp.Provider,
+		Model:            model,
+	}
+
+	if resp.Usage != nil && resp.Usage.TotalTokens > 0 {
+		record.InputTokens = resp.Usage.InputTokens
+		record.OutputTokens = resp.Usage.OutputTokens
+		record.TotalTokens = resp.Usage.TotalTokens
+	} else {
+		for _, msg := range messages {
+			record.InputTokens += estimateTokens(msg.Content)
+		}
+		record.OutputTokens = estimateTokens(resp.Content)
+		record.TotalTokens = record.InputTokens + record.OutputTokens
+		record.TokensEstimated = true
+	}
+	record.EstimatedCostUSD = estimateAICost(model, record.InputTokens, record.OutputTokens)
+
+	if err := s.usageRepo.Create(record); err != nil {
+		s.log.Errorw("Failed to record AI usage", "error", err, "organizationUUID", caller.OrganizationUUID, "feature", caller.Feature)
+		return
+	}
+
+	go s.notifyBudgetThresholds(caller.OrganizationUUID)
+}
+
+// GetBudget returns the budget of the organization (no limit when never configured)
+func (s *AIUsageService) GetBudget(organizationUUID string) (*domain.AIBudget, error) {
+	budget, err := s.budgetRepo.Get(organizationUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AI budget: %w", err)
+	}
+	if budget == nil {
+		return &domain.AIBudget{WarningPercent: defaultAIBudgetWarningPercent}, nil
+	}
+	return budget, nil
+}
+
+// GetBudgetStatus returns the spending of the current month against the budget
+func (s *AIUsageService) GetBudgetStatus(organizationUUID string) (*domain.AIBudgetStatus, error) {
+	budget, err := s.GetBudget(organizationUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	month := aiBudgetMonth(time.Now())
+	status := &domain.AIBudgetStatus{
+		Budget: *budget,
+		Month:  month,
+	}
+
+	status.SpentUSD, err = s.usageRepo.SumCost(organizationUUID, month, month.AddDate(0, 1, 0))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum AI usage: %w", err)
+	}
+
+	if budget.MonthlyLimitUSD > 0 {
+		remaining := budget.MonthlyLimitUSD - status.SpentUSD
+		if remaining < 0 {
+			remaining = 0
+		}
+		status.RemainingUSD = &remaining
+		status.PercentUsed = status.SpentUSD / budget.MonthlyLimitUSD * 100
+		status.Exceeded = status.SpentUSD >= budget.MonthlyLimitUSD
+		status.Warning = status.PercentUsed >= float64(budget.WarningPercent)
+	}
+
+	return status, nil
+}
+
+// UpdateBudget validates and saves the budget of the organization
+func (s *AIUsageService) UpdateBudget(organizationUUID string, budget *domain.AIBudget, updatedBy string) (*domain.AIBudgetStatus, error) {
+	if budget.MonthlyLimitUSD < 0 {
+		return nil, &domain.ValidationError{Field: "monthlyLimitUsd", Message: "must not be negative"}
+	}
+	if budget.WarningPercent == 0 {
+		budget.WarningPercent = defaultAIBudgetWarningPercent
+	}
+	if budget.WarningPercent < 1 || budget.WarningPercent > 100 {
+		return nil, &domain.ValidationError{Field: "warningPercent", Message: "must be between 1 and 100"}
+	}
+
+	if err := s.budgetRepo.Upsert(organizationUUID, budget, updatedBy); err != nil {
+		return nil, fmt.Errorf("failed to save AI budget: %w", err)
+	}
+
+	s.log.Infow("AI budget updated", "organizationUUID", organizationUUID, "monthlyLimitUsd", budget.MonthlyLimitUSD, "warningPercent", budget.WarningPercent, "updatedBy", updatedBy)
+
+	return s.GetBudgetStatus(organizationUUID)
+}
+
+// GetUsageReport returns the usage of the organization in [from, to), as a series by day or month
+// and broken down by feature, model and user
+func (s *AIUsageService) GetUsageReport(organizationUUID string, from, to time.Time, interval string) (*domain.AIUsageReport, error) {
+	report := &domain.AIUsageReport{
+		From:     from,
+		To:       to,
+		Interval: interval,
+	}
+
+	totals, err := s.usageRepo.Totals(organizationUUID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AI usage totals: %w", err)
+	}
+	report.Totals = *totals
+
+	if report.Series, err = s.usageRepo.Series(organizationUUID, from, to, interval); err != nil {
+		return nil, fmt.Errorf("failed to load AI usage series: %w", err)
+	}
+	if report.ByFeature, err = s.usageRepo.Breakdown(organizationUUID, from, to, "feature"); err != nil {
+		return nil, fmt.Errorf("failed to load AI usage by feature: %w", err)
+	}
+	if report.ByModel, err = s.usageRepo.Breakdown(organizationUUID, from, to, "model"); err != nil {
+		return nil, fmt.Errorf("failed to load AI usage by model: %w", err)
+	}
+	if report.ByUser, err = s.usageRepo.Breakdown(organizationUUID, from, to, "user"); err != nil {
+		return nil, fmt.Errorf("failed to load AI usage by user: %w", err)
+	}
+
+	return report, nil
+}
+
+// notifyBudgetThresholds warns the organization on Slack/Teams the first time in the month its
+// spending crosses the warning threshold, and again when it exceeds the budget
+func (s *AIUsageService) notifyBudgetThresholds(organizationUUID string) {
+	status, err := s.GetBudgetStatus(organizationUUID)
+	if err != nil {
+		s.log.Warnw("Failed to check AI budget thresholds", "error", err, "organizationUUID", organizationUUID)
+		return
+	}
+
+	kind := ""
+	switch {
+	case status.Exceeded:
+		kind = aiBudgetNotificationExceeded
+	case status.Warning:
+		kind = aiBudgetNotificationWarning
+	default:
+		return
+	}
+
+	claimed, err := s.budgetRepo.ClaimNotification(organizationUUID, kind, status.Month)
+	if err != nil {
+		s.log.Warnw("Failed to claim AI budget notification", "error", err, "organizationUUID", organizationUUID, "kind", kind)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	title := "Orçamento de IA próximo do limite"
+	color := "FFA500"
+	if kind == aiBudgetNotificationExceeded {
+		title = "Orçamento de IA excedido"
+		color = "D32F2F"
+	}
+	text := fmt.Sprintf("Consumo de IA em %s: US$ %.2f de US$ %.2f (%.0f%%).",
+		status.Month.Format("01/2006"), status.SpentUSD, status.Budget.MonthlyLimitUSD, status.PercentUsed)
+	if kind == aiBudgetNotificationExceeded {
+		text += " As funcionalidades de IA ficam indisponíveis até o próximo mês ou até o limite ser aumentado."
+	}
+
+	s.log.Infow("Sending AI budget notification", "organizationUUID", organizationUUID, "kind", kind, "spentUsd", status.SpentUSD)
+	s.sendBudgetAlert(organizationUUID, title, text, color)
+}
+
+func (s *AIUsageService) sendBudgetAlert(organizationUUID, title, text, color string) {
+	sent := false
+
+	slackConfig, err := s.integrationService.GetSlackConfig(organizationUUID)
+	if err != nil {
+		s.log.Warnw("Failed to load Slack config for AI budget alert", "error", err, "organizationUUID", organizationUUID)
+	} else if slackConfig != nil {
+		if err := NewSlackService(*slackConfig, s.log).SendAlert(title, text, "#"+color); err == nil {
+			sent = true
+		}
+	}
+
+	teamsConfig, err := s.integrationService.GetTeamsConfig(organizationUUID)
+	if err != nil {
+		s.log.Warnw("Failed to load Teams config for AI budget alert", "error", err, "organizationUUID", organizationUUID)
+	} else if teamsConfig != nil {
+		if err := NewTeamsService(*teamsConfig, s.log).SendAlert(title, text, color); err == nil {
+			sent = true
+		}
+	}
+
+	if !sent {
+		s.log.Warnw("AI budget alert not delivered to Slack or Teams", "organizationUUID", organizationUUID, "title", title)
+	}
+}
+
+// aiBudgetMonth is the first instant of the month (UTC) budgets are counted in
+func aiBudgetMonth(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// estimateTokens approximates the token count of a text (about 4 characters per token)
+func estimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
@@ -0,0 +1,455 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/PlatifyX/platifyx-core/internal/repository"
+	"github.com/PlatifyX/platifyx-core/pkg/logger"
+	"github.com/google/uuid"
+)
+
+const (
+	alertWebhookTokenPrefix  = "pfxa_"
+	defaultAlertTimelineDays = 7
+	maxAlertTimelineDays     = 90
+)
+
+// alertServiceLabels are the labels an alert is matched to a catalog service by, in order
+var alertServiceLabels = []string{"service", "app", "app_kubernetes_io_name", "container", "job", "namespace"}
+
+// alertIncidentSeverities maps the usual alert severity labels to incident severities
+var alertIncidentSeverities = map[string]string{
+	"critical": domain.IncidentSeverityCritical,
+	"page":     domain.IncidentSeverityCritical,
+	"high":     domain.IncidentSeverityHigh,
+	"error":    domain.IncidentSeverityHigh,
+	"warning":  domain.IncidentSeverityMedium,
+	"medium":   domain.IncidentSeverityMedium,
+	"info":     domain.IncidentSeverityLow,
+	"low":      domain.IncidentSeverityLow,
+}
+
+// AlertStore persists the alert occurrences and the webhook token of each organization
+type AlertStore interface {
+	GetWebhookToken(organizationUUID string) (*domain.AlertWebhookToken, string, error)
+	UpsertWebhookToken(organizationUUID, hash, prefix, createdBy string) (time.Time, error)
+	Record(alert *domain.Alert) (created bool, previousStatus string, err error)
+	List(organizationUUID string, filter domain.AlertFilter) ([]domain.Alert, int, error)
+	ListByService(organizationUUID, serviceName string, since time.Time) ([]domain.Alert, error)
+}
+
+// AlertService receives the alerts Alertmanager and Grafana push to the webhooks of each
+// organization, stores one record per alert occurrence, maps them to catalog services and routes
+// their firing and resolution to the notification engine
+type AlertService struct {
+	repo                AlertStore
+	serviceRepo         *repository.ServiceRepository
+	incidentService     *IncidentService
+	notificationService *NotificationService
+	log                 *logger.Logger
+}
+
+func NewAlertService(
+	repo AlertStore,
+	serviceRepo *repository.ServiceRepository,
+	incidentService *IncidentService,
+	notificationService *NotificationService,
+	log *logger.Logger,
+) *AlertService {
+	return &AlertService{
+		repo:                repo,
+		serviceRepo:         serviceRepo,
+		incidentService:     incidentService,
+		notificationService: notificationService,
+		log:                 log,
+	}
+}
+
+// GetWebhookToken describes the webhook token of the organization, without the token itself
+func (s *AlertService) GetWebhookToken(organizationUUID string) (*domain.AlertWebhookToken, error) {
+	token, _, err := s.repo.GetWebhookToken(organizationUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alert webhook token: %w", err)
+	}
+	if token == nil {
+		return &domain.AlertWebhookToken{Configured: false}, nil
+	}
+	return token, nil
+}
+
+// GenerateWebhookToken creates (or rotates) the webhook token of the organization. The token is
+// only returned here; the previous one stops working.
+func (s *AlertService) GenerateWebhookToken(organizationUUID, actor string) (*domain.AlertWebhookToken, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate alert webhook token: %w", err)
+	}
+	token := alertWebhookTokenPrefix + hex.EncodeToString(secret)
+	prefix := token[:len(alertWebhookTokenPrefix)+6]
+
+	createdAt, err := s.repo.UpsertWebhookToken(organizationUUID, hashAlertWebhookToken(token), prefix, actor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save alert webhook token: %w", err)
+	}
+
+	s.log.Infow("Alert webhook token generated", "organizationUUID", organizationUUID, "actor", actor)
+
+	return &domain.AlertWebhookToken{
+		Configured:  true,
+		Token:       token,
+		TokenPrefix: prefix,
+		CreatedBy:   actor,
+		CreatedAt:   &createdAt,
+	}, nil
+}
+
+// VerifyWebhookToken checks the token a webhook request was sent with
+func (s *AlertService) VerifyWebhookToken(organizationUUID, token string) error {
+	if token == "" {
+		return &domain.UnauthorizedError{Message: "alert webhook token is required"}
+	}
+	if _, err := uuid.Parse(organizationUUID); err != nil {
+		return &domain.UnauthorizedError{Message: "invalid alert webhook token"}
+	}
+
+	stored, hash, err := s.repo.GetWebhookToken(organizationUUID)
+	if err != nil {
+		return fmt.Errorf("failed to get alert webhook token: %w", err)
+	}
+	if stored == nil || subtle.ConstantTimeCompare([]byte(hash), []byte(hashAlertWebhookToken(token))) != 1 {
+		return &domain.UnauthorizedError{Message: "invalid alert webhook token"}
+	}
+	return nil
+}
+
+func hashAlertWebhookToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// ReceiveWebhook stores the alerts of an Alertmanager or Grafana webhook delivery. Only new
+// occurrences and resolutions are routed: Alertmanager re-sends firing alerts on every
+// repeat interval.
+func (s *AlertService) ReceiveWebhook(organizationUUID, source string, payload domain.AlertWebhookPayload) (*domain.AlertWebhookResult, error) {
+	if source != domain.AlertSourceAlertmanager && source != domain.AlertSourceGrafana {
+		return nil, &domain.ValidationError{Field: "source", Message: "must be alertmanager or grafana"}
+	}
+
+	services, err := s.catalogByName()
+	if err != nil {
+		return nil, err
+	}
+
+	result, changed, err := s.recordAlerts(organizationUUID, source, payload, services)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Infow("Alert webhook received",
+		"organizationUUID", organizationUUID,
+		"source", source,
+		"received", result.Received,
+		"created", result.Created,
+		"resolved", result.Resolved,
+	)
+
+	if len(changed) > 0 {
+		s.route(organizationUUID, changed)
+	}
+
+	return result, nil
+}
+
+// recordAlerts stores the alerts of a delivery and classifies them as new occurrences,
+// resolutions or repeats. It returns the alerts to route: the new ones and the resolved ones.
+func (s *AlertService) recordAlerts(organizationUUID, source string, payload domain.AlertWebhookPayload, services map[string]*domain.Service) (*domain.AlertWebhookResult, []domain.Alert, error) {
+	result := &domain.AlertWebhookResult{Received: len(payload.Alerts)}
+	var changed []domain.Alert
+	for _, received := range payload.Alerts {
+		alert := newAlert(organizationUUID, source, payload, received)
+		if svc := matchAlertService(alert.Labels, services); svc != nil {
+			alert.ServiceName = svc.Name
+			alert.Squad = svc.Squad
+		}
+
+		created, previousStatus, err := s.repo.Record(alert)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to record alert %s: %w", alert.Fingerprint, err)
+		}
+
+		switch {
+		case created:
+			result.Created++
+			if alert.Status == domain.AlertStatusResolved {
+				result.Resolved++
+			}
+		case previousStatus == domain.AlertStatusFiring && alert.Status == domain.AlertStatusResolved:
+			result.Resolved++
+		default:
+			result.Repeated++
+			continue
+		}
+		changed = append(changed, *alert)
+	}
+
+	return result, changed, nil
+}
+
+// newAlert builds the occurrence of a webhook alert
+func newAlert(organizationUUID, source string, payload domain.AlertWebhookPayload, received domain.AlertWebhookAlert) *domain.Alert {
+	labels := received.Labels
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	annotations := received.Annotations
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	status := received.Status
+	if status == "" {
+		status = payload.Status
+	}
+	if status != domain.AlertStatusResolved {
+		status = domain.AlertStatusFiring
+	}
+
+	name := firstLabel(labels, "alertname", "rulename")
+	if name == "" {
+		name = firstNonEmpty(payload.Title, "unnamed alert")
+	}
+
+	alert := &domain.Alert{
+		OrganizationUUID: organizationUUID,
+		Source:           source,
+		Fingerprint:      received.Fingerprint,
+		Status:           status,
+		Name:             name,
+		Severity:         strings.ToLower(labels["severity"]),
+		Summary:          firstLabel(annotations, "summary", "message"),
+		Description:      annotations["description"],
+		Labels:           labels,
+		Annotations:      annotations,
+		StartsAt:         received.StartsAt,
+		GeneratorURL:     firstNonEmpty(received.GeneratorURL, received.PanelURL, received.DashboardURL),
+	}
+	if alert.Summary == "" {
+		alert.Summary = received.ValueString
+	}
+	if alert.Fingerprint == "" {
+		alert.Fingerprint = labelsFingerprint(labels)
+	}
+	if alert.StartsAt.IsZero() {
+		alert.StartsAt = time.Now().Truncate(time.Second)
+	}
+	if status == domain.AlertStatusResolved {
+		endsAt := received.EndsAt
+		if endsAt.IsZero() || endsAt.Before(alert.StartsAt) {
+			endsAt = time.Now()
+		}
+		alert.EndsAt = &endsAt
+	}
+
+	return alert
+}
+
+// labelsFingerprint identifies an alert sent without fingerprint by its labels
+func labelsFingerprint(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	hash := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(hash, "%s=%s\x00", name, labels[name])
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
+
+func (s *AlertService) catalogByName() (map[string]*domain.Service, error) {
+	services, err := s.serviceRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+
+	byName := make(map[string]*domain.Service, len(services))
+	for i := range services {
+		byName[services[i].Name] = &services[i]
+	}
+	return byName, nil
+}
+
+// matchAlertService returns the catalog service named by the first label that names one
+func matchAlertService(labels map[string]string, services map[string]*domain.Service) *domain.Service {
+	for _, name := range alertServiceLabels {
+		if svc, ok := services[labels[name]]; ok && labels[name] != "" {
+			return svc
+		}
+	}
+	return nil
+}
+
+// route opens, updates or resolves the incidents of the alerts and publishes their firing and
+// resolution as notification events. The rules the alerts match decide which ones open incidents.
+func (s *AlertService) route(organizationUUID string, alerts []domain.Alert) {
+	for _, alert := range alerts {
+		event := alertNotificationEvent(alert)
+
+		rules, err := s.notificationService.MatchingRules(organizationUUID, event)
+		if err != nil {
+			s.log.Errorw("Failed to match notification rules", "error", err, "organizationUUID", organizationUUID)
+			continue
+		}
+		openIncident := false
+		for _, rule := range rules {
+			openIncident = openIncident || rule.OpenIncident
+		}
+
+		// Resolutions always reach the incident the alert may have opened
+		if openIncident || alert.Status == domain.AlertStatusResolved {
+			s.recordIncidentSignal(organizationUUID, alert, openIncident)
+		}
+		s.notificationService.Notify(organizationUUID, event)
+	}
+}
+
+// alertNotificationEvent is the alert.firing or alert.resolved event of an alert. Its labels are
+// matched by the rule matchers; repeats of a flapping alert share the dedup key.
+func alertNotificationEvent(alert domain.Alert) domain.NotificationEvent {
+	event := domain.NotificationEvent{
+		Type:        domain.NotificationEventAlertFiring,
+		Severity:    alert.Severity,
+		Title:       alert.Name,
+		Message:     firstNonEmpty(alert.Summary, alert.Description),
+		ServiceName: alert.ServiceName,
+		Squad:       alert.Squad,
+		URL:         alert.GeneratorURL,
+		Fields: map[string]string{
+			"Source":  alert.Source,
+			"Started": alert.StartsAt.Format(time.RFC3339),
+		},
+		Labels:     alert.Labels,
+		DedupKey:   fmt.Sprintf("alert:%s:%s:%s", alert.Source, alert.Fingerprint, alert.Status),
+		OccurredAt: alert.StartsAt,
+	}
+	if alert.Status == domain.AlertStatusResolved {
+		event.Type = domain.NotificationEventAlertResolved
+		if alert.EndsAt != nil {
+			event.Fields["Resolved"] = fmt.Sprintf("%s (after %s)", alert.EndsAt.Format(time.RFC3339), alert.EndsAt.Sub(alert.StartsAt).Round(time.Second))
+			event.OccurredAt = *alert.EndsAt
+		}
+	}
+	return event
+}
+
+func (s *AlertService) recordIncidentSignal(organizationUUID string, alert domain.Alert, open bool) {
+	signal := domain.IncidentSignal{
+		Source:      domain.IncidentSourcePrometheus,
+		ExternalID:  alert.Fingerprint,
+		Title:       alert.Name,
+		Description: firstNonEmpty(alert.Summary, alert.Description),
+		ServiceName: alert.ServiceName,
+		Severity:    alertIncidentSeverities[alert.Severity],
+		StartedAt:   alert.StartsAt,
+		ResolvedAt:  alert.EndsAt,
+	}
+	if alert.Source == domain.AlertSourceGrafana {
+		signal.Source = domain.IncidentSourceGrafana
+	}
+	if alert.ServiceName != "" {
+		signal.Title = fmt.Sprintf("%s on %s", alert.Name, alert.ServiceName)
+	}
+	if alert.Status == domain.AlertStatusFiring && !open {
+		return
+	}
+
+	if _, err := s.incidentService.RecordSignal(organizationUUID, signal); err != nil {
+		s.log.Errorw("Failed to record alert on incident", "error", err, "organizationUUID", organizationUUID, "fingerprint", alert.Fingerprint)
+	}
+}
+
+// ListAlerts returns the alerts of the organization, most recent first
+func (s *AlertService) ListAlerts(organizationUUID string, filter domain.AlertFilter) ([]domain.Alert, int, error) {
+	if filter.Size > 100 {
+		filter.Size = 100
+	}
+	return s.repo.List(organizationUUID, filter)
+}
+
+// GetServiceTimeline returns the firings and resolutions of the alerts of a service over the last
+// `days` days, most recent first
+func (s *AlertService) GetServiceTimeline(organizationUUID, serviceName string, days int) ([]domain.AlertTimelineEntry, error) {
+	if days < 1 {
+		days = defaultAlertTimelineDays
+	}
+	if days > maxAlertTimelineDays {
+		days = maxAlertTimelineDays
+	}
+	since := time.Now().AddDate(0, 0, -days)
+
+	alerts, err := s.repo.ListByService(organizationUUID, serviceName, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list alerts: %w", err)
+	}
+
+	timeline := []domain.AlertTimelineEntry{}
+	for _, alert := range alerts {
+		entry := domain.AlertTimelineEntry{
+			AlertID:     alert.ID,
+			Name:        alert.Name,
+			Source:      alert.Source,
+			ServiceName: alert.ServiceName,
+			Severity:    alert.Severity,
+			Summary:     alert.Summary,
+		}
+		if !alert.StartsAt.Before(since) {
+			firing := entry
+			firing.Status = domain.AlertStatusFiring
+			firing.At = alert.StartsAt
+			timeline = append(timeline, firing)
+		}
+		if alert.EndsAt != nil && !alert.EndsAt.Before(since) {
+			resolved := entry
+			resolved.Status = domain.AlertStatusResolved
+			resolved.At = *alert.EndsAt
+			timeline = append(timeline, resolved)
+		}
+	}
+
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].At.After(timeline[j].At)
+	})
+
+	return timeline, nil
+}
+
+// firstNonEmpty returns the first non-empty value
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// trimmedStrings returns the non-empty values, trimmed (never nil)
+func trimmedStrings(values []string) []string {
+	result := []string{}
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,460 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/PlatifyX/platifyx-core/pkg/logger"
+)
+
+const testAlertOrganization = "5f0c6f4e-1c2d-4f59-9b35-3f6c8a1d2e7b"
+
+// alertmanagerPayload is a delivery of the Alertmanager webhook receiver with two firing alerts
+const alertmanagerPayload = `{
+	"version": "4",
+	"groupKey": "{}:{alertname=\"HighLatency\"}",
+	"status": "firing",
+	"receiver": "platifyx",
+	"alerts": [
+		{
+			"status": "firing",
+			"labels": {"alertname": "HighLatency", "service": "checkout", "severity": "Critical"},
+			"annotations": {"summary": "p99 latency above 2s", "description": "Checkout is slow"},
+			"startsAt": "2026-03-10T10:00:00Z",
+			"endsAt": "0001-01-01T00:00:00Z",
+			"generatorURL": "http://prometheus:9090/graph?g0.expr=latency",
+			"fingerprint": "a1b2c3d4"
+		},
+		{
+			"status": "firing",
+			"labels": {"alertname": "PodCrashLooping", "namespace": "payments", "container": "worker", "severity": "warning"},
+			"annotations": {"message": "worker is restarting"},
+			"startsAt": "2026-03-10T10:05:00Z",
+			"endsAt": "0001-01-01T00:00:00Z",
+			"fingerprint": "e5f6a7b8"
+		}
+	]
+}`
+
+// grafanaPayload is a delivery of a Grafana contact point; Grafana sends no alert fingerprint
+const grafanaPayload = `{
+	"receiver": "platifyx",
+	"status": "firing",
+	"title": "[FIRING:1] DiskFull",
+	"message": "Disk usage above 95%",
+	"alerts": [
+		{
+			"status": "firing",
+			"labels": {"alertname": "DiskFull", "app": "search", "grafana_folder": "Infra"},
+			"annotations": {},
+			"startsAt": "2026-03-10T09:00:00Z",
+			"endsAt": "0001-01-01T00:00:00Z",
+			"dashboardURL": "http://grafana:3000/d/disk",
+			"panelURL": "http://grafana:3000/d/disk?viewPanel=2",
+			"valueString": "[ var='B' value=97 ]"
+		}
+	]
+}`
+
+func decodeAlertPayload(t *testing.T, body string) domain.AlertWebhookPayload {
+	t.Helper()
+
+	var payload domain.AlertWebhookPayload
+	if err := json.Unmarshal([]byte(body), &payload); err != nil {
+		t.Fatalf("decoding payload: %v", err)
+	}
+	return payload
+}
+
+// stubAlertStore keeps the occurrences the way AlertRepository.Record does: an occurrence is a
+// fingerprint and a start, and a resolved occurrence stays resolved
+type stubAlertStore struct {
+	occurrences map[string]string
+	tokenHash   string
+	tokenErr    error
+}
+
+func newStubAlertStore() *stubAlertStore {
+	return &stubAlertStore{occurrences: map[string]string{}}
+}
+
+func (s *stubAlertStore) GetWebhookToken(string) (*domain.AlertWebhookToken, string, error) {
+	if s.tokenErr != nil {
+		return nil, "", s.tokenErr
+	}
+	if s.tokenHash == "" {
+		return nil, "", nil
+	}
+	return &domain.AlertWebhookToken{Configured: true}, s.tokenHash, nil
+}
+
+func (s *stubAlertStore) UpsertWebhookToken(_, hash, _, _ string) (time.Time, error) {
+	s.tokenHash = hash
+	return time.Now(), nil
+}
+
+func (s *stubAlertStore) Record(alert *domain.Alert) (bool, string, error) {
+	key := alert.Source + "/" + alert.Fingerprint + "/" + alert.StartsAt.String()
+	previous, ok := s.occurrences[key]
+	if !ok {
+		s.occurrences[key] = alert.Status
+		return true, "", nil
+	}
+	if previous != domain.AlertStatusResolved {
+		s.occurrences[key] = alert.Status
+	}
+	return false, previous, nil
+}
+
+func (s *stubAlertStore) List(string, domain.AlertFilter) ([]domain.Alert, int, error) {
+	return nil, 0, nil
+}
+
+func (s *stubAlertStore) ListByService(string, string, time.Time) ([]domain.Alert, error) {
+	return nil, nil
+}
+
+func testAlertService(store AlertStore) *AlertService {
+	return NewAlertService(store, nil, nil, nil, logger.NewLogger("development"))
+}
+
+func TestNewAlert(t *testing.T) {
+	startsAt := time.Date(2026, time.March, 10, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		payload  domain.AlertWebhookPayload
+		received domain.AlertWebhookAlert
+		check    func(*testing.T, *domain.Alert)
+	}{
+		{
+			name:     "status of the delivery when the alert has none",
+			payload:  domain.AlertWebhookPayload{Status: "resolved"},
+			received: domain.AlertWebhookAlert{StartsAt: startsAt, EndsAt: startsAt.Add(time.Minute)},
+			check: func(t *testing.T, a *domain.Alert) {
+				if a.Status != domain.AlertStatusResolved || a.EndsAt == nil || !a.EndsAt.Equal(startsAt.Add(time.Minute)) {
+					t.Errorf("alert is %s ending %v, want resolved a minute after the start", a.Status, a.EndsAt)
+				}
+			},
+		},
+		{
+			name:     "unknown status is firing",
+			received: domain.AlertWebhookAlert{Status: "pending", StartsAt: startsAt},
+			check: func(t *testing.T, a *domain.Alert) {
+				if a.Status != domain.AlertStatusFiring || a.EndsAt != nil {
+					t.Errorf("alert is %s ending %v, want firing", a.Status, a.EndsAt)
+				}
+			},
+		},
+		{
+			name:     "resolution before the start is moved to now",
+			received: domain.AlertWebhookAlert{Status: "resolved", StartsAt: startsAt, EndsAt: startsAt.Add(-time.Hour)},
+			check: func(t *testing.T, a *domain.Alert) {
+				if a.EndsAt == nil || a.EndsAt.Before(startsAt) {
+					t.Errorf("alert ends at %v, before its start", a.EndsAt)
+				}
+			},
+		},
+		{
+			name:     "name from the Grafana rule name",
+			received: domain.AlertWebhookAlert{Labels: map[string]string{"rulename": "DiskFull"}},
+			check: func(t *testing.T, a *domain.Alert) {
+				if a.Name != "DiskFull" {
+					t.Errorf("name is %q", a.Name)
+				}
+			},
+		},
+		{
+			name:    "name from the delivery title, then a placeholder",
+			payload: domain.AlertWebhookPayload{Title: "[FIRING:1] DiskFull"},
+			check: func(t *testing.T, a *domain.Alert) {
+				if a.Name != "[FIRING:1] DiskFull" {
+					t.Errorf("name is %q", a.Name)
+				}
+				if unnamed := newAlert("org", domain.AlertSourceGrafana, domain.AlertWebhookPayload{}, domain.AlertWebhookAlert{}); unnamed.Name != "unnamed alert" {
+					t.Errorf("alert without name is %q", unnamed.Name)
+				}
+			},
+		},
+		{
+			name: "severity lowercased and summary from the message",
+			received: domain.AlertWebhookAlert{
+				Labels:      map[string]string{"alertname": "A", "severity": "Critical"},
+				Annotations: map[string]string{"message": "restarting"},
+			},
+			check: func(t *testing.T, a *domain.Alert) {
+				if a.Severity != "critical" || a.Summary != "restarting" {
+					t.Errorf("severity %q and summary %q", a.Severity, a.Summary)
+				}
+			},
+		},
+		{
+			name:     "summary from the Grafana value and panel link",
+			received: domain.AlertWebhookAlert{ValueString: "[ value=97 ]", PanelURL: "http://grafana/panel", DashboardURL: "http://grafana/dashboard"},
+			check: func(t *testing.T, a *domain.Alert) {
+				if a.Summary != "[ value=97 ]" || a.GeneratorURL != "http://grafana/panel" {
+					t.Errorf("summary %q and link %q", a.Summary, a.GeneratorURL)
+				}
+			},
+		},
+		{
+			name:     "fingerprint and start filled in",
+			received: domain.AlertWebhookAlert{Labels: map[string]string{"alertname": "A"}},
+			check: func(t *testing.T, a *domain.Alert) {
+				if a.Fingerprint != labelsFingerprint(map[string]string{"alertname": "A"}) {
+					t.Errorf("fingerprint is %q, want the one of the labels", a.Fingerprint)
+				}
+				if a.StartsAt.IsZero() {
+					t.Error("start not filled in")
+				}
+			},
+		},
+		{
+			name: "missing labels and annotations are empty",
+			check: func(t *testing.T, a *domain.Alert) {
+				if a.Labels == nil || a.Annotations == nil {
+					t.Errorf("labels %v and annotations %v must not be nil", a.Labels, a.Annotations)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.check(t, newAlert("org", domain.AlertSourceAlertmanager, tt.payload, tt.received))
+		})
+	}
+}
+
+func TestLabelsFingerprint(t *testing.T) {
+	labels := map[string]string{"alertname": "DiskFull", "app": "search"}
+
+	fingerprint := labelsFingerprint(labels)
+	if len(fingerprint) != 16 {
+		t.Fatalf("fingerprint %q is not 16 characters", fingerprint)
+	}
+	if again := labelsFingerprint(map[string]string{"app": "search", "alertname": "DiskFull"}); again != fingerprint {
+		t.Errorf("same labels gave %q and %q", fingerprint, again)
+	}
+
+	for _, other := range []map[string]string{
+		{"alertname": "DiskFull", "app": "index"},
+		{"alertname": "DiskFull"},
+		{"alertname": "DiskFull", "appsearch": ""},
+	} {
+		if labelsFingerprint(other) == fingerprint {
+			t.Errorf("labels %v share the fingerprint of %v", other, labels)
+		}
+	}
+}
+
+func TestMatchAlertService(t *testing.T) {
+	services := map[string]*domain.Service{
+		"checkout": {Name: "checkout"},
+		"worker":   {Name: "worker"},
+		"payments": {Name: "payments"},
+	}
+
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   string
+	}{
+		{name: "service label", labels: map[string]string{"service": "checkout"}, want: "checkout"},
+		{name: "container before namespace", labels: map[string]string{"namespace": "payments", "container": "worker"}, want: "worker"},
+		{name: "unknown service falls through", labels: map[string]string{"service": "legacy", "namespace": "payments"}, want: "payments"},
+		{name: "kubernetes app name", labels: map[string]string{"app_kubernetes_io_name": "checkout"}, want: "checkout"},
+		{name: "no match", labels: map[string]string{"job": "node-exporter"}},
+		{name: "no labels"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ""
+			if svc := matchAlertService(tt.labels, services); svc != nil {
+				got = svc.Name
+			}
+			if got != tt.want {
+				t.Errorf("matched %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordAlertsClassifiesDeliveries(t *testing.T) {
+	services := map[string]*domain.Service{
+		"checkout": {Name: "checkout", Squad: "shop"},
+		"worker":   {Name: "worker", Squad: "payments"},
+		"search":   {Name: "search", Squad: "discovery"},
+	}
+	firing := decodeAlertPayload(t, alertmanagerPayload)
+	resolved := decodeAlertPayload(t, alertmanagerPayload)
+	resolved.Alerts[0].Status = domain.AlertStatusResolved
+	resolved.Alerts[0].EndsAt = resolved.Alerts[0].StartsAt.Add(10 * time.Minute)
+	refired := decodeAlertPayload(t, alertmanagerPayload)
+	refired.Alerts[0].StartsAt = refired.Alerts[0].StartsAt.Add(time.Hour)
+
+	s := testAlertService(newStubAlertStore())
+
+	steps := []struct {
+		name        string
+		source      string
+		payload     domain.AlertWebhookPayload
+		want        domain.AlertWebhookResult
+		wantChanged []string
+	}{
+		{
+			name:        "new alerts",
+			source:      domain.AlertSourceAlertmanager,
+			payload:     firing,
+			want:        domain.AlertWebhookResult{Received: 2, Created: 2},
+			wantChanged: []string{"HighLatency:firing:checkout", "PodCrashLooping:firing:worker"},
+		},
+		{
+			name:    "repeat interval",
+			source:  domain.AlertSourceAlertmanager,
+			payload: firing,
+			want:    domain.AlertWebhookResult{Received: 2, Repeated: 2},
+		},
+		{
+			name:        "resolution",
+			source:      domain.AlertSourceAlertmanager,
+			payload:     resolved,
+			want:        domain.AlertWebhookResult{Received: 2, Resolved: 1, Repeated: 1},
+			wantChanged: []string{"HighLatency:resolved:checkout"},
+		},
+		{
+			name:    "resolution sent again",
+			source:  domain.AlertSourceAlertmanager,
+			payload: resolved,
+			want:    domain.AlertWebhookResult{Received: 2, Repeated: 2},
+		},
+		{
+			name:        "fires again with a new start",
+			source:      domain.AlertSourceAlertmanager,
+			payload:     refired,
+			want:        domain.AlertWebhookResult{Received: 2, Created: 1, Repeated: 1},
+			wantChanged: []string{"HighLatency:firing:checkout"},
+		},
+		{
+			name:        "Grafana alert without fingerprint",
+			source:      domain.AlertSourceGrafana,
+			payload:     decodeAlertPayload(t, grafanaPayload),
+			want:        domain.AlertWebhookResult{Received: 1, Created: 1},
+			wantChanged: []string{"DiskFull:firing:search"},
+		},
+		{
+			name:    "Grafana alert sent again",
+			source:  domain.AlertSourceGrafana,
+			payload: decodeAlertPayload(t, grafanaPayload),
+			want:    domain.AlertWebhookResult{Received: 1, Repeated: 1},
+		},
+	}
+
+	for _, step := range steps {
+		result, changed, err := s.recordAlerts(testAlertOrganization, step.source, step.payload, services)
+		if err != nil {
+			t.Fatalf("%s: recordAlerts: %v", step.name, err)
+		}
+		if *result != step.want {
+			t.Errorf("%s: result is %+v, want %+v", step.name, *result, step.want)
+		}
+
+		got := make([]string, len(changed))
+		for i, alert := range changed {
+			got[i] = alert.Name + ":" + alert.Status + ":" + alert.ServiceName
+		}
+		if len(got) != len(step.wantChanged) {
+			t.Errorf("%s: routed %v, want %v", step.name, got, step.wantChanged)
+			continue
+		}
+		for i := range got {
+			if got[i] != step.wantChanged[i] {
+				t.Errorf("%s: routed %v, want %v", step.name, got, step.wantChanged)
+				break
+			}
+		}
+	}
+
+	// A delivery whose first sight of an alert is its resolution counts it as new and resolved
+	late := decodeAlertPayload(t, alertmanagerPayload)
+	late.Alerts = late.Alerts[1:]
+	late.Alerts[0].Status = domain.AlertStatusResolved
+	result, changed, err := testAlertService(newStubAlertStore()).recordAlerts(testAlertOrganization, domain.AlertSourceAlertmanager, late, services)
+	if err != nil {
+		t.Fatalf("recordAlerts: %v", err)
+	}
+	if result.Created != 1 || result.Resolved != 1 || len(changed) != 1 {
+		t.Errorf("late resolution gave %+v and routed %d alerts", *result, len(changed))
+	}
+}
+
+func TestReceiveWebhookRejectsUnknownSource(t *testing.T) {
+	_, err := testAlertService(newStubAlertStore()).ReceiveWebhook(testAlertOrganization, "zabbix", domain.AlertWebhookPayload{})
+	var validation *domain.ValidationError
+	if !errors.As(err, &validation) {
+		t.Errorf("unknown source returned %v, want a validation error", err)
+	}
+}
+
+func TestVerifyWebhookToken(t *testing.T) {
+	store := newStubAlertStore()
+	s := testAlertService(store)
+
+	if err := s.VerifyWebhookToken(testAlertOrganization, "pfxa_anything"); !isUnauthorized(err) {
+		t.Errorf("organization without token returned %v", err)
+	}
+
+	generated, err := s.GenerateWebhookToken(testAlertOrganization, "admin")
+	if err != nil {
+		t.Fatalf("GenerateWebhookToken: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		organization string
+		token        string
+		wantErr      bool
+	}{
+		{name: "valid token", organization: testAlertOrganization, token: generated.Token},
+		{name: "missing token", organization: testAlertOrganization, wantErr: true},
+		{name: "wrong token", organization: testAlertOrganization, token: generated.Token + "x", wantErr: true},
+		{name: "invalid organization", organization: "not-a-uuid", token: generated.Token, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.VerifyWebhookToken(tt.organization, tt.token)
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("VerifyWebhookToken: %v", err)
+				}
+				return
+			}
+			if !isUnauthorized(err) {
+				t.Errorf("got %v, want an unauthorized error", err)
+			}
+		})
+	}
+
+	// A rotated token replaces the previous one
+	if _, err := s.GenerateWebhookToken(testAlertOrganization, "admin"); err != nil {
+		t.Fatalf("rotating the token: %v", err)
+	}
+	if err := s.VerifyWebhookToken(testAlertOrganization, generated.Token); !isUnauthorized(err) {
+		t.Errorf("previous token returned %v after rotation", err)
+	}
+
+	store.tokenErr = errors.New("connection refused")
+	if err := s.VerifyWebhookToken(testAlertOrganization, generated.Token); err == nil || isUnauthorized(err) {
+		t.Errorf("store failure returned %v, want an internal error", err)
+	}
+}
+
+func isUnauthorized(err error) bool {
+	var unauthorized *domain.UnauthorizedError
+	return errors.As(err, &unauthorized)
+}
@@ -0,0 +1,449 @@
+package service
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/config"
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/PlatifyX/platifyx-core/internal/repository"
+	"github.com/PlatifyX/platifyx-core/pkg/logger"
+)
+
+// redactedValue replaces the value of sensitive fields in audited payloads
+const redactedValue = "[REDACTED]"
+
+// sensitiveKeyParts are matched against normalized field names (lower case, without "_" and "-").
+// Any field whose name contains one of them is redacted.
+var sensitiveKeyParts = []string{
+	"password", "passwd", "passphrase", "secret", "token", "apikey", "privatekey",
+	"accesskey", "credential", "authorization", "cookie", "kubeconfig", "certificate",
+}
+
+// sensitiveKeys are short names redacted only on an exact match
+var sensitiveKeys = map[string]bool{
+	"key": true, "pat": true, "pin": true, "otp": true, "cert": true,
+}
+
+// integrationSecretKeys are the normalized names of the credential fields of every integration
+// (domain.IntegrationSecretFields), redacted wherever they appear in an audited payload
+var integrationSecretKeys = func() map[string]bool {
+	keys := make(map[string]bool)
+	for _, fields := range domain.IntegrationSecretFields {
+		for _, field := range fields {
+			keys[normalizeAuditKey(field)] = true
+		}
+	}
+	return keys
+}()
+
+// Audit export formats
+const (
+	AuditExportNDJSON = "ndjson"
+	AuditExportCSV    = "csv"
+)
+
+// auditExportColumns is the CSV header of audit exports
+var auditExportColumns = []string{
+	"id", "created_at", "organization_uuid", "sequence", "user_id", "user_email", "action", "resource",
+	"resource_id", "status", "ip_address", "user_agent", "details", "prev_hash", "hash",
+}
+
+// errChainBroken stops the chain walk at the first break
+var errChainBroken = errors.New("audit chain broken")
+
+// AuditRequest describes a mutating API request for the audit trail
+type AuditRequest struct {
+	Method      string
+	Route       string
+	Path        string
+	Query       string
+	StatusCode  int
+	DurationMs  int64
+	ContentType string
+	Body        []byte
+	Truncated   bool
+	// SecretBody marks routes whose body is a secret value (e.g. Vault or AWS secret writes):
+	// only its size is audited
+	SecretBody bool
+}
+
+// AuditService is the single entry point for writing the audit trail, used both by
+// the HTTP middleware and by services that audit domain events
+type AuditService struct {
+	repo       *repository.AuditRepository
+	signingKey ed25519.PrivateKey
+	log        *logger.Logger
+}
+
+func NewAuditService(repo *repository.AuditRepository, signingKey ed25519.PrivateKey, log *logger.Logger) *AuditService {
+	return &AuditService{
+		repo:       repo,
+		signingKey: signingKey,
+		log:        log,
+	}
+}
+
+// NewAuditServiceFromConfig loads the export signing key from the application config.
+// AUDIT_SIGNING_KEY is required: a key derived from another secret (such as JWT_SECRET) would let
+// anyone holding that secret re-sign a tampered audit chain.
+func NewAuditServiceFromConfig(cfg *config.Config, repo *repository.AuditRepository, log *logger.Logger) (*AuditService, error) {
+	if cfg.AuditSigningKey == "" {
+		return nil, errors.New("AUDIT_SIGNING_KEY is required (base64 32-byte Ed25519 seed, generate one with: openssl rand -base64 32)")
+	}
+
+	seed, err := base64.StdEncoding.DecodeString(cfg.AuditSigningKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid AUDIT_SIGNING_KEY: %w", err)
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("invalid AUDIT_SIGNING_KEY: expected %d bytes, got %d", ed25519.SeedSize, len(seed))
+	}
+
+	return NewAuditService(repo, ed25519.NewKeyFromSeed(seed), log), nil
+}
+
+// Record writes an audit entry. Failures are logged and never fail the caller.
+func (s *AuditService) Record(entry *domain.AuditLog) {
+	if entry.Status == "" {
+		entry.Status = "success"
+	}
+	if entry.UserEmail == "" {
+		entry.UserEmail = "system"
+	}
+
+	if err := s.repo.Create(entry); err != nil {
+		s.log.Errorw("Failed to write audit log",
+			"error", err,
+			"action", entry.Action,
+			"resource", entry.Resource,
+		)
+	}
+}
+
+// RecordRequest writes the audit entry of a mutating API request. The request body is
+// stored as the change set of the entry with sensitive fields redacted.
+func (s *AuditService) RecordRequest(entry *domain.AuditLog, req AuditRequest) {
+	details := map[string]interface{}{
+		"method":      req.Method,
+		"route":       req.Route,
+		"path":        req.Path,
+		"status_code": req.StatusCode,
+		"duration_ms": req.DurationMs,
+	}
+	if req.Query != "" {
+		details["query"] = RedactQuery(req.Query)
+	}
+	if changes := requestChanges(req); changes != nil {
+		details["changes"] = changes
+	}
+
+	raw, err := json.Marshal(details)
+	if err != nil {
+		s.log.Errorw("Failed to encode audit details", "error", err, "route", req.Route)
+	} else {
+		entry.Details = raw
+	}
+
+	s.Record(entry)
+}
+
+// requestChanges returns the redacted JSON body of the request, or a summary of it
+// when the body is not JSON or was too large to be captured
+func requestChanges(req AuditRequest) interface{} {
+	if len(req.Body) == 0 {
+		return nil
+	}
+
+	if req.SecretBody {
+		return map[string]interface{}{
+			"content_type": req.ContentType,
+			"size":         len(req.Body),
+			"redacted":     true,
+		}
+	}
+
+	if !req.Truncated && strings.Contains(req.ContentType, "json") {
+		var body interface{}
+		if err := json.Unmarshal(req.Body, &body); err == nil {
+			return Redact(body)
+		}
+	}
+
+	return map[string]interface{}{
+		"content_type": req.ContentType,
+		"size":         len(req.Body),
+		"truncated":    req.Truncated,
+	}
+}
+
+// Redact returns a copy of a decoded JSON value with the values of sensitive fields replaced
+func Redact(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			if IsSensitiveKey(key) {
+				redacted[key] = redactedValue
+				continue
+			}
+			redacted[key] = Redact(item)
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, item := range v {
+			redacted[i] = Redact(item)
+		}
+		return redacted
+	default:
+		return v
+	}
+}
+
+// RedactQuery redacts the values of sensitive query string parameters
+func RedactQuery(query string) string {
+	params := strings.Split(query, "&")
+	for i, param := range params {
+		name, _, found := strings.Cut(param, "=")
+		if found && IsSensitiveKey(name) {
+			params[i] = name + "=" + redactedValue
+		}
+	}
+	return strings.Join(params, "&")
+}
+
+// IsSensitiveKey reports whether a field name looks like it holds a secret or is the
+// credential field of an integration
+func IsSensitiveKey(key string) bool {
+	normalized := normalizeAuditKey(key)
+	if sensitiveKeys[normalized] || integrationSecretKeys[normalized] {
+		return true
+	}
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(normalized, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeAuditKey lower cases a field name and drops "_", "-" and "."
+func normalizeAuditKey(key string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "", ".", "").Replace(key))
+}
+
+// VerifyChain walks the audit chain of an organization (the global chain when orgUUID is empty)
+// and reports the first entry that was altered, removed or inserted out of order
+func (s *AuditService) VerifyChain(orgUUID string) (*domain.AuditChainVerification, error) {
+	head, err := s.repo.GetChainHead(orgUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.AuditChainVerification{
+		OrganizationUUID: orgUUID,
+		HeadSequence:     head.Sequence,
+		HeadHash:         head.Hash,
+		VerifiedAt:       time.Now().UTC(),
+	}
+
+	result.UnchainedEntries, err = s.repo.CountUnchained(orgUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Entries removed by retention leave an anchor the chain continues from
+	expectedSequence := head.AnchorSequence + 1
+	prevHash := head.AnchorHash
+
+	err = s.repo.StreamChain(orgUUID, func(entry *domain.AuditLog) error {
+		sequence := *entry.Sequence
+		if sequence <= head.AnchorSequence {
+			// entries left behind by an interrupted retention run are covered by the anchor
+			return nil
+		}
+
+		if sequence != expectedSequence {
+			result.Break = &domain.AuditChainBreak{
+				Sequence: expectedSequence,
+				Reason:   "missing entry",
+				Expected: strconv.FormatInt(expectedSequence, 10),
+				Actual:   strconv.FormatInt(sequence, 10),
+			}
+			return errChainBroken
+		}
+
+		if entry.PrevHash == nil || *entry.PrevHash != prevHash {
+			result.Break = &domain.AuditChainBreak{
+				Sequence: sequence,
+				LogID:    entry.ID,
+				Reason:   "previous hash mismatch",
+				Expected: prevHash,
+				Actual:   stringOrEmpty(entry.PrevHash),
+			}
+			return errChainBroken
+		}
+
+		hash, err := entry.ComputeHash()
+		if err != nil {
+			return err
+		}
+		if entry.Hash == nil || *entry.Hash != hash {
+			result.Break = &domain.AuditChainBreak{
+				Sequence: sequence,
+				LogID:    entry.ID,
+				Reason:   "entry content was modified",
+				Expected: hash,
+				Actual:   stringOrEmpty(entry.Hash),
+			}
+			return errChainBroken
+		}
+
+		result.Entries++
+		expectedSequence++
+		prevHash = hash
+		return nil
+	})
+	if err != nil && !errors.Is(err, errChainBroken) {
+		return nil, err
+	}
+
+	// Entries deleted from the end of the chain are only visible against the chain head
+	if result.Break == nil && (expectedSequence-1 != head.Sequence || prevHash != head.Hash) {
+		result.Break = &domain.AuditChainBreak{
+			Sequence: expectedSequence,
+			Reason:   "chain ends before the recorded head",
+			Expected: head.Hash,
+			Actual:   prevHash,
+		}
+	}
+
+	result.Valid = result.Break == nil
+	if !result.Valid {
+		s.log.Warnw("Audit chain verification failed",
+			"organizationUUID", orgUUID,
+			"sequence", result.Break.Sequence,
+			"reason", result.Break.Reason,
+		)
+	}
+
+	return result, nil
+}
+
+// Export writes the audit logs matching the filter to w as NDJSON or CSV, in chronological
+// order, and returns the signed manifest of the exported content. The filter should be bounded
+// by an end date so that the same export (and its manifest) can be reproduced.
+func (s *AuditService) Export(filter domain.AuditLogFilter, format string, w io.Writer) (*domain.AuditExportManifest, error) {
+	digest := sha256.New()
+	out := io.MultiWriter(w, digest)
+
+	var entries int64
+	var write func(*domain.AuditLog) error
+	var csvWriter *csv.Writer
+
+	switch format {
+	case AuditExportNDJSON:
+		encoder := json.NewEncoder(out)
+		write = func(entry *domain.AuditLog) error {
+			return encoder.Encode(entry)
+		}
+	case AuditExportCSV:
+		csvWriter = csv.NewWriter(out)
+		if err := csvWriter.Write(auditExportColumns); err != nil {
+			return nil, err
+		}
+		write = func(entry *domain.AuditLog) error {
+			return csvWriter.Write(auditExportRecord(entry))
+		}
+	default:
+		return nil, &domain.ValidationError{Field: "format", Message: fmt.Sprintf("unsupported export format %q", format)}
+	}
+
+	err := s.repo.Stream(filter, func(entry *domain.AuditLog) error {
+		entries++
+		return write(entry)
+	})
+	if csvWriter != nil {
+		csvWriter.Flush()
+		if err == nil {
+			err = csvWriter.Error()
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &domain.AuditExportManifest{
+		Format:      format,
+		Filter:      filter,
+		Entries:     entries,
+		SHA256:      hex.EncodeToString(digest.Sum(nil)),
+		GeneratedAt: time.Now().UTC(),
+		Algorithm:   "ed25519",
+		PublicKey:   s.PublicKey(),
+	}
+	if err := s.signManifest(manifest); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// PublicKey returns the base64 public key auditors use to check export manifests
+func (s *AuditService) PublicKey() string {
+	return base64.StdEncoding.EncodeToString(s.signingKey.Public().(ed25519.PublicKey))
+}
+
+// signManifest signs the JSON encoding of the manifest without its signature
+func (s *AuditService) signManifest(manifest *domain.AuditExportManifest) error {
+	manifest.Signature = ""
+	payload, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	manifest.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(s.signingKey, payload))
+	return nil
+}
+
+func auditExportRecord(entry *domain.AuditLog) []string {
+	sequence := ""
+	if entry.Sequence != nil {
+		sequence = strconv.FormatInt(*entry.Sequence, 10)
+	}
+
+	return []string{
+		entry.ID,
+		entry.CreatedAt.UTC().Format(time.RFC3339Nano),
+		stringOrEmpty(entry.OrganizationUUID),
+		sequence,
+		stringOrEmpty(entry.UserID),
+		entry.UserEmail,
+		entry.Action,
+		entry.Resource,
+		stringOrEmpty(entry.ResourceID),
+		entry.Status,
+		stringOrEmpty(entry.IPAddress),
+		stringOrEmpty(entry.UserAgent),
+		string(entry.Details),
+		stringOrEmpty(entry.PrevHash),
+		stringOrEmpty(entry.Hash),
+	}
+}
+
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -3,6 +3,9 @@ package service
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"path"
+	"sort"
 	"strings"
 	"time"
 
@@ -16,8 +19,6 @@ type AutoDocsService struct {
 	techDocsService   *TechDocsService
 	aiService         *AIService
 	diagramService    *DiagramService
-	githubService     *GitHubService
-	azureDevOpsService *AzureDevOpsService
 	integrationService *IntegrationService
 	kubernetesService  *KubernetesService
 	progressStore     *cache.RedisClient
@@ -28,8 +29,6 @@ func NewAutoDocsService(
 	techDocsService *TechDocsService,
 	aiService *AIService,
 	diagramService *DiagramService,
-	githubService *GitHubService,
-	azureDevOpsService *AzureDevOpsService,
 	integrationService *IntegrationService,
 	kubernetesService *KubernetesService,
 	progressStore *cache.RedisClient,
@@ -39,8 +38,6 @@ func NewAutoDocsService(
 		techDocsService:    techDocsService,
 		aiService:          aiService,
 		diagramService:     diagramService,
-		githubService:      githubService,
-		azureDevOpsService: azureDevOpsService,
 		integrationService: integrationService,
 		kubernetesService:  kubernetesService,
 		progressStore:      progressStore,
@@ -53,6 +50,7 @@ func (s *AutoDocsService) GenerateAutoDocs(organizationUUID string, req domain.A
 	
 	progress := &domain.AutoDocProgress{
 		ID:          progressID,
+		OrganizationUUID: organizationUUID,
 		Status:      "pending",
 		Progress:    0,
 		CurrentStep: "Iniciando análise do repositório",
@@ -80,7 +78,7 @@ func (s *AutoDocsService) runAutoDocsGeneration(organizationUUID string, progres
 	progress.Progress = 10
 	s.updateProgress(progressID, progress)
 
-	analysis, err := s.analyzeRepository(req)
+	analysis, err := s.analyzeRepository(organizationUUID, req)
 	if err != nil {
 		progress.Status = "failed"
 		progress.Errors = append(progress.Errors, fmt.Sprintf("Erro ao analisar repositório: %v", err))
@@ -129,7 +127,7 @@ func (s *AutoDocsService) runAutoDocsGeneration(organizationUUID string, progres
 	s.updateProgress(progressID, progress)
 
 	for _, doc := range progress.Documents {
-		err := s.saveDocumentToTechDocs(doc, req.ServiceName)
+		err := s.saveDocumentToTechDocs(organizationUUID, doc, req.Author)
 		if err != nil {
 			s.log.Errorw("Failed to save document", "path", doc.Path, "error", err)
 		}
@@ -141,7 +139,7 @@ func (s *AutoDocsService) runAutoDocsGeneration(organizationUUID string, progres
 	s.updateProgress(progressID, progress)
 }
 
-func (s *AutoDocsService) analyzeRepository(req domain.AutoDocRequest) (*domain.RepositoryAnalysis, error) {
+func (s *AutoDocsService) analyzeRepository(organizationUUID string, req domain.AutoDocRequest) (*domain.RepositoryAnalysis, error) {
 	analysis := &domain.RepositoryAnalysis{
 		RepositoryURL:    req.RepositoryURL,
 		ServiceName:      req.ServiceName,
@@ -152,21 +150,45 @@ func (s *AutoDocsService) analyzeRepository(req domain.AutoDocRequest) (*domain.
 
 	switch req.RepositorySource {
 	case domain.RepositorySourceGitHub:
-		return s.analyzeGitHubRepo(req, analysis)
+		return s.analyzeGitHubRepo(organizationUUID, req, analysis)
 	case domain.RepositorySourceAzureDevOps:
-		return s.analyzeAzureDevOpsRepo(req, analysis)
+		return s.analyzeAzureDevOpsRepo(organizationUUID, req, analysis)
 	case domain.RepositorySourceGitLab:
-		return s.analyzeGitLabRepo(req, analysis)
+		return s.analyzeGitLabRepo(organizationUUID, req, analysis)
 	default:
 		return nil, fmt.Errorf("unsupported repository source: %s", req.RepositorySource)
 	}
 }
 
-func (s *AutoDocsService) analyzeGitHubRepo(req domain.AutoDocRequest, analysis *domain.RepositoryAnalysis) (*domain.RepositoryAnalysis, error) {
-	if s.githubService == nil {
+// githubServiceFor returns the GitHub client of the request: the integration it names, or the
+// first GitHub integration of the organization
+func (s *AutoDocsService) githubServiceFor(organizationUUID string, integrationID int) (*GitHubService, error) {
+	if s.integrationService == nil {
 		return nil, fmt.Errorf("GitHub service not available")
 	}
 
+	var githubService *GitHubService
+	var err error
+	if integrationID != 0 {
+		githubService, err = s.integrationService.GetGitHubServiceByID(organizationUUID, integrationID)
+	} else {
+		githubService, err = s.integrationService.GetGitHubServiceByName(organizationUUID, "")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load GitHub integration: %w", err)
+	}
+	if githubService == nil {
+		return nil, fmt.Errorf("GitHub integration not configured")
+	}
+	return githubService, nil
+}
+
+func (s *AutoDocsService) analyzeGitHubRepo(organizationUUID string, req domain.AutoDocRequest, analysis *domain.RepositoryAnalysis) (*domain.RepositoryAnalysis, error) {
+	githubService, err := s.githubServiceFor(organizationUUID, req.IntegrationID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Parse repo URL to get owner/repo
 	parts := strings.Split(strings.TrimPrefix(req.RepositoryURL, "https://github.com/"), "/")
 	if len(parts) < 2 {
@@ -176,7 +198,7 @@ func (s *AutoDocsService) analyzeGitHubRepo(req domain.AutoDocRequest, analysis
 	repo := strings.TrimSuffix(parts[1], ".git")
 
 	// Get repository structure
-	repoInfo, err := s.githubService.GetRepository(owner, repo)
+	repoInfo, err := githubService.GetRepository(owner, repo)
 	if err != nil {
 		return nil, err
 	}
@@ -184,6 +206,23 @@ func (s *AutoDocsService) analyzeGitHubRepo(req domain.AutoDocRequest, analysis
 	analysis.Language = repoInfo.Language
 	analysis.Framework = s.detectFramework(repoInfo.Language, repoInfo.Description)
 
+	ref := req.Branch
+	if ref == "" {
+		ref = repoInfo.DefaultBranch
+	}
+
+	// The recursive tree (one call) shows what the repository actually contains
+	tree, err := githubService.GetRepositoryTree(owner, repo, ref)
+	if err == nil {
+		entries := make([]repositoryEntry, 0, len(tree.Entries))
+		for _, entry := range tree.Entries {
+			entries = append(entries, repositoryEntry{path: entry.Path, dir: entry.Type == "tree"})
+		}
+		s.analyzeRepositoryTree(entries, tree.Truncated, analysis)
+		return analysis, nil
+	}
+	s.log.Warnw("Failed to read repository tree, guessing from metadata", "error", err, "repository", req.RepositoryURL)
+
 	// Check for common files
 	analysis.HasHelmCharts = strings.Contains(strings.ToLower(req.RepositoryURL), "helm") ||
 		strings.Contains(strings.ToLower(repoInfo.Description), "helm")
@@ -194,11 +233,80 @@ func (s *AutoDocsService) analyzeGitHubRepo(req domain.AutoDocRequest, analysis
 	return analysis, nil
 }
 
-func (s *AutoDocsService) analyzeAzureDevOpsRepo(req domain.AutoDocRequest, analysis *domain.RepositoryAnalysis) (*domain.RepositoryAnalysis, error) {
-	if s.azureDevOpsService == nil {
+// repositoryEntry is a path of a repository tree, whatever the provider
+type repositoryEntry struct {
+	path string
+	dir  bool
+}
+
+// analyzeRepositoryTree detects charts, manifests, pipelines and policies from the file paths
+func (s *AutoDocsService) analyzeRepositoryTree(entries []repositoryEntry, truncated bool, analysis *domain.RepositoryAnalysis) {
+	directories := []string{}
+	infra := map[string]bool{}
+
+	for _, entry := range entries {
+		lowerPath := strings.ToLower(entry.path)
+		name := path.Base(lowerPath)
+
+		if entry.dir {
+			if !strings.Contains(entry.path, "/") {
+				directories = append(directories, entry.path)
+			}
+			continue
+		}
+
+		switch {
+		case name == "chart.yaml":
+			analysis.HasHelmCharts = true
+			infra["helm"] = true
+		case name == "kustomization.yaml" || name == "kustomization.yml":
+			analysis.HasK8sManifests = true
+			infra["kustomize"] = true
+		case (strings.HasPrefix(lowerPath, "k8s/") || strings.Contains(lowerPath, "/k8s/") ||
+			strings.HasPrefix(lowerPath, "manifests/") || strings.Contains(lowerPath, "/manifests/")) &&
+			(strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml")):
+			analysis.HasK8sManifests = true
+			infra["kubernetes"] = true
+		case strings.HasPrefix(lowerPath, ".github/workflows/"):
+			analysis.HasPipelines = true
+			infra["github-actions"] = true
+		case lowerPath == ".gitlab-ci.yml":
+			analysis.HasPipelines = true
+			infra["gitlab-ci"] = true
+		case name == "azure-pipelines.yml" || name == ".gitlab-ci.yml" || name == "jenkinsfile":
+			analysis.HasPipelines = true
+		case strings.HasSuffix(name, ".rego") || strings.HasPrefix(lowerPath, "policies/"):
+			analysis.HasPolicies = true
+		case name == "dockerfile" || strings.HasPrefix(name, "dockerfile."):
+			infra["docker"] = true
+		case strings.HasSuffix(name, ".tf"):
+			infra["terraform"] = true
+		}
+	}
+
+	for name := range infra {
+		analysis.DetectedInfra = append(analysis.DetectedInfra, name)
+	}
+	sort.Strings(analysis.DetectedInfra)
+
+	analysis.Structure["directories"] = directories
+	analysis.Structure["entries"] = len(entries)
+	analysis.Structure["truncated"] = truncated
+}
+
+func (s *AutoDocsService) analyzeAzureDevOpsRepo(organizationUUID string, req domain.AutoDocRequest, analysis *domain.RepositoryAnalysis) (*domain.RepositoryAnalysis, error) {
+	if s.integrationService == nil {
 		return nil, fmt.Errorf("Azure DevOps service not available")
 	}
 
+	config, err := s.integrationService.GetAzureDevOpsConfig(organizationUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Azure DevOps integration: %w", err)
+	}
+	if config == nil {
+		return nil, fmt.Errorf("Azure DevOps integration not configured")
+	}
+
 	analysis.HasPipelines = true // Azure DevOps always has pipelines
 	analysis.HasK8sManifests = strings.Contains(strings.ToLower(req.RepositoryURL), "k8s") ||
 		strings.Contains(strings.ToLower(req.RepositoryURL), "kubernetes")
@@ -206,12 +314,86 @@ func (s *AutoDocsService) analyzeAzureDevOpsRepo(req domain.AutoDocRequest, anal
 	return analysis, nil
 }
 
-func (s *AutoDocsService) analyzeGitLabRepo(req domain.AutoDocRequest, analysis *domain.RepositoryAnalysis) (*domain.RepositoryAnalysis, error) {
-	// GitLab integration would go here
-	analysis.HasPipelines = true // GitLab CI/CD
+// gitlabServiceFor returns the GitLab client of the request: the integration it names, or the
+// first GitLab integration of the organization
+func (s *AutoDocsService) gitlabServiceFor(organizationUUID string, integrationID int) (*GitLabService, error) {
+	if s.integrationService == nil {
+		return nil, fmt.Errorf("GitLab service not available")
+	}
+
+	var gitlabService *GitLabService
+	var err error
+	if integrationID != 0 {
+		gitlabService, err = s.integrationService.GetGitLabServiceByID(organizationUUID, integrationID)
+	} else {
+		gitlabService, err = s.integrationService.GetGitLabServiceByName(organizationUUID, "")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load GitLab integration: %w", err)
+	}
+	if gitlabService == nil {
+		return nil, fmt.Errorf("GitLab integration not configured")
+	}
+	return gitlabService, nil
+}
+
+func (s *AutoDocsService) analyzeGitLabRepo(organizationUUID string, req domain.AutoDocRequest, analysis *domain.RepositoryAnalysis) (*domain.RepositoryAnalysis, error) {
+	gitlabService, err := s.gitlabServiceFor(organizationUUID, req.IntegrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := gitlabProjectPath(req.RepositoryURL)
+	if err != nil {
+		return nil, err
+	}
+
+	projectInfo, err := gitlabService.GetProject(project)
+	if err != nil {
+		return nil, err
+	}
+
+	analysis.Framework = s.detectFramework("", projectInfo.Description)
+
+	ref := req.Branch
+	if ref == "" {
+		ref = projectInfo.DefaultBranch
+	}
+
+	tree, err := gitlabService.GetRepositoryTree(project, ref)
+	if err != nil {
+		s.log.Warnw("Failed to read repository tree, assuming GitLab CI/CD", "error", err, "repository", req.RepositoryURL)
+		analysis.HasPipelines = true
+		return analysis, nil
+	}
+
+	entries := make([]repositoryEntry, 0, len(tree))
+	for _, entry := range tree {
+		entries = append(entries, repositoryEntry{path: entry.Path, dir: entry.Type == "tree"})
+	}
+	s.analyzeRepositoryTree(entries, false, analysis)
+
 	return analysis, nil
 }
 
+// gitlabProjectPath extracts the project path (group/subgroup/project) of a GitLab URL
+func gitlabProjectPath(repositoryURL string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(repositoryURL))
+	if err != nil {
+		return "", fmt.Errorf("invalid GitLab URL: %w", err)
+	}
+
+	project := strings.Trim(u.Path, "/")
+	if i := strings.Index(project, "/-/"); i >= 0 {
+		project = project[:i]
+	}
+	project = strings.TrimSuffix(project, ".git")
+	if !strings.Contains(project, "/") {
+		return "", fmt.Errorf("invalid GitLab URL")
+	}
+	return project, nil
+}
+
 func (s *AutoDocsService) detectFramework(language, description string) string {
 	desc := strings.ToLower(description)
 	
@@ -286,7 +468,7 @@ Formato: Apenas código Mermaid, sem markdown.`,
 		req.ServiceName, analysis.Language, analysis.Framework, 
 		analysis.HasHelmCharts, analysis.HasK8sManifests, analysis.HasPipelines)
 
-	response, err := s.aiService.GenerateCompletion(organizationUUID, domain.AIProviderClaude, prompt, "")
+	response, err := s.aiService.GenerateCompletion(domain.AICaller{OrganizationUUID: organizationUUID, UserID: req.UserID, Feature: domain.AIFeatureAutoDocs}, "", prompt, "")
 	if err != nil {
 		return nil, err
 	}
@@ -317,7 +499,7 @@ Formato: Markdown bem estruturado.`,
 		req.ServiceName, analysis.Language, analysis.Framework,
 		analysis.HasHelmCharts, analysis.HasK8sManifests)
 
-	response, err := s.aiService.GenerateCompletion(organizationUUID, domain.AIProviderClaude, prompt, "")
+	response, err := s.aiService.GenerateCompletion(domain.AICaller{OrganizationUUID: organizationUUID, UserID: req.UserID, Feature: domain.AIFeatureAutoDocs}, "", prompt, "")
 	if err != nil {
 		return nil, err
 	}
@@ -347,7 +529,7 @@ Inclua:
 Baseado em: %s, %s, %v (K8s)`, 
 		req.ServiceName, analysis.Language, analysis.Framework, analysis.HasK8sManifests)
 
-	response, err := s.aiService.GenerateCompletion(organizationUUID, domain.AIProviderClaude, prompt, "")
+	response, err := s.aiService.GenerateCompletion(domain.AICaller{OrganizationUUID: organizationUUID, UserID: req.UserID, Feature: domain.AIFeatureAutoDocs}, "", prompt, "")
 	if err != nil {
 		return nil, err
 	}
@@ -376,7 +558,7 @@ Inclua:
 
 Formato: Markdown com tabelas.`, req.ServiceName)
 
-	response, err := s.aiService.GenerateCompletion(organizationUUID, domain.AIProviderClaude, prompt, "")
+	response, err := s.aiService.GenerateCompletion(domain.AICaller{OrganizationUUID: organizationUUID, UserID: req.UserID, Feature: domain.AIFeatureAutoDocs}, "", prompt, "")
 	if err != nil {
 		return nil, err
 	}
@@ -451,7 +633,7 @@ Formato: Markdown passo a passo.`,
 		req.ServiceName, analysis.Language, analysis.Framework,
 		analysis.HasHelmCharts, analysis.HasK8sManifests, analysis.HasPipelines)
 
-	response, err := s.aiService.GenerateCompletion(organizationUUID, domain.AIProviderClaude, prompt, "")
+	response, err := s.aiService.GenerateCompletion(domain.AICaller{OrganizationUUID: organizationUUID, UserID: req.UserID, Feature: domain.AIFeatureAutoDocs}, "", prompt, "")
 	if err != nil {
 		return nil, err
 	}
@@ -482,7 +664,7 @@ Formato: Diagrama Mermaid de flowchart.
 Baseado em: %v (Pipelines), %v (K8s)`, 
 		req.ServiceName, analysis.HasPipelines, analysis.HasK8sManifests)
 
-	response, err := s.aiService.GenerateCompletion(organizationUUID, domain.AIProviderClaude, prompt, "")
+	response, err := s.aiService.GenerateCompletion(domain.AICaller{OrganizationUUID: organizationUUID, UserID: req.UserID, Feature: domain.AIFeatureAutoDocs}, "", prompt, "")
 	if err != nil {
 		return nil, err
 	}
@@ -497,14 +679,14 @@ Baseado em: %v (Pipelines), %v (K8s)`,
 	}, nil
 }
 
-func (s *AutoDocsService) saveDocumentToTechDocs(doc domain.GeneratedDocument, serviceName string) error {
+// saveDocumentToTechDocs stores a generated document in the docs tree, where it is indexed for
+// the TechDocs chat like any other document
+func (s *AutoDocsService) saveDocumentToTechDocs(organizationUUID string, doc domain.GeneratedDocument, author domain.TechDocAuthor) error {
 	if s.techDocsService == nil {
 		return fmt.Errorf("TechDocs service not available")
 	}
 
-	// Save using TechDocs service
-	// This would integrate with the existing SaveDocument method
-	return nil
+	return s.techDocsService.SaveDocument(organizationUUID, doc.Path, doc.Content, author)
 }
 
 func (s *AutoDocsService) getDocTypeLabel(docType domain.DocType) string {
@@ -520,8 +702,17 @@ func (s *AutoDocsService) getDocTypeLabel(docType domain.DocType) string {
 	return labels[docType]
 }
 
-func (s *AutoDocsService) GetProgress(progressID string) (*domain.AutoDocProgress, error) {
-	return s.getProgress(progressID)
+// GetProgress returns a generation of the organization; the generations of other organizations
+// are reported as not found
+func (s *AutoDocsService) GetProgress(organizationUUID, progressID string) (*domain.AutoDocProgress, error) {
+	progress, err := s.getProgress(progressID)
+	if err != nil {
+		return nil, err
+	}
+	if progress.OrganizationUUID != organizationUUID {
+		return nil, &domain.NotFoundError{Resource: "auto docs progress", ID: progressID}
+	}
+	return progress, nil
 }
 
 func (s *AutoDocsService) getProgress(progressID string) (*domain.AutoDocProgress, error) {
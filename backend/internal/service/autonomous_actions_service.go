@@ -1,98 +1,445 @@
 package service
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/PlatifyX/platifyx-core/internal/repository"
 	"github.com/PlatifyX/platifyx-core/pkg/logger"
+	"github.com/google/uuid"
 )
 
 type AutonomousActionsService struct {
-	kubernetesService  *KubernetesService
-	azureDevOpsService *AzureDevOpsService
-	argocdService      interface{}
-	log                *logger.Logger
-	config             *domain.AutonomousConfig
+	kubernetesService   *KubernetesService
+	azureDevOpsService  *AzureDevOpsService
+	integrationService  *IntegrationService
+	notificationService *NotificationService
+	actionRepo          *repository.AutonomousActionRepository
+	configRepo          *repository.AutonomousConfigRepository
+	auditRepo           *repository.AuditRepository
+	log                 *logger.Logger
 }
 
+// NewAutonomousActionsService creates the service. Actions run against the Kubernetes and ArgoCD
+// integrations of the organization; kubernetesService is only used when the organization has no
+// Kubernetes integration (it may be nil). Actions awaiting approval are notified to the
+// notification channels of the autonomous config.
 func NewAutonomousActionsService(
 	kubernetesService *KubernetesService,
 	azureDevOpsService *AzureDevOpsService,
+	integrationService *IntegrationService,
+	notificationService *NotificationService,
+	actionRepo *repository.AutonomousActionRepository,
+	configRepo *repository.AutonomousConfigRepository,
+	auditRepo *repository.AuditRepository,
 	log *logger.Logger,
 ) *AutonomousActionsService {
 	return &AutonomousActionsService{
-		kubernetesService:  kubernetesService,
-		azureDevOpsService: azureDevOpsService,
+		kubernetesService:   kubernetesService,
+		azureDevOpsService:  azureDevOpsService,
+		integrationService:  integrationService,
+		notificationService: notificationService,
+		actionRepo:          actionRepo,
+		configRepo:          configRepo,
+		auditRepo:           auditRepo,
 		log:                 log,
-		config: &domain.AutonomousConfig{
-			Enabled:         false,
-			AutoExecute:    false,
-			RequireApproval: true,
-			AllowedActions:  []string{"rollback", "scale", "restart"},
-		},
 	}
 }
 
-func (s *AutonomousActionsService) ExecuteAction(action domain.RecommendedAction, userID string) (*domain.AutonomousAction, error) {
-	if !s.config.Enabled {
+// ExecuteAction records a requested action. It runs right away (in the background) when the
+// organization allows auto execution without approval, otherwise it waits for an approval.
+func (s *AutonomousActionsService) ExecuteAction(organizationUUID string, action domain.RecommendedAction, requester domain.AutonomousActionActor) (*domain.AutonomousAction, error) {
+	config, err := s.GetConfig(organizationUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !config.Enabled {
 		return nil, fmt.Errorf("autonomous actions are disabled")
 	}
 
-	if !s.isActionAllowed(action.Type) {
+	if !isActionAllowed(config, action.Type) {
 		return nil, fmt.Errorf("action type %s is not allowed", action.Type)
 	}
 
 	autonomousAction := &domain.AutonomousAction{
-		ID:          fmt.Sprintf("action-%d", time.Now().Unix()),
-		Type:        action.Type,
-		Status:      "pending",
-		Description: action.Description,
-		Trigger:     "manual",
-		Action:      action,
-		CreatedAt:   time.Now(),
-		ExecutedBy:  userID,
-	}
-
-	if s.config.AutoExecute && !s.config.RequireApproval {
-		err := s.execute(action)
-		if err != nil {
-			autonomousAction.Status = "failed"
-			autonomousAction.Error = err.Error()
-			return autonomousAction, err
+		OrganizationUUID: organizationUUID,
+		Type:             action.Type,
+		Status:           domain.AutonomousActionPendingApproval,
+		Description:      action.Description,
+		Trigger:          "manual",
+		Action:           action,
+		RequestedBy:      requester.UserID,
+	}
+
+	autoExecute := config.AutoExecute && !config.RequireApproval
+	if autoExecute {
+		autonomousAction.Status = domain.AutonomousActionApproved
+	}
+
+	if err := s.actionRepo.Create(autonomousAction); err != nil {
+		return nil, fmt.Errorf("failed to save action: %w", err)
+	}
+
+	s.audit(autonomousAction, "autonomous_action.request", requester, "")
+
+	if autoExecute {
+		s.runAsync(autonomousAction, requester.UserID)
+	} else {
+		s.notifyPendingApproval(autonomousAction, config, requester)
+	}
+
+	return autonomousAction, nil
+}
+
+// notifyPendingApproval publishes an action that waits for an approval to the notification
+// channels of the autonomous config, besides the rules that match it
+func (s *AutonomousActionsService) notifyPendingApproval(action *domain.AutonomousAction, config *domain.AutonomousConfig, requester domain.AutonomousActionActor) {
+	fields := map[string]string{
+		"Action":       action.ID,
+		"Requested by": firstNonEmpty(requester.UserEmail, requester.UserID),
+	}
+	for _, name := range []string{"application", "deployment", "namespace"} {
+		if value, _ := action.Action.Parameters[name].(string); value != "" {
+			fields[strings.ToUpper(name[:1])+name[1:]] = value
+		}
+	}
+
+	s.notificationService.Notify(action.OrganizationUUID, domain.NotificationEvent{
+		Type:     domain.NotificationEventActionPendingApproval,
+		Severity: domain.IncidentSeverityMedium,
+		Title:    fmt.Sprintf("Autonomous %s action awaits approval", action.Type),
+		Message:  action.Description,
+		Fields:   fields,
+		Labels:   map[string]string{"action": action.Type},
+		DedupKey: "autonomous-action:" + action.ID,
+		Channels: config.NotificationChannels,
+	})
+}
+
+// ListActions returns the actions of the organization, newest first
+func (s *AutonomousActionsService) ListActions(organizationUUID string, filter domain.AutonomousActionFilter) ([]domain.AutonomousAction, int, error) {
+	return s.actionRepo.List(organizationUUID, filter)
+}
+
+// GetAction returns an action requested in the organization
+func (s *AutonomousActionsService) GetAction(organizationUUID, actionID string) (*domain.AutonomousAction, error) {
+	action, err := s.actionRepo.GetByID(organizationUUID, actionID)
+	if err != nil {
+		return nil, err
+	}
+	if action == nil {
+		return nil, &domain.NotFoundError{Resource: "autonomous action", ID: actionID}
+	}
+	return action, nil
+}
+
+// ApproveAction approves a pending action and starts executing it in the background
+func (s *AutonomousActionsService) ApproveAction(organizationUUID, actionID string, approver domain.AutonomousActionActor, reason string) (*domain.AutonomousAction, error) {
+	config, err := s.GetConfig(organizationUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !config.Enabled {
+		return nil, fmt.Errorf("autonomous actions are disabled")
+	}
+
+	if err := s.checkApprover(organizationUUID, actionID, approver, "approve"); err != nil {
+		return nil, err
+	}
+
+	action, err := s.decide(organizationUUID, actionID, domain.AutonomousActionApproved, approver, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	// The configuration may have changed since the action was requested
+	if !isActionAllowed(config, action.Type) {
+		action.Status = domain.AutonomousActionFailed
+		action.Error = fmt.Sprintf("action type %s is not allowed", action.Type)
+		if err := s.actionRepo.Update(action); err != nil {
+			s.log.Errorw("Failed to update autonomous action", "error", err, "action", action.ID)
 		}
+		return action, nil
+	}
+
+	s.runAsync(action, approver.UserID)
+
+	return action, nil
+}
+
+// RejectAction rejects a pending action
+func (s *AutonomousActionsService) RejectAction(organizationUUID, actionID string, approver domain.AutonomousActionActor, reason string) (*domain.AutonomousAction, error) {
+	if err := s.checkApprover(organizationUUID, actionID, approver, "reject"); err != nil {
+		return nil, err
+	}
+
+	return s.decide(organizationUUID, actionID, domain.AutonomousActionRejected, approver, reason)
+}
+
+// checkApprover refuses a decision taken by the requester of the action: approval needs a second
+// person, the requester can only cancel
+func (s *AutonomousActionsService) checkApprover(organizationUUID, actionID string, approver domain.AutonomousActionActor, decision string) error {
+	action, err := s.GetAction(organizationUUID, actionID)
+	if err != nil {
+		return err
+	}
+	if action.RequestedBy != "" && action.RequestedBy == approver.UserID {
+		return &domain.ForbiddenError{Action: decision, Resource: "autonomous action requested by yourself"}
+	}
+	return nil
+}
 
-		autonomousAction.Status = "completed"
-		now := time.Now()
-		autonomousAction.ExecutedAt = &now
-		autonomousAction.Result = map[string]interface{}{
+// CancelAction withdraws a pending action before anyone decides on it. Only the requester can cancel it.
+func (s *AutonomousActionsService) CancelAction(organizationUUID, actionID string, requester domain.AutonomousActionActor, reason string) (*domain.AutonomousAction, error) {
+	action, err := s.GetAction(organizationUUID, actionID)
+	if err != nil {
+		return nil, err
+	}
+	if action.RequestedBy != requester.UserID {
+		return nil, &domain.ForbiddenError{Action: "cancel", Resource: "autonomous action requested by another user"}
+	}
+
+	return s.decide(organizationUUID, actionID, domain.AutonomousActionCancelled, requester, reason)
+}
+
+// UndoAction restores the state recorded before a completed action. It is allowed even when
+// autonomous actions are disabled, so that a bad action can always be reverted.
+func (s *AutonomousActionsService) UndoAction(organizationUUID, actionID string, actor domain.AutonomousActionActor) (*domain.AutonomousAction, error) {
+	original, err := s.GetAction(organizationUUID, actionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if original.Status != domain.AutonomousActionCompleted || original.BeforeState == nil {
+		return nil, &domain.InvalidStateError{Resource: "autonomous action", ID: actionID, State: original.Status}
+	}
+
+	undo := &domain.AutonomousAction{
+		OrganizationUUID: organizationUUID,
+		Type:             original.Type,
+		Status:           domain.AutonomousActionExecuting,
+		Description:      fmt.Sprintf("Undo of %s", original.ID),
+		Trigger:          "undo",
+		Action:           original.Action,
+		UndoOf:           original.ID,
+		RequestedBy:      actor.UserID,
+		ExecutedBy:       actor.UserID,
+	}
+	if err := s.actionRepo.Create(undo); err != nil {
+		return nil, fmt.Errorf("failed to save action: %w", err)
+	}
+
+	before, after, err := s.undo(organizationUUID, original)
+	now := time.Now()
+	undo.ExecutedAt = &now
+	undo.BeforeState = before
+	if err != nil {
+		undo.Status = domain.AutonomousActionFailed
+		undo.Error = err.Error()
+	} else {
+		undo.Status = domain.AutonomousActionCompleted
+		undo.AfterState = after
+		undo.Result = map[string]interface{}{
 			"success": true,
-			"message": "Action executed successfully",
+			"message": "Action undone successfully",
 		}
+	}
+
+	if updateErr := s.actionRepo.Update(undo); updateErr != nil {
+		s.log.Errorw("Failed to update autonomous action", "error", updateErr, "action", undo.ID)
+	}
+	s.audit(undo, "autonomous_action.undo", actor, "")
+
+	if err != nil {
+		return undo, err
+	}
+
+	original.Status = domain.AutonomousActionUndone
+	original.UndoneAt = &now
+	if err := s.actionRepo.Update(original); err != nil {
+		s.log.Errorw("Failed to mark autonomous action as undone", "error", err, "action", original.ID)
+	}
+
+	return undo, nil
+}
+
+// GetConfig returns the configuration of the organization, or the defaults when it was never saved
+func (s *AutonomousActionsService) GetConfig(organizationUUID string) (*domain.AutonomousConfig, error) {
+	config, err := s.configRepo.Get(organizationUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load autonomous config: %w", err)
+	}
+	if config == nil {
+		config = defaultAutonomousConfig()
+	}
+	return config, nil
+}
+
+func (s *AutonomousActionsService) UpdateConfig(organizationUUID string, config *domain.AutonomousConfig, updatedBy string) error {
+	return s.configRepo.Upsert(organizationUUID, config, updatedBy)
+}
+
+// RecoverInterruptedActions fails the actions that were approved or running when the process
+// stopped, since their execution goroutine is gone
+func (s *AutonomousActionsService) RecoverInterruptedActions() {
+	count, err := s.actionRepo.FailInterrupted()
+	if err != nil {
+		s.log.Errorw("Failed to recover interrupted autonomous actions", "error", err)
+		return
+	}
+	if count > 0 {
+		s.log.Warnw("Marked interrupted autonomous actions as failed", "count", count)
+	}
+}
+
+// decide moves a pending action to a final decision status and records it in the audit log
+func (s *AutonomousActionsService) decide(organizationUUID, actionID, status string, actor domain.AutonomousActionActor, reason string) (*domain.AutonomousAction, error) {
+	decided, err := s.actionRepo.Decide(organizationUUID, actionID, domain.AutonomousActionPendingApproval, status, actor.UserID, reason)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update action: %w", err)
+	}
+
+	action, err := s.GetAction(organizationUUID, actionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !decided {
+		return nil, &domain.InvalidStateError{Resource: "autonomous action", ID: actionID, State: action.Status}
+	}
+
+	auditAction := map[string]string{
+		domain.AutonomousActionApproved:  "autonomous_action.approve",
+		domain.AutonomousActionRejected:  "autonomous_action.reject",
+		domain.AutonomousActionCancelled: "autonomous_action.cancel",
+	}[status]
+	s.audit(action, auditAction, actor, reason)
+
+	return action, nil
+}
+
+// runAsync executes an approved action in the background, persisting its progress
+func (s *AutonomousActionsService) runAsync(action *domain.AutonomousAction, executedBy string) {
+	action.Status = domain.AutonomousActionExecuting
+	action.ExecutedBy = executedBy
+	if err := s.actionRepo.Update(action); err != nil {
+		s.log.Errorw("Failed to update autonomous action", "error", err, "action", action.ID)
+	}
+
+	execution := *action
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				s.log.Errorw("Autonomous action panicked", "action", execution.ID, "panic", r)
+				execution.Status = domain.AutonomousActionFailed
+				execution.Error = fmt.Sprintf("panic: %v", r)
+				if err := s.actionRepo.Update(&execution); err != nil {
+					s.log.Errorw("Failed to update autonomous action", "error", err, "action", execution.ID)
+				}
+			}
+		}()
+
+		s.run(&execution)
+	}()
+}
+
+func (s *AutonomousActionsService) run(action *domain.AutonomousAction) {
+	s.log.Infow("Executing autonomous action", "action", action.ID, "type", action.Type, "organization", action.OrganizationUUID)
+
+	before, after, err := s.execute(action.OrganizationUUID, action.Action)
+	now := time.Now()
+	action.ExecutedAt = &now
+	action.BeforeState = before
+
+	if err != nil {
+		s.log.Errorw("Autonomous action failed", "error", err, "action", action.ID)
+		action.Status = domain.AutonomousActionFailed
+		action.Error = err.Error()
 	} else {
-		autonomousAction.Status = "pending_approval"
+		action.Status = domain.AutonomousActionCompleted
+		action.AfterState = after
+		action.Result = map[string]interface{}{
+			"success": true,
+			"message": "Action executed successfully",
+		}
 	}
 
-	return autonomousAction, nil
+	if err := s.actionRepo.Update(action); err != nil {
+		s.log.Errorw("Failed to update autonomous action", "error", err, "action", action.ID)
+	}
 }
 
-func (s *AutonomousActionsService) execute(action domain.RecommendedAction) error {
+// audit writes an entry about the action to the audit log
+func (s *AutonomousActionsService) audit(action *domain.AutonomousAction, auditAction string, actor domain.AutonomousActionActor, reason string) {
+	details, err := json.Marshal(map[string]interface{}{
+		"organization_uuid": action.OrganizationUUID,
+		"type":              action.Type,
+		"status":            action.Status,
+		"parameters":        action.Action.Parameters,
+		"reason":            reason,
+	})
+	if err != nil {
+		s.log.Errorw("Failed to encode audit details", "error", err, "action", action.ID)
+		return
+	}
+
+	entry := &domain.AuditLog{
+		UserEmail:        actor.UserEmail,
+		OrganizationUUID: &action.OrganizationUUID,
+		Action:           auditAction,
+		Resource:         "autonomous_action",
+		ResourceID:       &action.ID,
+		Details:          details,
+		Status:           "success",
+	}
+	if action.Status == domain.AutonomousActionFailed {
+		entry.Status = "failure"
+	}
+	// audit_logs.user_id references users; service accounts such as "system" are kept in the details only
+	if _, err := uuid.Parse(actor.UserID); err == nil {
+		entry.UserID = &actor.UserID
+	}
+	if actor.IPAddress != "" {
+		entry.IPAddress = &actor.IPAddress
+	}
+	if actor.UserAgent != "" {
+		entry.UserAgent = &actor.UserAgent
+	}
+
+	if err := s.auditRepo.Create(entry); err != nil {
+		s.log.Errorw("Failed to write audit log", "error", err, "action", action.ID, "auditAction", auditAction)
+	}
+}
+
+func (s *AutonomousActionsService) execute(organizationUUID string, action domain.RecommendedAction) (*domain.AutonomousActionState, *domain.AutonomousActionState, error) {
 	switch action.Type {
 	case "rollback":
-		return s.executeRollback(action)
+		return s.executeRollback(organizationUUID, action)
 	case "scale":
-		return s.executeScale(action)
+		return s.executeScale(organizationUUID, action)
 	case "restart":
-		return s.executeRestart(action)
+		return s.executeRestart(organizationUUID, action)
 	default:
-		return fmt.Errorf("unsupported action type: %s", action.Type)
+		return nil, nil, fmt.Errorf("unsupported action type: %s", action.Type)
 	}
 }
 
-func (s *AutonomousActionsService) executeRollback(action domain.RecommendedAction) error {
+func (s *AutonomousActionsService) executeRollback(organizationUUID string, action domain.RecommendedAction) (*domain.AutonomousActionState, *domain.AutonomousActionState, error) {
+	// Applications managed by ArgoCD must be rolled back through ArgoCD, otherwise the next sync reverts it
+	if application, _ := action.Parameters["application"].(string); application != "" {
+		return s.rollbackArgoCDApplication(organizationUUID, application, nil, action)
+	}
+
 	deployment, ok := action.Parameters["deployment"].(string)
 	if !ok {
-		return fmt.Errorf("deployment parameter required")
+		return nil, nil, fmt.Errorf("deployment parameter required")
 	}
 
 	namespace, _ := action.Parameters["namespace"].(string)
@@ -102,18 +449,50 @@ func (s *AutonomousActionsService) executeRollback(action domain.RecommendedActi
 
 	s.log.Infow("Executing rollback", "deployment", deployment, "namespace", namespace)
 
-	return nil
+	kubernetesService, err := s.getKubernetesService(organizationUUID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	current, err := kubernetesService.GetDeploymentState(namespace, deployment)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if application := argoCDApplicationOf(current); application != "" {
+		return s.rollbackArgoCDApplication(organizationUUID, application, current, action)
+	}
+
+	var toRevision int64
+	if revision, ok := action.Parameters["revision"].(float64); ok {
+		toRevision = int64(revision)
+	}
+
+	before := &domain.AutonomousActionState{Deployment: current}
+	if _, err := kubernetesService.RollbackDeployment(namespace, deployment, toRevision); err != nil {
+		return before, nil, err
+	}
+
+	after, err := kubernetesService.GetDeploymentState(namespace, deployment)
+	if err != nil {
+		return before, nil, err
+	}
+
+	return before, &domain.AutonomousActionState{Deployment: after}, nil
 }
 
-func (s *AutonomousActionsService) executeScale(action domain.RecommendedAction) error {
+func (s *AutonomousActionsService) executeScale(organizationUUID string, action domain.RecommendedAction) (*domain.AutonomousActionState, *domain.AutonomousActionState, error) {
 	deployment, ok := action.Parameters["deployment"].(string)
 	if !ok {
-		return fmt.Errorf("deployment parameter required")
+		return nil, nil, fmt.Errorf("deployment parameter required")
 	}
 
 	replicas, ok := action.Parameters["replicas"].(float64)
 	if !ok {
-		return fmt.Errorf("replicas parameter required")
+		return nil, nil, fmt.Errorf("replicas parameter required")
+	}
+	if replicas < 0 || replicas > math.MaxInt32 || replicas != math.Trunc(replicas) {
+		return nil, nil, fmt.Errorf("replicas must be a whole number between 0 and %d", math.MaxInt32)
 	}
 
 	namespace, _ := action.Parameters["namespace"].(string)
@@ -123,13 +502,20 @@ func (s *AutonomousActionsService) executeScale(action domain.RecommendedAction)
 
 	s.log.Infow("Executing scale", "deployment", deployment, "namespace", namespace, "replicas", int(replicas))
 
-	return nil
+	kubernetesService, err := s.getKubernetesService(organizationUUID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.changeDeployment(kubernetesService, namespace, deployment, func() error {
+		return kubernetesService.ScaleDeployment(namespace, deployment, int32(replicas))
+	})
 }
 
-func (s *AutonomousActionsService) executeRestart(action domain.RecommendedAction) error {
+func (s *AutonomousActionsService) executeRestart(organizationUUID string, action domain.RecommendedAction) (*domain.AutonomousActionState, *domain.AutonomousActionState, error) {
 	deployment, ok := action.Parameters["deployment"].(string)
 	if !ok {
-		return fmt.Errorf("deployment parameter required")
+		return nil, nil, fmt.Errorf("deployment parameter required")
 	}
 
 	namespace, _ := action.Parameters["namespace"].(string)
@@ -139,23 +525,188 @@ func (s *AutonomousActionsService) executeRestart(action domain.RecommendedActio
 
 	s.log.Infow("Executing restart", "deployment", deployment, "namespace", namespace)
 
-	return nil
+	kubernetesService, err := s.getKubernetesService(organizationUUID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.changeDeployment(kubernetesService, namespace, deployment, func() error {
+		_, err := kubernetesService.RestartDeployment(namespace, deployment)
+		return err
+	})
 }
 
-func (s *AutonomousActionsService) isActionAllowed(actionType string) bool {
-	for _, allowed := range s.config.AllowedActions {
-		if allowed == actionType {
-			return true
+// undo reverts an action using the state recorded before it ran
+func (s *AutonomousActionsService) undo(organizationUUID string, original *domain.AutonomousAction) (*domain.AutonomousActionState, *domain.AutonomousActionState, error) {
+	previous := original.BeforeState
+
+	if previous.Application != "" {
+		s.log.Infow("Undoing ArgoCD rollback", "application", previous.Application, "historyId", previous.HistoryID)
+		return s.rollbackArgoCDApplication(organizationUUID, previous.Application, previous.Deployment, domain.RecommendedAction{
+			Parameters: map[string]interface{}{"historyId": float64(previous.HistoryID)},
+		})
+	}
+
+	if previous.Deployment == nil {
+		return nil, nil, fmt.Errorf("action %s has no recorded deployment state", original.ID)
+	}
+
+	namespace := previous.Deployment.Namespace
+	deployment := previous.Deployment.Name
+
+	kubernetesService, err := s.getKubernetesService(organizationUUID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.log.Infow("Undoing action", "action", original.ID, "type", original.Type, "deployment", deployment, "namespace", namespace)
+
+	switch original.Type {
+	case "rollback":
+		return s.changeDeployment(kubernetesService, namespace, deployment, func() error {
+			_, err := kubernetesService.RollbackDeployment(namespace, deployment, previous.Deployment.Revision)
+			return err
+		})
+	case "scale":
+		return s.changeDeployment(kubernetesService, namespace, deployment, func() error {
+			return kubernetesService.ScaleDeployment(namespace, deployment, previous.Deployment.Replicas)
+		})
+	case "restart":
+		// Restoring the previous annotation brings back the previous pod template
+		return s.changeDeployment(kubernetesService, namespace, deployment, func() error {
+			return kubernetesService.RestoreRestartedAt(namespace, deployment, previous.Deployment.RestartedAt)
+		})
+	default:
+		return nil, nil, fmt.Errorf("unsupported action type: %s", original.Type)
+	}
+}
+
+// changeDeployment applies a change to a deployment, recording its state before and after
+func (s *AutonomousActionsService) changeDeployment(kubernetesService *KubernetesService, namespace, deployment string, change func() error) (*domain.AutonomousActionState, *domain.AutonomousActionState, error) {
+	current, err := kubernetesService.GetDeploymentState(namespace, deployment)
+	if err != nil {
+		return nil, nil, err
+	}
+	before := &domain.AutonomousActionState{Deployment: current}
+
+	if err := change(); err != nil {
+		return before, nil, err
+	}
+
+	updated, err := kubernetesService.GetDeploymentState(namespace, deployment)
+	if err != nil {
+		return before, nil, err
+	}
+
+	return before, &domain.AutonomousActionState{Deployment: updated}, nil
+}
+
+// rollbackArgoCDApplication rolls an ArgoCD application back to the previous history entry,
+// or to the one given in the historyId parameter
+func (s *AutonomousActionsService) rollbackArgoCDApplication(organizationUUID, application string, deployment *domain.KubernetesDeploymentState, action domain.RecommendedAction) (*domain.AutonomousActionState, *domain.AutonomousActionState, error) {
+	if s.integrationService == nil {
+		return nil, nil, fmt.Errorf("argocd integration not configured")
+	}
+
+	argoCDService, err := s.integrationService.GetArgoCDService(organizationUUID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	app, err := argoCDService.GetApplication(application)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	history := app.Status.History
+	if len(history) == 0 {
+		return nil, nil, fmt.Errorf("argocd application %s has no deployment history", application)
+	}
+
+	current := history[len(history)-1]
+	before := &domain.AutonomousActionState{
+		Deployment:  deployment,
+		Application: application,
+		HistoryID:   current.ID,
+		Revision:    current.Revision,
+	}
+
+	var target *domain.ArgoCDRevisionHistory
+	if historyID, ok := action.Parameters["historyId"].(float64); ok {
+		for i := range history {
+			if history[i].ID == int64(historyID) {
+				target = &history[i]
+				break
+			}
+		}
+		if target == nil {
+			return before, nil, fmt.Errorf("history entry %d not found for argocd application %s", int64(historyID), application)
 		}
+	} else {
+		if len(history) < 2 {
+			return before, nil, fmt.Errorf("no previous revision found for argocd application %s", application)
+		}
+		target = &history[len(history)-2]
 	}
-	return false
+
+	s.log.Infow("Rolling back ArgoCD application", "application", application, "historyId", target.ID, "revision", target.Revision)
+
+	if err := argoCDService.RollbackApplication(application, strconv.FormatInt(target.ID, 10)); err != nil {
+		return before, nil, err
+	}
+
+	return before, &domain.AutonomousActionState{
+		Application: application,
+		HistoryID:   target.ID,
+		Revision:    target.Revision,
+	}, nil
 }
 
-func (s *AutonomousActionsService) GetConfig() *domain.AutonomousConfig {
-	return s.config
+// getKubernetesService returns the Kubernetes service of the organization
+func (s *AutonomousActionsService) getKubernetesService(organizationUUID string) (*KubernetesService, error) {
+	if organizationUUID != "" && s.integrationService != nil {
+		config, err := s.integrationService.GetKubernetesConfig(organizationUUID)
+		if err != nil {
+			return nil, err
+		}
+		if config != nil {
+			return NewKubernetesService(*config, s.log)
+		}
+	}
+
+	if s.kubernetesService != nil {
+		return s.kubernetesService, nil
+	}
+
+	return nil, fmt.Errorf("kubernetes integration not configured")
+}
+
+// argoCDApplicationOf returns the ArgoCD application that manages a deployment, if any
+func argoCDApplicationOf(deployment *domain.KubernetesDeploymentState) string {
+	// Annotation tracking: "<app>:<group>/<kind>:<namespace>/<name>"
+	if trackingID := deployment.Annotations["argocd.argoproj.io/tracking-id"]; trackingID != "" {
+		if application, _, found := strings.Cut(trackingID, ":"); found {
+			return application
+		}
+	}
+	return deployment.Labels["argocd.argoproj.io/instance"]
 }
 
-func (s *AutonomousActionsService) UpdateConfig(config *domain.AutonomousConfig) {
-	s.config = config
+func isActionAllowed(config *domain.AutonomousConfig, actionType string) bool {
+	for _, allowed := range config.AllowedActions {
+		if allowed == actionType {
+			return true
+		}
+	}
+	return false
 }
 
+func defaultAutonomousConfig() *domain.AutonomousConfig {
+	return &domain.AutonomousConfig{
+		Enabled:              false,
+		AutoExecute:          false,
+		RequireApproval:      true,
+		AllowedActions:       []string{"rollback", "scale", "restart"},
+		NotificationChannels: []string{},
+	}
+}
@@ -10,11 +10,12 @@ import (
 )
 
 type AutonomousRecommendationsService struct {
-	aiService          *AIService
-	kubernetesService  *KubernetesService
-	finOpsService      *FinOpsService
-	azureDevOpsService *AzureDevOpsService
-	log                *logger.Logger
+	aiService           *AIService
+	kubernetesService   *KubernetesService
+	finOpsService       *FinOpsService
+	azureDevOpsService  *AzureDevOpsService
+	notificationService *NotificationService
+	log                 *logger.Logger
 }
 
 func NewAutonomousRecommendationsService(
@@ -22,14 +23,16 @@ func NewAutonomousRecommendationsService(
 	kubernetesService *KubernetesService,
 	finOpsService *FinOpsService,
 	azureDevOpsService *AzureDevOpsService,
+	notificationService *NotificationService,
 	log *logger.Logger,
 ) *AutonomousRecommendationsService {
 	return &AutonomousRecommendationsService{
-		aiService:          aiService,
-		kubernetesService:  kubernetesService,
-		finOpsService:      finOpsService,
-		azureDevOpsService: azureDevOpsService,
-		log:                log,
+		aiService:           aiService,
+		kubernetesService:   kubernetesService,
+		finOpsService:       finOpsService,
+		azureDevOpsService:  azureDevOpsService,
+		notificationService: notificationService,
+		log:                 log,
 	}
 }
 
@@ -133,12 +136,34 @@ func (s *AutonomousRecommendationsService) analyzeCosts(organizationUUID string)
 				CreatedAt: time.Now(),
 			}
 			recommendations = append(recommendations, rec)
+			s.notifyCostAnomaly(organizationUUID, currentDailyCost, avgDailyCost, increase)
 		}
 	}
 
 	return recommendations, nil
 }
 
+// notifyCostAnomaly publishes a cost spike, at most once a day
+func (s *AutonomousRecommendationsService) notifyCostAnomaly(organizationUUID string, currentDailyCost, avgDailyCost, increase float64) {
+	severity := domain.SeverityMedium
+	if increase >= 50 {
+		severity = domain.SeverityHigh
+	}
+
+	s.notificationService.Notify(organizationUUID, domain.NotificationEvent{
+		Type:     domain.NotificationEventCostAnomaly,
+		Severity: string(severity),
+		Title:    fmt.Sprintf("Daily cloud cost is %.1f%% above average", increase),
+		Message:  fmt.Sprintf("Current cost: $%.2f/day vs average: $%.2f/day", currentDailyCost, avgDailyCost),
+		Fields: map[string]string{
+			"Current daily cost": fmt.Sprintf("$%.2f", currentDailyCost),
+			"Average daily cost": fmt.Sprintf("$%.2f", avgDailyCost),
+		},
+		DedupKey:           "cost-anomaly:" + time.Now().UTC().Format("2006-01-02"),
+		DedupWindowMinutes: 24 * 60,
+	})
+}
+
 func (s *AutonomousRecommendationsService) GenerateAIRecommendation(organizationUUID string, context map[string]interface{}) (*domain.Recommendation, error) {
 	if s.aiService == nil {
 		return nil, fmt.Errorf("AI service not available")
@@ -161,7 +186,7 @@ Gere uma recomendação no formato JSON com os campos:
 
 Responda APENAS com o JSON válido, sem markdown.`, string(contextJSON))
 
-	response, err := s.aiService.GenerateCompletion(organizationUUID, domain.AIProviderClaude, prompt, "")
+	response, err := s.aiService.GenerateCompletion(domain.AICaller{OrganizationUUID: organizationUUID, Feature: domain.AIFeatureRecommendations}, "", prompt, "")
 	if err != nil {
 		return nil, err
 	}
@@ -1,7 +1,6 @@
 package service
 
 import (
-	"encoding/json"
 	"fmt"
 	"time"
 
@@ -35,21 +34,6 @@ func NewAzureDevOpsServiceWithCache(config domain.AzureDevOpsConfig, cacheClient
 	}
 }
 
-// NewAzureDevOpsServiceFromIntegration creates an AzureDevOpsService from an Integration
-func NewAzureDevOpsServiceFromIntegration(integration *domain.Integration) (*AzureDevOpsService, error) {
-	// Parse config JSON
-	var config domain.AzureDevOpsConfig
-	err := json.Unmarshal(integration.Config, &config)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse Azure DevOps config: %w", err)
-	}
-
-	// Create a minimal logger (we don't have access to the main logger here)
-	log := logger.NewLogger("info")
-
-	return NewAzureDevOpsService(config, log), nil
-}
-
 func (s *AzureDevOpsService) GetPipelines() ([]domain.Pipeline, error) {
 	cacheKey := fmt.Sprintf("ci:pipelines:%s", s.config.Organization)
 
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/PlatifyX/platifyx-core/pkg/gitlab"
 	"github.com/PlatifyX/platifyx-core/pkg/logger"
 )
 
@@ -31,7 +32,7 @@ func NewBoardsService(
 	}
 }
 
-func (s *BoardsService) GetUnifiedBoard() (*domain.UnifiedBoard, error) {
+func (s *BoardsService) GetUnifiedBoard(organizationUUID string) (*domain.UnifiedBoard, error) {
 	board := &domain.UnifiedBoard{
 		ID:          "unified",
 		Name:        "Unified Board",
@@ -70,6 +71,15 @@ func (s *BoardsService) GetUnifiedBoard() (*domain.UnifiedBoard, error) {
 		}
 	}
 
+	// Fetch items from GitLab (issues and merge requests of the organization's integration)
+	if organizationUUID != "" {
+		gitlabItems, err := s.getGitLabItems(organizationUUID)
+		if err == nil {
+			allItems = append(allItems, gitlabItems...)
+			board.Sources = append(board.Sources, domain.BoardSourceGitLab)
+		}
+	}
+
 	// Fetch items from Jira (placeholder)
 	jiraItems, err := s.getJiraItems()
 	if err == nil {
@@ -140,6 +150,99 @@ func (s *BoardsService) getGitHubItems() ([]domain.BoardItem, error) {
 	return items, nil
 }
 
+// getGitLabItems maps the open issues and merge requests of the GitLab integration to board items:
+// open issues go to the backlog (or in progress when labelled "doing"/"in progress"), closed issues
+// to done and open merge requests to review
+func (s *BoardsService) getGitLabItems(organizationUUID string) ([]domain.BoardItem, error) {
+	if s.integrationService == nil {
+		return nil, fmt.Errorf("GitLab service not available")
+	}
+
+	gitlabService, err := s.integrationService.GetGitLabServiceByName(organizationUUID, "")
+	if err != nil {
+		return nil, err
+	}
+	if gitlabService == nil {
+		return nil, fmt.Errorf("GitLab integration not configured")
+	}
+
+	opts := gitlab.ListOptions{MaxPages: 1}
+	items := []domain.BoardItem{}
+
+	issues, err := gitlabService.ListGroupIssues("all", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, issue := range issues {
+		item := domain.BoardItem{
+			ID:          fmt.Sprintf("gitlab-issue-%d", issue.ID),
+			Title:       issue.Title,
+			Description: issue.Description,
+			Status:      s.mapGitLabIssueStatus(issue),
+			Labels:      issue.Labels,
+			Source:      domain.BoardSourceGitLab,
+			SourceURL:   issue.WebURL,
+			CreatedAt:   issue.CreatedAt,
+			UpdatedAt:   issue.UpdatedAt,
+			Metadata: map[string]interface{}{
+				"projectId": issue.ProjectID,
+				"iid":       issue.IID,
+				"type":      "issue",
+			},
+		}
+		if len(issue.Assignees) > 0 {
+			item.Assignee = issue.Assignees[0].Username
+		}
+		if dueDate, err := time.Parse("2006-01-02", issue.DueDate); err == nil {
+			item.DueDate = &dueDate
+		}
+		items = append(items, item)
+	}
+
+	mrs, err := gitlabService.ListGroupMergeRequests("opened", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, mr := range mrs {
+		items = append(items, domain.BoardItem{
+			ID:          fmt.Sprintf("gitlab-mr-%d", mr.ID),
+			Title:       mr.Title,
+			Description: mr.Description,
+			Status:      "review",
+			Assignee:    mr.Author.Username,
+			Labels:      mr.Labels,
+			Source:      domain.BoardSourceGitLab,
+			SourceURL:   mr.WebURL,
+			CreatedAt:   mr.CreatedAt,
+			UpdatedAt:   mr.UpdatedAt,
+			Metadata: map[string]interface{}{
+				"projectId":    mr.ProjectID,
+				"iid":          mr.IID,
+				"type":         "merge_request",
+				"sourceBranch": mr.SourceBranch,
+				"draft":        mr.Draft,
+			},
+		})
+	}
+
+	return items, nil
+}
+
+func (s *BoardsService) mapGitLabIssueStatus(issue domain.GitLabIssue) string {
+	if issue.State == "closed" {
+		return "done"
+	}
+	for _, label := range issue.Labels {
+		switch strings.ToLower(label) {
+		case "doing", "in progress", "in-progress", "workflow::in progress":
+			return "inprogress"
+		}
+	}
+	return "backlog"
+}
+
 func (s *BoardsService) getJiraItems() ([]domain.BoardItem, error) {
 	// Jira integration placeholder
 	items := []domain.BoardItem{}
@@ -185,7 +288,7 @@ func (s *BoardsService) matchesColumn(item domain.BoardItem, columnID string) bo
 	return false
 }
 
-func (s *BoardsService) GetBoardBySource(source domain.BoardSource) (*domain.Board, error) {
+func (s *BoardsService) GetBoardBySource(organizationUUID string, source domain.BoardSource) (*domain.Board, error) {
 	board := &domain.Board{
 		ID:          string(source),
 		Name:        fmt.Sprintf("%s Board", source),
@@ -203,6 +306,8 @@ func (s *BoardsService) GetBoardBySource(source domain.BoardSource) (*domain.Boa
 		items, err = s.getAzureDevOpsItems()
 	case domain.BoardSourceGitHub:
 		items, err = s.getGitHubItems()
+	case domain.BoardSourceGitLab:
+		items, err = s.getGitLabItems(organizationUUID)
 	case domain.BoardSourceJira:
 		items, err = s.getJiraItems()
 	default:
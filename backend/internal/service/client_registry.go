@@ -0,0 +1,250 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/PlatifyX/platifyx-core/pkg/logger"
+)
+
+const (
+	// defaultClientIdleTTL is how long an unused integration client is kept
+	defaultClientIdleTTL = 15 * time.Minute
+	// clientSweepInterval is how often idle clients are evicted
+	clientSweepInterval = time.Minute
+)
+
+// ClientKey identifies a client built from one version of an integration config.
+// Version is the integration's updated_at, so a config change never reuses an old client.
+type ClientKey struct {
+	OrganizationUUID string
+	IntegrationID    int
+	Version          int64
+	Kind             string
+}
+
+func (k ClientKey) String() string {
+	return fmt.Sprintf("%s/%d/%d/%s", k.OrganizationUUID, k.IntegrationID, k.Version, k.Kind)
+}
+
+func integrationClientKey(organizationUUID string, integration *domain.Integration, kind string) ClientKey {
+	return ClientKey{
+		OrganizationUUID: organizationUUID,
+		IntegrationID:    integration.ID,
+		Version:          integration.UpdatedAt.UnixNano(),
+		Kind:             kind,
+	}
+}
+
+type clientEntry struct {
+	client   interface{}
+	lastUsed atomic.Int64 // unix nano
+}
+
+// ClientRegistry caches the per-organization integration clients (Kubernetes clientsets,
+// HTTP API clients) so they are built once per config version instead of on every request.
+// Entries are invalidated when the integration changes and evicted after being idle.
+type ClientRegistry struct {
+	idleTTL time.Duration
+	log     *logger.Logger
+
+	mu      sync.RWMutex
+	entries map[ClientKey]*clientEntry
+
+	hits          atomic.Uint64
+	misses        atomic.Uint64
+	builds        atomic.Uint64
+	buildErrors   atomic.Uint64
+	evictions     atomic.Uint64
+	invalidations atomic.Uint64
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+func NewClientRegistry(idleTTL time.Duration, log *logger.Logger) *ClientRegistry {
+	if idleTTL <= 0 {
+		idleTTL = defaultClientIdleTTL
+	}
+
+	r := &ClientRegistry{
+		idleTTL: idleTTL,
+		log:     log,
+		entries: make(map[ClientKey]*clientEntry),
+		stop:    make(chan struct{}),
+	}
+	go r.sweepLoop()
+
+	return r
+}
+
+// getClient returns the cached client for key, building it on a miss. Concurrent misses for
+// the same key may build more than once; the first stored client wins. A nil registry
+// builds a new client on every call.
+func getClient[T any](r *ClientRegistry, key ClientKey, build func() (T, error)) (T, error) {
+	if r == nil {
+		return build()
+	}
+
+	r.mu.RLock()
+	entry, ok := r.entries[key]
+	r.mu.RUnlock()
+	if ok {
+		if client, ok := entry.client.(T); ok {
+			entry.lastUsed.Store(time.Now().UnixNano())
+			r.hits.Add(1)
+			return client, nil
+		}
+	}
+
+	r.misses.Add(1)
+	client, err := build()
+	if err != nil {
+		r.buildErrors.Add(1)
+		return client, err
+	}
+	r.builds.Add(1)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.entries[key]; ok {
+		if cached, ok := existing.client.(T); ok {
+			closeClient(client)
+			existing.lastUsed.Store(time.Now().UnixNano())
+			return cached, nil
+		}
+	}
+
+	entry = &clientEntry{client: client}
+	entry.lastUsed.Store(time.Now().UnixNano())
+	r.entries[key] = entry
+	r.log.Debugw("Integration client created", "key", key.String())
+
+	return client, nil
+}
+
+// InvalidateIntegration drops every client built from any version of an integration
+func (r *ClientRegistry) InvalidateIntegration(organizationUUID string, integrationID int) {
+	r.invalidate(func(key ClientKey) bool {
+		return key.OrganizationUUID == organizationUUID && key.IntegrationID == integrationID
+	})
+}
+
+// InvalidateOrganization drops every client of an organization (e.g. after a credential rotation)
+func (r *ClientRegistry) InvalidateOrganization(organizationUUID string) {
+	r.invalidate(func(key ClientKey) bool {
+		return key.OrganizationUUID == organizationUUID
+	})
+}
+
+func (r *ClientRegistry) invalidate(match func(ClientKey) bool) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, entry := range r.entries {
+		if match(key) {
+			delete(r.entries, key)
+			closeClient(entry.client)
+			r.invalidations.Add(1)
+		}
+	}
+}
+
+// Stats returns the registry counters
+func (r *ClientRegistry) Stats(organizationUUID string) domain.IntegrationClientStats {
+	if r == nil {
+		return domain.IntegrationClientStats{}
+	}
+
+	r.mu.RLock()
+	entries := len(r.entries)
+	organizationEntries := 0
+	for key := range r.entries {
+		if key.OrganizationUUID == organizationUUID {
+			organizationEntries++
+		}
+	}
+	r.mu.RUnlock()
+
+	stats := domain.IntegrationClientStats{
+		Entries:             entries,
+		OrganizationEntries: organizationEntries,
+		Hits:                r.hits.Load(),
+		Misses:              r.misses.Load(),
+		Builds:              r.builds.Load(),
+		BuildErrors:         r.buildErrors.Load(),
+		Evictions:           r.evictions.Load(),
+		Invalidations:       r.invalidations.Load(),
+		IdleTTLSeconds:      int64(r.idleTTL.Seconds()),
+	}
+	if total := stats.Hits + stats.Misses; total > 0 {
+		stats.HitRatio = float64(stats.Hits) / float64(total)
+	}
+	return stats
+}
+
+// Close stops the idle sweeper and releases every cached client
+func (r *ClientRegistry) Close() {
+	if r == nil {
+		return
+	}
+
+	r.stopOnce.Do(func() {
+		close(r.stop)
+		r.invalidate(func(ClientKey) bool { return true })
+	})
+}
+
+func (r *ClientRegistry) sweepLoop() {
+	interval := clientSweepInterval
+	if r.idleTTL < interval {
+		interval = r.idleTTL
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.evictIdle()
+		case <-r.stop:
+			return
+		}
+	}
+}
+
+func (r *ClientRegistry) evictIdle() {
+	cutoff := time.Now().Add(-r.idleTTL).UnixNano()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, entry := range r.entries {
+		if entry.lastUsed.Load() < cutoff {
+			delete(r.entries, key)
+			closeClient(entry.client)
+			r.evictions.Add(1)
+			r.log.Debugw("Idle integration client evicted", "key", key.String())
+		}
+	}
+}
+
+// closeClient releases clients that hold resources (e.g. idle HTTP connections)
+func closeClient(client interface{}) {
+	switch c := client.(type) {
+	case io.Closer:
+		c.Close()
+	case interface{ CloseIdleConnections() }:
+		c.CloseIdleConnections()
+	}
+}
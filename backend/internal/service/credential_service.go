@@ -0,0 +1,303 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/PlatifyX/platifyx-core/internal/config"
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/PlatifyX/platifyx-core/internal/repository"
+	"github.com/PlatifyX/platifyx-core/pkg/encryption"
+	"github.com/PlatifyX/platifyx-core/pkg/logger"
+)
+
+// sealedValuePrefix marks config values encrypted by CredentialService.
+// Format: sealed:v1:<data key version>:<base64 nonce+ciphertext>
+const sealedValuePrefix = "sealed:v1:"
+
+// CredentialService encrypts integration secrets with per-organization data keys
+// that are themselves wrapped by the master key ring (envelope encryption)
+type CredentialService struct {
+	keyRing *encryption.KeyRing
+	repo    *repository.DataKeyRepository
+	log     *logger.Logger
+
+	mu   sync.RWMutex
+	keys map[string]map[int][]byte // organizationUUID -> version -> unwrapped data key
+}
+
+func NewCredentialService(keyRing *encryption.KeyRing, repo *repository.DataKeyRepository, log *logger.Logger) *CredentialService {
+	return &CredentialService{
+		keyRing: keyRing,
+		repo:    repo,
+		log:     log,
+		keys:    make(map[string]map[int][]byte),
+	}
+}
+
+// NewCredentialServiceFromConfig builds the master key ring from the application config.
+// It returns nil when no master keys are configured.
+func NewCredentialServiceFromConfig(cfg *config.Config, repo *repository.DataKeyRepository, log *logger.Logger) (*CredentialService, error) {
+	if cfg.EncryptionMasterKeys == "" {
+		return nil, nil
+	}
+
+	keyRing, err := encryption.NewKeyRing(cfg.EncryptionMasterKeys, cfg.EncryptionActiveKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCredentialService(keyRing, repo, log), nil
+}
+
+// IsSealedValue reports whether a config value was encrypted by CredentialService
+func IsSealedValue(value string) bool {
+	return strings.HasPrefix(value, sealedValuePrefix)
+}
+
+// Seal encrypts the secret fields of an integration config with the organization's active data key.
+// Values that are already sealed are kept as they are.
+func (s *CredentialService) Seal(organizationUUID string, integrationType string, config map[string]interface{}) (map[string]interface{}, error) {
+	sealed := make(map[string]interface{}, len(config))
+	for key, value := range config {
+		sealed[key] = value
+	}
+
+	fields := domain.IntegrationSecretFields[domain.IntegrationType(integrationType)]
+	if len(fields) == 0 {
+		return sealed, nil
+	}
+
+	version, dataKey, err := s.activeKey(organizationUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range fields {
+		value, ok := sealed[field].(string)
+		if !ok || value == "" || IsSealedValue(value) {
+			continue
+		}
+
+		sealed[field], err = sealWith(version, dataKey, value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to seal field %s: %w", field, err)
+		}
+	}
+
+	return sealed, nil
+}
+
+// SealValue encrypts a single secret that is not part of an integration config (notification
+// channel webhooks, for instance) with the organization's active data key
+func (s *CredentialService) SealValue(organizationUUID string, value string) (string, error) {
+	if value == "" || IsSealedValue(value) {
+		return value, nil
+	}
+
+	version, dataKey, err := s.activeKey(organizationUUID)
+	if err != nil {
+		return "", err
+	}
+	return sealWith(version, dataKey, value)
+}
+
+// OpenValue decrypts a value sealed by SealValue; values that are not sealed are returned as they are
+func (s *CredentialService) OpenValue(organizationUUID string, value string) (string, error) {
+	if !IsSealedValue(value) {
+		return value, nil
+	}
+	return s.openValue(organizationUUID, value)
+}
+
+func sealWith(version int, dataKey []byte, value string) (string, error) {
+	ciphertext, err := encryption.Encrypt(dataKey, []byte(value))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s%d:%s", sealedValuePrefix, version, base64.StdEncoding.EncodeToString(ciphertext)), nil
+}
+
+// Open decrypts every sealed value of a stored integration config
+func (s *CredentialService) Open(organizationUUID string, config map[string]interface{}) (map[string]interface{}, error) {
+	opened := make(map[string]interface{}, len(config))
+	for key, value := range config {
+		str, ok := value.(string)
+		if !ok || !IsSealedValue(str) {
+			opened[key] = value
+			continue
+		}
+
+		plaintext, err := s.openValue(organizationUUID, str)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open field %s: %w", key, err)
+		}
+		opened[key] = plaintext
+	}
+
+	return opened, nil
+}
+
+// OpenJSON is like Open but works on the raw JSON stored in the integrations table
+func (s *CredentialService) OpenJSON(organizationUUID string, raw json.RawMessage) (json.RawMessage, error) {
+	if !strings.Contains(string(raw), sealedValuePrefix) {
+		return raw, nil
+	}
+
+	var config map[string]interface{}
+	if err := json.Unmarshal(raw, &config); err != nil {
+		return nil, err
+	}
+
+	opened, err := s.Open(organizationUUID, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(opened)
+}
+
+// RotateDataKey creates a new data key version for the organization and makes it the active one.
+// Previous versions are kept so existing values can still be opened until they are re-encrypted.
+func (s *CredentialService) RotateDataKey(organizationUUID string) (*domain.OrganizationDataKey, error) {
+	dataKey, err := encryption.GenerateDataKey()
+	if err != nil {
+		return nil, err
+	}
+
+	wrapped, masterKeyID, err := s.keyRing.WrapKey(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := s.repo.Create(organizationUUID, wrapped, masterKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store data key: %w", err)
+	}
+
+	s.cacheKey(organizationUUID, key.Version, dataKey)
+
+	s.log.Infow("Data key rotated", "organizationUUID", organizationUUID, "version", key.Version, "masterKeyID", masterKeyID)
+	return key, nil
+}
+
+// RewrapDataKeys re-wraps every data key that is not wrapped by the active master key.
+// Used after adding a new master key; integration values do not need to be re-encrypted.
+func (s *CredentialService) RewrapDataKeys() (int, error) {
+	keys, err := s.repo.GetAll()
+	if err != nil {
+		return 0, err
+	}
+
+	rewrapped := 0
+	for _, key := range keys {
+		if key.MasterKeyID == s.keyRing.ActiveKeyID() {
+			continue
+		}
+
+		dataKey, err := s.keyRing.UnwrapKey(key.WrappedKey, key.MasterKeyID)
+		if err != nil {
+			return rewrapped, fmt.Errorf("failed to unwrap data key %d: %w", key.ID, err)
+		}
+
+		wrapped, masterKeyID, err := s.keyRing.WrapKey(dataKey)
+		if err != nil {
+			return rewrapped, err
+		}
+
+		if err := s.repo.UpdateWrappedKey(key.ID, wrapped, masterKeyID); err != nil {
+			return rewrapped, err
+		}
+		rewrapped++
+	}
+
+	s.log.Infow("Data keys re-wrapped", "count", rewrapped, "masterKeyID", s.keyRing.ActiveKeyID())
+	return rewrapped, nil
+}
+
+func (s *CredentialService) openValue(organizationUUID string, value string) (string, error) {
+	parts := strings.SplitN(strings.TrimPrefix(value, sealedValuePrefix), ":", 2)
+	if len(parts) != 2 {
+		return "", fmt.Errorf("malformed sealed value")
+	}
+
+	version, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return "", fmt.Errorf("malformed sealed value version: %w", err)
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("malformed sealed value payload: %w", err)
+	}
+
+	dataKey, err := s.dataKey(organizationUUID, version)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := encryption.Decrypt(dataKey, ciphertext)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+// activeKey returns the organization's active data key, creating the first one on demand
+func (s *CredentialService) activeKey(organizationUUID string) (int, []byte, error) {
+	key, err := s.repo.GetActive(organizationUUID)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to fetch active data key: %w", err)
+	}
+
+	if key == nil {
+		key, err = s.RotateDataKey(organizationUUID)
+		if err != nil {
+			return 0, nil, err
+		}
+	}
+
+	dataKey, err := s.dataKey(organizationUUID, key.Version)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return key.Version, dataKey, nil
+}
+
+func (s *CredentialService) dataKey(organizationUUID string, version int) ([]byte, error) {
+	s.mu.RLock()
+	dataKey, ok := s.keys[organizationUUID][version]
+	s.mu.RUnlock()
+	if ok {
+		return dataKey, nil
+	}
+
+	key, err := s.repo.GetByVersion(organizationUUID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	dataKey, err = s.keyRing.UnwrapKey(key.WrappedKey, key.MasterKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cacheKey(organizationUUID, version, dataKey)
+	return dataKey, nil
+}
+
+func (s *CredentialService) cacheKey(organizationUUID string, version int, dataKey []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.keys[organizationUUID] == nil {
+		s.keys[organizationUUID] = make(map[int][]byte)
+	}
+	s.keys[organizationUUID][version] = dataKey
+}
@@ -29,7 +29,7 @@ func (s *DiagramService) GenerateDiagram(organizationUUID string, req domain.Gen
 	prompt := s.buildDiagramPrompt(req)
 
 	// Use AI to generate the diagram description
-	aiResp, err := s.aiService.GenerateCompletion(organizationUUID, req.Provider, prompt, req.Model)
+	aiResp, err := s.aiService.GenerateCompletion(domain.AICaller{OrganizationUUID: organizationUUID, UserID: req.UserID, Feature: domain.AIFeatureDiagram}, req.Provider, prompt, req.Model)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate diagram with AI: %w", err)
 	}
@@ -0,0 +1,438 @@
+package service
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/PlatifyX/platifyx-core/pkg/github"
+)
+
+// doraAlertsQuery selects critical alerts; alerts without a severity label are included too
+const doraAlertsQuery = `ALERTS{alertstate="firing",severity=~"critical|page|"}`
+
+const doraAlertsStep = 5 * time.Minute
+
+// doraGitHubListOptions bounds the workflow runs and commits fetched per repository (500 each)
+var doraGitHubListOptions = github.ListOptions{MaxPages: 5}
+
+// collectDeployments gathers production deployments from every CI/CD integration of the organization.
+// GitHub is collected first so its commit dates can be used to compute lead times of the other sources.
+func (s *MetricsService) collectDeployments(organizationUUID string, services []domain.Service, start, end time.Time) []domain.DeploymentEvent {
+	commitTimes := make(map[string]time.Time)
+
+	var events []domain.DeploymentEvent
+	events = append(events, s.collectGitHubDeployments(organizationUUID, services, start, end, commitTimes)...)
+	events = append(events, s.collectAzureDevOpsDeployments(organizationUUID, start, end, commitTimes)...)
+	events = append(events, s.collectArgoCDDeployments(organizationUUID, start, end, commitTimes)...)
+
+	return dedupeDeployments(events)
+}
+
+// collectGitHubDeployments uses the deploy/release workflow runs of the catalog services hosted on GitHub
+func (s *MetricsService) collectGitHubDeployments(organizationUUID string, services []domain.Service, start, end time.Time, commitTimes map[string]time.Time) []domain.DeploymentEvent {
+	configs, err := s.integrationService.GetAllGitHubConfigs(organizationUUID)
+	if err != nil {
+		s.log.Warnw("Failed to get GitHub integrations for DORA metrics", "error", err)
+		return nil
+	}
+	if len(configs) == 0 {
+		return nil
+	}
+
+	var events []domain.DeploymentEvent
+	for _, svc := range services {
+		owner, repo, ok := parseGitHubRepository(svc.RepositoryURL)
+		if !ok {
+			continue
+		}
+
+		for integrationName := range configs {
+			githubService, err := s.integrationService.GetGitHubServiceByName(organizationUUID, integrationName)
+			if err != nil || githubService == nil {
+				continue
+			}
+
+			runs, _, err := githubService.ListWorkflowRuns(owner, repo, doraGitHubListOptions)
+			if err != nil {
+				s.log.Debugw("Repository not reachable with GitHub integration", "integration", integrationName, "repo", repo, "error", err)
+				continue
+			}
+
+			commits, _, err := githubService.ListCommits(owner, repo, "", doraGitHubListOptions)
+			if err != nil {
+				s.log.Warnw("Failed to list commits for DORA lead time", "repo", repo, "error", err)
+			}
+			for _, commit := range commits {
+				commitTimes[commit.SHA] = commit.Author.Date
+			}
+
+			events = append(events, githubDeployments(svc.Name, runs, commits, start, end)...)
+			break
+		}
+	}
+
+	return events
+}
+
+// collectAzureDevOpsDeployments uses release deployments to production environments.
+// Integrations without classic releases fall back to completed builds of the main branch.
+func (s *MetricsService) collectAzureDevOpsDeployments(organizationUUID string, start, end time.Time, commitTimes map[string]time.Time) []domain.DeploymentEvent {
+	configs, err := s.integrationService.GetAllAzureDevOpsConfigs(organizationUUID)
+	if err != nil {
+		s.log.Warnw("Failed to get Azure DevOps integrations for DORA metrics", "error", err)
+		return nil
+	}
+
+	var events []domain.DeploymentEvent
+	for integrationName, config := range configs {
+		azureService := NewAzureDevOpsService(*config, s.log)
+
+		releases, err := azureService.GetReleases(100)
+		if err != nil {
+			s.log.Warnw("Failed to get releases for DORA metrics", "integration", integrationName, "error", err)
+			s.notificationService.Notify(organizationUUID, integrationDownEvent("Azure DevOps", err))
+		}
+
+		found := false
+		for _, release := range releases {
+			for _, env := range release.Environments {
+				if !isProductionEnvironment(env.Name) {
+					continue
+				}
+
+				var succeeded bool
+				switch strings.ToLower(env.Status) {
+				case "succeeded":
+					succeeded = true
+				case "rejected", "partiallysucceeded":
+					succeeded = false
+				default:
+					continue
+				}
+
+				found = true
+				if env.ModifiedOn.Before(start) || env.ModifiedOn.After(end) {
+					continue
+				}
+
+				events = append(events, domain.DeploymentEvent{
+					Service:    release.ReleaseDefinition.Name,
+					Source:     "azuredevops",
+					DeployedAt: env.ModifiedOn,
+					Succeeded:  succeeded,
+				})
+			}
+		}
+
+		if found {
+			continue
+		}
+
+		builds, err := azureService.GetBuilds(100)
+		if err != nil {
+			s.log.Warnw("Failed to get builds for DORA metrics", "integration", integrationName, "error", err)
+			s.notificationService.Notify(organizationUUID, integrationDownEvent("Azure DevOps", err))
+			continue
+		}
+
+		for _, build := range builds {
+			if !strings.EqualFold(build.Status, "completed") || !isMainBranch(build.SourceBranch) {
+				continue
+			}
+			if build.FinishTime.Before(start) || build.FinishTime.After(end) {
+				continue
+			}
+
+			var succeeded bool
+			switch strings.ToLower(build.Result) {
+			case "succeeded":
+				succeeded = true
+			case "failed", "partiallysucceeded":
+				succeeded = false
+			default:
+				continue
+			}
+
+			event := domain.DeploymentEvent{
+				Service:    build.Definition.Name,
+				Source:     "azuredevops",
+				Revision:   build.SourceVersion,
+				DeployedAt: build.FinishTime,
+				Succeeded:  succeeded,
+			}
+			if committedAt, ok := commitTimes[build.SourceVersion]; ok && succeeded {
+				event.LeadTimes = []time.Duration{build.FinishTime.Sub(committedAt)}
+			}
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
+
+// collectArgoCDDeployments uses the sync history of each application; failed syncs count as failed deployments
+func (s *MetricsService) collectArgoCDDeployments(organizationUUID string, start, end time.Time, commitTimes map[string]time.Time) []domain.DeploymentEvent {
+	config, err := s.integrationService.GetArgoCDConfig(organizationUUID)
+	if err != nil {
+		s.log.Warnw("Failed to get ArgoCD integration for DORA metrics", "error", err)
+		return nil
+	}
+	if config == nil {
+		return nil
+	}
+
+	apps, err := NewArgoCDService(*config, s.log).GetApplications()
+	if err != nil {
+		s.log.Warnw("Failed to get ArgoCD applications for DORA metrics", "error", err)
+		s.notificationService.Notify(organizationUUID, integrationDownEvent("ArgoCD", err))
+		return nil
+	}
+
+	var events []domain.DeploymentEvent
+	for _, app := range apps {
+		for _, history := range app.Status.History {
+			if history.DeployedAt.Before(start) || history.DeployedAt.After(end) {
+				continue
+			}
+
+			event := domain.DeploymentEvent{
+				Service:    app.Metadata.Name,
+				Source:     "argocd",
+				Revision:   history.Revision,
+				DeployedAt: history.DeployedAt,
+				Succeeded:  true,
+			}
+			if committedAt, ok := commitTimes[history.Revision]; ok {
+				event.LeadTimes = []time.Duration{history.DeployedAt.Sub(committedAt)}
+			}
+			events = append(events, event)
+		}
+
+		op := app.Status.OperationState
+		if op == nil || op.FinishedAt == nil || (op.Phase != "Failed" && op.Phase != "Error") {
+			continue
+		}
+		if op.FinishedAt.Before(start) || op.FinishedAt.After(end) {
+			continue
+		}
+
+		revision := ""
+		if op.Operation.Sync != nil {
+			revision = op.Operation.Sync.Revision
+		}
+		events = append(events, domain.DeploymentEvent{
+			Service:    app.Metadata.Name,
+			Source:     "argocd",
+			Revision:   revision,
+			DeployedAt: *op.FinishedAt,
+			Succeeded:  false,
+		})
+	}
+
+	return events
+}
+
+// collectRestores takes the failure/restore intervals from the incidents of the organization. Organizations
+// that do not track incidents fall back to the firing history of Prometheus alerts.
+func (s *MetricsService) collectRestores(organizationUUID string, start, end time.Time) []domain.RestoreEvent {
+	events, tracked, err := s.incidentService.RestoreEvents(organizationUUID, start, end)
+	if err != nil {
+		s.log.Warnw("Failed to list incidents for DORA metrics", "error", err)
+	}
+	if tracked {
+		return events
+	}
+
+	return s.collectAlertRestores(organizationUUID, start, end)
+}
+
+// collectAlertRestores derives failure/restore intervals from the firing history of Prometheus alerts.
+// Alerts still firing at the end of the window are not counted.
+func (s *MetricsService) collectAlertRestores(organizationUUID string, start, end time.Time) []domain.RestoreEvent {
+	config, err := s.integrationService.GetPrometheusConfig(organizationUUID)
+	if err != nil {
+		s.log.Warnw("Failed to get Prometheus integration for DORA metrics", "error", err)
+		return nil
+	}
+	if config == nil {
+		return nil
+	}
+
+	step := strconv.Itoa(int(doraAlertsStep.Seconds())) + "s"
+	result, err := NewPrometheusService(*config, s.log).QueryRange(doraAlertsQuery, start, end, step)
+	if err != nil {
+		s.log.Warnw("Failed to query alert history for DORA metrics", "error", err)
+		s.notificationService.Notify(organizationUUID, integrationDownEvent("Prometheus", err))
+		return nil
+	}
+
+	var events []domain.RestoreEvent
+	for _, series := range result.Data.Result {
+		service := firstLabel(series.Metric, "service", "app", "job", "namespace")
+
+		var segmentStart, last time.Time
+		for _, value := range series.Values {
+			if len(value) == 0 {
+				continue
+			}
+			seconds, ok := value[0].(float64)
+			if !ok {
+				continue
+			}
+			ts := time.Unix(int64(seconds), 0)
+
+			if !last.IsZero() && ts.Sub(last) > 2*doraAlertsStep {
+				events = append(events, domain.RestoreEvent{
+					Service:    service,
+					Source:     "prometheus",
+					Name:       series.Metric["alertname"],
+					StartedAt:  segmentStart,
+					ResolvedAt: last.Add(doraAlertsStep),
+				})
+				segmentStart = time.Time{}
+			}
+			if segmentStart.IsZero() {
+				segmentStart = ts
+			}
+			last = ts
+		}
+
+		if !last.IsZero() && end.Sub(last) > 2*doraAlertsStep {
+			events = append(events, domain.RestoreEvent{
+				Service:    service,
+				Source:     "prometheus",
+				Name:       series.Metric["alertname"],
+				StartedAt:  segmentStart,
+				ResolvedAt: last.Add(doraAlertsStep),
+			})
+		}
+	}
+
+	return events
+}
+
+// githubDeployments turns completed deploy workflow runs into deployment events.
+// The lead time of a successful deployment covers every commit since the previous one.
+func githubDeployments(service string, runs []domain.GitHubWorkflowRun, commits []domain.GitHubCommit, start, end time.Time) []domain.DeploymentEvent {
+	commitIndex := make(map[string]int, len(commits))
+	for i, commit := range commits {
+		commitIndex[commit.SHA] = i
+	}
+
+	sorted := make([]domain.GitHubWorkflowRun, len(runs))
+	copy(sorted, runs)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].UpdatedAt.Before(sorted[j].UpdatedAt) })
+
+	var events []domain.DeploymentEvent
+	previousIndex := -1
+	for _, run := range sorted {
+		if run.Status != "completed" || !isDeploymentWorkflow(run.Name) {
+			continue
+		}
+		if !isMainBranch(run.HeadBranch) && run.Event != "release" {
+			continue
+		}
+		if run.Conclusion != "success" && run.Conclusion != "failure" {
+			continue
+		}
+
+		succeeded := run.Conclusion == "success"
+		index, known := commitIndex[run.HeadSHA]
+
+		if !run.UpdatedAt.Before(start) && !run.UpdatedAt.After(end) {
+			event := domain.DeploymentEvent{
+				Service:    service,
+				Source:     "github",
+				Revision:   run.HeadSHA,
+				DeployedAt: run.UpdatedAt,
+				Succeeded:  succeeded,
+			}
+
+			if succeeded && known {
+				// Commits are listed newest first: the changes shipped are those between this head and the previous deployed head
+				last := index + 1
+				if previousIndex > index {
+					last = previousIndex
+				}
+				for _, commit := range commits[index:last] {
+					if leadTime := run.UpdatedAt.Sub(commit.Author.Date); leadTime >= 0 {
+						event.LeadTimes = append(event.LeadTimes, leadTime)
+					}
+				}
+			}
+			events = append(events, event)
+		}
+
+		if succeeded && known {
+			previousIndex = index
+		}
+	}
+
+	return events
+}
+
+// dedupeDeployments drops the same revision reported by more than one source on the same day
+func dedupeDeployments(events []domain.DeploymentEvent) []domain.DeploymentEvent {
+	seen := make(map[string]bool)
+	deduped := make([]domain.DeploymentEvent, 0, len(events))
+	for _, event := range events {
+		if event.Revision != "" {
+			key := strings.ToLower(event.Service) + "|" + event.Revision + "|" + strconv.FormatBool(event.Succeeded) + "|" + event.DeployedAt.UTC().Format("2006-01-02")
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		deduped = append(deduped, event)
+	}
+	return deduped
+}
+
+func parseGitHubRepository(repositoryURL string) (string, string, bool) {
+	parsed, err := url.Parse(repositoryURL)
+	if err != nil || !strings.Contains(parsed.Host, "github.com") {
+		return "", "", false
+	}
+
+	parts := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(parts) < 2 {
+		return "", "", false
+	}
+
+	return parts[0], strings.TrimSuffix(parts[1], ".git"), true
+}
+
+func isDeploymentWorkflow(name string) bool {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9')
+	})
+	for _, word := range words {
+		if strings.HasPrefix(word, "deploy") || strings.HasPrefix(word, "release") || word == "cd" {
+			return true
+		}
+	}
+	return false
+}
+
+func isProductionEnvironment(name string) bool {
+	name = strings.ToLower(name)
+	return strings.Contains(name, "prod") || strings.Contains(name, "prd")
+}
+
+func isMainBranch(branch string) bool {
+	branch = strings.TrimPrefix(branch, "refs/heads/")
+	return branch == "main" || branch == "master"
+}
+
+func firstLabel(labels map[string]string, names ...string) string {
+	for _, name := range names {
+		if value := labels[name]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
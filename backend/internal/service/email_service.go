@@ -2,7 +2,9 @@ package service
 
 import (
 	"fmt"
+	"html"
 	"os"
+	"strings"
 
 	"github.com/PlatifyX/platifyx-core/pkg/logger"
 	"github.com/sendgrid/sendgrid-go"
@@ -187,3 +189,40 @@ func formatOptionalFieldHTML(value *string) string {
 	}
 	return *value
 }
+
+// SendNotification sends a notification to a list of recipients. Unlike the other emails it fails
+// when SendGrid is not configured, so the delivery is reported as failed.
+func (s *EmailService) SendNotification(recipients []string, subject, text string) error {
+	if s.apiKey == "" {
+		return fmt.Errorf("email is not configured (SENDGRID_API_KEY is not set)")
+	}
+	if len(recipients) == 0 {
+		return fmt.Errorf("no recipients")
+	}
+
+	message := mail.NewV3Mail()
+	message.SetFrom(mail.NewEmail(s.fromName, s.fromEmail))
+	message.Subject = subject
+
+	personalization := mail.NewPersonalization()
+	for _, recipient := range recipients {
+		personalization.AddTos(mail.NewEmail("", recipient))
+	}
+	message.AddPersonalizations(personalization)
+
+	htmlContent := "<div style=\"font-family: Arial, sans-serif; line-height: 1.6; color: #333;\">" +
+		strings.ReplaceAll(html.EscapeString(text), "\n", "<br>") +
+		"</div>"
+	message.AddContent(mail.NewContent("text/plain", text), mail.NewContent("text/html", htmlContent))
+
+	response, err := sendgrid.NewSendClient(s.apiKey).Send(message)
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	if response.StatusCode >= 400 {
+		return fmt.Errorf("sendgrid error: %s", response.Body)
+	}
+
+	s.log.Infow("Notification email sent", "recipients", len(recipients), "status_code", response.StatusCode)
+	return nil
+}
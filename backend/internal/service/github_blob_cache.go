@@ -0,0 +1,46 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/pkg/cache"
+	"github.com/PlatifyX/platifyx-core/pkg/github"
+)
+
+const (
+	githubBlobKeyPrefix = "github:blob:"
+	githubBlobTTL       = 7 * 24 * time.Hour
+)
+
+// githubBlobCache keeps the blobs read by repository ingestion in Redis. Blobs are addressed by
+// SHA, so an entry never goes stale; keys are scoped by organization to keep tenants apart.
+type githubBlobCache struct {
+	redis            *cache.RedisClient
+	organizationUUID string
+}
+
+// newGitHubBlobCache returns nil without Redis, in which case every blob is fetched
+func newGitHubBlobCache(redis *cache.RedisClient, organizationUUID string) github.BlobCache {
+	if redis == nil {
+		return nil
+	}
+	return &githubBlobCache{redis: redis, organizationUUID: organizationUUID}
+}
+
+func (c *githubBlobCache) key(sha string) string {
+	return fmt.Sprintf("%s%s:%s", githubBlobKeyPrefix, c.organizationUUID, sha)
+}
+
+func (c *githubBlobCache) Get(sha string) ([]byte, bool) {
+	var content []byte
+	if err := c.redis.GetJSON(c.key(sha), &content); err != nil {
+		return nil, false
+	}
+	return content, true
+}
+
+func (c *githubBlobCache) Set(sha string, content []byte) {
+	// Best effort: a miss only costs a fetch
+	_ = c.redis.Set(c.key(sha), content, githubBlobTTL)
+}
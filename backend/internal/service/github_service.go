@@ -15,6 +15,7 @@ type GitHubService struct {
 func NewGitHubService(config domain.GitHubConfig, log *logger.Logger) *GitHubService {
 	log.Infow("Creating GitHub service",
 		"organization", config.Organization,
+		"githubApp", config.UsesApp(),
 	)
 	return &GitHubService{
 		client:       github.NewClient(config),
@@ -41,17 +42,46 @@ func (s *GitHubService) GetAuthenticatedUser() (*domain.GitHubUser, error) {
 	return user, nil
 }
 
-func (s *GitHubService) ListRepositories() ([]domain.GitHubRepository, error) {
+// GetInstallation returns the GitHub App installation of a service configured with a GitHub App
+func (s *GitHubService) GetInstallation() (*domain.GitHubInstallation, error) {
+	s.log.Info("Fetching GitHub App installation")
+
+	installation, err := s.client.GetInstallation()
+	if err != nil {
+		s.log.Errorw("Failed to fetch GitHub App installation", "error", err)
+		return nil, err
+	}
+
+	s.log.Infow("Fetched GitHub App installation successfully", "account", installation.Account.Login)
+	return installation, nil
+}
+
+// TestConnection checks the credentials, minting an installation token for a GitHub App
+func (s *GitHubService) TestConnection() error {
+	return s.client.TestConnection()
+}
+
+func (s *GitHubService) ListRepositories(opts github.ListOptions) ([]domain.GitHubRepository, error) {
+	repos, _, err := s.ListRepositoriesPage(opts)
+	return repos, err
+}
+
+// ListRepositoriesPage lists repositories up to the page cap of opts, and reports the cursor
+// to resume from when the cap stopped the listing early
+func (s *GitHubService) ListRepositoriesPage(opts github.ListOptions) ([]domain.GitHubRepository, github.ListPage, error) {
 	s.log.Info("Fetching GitHub repositories")
 
-	repos, err := s.client.ListRepositories()
+	repos, page, err := s.client.ListRepositoriesPage(opts)
 	if err != nil {
 		s.log.Errorw("Failed to fetch repositories", "error", err)
-		return nil, err
+		return nil, github.ListPage{}, err
 	}
 
+	if page.Truncated() {
+		s.log.Warnw("Repository list truncated by the page cap", "count", len(repos), "maxPages", opts.MaxPages)
+	}
 	s.log.Infow("Fetched repositories successfully", "count", len(repos))
-	return repos, nil
+	return repos, page, nil
 }
 
 func (s *GitHubService) GetRepository(owner, repo string) (*domain.GitHubRepository, error) {
@@ -67,69 +97,84 @@ func (s *GitHubService) GetRepository(owner, repo string) (*domain.GitHubReposit
 	return repository, nil
 }
 
-func (s *GitHubService) ListCommits(owner, repo, branch string) ([]domain.GitHubCommit, error) {
+func (s *GitHubService) ListCommits(owner, repo, branch string, opts github.ListOptions) ([]domain.GitHubCommit, github.ListPage, error) {
 	s.log.Infow("Fetching GitHub commits", "owner", owner, "repo", repo, "branch", branch)
 
-	commits, err := s.client.ListCommits(owner, repo, branch)
+	commits, page, err := s.client.ListCommits(owner, repo, branch, opts)
 	if err != nil {
 		s.log.Errorw("Failed to fetch commits", "error", err, "owner", owner, "repo", repo)
-		return nil, err
+		return nil, github.ListPage{}, err
 	}
 
+	if page.Truncated() {
+		s.log.Warnw("Commit list truncated by the page cap", "count", len(commits), "maxPages", opts.MaxPages)
+	}
 	s.log.Infow("Fetched commits successfully", "count", len(commits))
-	return commits, nil
+	return commits, page, nil
 }
 
-func (s *GitHubService) ListPullRequests(owner, repo, state string) ([]domain.GitHubPullRequest, error) {
+func (s *GitHubService) ListPullRequests(owner, repo, state string, opts github.ListOptions) ([]domain.GitHubPullRequest, github.ListPage, error) {
 	s.log.Infow("Fetching GitHub pull requests", "owner", owner, "repo", repo, "state", state)
 
-	prs, err := s.client.ListPullRequests(owner, repo, state)
+	prs, page, err := s.client.ListPullRequests(owner, repo, state, opts)
 	if err != nil {
 		s.log.Errorw("Failed to fetch pull requests", "error", err, "owner", owner, "repo", repo)
-		return nil, err
+		return nil, github.ListPage{}, err
 	}
 
+	if page.Truncated() {
+		s.log.Warnw("Pull request list truncated by the page cap", "count", len(prs), "maxPages", opts.MaxPages)
+	}
 	s.log.Infow("Fetched pull requests successfully", "count", len(prs))
-	return prs, nil
+	return prs, page, nil
 }
 
-func (s *GitHubService) ListIssues(owner, repo, state string) ([]domain.GitHubIssue, error) {
+func (s *GitHubService) ListIssues(owner, repo, state string, opts github.ListOptions) ([]domain.GitHubIssue, github.ListPage, error) {
 	s.log.Infow("Fetching GitHub issues", "owner", owner, "repo", repo, "state", state)
 
-	issues, err := s.client.ListIssues(owner, repo, state)
+	issues, page, err := s.client.ListIssues(owner, repo, state, opts)
 	if err != nil {
 		s.log.Errorw("Failed to fetch issues", "error", err, "owner", owner, "repo", repo)
-		return nil, err
+		return nil, github.ListPage{}, err
 	}
 
+	if page.Truncated() {
+		s.log.Warnw("Issue list truncated by the page cap", "count", len(issues), "maxPages", opts.MaxPages)
+	}
 	s.log.Infow("Fetched issues successfully", "count", len(issues))
-	return issues, nil
+	return issues, page, nil
 }
 
-func (s *GitHubService) ListBranches(owner, repo string) ([]domain.GitHubBranch, error) {
+func (s *GitHubService) ListBranches(owner, repo string, opts github.ListOptions) ([]domain.GitHubBranch, github.ListPage, error) {
 	s.log.Infow("Fetching GitHub branches", "owner", owner, "repo", repo)
 
-	branches, err := s.client.ListBranches(owner, repo)
+	branches, page, err := s.client.ListBranches(owner, repo, opts)
 	if err != nil {
 		s.log.Errorw("Failed to fetch branches", "error", err, "owner", owner, "repo", repo)
-		return nil, err
+		return nil, github.ListPage{}, err
 	}
 
+	if page.Truncated() {
+		s.log.Warnw("Branch list truncated by the page cap", "count", len(branches), "maxPages", opts.MaxPages)
+	}
 	s.log.Infow("Fetched branches successfully", "count", len(branches))
-	return branches, nil
+	return branches, page, nil
 }
 
-func (s *GitHubService) ListWorkflowRuns(owner, repo string) ([]domain.GitHubWorkflowRun, error) {
+func (s *GitHubService) ListWorkflowRuns(owner, repo string, opts github.ListOptions) ([]domain.GitHubWorkflowRun, github.ListPage, error) {
 	s.log.Infow("Fetching GitHub workflow runs", "owner", owner, "repo", repo)
 
-	runs, err := s.client.ListWorkflowRuns(owner, repo)
+	runs, page, err := s.client.ListWorkflowRuns(owner, repo, opts)
 	if err != nil {
 		s.log.Errorw("Failed to fetch workflow runs", "error", err, "owner", owner, "repo", repo)
-		return nil, err
+		return nil, github.ListPage{}, err
 	}
 
+	if page.Truncated() {
+		s.log.Warnw("Workflow run list truncated by the page cap", "count", len(runs), "maxPages", opts.MaxPages)
+	}
 	s.log.Infow("Fetched workflow runs successfully", "count", len(runs))
-	return runs, nil
+	return runs, page, nil
 }
 
 func (s *GitHubService) GetOrganization(org string) (*domain.GitHubOrganization, error) {
@@ -146,7 +191,7 @@ func (s *GitHubService) GetOrganization(org string) (*domain.GitHubOrganization,
 }
 
 func (s *GitHubService) GetStats() map[string]interface{} {
-	repos, err := s.ListRepositories()
+	repos, err := s.ListRepositories(github.ListOptions{})
 	if err != nil {
 		return map[string]interface{}{
 			"error": err.Error(),
@@ -204,11 +249,72 @@ func (s *GitHubService) GetFileContent(owner, repo, path, ref string) (string, e
 	return content, nil
 }
 
+// RateLimit returns the GitHub quota reported by the last request
+func (s *GitHubService) RateLimit() github.RateLimit {
+	return s.client.RateLimit()
+}
+
+// CloseIdleConnections releases the idle connections of the client (called when the cached client is evicted)
+func (s *GitHubService) CloseIdleConnections() {
+	s.client.CloseIdleConnections()
+}
+
 // GetRepositoryURL returns the web URL for a repository
 func (s *GitHubService) GetRepositoryURL(owner, repo string) string {
 	return s.client.GetRepositoryURL(owner, repo)
 }
 
+// GetRepositoryTree returns the recursive tree of a repository at a ref
+func (s *GitHubService) GetRepositoryTree(owner, repo, ref string) (*github.Tree, error) {
+	tree, err := s.client.GetTree(owner, repo, ref, true)
+	if err != nil {
+		s.log.Errorw("Failed to fetch repository tree",
+			"error", err,
+			"owner", owner,
+			"repository", repo,
+			"ref", ref,
+		)
+		return nil, err
+	}
+
+	return tree, nil
+}
+
+// IngestRepository reads the source files of a repository through the Git Trees API
+func (s *GitHubService) IngestRepository(owner, repo, ref string, opts github.IngestOptions) (*github.RepositoryIngestion, error) {
+	s.log.Infow("Ingesting GitHub repository",
+		"owner", owner,
+		"repository", repo,
+		"ref", ref,
+	)
+
+	ingestion, err := s.client.IngestRepository(owner, repo, ref, opts)
+	if err != nil {
+		s.log.Errorw("Failed to ingest repository",
+			"error", err,
+			"owner", owner,
+			"repository", repo,
+		)
+		return nil, err
+	}
+
+	s.log.Infow("Ingested repository successfully",
+		"owner", owner,
+		"repository", repo,
+		"treeSha", ingestion.TreeSHA,
+		"files", ingestion.Stats.Files,
+		"totalSize", ingestion.Stats.TotalSize,
+		"cachedBlobs", ingestion.Stats.CachedBlobs,
+		"fetchedBlobs", ingestion.Stats.FetchedBlobs,
+		"failedBlobs", ingestion.Stats.FailedBlobs,
+		"skippedGenerated", ingestion.Stats.SkippedGenerated,
+		"skippedTooLarge", ingestion.Stats.SkippedTooLarge,
+		"skippedOverBudget", ingestion.Stats.SkippedOverBudget,
+		"truncated", ingestion.Stats.Truncated,
+	)
+	return ingestion, nil
+}
+
 // GetAllRepositoryFiles retrieves all files from a repository
 func (s *GitHubService) GetAllRepositoryFiles(owner, repo, ref string) ([]github.GitHubRepositoryFile, error) {
 	s.log.Infow("Fetching all files from GitHub repository",
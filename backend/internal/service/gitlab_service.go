@@ -0,0 +1,249 @@
+package service
+
+import (
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/PlatifyX/platifyx-core/pkg/gitlab"
+	"github.com/PlatifyX/platifyx-core/pkg/logger"
+)
+
+type GitLabService struct {
+	client *gitlab.Client
+	group  string
+	log    *logger.Logger
+}
+
+func NewGitLabService(config domain.GitLabConfig, log *logger.Logger) *GitLabService {
+	log.Infow("Creating GitLab service",
+		"url", config.URL,
+		"group", config.Group,
+	)
+	return &GitLabService{
+		client: gitlab.NewClient(config),
+		group:  config.Group,
+		log:    log,
+	}
+}
+
+// GetConfiguredGroup returns the configured GitLab group
+func (s *GitLabService) GetConfiguredGroup() string {
+	return s.group
+}
+
+// CloseIdleConnections releases the idle connections of the client (called by the client registry)
+func (s *GitLabService) CloseIdleConnections() {
+	s.client.CloseIdleConnections()
+}
+
+// TestConnection checks the token against the instance
+func (s *GitLabService) TestConnection() error {
+	return s.client.TestConnection()
+}
+
+func (s *GitLabService) GetAuthenticatedUser() (*domain.GitLabUser, error) {
+	s.log.Info("Fetching authenticated GitLab user")
+
+	user, err := s.client.GetAuthenticatedUser()
+	if err != nil {
+		s.log.Errorw("Failed to fetch authenticated user", "error", err)
+		return nil, err
+	}
+
+	s.log.Infow("Fetched authenticated user successfully", "username", user.Username)
+	return user, nil
+}
+
+func (s *GitLabService) ListProjects(opts gitlab.ListOptions) ([]domain.GitLabProject, error) {
+	s.log.Info("Fetching GitLab projects")
+
+	projects, err := s.client.ListProjects(opts)
+	if err != nil {
+		s.log.Errorw("Failed to fetch projects", "error", err)
+		return nil, err
+	}
+
+	s.log.Infow("Fetched projects successfully", "count", len(projects))
+	return projects, nil
+}
+
+func (s *GitLabService) GetProject(project string) (*domain.GitLabProject, error) {
+	s.log.Infow("Fetching GitLab project", "project", project)
+
+	result, err := s.client.GetProject(project)
+	if err != nil {
+		s.log.Errorw("Failed to fetch project", "error", err, "project", project)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *GitLabService) ListCommits(project, ref string, opts gitlab.ListOptions) ([]domain.GitLabCommit, error) {
+	s.log.Infow("Fetching GitLab commits", "project", project, "ref", ref)
+
+	commits, err := s.client.ListCommits(project, ref, opts)
+	if err != nil {
+		s.log.Errorw("Failed to fetch commits", "error", err, "project", project)
+		return nil, err
+	}
+
+	s.log.Infow("Fetched commits successfully", "count", len(commits))
+	return commits, nil
+}
+
+func (s *GitLabService) ListMergeRequests(project, state string, opts gitlab.ListOptions) ([]domain.GitLabMergeRequest, error) {
+	s.log.Infow("Fetching GitLab merge requests", "project", project, "state", state)
+
+	mrs, err := s.client.ListMergeRequests(project, state, opts)
+	if err != nil {
+		s.log.Errorw("Failed to fetch merge requests", "error", err, "project", project)
+		return nil, err
+	}
+
+	s.log.Infow("Fetched merge requests successfully", "count", len(mrs))
+	return mrs, nil
+}
+
+// ListGroupMergeRequests lists the merge requests of the configured group
+func (s *GitLabService) ListGroupMergeRequests(state string, opts gitlab.ListOptions) ([]domain.GitLabMergeRequest, error) {
+	mrs, err := s.client.ListGroupMergeRequests(state, opts)
+	if err != nil {
+		s.log.Errorw("Failed to fetch group merge requests", "error", err, "group", s.group)
+		return nil, err
+	}
+
+	return mrs, nil
+}
+
+func (s *GitLabService) ListIssues(project, state string, opts gitlab.ListOptions) ([]domain.GitLabIssue, error) {
+	s.log.Infow("Fetching GitLab issues", "project", project, "state", state)
+
+	issues, err := s.client.ListIssues(project, state, opts)
+	if err != nil {
+		s.log.Errorw("Failed to fetch issues", "error", err, "project", project)
+		return nil, err
+	}
+
+	s.log.Infow("Fetched issues successfully", "count", len(issues))
+	return issues, nil
+}
+
+// ListGroupIssues lists the issues of the configured group
+func (s *GitLabService) ListGroupIssues(state string, opts gitlab.ListOptions) ([]domain.GitLabIssue, error) {
+	issues, err := s.client.ListGroupIssues(state, opts)
+	if err != nil {
+		s.log.Errorw("Failed to fetch group issues", "error", err, "group", s.group)
+		return nil, err
+	}
+
+	return issues, nil
+}
+
+func (s *GitLabService) ListBranches(project string, opts gitlab.ListOptions) ([]domain.GitLabBranch, error) {
+	s.log.Infow("Fetching GitLab branches", "project", project)
+
+	branches, err := s.client.ListBranches(project, opts)
+	if err != nil {
+		s.log.Errorw("Failed to fetch branches", "error", err, "project", project)
+		return nil, err
+	}
+
+	s.log.Infow("Fetched branches successfully", "count", len(branches))
+	return branches, nil
+}
+
+func (s *GitLabService) ListPipelines(project string, opts gitlab.ListOptions) ([]domain.GitLabPipeline, error) {
+	s.log.Infow("Fetching GitLab pipelines", "project", project)
+
+	pipelines, err := s.client.ListPipelines(project, opts)
+	if err != nil {
+		s.log.Errorw("Failed to fetch pipelines", "error", err, "project", project)
+		return nil, err
+	}
+
+	s.log.Infow("Fetched pipelines successfully", "count", len(pipelines))
+	return pipelines, nil
+}
+
+func (s *GitLabService) GetGroup(group string) (*domain.GitLabGroup, error) {
+	s.log.Infow("Fetching GitLab group", "group", group)
+
+	result, err := s.client.GetGroup(group)
+	if err != nil {
+		s.log.Errorw("Failed to fetch group", "error", err, "group", group)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *GitLabService) GetStats() map[string]interface{} {
+	projects, err := s.ListProjects(gitlab.ListOptions{})
+	if err != nil {
+		return map[string]interface{}{
+			"error": err.Error(),
+		}
+	}
+
+	totalStars := 0
+	totalForks := 0
+	totalIssues := 0
+	visibilityCount := make(map[string]int)
+
+	for _, project := range projects {
+		totalStars += project.StarCount
+		totalForks += project.ForksCount
+		totalIssues += project.OpenIssuesCount
+		if project.Visibility != "" {
+			visibilityCount[project.Visibility]++
+		}
+	}
+
+	return map[string]interface{}{
+		"totalProjects":   len(projects),
+		"totalStars":      totalStars,
+		"totalForks":      totalForks,
+		"totalOpenIssues": totalIssues,
+		"visibilityCount": visibilityCount,
+	}
+}
+
+// GetFileContent fetches a file content from a project
+func (s *GitLabService) GetFileContent(project, path, ref string) (string, error) {
+	s.log.Infow("Fetching file content from GitLab",
+		"project", project,
+		"path", path,
+		"ref", ref,
+	)
+
+	content, err := s.client.GetFileContent(project, path, ref)
+	if err != nil {
+		s.log.Errorw("Failed to fetch file content from GitLab",
+			"error", err,
+			"project", project,
+			"path", path,
+		)
+		return "", err
+	}
+
+	return content, nil
+}
+
+// GetRepositoryURL returns the web URL of a project
+func (s *GitLabService) GetRepositoryURL(project string) string {
+	return s.client.GetRepositoryURL(project)
+}
+
+// GetRepositoryTree returns the entries of the repository tree at a ref (up to 5000 entries)
+func (s *GitLabService) GetRepositoryTree(project, ref string) ([]gitlab.TreeEntry, error) {
+	entries, err := s.client.GetTree(project, ref, gitlab.ListOptions{MaxPages: 50})
+	if err != nil {
+		s.log.Errorw("Failed to fetch repository tree",
+			"error", err,
+			"project", project,
+			"ref", ref,
+		)
+		return nil, err
+	}
+
+	return entries, nil
+}
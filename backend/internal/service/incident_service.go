@@ -0,0 +1,548 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/PlatifyX/platifyx-core/internal/repository"
+	"github.com/PlatifyX/platifyx-core/pkg/logger"
+)
+
+const (
+	defaultIncidentMetricsDays = 30
+	maxIncidentTitleLength     = 500
+)
+
+var incidentSeverities = map[string]bool{
+	domain.IncidentSeverityCritical: true,
+	domain.IncidentSeverityHigh:     true,
+	domain.IncidentSeverityMedium:   true,
+	domain.IncidentSeverityLow:      true,
+}
+
+// IncidentService tracks the incidents of each organization, opened manually, from Slack or from
+// alerts, and derives MTTR and incident counts from them
+type IncidentService struct {
+	repo               *repository.IncidentRepository
+	serviceRepo        *repository.ServiceRepository
+	integrationService *IntegrationService
+	log                *logger.Logger
+}
+
+func NewIncidentService(
+	repo *repository.IncidentRepository,
+	serviceRepo *repository.ServiceRepository,
+	integrationService *IntegrationService,
+	log *logger.Logger,
+) *IncidentService {
+	return &IncidentService{
+		repo:               repo,
+		serviceRepo:        serviceRepo,
+		integrationService: integrationService,
+		log:                log,
+	}
+}
+
+// CreateIncident opens an incident. The service, when given, must be in the catalog.
+func (s *IncidentService) CreateIncident(organizationUUID string, req domain.CreateIncidentRequest, actor string) (*domain.Incident, error) {
+	return s.createIncident(organizationUUID, req, domain.IncidentSourceManual, "", actor)
+}
+
+func (s *IncidentService) createIncident(organizationUUID string, req domain.CreateIncidentRequest, source, externalID, actor string) (*domain.Incident, error) {
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return nil, &domain.ValidationError{Field: "title", Message: "is required"}
+	}
+	if len(title) > maxIncidentTitleLength {
+		return nil, &domain.ValidationError{Field: "title", Message: fmt.Sprintf("must have at most %d characters", maxIncidentTitleLength)}
+	}
+
+	severity := req.Severity
+	if severity == "" {
+		severity = domain.IncidentSeverityMedium
+	}
+	if !incidentSeverities[severity] {
+		return nil, &domain.ValidationError{Field: "severity", Message: "must be critical, high, medium or low"}
+	}
+
+	now := time.Now()
+	openedAt := now
+	if req.OpenedAt != nil {
+		if req.OpenedAt.After(now) {
+			return nil, &domain.ValidationError{Field: "openedAt", Message: "must not be in the future"}
+		}
+		openedAt = *req.OpenedAt
+	}
+
+	incident := &domain.Incident{
+		OrganizationUUID: organizationUUID,
+		Title:            title,
+		Description:      strings.TrimSpace(req.Description),
+		Severity:         severity,
+		Status:           domain.IncidentStatusOpen,
+		Source:           source,
+		ExternalID:       externalID,
+		OpenedBy:         actor,
+		OpenedAt:         openedAt,
+	}
+	if err := s.linkService(incident, req.ServiceName); err != nil {
+		return nil, err
+	}
+
+	entry := &domain.IncidentTimelineEntry{
+		Type:      domain.IncidentEntryOpened,
+		Message:   fmt.Sprintf("Incident opened with severity %s", severity),
+		Author:    actor,
+		CreatedAt: openedAt,
+	}
+	if err := s.repo.Create(incident, entry); err != nil {
+		var conflict *domain.ConflictError
+		if errors.As(err, &conflict) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to create incident: %w", err)
+	}
+
+	s.log.Infow("Incident opened",
+		"organizationUUID", organizationUUID,
+		"incident", incident.ID,
+		"service", incident.ServiceName,
+		"severity", severity,
+		"source", source,
+	)
+
+	incident.Timeline = []domain.IncidentTimelineEntry{*entry}
+	return incident, nil
+}
+
+// linkService sets the service of an incident and its current squad
+func (s *IncidentService) linkService(incident *domain.Incident, serviceName string) error {
+	serviceName = strings.TrimSpace(serviceName)
+	if serviceName == "" {
+		incident.ServiceName = ""
+		incident.Squad = ""
+		return nil
+	}
+
+	svc, err := s.serviceRepo.GetByName(serviceName)
+	if err != nil {
+		return fmt.Errorf("failed to get service: %w", err)
+	}
+	if svc == nil {
+		return &domain.ValidationError{Field: "serviceName", Message: fmt.Sprintf("service %q is not in the catalog", serviceName)}
+	}
+
+	incident.ServiceName = svc.Name
+	incident.Squad = svc.Squad
+	return nil
+}
+
+// GetIncident returns an incident of the organization with its timeline
+func (s *IncidentService) GetIncident(organizationUUID, id string) (*domain.Incident, error) {
+	incident, err := s.getIncident(organizationUUID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	timeline, err := s.repo.ListTimeline(incident.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incident timeline: %w", err)
+	}
+	incident.Timeline = timeline
+
+	return incident, nil
+}
+
+func (s *IncidentService) getIncident(organizationUUID, id string) (*domain.Incident, error) {
+	incident, err := s.repo.GetByID(organizationUUID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incident: %w", err)
+	}
+	if incident == nil {
+		return nil, &domain.NotFoundError{Resource: "incident", ID: id}
+	}
+	return incident, nil
+}
+
+// ListIncidents returns the incidents of the organization, most recent first
+func (s *IncidentService) ListIncidents(organizationUUID string, filter domain.IncidentFilter) ([]domain.Incident, int, error) {
+	if filter.Size > 100 {
+		filter.Size = 100
+	}
+	return s.repo.List(organizationUUID, filter)
+}
+
+// UpdateIncident changes the title, description, service or severity of an incident
+func (s *IncidentService) UpdateIncident(organizationUUID, id string, req domain.UpdateIncidentRequest, actor string) (*domain.Incident, error) {
+	incident, err := s.getIncident(organizationUUID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []string
+	if req.Title != nil {
+		title := strings.TrimSpace(*req.Title)
+		if title == "" || len(title) > maxIncidentTitleLength {
+			return nil, &domain.ValidationError{Field: "title", Message: fmt.Sprintf("must have 1 to %d characters", maxIncidentTitleLength)}
+		}
+		if title != incident.Title {
+			incident.Title = title
+			changes = append(changes, "title")
+		}
+	}
+	if req.Description != nil && strings.TrimSpace(*req.Description) != incident.Description {
+		incident.Description = strings.TrimSpace(*req.Description)
+		changes = append(changes, "description")
+	}
+	if req.Severity != nil && *req.Severity != incident.Severity {
+		if !incidentSeverities[*req.Severity] {
+			return nil, &domain.ValidationError{Field: "severity", Message: "must be critical, high, medium or low"}
+		}
+		changes = append(changes, fmt.Sprintf("severity %s → %s", incident.Severity, *req.Severity))
+		incident.Severity = *req.Severity
+	}
+	if req.ServiceName != nil && strings.TrimSpace(*req.ServiceName) != incident.ServiceName {
+		previous := incident.ServiceName
+		if err := s.linkService(incident, *req.ServiceName); err != nil {
+			return nil, err
+		}
+		changes = append(changes, fmt.Sprintf("service %s → %s", orNone(previous), orNone(incident.ServiceName)))
+	}
+
+	if len(changes) == 0 {
+		return s.GetIncident(organizationUUID, id)
+	}
+
+	entry := &domain.IncidentTimelineEntry{
+		IncidentID: incident.ID,
+		Type:       domain.IncidentEntryUpdated,
+		Message:    "Updated " + strings.Join(changes, ", "),
+		Author:     actor,
+	}
+	if err := s.repo.Update(incident, entry); err != nil {
+		return nil, fmt.Errorf("failed to update incident: %w", err)
+	}
+
+	return s.GetIncident(organizationUUID, id)
+}
+
+// AcknowledgeIncident records that someone is working on an open incident
+func (s *IncidentService) AcknowledgeIncident(organizationUUID, id string, req domain.IncidentTransitionRequest, actor string) (*domain.Incident, error) {
+	incident, err := s.getIncident(organizationUUID, id)
+	if err != nil {
+		return nil, err
+	}
+	if incident.Status != domain.IncidentStatusOpen {
+		return nil, &domain.InvalidStateError{Resource: "incident", ID: incident.ID, State: incident.Status}
+	}
+
+	at, err := transitionTime(incident, req.At)
+	if err != nil {
+		return nil, err
+	}
+
+	incident.Status = domain.IncidentStatusAcknowledged
+	incident.AcknowledgedBy = actor
+	incident.AcknowledgedAt = &at
+
+	return s.transition(incident, []string{domain.IncidentStatusOpen}, domain.IncidentEntryAcknowledged, transitionMessage("Incident acknowledged", req.Message), actor, at)
+}
+
+// ResolveIncident closes an open or acknowledged incident; its MTTR counts from then on
+func (s *IncidentService) ResolveIncident(organizationUUID, id string, req domain.IncidentTransitionRequest, actor string) (*domain.Incident, error) {
+	incident, err := s.getIncident(organizationUUID, id)
+	if err != nil {
+		return nil, err
+	}
+	if incident.Status == domain.IncidentStatusResolved {
+		return nil, &domain.InvalidStateError{Resource: "incident", ID: incident.ID, State: incident.Status}
+	}
+
+	at, err := transitionTime(incident, req.At)
+	if err != nil {
+		return nil, err
+	}
+
+	incident.Status = domain.IncidentStatusResolved
+	incident.ResolvedBy = actor
+	incident.ResolvedAt = &at
+
+	return s.transition(incident, []string{domain.IncidentStatusOpen, domain.IncidentStatusAcknowledged}, domain.IncidentEntryResolved, transitionMessage("Incident resolved", req.Message), actor, at)
+}
+
+// ReopenIncident reopens a resolved incident; it keeps its opening time
+func (s *IncidentService) ReopenIncident(organizationUUID, id string, req domain.IncidentTransitionRequest, actor string) (*domain.Incident, error) {
+	incident, err := s.getIncident(organizationUUID, id)
+	if err != nil {
+		return nil, err
+	}
+	if incident.Status != domain.IncidentStatusResolved {
+		return nil, &domain.InvalidStateError{Resource: "incident", ID: incident.ID, State: incident.Status}
+	}
+
+	incident.Status = domain.IncidentStatusOpen
+	incident.ResolvedBy = ""
+	incident.ResolvedAt = nil
+	if incident.AcknowledgedAt != nil {
+		incident.Status = domain.IncidentStatusAcknowledged
+	}
+
+	return s.transition(incident, []string{domain.IncidentStatusResolved}, domain.IncidentEntryReopened, transitionMessage("Incident reopened", req.Message), actor, time.Now())
+}
+
+func (s *IncidentService) transition(incident *domain.Incident, from []string, entryType, message, actor string, at time.Time) (*domain.Incident, error) {
+	entry := &domain.IncidentTimelineEntry{
+		IncidentID: incident.ID,
+		Type:       entryType,
+		Message:    message,
+		Author:     actor,
+		CreatedAt:  at,
+	}
+
+	ok, err := s.repo.Transition(incident, from, entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update incident: %w", err)
+	}
+	if !ok {
+		// Another request changed the status first
+		current, err := s.getIncident(incident.OrganizationUUID, incident.ID)
+		if err != nil {
+			return nil, err
+		}
+		return nil, &domain.InvalidStateError{Resource: "incident", ID: incident.ID, State: current.Status}
+	}
+
+	s.log.Infow("Incident status changed", "organizationUUID", incident.OrganizationUUID, "incident", incident.ID, "status", incident.Status, "actor", actor)
+
+	return s.GetIncident(incident.OrganizationUUID, incident.ID)
+}
+
+// AddNote adds a note to the timeline of an incident
+func (s *IncidentService) AddNote(organizationUUID, id, message, actor string) (*domain.IncidentTimelineEntry, error) {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return nil, &domain.ValidationError{Field: "message", Message: "is required"}
+	}
+
+	incident, err := s.getIncident(organizationUUID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &domain.IncidentTimelineEntry{
+		IncidentID: incident.ID,
+		Type:       domain.IncidentEntryNote,
+		Message:    message,
+		Author:     actor,
+	}
+	if err := s.repo.AddTimelineEntry(entry); err != nil {
+		return nil, fmt.Errorf("failed to add incident note: %w", err)
+	}
+	return entry, nil
+}
+
+// RecordSignal opens the incident of an alert, adds to its timeline while it fires again, and
+// resolves it when the alert resolves. Alerts of services outside the catalog open incidents
+// without service.
+func (s *IncidentService) RecordSignal(organizationUUID string, signal domain.IncidentSignal) (*domain.Incident, error) {
+	if signal.ExternalID == "" {
+		return nil, &domain.ValidationError{Field: "externalId", Message: "is required"}
+	}
+
+	incident, err := s.repo.GetUnresolvedByExternalID(organizationUUID, signal.Source, signal.ExternalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incident: %w", err)
+	}
+	actor := signal.Source
+
+	if incident == nil {
+		if signal.ResolvedAt != nil {
+			// Resolution of an alert whose incident was resolved (or never opened)
+			return nil, nil
+		}
+
+		req := domain.CreateIncidentRequest{
+			Title:       signal.Title,
+			Description: signal.Description,
+			Severity:    signal.Severity,
+		}
+		if !signal.StartedAt.IsZero() && signal.StartedAt.Before(time.Now()) {
+			req.OpenedAt = &signal.StartedAt
+		}
+		if !incidentSeverities[req.Severity] {
+			req.Severity = domain.IncidentSeverityMedium
+		}
+		if signal.ServiceName != "" {
+			if svc, err := s.serviceRepo.GetByName(signal.ServiceName); err == nil && svc != nil {
+				req.ServiceName = svc.Name
+			}
+		}
+
+		incident, err = s.createIncident(organizationUUID, req, signal.Source, signal.ExternalID, actor)
+		var conflict *domain.ConflictError
+		if !errors.As(err, &conflict) {
+			return incident, err
+		}
+
+		// Opened meanwhile by a concurrent delivery of the same alert
+		incident, err = s.repo.GetUnresolvedByExternalID(organizationUUID, signal.Source, signal.ExternalID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get incident: %w", err)
+		}
+		if incident == nil {
+			return nil, fmt.Errorf("incident of alert %s was resolved meanwhile", signal.ExternalID)
+		}
+	}
+
+	if signal.ResolvedAt != nil {
+		return s.ResolveIncident(organizationUUID, incident.ID, domain.IncidentTransitionRequest{
+			Message: "Alert resolved",
+			At:      signal.ResolvedAt,
+		}, actor)
+	}
+
+	if _, err := s.AddNote(organizationUUID, incident.ID, "Alert firing: "+signal.Title, actor); err != nil {
+		return nil, err
+	}
+	return incident, nil
+}
+
+// GetMetrics summarizes the incidents of the organization, a service or a squad over the last `days` days
+func (s *IncidentService) GetMetrics(organizationUUID, scope, scopeKey string, days int) (*domain.IncidentMetrics, error) {
+	if days < 1 {
+		days = defaultIncidentMetricsDays
+	}
+	if scope == "" || scope == domain.DORAScopeOrganization {
+		scope = domain.DORAScopeOrganization
+		scopeKey = organizationUUID
+	}
+
+	windowStart, windowEnd := doraWindow(days)
+	incidents, err := s.repo.ListActive(organizationUUID, windowStart, windowEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incidents: %w", err)
+	}
+
+	metrics := &domain.IncidentMetrics{
+		Scope:            scope,
+		ScopeKey:         scopeKey,
+		Days:             days,
+		WindowStart:      windowStart,
+		WindowEnd:        windowEnd,
+		OpenedBySeverity: map[string]int{},
+	}
+	tallyIncidentMetrics(metrics, incidents)
+
+	return metrics, nil
+}
+
+// tallyIncidentMetrics counts the incidents of the scope of the metrics over their window, and
+// averages the minutes from opening to resolution (MTTR) and to acknowledgement (MTTA) of those
+// resolved or acknowledged within it
+func tallyIncidentMetrics(metrics *domain.IncidentMetrics, incidents []domain.Incident) {
+	scope, scopeKey := metrics.Scope, metrics.ScopeKey
+	windowStart, windowEnd := metrics.WindowStart, metrics.WindowEnd
+
+	var restoreTotal, acknowledgeTotal float64
+	for _, incident := range incidents {
+		switch scope {
+		case domain.DORAScopeService:
+			if incident.ServiceName != scopeKey {
+				continue
+			}
+		case domain.DORAScopeSquad:
+			if incident.Squad != scopeKey {
+				continue
+			}
+		}
+
+		if incident.Status != domain.IncidentStatusResolved {
+			metrics.Open++
+		}
+		if inWindow(incident.OpenedAt, windowStart, windowEnd) {
+			metrics.Opened++
+			metrics.OpenedBySeverity[incident.Severity]++
+		}
+		if incident.ResolvedAt != nil && inWindow(*incident.ResolvedAt, windowStart, windowEnd) {
+			metrics.Resolved++
+			restoreTotal += incident.ResolvedAt.Sub(incident.OpenedAt).Minutes()
+		}
+		if incident.AcknowledgedAt != nil && inWindow(*incident.AcknowledgedAt, windowStart, windowEnd) {
+			metrics.Acknowledged++
+			acknowledgeTotal += incident.AcknowledgedAt.Sub(incident.OpenedAt).Minutes()
+		}
+	}
+
+	if metrics.Resolved > 0 {
+		metrics.MTTRMinutes = restoreTotal / float64(metrics.Resolved)
+	}
+	if metrics.Acknowledged > 0 {
+		metrics.MTTAMinutes = acknowledgeTotal / float64(metrics.Acknowledged)
+	}
+}
+
+// RestoreEvents returns the incidents resolved between start and end as DORA restores. ok is false
+// when the organization has no incident in the range, so callers can fall back to other sources.
+func (s *IncidentService) RestoreEvents(organizationUUID string, start, end time.Time) ([]domain.RestoreEvent, bool, error) {
+	incidents, err := s.repo.ListActive(organizationUUID, start, end)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return restoreEvents(incidents, start, end), len(incidents) > 0, nil
+}
+
+// restoreEvents converts the incidents resolved between start and end
+func restoreEvents(incidents []domain.Incident, start, end time.Time) []domain.RestoreEvent {
+	var events []domain.RestoreEvent
+	for _, incident := range incidents {
+		if incident.ResolvedAt == nil || !inWindow(*incident.ResolvedAt, start, end) {
+			continue
+		}
+		events = append(events, domain.RestoreEvent{
+			Service:    incident.ServiceName,
+			Source:     "incident",
+			Name:       incident.Title,
+			StartedAt:  incident.OpenedAt,
+			ResolvedAt: *incident.ResolvedAt,
+		})
+	}
+	return events
+}
+
+// transitionTime is the time of an acknowledgement or resolution: now, or a past time given by the
+// caller that must not precede the opening
+func transitionTime(incident *domain.Incident, at *time.Time) (time.Time, error) {
+	now := time.Now()
+	if at == nil {
+		return now, nil
+	}
+	if at.After(now) {
+		return time.Time{}, &domain.ValidationError{Field: "at", Message: "must not be in the future"}
+	}
+	if at.Before(incident.OpenedAt) {
+		return time.Time{}, &domain.ValidationError{Field: "at", Message: "must not be before the incident was opened"}
+	}
+	return *at, nil
+}
+
+func transitionMessage(message, detail string) string {
+	if detail = strings.TrimSpace(detail); detail != "" {
+		return message + ": " + detail
+	}
+	return message
+}
+
+func inWindow(t, start, end time.Time) bool {
+	return !t.Before(start) && !t.After(end)
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+)
+
+var (
+	incidentWindowStart = time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	incidentWindowEnd   = time.Date(2026, time.March, 31, 0, 0, 0, 0, time.UTC)
+)
+
+// testIncident is opened at the given day and hour of the window; ackAfter and resolveAfter are
+// how long after the opening it was acknowledged and resolved (0 when it was not)
+func testIncident(service, squad, severity string, day, hour int, ackAfter, resolveAfter time.Duration) domain.Incident {
+	incident := domain.Incident{
+		ServiceName: service,
+		Squad:       squad,
+		Severity:    severity,
+		Status:      domain.IncidentStatusOpen,
+		Title:       service + " incident",
+		OpenedAt:    time.Date(2026, time.March, day, hour, 0, 0, 0, time.UTC),
+	}
+	if ackAfter > 0 {
+		at := incident.OpenedAt.Add(ackAfter)
+		incident.AcknowledgedAt = &at
+		incident.Status = domain.IncidentStatusAcknowledged
+	}
+	if resolveAfter > 0 {
+		at := incident.OpenedAt.Add(resolveAfter)
+		incident.ResolvedAt = &at
+		incident.Status = domain.IncidentStatusResolved
+	}
+	return incident
+}
+
+func TestTallyIncidentMetrics(t *testing.T) {
+	incidents := []domain.Incident{
+		testIncident("checkout", "shop", "critical", 2, 10, 10*time.Minute, time.Hour),
+		testIncident("checkout", "shop", "high", 5, 10, 20*time.Minute, 3*time.Hour),
+		testIncident("search", "discovery", "high", 10, 10, 0, 0),
+		testIncident("search", "discovery", "low", 12, 10, 5*time.Minute, 0),
+		// Opened before the window, acknowledged and resolved within it
+		testIncident("checkout", "shop", "medium", 0, 22, 4*time.Hour, 6*time.Hour),
+	}
+
+	tests := []struct {
+		name     string
+		scope    string
+		scopeKey string
+		want     domain.IncidentMetrics
+	}{
+		{
+			name:  "organization",
+			scope: domain.DORAScopeOrganization,
+			want: domain.IncidentMetrics{
+				Opened: 4, Resolved: 3, Open: 2, Acknowledged: 4,
+				// (60 + 180 + 360) / 3 and (10 + 20 + 5 + 240) / 4
+				MTTRMinutes: 200, MTTAMinutes: 68.75,
+				OpenedBySeverity: map[string]int{"critical": 1, "high": 2, "low": 1},
+			},
+		},
+		{
+			name:     "service",
+			scope:    domain.DORAScopeService,
+			scopeKey: "checkout",
+			want: domain.IncidentMetrics{
+				Opened: 2, Resolved: 3, Acknowledged: 3,
+				MTTRMinutes: 200, MTTAMinutes: 90,
+				OpenedBySeverity: map[string]int{"critical": 1, "high": 1},
+			},
+		},
+		{
+			// MTTR and MTTA stay 0 without samples
+			name:     "squad without resolutions",
+			scope:    domain.DORAScopeSquad,
+			scopeKey: "discovery",
+			want: domain.IncidentMetrics{
+				Opened: 2, Open: 2, Acknowledged: 1,
+				MTTAMinutes:      5,
+				OpenedBySeverity: map[string]int{"high": 1, "low": 1},
+			},
+		},
+		{
+			name:     "unknown service",
+			scope:    domain.DORAScopeService,
+			scopeKey: "billing",
+			want:     domain.IncidentMetrics{OpenedBySeverity: map[string]int{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := &domain.IncidentMetrics{
+				Scope:            tt.scope,
+				ScopeKey:         tt.scopeKey,
+				WindowStart:      incidentWindowStart,
+				WindowEnd:        incidentWindowEnd,
+				OpenedBySeverity: map[string]int{},
+			}
+			tallyIncidentMetrics(metrics, incidents)
+
+			if metrics.Opened != tt.want.Opened || metrics.Resolved != tt.want.Resolved || metrics.Open != tt.want.Open || metrics.Acknowledged != tt.want.Acknowledged {
+				t.Errorf("counts are opened %d, resolved %d, open %d, acknowledged %d; want %d, %d, %d, %d",
+					metrics.Opened, metrics.Resolved, metrics.Open, metrics.Acknowledged,
+					tt.want.Opened, tt.want.Resolved, tt.want.Open, tt.want.Acknowledged)
+			}
+			if metrics.MTTRMinutes != tt.want.MTTRMinutes || metrics.MTTAMinutes != tt.want.MTTAMinutes {
+				t.Errorf("MTTR %v and MTTA %v, want %v and %v", metrics.MTTRMinutes, metrics.MTTAMinutes, tt.want.MTTRMinutes, tt.want.MTTAMinutes)
+			}
+			if len(metrics.OpenedBySeverity) != len(tt.want.OpenedBySeverity) {
+				t.Fatalf("opened by severity is %v, want %v", metrics.OpenedBySeverity, tt.want.OpenedBySeverity)
+			}
+			for severity, count := range tt.want.OpenedBySeverity {
+				if metrics.OpenedBySeverity[severity] != count {
+					t.Errorf("opened by severity is %v, want %v", metrics.OpenedBySeverity, tt.want.OpenedBySeverity)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestRestoreEvents(t *testing.T) {
+	incidents := []domain.Incident{
+		testIncident("checkout", "shop", "critical", 2, 10, 0, time.Hour),
+		testIncident("search", "discovery", "high", 10, 10, 0, 0),
+		// Resolved after the range
+		testIncident("search", "discovery", "low", 30, 23, 0, 2*time.Hour),
+	}
+
+	events := restoreEvents(incidents, incidentWindowStart, incidentWindowEnd)
+	if len(events) != 1 {
+		t.Fatalf("got %d restore events, want 1: %+v", len(events), events)
+	}
+
+	event := events[0]
+	if event.Service != "checkout" || event.Source != "incident" || event.Name != "checkout incident" {
+		t.Errorf("restore event is %+v", event)
+	}
+	if event.ResolvedAt.Sub(event.StartedAt) != time.Hour {
+		t.Errorf("restore took %v, want 1h", event.ResolvedAt.Sub(event.StartedAt))
+	}
+
+	if events := restoreEvents(nil, incidentWindowStart, incidentWindowEnd); len(events) != 0 {
+		t.Errorf("no incidents gave %d restore events", len(events))
+	}
+}
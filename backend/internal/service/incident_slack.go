@@ -0,0 +1,209 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/google/uuid"
+)
+
+// slackRequestMaxAge is how old a signed Slack request may be, to prevent replays
+const slackRequestMaxAge = 5 * time.Minute
+
+const slackIncidentHelp = "Usage:\n" +
+	"• `/incident open <service|-> [critical|high|medium|low] <title>` opens an incident\n" +
+	"• `/incident ack <id> [message]` acknowledges an incident\n" +
+	"• `/incident resolve <id> [message]` resolves an incident\n" +
+	"• `/incident note <id> <message>` adds a note to the timeline\n" +
+	"• `/incident list` lists the unresolved incidents"
+
+// VerifySlackRequest checks the signature Slack sends with each slash command against the signing
+// secret of the organization's Slack integration
+// (https://api.slack.com/authentication/verifying-requests-from-slack)
+func (s *IncidentService) VerifySlackRequest(organizationUUID, timestamp, signature string, body []byte) error {
+	if _, err := uuid.Parse(organizationUUID); err != nil {
+		return &domain.UnauthorizedError{Message: "Slack commands are not enabled for this organization"}
+	}
+
+	config, err := s.integrationService.GetSlackConfig(organizationUUID)
+	if err != nil {
+		return fmt.Errorf("failed to get Slack config: %w", err)
+	}
+	if config == nil || config.SigningSecret == "" {
+		return &domain.UnauthorizedError{Message: "Slack commands are not enabled for this organization"}
+	}
+
+	return verifySlackSignature(config.SigningSecret, timestamp, signature, body, time.Now())
+}
+
+// verifySlackSignature checks the v0 signature of a request body, and that its timestamp is
+// within slackRequestMaxAge of now
+func verifySlackSignature(signingSecret, timestamp, signature string, body []byte, now time.Time) error {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return &domain.UnauthorizedError{Message: "invalid Slack request timestamp"}
+	}
+	age := now.Sub(time.Unix(ts, 0))
+	if age > slackRequestMaxAge || age < -slackRequestMaxAge {
+		return &domain.UnauthorizedError{Message: "Slack request is too old"}
+	}
+
+	mac := hmac.New(sha256.New, []byte(signingSecret))
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return &domain.UnauthorizedError{Message: "invalid Slack request signature"}
+	}
+
+	return nil
+}
+
+// HandleSlackCommand runs an /incident slash command. Errors of the command itself are replied to
+// the caller; only unexpected errors are returned.
+func (s *IncidentService) HandleSlackCommand(organizationUUID string, cmd domain.SlackCommand) (*domain.SlackCommandResponse, error) {
+	actor := "slack:" + cmd.UserName
+	if cmd.UserName == "" {
+		actor = "slack:" + cmd.UserID
+	}
+
+	args := strings.Fields(cmd.Text)
+	if len(args) == 0 {
+		return slackReply(slackIncidentHelp), nil
+	}
+
+	var (
+		incident *domain.Incident
+		text     string
+		err      error
+	)
+	switch strings.ToLower(args[0]) {
+	case "open":
+		incident, err = s.slackOpen(organizationUUID, args[1:], actor)
+		if err == nil {
+			text = fmt.Sprintf("🚨 <@%s> opened incident `%s`", cmd.UserID, incident.ID)
+		}
+	case "ack", "acknowledge":
+		if len(args) < 2 {
+			return slackReply("Usage: `/incident ack <id> [message]`"), nil
+		}
+		incident, err = s.AcknowledgeIncident(organizationUUID, args[1], domain.IncidentTransitionRequest{Message: strings.Join(args[2:], " ")}, actor)
+		if err == nil {
+			text = fmt.Sprintf("👀 <@%s> acknowledged incident `%s`", cmd.UserID, incident.ID)
+		}
+	case "resolve":
+		if len(args) < 2 {
+			return slackReply("Usage: `/incident resolve <id> [message]`"), nil
+		}
+		incident, err = s.ResolveIncident(organizationUUID, args[1], domain.IncidentTransitionRequest{Message: strings.Join(args[2:], " ")}, actor)
+		if err == nil {
+			text = fmt.Sprintf("✅ <@%s> resolved incident `%s` after %s", cmd.UserID, incident.ID, incident.ResolvedAt.Sub(incident.OpenedAt).Round(time.Minute))
+		}
+	case "note":
+		if len(args) < 3 {
+			return slackReply("Usage: `/incident note <id> <message>`"), nil
+		}
+		if _, err := s.AddNote(organizationUUID, args[1], strings.Join(args[2:], " "), actor); err != nil {
+			return slackCommandError(err)
+		}
+		return slackReply(fmt.Sprintf("Note added to incident `%s`", args[1])), nil
+	case "list":
+		return s.slackList(organizationUUID)
+	default:
+		return slackReply(slackIncidentHelp), nil
+	}
+
+	if err != nil {
+		return slackCommandError(err)
+	}
+
+	return &domain.SlackCommandResponse{
+		ResponseType: "in_channel",
+		Text:         text + "\n" + slackIncidentSummary(incident),
+	}, nil
+}
+
+// slackOpen opens an incident from `<service|-> [severity] <title>`
+func (s *IncidentService) slackOpen(organizationUUID string, args []string, actor string) (*domain.Incident, error) {
+	req, err := parseSlackOpen(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.createIncident(organizationUUID, req, domain.IncidentSourceSlack, "", actor)
+}
+
+// parseSlackOpen reads the arguments of `/incident open`; a severity is only taken when a title
+// follows it
+func parseSlackOpen(args []string) (domain.CreateIncidentRequest, error) {
+	if len(args) < 2 {
+		return domain.CreateIncidentRequest{}, &domain.ValidationError{Field: "command", Message: "usage: /incident open <service|-> [critical|high|medium|low] <title>"}
+	}
+
+	req := domain.CreateIncidentRequest{}
+	if args[0] != "-" {
+		req.ServiceName = args[0]
+	}
+	args = args[1:]
+	if len(args) > 1 && incidentSeverities[strings.ToLower(args[0])] {
+		req.Severity = strings.ToLower(args[0])
+		args = args[1:]
+	}
+	req.Title = strings.Join(args, " ")
+
+	return req, nil
+}
+
+func (s *IncidentService) slackList(organizationUUID string) (*domain.SlackCommandResponse, error) {
+	var lines []string
+	for _, status := range []string{domain.IncidentStatusOpen, domain.IncidentStatusAcknowledged} {
+		incidents, _, err := s.ListIncidents(organizationUUID, domain.IncidentFilter{Status: status, Size: 20})
+		if err != nil {
+			return nil, err
+		}
+		for _, incident := range incidents {
+			lines = append(lines, slackIncidentSummary(&incident))
+		}
+	}
+
+	if len(lines) == 0 {
+		return slackReply("No unresolved incidents 🎉"), nil
+	}
+	return slackReply(strings.Join(lines, "\n")), nil
+}
+
+func slackIncidentSummary(incident *domain.Incident) string {
+	return fmt.Sprintf("`%s` [%s] *%s* (%s, %s) opened %s by %s",
+		incident.ID,
+		strings.ToUpper(incident.Severity),
+		incident.Title,
+		orNone(incident.ServiceName),
+		incident.Status,
+		incident.OpenedAt.Format("2006-01-02 15:04 MST"),
+		incident.OpenedBy,
+	)
+}
+
+// slackCommandError replies the errors the caller can fix and returns the others
+func slackCommandError(err error) (*domain.SlackCommandResponse, error) {
+	var validationErr *domain.ValidationError
+	var notFoundErr *domain.NotFoundError
+	var invalidStateErr *domain.InvalidStateError
+	switch {
+	case errors.As(err, &validationErr), errors.As(err, &notFoundErr), errors.As(err, &invalidStateErr):
+		return slackReply("⚠️ " + err.Error()), nil
+	default:
+		return nil, err
+	}
+}
+
+func slackReply(text string) *domain.SlackCommandResponse {
+	return &domain.SlackCommandResponse{ResponseType: "ephemeral", Text: text}
+}
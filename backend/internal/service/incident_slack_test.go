@@ -0,0 +1,137 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/PlatifyX/platifyx-core/pkg/logger"
+)
+
+// slackSignature signs a body the way Slack does
+func slackSignature(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+	return "v0=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifySlackSignature(t *testing.T) {
+	const secret = "8f742231b10e8888abcd99yyyzzz85a5"
+	body := []byte("token=x&team_id=T1&command=%2Fincident&text=list&user_id=U1&user_name=ada")
+	now := time.Unix(1_773_000_000, 0)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	tests := []struct {
+		name      string
+		timestamp string
+		signature string
+		body      []byte
+		now       time.Time
+		wantErr   bool
+	}{
+		{name: "valid", timestamp: timestamp, signature: slackSignature(secret, timestamp, body), body: body, now: now},
+		{name: "clock skew within the limit", timestamp: timestamp, signature: slackSignature(secret, timestamp, body), body: body, now: now.Add(slackRequestMaxAge - time.Second)},
+		{name: "replayed", timestamp: timestamp, signature: slackSignature(secret, timestamp, body), body: body, now: now.Add(slackRequestMaxAge + time.Second), wantErr: true},
+		{name: "from the future", timestamp: timestamp, signature: slackSignature(secret, timestamp, body), body: body, now: now.Add(-slackRequestMaxAge - time.Second), wantErr: true},
+		{name: "invalid timestamp", timestamp: "yesterday", signature: slackSignature(secret, "yesterday", body), body: body, now: now, wantErr: true},
+		{name: "tampered body", timestamp: timestamp, signature: slackSignature(secret, timestamp, body), body: append([]byte("x"), body...), now: now, wantErr: true},
+		{name: "other secret", timestamp: timestamp, signature: slackSignature("other", timestamp, body), body: body, now: now, wantErr: true},
+		{name: "signature of another timestamp", timestamp: timestamp, signature: slackSignature(secret, strconv.FormatInt(now.Unix()-1, 10), body), body: body, now: now, wantErr: true},
+		{name: "missing signature", timestamp: timestamp, body: body, now: now, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifySlackSignature(secret, tt.timestamp, tt.signature, tt.body, tt.now)
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("verifySlackSignature: %v", err)
+				}
+				return
+			}
+			if !isUnauthorized(err) {
+				t.Errorf("got %v, want an unauthorized error", err)
+			}
+		})
+	}
+}
+
+func TestVerifySlackRequestRejectsInvalidOrganization(t *testing.T) {
+	s := NewIncidentService(nil, nil, nil, logger.NewLogger("development"))
+
+	if err := s.VerifySlackRequest("acme", "0", "v0=", nil); !isUnauthorized(err) {
+		t.Errorf("malformed organization returned %v, want an unauthorized error", err)
+	}
+}
+
+func TestParseSlackOpen(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    domain.CreateIncidentRequest
+		wantErr bool
+	}{
+		{name: "service, severity and title", text: "checkout critical Payments failing", want: domain.CreateIncidentRequest{ServiceName: "checkout", Severity: "critical", Title: "Payments failing"}},
+		{name: "severity in capitals", text: "checkout HIGH Slow pages", want: domain.CreateIncidentRequest{ServiceName: "checkout", Severity: "high", Title: "Slow pages"}},
+		{name: "no service", text: "- low Typo on the status page", want: domain.CreateIncidentRequest{Severity: "low", Title: "Typo on the status page"}},
+		{name: "no severity", text: "search Index is stale", want: domain.CreateIncidentRequest{ServiceName: "search", Title: "Index is stale"}},
+		{name: "severity word as the whole title", text: "search critical", want: domain.CreateIncidentRequest{ServiceName: "search", Title: "critical"}},
+		{name: "unknown severity is part of the title", text: "search urgent Index is stale", want: domain.CreateIncidentRequest{ServiceName: "search", Title: "urgent Index is stale"}},
+		{name: "service only", text: "search", wantErr: true},
+		{name: "nothing", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSlackOpen(strings.Fields(tt.text))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parsed %+v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseSlackOpen: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("parsed %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestHandleSlackCommandReplies covers the commands answered without touching the incidents
+func TestHandleSlackCommandReplies(t *testing.T) {
+	s := NewIncidentService(nil, nil, nil, logger.NewLogger("development"))
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "no arguments", text: "  ", want: "Usage:"},
+		{name: "unknown command", text: "escalate 42", want: "Usage:"},
+		{name: "ack without id", text: "ack", want: "Usage: `/incident ack"},
+		{name: "resolve without id", text: "Resolve", want: "Usage: `/incident resolve"},
+		{name: "note without message", text: "note INC-1", want: "Usage: `/incident note"},
+		{name: "open without title", text: "open checkout", want: "⚠️ "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.HandleSlackCommand("org", domain.SlackCommand{Text: tt.text, UserID: "U1"})
+			if err != nil {
+				t.Fatalf("HandleSlackCommand: %v", err)
+			}
+			if resp.ResponseType != "ephemeral" || !strings.HasPrefix(resp.Text, tt.want) {
+				t.Errorf("reply is %s %q, want an ephemeral reply starting with %q", resp.ResponseType, resp.Text, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+)
+
+// The getters below return the cached client of an organization's integration, or nil when the
+// integration is not configured or disabled. Clients are keyed by the integration version, so an
+// updated config always builds a new client.
+
+func (s *IntegrationService) GetKubernetesService(organizationUUID string) (*KubernetesService, error) {
+	return s.GetKubernetesServiceByName(organizationUUID, "")
+}
+
+// GetKubernetesServiceByName returns the client of the named Kubernetes integration,
+// or of the first one when name is empty
+func (s *IntegrationService) GetKubernetesServiceByName(organizationUUID, name string) (*KubernetesService, error) {
+	integration, err := s.enabledIntegration(domain.IntegrationTypeKubernetes, organizationUUID, name)
+	if err != nil || integration == nil {
+		return nil, err
+	}
+
+	return getClient(s.clients, integrationClientKey(organizationUUID, integration, "kubernetes"), func() (*KubernetesService, error) {
+		config, err := s.kubernetesConfigOf(organizationUUID, integration)
+		if err != nil {
+			s.log.Errorw("Failed to unmarshal Kubernetes config", "error", err, "integration", integration.Name)
+			return nil, err
+		}
+		return NewKubernetesService(*config, s.log)
+	})
+}
+
+func (s *IntegrationService) GetAzureDevOpsService(organizationUUID string) (*AzureDevOpsService, error) {
+	integration, err := s.enabledIntegration(domain.IntegrationTypeAzureDevOps, organizationUUID, "")
+	if err != nil || integration == nil {
+		return nil, err
+	}
+
+	return getClient(s.clients, integrationClientKey(organizationUUID, integration, "azuredevops"), func() (*AzureDevOpsService, error) {
+		config, err := s.azureDevOpsConfigOf(organizationUUID, integration)
+		if err != nil {
+			s.log.Errorw("Failed to unmarshal Azure DevOps config", "error", err)
+			return nil, err
+		}
+		return NewAzureDevOpsService(*config, s.log), nil
+	})
+}
+
+func (s *IntegrationService) GetSonarQubeService(organizationUUID string) (*SonarQubeService, error) {
+	integration, err := s.enabledIntegration(domain.IntegrationTypeSonarQube, organizationUUID, "")
+	if err != nil || integration == nil {
+		return nil, err
+	}
+
+	return getClient(s.clients, integrationClientKey(organizationUUID, integration, "sonarqube"), func() (*SonarQubeService, error) {
+		config, err := s.sonarQubeConfigOf(organizationUUID, integration)
+		if err != nil {
+			s.log.Errorw("Failed to unmarshal SonarQube config", "error", err)
+			return nil, err
+		}
+		return NewSonarQubeService(*config, s.log), nil
+	})
+}
+
+func (s *IntegrationService) GetGrafanaService(organizationUUID string) (*GrafanaService, error) {
+	integration, err := s.enabledIntegration(domain.IntegrationTypeGrafana, organizationUUID, "")
+	if err != nil || integration == nil {
+		return nil, err
+	}
+
+	return getClient(s.clients, integrationClientKey(organizationUUID, integration, "grafana"), func() (*GrafanaService, error) {
+		config, err := s.grafanaConfigOf(organizationUUID, integration)
+		if err != nil {
+			s.log.Errorw("Failed to unmarshal Grafana config", "error", err)
+			return nil, err
+		}
+		return NewGrafanaService(*config, s.log), nil
+	})
+}
+
+// GetGitHubServiceByName returns the client of the named GitHub integration,
+// or of the first one when name is empty
+func (s *IntegrationService) GetGitHubServiceByName(organizationUUID, name string) (*GitHubService, error) {
+	integration, err := s.enabledIntegration(domain.IntegrationTypeGitHub, organizationUUID, name)
+	if err != nil || integration == nil {
+		return nil, err
+	}
+
+	return s.gitHubService(organizationUUID, integration)
+}
+
+// GetGitHubServiceByID returns the client of a GitHub integration of the organization
+func (s *IntegrationService) GetGitHubServiceByID(organizationUUID string, id int) (*GitHubService, error) {
+	integration, err := s.repo.GetByID(id, organizationUUID)
+	if err != nil {
+		return nil, err
+	}
+	if integration == nil || !integration.Enabled || integration.Type != string(domain.IntegrationTypeGitHub) {
+		return nil, nil
+	}
+
+	return s.gitHubService(organizationUUID, integration)
+}
+
+func (s *IntegrationService) gitHubService(organizationUUID string, integration *domain.Integration) (*GitHubService, error) {
+	return getClient(s.clients, integrationClientKey(organizationUUID, integration, "github"), func() (*GitHubService, error) {
+		config, err := s.gitHubConfigOf(organizationUUID, integration)
+		if err != nil {
+			s.log.Errorw("Failed to unmarshal GitHub config", "error", err, "integration", integration.Name)
+			return nil, err
+		}
+		return NewGitHubService(*config, s.log), nil
+	})
+}
+
+// GetGitLabServiceByName returns the client of the named GitLab integration,
+// or of the first one when name is empty
+func (s *IntegrationService) GetGitLabServiceByName(organizationUUID, name string) (*GitLabService, error) {
+	integration, err := s.enabledIntegration(domain.IntegrationTypeGitLab, organizationUUID, name)
+	if err != nil || integration == nil {
+		return nil, err
+	}
+
+	return s.gitLabService(organizationUUID, integration)
+}
+
+// GetGitLabServiceByID returns the client of a GitLab integration of the organization
+func (s *IntegrationService) GetGitLabServiceByID(organizationUUID string, id int) (*GitLabService, error) {
+	integration, err := s.repo.GetByID(id, organizationUUID)
+	if err != nil {
+		return nil, err
+	}
+	if integration == nil || !integration.Enabled || integration.Type != string(domain.IntegrationTypeGitLab) {
+		return nil, nil
+	}
+
+	return s.gitLabService(organizationUUID, integration)
+}
+
+func (s *IntegrationService) gitLabService(organizationUUID string, integration *domain.Integration) (*GitLabService, error) {
+	return getClient(s.clients, integrationClientKey(organizationUUID, integration, "gitlab"), func() (*GitLabService, error) {
+		config, err := s.gitLabConfigOf(organizationUUID, integration)
+		if err != nil {
+			s.log.Errorw("Failed to unmarshal GitLab config", "error", err, "integration", integration.Name)
+			return nil, err
+		}
+		return NewGitLabService(*config, s.log), nil
+	})
+}
+
+// ClientStats returns the hit/miss counters of the integration client cache
+func (s *IntegrationService) ClientStats(organizationUUID string) domain.IntegrationClientStats {
+	return s.clients.Stats(organizationUUID)
+}
+
+// enabledIntegration returns the enabled integration of a type with the given name,
+// or the first integration of that type when name is empty
+func (s *IntegrationService) enabledIntegration(integrationType domain.IntegrationType, organizationUUID, name string) (*domain.Integration, error) {
+	if name == "" {
+		integration, err := s.repo.GetByType(string(integrationType), organizationUUID)
+		if err != nil {
+			return nil, err
+		}
+		if integration == nil || !integration.Enabled {
+			return nil, nil
+		}
+		return integration, nil
+	}
+
+	integrations, err := s.repo.GetAllByType(string(integrationType), organizationUUID)
+	if err != nil {
+		s.log.Errorw("Failed to fetch integrations", "error", err, "type", integrationType)
+		return nil, err
+	}
+
+	for i := range integrations {
+		if integrations[i].Name == name && integrations[i].Enabled {
+			return &integrations[i], nil
+		}
+	}
+	return nil, nil
+}
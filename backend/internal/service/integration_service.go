@@ -3,6 +3,7 @@ package service
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/PlatifyX/platifyx-core/internal/domain"
 	"github.com/PlatifyX/platifyx-core/internal/repository"
@@ -10,14 +11,21 @@ import (
 )
 
 type IntegrationService struct {
-	repo *repository.IntegrationRepository
-	log  *logger.Logger
+	repo        *repository.IntegrationRepository
+	credentials *CredentialService
+	clients     *ClientRegistry
+	log         *logger.Logger
 }
 
-func NewIntegrationService(repo *repository.IntegrationRepository, log *logger.Logger) *IntegrationService {
+// NewIntegrationService creates the integration service. When credentials is nil,
+// secret config fields are stored without encryption. When clients is nil, integration
+// clients are built on every call instead of being cached.
+func NewIntegrationService(repo *repository.IntegrationRepository, credentials *CredentialService, clients *ClientRegistry, log *logger.Logger) *IntegrationService {
 	return &IntegrationService{
-		repo: repo,
-		log:  log,
+		repo:        repo,
+		credentials: credentials,
+		clients:     clients,
+		log:         log,
 	}
 }
 
@@ -67,6 +75,12 @@ func (s *IntegrationService) Create(integration *domain.Integration, organizatio
 		return err
 	}
 
+	config, err := s.sealConfig(organizationUUID, integration.Type, config)
+	if err != nil {
+		s.log.Errorw("Failed to seal integration credentials", "error", err)
+		return err
+	}
+
 	created, err := s.repo.Create(integration.Name, integration.Type, organizationUUID, integration.Enabled, config)
 	if err != nil {
 		s.log.Errorw("Failed to create integration", "error", err)
@@ -86,11 +100,36 @@ func (s *IntegrationService) Create(integration *domain.Integration, organizatio
 func (s *IntegrationService) Update(id int, organizationUUID string, enabled bool, config map[string]interface{}) error {
 	s.log.Infow("Updating integration", "id", id, "enabled", enabled, "organizationUUID", organizationUUID)
 
-	err := s.repo.Update(id, organizationUUID, enabled, config)
+	existing, err := s.repo.GetByID(id, organizationUUID)
+	if err != nil {
+		s.log.Errorw("Failed to fetch integration", "error", err, "id", id)
+		return err
+	}
+
+	// Secret fields sent back masked keep their stored value
+	var stored map[string]interface{}
+	if err := json.Unmarshal(existing.Config, &stored); err != nil {
+		s.log.Errorw("Failed to unmarshal stored config", "error", err, "id", id)
+		return err
+	}
+	for key, value := range config {
+		if value == domain.MaskedSecretValue {
+			config[key] = stored[key]
+		}
+	}
+
+	config, err = s.sealConfig(organizationUUID, existing.Type, config)
+	if err != nil {
+		s.log.Errorw("Failed to seal integration credentials", "error", err, "id", id)
+		return err
+	}
+
+	err = s.repo.Update(id, organizationUUID, enabled, config)
 	if err != nil {
 		s.log.Errorw("Failed to update integration", "error", err, "id", id)
 		return err
 	}
+	s.clients.InvalidateIntegration(organizationUUID, id)
 
 	s.log.Info("Integration updated successfully")
 	return nil
@@ -104,11 +143,101 @@ func (s *IntegrationService) Delete(id int, organizationUUID string) error {
 		s.log.Errorw("Failed to delete integration", "error", err, "id", id)
 		return err
 	}
+	s.clients.InvalidateIntegration(organizationUUID, id)
 
 	s.log.Info("Integration deleted successfully")
 	return nil
 }
 
+// MaskSecrets replaces the secret config fields of an integration before it is returned by the API
+func (s *IntegrationService) MaskSecrets(integration *domain.Integration) {
+	var config map[string]interface{}
+	if err := json.Unmarshal(integration.Config, &config); err != nil {
+		integration.Config = json.RawMessage("{}")
+		return
+	}
+
+	for _, field := range domain.IntegrationSecretFields[domain.IntegrationType(integration.Type)] {
+		if value, ok := config[field].(string); ok && value != "" {
+			config[field] = domain.MaskedSecretValue
+		}
+	}
+	for key, value := range config {
+		if str, ok := value.(string); ok && IsSealedValue(str) {
+			config[key] = domain.MaskedSecretValue
+		}
+	}
+
+	masked, err := json.Marshal(config)
+	if err != nil {
+		integration.Config = json.RawMessage("{}")
+		return
+	}
+	integration.Config = masked
+}
+
+// RotateCredentials creates a new data key for the organization and re-encrypts
+// every integration config with it. Plaintext configs stored before encryption
+// was enabled are sealed as well.
+func (s *IntegrationService) RotateCredentials(organizationUUID string) (int, error) {
+	if s.credentials == nil {
+		return 0, fmt.Errorf("credential encryption is not configured")
+	}
+
+	if _, err := s.credentials.RotateDataKey(organizationUUID); err != nil {
+		return 0, err
+	}
+
+	integrations, err := s.repo.GetAll(organizationUUID)
+	if err != nil {
+		return 0, err
+	}
+
+	reencrypted := 0
+	for _, integration := range integrations {
+		var config map[string]interface{}
+		if err := s.decodeConfig(organizationUUID, integration.Config, &config); err != nil {
+			return reencrypted, fmt.Errorf("failed to open integration %d: %w", integration.ID, err)
+		}
+
+		sealed, err := s.sealConfig(organizationUUID, integration.Type, config)
+		if err != nil {
+			return reencrypted, fmt.Errorf("failed to seal integration %d: %w", integration.ID, err)
+		}
+
+		if err := s.repo.Update(integration.ID, organizationUUID, integration.Enabled, sealed); err != nil {
+			return reencrypted, fmt.Errorf("failed to update integration %d: %w", integration.ID, err)
+		}
+		reencrypted++
+	}
+	s.clients.InvalidateOrganization(organizationUUID)
+
+	s.log.Infow("Integration credentials re-encrypted", "organizationUUID", organizationUUID, "count", reencrypted)
+	return reencrypted, nil
+}
+
+// decodeConfig decrypts the sealed fields of a stored config and unmarshals it
+func (s *IntegrationService) decodeConfig(organizationUUID string, raw json.RawMessage, v interface{}) error {
+	if s.credentials != nil {
+		opened, err := s.credentials.OpenJSON(organizationUUID, raw)
+		if err != nil {
+			return err
+		}
+		raw = opened
+	} else if strings.Contains(string(raw), sealedValuePrefix) {
+		return fmt.Errorf("integration config is encrypted but credential encryption is not configured")
+	}
+
+	return json.Unmarshal(raw, v)
+}
+
+func (s *IntegrationService) sealConfig(organizationUUID string, integrationType string, config map[string]interface{}) (map[string]interface{}, error) {
+	if s.credentials == nil {
+		return config, nil
+	}
+	return s.credentials.Seal(organizationUUID, integrationType, config)
+}
+
 func (s *IntegrationService) GetAzureDevOpsConfig(organizationUUID string) (*domain.AzureDevOpsConfig, error) {
 	integration, err := s.repo.GetByType(string(domain.IntegrationTypeAzureDevOps), organizationUUID)
 	if err != nil {
@@ -119,12 +248,21 @@ func (s *IntegrationService) GetAzureDevOpsConfig(organizationUUID string) (*dom
 		return nil, nil
 	}
 
-	var config domain.AzureDevOpsIntegrationConfig
-	if err := json.Unmarshal(integration.Config, &config); err != nil {
+	config, err := s.azureDevOpsConfigOf(organizationUUID, integration)
+	if err != nil {
 		s.log.Errorw("Failed to unmarshal Azure DevOps config", "error", err)
 		return nil, err
 	}
 
+	return config, nil
+}
+
+func (s *IntegrationService) azureDevOpsConfigOf(organizationUUID string, integration *domain.Integration) (*domain.AzureDevOpsConfig, error) {
+	var config domain.AzureDevOpsIntegrationConfig
+	if err := s.decodeConfig(organizationUUID, integration.Config, &config); err != nil {
+		return nil, err
+	}
+
 	return &domain.AzureDevOpsConfig{
 		Organization: config.Organization,
 		Project:      config.Project,
@@ -142,7 +280,7 @@ func (s *IntegrationService) GetAllAzureDevOpsConfigs(organizationUUID string) (
 	configs := make(map[string]*domain.AzureDevOpsConfig)
 	for _, integration := range integrations {
 		var config domain.AzureDevOpsIntegrationConfig
-		if err := json.Unmarshal(integration.Config, &config); err != nil {
+		if err := s.decodeConfig(organizationUUID, integration.Config, &config); err != nil {
 			s.log.Errorw("Failed to unmarshal Azure DevOps config", "error", err, "integration", integration.Name)
 			continue
 		}
@@ -167,12 +305,21 @@ func (s *IntegrationService) GetSonarQubeConfig(organizationUUID string) (*domai
 		return nil, nil
 	}
 
-	var config domain.SonarQubeIntegrationConfig
-	if err := json.Unmarshal(integration.Config, &config); err != nil {
+	config, err := s.sonarQubeConfigOf(organizationUUID, integration)
+	if err != nil {
 		s.log.Errorw("Failed to unmarshal SonarQube config", "error", err)
 		return nil, err
 	}
 
+	return config, nil
+}
+
+func (s *IntegrationService) sonarQubeConfigOf(organizationUUID string, integration *domain.Integration) (*domain.SonarQubeConfig, error) {
+	var config domain.SonarQubeIntegrationConfig
+	if err := s.decodeConfig(organizationUUID, integration.Config, &config); err != nil {
+		return nil, err
+	}
+
 	return &domain.SonarQubeConfig{
 		URL:   config.URL,
 		Token: config.Token,
@@ -189,7 +336,7 @@ func (s *IntegrationService) GetAllSonarQubeConfigs(organizationUUID string) (ma
 	configs := make(map[string]*domain.SonarQubeConfig)
 	for _, integration := range integrations {
 		var config domain.SonarQubeIntegrationConfig
-		if err := json.Unmarshal(integration.Config, &config); err != nil {
+		if err := s.decodeConfig(organizationUUID, integration.Config, &config); err != nil {
 			s.log.Errorw("Failed to unmarshal SonarQube config", "error", err, "integration", integration.Name)
 			continue
 		}
@@ -218,7 +365,7 @@ func (s *IntegrationService) GetAllAzureCloudConfigs(organizationUUID string) (m
 		}
 
 		var config domain.AzureCloudIntegrationConfig
-		if err := json.Unmarshal(integration.Config, &config); err != nil {
+		if err := s.decodeConfig(organizationUUID, integration.Config, &config); err != nil {
 			s.log.Errorw("Failed to unmarshal Azure Cloud config", "error", err, "integration", integration.Name)
 			continue
 		}
@@ -248,7 +395,7 @@ func (s *IntegrationService) GetAllGCPConfigs(organizationUUID string) (map[stri
 		}
 
 		var config domain.GCPCloudIntegrationConfig
-		if err := json.Unmarshal(integration.Config, &config); err != nil {
+		if err := s.decodeConfig(organizationUUID, integration.Config, &config); err != nil {
 			s.log.Errorw("Failed to unmarshal GCP config", "error", err, "integration", integration.Name)
 			continue
 		}
@@ -276,7 +423,7 @@ func (s *IntegrationService) GetAllAWSConfigs(organizationUUID string) (map[stri
 		}
 
 		var config domain.AWSCloudIntegrationConfig
-		if err := json.Unmarshal(integration.Config, &config); err != nil {
+		if err := s.decodeConfig(organizationUUID, integration.Config, &config); err != nil {
 			s.log.Errorw("Failed to unmarshal AWS config", "error", err, "integration", integration.Name)
 			continue
 		}
@@ -301,7 +448,7 @@ func (s *IntegrationService) GetAWSConfigByName(name string, organizationUUID st
 	for _, integration := range integrations {
 		if integration.Name == name && integration.Enabled {
 			var config domain.AWSCloudIntegrationConfig
-			if err := json.Unmarshal(integration.Config, &config); err != nil {
+			if err := s.decodeConfig(organizationUUID, integration.Config, &config); err != nil {
 				s.log.Errorw("Failed to unmarshal AWS config", "error", err, "integration", integration.Name)
 				return nil, err
 			}
@@ -327,12 +474,21 @@ func (s *IntegrationService) GetKubernetesConfig(organizationUUID string) (*doma
 		return nil, nil
 	}
 
-	var config domain.KubernetesIntegrationConfig
-	if err := json.Unmarshal(integration.Config, &config); err != nil {
+	config, err := s.kubernetesConfigOf(organizationUUID, integration)
+	if err != nil {
 		s.log.Errorw("Failed to unmarshal Kubernetes config", "error", err)
 		return nil, err
 	}
 
+	return config, nil
+}
+
+func (s *IntegrationService) kubernetesConfigOf(organizationUUID string, integration *domain.Integration) (*domain.KubernetesConfig, error) {
+	var config domain.KubernetesIntegrationConfig
+	if err := s.decodeConfig(organizationUUID, integration.Config, &config); err != nil {
+		return nil, err
+	}
+
 	return &domain.KubernetesConfig{
 		KubeConfig: config.KubeConfig,
 		Context:    config.Context,
@@ -352,16 +508,13 @@ func (s *IntegrationService) GetAllKubernetesConfigs(organizationUUID string) (m
 			continue
 		}
 
-		var config domain.KubernetesIntegrationConfig
-		if err := json.Unmarshal(integration.Config, &config); err != nil {
+		config, err := s.kubernetesConfigOf(organizationUUID, &integration)
+		if err != nil {
 			s.log.Errorw("Failed to unmarshal Kubernetes config", "error", err, "integration", integration.Name)
 			continue
 		}
 
-		configs[integration.Name] = &domain.KubernetesConfig{
-			KubeConfig: config.KubeConfig,
-			Context:    config.Context,
-		}
+		configs[integration.Name] = config
 	}
 
 	return configs, nil
@@ -377,12 +530,21 @@ func (s *IntegrationService) GetGrafanaConfig(organizationUUID string) (*domain.
 		return nil, nil
 	}
 
-	var config domain.GrafanaIntegrationConfig
-	if err := json.Unmarshal(integration.Config, &config); err != nil {
+	config, err := s.grafanaConfigOf(organizationUUID, integration)
+	if err != nil {
 		s.log.Errorw("Failed to unmarshal Grafana config", "error", err)
 		return nil, err
 	}
 
+	return config, nil
+}
+
+func (s *IntegrationService) grafanaConfigOf(organizationUUID string, integration *domain.Integration) (*domain.GrafanaConfig, error) {
+	var config domain.GrafanaIntegrationConfig
+	if err := s.decodeConfig(organizationUUID, integration.Config, &config); err != nil {
+		return nil, err
+	}
+
 	return &domain.GrafanaConfig{
 		URL:    config.URL,
 		APIKey: config.APIKey,
@@ -403,7 +565,7 @@ func (s *IntegrationService) GetAllGrafanaConfigs(organizationUUID string) (map[
 		}
 
 		var config domain.GrafanaIntegrationConfig
-		if err := json.Unmarshal(integration.Config, &config); err != nil {
+		if err := s.decodeConfig(organizationUUID, integration.Config, &config); err != nil {
 			s.log.Errorw("Failed to unmarshal Grafana config", "error", err, "integration", integration.Name)
 			continue
 		}
@@ -427,15 +589,27 @@ func (s *IntegrationService) GetGitHubConfig(organizationUUID string) (*domain.G
 		return nil, nil
 	}
 
-	var config domain.GitHubIntegrationConfig
-	if err := json.Unmarshal(integration.Config, &config); err != nil {
+	config, err := s.gitHubConfigOf(organizationUUID, integration)
+	if err != nil {
 		s.log.Errorw("Failed to unmarshal GitHub config", "error", err)
 		return nil, err
 	}
 
+	return config, nil
+}
+
+func (s *IntegrationService) gitHubConfigOf(organizationUUID string, integration *domain.Integration) (*domain.GitHubConfig, error) {
+	var config domain.GitHubIntegrationConfig
+	if err := s.decodeConfig(organizationUUID, integration.Config, &config); err != nil {
+		return nil, err
+	}
+
 	return &domain.GitHubConfig{
-		Token:        config.Token,
-		Organization: config.Organization,
+		Token:          config.Token,
+		Organization:   config.Organization,
+		AppID:          config.AppID,
+		PrivateKey:     config.PrivateKey,
+		InstallationID: config.InstallationID,
 	}, nil
 }
 
@@ -452,16 +626,13 @@ func (s *IntegrationService) GetAllGitHubConfigs(organizationUUID string) (map[s
 			continue
 		}
 
-		var config domain.GitHubIntegrationConfig
-		if err := json.Unmarshal(integration.Config, &config); err != nil {
+		config, err := s.gitHubConfigOf(organizationUUID, &integration)
+		if err != nil {
 			s.log.Errorw("Failed to unmarshal GitHub config", "error", err, "integration", integration.Name)
 			continue
 		}
 
-		configs[integration.Name] = &domain.GitHubConfig{
-			Token:        config.Token,
-			Organization: config.Organization,
-		}
+		configs[integration.Name] = config
 	}
 
 	return configs, nil
@@ -484,17 +655,14 @@ func (s *IntegrationService) GetGitHubConfigByName(name string, organizationUUID
 	for _, integration := range integrations {
 		s.log.Debugw("Checking integration", "name", integration.Name, "enabled", integration.Enabled, "matches", integration.Name == name)
 		if integration.Name == name && integration.Enabled {
-			var config domain.GitHubIntegrationConfig
-			if err := json.Unmarshal(integration.Config, &config); err != nil {
+			config, err := s.gitHubConfigOf(organizationUUID, &integration)
+			if err != nil {
 				s.log.Errorw("Failed to unmarshal GitHub config", "error", err, "integration", integration.Name)
 				return nil, err
 			}
 
 			s.log.Infow("Found matching GitHub integration", "name", name)
-			return &domain.GitHubConfig{
-				Token:        config.Token,
-				Organization: config.Organization,
-			}, nil
+			return config, nil
 		}
 	}
 
@@ -503,6 +671,63 @@ func (s *IntegrationService) GetGitHubConfigByName(name string, organizationUUID
 	return nil, nil
 }
 
+func (s *IntegrationService) GetGitLabConfig(organizationUUID string) (*domain.GitLabConfig, error) {
+	integration, err := s.repo.GetByType(string(domain.IntegrationTypeGitLab), organizationUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if integration == nil || !integration.Enabled {
+		return nil, nil
+	}
+
+	config, err := s.gitLabConfigOf(organizationUUID, integration)
+	if err != nil {
+		s.log.Errorw("Failed to unmarshal GitLab config", "error", err)
+		return nil, err
+	}
+
+	return config, nil
+}
+
+func (s *IntegrationService) gitLabConfigOf(organizationUUID string, integration *domain.Integration) (*domain.GitLabConfig, error) {
+	var config domain.GitLabIntegrationConfig
+	if err := s.decodeConfig(organizationUUID, integration.Config, &config); err != nil {
+		return nil, err
+	}
+
+	return &domain.GitLabConfig{
+		URL:   config.URL,
+		Token: config.Token,
+		Group: config.Group,
+	}, nil
+}
+
+func (s *IntegrationService) GetAllGitLabConfigs(organizationUUID string) (map[string]*domain.GitLabConfig, error) {
+	integrations, err := s.repo.GetAllByType(string(domain.IntegrationTypeGitLab), organizationUUID)
+	if err != nil {
+		s.log.Errorw("Failed to fetch GitLab integrations", "error", err)
+		return nil, err
+	}
+
+	configs := make(map[string]*domain.GitLabConfig)
+	for _, integration := range integrations {
+		if !integration.Enabled {
+			continue
+		}
+
+		config, err := s.gitLabConfigOf(organizationUUID, &integration)
+		if err != nil {
+			s.log.Errorw("Failed to unmarshal GitLab config", "error", err, "integration", integration.Name)
+			continue
+		}
+
+		configs[integration.Name] = config
+	}
+
+	return configs, nil
+}
+
 func (s *IntegrationService) GetJiraConfig(organizationUUID string) (*domain.JiraConfig, error) {
 	integration, err := s.repo.GetByType(string(domain.IntegrationTypeJira), organizationUUID)
 	if err != nil {
@@ -514,7 +739,7 @@ func (s *IntegrationService) GetJiraConfig(organizationUUID string) (*domain.Jir
 	}
 
 	var config domain.JiraIntegrationConfig
-	if err := json.Unmarshal(integration.Config, &config); err != nil {
+	if err := s.decodeConfig(organizationUUID, integration.Config, &config); err != nil {
 		s.log.Errorw("Failed to unmarshal Jira config", "error", err)
 		return nil, err
 	}
@@ -550,14 +775,15 @@ func (s *IntegrationService) GetSlackConfig(organizationUUID string) (*domain.Sl
 	}
 
 	var config domain.SlackIntegrationConfig
-	if err := json.Unmarshal(integration.Config, &config); err != nil {
+	if err := s.decodeConfig(organizationUUID, integration.Config, &config); err != nil {
 		s.log.Errorw("Failed to unmarshal Slack config", "error", err)
 		return nil, err
 	}
 
 	return &domain.SlackConfig{
-		WebhookURL: config.WebhookURL,
-		BotToken:   config.BotToken,
+		WebhookURL:    config.WebhookURL,
+		BotToken:      config.BotToken,
+		SigningSecret: config.SigningSecret,
 	}, nil
 }
 
@@ -585,7 +811,7 @@ func (s *IntegrationService) GetTeamsConfig(organizationUUID string) (*domain.Te
 	}
 
 	var config domain.TeamsIntegrationConfig
-	if err := json.Unmarshal(integration.Config, &config); err != nil {
+	if err := s.decodeConfig(organizationUUID, integration.Config, &config); err != nil {
 		s.log.Errorw("Failed to unmarshal Teams config", "error", err)
 		return nil, err
 	}
@@ -619,8 +845,12 @@ func (s *IntegrationService) GetArgoCDConfig(organizationUUID string) (*domain.A
 		return nil, nil
 	}
 
+	return s.argoCDConfigOf(organizationUUID, integration)
+}
+
+func (s *IntegrationService) argoCDConfigOf(organizationUUID string, integration *domain.Integration) (*domain.ArgoCDConfig, error) {
 	var config domain.ArgoCDIntegrationConfig
-	if err := json.Unmarshal(integration.Config, &config); err != nil {
+	if err := s.decodeConfig(organizationUUID, integration.Config, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse ArgoCD config: %w", err)
 	}
 
@@ -632,16 +862,22 @@ func (s *IntegrationService) GetArgoCDConfig(organizationUUID string) (*domain.A
 }
 
 func (s *IntegrationService) GetArgoCDService(organizationUUID string) (*ArgoCDService, error) {
-	config, err := s.GetArgoCDConfig(organizationUUID)
+	integration, err := s.repo.GetByType(string(domain.IntegrationTypeArgoCD), organizationUUID)
 	if err != nil {
 		return nil, err
 	}
 
-	if config == nil {
+	if integration == nil {
 		return nil, fmt.Errorf("ArgoCD integration not configured")
 	}
 
-	return NewArgoCDService(*config, s.log), nil
+	return getClient(s.clients, integrationClientKey(organizationUUID, integration, "argocd"), func() (*ArgoCDService, error) {
+		config, err := s.argoCDConfigOf(organizationUUID, integration)
+		if err != nil {
+			return nil, err
+		}
+		return NewArgoCDService(*config, s.log), nil
+	})
 }
 
 // Prometheus methods
@@ -656,7 +892,7 @@ func (s *IntegrationService) GetPrometheusConfig(organizationUUID string) (*doma
 	}
 
 	var config domain.PrometheusIntegrationConfig
-	if err := json.Unmarshal(integration.Config, &config); err != nil {
+	if err := s.decodeConfig(organizationUUID, integration.Config, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse Prometheus config: %w", err)
 	}
 
@@ -692,7 +928,7 @@ func (s *IntegrationService) GetLokiConfig(organizationUUID string) (*domain.Lok
 	}
 
 	var config domain.LokiIntegrationConfig
-	if err := json.Unmarshal(integration.Config, &config); err != nil {
+	if err := s.decodeConfig(organizationUUID, integration.Config, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse Loki config: %w", err)
 	}
 
@@ -728,7 +964,7 @@ func (s *IntegrationService) GetVaultConfig(organizationUUID string) (*domain.Va
 	}
 
 	var config domain.VaultIntegrationConfig
-	if err := json.Unmarshal(integration.Config, &config); err != nil {
+	if err := s.decodeConfig(organizationUUID, integration.Config, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse Vault config: %w", err)
 	}
 
@@ -764,7 +1000,7 @@ func (s *IntegrationService) GetAWSSecretsConfig(organizationUUID string) (*doma
 	}
 
 	var config domain.AWSSecretsIntegrationConfig
-	if err := json.Unmarshal(integration.Config, &config); err != nil {
+	if err := s.decodeConfig(organizationUUID, integration.Config, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse AWS Secrets config: %w", err)
 	}
 
@@ -809,7 +1045,7 @@ func (s *IntegrationService) GetAWSConfigByID(integrationID int, organizationUUI
 	}
 
 	var config domain.AWSCloudIntegrationConfig
-	if err := json.Unmarshal(integration.Config, &config); err != nil {
+	if err := s.decodeConfig(organizationUUID, integration.Config, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse AWS config: %w", err)
 	}
 
@@ -850,7 +1086,7 @@ func (s *IntegrationService) GetVaultConfigByID(integrationID int, organizationU
 	}
 
 	var config domain.VaultIntegrationConfig
-	if err := json.Unmarshal(integration.Config, &config); err != nil {
+	if err := s.decodeConfig(organizationUUID, integration.Config, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse Vault config: %w", err)
 	}
 
@@ -883,7 +1119,7 @@ func (s *IntegrationService) GetOpenAIConfig(organizationUUID string) (*domain.O
 	}
 
 	var config domain.OpenAIIntegrationConfig
-	if err := json.Unmarshal(integration.Config, &config); err != nil {
+	if err := s.decodeConfig(organizationUUID, integration.Config, &config); err != nil {
 		s.log.Errorw("Failed to unmarshal OpenAI config", "error", err)
 		return nil, err
 	}
@@ -902,7 +1138,7 @@ func (s *IntegrationService) GetClaudeConfig(organizationUUID string) (*domain.C
 	}
 
 	var config domain.ClaudeIntegrationConfig
-	if err := json.Unmarshal(integration.Config, &config); err != nil {
+	if err := s.decodeConfig(organizationUUID, integration.Config, &config); err != nil {
 		s.log.Errorw("Failed to unmarshal Claude config", "error", err)
 		return nil, err
 	}
@@ -921,7 +1157,7 @@ func (s *IntegrationService) GetGeminiConfig(organizationUUID string) (*domain.G
 	}
 
 	var config domain.GeminiIntegrationConfig
-	if err := json.Unmarshal(integration.Config, &config); err != nil {
+	if err := s.decodeConfig(organizationUUID, integration.Config, &config); err != nil {
 		s.log.Errorw("Failed to unmarshal Gemini config", "error", err)
 		return nil, err
 	}
@@ -941,7 +1177,7 @@ func (s *IntegrationService) GetOpenVPNConfig(organizationUUID string) (*domain.
 	}
 
 	var config domain.OpenVPNIntegrationConfig
-	if err := json.Unmarshal(integration.Config, &config); err != nil {
+	if err := s.decodeConfig(organizationUUID, integration.Config, &config); err != nil {
 		s.log.Errorw("Failed to unmarshal OpenVPN config", "error", err)
 		return nil, err
 	}
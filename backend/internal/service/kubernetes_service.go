@@ -24,6 +24,14 @@ func NewKubernetesService(config domain.KubernetesConfig, log *logger.Logger) (*
 	}, nil
 }
 
+// NewKubernetesServiceFromClient builds the service on top of an existing client
+func NewKubernetesServiceFromClient(client *kubernetes.Client, log *logger.Logger) *KubernetesService {
+	return &KubernetesService{
+		client: client,
+		log:    log,
+	}
+}
+
 func (k *KubernetesService) GetClusterInfo() (*domain.KubernetesCluster, error) {
 	k.log.Info("Fetching Kubernetes cluster information")
 
@@ -102,7 +110,7 @@ func (k *KubernetesService) GetNodes() ([]domain.KubernetesNode, error) {
 	return nodes, nil
 }
 // GetClientset returns the Kubernetes clientset for direct API access
-func (k *KubernetesService) GetClientset() *k8s.Clientset {
+func (k *KubernetesService) GetClientset() k8s.Interface {
 	if k.client == nil {
 		return nil
 	}
@@ -122,3 +130,61 @@ func (k *KubernetesService) GetPodLogs(namespace, podName, container, tailLines
 	k.log.Infow("Fetched pod logs successfully", "namespace", namespace, "pod", podName)
 	return logs, nil
 }
+
+// GetDeploymentState returns the current replicas, revision and images of a deployment
+func (k *KubernetesService) GetDeploymentState(namespace, name string) (*domain.KubernetesDeploymentState, error) {
+	state, err := k.client.GetDeploymentState(namespace, name)
+	if err != nil {
+		k.log.Errorw("Failed to fetch deployment state", "error", err, "namespace", namespace, "deployment", name)
+		return nil, err
+	}
+	return state, nil
+}
+
+// ScaleDeployment sets the number of replicas of a deployment
+func (k *KubernetesService) ScaleDeployment(namespace, name string, replicas int32) error {
+	k.log.Infow("Scaling deployment", "namespace", namespace, "deployment", name, "replicas", replicas)
+
+	if err := k.client.ScaleDeployment(namespace, name, replicas); err != nil {
+		k.log.Errorw("Failed to scale deployment", "error", err, "namespace", namespace, "deployment", name)
+		return err
+	}
+	return nil
+}
+
+// RestartDeployment triggers a rolling restart of a deployment
+func (k *KubernetesService) RestartDeployment(namespace, name string) (string, error) {
+	k.log.Infow("Restarting deployment", "namespace", namespace, "deployment", name)
+
+	restartedAt, err := k.client.RestartDeployment(namespace, name)
+	if err != nil {
+		k.log.Errorw("Failed to restart deployment", "error", err, "namespace", namespace, "deployment", name)
+		return "", err
+	}
+	return restartedAt, nil
+}
+
+// RestoreRestartedAt puts back a previous value of the restart annotation (empty removes it)
+func (k *KubernetesService) RestoreRestartedAt(namespace, name, restartedAt string) error {
+	k.log.Infow("Restoring deployment restart annotation", "namespace", namespace, "deployment", name, "restartedAt", restartedAt)
+
+	if err := k.client.SetRestartedAt(namespace, name, restartedAt); err != nil {
+		k.log.Errorw("Failed to restore restart annotation", "error", err, "namespace", namespace, "deployment", name)
+		return err
+	}
+	return nil
+}
+
+// RollbackDeployment rolls a deployment back to a previous ReplicaSet revision (0 means the previous one)
+func (k *KubernetesService) RollbackDeployment(namespace, name string, toRevision int64) (int64, error) {
+	k.log.Infow("Rolling back deployment", "namespace", namespace, "deployment", name, "toRevision", toRevision)
+
+	revision, err := k.client.RollbackDeployment(namespace, name, toRevision)
+	if err != nil {
+		k.log.Errorw("Failed to rollback deployment", "error", err, "namespace", namespace, "deployment", name)
+		return 0, err
+	}
+
+	k.log.Infow("Deployment rolled back", "namespace", namespace, "deployment", name, "revision", revision)
+	return revision, nil
+}
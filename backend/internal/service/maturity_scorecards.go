@@ -0,0 +1,1007 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+)
+
+const (
+	// scorecardEvaluationInterval is how often the checks of each organization are re-evaluated
+	scorecardEvaluationInterval = time.Hour
+	// scorecardEvaluatorInterval is how often the evaluator looks for organizations to re-evaluate
+	scorecardEvaluatorInterval = 5 * time.Minute
+	// scorecardHistoryDays is the window of the score history returned with the scorecards
+	scorecardHistoryDays = 30
+	// scorecardTrendDays is how far back the current score is compared with for the trend
+	scorecardTrendDays = 7
+	// scorecardTrendThreshold is the score change (0-10) below which the trend is stable
+	scorecardTrendThreshold = 0.5
+
+	maxScorecardChecks = 200
+)
+
+// scorecardCategories is the order categories are reported in
+var scorecardCategories = []domain.MaturityCategory{
+	domain.MaturityCategoryObservability,
+	domain.MaturityCategoryAutomatedTests,
+	domain.MaturityCategoryIncidentResponse,
+	domain.MaturityCategoryDelivery,
+	domain.MaturityCategorySecurity,
+	domain.MaturityCategoryDocumentation,
+	domain.MaturityCategoryFinOps,
+}
+
+var scorecardCheckTypes = map[domain.ScorecardCheckType]bool{
+	domain.ScorecardCheckGrafanaDashboard:     true,
+	domain.ScorecardCheckSonarQubeMetric:      true,
+	domain.ScorecardCheckSonarQubeQualityGate: true,
+	domain.ScorecardCheckPrometheusAlertRules: true,
+	domain.ScorecardCheckTechDocsDocument:     true,
+	domain.ScorecardCheckArgoCDStatus:         true,
+	domain.ScorecardCheckServiceField:         true,
+	domain.ScorecardCheckIncidentMetric:       true,
+}
+
+var scorecardSonarMetrics = map[string]func(*domain.SonarProjectDetails) float64{
+	"coverage":          func(d *domain.SonarProjectDetails) float64 { return d.Coverage },
+	"bugs":              func(d *domain.SonarProjectDetails) float64 { return float64(d.Bugs) },
+	"vulnerabilities":   func(d *domain.SonarProjectDetails) float64 { return float64(d.Vulnerabilities) },
+	"code_smells":       func(d *domain.SonarProjectDetails) float64 { return float64(d.CodeSmells) },
+	"duplications":      func(d *domain.SonarProjectDetails) float64 { return d.Duplications },
+	"security_hotspots": func(d *domain.SonarProjectDetails) float64 { return float64(d.SecurityHotspots) },
+}
+
+var scorecardOperators = map[string]func(value, threshold float64) bool{
+	">=": func(v, t float64) bool { return v >= t },
+	">":  func(v, t float64) bool { return v > t },
+	"<=": func(v, t float64) bool { return v <= t },
+	"<":  func(v, t float64) bool { return v < t },
+	"==": func(v, t float64) bool { return v == t },
+	"!=": func(v, t float64) bool { return v != t },
+}
+
+// scorecardIncidentMetrics returns the value of an incident metric, and false when there is no
+// sample (no incident resolved or acknowledged in the window)
+var scorecardIncidentMetrics = map[string]func(*domain.IncidentMetrics) (float64, bool){
+	"mttr_minutes": func(m *domain.IncidentMetrics) (float64, bool) { return m.MTTRMinutes, m.Resolved > 0 },
+	"mtta_minutes": func(m *domain.IncidentMetrics) (float64, bool) { return m.MTTAMinutes, m.Acknowledged > 0 },
+	"opened":       func(m *domain.IncidentMetrics) (float64, bool) { return float64(m.Opened), true },
+	"open":         func(m *domain.IncidentMetrics) (float64, bool) { return float64(m.Open), true },
+}
+
+var scorecardServiceFields = map[string]func(*domain.Service) bool{
+	"sonarqubeProject": func(s *domain.Service) bool { return s.SonarQubeProject != "" },
+	"namespace":        func(s *domain.Service) bool { return s.Namespace != "" },
+	"repositoryUrl":    func(s *domain.Service) bool { return s.RepositoryURL != "" },
+	"testUnit":         func(s *domain.Service) bool { return s.TestUnit },
+	"hasStage":         func(s *domain.Service) bool { return s.HasStage },
+	"hasProd":          func(s *domain.Service) bool { return s.HasProd },
+}
+
+// defaultScorecardDefinition is evaluated for organizations that have not defined their checks
+func defaultScorecardDefinition() *domain.ScorecardDefinition {
+	return &domain.ScorecardDefinition{
+		IsDefault: true,
+		Checks: []domain.ScorecardCheck{
+			{ID: "grafana-dashboard", Name: "Has a Grafana dashboard", Category: domain.MaturityCategoryObservability, Type: domain.ScorecardCheckGrafanaDashboard, Weight: 2, Tag: domain.ScorecardServicePlaceholder},
+			{ID: "slos", Name: "Has SLOs in TechDocs", Category: domain.MaturityCategoryObservability, Type: domain.ScorecardCheckTechDocsDocument, Weight: 1, Path: domain.ScorecardServicePlaceholder + "/slos.md"},
+			{ID: "sonarqube-coverage", Name: "SonarQube coverage >= 70%", Category: domain.MaturityCategoryAutomatedTests, Type: domain.ScorecardCheckSonarQubeMetric, Weight: 3, Metric: "coverage", Operator: ">=", Threshold: 70},
+			{ID: "sonarqube-quality-gate", Name: "SonarQube quality gate passes", Category: domain.MaturityCategoryAutomatedTests, Type: domain.ScorecardCheckSonarQubeQualityGate, Weight: 1},
+			{ID: "prometheus-alerts", Name: "Has Prometheus alert rules", Category: domain.MaturityCategoryIncidentResponse, Type: domain.ScorecardCheckPrometheusAlertRules, Weight: 2, Label: "service"},
+			{ID: "runbook", Name: "Has a runbook in TechDocs", Category: domain.MaturityCategoryIncidentResponse, Type: domain.ScorecardCheckTechDocsDocument, Weight: 2, Path: domain.ScorecardServicePlaceholder + "/runbook.md"},
+			{ID: "incident-mttr", Name: "MTTR under 1 hour", Category: domain.MaturityCategoryIncidentResponse, Type: domain.ScorecardCheckIncidentMetric, Weight: 2, Metric: "mttr_minutes", Operator: "<=", Threshold: 60, Days: 30},
+			{ID: "incident-mtta", Name: "Incidents acknowledged within 15 minutes", Category: domain.MaturityCategoryIncidentResponse, Type: domain.ScorecardCheckIncidentMetric, Weight: 1, Metric: "mtta_minutes", Operator: "<=", Threshold: 15, Days: 30},
+			{ID: "argocd-synced", Name: "ArgoCD application is Synced", Category: domain.MaturityCategoryDelivery, Type: domain.ScorecardCheckArgoCDStatus, Weight: 2, Application: domain.ScorecardServicePlaceholder, SyncStatus: "Synced"},
+			{ID: "argocd-healthy", Name: "ArgoCD application is Healthy", Category: domain.MaturityCategoryDelivery, Type: domain.ScorecardCheckArgoCDStatus, Weight: 1, Application: domain.ScorecardServicePlaceholder, Health: "Healthy"},
+			{ID: "sonarqube-vulnerabilities", Name: "No SonarQube vulnerabilities", Category: domain.MaturityCategorySecurity, Type: domain.ScorecardCheckSonarQubeMetric, Weight: 2, Metric: "vulnerabilities", Operator: "==", Threshold: 0},
+			{ID: "architecture-doc", Name: "Has architecture docs in TechDocs", Category: domain.MaturityCategoryDocumentation, Type: domain.ScorecardCheckTechDocsDocument, Weight: 1, Path: domain.ScorecardServicePlaceholder + "/architecture.md"},
+		},
+	}
+}
+
+// GetScorecardDefinition returns the scorecard checks of the organization, or the default ones
+func (s *MaturityService) GetScorecardDefinition(organizationUUID string) (*domain.ScorecardDefinition, error) {
+	definition, err := s.scorecardRepo.GetDefinition(organizationUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scorecard definition: %w", err)
+	}
+	if definition == nil {
+		return defaultScorecardDefinition(), nil
+	}
+	return definition, nil
+}
+
+// UpdateScorecardDefinition validates and replaces the scorecard checks of the organization. The
+// services are re-evaluated in the background.
+func (s *MaturityService) UpdateScorecardDefinition(organizationUUID string, definition *domain.ScorecardDefinition, updatedBy string) (*domain.ScorecardDefinition, error) {
+	if err := normalizeScorecardDefinition(definition); err != nil {
+		return nil, err
+	}
+
+	if err := s.scorecardRepo.UpsertDefinition(organizationUUID, definition, updatedBy); err != nil {
+		return nil, fmt.Errorf("failed to save scorecard definition: %w", err)
+	}
+
+	s.requestEvaluation(organizationUUID)
+
+	s.log.Infow("Scorecard definition updated", "organizationUUID", organizationUUID, "updatedBy", updatedBy, "checks", len(definition.Checks))
+
+	return s.GetScorecardDefinition(organizationUUID)
+}
+
+// EvaluateScorecards evaluates the checks of the organization for every service of the catalog
+// and stores the results of the day
+func (s *MaturityService) EvaluateScorecards(organizationUUID string) ([]domain.ScorecardResult, error) {
+	lock := s.evaluationLock(organizationUUID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	return s.evaluateScorecards(organizationUUID)
+}
+
+func (s *MaturityService) evaluateScorecards(organizationUUID string) ([]domain.ScorecardResult, error) {
+	definition, err := s.GetScorecardDefinition(organizationUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	services, err := s.serviceRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+
+	s.log.Infow("Evaluating scorecards", "organizationUUID", organizationUUID, "services", len(services), "checks", len(definition.Checks))
+
+	sources := newScorecardSources(s, organizationUUID)
+	now := time.Now()
+	results := make([]domain.ScorecardResult, 0, len(services))
+	for i := range services {
+		svc := &services[i]
+
+		checks := make([]domain.ScorecardCheckResult, 0, len(definition.Checks))
+		for _, check := range definition.Checks {
+			status, message := sources.evaluate(check, svc)
+			checks = append(checks, domain.ScorecardCheckResult{
+				CheckID:  check.ID,
+				Name:     check.Name,
+				Category: check.Category,
+				Weight:   check.Weight,
+				Status:   status,
+				Message:  message,
+			})
+		}
+
+		categoryScores := scoreScorecardChecks(checks)
+		result := domain.ScorecardResult{
+			OrganizationUUID: organizationUUID,
+			ServiceName:      svc.Name,
+			Squad:            svc.Squad,
+			Date:             startOfDay(now),
+			CategoryScores:   categoryScores,
+			OverallScore:     averageScore(categoryScores),
+			Checks:           checks,
+			EvaluatedAt:      now,
+		}
+		if err := s.scorecardRepo.UpsertResult(&result); err != nil {
+			return nil, fmt.Errorf("failed to store scorecard result: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	s.mu.Lock()
+	s.lastEvaluation[organizationUUID] = now
+	s.mu.Unlock()
+
+	s.notifyEvaluation(organizationUUID, sources)
+
+	return results, nil
+}
+
+// notifyEvaluation publishes the failed quality gates and the integrations that could not be
+// queried. Scorecards are re-evaluated often, so a failing gate is notified at most once a day.
+func (s *MaturityService) notifyEvaluation(organizationUUID string, sources *scorecardSources) {
+	for serviceName, status := range sources.failedQualityGates {
+		s.notificationService.Notify(organizationUUID, domain.NotificationEvent{
+			Type:               domain.NotificationEventQualityGateFailed,
+			Severity:           domain.IncidentSeverityMedium,
+			Title:              fmt.Sprintf("SonarQube quality gate of %s is failing", serviceName),
+			Message:            fmt.Sprintf("Quality gate status is %s", status),
+			ServiceName:        serviceName,
+			Fields:             map[string]string{"Status": status},
+			DedupKey:           "quality-gate:" + serviceName,
+			DedupWindowMinutes: 24 * 60,
+		})
+	}
+	for integration, err := range sources.failedIntegrations() {
+		s.notificationService.Notify(organizationUUID, integrationDownEvent(integration, err))
+	}
+}
+
+// requestEvaluation marks the results of the organization stale and wakes the evaluator
+func (s *MaturityService) requestEvaluation(organizationUUID string) {
+	s.mu.Lock()
+	s.lastEvaluation[organizationUUID] = time.Time{}
+	s.mu.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// evaluationLoop re-evaluates the scorecards in the background, so reads only serve the stored
+// results and never wait on the integrations
+func (s *MaturityService) evaluationLoop() {
+	ticker := time.NewTicker(scorecardEvaluatorInterval)
+	defer ticker.Stop()
+
+	for {
+		s.evaluateStale()
+
+		select {
+		case <-ticker.C:
+		case <-s.wake:
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// evaluateStale evaluates the organizations whose latest results are older than
+// scorecardEvaluationInterval, one at a time
+func (s *MaturityService) evaluateStale() {
+	organizations, err := s.organizationRepo.GetAll()
+	if err != nil {
+		s.log.Errorw("Failed to list organizations for scorecard evaluation", "error", err)
+		return
+	}
+
+	for _, org := range organizations {
+		select {
+		case <-s.stop:
+			return
+		default:
+		}
+
+		stale, err := s.evaluationStale(org.UUID)
+		if err != nil {
+			s.log.Errorw("Failed to get latest scorecard evaluation", "error", err, "organizationUUID", org.UUID)
+			continue
+		}
+		if !stale {
+			continue
+		}
+
+		if _, err := s.EvaluateScorecards(org.UUID); err != nil {
+			s.log.Errorw("Failed to evaluate scorecards", "error", err, "organizationUUID", org.UUID)
+		}
+	}
+}
+
+// evaluationStale tells whether the organization is due for evaluation; after a restart the
+// time of the stored results is used
+func (s *MaturityService) evaluationStale(organizationUUID string) (bool, error) {
+	s.mu.Lock()
+	last, ok := s.lastEvaluation[organizationUUID]
+	s.mu.Unlock()
+
+	if !ok {
+		latest, err := s.scorecardRepo.GetLatestEvaluation(organizationUUID)
+		if err != nil {
+			return false, err
+		}
+		if latest != nil {
+			last = *latest
+			s.mu.Lock()
+			if _, ok := s.lastEvaluation[organizationUUID]; !ok {
+				s.lastEvaluation[organizationUUID] = last
+			}
+			s.mu.Unlock()
+		}
+	}
+
+	return time.Since(last) >= scorecardEvaluationInterval, nil
+}
+
+func (s *MaturityService) evaluationLock(organizationUUID string) *sync.Mutex {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lock, ok := s.evaluationLocks[organizationUUID]
+	if !ok {
+		lock = &sync.Mutex{}
+		s.evaluationLocks[organizationUUID] = lock
+	}
+	return lock
+}
+
+// scorecardState is the catalog with the stored results of the history window
+type scorecardState struct {
+	services []domain.Service
+	// Most recent result of each service
+	latest  map[string]domain.ScorecardResult
+	history []domain.ScorecardResult
+}
+
+// loadScorecardState reads the stored results; an organization never evaluated gets empty
+// scorecards until the evaluator has run
+func (s *MaturityService) loadScorecardState(organizationUUID string) (*scorecardState, error) {
+	services, err := s.serviceRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+
+	today := startOfDay(time.Now())
+	history, err := s.scorecardRepo.ListResults(organizationUUID, today.AddDate(0, 0, -(scorecardHistoryDays-1)), today)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scorecard results: %w", err)
+	}
+
+	latest := make(map[string]domain.ScorecardResult)
+	for _, result := range history {
+		latest[result.ServiceName] = result
+	}
+
+	return &scorecardState{services: services, latest: latest, history: history}, nil
+}
+
+// GetServiceScorecard returns the scorecard of a service of the catalog, with its checks
+func (s *MaturityService) GetServiceScorecard(organizationUUID, serviceName string) (*domain.ServiceMaturityScorecard, error) {
+	state, err := s.loadScorecardState(organizationUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	var svc *domain.Service
+	for i := range state.services {
+		if state.services[i].Name == serviceName {
+			svc = &state.services[i]
+			break
+		}
+	}
+	if svc == nil {
+		return nil, &domain.NotFoundError{Resource: "service", ID: serviceName}
+	}
+
+	scorecard := &domain.ServiceMaturityScorecard{
+		ServiceName: svc.Name,
+		Squad:       svc.Squad,
+		Scores:      []domain.MaturityScore{},
+		Checks:      []domain.ScorecardCheckResult{},
+		Trend:       "stable",
+		History:     []domain.ScorecardTrendPoint{},
+	}
+
+	result, ok := state.latest[svc.Name]
+	if !ok {
+		return scorecard, nil
+	}
+
+	scorecard.Scores = s.maturityScores(result.CategoryScores, result.EvaluatedAt)
+	scorecard.OverallScore = roundScore(result.OverallScore)
+	scorecard.Checks = result.Checks
+	scorecard.LastUpdated = result.EvaluatedAt
+	scorecard.History = scorecardHistory(state.history, func(r domain.ScorecardResult) bool { return r.ServiceName == svc.Name })
+	scorecard.Trend = scorecardTrend(scorecard.History)
+
+	return scorecard, nil
+}
+
+// CalculateTeamMaturityScorecard aggregates the scorecards of the services of a squad: each
+// category scores the average of the services evaluated in it
+func (s *MaturityService) CalculateTeamMaturityScorecard(organizationUUID, teamName string) (*domain.TeamMaturityScorecard, error) {
+	state, err := s.loadScorecardState(organizationUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	scorecard := s.teamScorecard(state, teamName)
+	if len(scorecard.Services) == 0 {
+		return nil, &domain.NotFoundError{Resource: "team", ID: teamName}
+	}
+	return scorecard, nil
+}
+
+// GetAllTeamScorecards returns the scorecard of every squad of the catalog, best first
+func (s *MaturityService) GetAllTeamScorecards(organizationUUID string) ([]domain.TeamMaturityScorecard, error) {
+	state, err := s.loadScorecardState(organizationUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	var teams []string
+	for _, svc := range state.services {
+		if svc.Squad != "" && !seen[svc.Squad] {
+			seen[svc.Squad] = true
+			teams = append(teams, svc.Squad)
+		}
+	}
+
+	scorecards := make([]domain.TeamMaturityScorecard, 0, len(teams))
+	for _, team := range teams {
+		scorecards = append(scorecards, *s.teamScorecard(state, team))
+	}
+
+	sort.SliceStable(scorecards, func(i, j int) bool {
+		if scorecards[i].OverallScore != scorecards[j].OverallScore {
+			return scorecards[i].OverallScore > scorecards[j].OverallScore
+		}
+		return scorecards[i].TeamName < scorecards[j].TeamName
+	})
+	for i := range scorecards {
+		scorecards[i].Rank = i + 1
+	}
+
+	return scorecards, nil
+}
+
+func (s *MaturityService) teamScorecard(state *scorecardState, teamName string) *domain.TeamMaturityScorecard {
+	scorecard := &domain.TeamMaturityScorecard{
+		TeamID:   teamName,
+		TeamName: teamName,
+		Scores:   []domain.MaturityScore{},
+		Services: []string{},
+		Trend:    "stable",
+		History:  []domain.ScorecardTrendPoint{},
+	}
+
+	sums := map[domain.MaturityCategory]float64{}
+	counts := map[domain.MaturityCategory]int{}
+	for _, svc := range state.services {
+		if svc.Squad != teamName {
+			continue
+		}
+		scorecard.Services = append(scorecard.Services, svc.Name)
+
+		result, ok := state.latest[svc.Name]
+		if !ok {
+			continue
+		}
+		for category, score := range result.CategoryScores {
+			sums[category] += score
+			counts[category]++
+		}
+		if result.EvaluatedAt.After(scorecard.LastUpdated) {
+			scorecard.LastUpdated = result.EvaluatedAt
+		}
+	}
+
+	categoryScores := make(map[domain.MaturityCategory]float64, len(sums))
+	for category, sum := range sums {
+		categoryScores[category] = sum / float64(counts[category])
+	}
+
+	scorecard.Scores = s.maturityScores(categoryScores, scorecard.LastUpdated)
+	scorecard.OverallScore = s.calculateOverallScore(scorecard.Scores)
+	scorecard.History = scorecardHistory(state.history, func(r domain.ScorecardResult) bool { return r.Squad == teamName })
+	scorecard.Trend = scorecardTrend(scorecard.History)
+
+	recommendations := s.generateMaturityRecommendations(scorecard)
+	for i := range scorecard.Scores {
+		scorecard.Scores[i].Recommendations = recommendations[scorecard.Scores[i].Category]
+	}
+
+	return scorecard
+}
+
+// maturityScores lists the category scores in report order
+func (s *MaturityService) maturityScores(categoryScores map[domain.MaturityCategory]float64, updated time.Time) []domain.MaturityScore {
+	scores := []domain.MaturityScore{}
+	for _, category := range scorecardCategories {
+		score, ok := categoryScores[category]
+		if !ok {
+			continue
+		}
+		scores = append(scores, domain.MaturityScore{
+			Category:        category,
+			Score:           roundScore(score),
+			MaxScore:        10.0,
+			Level:           maturityLevel(score),
+			Metrics:         []domain.ServiceMetric{},
+			Recommendations: []domain.Recommendation{},
+			LastUpdated:     updated,
+		})
+	}
+	return scores
+}
+
+// scoreScorecardChecks scores each category from 0 to 10 as the weight of its passed checks over
+// the weight of its evaluated ones. Checks whose integration failed are left out, so an outage
+// does not show as a drop in maturity.
+func scoreScorecardChecks(checks []domain.ScorecardCheckResult) map[domain.MaturityCategory]float64 {
+	passed := map[domain.MaturityCategory]float64{}
+	total := map[domain.MaturityCategory]float64{}
+	for _, check := range checks {
+		if check.Status == domain.ScorecardCheckError {
+			continue
+		}
+		total[check.Category] += check.Weight
+		if check.Status == domain.ScorecardCheckPassed {
+			passed[check.Category] += check.Weight
+		}
+	}
+
+	scores := make(map[domain.MaturityCategory]float64, len(total))
+	for category, weight := range total {
+		if weight > 0 {
+			scores[category] = roundScore(passed[category] / weight * 10)
+		}
+	}
+	return scores
+}
+
+func averageScore(scores map[domain.MaturityCategory]float64) float64 {
+	if len(scores) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, score := range scores {
+		sum += score
+	}
+	return roundScore(sum / float64(len(scores)))
+}
+
+// scorecardHistory averages, per day, the overall score of the results matching the filter
+func scorecardHistory(results []domain.ScorecardResult, match func(domain.ScorecardResult) bool) []domain.ScorecardTrendPoint {
+	sums := map[string]float64{}
+	counts := map[string]int{}
+	var dates []string
+	for _, result := range results {
+		if !match(result) {
+			continue
+		}
+		date := result.Date.Format("2006-01-02")
+		if _, ok := counts[date]; !ok {
+			dates = append(dates, date)
+		}
+		sums[date] += result.OverallScore
+		counts[date]++
+	}
+	sort.Strings(dates)
+
+	history := make([]domain.ScorecardTrendPoint, 0, len(dates))
+	for _, date := range dates {
+		history = append(history, domain.ScorecardTrendPoint{Date: date, Score: roundScore(sums[date] / float64(counts[date]))})
+	}
+	return history
+}
+
+// scorecardTrend compares the latest score with the one scorecardTrendDays before (or the oldest
+// one when the history is shorter)
+func scorecardTrend(history []domain.ScorecardTrendPoint) string {
+	if len(history) < 2 {
+		return "stable"
+	}
+
+	current := history[len(history)-1]
+	currentDate, _ := time.Parse("2006-01-02", current.Date)
+	cutoff := currentDate.AddDate(0, 0, -scorecardTrendDays).Format("2006-01-02")
+
+	previous := history[0]
+	for _, point := range history[:len(history)-1] {
+		if point.Date <= cutoff {
+			previous = point
+		}
+	}
+
+	switch delta := current.Score - previous.Score; {
+	case delta >= scorecardTrendThreshold:
+		return "improving"
+	case delta <= -scorecardTrendThreshold:
+		return "declining"
+	default:
+		return "stable"
+	}
+}
+
+func maturityLevel(score float64) string {
+	switch {
+	case score >= 8:
+		return "expert"
+	case score >= 6:
+		return "advanced"
+	case score >= 4:
+		return "intermediate"
+	default:
+		return "beginner"
+	}
+}
+
+func roundScore(score float64) float64 {
+	return math.Round(score*10) / 10
+}
+
+// normalizeScorecardDefinition validates the checks and fills in the defaults of their parameters
+func normalizeScorecardDefinition(definition *domain.ScorecardDefinition) error {
+	if len(definition.Checks) == 0 {
+		return &domain.ValidationError{Field: "checks", Message: "at least one check is required"}
+	}
+	if len(definition.Checks) > maxScorecardChecks {
+		return &domain.ValidationError{Field: "checks", Message: fmt.Sprintf("at most %d checks are allowed", maxScorecardChecks)}
+	}
+
+	categories := map[domain.MaturityCategory]bool{}
+	for _, category := range scorecardCategories {
+		categories[category] = true
+	}
+
+	ids := map[string]bool{}
+	for i := range definition.Checks {
+		check := &definition.Checks[i]
+		field := fmt.Sprintf("checks[%d]", i)
+
+		check.ID = strings.TrimSpace(check.ID)
+		if check.ID == "" {
+			return &domain.ValidationError{Field: field + ".id", Message: "is required"}
+		}
+		if ids[check.ID] {
+			return &domain.ValidationError{Field: field + ".id", Message: fmt.Sprintf("duplicate check id %q", check.ID)}
+		}
+		ids[check.ID] = true
+
+		if check.Name == "" {
+			check.Name = check.ID
+		}
+		if !categories[check.Category] {
+			return &domain.ValidationError{Field: field + ".category", Message: fmt.Sprintf("unknown category %q", check.Category)}
+		}
+		if !scorecardCheckTypes[check.Type] {
+			return &domain.ValidationError{Field: field + ".type", Message: fmt.Sprintf("unknown check type %q", check.Type)}
+		}
+		if check.Weight < 0 {
+			return &domain.ValidationError{Field: field + ".weight", Message: "must not be negative"}
+		}
+		if check.Weight == 0 {
+			check.Weight = 1
+		}
+
+		switch check.Type {
+		case domain.ScorecardCheckGrafanaDashboard:
+			if check.Tag == "" {
+				check.Tag = domain.ScorecardServicePlaceholder
+			}
+		case domain.ScorecardCheckSonarQubeMetric:
+			if _, ok := scorecardSonarMetrics[check.Metric]; !ok {
+				return &domain.ValidationError{Field: field + ".metric", Message: fmt.Sprintf("unknown SonarQube metric %q", check.Metric)}
+			}
+			if check.Operator == "" {
+				check.Operator = ">="
+			}
+			if _, ok := scorecardOperators[check.Operator]; !ok {
+				return &domain.ValidationError{Field: field + ".operator", Message: fmt.Sprintf("unknown operator %q", check.Operator)}
+			}
+		case domain.ScorecardCheckPrometheusAlertRules:
+			if check.Label == "" {
+				check.Label = "service"
+			}
+		case domain.ScorecardCheckTechDocsDocument:
+			cleanPath := filepath.Clean(strings.TrimPrefix(check.Path, "/"))
+			if check.Path == "" || cleanPath == "." || strings.Contains(cleanPath, "..") {
+				return &domain.ValidationError{Field: field + ".path", Message: "must be a path inside the TechDocs tree"}
+			}
+			check.Path = filepath.ToSlash(cleanPath)
+		case domain.ScorecardCheckArgoCDStatus:
+			if check.Application == "" {
+				check.Application = domain.ScorecardServicePlaceholder
+			}
+			if check.SyncStatus == "" && check.Health == "" {
+				check.SyncStatus = "Synced"
+			}
+		case domain.ScorecardCheckServiceField:
+			if _, ok := scorecardServiceFields[check.Field]; !ok {
+				return &domain.ValidationError{Field: field + ".field", Message: fmt.Sprintf("unknown service field %q", check.Field)}
+			}
+		case domain.ScorecardCheckIncidentMetric:
+			if _, ok := scorecardIncidentMetrics[check.Metric]; !ok {
+				return &domain.ValidationError{Field: field + ".metric", Message: fmt.Sprintf("unknown incident metric %q", check.Metric)}
+			}
+			if check.Operator == "" {
+				check.Operator = "<="
+			}
+			if _, ok := scorecardOperators[check.Operator]; !ok {
+				return &domain.ValidationError{Field: field + ".operator", Message: fmt.Sprintf("unknown operator %q", check.Operator)}
+			}
+			if check.Days < 0 || check.Days > 365 {
+				return &domain.ValidationError{Field: field + ".days", Message: "must be between 1 and 365"}
+			}
+			if check.Days == 0 {
+				check.Days = defaultIncidentMetricsDays
+			}
+		}
+	}
+
+	return nil
+}
+
+// scorecardSources queries the integrations of an organization for one evaluation run, caching
+// what several services or checks share
+type scorecardSources struct {
+	s                *MaturityService
+	organizationUUID string
+
+	grafana       *GrafanaService
+	grafanaErr    error
+	grafanaLoaded bool
+	dashboards    map[string]int
+
+	sonarQube       *SonarQubeService
+	sonarQubeErr    error
+	sonarQubeLoaded bool
+	sonarProjects   map[string]*domain.SonarProjectDetails
+	sonarErrs       map[string]error
+	// Quality gate status of the services whose gate does not pass
+	failedQualityGates map[string]string
+
+	alertRules       []domain.PrometheusRule
+	alertRulesErr    error
+	alertRulesLoaded bool
+	prometheusFound  bool
+
+	argoApps       map[string]domain.ArgoCDApplication
+	argoErr        error
+	argoLoaded     bool
+	argoConfigured bool
+
+	incidentMetrics map[string]*domain.IncidentMetrics
+}
+
+func newScorecardSources(s *MaturityService, organizationUUID string) *scorecardSources {
+	return &scorecardSources{
+		s:                  s,
+		organizationUUID:   organizationUUID,
+		dashboards:         map[string]int{},
+		sonarProjects:      map[string]*domain.SonarProjectDetails{},
+		sonarErrs:          map[string]error{},
+		failedQualityGates: map[string]string{},
+		incidentMetrics:    map[string]*domain.IncidentMetrics{},
+	}
+}
+
+// failedIntegrations returns the integrations that could not be queried during the run
+func (e *scorecardSources) failedIntegrations() map[string]error {
+	failed := map[string]error{}
+	for name, err := range map[string]error{
+		"Grafana":    e.grafanaErr,
+		"SonarQube":  e.sonarQubeErr,
+		"Prometheus": e.alertRulesErr,
+		"ArgoCD":     e.argoErr,
+	} {
+		if err != nil {
+			failed[name] = err
+		}
+	}
+	return failed
+}
+
+// evaluate runs a check for a service. Missing integrations fail the check; integrations that
+// cannot be queried make it an error.
+func (e *scorecardSources) evaluate(check domain.ScorecardCheck, svc *domain.Service) (domain.ScorecardCheckStatus, string) {
+	expand := func(value string) string {
+		return strings.ReplaceAll(value, domain.ScorecardServicePlaceholder, svc.Name)
+	}
+
+	switch check.Type {
+	case domain.ScorecardCheckGrafanaDashboard:
+		return e.grafanaDashboard(expand(check.Tag))
+	case domain.ScorecardCheckSonarQubeMetric, domain.ScorecardCheckSonarQubeQualityGate:
+		return e.sonarQubeProject(check, svc)
+	case domain.ScorecardCheckPrometheusAlertRules:
+		return e.prometheusAlertRules(check.Label, svc.Name)
+	case domain.ScorecardCheckTechDocsDocument:
+		docPath := expand(check.Path)
+		exists, err := e.s.techDocsService.DocumentExists(e.organizationUUID, docPath)
+		if err != nil {
+			return domain.ScorecardCheckError, err.Error()
+		}
+		if !exists {
+			return domain.ScorecardCheckFailed, fmt.Sprintf("no document at %s", docPath)
+		}
+		return domain.ScorecardCheckPassed, fmt.Sprintf("document at %s", docPath)
+	case domain.ScorecardCheckArgoCDStatus:
+		return e.argoCDStatus(check, expand(check.Application))
+	case domain.ScorecardCheckServiceField:
+		if scorecardServiceFields[check.Field](svc) {
+			return domain.ScorecardCheckPassed, fmt.Sprintf("%s is set in the catalog", check.Field)
+		}
+		return domain.ScorecardCheckFailed, fmt.Sprintf("%s is not set in the catalog", check.Field)
+	case domain.ScorecardCheckIncidentMetric:
+		return e.incidentMetric(check, svc.Name)
+	}
+
+	return domain.ScorecardCheckError, fmt.Sprintf("unknown check type %q", check.Type)
+}
+
+func (e *scorecardSources) grafanaDashboard(tag string) (domain.ScorecardCheckStatus, string) {
+	if !e.grafanaLoaded {
+		e.grafana, e.grafanaErr = e.s.integrationService.GetGrafanaService(e.organizationUUID)
+		e.grafanaLoaded = true
+	}
+	if e.grafanaErr != nil {
+		return domain.ScorecardCheckError, fmt.Sprintf("failed to load Grafana integration: %v", e.grafanaErr)
+	}
+	if e.grafana == nil {
+		return domain.ScorecardCheckFailed, "Grafana integration not configured"
+	}
+
+	count, ok := e.dashboards[tag]
+	if !ok {
+		dashboards, err := e.grafana.SearchDashboards("", []string{tag})
+		if err != nil {
+			return domain.ScorecardCheckError, fmt.Sprintf("failed to search Grafana dashboards: %v", err)
+		}
+		count = len(dashboards)
+		e.dashboards[tag] = count
+	}
+
+	if count == 0 {
+		return domain.ScorecardCheckFailed, fmt.Sprintf("no dashboard tagged %q", tag)
+	}
+	return domain.ScorecardCheckPassed, fmt.Sprintf("%d dashboard(s) tagged %q", count, tag)
+}
+
+func (e *scorecardSources) sonarQubeProject(check domain.ScorecardCheck, svc *domain.Service) (domain.ScorecardCheckStatus, string) {
+	if !e.sonarQubeLoaded {
+		e.sonarQube, e.sonarQubeErr = e.s.integrationService.GetSonarQubeService(e.organizationUUID)
+		e.sonarQubeLoaded = true
+	}
+	if e.sonarQubeErr != nil {
+		return domain.ScorecardCheckError, fmt.Sprintf("failed to load SonarQube integration: %v", e.sonarQubeErr)
+	}
+	if e.sonarQube == nil {
+		return domain.ScorecardCheckFailed, "SonarQube integration not configured"
+	}
+
+	projectKey := svc.SonarQubeProject
+	if projectKey == "" {
+		projectKey = svc.Name
+	}
+
+	details, ok := e.sonarProjects[projectKey]
+	if !ok {
+		if err, failed := e.sonarErrs[projectKey]; failed {
+			return domain.ScorecardCheckError, fmt.Sprintf("failed to get SonarQube measures of %s: %v", projectKey, err)
+		}
+		var err error
+		details, err = e.sonarQube.GetProjectMeasures(projectKey)
+		if err != nil {
+			e.sonarErrs[projectKey] = err
+			return domain.ScorecardCheckError, fmt.Sprintf("failed to get SonarQube measures of %s: %v", projectKey, err)
+		}
+		e.sonarProjects[projectKey] = details
+	}
+
+	if check.Type == domain.ScorecardCheckSonarQubeQualityGate {
+		if details.QualityGateStatus == "OK" {
+			return domain.ScorecardCheckPassed, "quality gate passed"
+		}
+		e.failedQualityGates[svc.Name] = details.QualityGateStatus
+		return domain.ScorecardCheckFailed, fmt.Sprintf("quality gate status is %q", details.QualityGateStatus)
+	}
+
+	value := scorecardSonarMetrics[check.Metric](details)
+	message := fmt.Sprintf("%s is %g (required %s %g)", check.Metric, value, check.Operator, check.Threshold)
+	if scorecardOperators[check.Operator](value, check.Threshold) {
+		return domain.ScorecardCheckPassed, message
+	}
+	return domain.ScorecardCheckFailed, message
+}
+
+func (e *scorecardSources) prometheusAlertRules(label, serviceName string) (domain.ScorecardCheckStatus, string) {
+	if !e.alertRulesLoaded {
+		e.alertRulesLoaded = true
+		prometheus, err := e.s.integrationService.GetPrometheusService(e.organizationUUID)
+		if err != nil {
+			e.alertRulesErr = err
+		} else if prometheus != nil {
+			e.prometheusFound = true
+			rules, err := prometheus.GetRules()
+			if err != nil {
+				e.alertRulesErr = err
+			} else {
+				for _, group := range rules.Data.Groups {
+					for _, rule := range group.Rules {
+						if rule.Type == "alerting" {
+							e.alertRules = append(e.alertRules, rule)
+						}
+					}
+				}
+			}
+		}
+	}
+	if e.alertRulesErr != nil {
+		return domain.ScorecardCheckError, fmt.Sprintf("failed to get Prometheus rules: %v", e.alertRulesErr)
+	}
+	if !e.prometheusFound {
+		return domain.ScorecardCheckFailed, "Prometheus integration not configured"
+	}
+
+	// Rules are matched by label, or by a selector on the label in their query
+	selector := fmt.Sprintf(`%s="%s"`, label, serviceName)
+	count := 0
+	for _, rule := range e.alertRules {
+		if strings.EqualFold(rule.Labels[label], serviceName) || strings.Contains(rule.Query, selector) {
+			count++
+		}
+	}
+
+	if count == 0 {
+		return domain.ScorecardCheckFailed, fmt.Sprintf("no alerting rule for %s", selector)
+	}
+	return domain.ScorecardCheckPassed, fmt.Sprintf("%d alerting rule(s) for %s", count, selector)
+}
+
+func (e *scorecardSources) argoCDStatus(check domain.ScorecardCheck, application string) (domain.ScorecardCheckStatus, string) {
+	if !e.argoLoaded {
+		e.argoLoaded = true
+		argoCD, err := e.s.integrationService.GetArgoCDService(e.organizationUUID)
+		if err != nil {
+			e.argoErr = err
+		} else if argoCD != nil {
+			e.argoConfigured = true
+			apps, err := argoCD.GetApplications()
+			if err != nil {
+				e.argoErr = err
+			} else {
+				e.argoApps = make(map[string]domain.ArgoCDApplication, len(apps))
+				for _, app := range apps {
+					e.argoApps[app.Metadata.Name] = app
+				}
+			}
+		}
+	}
+	if e.argoErr != nil {
+		return domain.ScorecardCheckError, fmt.Sprintf("failed to list ArgoCD applications: %v", e.argoErr)
+	}
+	if !e.argoConfigured {
+		return domain.ScorecardCheckFailed, "ArgoCD integration not configured"
+	}
+
+	app, ok := e.argoApps[application]
+	if !ok {
+		return domain.ScorecardCheckFailed, fmt.Sprintf("no ArgoCD application %s", application)
+	}
+
+	sync := app.Status.Sync.Status
+	health := app.Status.Health.Status
+	message := fmt.Sprintf("application %s is %s and %s", application, sync, health)
+	if check.SyncStatus != "" && !strings.EqualFold(sync, check.SyncStatus) {
+		return domain.ScorecardCheckFailed, message
+	}
+	if check.Health != "" && !strings.EqualFold(health, check.Health) {
+		return domain.ScorecardCheckFailed, message
+	}
+	return domain.ScorecardCheckPassed, message
+}
+
+func (e *scorecardSources) incidentMetric(check domain.ScorecardCheck, serviceName string) (domain.ScorecardCheckStatus, string) {
+	key := fmt.Sprintf("%s/%d", serviceName, check.Days)
+	metrics, ok := e.incidentMetrics[key]
+	if !ok {
+		var err error
+		metrics, err = e.s.incidentService.GetMetrics(e.organizationUUID, domain.DORAScopeService, serviceName, check.Days)
+		if err != nil {
+			return domain.ScorecardCheckError, fmt.Sprintf("failed to get incident metrics: %v", err)
+		}
+		e.incidentMetrics[key] = metrics
+	}
+
+	value, ok := scorecardIncidentMetrics[check.Metric](metrics)
+	if !ok {
+		// Nothing to measure: no incident was resolved (acknowledged) in the window
+		return domain.ScorecardCheckPassed, fmt.Sprintf("no incident to measure %s in the last %d days", check.Metric, check.Days)
+	}
+
+	message := fmt.Sprintf("%s is %g over the last %d days (required %s %g)", check.Metric, math.Round(value*10)/10, check.Days, check.Operator, check.Threshold)
+	if scorecardOperators[check.Operator](value, check.Threshold) {
+		return domain.ScorecardCheckPassed, message
+	}
+	return domain.ScorecardCheckFailed, message
+}
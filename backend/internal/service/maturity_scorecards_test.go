@@ -0,0 +1,277 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+)
+
+func TestNormalizeScorecardDefinition(t *testing.T) {
+	check := func(checkType domain.ScorecardCheckType, edit func(*domain.ScorecardCheck)) domain.ScorecardCheck {
+		c := domain.ScorecardCheck{ID: "check", Category: domain.MaturityCategoryObservability, Type: checkType}
+		if edit != nil {
+			edit(&c)
+		}
+		return c
+	}
+
+	tests := []struct {
+		name      string
+		checks    []domain.ScorecardCheck
+		wantField string
+		want      func(*testing.T, domain.ScorecardCheck)
+	}{
+		{name: "no checks", wantField: "checks"},
+		{
+			name:      "duplicate id",
+			checks:    []domain.ScorecardCheck{check(domain.ScorecardCheckGrafanaDashboard, nil), check(domain.ScorecardCheckGrafanaDashboard, nil)},
+			wantField: "checks[1].id",
+		},
+		{
+			name:      "blank id",
+			checks:    []domain.ScorecardCheck{check(domain.ScorecardCheckGrafanaDashboard, func(c *domain.ScorecardCheck) { c.ID = "  " })},
+			wantField: "checks[0].id",
+		},
+		{
+			name:      "unknown category",
+			checks:    []domain.ScorecardCheck{check(domain.ScorecardCheckGrafanaDashboard, func(c *domain.ScorecardCheck) { c.Category = "happiness" })},
+			wantField: "checks[0].category",
+		},
+		{
+			name:      "unknown type",
+			checks:    []domain.ScorecardCheck{check("jenkins_job", nil)},
+			wantField: "checks[0].type",
+		},
+		{
+			name:      "negative weight",
+			checks:    []domain.ScorecardCheck{check(domain.ScorecardCheckGrafanaDashboard, func(c *domain.ScorecardCheck) { c.Weight = -1 })},
+			wantField: "checks[0].weight",
+		},
+		{
+			name:      "unknown SonarQube metric",
+			checks:    []domain.ScorecardCheck{check(domain.ScorecardCheckSonarQubeMetric, func(c *domain.ScorecardCheck) { c.Metric = "lines" })},
+			wantField: "checks[0].metric",
+		},
+		{
+			name: "unknown operator",
+			checks: []domain.ScorecardCheck{check(domain.ScorecardCheckSonarQubeMetric, func(c *domain.ScorecardCheck) {
+				c.Metric = "coverage"
+				c.Operator = "~="
+			})},
+			wantField: "checks[0].operator",
+		},
+		{
+			name:      "document outside the tree",
+			checks:    []domain.ScorecardCheck{check(domain.ScorecardCheckTechDocsDocument, func(c *domain.ScorecardCheck) { c.Path = "../secrets.md" })},
+			wantField: "checks[0].path",
+		},
+		{
+			name:      "unknown service field",
+			checks:    []domain.ScorecardCheck{check(domain.ScorecardCheckServiceField, func(c *domain.ScorecardCheck) { c.Field = "owner" })},
+			wantField: "checks[0].field",
+		},
+		{
+			name: "incident window too long",
+			checks: []domain.ScorecardCheck{check(domain.ScorecardCheckIncidentMetric, func(c *domain.ScorecardCheck) {
+				c.Metric = "mttr_minutes"
+				c.Days = 400
+			})},
+			wantField: "checks[0].days",
+		},
+		{
+			name:   "grafana defaults",
+			checks: []domain.ScorecardCheck{check(domain.ScorecardCheckGrafanaDashboard, nil)},
+			want: func(t *testing.T, c domain.ScorecardCheck) {
+				if c.Name != "check" || c.Weight != 1 || c.Tag != domain.ScorecardServicePlaceholder {
+					t.Errorf("defaults not filled in: %+v", c)
+				}
+			},
+		},
+		{
+			name:   "sonarqube operator default",
+			checks: []domain.ScorecardCheck{check(domain.ScorecardCheckSonarQubeMetric, func(c *domain.ScorecardCheck) { c.Metric = "coverage" })},
+			want: func(t *testing.T, c domain.ScorecardCheck) {
+				if c.Operator != ">=" {
+					t.Errorf("operator is %q, want >=", c.Operator)
+				}
+			},
+		},
+		{
+			name:   "document path cleaned",
+			checks: []domain.ScorecardCheck{check(domain.ScorecardCheckTechDocsDocument, func(c *domain.ScorecardCheck) { c.Path = "/{service}//runbook.md" })},
+			want: func(t *testing.T, c domain.ScorecardCheck) {
+				if c.Path != "{service}/runbook.md" {
+					t.Errorf("path is %q", c.Path)
+				}
+			},
+		},
+		{
+			name:   "argocd defaults",
+			checks: []domain.ScorecardCheck{check(domain.ScorecardCheckArgoCDStatus, nil)},
+			want: func(t *testing.T, c domain.ScorecardCheck) {
+				if c.Application != domain.ScorecardServicePlaceholder || c.SyncStatus != "Synced" {
+					t.Errorf("defaults not filled in: %+v", c)
+				}
+			},
+		},
+		{
+			name:   "incident metric defaults",
+			checks: []domain.ScorecardCheck{check(domain.ScorecardCheckIncidentMetric, func(c *domain.ScorecardCheck) { c.Metric = "mtta_minutes" })},
+			want: func(t *testing.T, c domain.ScorecardCheck) {
+				if c.Operator != "<=" || c.Days != defaultIncidentMetricsDays {
+					t.Errorf("defaults not filled in: %+v", c)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			definition := &domain.ScorecardDefinition{Checks: tt.checks}
+
+			err := normalizeScorecardDefinition(definition)
+			if tt.wantField != "" {
+				var validation *domain.ValidationError
+				if !errors.As(err, &validation) || validation.Field != tt.wantField {
+					t.Fatalf("got %v, want a validation error on %s", err, tt.wantField)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("normalizeScorecardDefinition: %v", err)
+			}
+			tt.want(t, definition.Checks[0])
+		})
+	}
+
+	if err := normalizeScorecardDefinition(defaultScorecardDefinition()); err != nil {
+		t.Errorf("the default definition is invalid: %v", err)
+	}
+}
+
+func TestScoreScorecardChecks(t *testing.T) {
+	result := func(category domain.MaturityCategory, weight float64, status domain.ScorecardCheckStatus) domain.ScorecardCheckResult {
+		return domain.ScorecardCheckResult{Category: category, Weight: weight, Status: status}
+	}
+	observability, security := domain.MaturityCategoryObservability, domain.MaturityCategorySecurity
+
+	tests := []struct {
+		name   string
+		checks []domain.ScorecardCheckResult
+		want   map[domain.MaturityCategory]float64
+	}{
+		{name: "no checks", want: map[domain.MaturityCategory]float64{}},
+		{
+			name:   "weighted by check",
+			checks: []domain.ScorecardCheckResult{result(observability, 2, domain.ScorecardCheckPassed), result(observability, 1, domain.ScorecardCheckFailed)},
+			want:   map[domain.MaturityCategory]float64{observability: 6.7},
+		},
+		{
+			name:   "errors left out",
+			checks: []domain.ScorecardCheckResult{result(observability, 1, domain.ScorecardCheckPassed), result(observability, 3, domain.ScorecardCheckError)},
+			want:   map[domain.MaturityCategory]float64{observability: 10},
+		},
+		{
+			name:   "category with only errors is absent",
+			checks: []domain.ScorecardCheckResult{result(observability, 1, domain.ScorecardCheckFailed), result(security, 1, domain.ScorecardCheckError)},
+			want:   map[domain.MaturityCategory]float64{observability: 0},
+		},
+		{
+			name:   "zero weights are not scored",
+			checks: []domain.ScorecardCheckResult{result(security, 0, domain.ScorecardCheckPassed)},
+			want:   map[domain.MaturityCategory]float64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scoreScorecardChecks(tt.checks); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("scores are %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScorecardHistory(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2026, time.March, d, 0, 0, 0, 0, time.UTC)
+	}
+	results := []domain.ScorecardResult{
+		{ServiceName: "api", Squad: "core", Date: day(2), OverallScore: 6},
+		{ServiceName: "web", Squad: "core", Date: day(1), OverallScore: 4},
+		{ServiceName: "api", Squad: "core", Date: day(1), OverallScore: 8},
+		{ServiceName: "jobs", Squad: "data", Date: day(1), OverallScore: 1},
+		{ServiceName: "web", Squad: "core", Date: day(2), OverallScore: 5},
+	}
+
+	tests := []struct {
+		name  string
+		match func(domain.ScorecardResult) bool
+		want  []domain.ScorecardTrendPoint
+	}{
+		{
+			name:  "service",
+			match: func(r domain.ScorecardResult) bool { return r.ServiceName == "api" },
+			want:  []domain.ScorecardTrendPoint{{Date: "2026-03-01", Score: 8}, {Date: "2026-03-02", Score: 6}},
+		},
+		{
+			name:  "team average per day",
+			match: func(r domain.ScorecardResult) bool { return r.Squad == "core" },
+			want:  []domain.ScorecardTrendPoint{{Date: "2026-03-01", Score: 6}, {Date: "2026-03-02", Score: 5.5}},
+		},
+		{
+			name:  "no match",
+			match: func(r domain.ScorecardResult) bool { return false },
+			want:  []domain.ScorecardTrendPoint{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scorecardHistory(results, tt.match); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("history is %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScorecardTrend(t *testing.T) {
+	point := func(date string, score float64) domain.ScorecardTrendPoint {
+		return domain.ScorecardTrendPoint{Date: date, Score: score}
+	}
+
+	tests := []struct {
+		name    string
+		history []domain.ScorecardTrendPoint
+		want    string
+	}{
+		{name: "no history", want: "stable"},
+		{name: "single point", history: []domain.ScorecardTrendPoint{point("2026-03-10", 5)}, want: "stable"},
+		{name: "improving", history: []domain.ScorecardTrendPoint{point("2026-03-01", 5), point("2026-03-10", 6)}, want: "improving"},
+		{name: "declining", history: []domain.ScorecardTrendPoint{point("2026-03-01", 5), point("2026-03-10", 4.5)}, want: "declining"},
+		{name: "below the threshold", history: []domain.ScorecardTrendPoint{point("2026-03-01", 5), point("2026-03-10", 5.4)}, want: "stable"},
+		{
+			// The last point a week before the latest one is the reference, not the oldest
+			name:    "compared with a week before",
+			history: []domain.ScorecardTrendPoint{point("2026-03-01", 2), point("2026-03-03", 7), point("2026-03-08", 9), point("2026-03-10", 7)},
+			want:    "stable",
+		},
+		{
+			// Shorter history than a week: the oldest point is the reference
+			name:    "compared with the oldest point",
+			history: []domain.ScorecardTrendPoint{point("2026-03-08", 9), point("2026-03-09", 3), point("2026-03-10", 7)},
+			want:    "declining",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scorecardTrend(tt.history); got != tt.want {
+				t.Errorf("trend is %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,19 +3,39 @@ package service
 import (
 	"fmt"
 	"math"
+	"sync"
 	"time"
 
 	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/PlatifyX/platifyx-core/internal/repository"
 	"github.com/PlatifyX/platifyx-core/pkg/logger"
 )
 
+// MaturityService scores the maturity of services and teams. Scorecards are evaluated from the
+// checks each organization defines against its integrations, and stored daily for trends.
 type MaturityService struct {
-	kubernetesService  *KubernetesService
-	azureDevOpsService *AzureDevOpsService
-	sonarQubeService   *SonarQubeService
-	finOpsService      *FinOpsService
-	aiService          *AIService
-	log                *logger.Logger
+	kubernetesService   *KubernetesService
+	azureDevOpsService  *AzureDevOpsService
+	sonarQubeService    *SonarQubeService
+	finOpsService       *FinOpsService
+	aiService           *AIService
+	integrationService  *IntegrationService
+	techDocsService     *TechDocsService
+	incidentService     *IncidentService
+	notificationService *NotificationService
+	serviceRepo         *repository.ServiceRepository
+	scorecardRepo       *repository.ScorecardRepository
+	organizationRepo    *repository.OrganizationRepository
+	log                 *logger.Logger
+
+	mu              sync.Mutex
+	lastEvaluation  map[string]time.Time
+	evaluationLocks map[string]*sync.Mutex
+
+	// Buffered by one: requests while the evaluator runs are picked up by its next run
+	wake     chan struct{}
+	stop     chan struct{}
+	stopOnce sync.Once
 }
 
 func NewMaturityService(
@@ -24,19 +44,47 @@ func NewMaturityService(
 	sonarQubeService *SonarQubeService,
 	finOpsService *FinOpsService,
 	aiService *AIService,
+	integrationService *IntegrationService,
+	techDocsService *TechDocsService,
+	incidentService *IncidentService,
+	notificationService *NotificationService,
+	serviceRepo *repository.ServiceRepository,
+	scorecardRepo *repository.ScorecardRepository,
+	organizationRepo *repository.OrganizationRepository,
 	log *logger.Logger,
 ) *MaturityService {
-	return &MaturityService{
-		kubernetesService:  kubernetesService,
-		azureDevOpsService: azureDevOpsService,
-		sonarQubeService:   sonarQubeService,
-		finOpsService:      finOpsService,
-		aiService:          aiService,
-		log:                log,
+	s := &MaturityService{
+		kubernetesService:   kubernetesService,
+		azureDevOpsService:  azureDevOpsService,
+		sonarQubeService:    sonarQubeService,
+		finOpsService:       finOpsService,
+		aiService:           aiService,
+		integrationService:  integrationService,
+		techDocsService:     techDocsService,
+		incidentService:     incidentService,
+		notificationService: notificationService,
+		serviceRepo:         serviceRepo,
+		scorecardRepo:       scorecardRepo,
+		organizationRepo:    organizationRepo,
+		log:                 log,
+		lastEvaluation:      make(map[string]time.Time),
+		evaluationLocks:     make(map[string]*sync.Mutex),
+		wake:                make(chan struct{}, 1),
+		stop:                make(chan struct{}),
+	}
+	go s.evaluationLoop()
+	return s
+}
+
+// Close stops the scorecard evaluator
+func (s *MaturityService) Close() {
+	if s == nil {
+		return
 	}
+	s.stopOnce.Do(func() { close(s.stop) })
 }
 
-func (s *MaturityService) CalculateServiceMetrics(serviceName string) ([]domain.ServiceMetric, error) {
+func (s *MaturityService) CalculateServiceMetrics(organizationUUID, serviceName string) ([]domain.ServiceMetric, error) {
 	metrics := []domain.ServiceMetric{}
 
 	// Test Coverage
@@ -52,7 +100,7 @@ func (s *MaturityService) CalculateServiceMetrics(serviceName string) ([]domain.
 	}
 
 	// MTTR
-	mttr, err := s.calculateMTTR(serviceName)
+	mttr, err := s.calculateMTTR(organizationUUID, serviceName)
 	if err == nil {
 		metrics = append(metrics, mttr)
 	}
@@ -156,18 +204,26 @@ func (s *MaturityService) calculateDeployVelocity(serviceName string) (domain.Se
 	}, nil
 }
 
-func (s *MaturityService) calculateMTTR(serviceName string) (domain.ServiceMetric, error) {
-	// MTTR calculation would require incident/alert data
-	// For now, we'll use a placeholder that can be enhanced
+func (s *MaturityService) calculateMTTR(organizationUUID, serviceName string) (domain.ServiceMetric, error) {
+	incidents, err := s.incidentService.GetMetrics(organizationUUID, domain.DORAScopeService, serviceName, defaultIncidentMetricsDays)
+	if err != nil {
+		return domain.ServiceMetric{}, err
+	}
+
 	return domain.ServiceMetric{
 		ID:          fmt.Sprintf("mttr-%s-%d", serviceName, time.Now().Unix()),
 		ServiceName: serviceName,
 		Type:        domain.MetricTypeMTTR,
-		Value:       0, // Placeholder - needs incident data
+		Value:       incidents.MTTRMinutes,
 		Unit:        "minutes",
 		Timestamp:   time.Now(),
 		Metadata: map[string]interface{}{
-			"note": "Requires incident tracking integration",
+			"incidentsOpened":   incidents.Opened,
+			"incidentsResolved": incidents.Resolved,
+			"incidentsOpen":     incidents.Open,
+			"mttaMinutes":       incidents.MTTAMinutes,
+			"days":              incidents.Days,
+			"source":            "incidents",
 		},
 	}, nil
 }
@@ -265,196 +321,6 @@ func (s *MaturityService) detectPerformanceRegression(serviceName string) (domai
 	}, nil
 }
 
-func (s *MaturityService) CalculateTeamMaturityScorecard(teamName string) (*domain.TeamMaturityScorecard, error) {
-	scores := []domain.MaturityScore{}
-
-	// Observability Score
-	obsScore := s.calculateObservabilityScore(teamName)
-	scores = append(scores, obsScore)
-
-	// Automated Tests Score
-	testScore := s.calculateAutomatedTestsScore(teamName)
-	scores = append(scores, testScore)
-
-	// Incident Response Score
-	incidentScore := s.calculateIncidentResponseScore(teamName)
-	scores = append(scores, incidentScore)
-
-	// FinOps Score
-	finOpsScore := s.calculateFinOpsScore(teamName)
-	scores = append(scores, finOpsScore)
-
-	// Calculate overall score
-	overallScore := s.calculateOverallScore(scores)
-
-	scorecard := &domain.TeamMaturityScorecard{
-		TeamID:       teamName,
-		TeamName:     teamName,
-		Scores:       scores,
-		OverallScore: overallScore,
-		LastUpdated:  time.Now(),
-		Trend:        "stable", // Would calculate based on historical data
-	}
-
-	// Generate AI recommendations
-	recommendations := s.generateMaturityRecommendations(scorecard)
-	for i := range scorecard.Scores {
-		scorecard.Scores[i].Recommendations = recommendations[scorecard.Scores[i].Category]
-	}
-
-	return scorecard, nil
-}
-
-func (s *MaturityService) calculateObservabilityScore(teamName string) domain.MaturityScore {
-	// Score based on:
-	// - Grafana dashboards per service
-	// - Alert coverage
-	// - Log aggregation
-	// - Metrics collection
-
-	score := 5.0 // Base score
-	level := "intermediate"
-
-	if score >= 8 {
-		level = "expert"
-	} else if score >= 6 {
-		level = "advanced"
-	} else if score >= 4 {
-		level = "intermediate"
-	} else {
-		level = "beginner"
-	}
-
-	return domain.MaturityScore{
-		Category:    domain.MaturityCategoryObservability,
-		Score:       math.Round(score*10) / 10,
-		MaxScore:    10.0,
-		Level:       level,
-		Metrics:     []domain.ServiceMetric{},
-		LastUpdated: time.Now(),
-	}
-}
-
-func (s *MaturityService) calculateAutomatedTestsScore(teamName string) domain.MaturityScore {
-	if s.sonarQubeService == nil {
-		return domain.MaturityScore{
-			Category:    domain.MaturityCategoryAutomatedTests,
-			Score:       0,
-			MaxScore:    10.0,
-			Level:       "beginner",
-			LastUpdated: time.Now(),
-		}
-	}
-
-	projects, err := s.sonarQubeService.GetProjects()
-	if err != nil {
-		return domain.MaturityScore{
-			Category:    domain.MaturityCategoryAutomatedTests,
-			Score:       0,
-			MaxScore:    10.0,
-			Level:       "beginner",
-			LastUpdated: time.Now(),
-		}
-	}
-
-	var totalCoverage float64
-	var count int
-	for _, project := range projects {
-		// Get project details to get coverage
-		details, err := s.sonarQubeService.GetProjectMeasures(project.Key)
-		if err == nil {
-			totalCoverage += details.Coverage
-			count++
-		}
-	}
-
-	avgCoverage := float64(0)
-	if count > 0 {
-		avgCoverage = totalCoverage / float64(count)
-	}
-
-	// Convert coverage percentage (0-100) to score (0-10)
-	score := avgCoverage / 10.0
-	if score > 10 {
-		score = 10
-	}
-
-	level := "beginner"
-	if score >= 8 {
-		level = "expert"
-	} else if score >= 6 {
-		level = "advanced"
-	} else if score >= 4 {
-		level = "intermediate"
-	}
-
-	return domain.MaturityScore{
-		Category:    domain.MaturityCategoryAutomatedTests,
-		Score:       math.Round(score*10) / 10,
-		MaxScore:    10.0,
-		Level:       level,
-		LastUpdated: time.Now(),
-	}
-}
-
-func (s *MaturityService) calculateIncidentResponseScore(teamName string) domain.MaturityScore {
-	// Score based on:
-	// - MTTR
-	// - Incident response time
-	// - Post-mortem documentation
-	// - Runbook availability
-
-	score := 6.0 // Placeholder
-	level := "intermediate"
-
-	if score >= 8 {
-		level = "expert"
-	} else if score >= 6 {
-		level = "advanced"
-	} else if score >= 4 {
-		level = "intermediate"
-	} else {
-		level = "beginner"
-	}
-
-	return domain.MaturityScore{
-		Category:    domain.MaturityCategoryIncidentResponse,
-		Score:       math.Round(score*10) / 10,
-		MaxScore:    10.0,
-		Level:       level,
-		LastUpdated: time.Now(),
-	}
-}
-
-func (s *MaturityService) calculateFinOpsScore(teamName string) domain.MaturityScore {
-	// Score based on:
-	// - Cost visibility
-	// - Budget tracking
-	// - Cost optimization
-	// - Resource right-sizing
-
-	score := 9.0 // Placeholder - FinOps seems well implemented
-	level := "expert"
-
-	if score >= 8 {
-		level = "expert"
-	} else if score >= 6 {
-		level = "advanced"
-	} else if score >= 4 {
-		level = "intermediate"
-	} else {
-		level = "beginner"
-	}
-
-	return domain.MaturityScore{
-		Category:    domain.MaturityCategoryFinOps,
-		Score:       math.Round(score*10) / 10,
-		MaxScore:    10.0,
-		Level:       level,
-		LastUpdated: time.Now(),
-	}
-}
-
 func (s *MaturityService) calculateOverallScore(scores []domain.MaturityScore) float64 {
 	if len(scores) == 0 {
 		return 0
@@ -1,53 +1,492 @@
 package service
 
-type MetricsService struct{}
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
-func NewMetricsService() *MetricsService {
-	return &MetricsService{}
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/PlatifyX/platifyx-core/internal/repository"
+	"github.com/PlatifyX/platifyx-core/pkg/logger"
+)
+
+// doraRefreshInterval limits how often the partial current day is re-collected from the integrations
+const doraRefreshInterval = 15 * time.Minute
+
+// failedDeploymentNotifyWindow is how recent a failed deployment must be to be notified: older
+// ones are only re-collected for the metrics
+const failedDeploymentNotifyWindow = 24 * time.Hour
+
+const doraNoData = "No data"
+
+// MetricsService computes DORA metrics from the CI/CD and observability integrations
+// of each organization and keeps daily snapshots for trends
+type MetricsService struct {
+	integrationService  *IntegrationService
+	incidentService     *IncidentService
+	notificationService *NotificationService
+	serviceRepo         *repository.ServiceRepository
+	snapshotRepo        *repository.DORASnapshotRepository
+	log                 *logger.Logger
+
+	mu          sync.Mutex
+	lastRefresh map[string]time.Time
+}
+
+func NewMetricsService(
+	integrationService *IntegrationService,
+	incidentService *IncidentService,
+	notificationService *NotificationService,
+	serviceRepo *repository.ServiceRepository,
+	snapshotRepo *repository.DORASnapshotRepository,
+	log *logger.Logger,
+) *MetricsService {
+	return &MetricsService{
+		integrationService:  integrationService,
+		incidentService:     incidentService,
+		notificationService: notificationService,
+		serviceRepo:         serviceRepo,
+		snapshotRepo:        snapshotRepo,
+		log:                 log,
+		lastRefresh:         make(map[string]time.Time),
+	}
 }
 
-func (m *MetricsService) GetDashboardMetrics() map[string]interface{} {
+// GetDashboardMetrics summarizes the last 7 days compared with the 7 days before
+func (s *MetricsService) GetDashboardMetrics(organizationUUID string) (map[string]interface{}, error) {
+	services, err := s.serviceRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+
+	today := startOfDay(time.Now())
+	windowStart := today.AddDate(0, 0, -13)
+	if err := s.ensureSnapshots(organizationUUID, windowStart); err != nil {
+		return nil, err
+	}
+
+	snapshots, err := s.snapshotRepo.List(organizationUUID, domain.DORAScopeOrganization, organizationUUID, windowStart, today)
+	if err != nil {
+		return nil, err
+	}
+
+	currentStart := today.AddDate(0, 0, -6)
+	var previous, current []domain.DORASnapshot
+	for _, snapshot := range snapshots {
+		if snapshot.Date.Before(currentStart) {
+			previous = append(previous, snapshot)
+		} else {
+			current = append(current, snapshot)
+		}
+	}
+
+	now := aggregateDORA(domain.DORAScopeOrganization, organizationUUID, current, currentStart, time.Now(), 7)
+	before := aggregateDORA(domain.DORAScopeOrganization, organizationUUID, previous, windowStart, currentStart, 7)
+
+	successRate := func(m *domain.DORAMetrics) float64 {
+		if m.ChangeFailureRate.Samples == 0 {
+			return 0
+		}
+		return 100 - m.ChangeFailureRate.Value
+	}
+
 	return map[string]interface{}{
-		"activeServices": 42,
-		"deployFrequency": map[string]interface{}{
-			"value":  "23/dia",
-			"change": "+12%",
-			"trend":  "up",
-		},
-		"mttr": map[string]interface{}{
-			"value":  "18min",
-			"change": "-8%",
-			"trend":  "down",
-		},
-		"successRate": map[string]interface{}{
-			"value":  "99.2%",
-			"change": "+0.3%",
-			"trend":  "up",
-		},
-	}
-}
-
-func (m *MetricsService) GetDORAMetrics() map[string]interface{} {
+		"activeServices": len(services),
+		"deployFrequency": dashboardMetric(
+			fmt.Sprintf("%.1f/dia", now.DeploymentFrequency.Value),
+			now.DeploymentFrequency.Value, before.DeploymentFrequency.Value,
+		),
+		"mttr": dashboardMetric(
+			fmt.Sprintf("%.0fmin", now.TimeToRestore.Value),
+			now.TimeToRestore.Value, before.TimeToRestore.Value,
+		),
+		"successRate": dashboardMetric(
+			fmt.Sprintf("%.1f%%", successRate(now)),
+			successRate(now), successRate(before),
+		),
+	}, nil
+}
+
+// GetDORAMetrics returns the DORA metrics of one scope over the last `days` days
+func (s *MetricsService) GetDORAMetrics(organizationUUID, scope, scopeKey string, days int) (*domain.DORAMetrics, error) {
+	if scope == domain.DORAScopeOrganization {
+		scopeKey = organizationUUID
+	}
+
+	windowStart, windowEnd := doraWindow(days)
+	if err := s.ensureSnapshots(organizationUUID, windowStart); err != nil {
+		return nil, err
+	}
+
+	snapshots, err := s.snapshotRepo.List(organizationUUID, scope, scopeKey, windowStart, windowEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	return aggregateDORA(scope, scopeKey, snapshots, windowStart, windowEnd, days), nil
+}
+
+// GetDORABreakdown returns the DORA metrics of every service or squad over the last `days` days
+func (s *MetricsService) GetDORABreakdown(organizationUUID, scope string, days int) ([]domain.DORAMetrics, error) {
+	windowStart, windowEnd := doraWindow(days)
+	if err := s.ensureSnapshots(organizationUUID, windowStart); err != nil {
+		return nil, err
+	}
+
+	snapshots, err := s.snapshotRepo.ListByScope(organizationUUID, scope, windowStart, windowEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[string][]domain.DORASnapshot)
+	var keys []string
+	for _, snapshot := range snapshots {
+		if _, ok := byKey[snapshot.ScopeKey]; !ok {
+			keys = append(keys, snapshot.ScopeKey)
+		}
+		byKey[snapshot.ScopeKey] = append(byKey[snapshot.ScopeKey], snapshot)
+	}
+
+	breakdown := make([]domain.DORAMetrics, 0, len(keys))
+	for _, key := range keys {
+		breakdown = append(breakdown, *aggregateDORA(scope, key, byKey[key], windowStart, windowEnd, days))
+	}
+
+	return breakdown, nil
+}
+
+// RefreshDORAWindow re-collects every day of the last `days` days and returns the organization metrics
+func (s *MetricsService) RefreshDORAWindow(organizationUUID string, days int) (*domain.DORAMetrics, error) {
+	windowStart, windowEnd := doraWindow(days)
+	if err := s.RefreshSnapshots(organizationUUID, windowStart, windowEnd); err != nil {
+		return nil, err
+	}
+
+	snapshots, err := s.snapshotRepo.List(organizationUUID, domain.DORAScopeOrganization, organizationUUID, windowStart, windowEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	return aggregateDORA(domain.DORAScopeOrganization, organizationUUID, snapshots, windowStart, windowEnd, days), nil
+}
+
+// RefreshSnapshots collects deployments and restores between start and end
+// and stores one snapshot per day for the organization, each service and each squad
+func (s *MetricsService) RefreshSnapshots(organizationUUID string, start, end time.Time) error {
+	s.log.Infow("Refreshing DORA snapshots", "organizationUUID", organizationUUID, "start", start, "end", end)
+
+	services, err := s.serviceRepo.GetAll()
+	if err != nil {
+		return fmt.Errorf("failed to list services: %w", err)
+	}
+
+	squads := make(map[string]string, len(services))
+	for _, svc := range services {
+		squads[strings.ToLower(svc.Name)] = svc.Squad
+	}
+
+	deployments := s.collectDeployments(organizationUUID, services, start, end)
+	restores := s.collectRestores(organizationUUID, start, end)
+	s.notifyFailedDeployments(organizationUUID, deployments)
+
+	snapshots := buildSnapshots(organizationUUID, squads, deployments, restores, start, end)
+	for _, snapshot := range snapshots {
+		if err := s.snapshotRepo.Upsert(snapshot); err != nil {
+			return fmt.Errorf("failed to store DORA snapshot: %w", err)
+		}
+	}
+
+	s.mu.Lock()
+	s.lastRefresh[organizationUUID] = time.Now()
+	s.mu.Unlock()
+
+	s.log.Infow("DORA snapshots refreshed",
+		"organizationUUID", organizationUUID,
+		"deployments", len(deployments),
+		"restores", len(restores),
+		"snapshots", len(snapshots),
+	)
+	return nil
+}
+
+// notifyFailedDeployments publishes a deploy.failed event for each recent failed deployment.
+// Deployments are collected again on every refresh, so the dedup window outlasts the notify window.
+func (s *MetricsService) notifyFailedDeployments(organizationUUID string, deployments []domain.DeploymentEvent) {
+	since := time.Now().Add(-failedDeploymentNotifyWindow)
+	for _, deployment := range deployments {
+		if deployment.Succeeded || deployment.DeployedAt.Before(since) {
+			continue
+		}
+
+		fields := map[string]string{
+			"Source":      deployment.Source,
+			"Deployed at": deployment.DeployedAt.Format(time.RFC3339),
+		}
+		if deployment.Revision != "" {
+			fields["Revision"] = deployment.Revision
+		}
+
+		s.notificationService.Notify(organizationUUID, domain.NotificationEvent{
+			Type:               domain.NotificationEventDeployFailed,
+			Severity:           domain.IncidentSeverityHigh,
+			Title:              fmt.Sprintf("Production deployment of %s failed", deployment.Service),
+			ServiceName:        deployment.Service,
+			Fields:             fields,
+			Labels:             map[string]string{"source": deployment.Source},
+			DedupKey:           fmt.Sprintf("deploy:%s:%s:%d", deployment.Source, deployment.Service, deployment.DeployedAt.Unix()),
+			DedupWindowMinutes: int(2 * failedDeploymentNotifyWindow / time.Minute),
+			OccurredAt:         deployment.DeployedAt,
+		})
+	}
+}
+
+// ensureSnapshots collects the days of the window that have no snapshot yet,
+// and re-collects the current (partial) day at most every doraRefreshInterval
+func (s *MetricsService) ensureSnapshots(organizationUUID string, windowStart time.Time) error {
+	today := startOfDay(time.Now())
+
+	existing, err := s.snapshotRepo.List(organizationUUID, domain.DORAScopeOrganization, organizationUUID, windowStart, today)
+	if err != nil {
+		return err
+	}
+
+	have := make(map[string]bool, len(existing))
+	for _, snapshot := range existing {
+		have[snapshot.Date.Format("2006-01-02")] = true
+	}
+
+	var from time.Time
+	for day := windowStart; !day.After(today); day = day.AddDate(0, 0, 1) {
+		if !have[day.Format("2006-01-02")] {
+			from = day
+			break
+		}
+	}
+
+	if from.IsZero() || from.Equal(today) {
+		s.mu.Lock()
+		last := s.lastRefresh[organizationUUID]
+		s.mu.Unlock()
+		if time.Since(last) < doraRefreshInterval {
+			return nil
+		}
+		from = today
+	}
+
+	return s.RefreshSnapshots(organizationUUID, from, time.Now())
+}
+
+// buildSnapshots buckets events per UTC day and scope. Every day of the range gets an
+// organization snapshot, even without events, so collected days can be told apart from missing ones.
+func buildSnapshots(organizationUUID string, squads map[string]string, deployments []domain.DeploymentEvent, restores []domain.RestoreEvent, start, end time.Time) []*domain.DORASnapshot {
+	snapshots := make(map[string]*domain.DORASnapshot)
+
+	get := func(scope, key string, day time.Time) *domain.DORASnapshot {
+		id := scope + "|" + key + "|" + day.Format("2006-01-02")
+		if snapshot, ok := snapshots[id]; ok {
+			return snapshot
+		}
+		snapshot := &domain.DORASnapshot{
+			OrganizationUUID: organizationUUID,
+			Scope:            scope,
+			ScopeKey:         key,
+			Date:             day,
+		}
+		snapshots[id] = snapshot
+		return snapshot
+	}
+
+	scopesFor := func(service string, day time.Time) []*domain.DORASnapshot {
+		scoped := []*domain.DORASnapshot{get(domain.DORAScopeOrganization, organizationUUID, day)}
+		if service != "" {
+			scoped = append(scoped, get(domain.DORAScopeService, service, day))
+			if squad := squads[strings.ToLower(service)]; squad != "" {
+				scoped = append(scoped, get(domain.DORAScopeSquad, squad, day))
+			}
+		}
+		return scoped
+	}
+
+	for day := startOfDay(start); !day.After(end); day = day.AddDate(0, 0, 1) {
+		get(domain.DORAScopeOrganization, organizationUUID, day)
+	}
+
+	for _, deployment := range deployments {
+		for _, snapshot := range scopesFor(deployment.Service, startOfDay(deployment.DeployedAt)) {
+			snapshot.Deployments++
+			if !deployment.Succeeded {
+				snapshot.FailedDeployments++
+				continue
+			}
+			for _, leadTime := range deployment.LeadTimes {
+				if leadTime < 0 {
+					continue
+				}
+				snapshot.LeadTimeMinutesTotal += leadTime.Minutes()
+				snapshot.LeadTimeSamples++
+			}
+		}
+	}
+
+	for _, restore := range restores {
+		duration := restore.ResolvedAt.Sub(restore.StartedAt)
+		if duration < 0 {
+			continue
+		}
+		for _, snapshot := range scopesFor(restore.Service, startOfDay(restore.ResolvedAt)) {
+			snapshot.RestoreMinutesTotal += duration.Minutes()
+			snapshot.Restores++
+		}
+	}
+
+	result := make([]*domain.DORASnapshot, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		result = append(result, snapshot)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Date.Before(result[j].Date) })
+
+	return result
+}
+
+// aggregateDORA computes the four DORA metrics from daily snapshots
+func aggregateDORA(scope, scopeKey string, snapshots []domain.DORASnapshot, windowStart, windowEnd time.Time, days int) *domain.DORAMetrics {
+	var deployments, failed, leadSamples, restores int
+	var leadTotal, restoreTotal float64
+
+	trend := make([]domain.DORATrendPoint, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		deployments += snapshot.Deployments
+		failed += snapshot.FailedDeployments
+		leadTotal += snapshot.LeadTimeMinutesTotal
+		leadSamples += snapshot.LeadTimeSamples
+		restoreTotal += snapshot.RestoreMinutesTotal
+		restores += snapshot.Restores
+
+		point := domain.DORATrendPoint{
+			Date:              snapshot.Date.Format("2006-01-02"),
+			Deployments:       snapshot.Deployments,
+			FailedDeployments: snapshot.FailedDeployments,
+		}
+		if snapshot.LeadTimeSamples > 0 {
+			point.LeadTimeMinutes = snapshot.LeadTimeMinutesTotal / float64(snapshot.LeadTimeSamples)
+		}
+		if snapshot.Restores > 0 {
+			point.TimeToRestoreMinutes = snapshot.RestoreMinutesTotal / float64(snapshot.Restores)
+		}
+		trend = append(trend, point)
+	}
+
+	metrics := &domain.DORAMetrics{
+		Scope:       scope,
+		ScopeKey:    scopeKey,
+		WindowStart: windowStart,
+		WindowEnd:   windowEnd,
+		Days:        days,
+		Trend:       trend,
+	}
+
+	successful := deployments - failed
+	perDay := float64(successful) / float64(days)
+	metrics.DeploymentFrequency = domain.DORAMetric{
+		Value:          perDay,
+		Unit:           "per day",
+		Classification: classifyDeploymentFrequency(perDay, successful),
+		Samples:        successful,
+	}
+
+	metrics.LeadTimeForChanges = domain.DORAMetric{Unit: "minutes", Classification: doraNoData, Samples: leadSamples}
+	if leadSamples > 0 {
+		metrics.LeadTimeForChanges.Value = leadTotal / float64(leadSamples)
+		metrics.LeadTimeForChanges.Classification = classifyDuration(metrics.LeadTimeForChanges.Value, 24*60, 7*24*60, 30*24*60)
+	}
+
+	metrics.ChangeFailureRate = domain.DORAMetric{Unit: "percent", Classification: doraNoData, Samples: deployments}
+	if deployments > 0 {
+		rate := float64(failed) / float64(deployments) * 100
+		metrics.ChangeFailureRate.Value = rate
+		metrics.ChangeFailureRate.Classification = classifyChangeFailureRate(rate)
+	}
+
+	metrics.TimeToRestore = domain.DORAMetric{Unit: "minutes", Classification: doraNoData, Samples: restores}
+	if restores > 0 {
+		metrics.TimeToRestore.Value = restoreTotal / float64(restores)
+		metrics.TimeToRestore.Classification = classifyDuration(metrics.TimeToRestore.Value, 60, 24*60, 7*24*60)
+	}
+
+	return metrics
+}
+
+// classifyDeploymentFrequency: Elite daily or more, High weekly, Medium monthly
+func classifyDeploymentFrequency(perDay float64, samples int) string {
+	switch {
+	case samples == 0:
+		return doraNoData
+	case perDay >= 1:
+		return domain.DORAClassificationElite
+	case perDay >= 1.0/7:
+		return domain.DORAClassificationHigh
+	case perDay >= 1.0/30:
+		return domain.DORAClassificationMedium
+	default:
+		return domain.DORAClassificationLow
+	}
+}
+
+// classifyChangeFailureRate: Elite up to 5%, High 10%, Medium 15%
+func classifyChangeFailureRate(rate float64) string {
+	switch {
+	case rate <= 5:
+		return domain.DORAClassificationElite
+	case rate <= 10:
+		return domain.DORAClassificationHigh
+	case rate <= 15:
+		return domain.DORAClassificationMedium
+	default:
+		return domain.DORAClassificationLow
+	}
+}
+
+func classifyDuration(minutes, elite, high, medium float64) string {
+	switch {
+	case minutes < elite:
+		return domain.DORAClassificationElite
+	case minutes < high:
+		return domain.DORAClassificationHigh
+	case minutes < medium:
+		return domain.DORAClassificationMedium
+	default:
+		return domain.DORAClassificationLow
+	}
+}
+
+func dashboardMetric(value string, current, previous float64) map[string]interface{} {
+	change := "0%"
+	if previous != 0 {
+		change = fmt.Sprintf("%+.0f%%", (current-previous)/previous*100)
+	}
+
+	trend := "stable"
+	if current > previous {
+		trend = "up"
+	} else if current < previous {
+		trend = "down"
+	}
+
 	return map[string]interface{}{
-		"deploymentFrequency": map[string]interface{}{
-			"value":       23.5,
-			"unit":        "per day",
-			"classification": "Elite",
-		},
-		"leadTimeForChanges": map[string]interface{}{
-			"value":       45,
-			"unit":        "minutes",
-			"classification": "Elite",
-		},
-		"changeFailureRate": map[string]interface{}{
-			"value":       2.3,
-			"unit":        "percent",
-			"classification": "High",
-		},
-		"timeToRestore": map[string]interface{}{
-			"value":       18,
-			"unit":        "minutes",
-			"classification": "Elite",
-		},
+		"value":  value,
+		"change": change,
+		"trend":  trend,
 	}
 }
+
+func doraWindow(days int) (time.Time, time.Time) {
+	end := time.Now()
+	return startOfDay(end).AddDate(0, 0, -(days - 1)), end
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,474 @@
+package service
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"text/template"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+)
+
+// errNotificationDestinationBlocked is returned when a webhook resolves to an internal address
+var errNotificationDestinationBlocked = errors.New("webhook destination is a loopback, private or link-local address")
+
+// notificationHTTPClient posts the deliveries of webhook channels and the Slack and Teams webhooks.
+// Webhook URLs are set by the organization, so connections are checked after DNS resolution (on every redirect as well) and
+// never reach the internal network or the cloud metadata service. Proxies are not used, since
+// they would dial the destination on our behalf.
+var notificationHTTPClient = &http.Client{
+	Timeout: 10 * time.Second,
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: 5 * time.Second,
+			Control: checkNotificationDestination,
+		}).DialContext,
+		TLSHandshakeTimeout: 5 * time.Second,
+		MaxIdleConns:        10,
+		IdleConnTimeout:     90 * time.Second,
+	},
+}
+
+// checkNotificationDestination refuses connections to addresses that are not public
+func checkNotificationDestination(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := addrPort.Addr().Unmap()
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsUnspecified() || ip.IsMulticast() || ip.IsInterfaceLocalMulticast() || carrierGradeNAT.Contains(ip) {
+		return errNotificationDestinationBlocked
+	}
+	return nil
+}
+
+// carrierGradeNAT is the shared address space (RFC 6598), internal to providers
+var carrierGradeNAT = netip.MustParsePrefix("100.64.0.0/10")
+
+const defaultNotificationTitle = `{{if .Severity}}[{{upper .Severity}}] {{end}}{{.Title}}{{if .ServiceName}} — {{.ServiceName}}{{end}}`
+
+// defaultNotificationTemplates are the title and body templates of each channel type, used when
+// the channel does not set its own. Webhook channels post the event as JSON by default.
+var defaultNotificationTemplates = map[string][2]string{
+	domain.NotificationChannelSlack: {defaultNotificationTitle, `{{if .Message}}{{.Message}}
+{{end}}{{range $name, $value := .Fields}}*{{$name}}:* {{$value}}
+{{end}}{{if .Squad}}*Squad:* {{.Squad}}
+{{end}}{{if .URL}}<{{.URL}}|Open>{{end}}`},
+	domain.NotificationChannelTeams: {defaultNotificationTitle, `{{if .Message}}{{.Message}}
+
+{{end}}{{range $name, $value := .Fields}}**{{$name}}:** {{$value}}
+
+{{end}}{{if .Squad}}**Squad:** {{.Squad}}
+
+{{end}}{{if .URL}}[Open]({{.URL}}){{end}}`},
+	domain.NotificationChannelEmail: {"[PlatifyX] " + defaultNotificationTitle, `{{if .Message}}{{.Message}}
+
+{{end}}{{range $name, $value := .Fields}}{{$name}}: {{$value}}
+{{end}}{{if .Squad}}Squad: {{.Squad}}
+{{end}}{{if .URL}}
+{{.URL}}
+{{end}}
+Event: {{.Type}} at {{.OccurredAt.Format "2006-01-02 15:04 MST"}}`},
+	domain.NotificationChannelWebhook: {defaultNotificationTitle, ""},
+}
+
+var notificationTemplateFuncs = template.FuncMap{
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// notificationWebhookPayload is the body webhook channels receive when they set no body template
+type notificationWebhookPayload struct {
+	OrganizationUUID string                   `json:"organizationUuid"`
+	Title            string                   `json:"title"`
+	Event            domain.NotificationEvent `json:"event"`
+}
+
+// ListChannels returns the notification channels of the organization, without their secrets
+func (s *NotificationService) ListChannels(organizationUUID string) ([]domain.NotificationChannel, error) {
+	channels, err := s.channelRepo.List(organizationUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification channels: %w", err)
+	}
+	for i := range channels {
+		maskNotificationChannel(&channels[i])
+	}
+	return channels, nil
+}
+
+// CreateChannel adds a notification channel to the organization
+func (s *NotificationService) CreateChannel(organizationUUID string, req domain.NotificationChannelRequest, actor string) (*domain.NotificationChannel, error) {
+	channel := &domain.NotificationChannel{
+		OrganizationUUID: organizationUUID,
+		Enabled:          true,
+		CreatedBy:        actor,
+	}
+	if err := s.applyNotificationChannel(channel, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.channelRepo.Create(channel); err != nil {
+		return nil, err
+	}
+
+	s.log.Infow("Notification channel created", "organizationUUID", organizationUUID, "channel", channel.Name, "type", channel.Type)
+	maskNotificationChannel(channel)
+	return channel, nil
+}
+
+// UpdateChannel replaces the settings of a notification channel. Secrets sent back masked are kept.
+func (s *NotificationService) UpdateChannel(organizationUUID string, id int, req domain.NotificationChannelRequest) (*domain.NotificationChannel, error) {
+	channel, err := s.getChannel(organizationUUID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyNotificationChannel(channel, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.channelRepo.Update(channel); err != nil {
+		return nil, err
+	}
+
+	maskNotificationChannel(channel)
+	return channel, nil
+}
+
+// DeleteChannel removes a notification channel, its deliveries and its use by the rules
+func (s *NotificationService) DeleteChannel(organizationUUID string, id int) error {
+	deleted, err := s.channelRepo.Delete(organizationUUID, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete notification channel: %w", err)
+	}
+	if !deleted {
+		return &domain.NotFoundError{Resource: "notification channel", ID: fmt.Sprint(id)}
+	}
+	return nil
+}
+
+// TestChannel sends a sample notification to a channel right away, without queuing it
+func (s *NotificationService) TestChannel(organizationUUID string, id int) error {
+	channel, err := s.getChannel(organizationUUID, id)
+	if err != nil {
+		return err
+	}
+
+	event := domain.NotificationEvent{
+		Type:       "notification.test",
+		Severity:   domain.IncidentSeverityLow,
+		Title:      "Test notification",
+		Message:    fmt.Sprintf("This is a test of the %s notification channel.", channel.Name),
+		Fields:     map[string]string{"Channel": channel.Name},
+		OccurredAt: time.Now(),
+	}
+	if err := s.sendToChannel(organizationUUID, channel, event); err != nil {
+		return fmt.Errorf("%w: %v", ErrNotificationSendFailed, err)
+	}
+	return nil
+}
+
+func (s *NotificationService) getChannel(organizationUUID string, id int) (*domain.NotificationChannel, error) {
+	channel, err := s.channelRepo.GetByID(organizationUUID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification channel: %w", err)
+	}
+	if channel == nil {
+		return nil, &domain.NotFoundError{Resource: "notification channel", ID: fmt.Sprint(id)}
+	}
+	return channel, nil
+}
+
+// applyNotificationChannel validates a request and copies it to the channel, sealing its secrets
+func (s *NotificationService) applyNotificationChannel(channel *domain.NotificationChannel, req domain.NotificationChannelRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return &domain.ValidationError{Field: "name", Message: "is required"}
+	}
+	if notificationIntegrationChannels[name] {
+		return &domain.ValidationError{Field: "name", Message: fmt.Sprintf("%q is reserved for the %s integration", name, name)}
+	}
+	if !notificationChannelTypes[req.Type] {
+		return &domain.ValidationError{Field: "type", Message: "must be slack, teams, email or webhook"}
+	}
+
+	// Masked secrets are the ones the API returned: keep the stored values
+	config := domain.NotificationChannelConfig{
+		WebhookURL: strings.TrimSpace(req.Config.WebhookURL),
+		Secret:     req.Config.Secret,
+	}
+	if config.WebhookURL == domain.MaskedSecretValue {
+		config.WebhookURL = channel.Config.WebhookURL
+	} else if config.WebhookURL != "" {
+		if parsed, err := url.Parse(config.WebhookURL); err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+			return &domain.ValidationError{Field: "config.webhookUrl", Message: "must be an http(s) URL"}
+		}
+	}
+	if config.Secret == domain.MaskedSecretValue {
+		config.Secret = channel.Config.Secret
+	}
+
+	switch req.Type {
+	case domain.NotificationChannelEmail:
+		config = domain.NotificationChannelConfig{Recipients: []string{}}
+		for _, recipient := range trimmedStrings(req.Config.Recipients) {
+			if _, err := mail.ParseAddress(recipient); err != nil {
+				return &domain.ValidationError{Field: "config.recipients", Message: fmt.Sprintf("invalid email address %q", recipient)}
+			}
+			config.Recipients = append(config.Recipients, recipient)
+		}
+		if len(config.Recipients) == 0 {
+			return &domain.ValidationError{Field: "config.recipients", Message: "at least one recipient is required"}
+		}
+	case domain.NotificationChannelWebhook:
+		if config.WebhookURL == "" {
+			return &domain.ValidationError{Field: "config.webhookUrl", Message: "is required"}
+		}
+	default:
+		// Slack and Teams channels without a webhook send to the integration of the organization
+		config.Secret = ""
+	}
+
+	for field, text := range map[string]string{"titleTemplate": req.TitleTemplate, "bodyTemplate": req.BodyTemplate} {
+		if err := validateNotificationTemplate(text); err != nil {
+			return &domain.ValidationError{Field: field, Message: err.Error()}
+		}
+	}
+
+	sealed, err := s.sealChannelConfig(channel.OrganizationUUID, config)
+	if err != nil {
+		return err
+	}
+
+	channel.Name = name
+	channel.Type = req.Type
+	if req.Enabled != nil {
+		channel.Enabled = *req.Enabled
+	}
+	channel.Config = sealed
+	channel.TitleTemplate = req.TitleTemplate
+	channel.BodyTemplate = req.BodyTemplate
+	return nil
+}
+
+func (s *NotificationService) sealChannelConfig(organizationUUID string, config domain.NotificationChannelConfig) (domain.NotificationChannelConfig, error) {
+	if s.credentials == nil {
+		return config, nil
+	}
+
+	var err error
+	if config.WebhookURL, err = s.credentials.SealValue(organizationUUID, config.WebhookURL); err != nil {
+		return config, fmt.Errorf("failed to seal notification channel webhook: %w", err)
+	}
+	if config.Secret, err = s.credentials.SealValue(organizationUUID, config.Secret); err != nil {
+		return config, fmt.Errorf("failed to seal notification channel secret: %w", err)
+	}
+	return config, nil
+}
+
+func (s *NotificationService) openChannelConfig(organizationUUID string, config domain.NotificationChannelConfig) (domain.NotificationChannelConfig, error) {
+	if s.credentials == nil {
+		if IsSealedValue(config.WebhookURL) || IsSealedValue(config.Secret) {
+			return config, fmt.Errorf("notification channel is encrypted but credential encryption is not configured")
+		}
+		return config, nil
+	}
+
+	var err error
+	if config.WebhookURL, err = s.credentials.OpenValue(organizationUUID, config.WebhookURL); err != nil {
+		return config, fmt.Errorf("failed to open notification channel webhook: %w", err)
+	}
+	if config.Secret, err = s.credentials.OpenValue(organizationUUID, config.Secret); err != nil {
+		return config, fmt.Errorf("failed to open notification channel secret: %w", err)
+	}
+	return config, nil
+}
+
+// maskNotificationChannel replaces the secrets of a channel before it is returned by the API
+func maskNotificationChannel(channel *domain.NotificationChannel) {
+	if channel.Config.WebhookURL != "" {
+		channel.Config.WebhookURL = domain.MaskedSecretValue
+	}
+	if channel.Config.Secret != "" {
+		channel.Config.Secret = domain.MaskedSecretValue
+	}
+}
+
+// validateNotificationTemplate parses a template and renders it with a sample event, so that
+// unknown fields are reported when the channel is saved rather than when it is sent to
+func validateNotificationTemplate(text string) error {
+	if text == "" {
+		return nil
+	}
+	_, err := renderNotificationTemplate(text, domain.NotificationEvent{
+		Type:        domain.NotificationEventDeployFailed,
+		Severity:    domain.IncidentSeverityHigh,
+		Title:       "Deployment failed",
+		Message:     "sample",
+		ServiceName: "sample-service",
+		Squad:       "sample-squad",
+		URL:         "https://example.com",
+		Fields:      map[string]string{"Revision": "abc123"},
+		Labels:      map[string]string{"env": "production"},
+		DedupKey:    "sample",
+		OccurredAt:  time.Now(),
+	})
+	return err
+}
+
+func renderNotificationTemplate(text string, event domain.NotificationEvent) (string, error) {
+	tmpl, err := template.New("notification").Funcs(notificationTemplateFuncs).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid template: %w", err)
+	}
+
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, event); err != nil {
+		return "", fmt.Errorf("invalid template: %w", err)
+	}
+	return strings.TrimSpace(rendered.String()), nil
+}
+
+// renderNotification renders the title and body of an event with the templates of a channel
+func renderNotification(channel *domain.NotificationChannel, event domain.NotificationEvent) (string, string, error) {
+	defaults := defaultNotificationTemplates[channel.Type]
+	titleTemplate := firstNonEmpty(channel.TitleTemplate, defaults[0])
+	bodyTemplate := firstNonEmpty(channel.BodyTemplate, defaults[1])
+
+	title, err := renderNotificationTemplate(titleTemplate, event)
+	if err != nil {
+		return "", "", err
+	}
+	body, err := renderNotificationTemplate(bodyTemplate, event)
+	if err != nil {
+		return "", "", err
+	}
+	return title, body, nil
+}
+
+// notificationColor is the color of the Slack attachment or Teams card of an event
+func notificationColor(event domain.NotificationEvent) string {
+	if event.Type == domain.NotificationEventAlertResolved {
+		return "2E7D32"
+	}
+	switch event.Severity {
+	case domain.IncidentSeverityCritical, "page", domain.IncidentSeverityHigh, "error":
+		return "D32F2F"
+	case domain.IncidentSeverityMedium, "warning":
+		return "F57C00"
+	default:
+		return "1976D2"
+	}
+}
+
+// sendToChannel renders an event and sends it to a channel
+func (s *NotificationService) sendToChannel(organizationUUID string, channel *domain.NotificationChannel, event domain.NotificationEvent) error {
+	title, body, err := renderNotification(channel, event)
+	if err != nil {
+		return &permanentDeliveryError{err: err}
+	}
+
+	config, err := s.openChannelConfig(organizationUUID, channel.Config)
+	if err != nil {
+		return &permanentDeliveryError{err: err}
+	}
+
+	color := notificationColor(event)
+	switch channel.Type {
+	case domain.NotificationChannelSlack:
+		slackConfig := &domain.SlackConfig{WebhookURL: config.WebhookURL}
+		if slackConfig.WebhookURL == "" {
+			if slackConfig, err = s.integrationService.GetSlackConfig(organizationUUID); err != nil {
+				return fmt.Errorf("failed to get Slack config: %w", err)
+			}
+			if slackConfig == nil {
+				return permanentf("Slack is not configured")
+			}
+		}
+		return permanentIfBlocked(NewSlackService(*slackConfig, s.log).SendAlert(title, body, "#"+color))
+	case domain.NotificationChannelTeams:
+		teamsConfig := &domain.TeamsConfig{WebhookURL: config.WebhookURL}
+		if teamsConfig.WebhookURL == "" {
+			if teamsConfig, err = s.integrationService.GetTeamsConfig(organizationUUID); err != nil {
+				return fmt.Errorf("failed to get Teams config: %w", err)
+			}
+			if teamsConfig == nil {
+				return permanentf("Teams is not configured")
+			}
+		}
+		return permanentIfBlocked(NewTeamsService(*teamsConfig, s.log).SendAlert(title, body, color))
+	case domain.NotificationChannelEmail:
+		return s.emailService.SendNotification(config.Recipients, title, body)
+	case domain.NotificationChannelWebhook:
+		return postNotificationWebhook(organizationUUID, channel, config, event, title, body)
+	}
+
+	return permanentf("unknown notification channel type %q", channel.Type)
+}
+
+// permanentIfBlocked makes deliveries to internal destinations permanent failures: retrying cannot help
+func permanentIfBlocked(err error) error {
+	if errors.Is(err, errNotificationDestinationBlocked) {
+		return &permanentDeliveryError{err: errNotificationDestinationBlocked}
+	}
+	return err
+}
+
+// postNotificationWebhook posts an event to a webhook channel: the rendered body when the channel
+// has a body template, the event as JSON otherwise. The body is signed when the channel has a secret.
+func postNotificationWebhook(organizationUUID string, channel *domain.NotificationChannel, config domain.NotificationChannelConfig, event domain.NotificationEvent, title, body string) error {
+	payload := []byte(body)
+	if channel.BodyTemplate == "" {
+		var err error
+		payload, err = json.Marshal(notificationWebhookPayload{
+			OrganizationUUID: organizationUUID,
+			Title:            title,
+			Event:            event,
+		})
+		if err != nil {
+			return &permanentDeliveryError{err: err}
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodPost, config.WebhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return &permanentDeliveryError{err: err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "PlatifyX-Notifications")
+	req.Header.Set("X-PlatifyX-Event", event.Type)
+	if config.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(config.Secret))
+		mac.Write(payload)
+		req.Header.Set("X-PlatifyX-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := notificationHTTPClient.Do(req)
+	if err != nil {
+		return permanentIfBlocked(fmt.Errorf("failed to post webhook: %w", err))
+	}
+	defer resp.Body.Close()
+
+	// The response body is not kept: the delivery log is readable through the API and must not
+	// echo what the destination answered
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/PlatifyX/platifyx-core/pkg/logger"
+)
+
+func TestCheckNotificationDestination(t *testing.T) {
+	blocked := []string{
+		"127.0.0.1:80", "[::1]:443", "10.0.0.5:8080", "172.16.3.4:80", "192.168.1.1:80",
+		"169.254.169.254:80", "[fe80::1]:80", "0.0.0.0:80", "100.64.0.1:80", "[::ffff:127.0.0.1]:80",
+		"[fd00::1]:80",
+	}
+	for _, address := range blocked {
+		if err := checkNotificationDestination("tcp", address, nil); !errors.Is(err, errNotificationDestinationBlocked) {
+			t.Errorf("%s was allowed", address)
+		}
+	}
+
+	for _, address := range []string{"8.8.8.8:443", "[2606:4700:4700::1111]:443"} {
+		if err := checkNotificationDestination("tcp", address, nil); err != nil {
+			t.Errorf("%s was refused: %v", address, err)
+		}
+	}
+}
+
+func TestWebhookRefusesLoopbackDestination(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+	}))
+	defer srv.Close()
+
+	channel := &domain.NotificationChannel{Name: "internal", Type: domain.NotificationChannelWebhook}
+	config := domain.NotificationChannelConfig{WebhookURL: srv.URL}
+	event := domain.NotificationEvent{Type: "notification.test", Title: "Test"}
+
+	err := postNotificationWebhook("org", channel, config, event, "Test", "")
+	var permanent *permanentDeliveryError
+	if !errors.As(err, &permanent) || !errors.Is(err, errNotificationDestinationBlocked) {
+		t.Fatalf("got %v, want a permanent blocked destination error", err)
+	}
+	if atomic.LoadInt32(&hits) != 0 {
+		t.Error("the loopback server received the webhook")
+	}
+}
+
+func TestSlackAndTeamsRefuseLoopbackDestination(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+	}))
+	defer srv.Close()
+
+	log := logger.NewLogger("development")
+	sends := map[string]func() error{
+		"slack": func() error {
+			return NewSlackService(domain.SlackConfig{WebhookURL: srv.URL}, log).SendAlert("Test", "body", "#1976D2")
+		},
+		"teams": func() error {
+			return NewTeamsService(domain.TeamsConfig{WebhookURL: srv.URL}, log).SendAlert("Test", "body", "1976D2")
+		},
+	}
+	for name, send := range sends {
+		var permanent *permanentDeliveryError
+		if err := permanentIfBlocked(send()); !errors.As(err, &permanent) {
+			t.Errorf("%s: got %v, want a permanent blocked destination error", name, err)
+		}
+	}
+	if atomic.LoadInt32(&hits) != 0 {
+		t.Error("the loopback server received a webhook")
+	}
+}
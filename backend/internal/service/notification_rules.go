@@ -0,0 +1,195 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+)
+
+// ListNotificationRules returns the notification rules of the organization
+func (s *NotificationService) ListNotificationRules(organizationUUID string) ([]domain.NotificationRule, error) {
+	return s.ruleRepo.List(organizationUUID, false)
+}
+
+// CreateNotificationRule adds a notification rule to the organization
+func (s *NotificationService) CreateNotificationRule(organizationUUID string, req domain.NotificationRuleRequest, actor string) (*domain.NotificationRule, error) {
+	rule := &domain.NotificationRule{
+		OrganizationUUID: organizationUUID,
+		Enabled:          true,
+		CreatedBy:        actor,
+	}
+	if err := s.applyNotificationRule(rule, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.ruleRepo.Create(rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// UpdateNotificationRule replaces the criteria and channels of a notification rule
+func (s *NotificationService) UpdateNotificationRule(organizationUUID string, id int, req domain.NotificationRuleRequest) (*domain.NotificationRule, error) {
+	rule, err := s.ruleRepo.GetByID(organizationUUID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification rule: %w", err)
+	}
+	if rule == nil {
+		return nil, &domain.NotFoundError{Resource: "notification rule", ID: fmt.Sprint(id)}
+	}
+
+	if err := s.applyNotificationRule(rule, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.ruleRepo.Update(rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// DeleteNotificationRule removes a notification rule of the organization
+func (s *NotificationService) DeleteNotificationRule(organizationUUID string, id int) error {
+	deleted, err := s.ruleRepo.Delete(organizationUUID, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete notification rule: %w", err)
+	}
+	if !deleted {
+		return &domain.NotFoundError{Resource: "notification rule", ID: fmt.Sprint(id)}
+	}
+	return nil
+}
+
+// applyNotificationRule validates a request and copies it to the rule
+func (s *NotificationService) applyNotificationRule(rule *domain.NotificationRule, req domain.NotificationRuleRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return &domain.ValidationError{Field: "name", Message: "is required"}
+	}
+
+	eventTypes := []string{}
+	for _, eventType := range trimmedStrings(req.EventTypes) {
+		if !slices.Contains(domain.NotificationEventTypes, eventType) {
+			return &domain.ValidationError{Field: "eventTypes", Message: fmt.Sprintf("unknown event type %q (use %s)", eventType, strings.Join(domain.NotificationEventTypes, ", "))}
+		}
+		if !slices.Contains(eventTypes, eventType) {
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+	if req.OpenIncident && len(eventTypes) > 0 && !slices.Contains(eventTypes, domain.NotificationEventAlertFiring) {
+		return &domain.ValidationError{Field: "openIncident", Message: "requires the " + domain.NotificationEventAlertFiring + " event type"}
+	}
+
+	channels := []string{}
+	for _, channel := range req.Channels {
+		if !notificationIntegrationChannels[channel] {
+			return &domain.ValidationError{Field: "channels", Message: fmt.Sprintf("unknown channel %q (use slack or teams, or channelIds for notification channels)", channel)}
+		}
+		if !slices.Contains(channels, channel) {
+			channels = append(channels, channel)
+		}
+	}
+
+	channelIDs := []int{}
+	for _, id := range req.ChannelIDs {
+		channel, err := s.channelRepo.GetByID(rule.OrganizationUUID, id)
+		if err != nil {
+			return fmt.Errorf("failed to get notification channel: %w", err)
+		}
+		if channel == nil {
+			return &domain.ValidationError{Field: "channelIds", Message: fmt.Sprintf("notification channel %d does not exist", id)}
+		}
+		if !slices.Contains(channelIDs, id) {
+			channelIDs = append(channelIDs, id)
+		}
+	}
+	if len(channels) == 0 && len(channelIDs) == 0 && !req.OpenIncident {
+		return &domain.ValidationError{Field: "channels", Message: "at least one channel is required unless the rule opens incidents"}
+	}
+
+	services := trimmedStrings(req.Services)
+	for _, serviceName := range services {
+		svc, err := s.serviceRepo.GetByName(serviceName)
+		if err != nil {
+			return fmt.Errorf("failed to get service: %w", err)
+		}
+		if svc == nil {
+			return &domain.ValidationError{Field: "services", Message: fmt.Sprintf("service %q is not in the catalog", serviceName)}
+		}
+	}
+
+	quietHours, err := normalizeQuietHours(req.QuietHours)
+	if err != nil {
+		return err
+	}
+
+	if req.DedupWindowMinutes < 0 || req.DedupWindowMinutes > maxNotificationDedupWindow {
+		return &domain.ValidationError{Field: "dedupWindowMinutes", Message: fmt.Sprintf("must be between 0 and %d", maxNotificationDedupWindow)}
+	}
+
+	matchers := map[string]string{}
+	for name, value := range req.Matchers {
+		if name = strings.TrimSpace(name); name != "" {
+			matchers[name] = value
+		}
+	}
+
+	rule.Name = name
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+	rule.EventTypes = eventTypes
+	rule.Matchers = matchers
+	rule.Services = services
+	rule.Squads = trimmedStrings(req.Squads)
+	rule.Severities = lowerStrings(trimmedStrings(req.Severities))
+	rule.Channels = channels
+	rule.ChannelIDs = channelIDs
+	rule.QuietHours = quietHours
+	rule.DedupWindowMinutes = req.DedupWindowMinutes
+	rule.OpenIncident = req.OpenIncident
+	return nil
+}
+
+func normalizeQuietHours(quiet *domain.NotificationQuietHours) (*domain.NotificationQuietHours, error) {
+	if quiet == nil || (quiet.Start == "" && quiet.End == "") {
+		return nil, nil
+	}
+
+	start, err := parseClock(quiet.Start)
+	if err != nil {
+		return nil, &domain.ValidationError{Field: "quietHours.start", Message: "must be a time of day as HH:MM"}
+	}
+	end, err := parseClock(quiet.End)
+	if err != nil {
+		return nil, &domain.ValidationError{Field: "quietHours.end", Message: "must be a time of day as HH:MM"}
+	}
+	if start == end {
+		return nil, &domain.ValidationError{Field: "quietHours.end", Message: "must differ from the start"}
+	}
+
+	timezone := strings.TrimSpace(quiet.Timezone)
+	if timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return nil, &domain.ValidationError{Field: "quietHours.timezone", Message: fmt.Sprintf("unknown time zone %q", timezone)}
+		}
+	}
+
+	return &domain.NotificationQuietHours{
+		Start:            fmt.Sprintf("%02d:%02d", start/60, start%60),
+		End:              fmt.Sprintf("%02d:%02d", end/60, end%60),
+		Timezone:         timezone,
+		BypassSeverities: lowerStrings(trimmedStrings(quiet.BypassSeverities)),
+	}, nil
+}
+
+// lowerStrings lowercases the values in place and returns them
+func lowerStrings(values []string) []string {
+	for i := range values {
+		values[i] = strings.ToLower(values[i])
+	}
+	return values
+}
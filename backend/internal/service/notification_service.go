@@ -0,0 +1,575 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/PlatifyX/platifyx-core/internal/repository"
+	"github.com/PlatifyX/platifyx-core/pkg/logger"
+)
+
+const (
+	// notificationWorkerInterval is how often the delivery worker looks for due deliveries
+	notificationWorkerInterval = 15 * time.Second
+	notificationClaimBatch     = 20
+	// notificationSendLease delays the next attempt of a claimed delivery, so it is retried if
+	// the process stops while sending it
+	notificationSendLease   = 2 * time.Minute
+	notificationMaxAttempts = 5
+	// maxNotificationDedupWindow is the longest dedup window a rule may set, in minutes (7 days)
+	maxNotificationDedupWindow = 7 * 24 * 60
+	// integrationDownDedupWindow limits integration.down events to one per integration and hour
+	integrationDownDedupWindow = 60
+)
+
+// notificationRetryBackoff is the wait before each retry of a failed delivery
+var notificationRetryBackoff = []time.Duration{time.Minute, 5 * time.Minute, 15 * time.Minute, time.Hour}
+
+// ErrNotificationSendFailed is returned when a test notification could not be sent
+var ErrNotificationSendFailed = errors.New("failed to send test notification")
+
+var notificationChannelTypes = map[string]bool{
+	domain.NotificationChannelSlack:   true,
+	domain.NotificationChannelTeams:   true,
+	domain.NotificationChannelEmail:   true,
+	domain.NotificationChannelWebhook: true,
+}
+
+// notificationIntegrationChannels are the channels rules and events may name without creating
+// them: the Slack and Teams integrations of the organization
+var notificationIntegrationChannels = map[string]bool{
+	domain.NotificationChannelSlack: true,
+	domain.NotificationChannelTeams: true,
+}
+
+// NotificationService routes the events of each organization (failed deploys and quality gates,
+// cost anomalies, actions awaiting approval, integrations down, alerts) to its channels. Events
+// are matched against the notification rules, queued as one delivery per channel and sent by a
+// background worker that retries failed deliveries with backoff.
+type NotificationService struct {
+	ruleRepo           *repository.NotificationRuleRepository
+	channelRepo        *repository.NotificationChannelRepository
+	deliveryRepo       *repository.NotificationDeliveryRepository
+	serviceRepo        *repository.ServiceRepository
+	integrationService *IntegrationService
+	credentials        *CredentialService
+	emailService       *EmailService
+	log                *logger.Logger
+
+	// Buffered by one: publishes while the worker runs are picked up by its next run
+	wake     chan struct{}
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewNotificationService creates the service and starts its delivery worker. credentials may be
+// nil, in which case channel secrets are stored unencrypted.
+func NewNotificationService(
+	ruleRepo *repository.NotificationRuleRepository,
+	channelRepo *repository.NotificationChannelRepository,
+	deliveryRepo *repository.NotificationDeliveryRepository,
+	serviceRepo *repository.ServiceRepository,
+	integrationService *IntegrationService,
+	credentials *CredentialService,
+	emailService *EmailService,
+	log *logger.Logger,
+) *NotificationService {
+	s := &NotificationService{
+		ruleRepo:           ruleRepo,
+		channelRepo:        channelRepo,
+		deliveryRepo:       deliveryRepo,
+		serviceRepo:        serviceRepo,
+		integrationService: integrationService,
+		credentials:        credentials,
+		emailService:       emailService,
+		log:                log,
+		wake:               make(chan struct{}, 1),
+		stop:               make(chan struct{}),
+	}
+	go s.deliveryLoop()
+	return s
+}
+
+// Close stops the delivery worker; pending deliveries are sent after the next start
+func (s *NotificationService) Close() {
+	if s == nil {
+		return
+	}
+	s.stopOnce.Do(func() { close(s.stop) })
+}
+
+// Notify publishes an event on behalf of another service: failures are logged, never returned,
+// so notifying never breaks the operation that emitted the event
+func (s *NotificationService) Notify(organizationUUID string, event domain.NotificationEvent) {
+	if s == nil {
+		return
+	}
+	if _, err := s.Publish(organizationUUID, event); err != nil {
+		s.log.Errorw("Failed to publish notification event", "error", err, "organizationUUID", organizationUUID, "type", event.Type)
+	}
+}
+
+// notificationTarget is a channel an event is queued for, with the rule that routed it there
+type notificationTarget struct {
+	ruleID      *int
+	channelID   *int
+	channelType string
+	dedupWindow int
+	deliverAt   time.Time
+	deferred    bool // Held back by quiet hours
+}
+
+// notificationTargets collects the channels of an event in the order they are first routed to
+type notificationTargets struct {
+	event domain.NotificationEvent
+	now   time.Time
+	order []string
+	byKey map[string]*notificationTarget
+}
+
+func newNotificationTargets(event domain.NotificationEvent, now time.Time) *notificationTargets {
+	return &notificationTargets{event: event, now: now, byKey: map[string]*notificationTarget{}}
+}
+
+// add routes the event to a channel, for a rule or (nil) because the event names the channel
+func (t *notificationTargets) add(channelType string, channelID *int, rule *domain.NotificationRule) {
+	key := channelType
+	if channelID != nil {
+		key = fmt.Sprintf("%s:%d", channelType, *channelID)
+	}
+
+	deliverAt := t.now
+	dedupWindow := t.event.DedupWindowMinutes
+	var ruleID *int
+	if rule != nil {
+		ruleID = &rule.ID
+		dedupWindow = max(dedupWindow, rule.DedupWindowMinutes)
+		if until := quietUntil(rule.QuietHours, t.event.Severity, t.now); !until.IsZero() {
+			deliverAt = until
+		}
+	}
+
+	target, ok := t.byKey[key]
+	if !ok {
+		t.byKey[key] = &notificationTarget{
+			ruleID:      ruleID,
+			channelID:   channelID,
+			channelType: channelType,
+			dedupWindow: dedupWindow,
+			deliverAt:   deliverAt,
+			deferred:    deliverAt.After(t.now),
+		}
+		t.order = append(t.order, key)
+		return
+	}
+	// Routed by several rules: the longest dedup window and the earliest delivery win
+	target.dedupWindow = max(target.dedupWindow, dedupWindow)
+	if deliverAt.Before(target.deliverAt) {
+		target.ruleID = ruleID
+		target.deliverAt = deliverAt
+		target.deferred = deliverAt.After(t.now)
+	}
+}
+
+// list returns the targets in routing order
+func (t *notificationTargets) list() []*notificationTarget {
+	targets := make([]*notificationTarget, len(t.order))
+	for i, key := range t.order {
+		targets[i] = t.byKey[key]
+	}
+	return targets
+}
+
+// Publish queues an event for the channels of the rules it matches and for the channels it names.
+// Repeats of the event within the dedup window of a channel are dropped; during the quiet hours
+// of a rule, the delivery waits for them to end.
+func (s *NotificationService) Publish(organizationUUID string, event domain.NotificationEvent) (*domain.NotificationPublishResult, error) {
+	if err := s.normalizeEvent(&event); err != nil {
+		return nil, err
+	}
+
+	rules, err := s.MatchingRules(organizationUUID, event)
+	if err != nil {
+		return nil, err
+	}
+
+	channels, err := s.channelRepo.List(organizationUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification channels: %w", err)
+	}
+	byID := make(map[int]*domain.NotificationChannel, len(channels))
+	byName := make(map[string]*domain.NotificationChannel, len(channels))
+	for i := range channels {
+		byID[channels[i].ID] = &channels[i]
+		byName[channels[i].Name] = &channels[i]
+	}
+
+	now := time.Now()
+	targets := newNotificationTargets(event, now)
+	for i := range rules {
+		rule := &rules[i]
+		for _, channelType := range rule.Channels {
+			targets.add(channelType, nil, rule)
+		}
+		for _, id := range rule.ChannelIDs {
+			channel := byID[id]
+			if channel == nil || !channel.Enabled {
+				continue
+			}
+			targets.add(channel.Type, &channel.ID, rule)
+		}
+	}
+	for _, name := range event.Channels {
+		if notificationIntegrationChannels[name] {
+			targets.add(name, nil, nil)
+			continue
+		}
+		channel := byName[name]
+		if channel == nil {
+			s.log.Warnw("Notification event names an unknown channel", "organizationUUID", organizationUUID, "type", event.Type, "channel", name)
+			continue
+		}
+		if channel.Enabled {
+			targets.add(channel.Type, &channel.ID, nil)
+		}
+	}
+
+	result := &domain.NotificationPublishResult{}
+	for _, target := range targets.list() {
+		delivery := &domain.NotificationDelivery{
+			OrganizationUUID: organizationUUID,
+			RuleID:           target.ruleID,
+			ChannelID:        target.channelID,
+			ChannelType:      target.channelType,
+			EventType:        event.Type,
+			DedupKey:         event.DedupKey,
+			Event:            event,
+			NextAttemptAt:    target.deliverAt,
+		}
+
+		var dedupSince *time.Time
+		if target.dedupWindow > 0 {
+			since := now.Add(-time.Duration(target.dedupWindow) * time.Minute)
+			dedupSince = &since
+		}
+
+		queued, err := s.deliveryRepo.Enqueue(delivery, dedupSince)
+		if err != nil {
+			return nil, fmt.Errorf("failed to queue notification: %w", err)
+		}
+		switch {
+		case !queued:
+			result.Deduplicated++
+		case target.deferred:
+			result.Queued++
+			result.Deferred++
+		default:
+			result.Queued++
+		}
+	}
+
+	if result.Queued > result.Deferred {
+		s.wakeWorker()
+	}
+
+	s.log.Debugw("Notification event published",
+		"organizationUUID", organizationUUID,
+		"type", event.Type,
+		"rules", len(rules),
+		"queued", result.Queued,
+		"deduplicated", result.Deduplicated,
+		"deferred", result.Deferred,
+	)
+
+	return result, nil
+}
+
+// normalizeEvent validates an event and fills its defaults: occurrence time, dedup key and the
+// squad of its catalog service
+func (s *NotificationService) normalizeEvent(event *domain.NotificationEvent) error {
+	event.Type = strings.TrimSpace(event.Type)
+	if event.Type == "" {
+		return &domain.ValidationError{Field: "type", Message: "is required"}
+	}
+	event.Title = strings.TrimSpace(event.Title)
+	if event.Title == "" {
+		return &domain.ValidationError{Field: "title", Message: "is required"}
+	}
+	if event.DedupWindowMinutes < 0 || event.DedupWindowMinutes > maxNotificationDedupWindow {
+		return &domain.ValidationError{Field: "dedupWindowMinutes", Message: fmt.Sprintf("must be between 0 and %d", maxNotificationDedupWindow)}
+	}
+	event.Severity = strings.ToLower(strings.TrimSpace(event.Severity))
+
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+	if event.DedupKey == "" {
+		event.DedupKey = strings.Join([]string{event.Type, event.ServiceName, event.Title}, ":")
+	}
+	if len(event.DedupKey) > 500 {
+		event.DedupKey = event.DedupKey[:500]
+	}
+
+	if event.ServiceName != "" && event.Squad == "" {
+		svc, err := s.serviceRepo.GetByName(event.ServiceName)
+		if err != nil {
+			return fmt.Errorf("failed to get service: %w", err)
+		}
+		if svc != nil {
+			event.Squad = svc.Squad
+		}
+	}
+	return nil
+}
+
+// MatchingRules returns the enabled rules of the organization an event matches
+func (s *NotificationService) MatchingRules(organizationUUID string, event domain.NotificationEvent) ([]domain.NotificationRule, error) {
+	rules, err := s.ruleRepo.List(organizationUUID, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification rules: %w", err)
+	}
+
+	matching := []domain.NotificationRule{}
+	for _, rule := range rules {
+		if notificationRuleMatches(rule, event) {
+			matching = append(matching, rule)
+		}
+	}
+	return matching, nil
+}
+
+// notificationRuleMatches tells whether an event meets every criterion the rule sets
+func notificationRuleMatches(rule domain.NotificationRule, event domain.NotificationEvent) bool {
+	if len(rule.EventTypes) > 0 && !slices.Contains(rule.EventTypes, event.Type) {
+		return false
+	}
+	for name, value := range rule.Matchers {
+		if event.Labels[name] != value {
+			return false
+		}
+	}
+	if len(rule.Services) > 0 && !slices.Contains(rule.Services, event.ServiceName) {
+		return false
+	}
+	if len(rule.Squads) > 0 && !slices.Contains(rule.Squads, event.Squad) {
+		return false
+	}
+	if len(rule.Severities) > 0 && !slices.Contains(rule.Severities, strings.ToLower(event.Severity)) {
+		return false
+	}
+	return true
+}
+
+// quietUntil returns when the quiet hours end if now falls within them, and the zero time
+// otherwise or when the event severity bypasses them
+func quietUntil(quiet *domain.NotificationQuietHours, severity string, now time.Time) time.Time {
+	if quiet == nil || slices.Contains(quiet.BypassSeverities, strings.ToLower(severity)) {
+		return time.Time{}
+	}
+
+	start, errStart := parseClock(quiet.Start)
+	end, errEnd := parseClock(quiet.End)
+	if errStart != nil || errEnd != nil || start == end {
+		return time.Time{}
+	}
+
+	location := time.UTC
+	if quiet.Timezone != "" {
+		if loc, err := time.LoadLocation(quiet.Timezone); err == nil {
+			location = loc
+		}
+	}
+
+	local := now.In(location)
+	minute := local.Hour()*60 + local.Minute()
+	endOn := func(days int) time.Time {
+		return time.Date(local.Year(), local.Month(), local.Day()+days, end/60, end%60, 0, 0, location)
+	}
+
+	if start < end {
+		if minute >= start && minute < end {
+			return endOn(0)
+		}
+		return time.Time{}
+	}
+	// The period crosses midnight
+	if minute >= start {
+		return endOn(1)
+	}
+	if minute < end {
+		return endOn(0)
+	}
+	return time.Time{}
+}
+
+// parseClock parses a HH:MM time of day into minutes since midnight
+func parseClock(value string) (int, error) {
+	clock, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, err
+	}
+	return clock.Hour()*60 + clock.Minute(), nil
+}
+
+func (s *NotificationService) wakeWorker() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *NotificationService) deliveryLoop() {
+	ticker := time.NewTicker(notificationWorkerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-s.wake:
+		case <-s.stop:
+			return
+		}
+		s.deliverDue()
+	}
+}
+
+// deliverDue sends the deliveries whose attempt is due, in batches
+func (s *NotificationService) deliverDue() {
+	for {
+		deliveries, err := s.deliveryRepo.ClaimDue(notificationClaimBatch, notificationSendLease)
+		if err != nil {
+			s.log.Errorw("Failed to claim notification deliveries", "error", err)
+			return
+		}
+
+		for i := range deliveries {
+			s.deliver(&deliveries[i])
+		}
+
+		if len(deliveries) < notificationClaimBatch {
+			return
+		}
+		select {
+		case <-s.stop:
+			return
+		default:
+		}
+	}
+}
+
+// deliver sends a claimed delivery and records the outcome: failures are retried with backoff
+// until notificationMaxAttempts, except those retrying cannot fix
+func (s *NotificationService) deliver(delivery *domain.NotificationDelivery) {
+	err := s.send(delivery)
+	if err == nil {
+		if err := s.deliveryRepo.MarkSent(delivery.ID); err != nil {
+			s.log.Errorw("Failed to record notification delivery", "error", err, "deliveryID", delivery.ID)
+		}
+		return
+	}
+
+	var next *time.Time
+	var permanent *permanentDeliveryError
+	if !errors.As(err, &permanent) && delivery.Attempts < notificationMaxAttempts {
+		at := time.Now().Add(notificationRetryDelay(delivery.Attempts))
+		next = &at
+	}
+
+	s.log.Warnw("Failed to send notification",
+		"error", err,
+		"organizationUUID", delivery.OrganizationUUID,
+		"deliveryID", delivery.ID,
+		"channelType", delivery.ChannelType,
+		"attempt", delivery.Attempts,
+		"willRetry", next != nil,
+	)
+
+	if err := s.deliveryRepo.MarkFailed(delivery.ID, err.Error(), next); err != nil {
+		s.log.Errorw("Failed to record notification delivery", "error", err, "deliveryID", delivery.ID)
+	}
+}
+
+// notificationRetryDelay is the wait after the given number of failed attempts; the last backoff
+// repeats once they are exhausted
+func notificationRetryDelay(attempts int) time.Duration {
+	return notificationRetryBackoff[min(max(attempts, 1), len(notificationRetryBackoff))-1]
+}
+
+// permanentDeliveryError is a delivery failure retrying cannot fix (missing channel or
+// configuration, invalid template)
+type permanentDeliveryError struct {
+	err error
+}
+
+func (e *permanentDeliveryError) Error() string { return e.err.Error() }
+func (e *permanentDeliveryError) Unwrap() error { return e.err }
+
+func permanentf(format string, args ...interface{}) error {
+	return &permanentDeliveryError{err: fmt.Errorf(format, args...)}
+}
+
+func (s *NotificationService) send(delivery *domain.NotificationDelivery) error {
+	channel := &domain.NotificationChannel{Type: delivery.ChannelType, Enabled: true}
+	if delivery.ChannelID != nil {
+		var err error
+		channel, err = s.channelRepo.GetByID(delivery.OrganizationUUID, *delivery.ChannelID)
+		if err != nil {
+			return fmt.Errorf("failed to get notification channel: %w", err)
+		}
+		if channel == nil {
+			return permanentf("notification channel %d no longer exists", *delivery.ChannelID)
+		}
+		if !channel.Enabled {
+			return permanentf("notification channel %s is disabled", channel.Name)
+		}
+	}
+
+	return s.sendToChannel(delivery.OrganizationUUID, channel, delivery.Event)
+}
+
+// ListDeliveries returns the deliveries of the organization, most recent first
+func (s *NotificationService) ListDeliveries(organizationUUID string, filter domain.NotificationDeliveryFilter) ([]domain.NotificationDelivery, int, error) {
+	if filter.Size > 100 {
+		filter.Size = 100
+	}
+	return s.deliveryRepo.List(organizationUUID, filter)
+}
+
+// RetryDelivery queues a failed delivery again, with a new set of attempts
+func (s *NotificationService) RetryDelivery(organizationUUID string, id int) (*domain.NotificationDelivery, error) {
+	delivery, err := s.deliveryRepo.GetByID(organizationUUID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification delivery: %w", err)
+	}
+	if delivery == nil {
+		return nil, &domain.NotFoundError{Resource: "notification delivery", ID: fmt.Sprint(id)}
+	}
+	if delivery.Status != domain.NotificationDeliveryFailed {
+		return nil, &domain.InvalidStateError{Resource: "notification delivery", ID: fmt.Sprint(id), State: delivery.Status}
+	}
+
+	if err := s.deliveryRepo.Retry(organizationUUID, id); err != nil {
+		return nil, fmt.Errorf("failed to retry notification delivery: %w", err)
+	}
+	s.wakeWorker()
+
+	return s.deliveryRepo.GetByID(organizationUUID, id)
+}
+
+// integrationDownEvent is the event of an integration that could not be queried
+func integrationDownEvent(integration string, err error) domain.NotificationEvent {
+	return domain.NotificationEvent{
+		Type:               domain.NotificationEventIntegrationDown,
+		Severity:           domain.IncidentSeverityHigh,
+		Title:              integration + " integration is not responding",
+		Message:            err.Error(),
+		Labels:             map[string]string{"integration": integration},
+		DedupKey:           "integration-down:" + strings.ToLower(integration),
+		DedupWindowMinutes: integrationDownDedupWindow,
+	}
+}
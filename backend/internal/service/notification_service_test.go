@@ -0,0 +1,222 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+)
+
+func TestQuietUntil(t *testing.T) {
+	at := func(day, hour, minute int, location *time.Location) time.Time {
+		return time.Date(2026, time.March, day, hour, minute, 0, 0, location)
+	}
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+
+	office := &domain.NotificationQuietHours{Start: "09:00", End: "17:00"}
+	night := &domain.NotificationQuietHours{Start: "22:00", End: "07:00", BypassSeverities: []string{"critical"}}
+	localNight := &domain.NotificationQuietHours{Start: "22:00", End: "07:00", Timezone: "America/Sao_Paulo"}
+
+	tests := []struct {
+		name     string
+		quiet    *domain.NotificationQuietHours
+		severity string
+		now      time.Time
+		want     time.Time
+	}{
+		{name: "no quiet hours", now: at(10, 12, 0, time.UTC)},
+		{name: "within the day period", quiet: office, now: at(10, 12, 0, time.UTC), want: at(10, 17, 0, time.UTC)},
+		{name: "at the start", quiet: office, now: at(10, 9, 0, time.UTC), want: at(10, 17, 0, time.UTC)},
+		{name: "at the end", quiet: office, now: at(10, 17, 0, time.UTC)},
+		{name: "before the day period", quiet: office, now: at(10, 8, 59, time.UTC)},
+		{name: "before midnight", quiet: night, now: at(10, 23, 30, time.UTC), want: at(11, 7, 0, time.UTC)},
+		{name: "after midnight", quiet: night, now: at(11, 3, 0, time.UTC), want: at(11, 7, 0, time.UTC)},
+		{name: "outside the night period", quiet: night, now: at(10, 12, 0, time.UTC)},
+		{name: "bypass severity", quiet: night, severity: "critical", now: at(10, 23, 30, time.UTC)},
+		{name: "bypass severity in another case", quiet: night, severity: "CRITICAL", now: at(10, 23, 30, time.UTC)},
+		{name: "other severity", quiet: night, severity: "low", now: at(10, 23, 30, time.UTC), want: at(11, 7, 0, time.UTC)},
+		{
+			// 02:00 UTC is 23:00 of the day before in São Paulo
+			name:  "time zone of the rule",
+			quiet: localNight,
+			now:   at(10, 2, 0, time.UTC),
+			want:  at(10, 7, 0, saoPaulo),
+		},
+		{name: "outside the period in the rule time zone", quiet: localNight, now: at(10, 12, 0, time.UTC)},
+		{
+			name:  "unknown time zone falls back to UTC",
+			quiet: &domain.NotificationQuietHours{Start: "22:00", End: "07:00", Timezone: "Mars/Olympus"},
+			now:   at(10, 23, 0, time.UTC),
+			want:  at(11, 7, 0, time.UTC),
+		},
+		{name: "invalid clock", quiet: &domain.NotificationQuietHours{Start: "25:00", End: "07:00"}, now: at(10, 23, 0, time.UTC)},
+		{name: "empty period", quiet: &domain.NotificationQuietHours{Start: "07:00", End: "07:00"}, now: at(10, 7, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := quietUntil(tt.quiet, tt.severity, tt.now)
+			if !got.Equal(tt.want) {
+				t.Errorf("quietUntil = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotificationRuleMatches(t *testing.T) {
+	event := domain.NotificationEvent{
+		Type:        "alert.firing",
+		Severity:    "High",
+		ServiceName: "checkout",
+		Squad:       "payments",
+		Labels:      map[string]string{"env": "prod", "team": "payments"},
+	}
+
+	tests := []struct {
+		name string
+		rule domain.NotificationRule
+		want bool
+	}{
+		{name: "no criteria", want: true},
+		{name: "event type", rule: domain.NotificationRule{EventTypes: []string{"incident.opened", "alert.firing"}}, want: true},
+		{name: "other event type", rule: domain.NotificationRule{EventTypes: []string{"incident.opened"}}},
+		{name: "matchers", rule: domain.NotificationRule{Matchers: map[string]string{"env": "prod"}}, want: true},
+		{name: "matcher value differs", rule: domain.NotificationRule{Matchers: map[string]string{"env": "staging"}}},
+		{name: "matcher label missing", rule: domain.NotificationRule{Matchers: map[string]string{"region": "eu"}}},
+		{name: "service", rule: domain.NotificationRule{Services: []string{"checkout"}}, want: true},
+		{name: "other service", rule: domain.NotificationRule{Services: []string{"search"}}},
+		{name: "squad", rule: domain.NotificationRule{Squads: []string{"payments"}}, want: true},
+		{name: "other squad", rule: domain.NotificationRule{Squads: []string{"platform"}}},
+		{name: "severity in another case", rule: domain.NotificationRule{Severities: []string{"high", "critical"}}, want: true},
+		{name: "other severity", rule: domain.NotificationRule{Severities: []string{"critical"}}},
+		{
+			name: "every criterion must match",
+			rule: domain.NotificationRule{EventTypes: []string{"alert.firing"}, Services: []string{"checkout"}, Squads: []string{"platform"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := notificationRuleMatches(tt.rule, event); got != tt.want {
+				t.Errorf("notificationRuleMatches = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotificationTargetsMerge(t *testing.T) {
+	now := time.Date(2026, time.March, 10, 23, 0, 0, 0, time.UTC)
+	morning := time.Date(2026, time.March, 11, 7, 0, 0, 0, time.UTC)
+	slackID, emailID := 1, 2
+
+	night := &domain.NotificationQuietHours{Start: "22:00", End: "07:00"}
+	quietRule := &domain.NotificationRule{ID: 10, QuietHours: night, DedupWindowMinutes: 5}
+	loudRule := &domain.NotificationRule{ID: 11, DedupWindowMinutes: 60}
+	criticalRule := &domain.NotificationRule{ID: 12, QuietHours: &domain.NotificationQuietHours{Start: "22:00", End: "07:00", BypassSeverities: []string{"critical"}}}
+
+	type routing struct {
+		channelType string
+		channelID   *int
+		rule        *domain.NotificationRule
+	}
+	tests := []struct {
+		name     string
+		event    domain.NotificationEvent
+		routings []routing
+		want     []notificationTarget
+	}{
+		{
+			name:     "quiet hours defer the delivery",
+			routings: []routing{{"slack", nil, quietRule}},
+			want:     []notificationTarget{{ruleID: &quietRule.ID, channelType: "slack", dedupWindow: 5, deliverAt: morning, deferred: true}},
+		},
+		{
+			name:     "earliest delivery and longest dedup window win",
+			routings: []routing{{"slack", nil, quietRule}, {"slack", nil, loudRule}},
+			want:     []notificationTarget{{ruleID: &loudRule.ID, channelType: "slack", dedupWindow: 60, deliverAt: now}},
+		},
+		{
+			name:     "a later rule does not delay an immediate delivery",
+			routings: []routing{{"slack", nil, loudRule}, {"slack", nil, quietRule}},
+			want:     []notificationTarget{{ruleID: &loudRule.ID, channelType: "slack", dedupWindow: 60, deliverAt: now}},
+		},
+		{
+			name:     "event dedup window is the minimum",
+			event:    domain.NotificationEvent{DedupWindowMinutes: 30},
+			routings: []routing{{"slack", nil, quietRule}},
+			want:     []notificationTarget{{ruleID: &quietRule.ID, channelType: "slack", dedupWindow: 30, deliverAt: morning, deferred: true}},
+		},
+		{
+			name:     "bypass severity is delivered right away",
+			event:    domain.NotificationEvent{Severity: "critical"},
+			routings: []routing{{"slack", nil, criticalRule}},
+			want:     []notificationTarget{{ruleID: &criticalRule.ID, channelType: "slack", deliverAt: now}},
+		},
+		{
+			name:     "channels named by the event are not quiet",
+			event:    domain.NotificationEvent{DedupWindowMinutes: 15},
+			routings: []routing{{"webhook", &emailID, nil}, {"webhook", &emailID, quietRule}},
+			want:     []notificationTarget{{channelID: &emailID, channelType: "webhook", dedupWindow: 15, deliverAt: now}},
+		},
+		{
+			name:     "channels are kept apart and in routing order",
+			routings: []routing{{"email", &emailID, quietRule}, {"slack", &slackID, loudRule}, {"slack", nil, loudRule}},
+			want: []notificationTarget{
+				{ruleID: &quietRule.ID, channelID: &emailID, channelType: "email", dedupWindow: 5, deliverAt: morning, deferred: true},
+				{ruleID: &loudRule.ID, channelID: &slackID, channelType: "slack", dedupWindow: 60, deliverAt: now},
+				{ruleID: &loudRule.ID, channelType: "slack", dedupWindow: 60, deliverAt: now},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			targets := newNotificationTargets(tt.event, now)
+			for _, r := range tt.routings {
+				targets.add(r.channelType, r.channelID, r.rule)
+			}
+
+			got := targets.list()
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d targets, want %d", len(got), len(tt.want))
+			}
+			for i, want := range tt.want {
+				target := got[i]
+				if target.channelType != want.channelType || target.channelID != want.channelID || target.ruleID != want.ruleID {
+					t.Errorf("target %d routes %s (channel %v, rule %v), want %s (channel %v, rule %v)",
+						i, target.channelType, target.channelID, target.ruleID, want.channelType, want.channelID, want.ruleID)
+				}
+				if target.dedupWindow != want.dedupWindow {
+					t.Errorf("target %d dedup window is %d, want %d", i, target.dedupWindow, want.dedupWindow)
+				}
+				if !target.deliverAt.Equal(want.deliverAt) || target.deferred != want.deferred {
+					t.Errorf("target %d is delivered at %v (deferred %v), want %v (deferred %v)",
+						i, target.deliverAt, target.deferred, want.deliverAt, want.deferred)
+				}
+			}
+		})
+	}
+}
+
+func TestNotificationRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 0, want: time.Minute},
+		{attempts: 1, want: time.Minute},
+		{attempts: 2, want: 5 * time.Minute},
+		{attempts: 3, want: 15 * time.Minute},
+		{attempts: 4, want: time.Hour},
+		{attempts: 9, want: time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := notificationRetryDelay(tt.attempts); got != tt.want {
+			t.Errorf("delay after %d attempts is %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
@@ -1,11 +1,9 @@
 package service
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
-	"os"
-	"path/filepath"
-	"strings"
 
 	"github.com/PlatifyX/platifyx-core/internal/domain"
 	"github.com/PlatifyX/platifyx-core/internal/repository"
@@ -17,14 +15,16 @@ import (
 type OrganizationService struct {
 	repo     *repository.OrganizationRepository
 	coreDB   *sql.DB
+	nodeDBs  *database.TenantDBManager
 	log      *logger.Logger
 }
 
-func NewOrganizationService(repo *repository.OrganizationRepository, coreDB *sql.DB, log *logger.Logger) *OrganizationService {
+func NewOrganizationService(repo *repository.OrganizationRepository, coreDB *sql.DB, nodeDBs *database.TenantDBManager, log *logger.Logger) *OrganizationService {
 	return &OrganizationService{
-		repo:   repo,
-		coreDB: coreDB,
-		log:    log,
+		repo:    repo,
+		coreDB:  coreDB,
+		nodeDBs: nodeDBs,
+		log:     log,
 	}
 }
 
@@ -84,12 +84,11 @@ func (s *OrganizationService) Create(req domain.CreateOrganizationRequest) (*dom
 		return nil, fmt.Errorf("failed to create organization: %w", err)
 	}
 
-	nodeDB, err := database.NewPostgresConnection(org.DatabaseAddressWrite)
+	nodeDB, err := s.nodeDBs.Writer(org)
 	if err != nil {
-		s.log.Errorw("Failed to connect to node database", "error", err, "address", org.DatabaseAddressWrite)
+		s.log.Errorw("Failed to connect to node database", "error", err, "uuid", orgUUID)
 		return nil, fmt.Errorf("failed to connect to node database: %w", err)
 	}
-	defer nodeDB.Close()
 
 	err = s.CreateSchemaInNodeDB(nodeDB, orgUUID)
 	if err != nil {
@@ -150,16 +149,16 @@ func (s *OrganizationService) Delete(uuid string) error {
 		return err
 	}
 
-	nodeDB, err := database.NewPostgresConnection(org.DatabaseAddressWrite)
+	nodeDB, err := s.nodeDBs.Writer(org)
 	if err != nil {
 		s.log.Errorw("Failed to connect to node database for deletion", "error", err)
 	} else {
-		defer nodeDB.Close()
 		err = s.DropSchemaInNodeDB(nodeDB, uuid)
 		if err != nil {
 			s.log.Errorw("Failed to drop schema in node database", "error", err, "uuid", uuid)
 		}
 	}
+	s.nodeDBs.Invalidate(uuid)
 
 	err = s.repo.Delete(uuid)
 	if err != nil {
@@ -171,71 +170,34 @@ func (s *OrganizationService) Delete(uuid string) error {
 	return nil
 }
 
-func (s *OrganizationService) CreateSchemaInNodeDB(nodeDB *sql.DB, schemaUUID string) error {
-	schemaName := strings.ReplaceAll(schemaUUID, "-", "_")
-	schemaNameEscaped := fmt.Sprintf(`"%s"`, strings.ReplaceAll(schemaName, `"`, `""`))
-
-	_, err := nodeDB.Exec(fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", schemaNameEscaped))
+// NodeDatabaseHealth pings the write and read pools of the organization node database
+func (s *OrganizationService) NodeDatabaseHealth(ctx context.Context, uuid string) (*domain.NodeDatabaseHealth, error) {
+	org, err := s.repo.GetByUUID(uuid)
 	if err != nil {
-		return fmt.Errorf("failed to create schema: %w", err)
+		return nil, err
 	}
 
-	migrationPath := filepath.Join("migrations", "013_create_node_default_schema.sql")
-	content, err := os.ReadFile(migrationPath)
-	if err != nil {
-		return fmt.Errorf("failed to read schema template: %w", err)
-	}
+	return s.nodeDBs.Health(ctx, org)
+}
 
-	schemaSQL := string(content)
-	
-	lines := strings.Split(schemaSQL, "\n")
-	var processedSQL strings.Builder
-	
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
-			continue
-		}
-		
-		if strings.Contains(trimmed, "CREATE TABLE IF NOT EXISTS") {
-			line = strings.ReplaceAll(line, "CREATE TABLE IF NOT EXISTS", fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.", schemaNameEscaped))
-		} else if strings.Contains(trimmed, "CREATE INDEX IF NOT EXISTS") {
-			line = strings.ReplaceAll(line, "ON users(", fmt.Sprintf("ON %s.users(", schemaNameEscaped))
-			line = strings.ReplaceAll(line, "ON users ", fmt.Sprintf("ON %s.users ", schemaNameEscaped))
-		}
-		
-		processedSQL.WriteString(line)
-		processedSQL.WriteString("\n")
+// CreateSchemaInNodeDB creates the organization schema and applies the tenant migration track
+func (s *OrganizationService) CreateSchemaInNodeDB(nodeDB *sql.DB, schemaUUID string) error {
+	migrations, err := database.LoadTenantMigrations(TenantMigrationsPath)
+	if err != nil {
+		return err
 	}
 
-	fullSQL := processedSQL.String()
-	
-	statements := strings.Split(fullSQL, ";")
-	for _, stmt := range statements {
-		stmt = strings.TrimSpace(stmt)
-		if stmt == "" {
-			continue
-		}
-
-		_, err := nodeDB.Exec(stmt)
-		if err != nil {
-			s.log.Warnw("Failed to execute schema statement", "error", err, "statement", stmt[:min(100, len(stmt))])
-		}
+	executed, err := database.MigrateTenantSchema(nodeDB, schemaUUID, migrations, false)
+	if err != nil {
+		return fmt.Errorf("failed to migrate schema: %w", err)
 	}
 
+	s.log.Infow("Organization schema migrated", "uuid", schemaUUID, "versions", executed)
 	return nil
 }
 
-func (s *OrganizationService) DropSchemaInNodeDB(nodeDB *sql.DB, schemaName string) error {
-	schemaNameEscaped := fmt.Sprintf(`"%s"`, strings.ReplaceAll(schemaName, `"`, `""`))
+func (s *OrganizationService) DropSchemaInNodeDB(nodeDB *sql.DB, schemaUUID string) error {
+	schemaNameEscaped := database.EscapeSchemaName(database.SchemaName(schemaUUID))
 	_, err := nodeDB.Exec(fmt.Sprintf("DROP SCHEMA IF EXISTS %s CASCADE", schemaNameEscaped))
 	return err
 }
-
-func min(a, b int) int {
-	if a < b {
-		return a
-	}
-	return b
-}
-
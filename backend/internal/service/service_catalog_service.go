@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -20,7 +19,7 @@ import (
 
 type ServiceCatalogService struct {
 	serviceRepo        *repository.ServiceRepository
-	integrationRepo    *repository.IntegrationRepository
+	integrationService *IntegrationService
 	kubeService        *KubernetesService
 	azureDevOpsService *AzureDevOpsService
 	githubService      *GitHubService
@@ -30,7 +29,7 @@ type ServiceCatalogService struct {
 
 func NewServiceCatalogService(
 	serviceRepo *repository.ServiceRepository,
-	integrationRepo *repository.IntegrationRepository,
+	integrationService *IntegrationService,
 	kubeService *KubernetesService,
 	azureDevOpsService *AzureDevOpsService,
 	githubService *GitHubService,
@@ -39,7 +38,7 @@ func NewServiceCatalogService(
 ) *ServiceCatalogService {
 	return &ServiceCatalogService{
 		serviceRepo:        serviceRepo,
-		integrationRepo:    integrationRepo,
+		integrationService: integrationService,
 		kubeService:        kubeService,
 		azureDevOpsService: azureDevOpsService,
 		githubService:      githubService,
@@ -184,7 +183,7 @@ func (s *ServiceCatalogService) SyncFromKubernetesWithServiceAndOrg(kubeService
 	return nil
 }
 
-// fetchServiceMetadata fetches metadata from GitHub or Azure DevOps ci/pipeline.yml
+// fetchServiceMetadata fetches metadata from the GitHub, GitLab or Azure DevOps ci/pipeline.yml
 func (s *ServiceCatalogService) fetchServiceMetadata(organizationUUID string, squad, application, namespace string) (*domain.Service, error) {
 	serviceName := fmt.Sprintf("%s-%s", squad, application)
 	var fileContent string
@@ -193,40 +192,28 @@ func (s *ServiceCatalogService) fetchServiceMetadata(organizationUUID string, sq
 	var repositoryType string
 
 	// Try ALL GitHub integrations first
-	if s.integrationRepo != nil {
+	if s.integrationService != nil {
 		s.log.Infow("Trying to fetch from GitHub integrations", "service", serviceName)
 
 		// Get all GitHub integrations
-		githubIntegrations, err := s.integrationRepo.GetAllByType("github", organizationUUID)
+		githubConfigs, err := s.integrationService.GetAllGitHubConfigs(organizationUUID)
 		if err != nil {
 			s.log.Warnw("Failed to get GitHub integrations", "error", err)
 		} else {
 			// Try each GitHub integration
-			for _, integration := range githubIntegrations {
+			for integrationName, githubConfig := range githubConfigs {
 				s.log.Infow("Trying GitHub integration",
 					"service", serviceName,
-					"integration", integration.Name,
+					"integration", integrationName,
 				)
 
-				// Parse GitHub integration config
-				var integrationConfig domain.GitHubIntegrationConfig
-				if err := json.Unmarshal(integration.Config, &integrationConfig); err != nil {
-					s.log.Warnw("Failed to parse GitHub integration config",
-						"integration", integration.Name,
-						"error", err,
-					)
+				// Reuse the cached client, so a GitHub App keeps its installation token
+				githubService, err := s.integrationService.GetGitHubServiceByName(organizationUUID, integrationName)
+				if err != nil || githubService == nil {
+					s.log.Warnw("Failed to get GitHub client", "error", err, "integration", integrationName)
 					continue
 				}
 
-				// Convert to GitHubConfig
-				githubConfig := domain.GitHubConfig{
-					Token:        integrationConfig.Token,
-					Organization: integrationConfig.Organization,
-				}
-
-				// Create a temporary GitHub service for this integration
-				githubService := NewGitHubService(githubConfig, s.log)
-
 				// Try different owners (from config or default)
 				owners := []string{githubConfig.Organization}
 				if githubConfig.Organization == "" {
@@ -244,7 +231,7 @@ func (s *ServiceCatalogService) fetchServiceMetadata(organizationUUID string, sq
 							"repo", serviceName,
 							"branch", branch,
 							"path", "ci/pipeline.yml",
-							"integration", integration.Name,
+							"integration", integrationName,
 						)
 
 						fileContent, err = githubService.GetFileContent(owner, serviceName, "ci/pipeline.yml", branch)
@@ -255,7 +242,7 @@ func (s *ServiceCatalogService) fetchServiceMetadata(organizationUUID string, sq
 								"owner", owner,
 								"repo", serviceName,
 								"branch", branch,
-								"integration", integration.Name,
+								"integration", integrationName,
 							)
 							found = true
 							break
@@ -264,7 +251,7 @@ func (s *ServiceCatalogService) fetchServiceMetadata(organizationUUID string, sq
 							"owner", owner,
 							"repo", serviceName,
 							"branch", branch,
-							"integration", integration.Name,
+							"integration", integrationName,
 							"error", err.Error(),
 						)
 					}
@@ -277,38 +264,80 @@ func (s *ServiceCatalogService) fetchServiceMetadata(organizationUUID string, sq
 				} else {
 					s.log.Debugw("Repository not found in this GitHub integration",
 						"service", serviceName,
-						"integration", integration.Name,
+						"integration", integrationName,
 					)
 				}
 			}
 		}
 	}
 
-	// If GitHub failed or not available, try ALL Azure DevOps integrations
-	if fileContent == "" && s.integrationRepo != nil {
+	// If GitHub failed or not available, try ALL GitLab integrations
+	if fileContent == "" && s.integrationService != nil {
+		s.log.Infow("Trying to fetch from GitLab integrations", "service", serviceName)
+
+		gitlabConfigs, err := s.integrationService.GetAllGitLabConfigs(organizationUUID)
+		if err != nil {
+			s.log.Warnw("Failed to get GitLab integrations", "error", err)
+		} else {
+			for integrationName, gitlabConfig := range gitlabConfigs {
+				gitlabService, err := s.integrationService.GetGitLabServiceByName(organizationUUID, integrationName)
+				if err != nil || gitlabService == nil {
+					s.log.Warnw("Failed to get GitLab client", "error", err, "integration", integrationName)
+					continue
+				}
+
+				// Projects live in the configured group; without one the service name must be the full path
+				project := serviceName
+				if gitlabConfig.Group != "" {
+					project = gitlabConfig.Group + "/" + serviceName
+				}
+
+				found := false
+				for _, branch := range []string{"main", "master"} {
+					fileContent, err = gitlabService.GetFileContent(project, "ci/pipeline.yml", branch)
+					if err == nil {
+						repoURL = gitlabService.GetRepositoryURL(project)
+						repositoryType = "gitlab"
+						s.log.Infow("Successfully found repository on GitLab",
+							"project", project,
+							"branch", branch,
+							"integration", integrationName,
+						)
+						found = true
+						break
+					}
+					s.log.Debugw("GitLab fetch failed, trying next branch",
+						"project", project,
+						"branch", branch,
+						"integration", integrationName,
+						"error", err.Error(),
+					)
+				}
+				if found {
+					break
+				}
+			}
+		}
+	}
+
+	// If GitHub and GitLab failed or are not available, try ALL Azure DevOps integrations
+	if fileContent == "" && s.integrationService != nil {
 		s.log.Infow("Trying to fetch from Azure DevOps integrations", "service", serviceName)
 
 		// Get all Azure DevOps integrations
-		azureIntegrations, err := s.integrationRepo.GetAllByType("azuredevops", organizationUUID)
+		azureConfigs, err := s.integrationService.GetAllAzureDevOpsConfigs(organizationUUID)
 		if err != nil {
 			s.log.Warnw("Failed to get Azure DevOps integrations", "error", err)
 		} else {
 			// Try each integration
-			for _, integration := range azureIntegrations {
+			for integrationName, azureConfig := range azureConfigs {
 				s.log.Infow("Trying Azure DevOps integration",
 					"service", serviceName,
-					"integration", integration.Name,
+					"integration", integrationName,
 				)
 
 				// Create a temporary Azure DevOps service for this integration
-				azureService, err := NewAzureDevOpsServiceFromIntegration(&integration)
-				if err != nil {
-					s.log.Warnw("Failed to create Azure DevOps service from integration",
-						"integration", integration.Name,
-						"error", err,
-					)
-					continue
-				}
+				azureService := NewAzureDevOpsService(*azureConfig, s.log)
 
 				// Try to fetch the file
 				fileContent, err = azureService.GetFileContent(serviceName, "ci/pipeline.yml", "main")
@@ -322,13 +351,13 @@ func (s *ServiceCatalogService) fetchServiceMetadata(organizationUUID string, sq
 					repositoryType = "azuredevops"
 					s.log.Infow("Successfully found repository in Azure DevOps",
 						"service", serviceName,
-						"integration", integration.Name,
+						"integration", integrationName,
 					)
 					break
 				} else {
 					s.log.Debugw("Repository not found in this Azure DevOps integration",
 						"service", serviceName,
-						"integration", integration.Name,
+						"integration", integrationName,
 						"error", err.Error(),
 					)
 				}
@@ -336,9 +365,9 @@ func (s *ServiceCatalogService) fetchServiceMetadata(organizationUUID string, sq
 		}
 	}
 
-	// If both failed, return error
+	// If every provider failed, return error
 	if fileContent == "" {
-		return nil, fmt.Errorf("failed to fetch pipeline.yml from GitHub and Azure DevOps: %w", err)
+		return nil, fmt.Errorf("failed to fetch pipeline.yml from GitHub, GitLab and Azure DevOps: %w", err)
 	}
 
 	// Parse YAML to extract variables
@@ -495,7 +524,7 @@ func (s *ServiceCatalogService) GetServiceStatusWithKubeService(serviceName stri
 }
 
 // getDeploymentStatus gets status of a specific deployment
-func (s *ServiceCatalogService) getDeploymentStatus(clientset *kubernetes.Clientset, deploymentName, environment string) (*domain.DeploymentStatus, error) {
+func (s *ServiceCatalogService) getDeploymentStatus(clientset kubernetes.Interface, deploymentName, environment string) (*domain.DeploymentStatus, error) {
 	// First try to find by name directly (searching all namespaces)
 	deployments, err := clientset.AppsV1().Deployments("").List(context.TODO(), metav1.ListOptions{})
 	if err != nil {
@@ -558,7 +587,7 @@ func (s *ServiceCatalogService) getDeploymentStatus(clientset *kubernetes.Client
 }
 
 // getPodsForDeployment gets pods related to a deployment
-func (s *ServiceCatalogService) getPodsForDeployment(clientset *kubernetes.Clientset, namespace, deploymentName string) ([]domain.PodInfo, error) {
+func (s *ServiceCatalogService) getPodsForDeployment(clientset kubernetes.Interface, namespace, deploymentName string) ([]domain.PodInfo, error) {
 	// Try to get pods by matching the deployment name in labels or pod name prefix
 	pods, err := clientset.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{})
 	if err != nil {
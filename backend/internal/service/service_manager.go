@@ -2,26 +2,36 @@ package service
 
 import (
 	"database/sql"
+	"time"
 
 	"github.com/PlatifyX/platifyx-core/internal/config"
 	"github.com/PlatifyX/platifyx-core/internal/domain"
 	"github.com/PlatifyX/platifyx-core/internal/repository"
 	"github.com/PlatifyX/platifyx-core/pkg/cache"
+	"github.com/PlatifyX/platifyx-core/pkg/database"
+	"github.com/PlatifyX/platifyx-core/pkg/gitrepo"
 	"github.com/PlatifyX/platifyx-core/pkg/logger"
 )
 
 type ServiceManager struct {
 	CacheService           *CacheService
 	MetricsService         *MetricsService
+	IncidentService        *IncidentService
+	AlertService           *AlertService
+	NotificationService    *NotificationService
 	KubernetesService      *KubernetesService
 	AzureDevOpsService     *AzureDevOpsService
 	SonarQubeService       *SonarQubeService
 	IntegrationService     *IntegrationService
+	CredentialService      *CredentialService
+	ClientRegistry         *ClientRegistry
+	NodeDBs                *database.TenantDBManager
 	FinOpsService          *FinOpsService
 	TechDocsService        *TechDocsService
 	ServiceTemplateService *ServiceTemplateService
 	ServiceCatalogService  *ServiceCatalogService
 	AIService                        *AIService
+	AIUsageService                   *AIUsageService
 	DiagramService                   *DiagramService
 	TemplateService                  *TemplateService
 	UserService                      *UserService
@@ -35,8 +45,10 @@ type ServiceManager struct {
 	ServicePlaybookService           *ServicePlaybookService
 	BoardsService                    *BoardsService
 	OrganizationService              *OrganizationService
+	TenantMigrationService           *TenantMigrationService
 	UserOrganizationService          *UserOrganizationService
 	OrganizationUserService          *OrganizationUserService
+	AuditService                     *AuditService
 	// User Management Repositories (exposed for handlers)
 	UserRepository          *repository.UserRepository
 	RoleRepository          *repository.RoleRepository
@@ -61,8 +73,18 @@ func NewServiceManager(cfg *config.Config, log *logger.Logger, db *sql.DB) *Serv
 	sessionRepo := repository.NewSessionRepository(db)
 	passwordResetRepo := repository.NewPasswordResetRepository(db)
 
+	// Initialize credential encryption (envelope encryption of integration secrets)
+	credentialService, err := NewCredentialServiceFromConfig(cfg, repository.NewDataKeyRepository(db), log)
+	if err != nil {
+		log.Fatalw("Failed to initialize credential encryption", "error", err)
+	}
+	if credentialService == nil {
+		log.Warn("ENCRYPTION_MASTER_KEYS not set, integration credentials will be stored unencrypted")
+	}
+
 	// Initialize integration service
-	integrationService := NewIntegrationService(integrationRepo, log)
+	clientRegistry := NewClientRegistry(defaultClientIdleTTL, log)
+	integrationService := NewIntegrationService(integrationRepo, credentialService, clientRegistry, log)
 
 	// Kubernetes, GitHub, and SonarQube services are now initialized on-demand per organization
 	// They cannot be initialized here during startup as they require organizationUUID
@@ -108,16 +130,56 @@ func NewServiceManager(cfg *config.Config, log *logger.Logger, db *sql.DB) *Serv
 	// Initialize ServiceCatalog service (with cache support)
 	// ServiceCatalogService can work without KubernetesService for listing services (GetAll)
 	// KubernetesService will be created dynamically per organization when needed for sync
-	serviceCatalogService := NewServiceCatalogService(serviceRepo, integrationRepo, nil, nil, nil, redisClient, log)
+	serviceCatalogService := NewServiceCatalogService(serviceRepo, integrationService, nil, nil, nil, redisClient, log)
+
+	// Initialize notification engine (routes events to Slack, Teams, email and webhook channels)
+	emailService := NewEmailService(log)
+	notificationService := NewNotificationService(
+		repository.NewNotificationRuleRepository(db),
+		repository.NewNotificationChannelRepository(db),
+		repository.NewNotificationDeliveryRepository(db),
+		serviceRepo,
+		integrationService,
+		credentialService,
+		emailService,
+		log,
+	)
+
+	// Initialize incident tracking (feeds MTTR in DORA metrics and maturity scorecards)
+	incidentService := NewIncidentService(repository.NewIncidentRepository(db), serviceRepo, integrationService, log)
+
+	// Initialize alert webhooks (Alertmanager / Grafana)
+	alertService := NewAlertService(
+		repository.NewAlertRepository(db),
+		serviceRepo,
+		incidentService,
+		notificationService,
+		log,
+	)
+
+	// Initialize DORA metrics engine
+	metricsService := NewMetricsService(integrationService, incidentService, notificationService, serviceRepo, repository.NewDORASnapshotRepository(db), log)
 
 	// Initialize FinOps service
 	finOpsService := NewFinOpsService(integrationService, log)
 
 	// Initialize AI services
-	aiService := NewAIService(integrationService, log)
+	aiUsageService := NewAIUsageService(repository.NewAIUsageRepository(db), repository.NewAIBudgetRepository(db), integrationService, log)
+	aiService := NewAIService(integrationService, repository.NewAIRoutingPolicyRepository(db), aiUsageService, cfg.AIFakeProvider, log)
 	diagramService := NewDiagramService(aiService, log)
 
-	techDocsService := NewTechDocsService("docs", aiService, diagramService, githubService, redisClient, log)
+	techDocsIndexService := NewTechDocsIndexService("docs", aiService, repository.NewTechDocsIndexRepository(db), log)
+	techDocsStore := NewTechDocsStore(cfg.TechDocsStore, "docs", gitrepo.Options{
+		RemoteURL: cfg.TechDocsGitRemoteURL,
+		Branch:    cfg.TechDocsGitBranch,
+		Token:     cfg.TechDocsGitToken,
+	}, log)
+	techDocsService := NewTechDocsService("docs", aiService, diagramService, integrationService, techDocsIndexService, techDocsStore, redisClient, log)
+	if cfg.TechDocsLegacyOrganization != "" {
+		if err := techDocsService.MigrateLegacyDocs(cfg.TechDocsLegacyOrganization); err != nil {
+			log.Errorw("Failed to move legacy TechDocs to their organization", "error", err, "organization", cfg.TechDocsLegacyOrganization)
+		}
+	}
 
 	// Initialize ServiceTemplate service
 	serviceTemplateRepo := repository.NewServiceTemplateRepository(db)
@@ -133,7 +195,10 @@ func NewServiceManager(cfg *config.Config, log *logger.Logger, db *sql.DB) *Serv
 	// Initialize User Management services
 	userService := NewUserService(userRepo, auditRepo)
 	authService := NewAuthService(userRepo, sessionRepo, auditRepo, passwordResetRepo, cfg.JWTSecret)
-	emailService := NewEmailService(log)
+	auditService, err := NewAuditServiceFromConfig(cfg, auditRepo, log)
+	if err != nil {
+		log.Fatalw("Failed to initialize audit service", "error", err)
+	}
 
 	// Initialize Autonomous Engineering services
 	autonomousRecommendationsService := NewAutonomousRecommendationsService(
@@ -141,6 +206,7 @@ func NewServiceManager(cfg *config.Config, log *logger.Logger, db *sql.DB) *Serv
 		kubernetesService,
 		finOpsService,
 		azureDevOpsService,
+		notificationService,
 		log,
 	)
 
@@ -154,15 +220,29 @@ func NewServiceManager(cfg *config.Config, log *logger.Logger, db *sql.DB) *Serv
 	autonomousActionsService := NewAutonomousActionsService(
 		kubernetesService,
 		azureDevOpsService,
+		integrationService,
+		notificationService,
+		repository.NewAutonomousActionRepository(db),
+		repository.NewAutonomousConfigRepository(db),
+		auditRepo,
 		log,
 	)
+	autonomousActionsService.RecoverInterruptedActions()
 
+	organizationRepo := repository.NewOrganizationRepository(db)
 	maturityService := NewMaturityService(
 		kubernetesService,
 		azureDevOpsService,
 		sonarQubeService,
 		finOpsService,
 		aiService,
+		integrationService,
+		techDocsService,
+		incidentService,
+		notificationService,
+		serviceRepo,
+		repository.NewScorecardRepository(db),
+		organizationRepo,
 		log,
 	)
 
@@ -170,8 +250,6 @@ func NewServiceManager(cfg *config.Config, log *logger.Logger, db *sql.DB) *Serv
 		techDocsService,
 		aiService,
 		diagramService,
-		githubService,
-		azureDevOpsService,
 		integrationService,
 		kubernetesService,
 		redisClient,
@@ -198,27 +276,41 @@ func NewServiceManager(cfg *config.Config, log *logger.Logger, db *sql.DB) *Serv
 		log,
 	)
 
-	organizationRepo := repository.NewOrganizationRepository(db)
-	organizationService := NewOrganizationService(organizationRepo, db, log)
+	nodeDBs := database.NewTenantDBManager(database.TenantPoolConfig{
+		MaxOpenConns:    cfg.NodeDBMaxOpenConns,
+		MaxIdleConns:    cfg.NodeDBMaxIdleConns,
+		ConnMaxLifetime: time.Duration(cfg.NodeDBConnMaxLifetime) * time.Second,
+		ConnMaxIdleTime: 5 * time.Minute,
+	})
+	organizationService := NewOrganizationService(organizationRepo, db, nodeDBs, log)
+	tenantMigrationService := NewTenantMigrationService(organizationRepo, nodeDBs, TenantMigrationsPath, log)
 
 	userOrgRepo := repository.NewUserOrganizationRepository(db)
-	userOrganizationService := NewUserOrganizationService(userOrgRepo, userRepo, organizationRepo, log)
+	roleBindingRepo := repository.NewRoleBindingRepository(db)
+	userOrganizationService := NewUserOrganizationService(userOrgRepo, userRepo, organizationRepo, roleRepo, roleBindingRepo, log)
 
-	orgUserRepo := repository.NewOrganizationUserRepository(db)
+	orgUserRepo := repository.NewOrganizationUserRepository(db, nodeDBs)
 	organizationUserService := NewOrganizationUserService(orgUserRepo, log)
 
 	return &ServiceManager{
 		CacheService:           cacheService,
-		MetricsService:         NewMetricsService(),
+		MetricsService:         metricsService,
+		IncidentService:        incidentService,
+		AlertService:           alertService,
+		NotificationService:    notificationService,
 		KubernetesService:      kubernetesService,
 		AzureDevOpsService:     azureDevOpsService,
 		SonarQubeService:       sonarQubeService,
 		IntegrationService:     integrationService,
+		CredentialService:      credentialService,
+		ClientRegistry:         clientRegistry,
+		NodeDBs:                nodeDBs,
 		FinOpsService:          finOpsService,
 		TechDocsService:        techDocsService,
 		ServiceTemplateService: serviceTemplateService,
 		ServiceCatalogService:  serviceCatalogService,
 		AIService:              aiService,
+		AIUsageService:         aiUsageService,
 		DiagramService:         diagramService,
 		TemplateService:        templateService,
 		UserService:                      userService,
@@ -232,8 +324,10 @@ func NewServiceManager(cfg *config.Config, log *logger.Logger, db *sql.DB) *Serv
 		ServicePlaybookService:           servicePlaybookService,
 		BoardsService:                    boardsService,
 		OrganizationService:             organizationService,
+		TenantMigrationService:          tenantMigrationService,
 		UserOrganizationService:         userOrganizationService,
 		OrganizationUserService:         organizationUserService,
+		AuditService:                    auditService,
 		UserRepository:                  userRepo,
 		RoleRepository:          roleRepo,
 		TeamRepository:          teamRepo,
@@ -243,3 +337,12 @@ func NewServiceManager(cfg *config.Config, log *logger.Logger, db *sql.DB) *Serv
 		PasswordResetRepository: passwordResetRepo,
 	}
 }
+
+// Close releases the resources held by the services (notification worker, scorecard evaluator,
+// cached integration clients and organization node database pools)
+func (sm *ServiceManager) Close() {
+	sm.NotificationService.Close()
+	sm.MaturityService.Close()
+	sm.ClientRegistry.Close()
+	sm.NodeDBs.Close()
+}
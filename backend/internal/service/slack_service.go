@@ -11,8 +11,9 @@ type SlackService struct {
 	log    *logger.Logger
 }
 
+// NewSlackService posts through notificationHTTPClient, since webhook URLs are set by organizations
 func NewSlackService(config domain.SlackConfig, log *logger.Logger) *SlackService {
-	client := slack.NewClient(config)
+	client := slack.NewClient(config, slack.WithHTTPClient(notificationHTTPClient))
 	return &SlackService{
 		client: client,
 		log:    log,
@@ -11,8 +11,9 @@ type TeamsService struct {
 	log    *logger.Logger
 }
 
+// NewTeamsService posts through notificationHTTPClient, since webhook URLs are set by organizations
 func NewTeamsService(config domain.TeamsConfig, log *logger.Logger) *TeamsService {
-	client := teams.NewClient(config)
+	client := teams.NewClient(config, teams.WithHTTPClient(notificationHTTPClient))
 	return &TeamsService{
 		client: client,
 		log:    log,
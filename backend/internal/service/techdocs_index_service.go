@@ -0,0 +1,479 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/PlatifyX/platifyx-core/internal/repository"
+	"github.com/PlatifyX/platifyx-core/pkg/logger"
+)
+
+const (
+	// Chunks are sections of about this size, split on headings then paragraphs
+	techDocsChunkMaxChars = 1500
+	// Texts sent per embedding request
+	techDocsEmbedBatchSize = 32
+	// The index is compared with the docs tree at most this often before a search
+	techDocsIndexSyncInterval = 10 * time.Minute
+	// Chunks retrieved per question, and the similarity below which a chunk is not relevant
+	techDocsRetrievalTopK     = 6
+	techDocsRetrievalMinScore = 0.2
+)
+
+// TechDocsIndexService keeps the retrieval index of the TechDocs: every markdown document is split
+// in chunks, embedded through the AI gateway and stored per organization. Similarity is computed
+// in Go over the vectors of the embedding model in use.
+type TechDocsIndexService struct {
+	docsPath  string
+	aiService *AIService
+	repo      *repository.TechDocsIndexRepository
+	log       *logger.Logger
+
+	mu       sync.Mutex
+	locks    map[string]*sync.Mutex
+	lastSync map[string]time.Time
+}
+
+// scoredChunk is a chunk with its similarity to the question
+type scoredChunk struct {
+	chunk domain.TechDocChunk
+	score float64
+}
+
+func NewTechDocsIndexService(docsPath string, aiService *AIService, repo *repository.TechDocsIndexRepository, log *logger.Logger) *TechDocsIndexService {
+	return &TechDocsIndexService{
+		docsPath:  docsPath,
+		aiService: aiService,
+		repo:      repo,
+		log:       log,
+		locks:     map[string]*sync.Mutex{},
+		lastSync:  map[string]time.Time{},
+	}
+}
+
+// orgLock serializes the index updates of an organization
+func (s *TechDocsIndexService) orgLock(organizationUUID string) *sync.Mutex {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lock, ok := s.locks[organizationUUID]
+	if !ok {
+		lock = &sync.Mutex{}
+		s.locks[organizationUUID] = lock
+	}
+	return lock
+}
+
+// IndexDocument (re)indexes a saved document
+func (s *TechDocsIndexService) IndexDocument(ctx context.Context, organizationUUID, docPath, content string) error {
+	if !isIndexableDoc(docPath) {
+		return nil
+	}
+
+	lock := s.orgLock(organizationUUID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	return s.indexDocument(ctx, organizationUUID, docPath, content)
+}
+
+// RemoveDocument drops a deleted document from the index
+func (s *TechDocsIndexService) RemoveDocument(organizationUUID, docPath string) error {
+	lock := s.orgLock(organizationUUID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	return s.repo.DeleteDocument(organizationUUID, docPath)
+}
+
+// Sync brings the index of the organization in line with the docs tree: new and changed documents
+// (or documents embedded by another model) are indexed, removed ones are dropped
+func (s *TechDocsIndexService) Sync(ctx context.Context, organizationUUID string) (*domain.TechDocsIndexSyncResult, error) {
+	lock := s.orgLock(organizationUUID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	model, err := s.aiService.EmbeddingModel(organizationUUID)
+	if err != nil {
+		return nil, err
+	}
+	if model == "" {
+		return nil, fmt.Errorf("no AI provider with embeddings configured")
+	}
+
+	indexed, err := s.repo.ListDocuments(organizationUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list indexed documents: %w", err)
+	}
+
+	files, err := s.readDocs(organizationUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.TechDocsIndexSyncResult{}
+	for docPath, content := range files {
+		if doc, ok := indexed[docPath]; ok && doc.ContentHash == contentHash(content) && doc.EmbeddingModel == model {
+			result.Unchanged++
+			continue
+		}
+
+		if err := s.indexDocument(ctx, organizationUUID, docPath, content); err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			var exceeded *domain.AIBudgetExceededError
+			if errors.As(err, &exceeded) {
+				return nil, err
+			}
+			s.log.Warnw("Failed to index document", "path", docPath, "error", err, "organizationUUID", organizationUUID)
+			result.Failed = append(result.Failed, docPath)
+			continue
+		}
+		result.Indexed++
+	}
+
+	for docPath := range indexed {
+		if _, ok := files[docPath]; ok {
+			continue
+		}
+		if err := s.repo.DeleteDocument(organizationUUID, docPath); err != nil {
+			return nil, fmt.Errorf("failed to remove %s from index: %w", docPath, err)
+		}
+		result.Removed++
+	}
+
+	s.mu.Lock()
+	s.lastSync[organizationUUID] = time.Now()
+	s.mu.Unlock()
+
+	s.log.Infow("TechDocs index synchronized",
+		"organizationUUID", organizationUUID,
+		"model", model,
+		"indexed", result.Indexed,
+		"removed", result.Removed,
+		"unchanged", result.Unchanged,
+		"failed", len(result.Failed))
+
+	return result, nil
+}
+
+// Search returns the chunks most similar to the question, across all documents of the organization.
+// The index is synchronized first when it was not for a while.
+func (s *TechDocsIndexService) Search(ctx context.Context, caller domain.AICaller, question string) ([]scoredChunk, error) {
+	s.syncIfStale(ctx, caller.OrganizationUUID)
+
+	caller.Feature = domain.AIFeatureEmbeddings
+	embeddings, err := s.aiService.Embed(ctx, caller, []string{question})
+	if err != nil {
+		return nil, err
+	}
+
+	chunks, err := s.repo.ListChunks(caller.OrganizationUUID, embeddings.Model)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load index: %w", err)
+	}
+
+	query := embeddings.Vectors[0]
+	scored := make([]scoredChunk, 0, len(chunks))
+	for _, chunk := range chunks {
+		score := cosineSimilarity(query, chunk.Embedding)
+		if score < techDocsRetrievalMinScore {
+			continue
+		}
+		scored = append(scored, scoredChunk{chunk: chunk, score: score})
+	}
+
+	sort.Slice(scored, func(i, j int) bool {
+		return scored[i].score > scored[j].score
+	})
+	if len(scored) > techDocsRetrievalTopK {
+		scored = scored[:techDocsRetrievalTopK]
+	}
+
+	return scored, nil
+}
+
+// Status summarizes the index of the organization against the docs tree
+func (s *TechDocsIndexService) Status(organizationUUID string) (*domain.TechDocsIndexStatus, error) {
+	model, err := s.aiService.EmbeddingModel(organizationUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	indexed, err := s.repo.ListDocuments(organizationUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list indexed documents: %w", err)
+	}
+
+	files, err := s.readDocs(organizationUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &domain.TechDocsIndexStatus{EmbeddingModel: model}
+	for docPath, content := range files {
+		doc, ok := indexed[docPath]
+		if !ok || doc.ContentHash != contentHash(content) || doc.EmbeddingModel != model {
+			status.StaleDocuments++
+		}
+	}
+	for _, doc := range indexed {
+		status.Documents++
+		status.Chunks += doc.Chunks
+		if status.LastIndexedAt == nil || doc.IndexedAt.After(*status.LastIndexedAt) {
+			indexedAt := doc.IndexedAt
+			status.LastIndexedAt = &indexedAt
+		}
+	}
+
+	return status, nil
+}
+
+func (s *TechDocsIndexService) syncIfStale(ctx context.Context, organizationUUID string) {
+	s.mu.Lock()
+	last, ok := s.lastSync[organizationUUID]
+	s.mu.Unlock()
+	if ok && time.Since(last) < techDocsIndexSyncInterval {
+		return
+	}
+
+	if _, err := s.Sync(ctx, organizationUUID); err != nil {
+		s.log.Warnw("Failed to synchronize TechDocs index", "error", err, "organizationUUID", organizationUUID)
+	}
+}
+
+func (s *TechDocsIndexService) indexDocument(ctx context.Context, organizationUUID, docPath, content string) error {
+	chunks := chunkMarkdown(docPath, content)
+	if len(chunks) == 0 {
+		return s.repo.DeleteDocument(organizationUUID, docPath)
+	}
+
+	caller := domain.AICaller{OrganizationUUID: organizationUUID, Feature: domain.AIFeatureEmbeddings}
+	model := ""
+	for start := 0; start < len(chunks); start += techDocsEmbedBatchSize {
+		end := start + techDocsEmbedBatchSize
+		if end > len(chunks) {
+			end = len(chunks)
+		}
+
+		texts := make([]string, 0, end-start)
+		for _, chunk := range chunks[start:end] {
+			texts = append(texts, embeddingText(chunk))
+		}
+
+		embeddings, err := s.aiService.Embed(ctx, caller, texts)
+		if err != nil {
+			return err
+		}
+		// A fallback in the middle of a document would mix incomparable vectors
+		if model != "" && embeddings.Model != model {
+			return fmt.Errorf("embedding model changed while indexing %s", docPath)
+		}
+		model = embeddings.Model
+
+		for i, vector := range embeddings.Vectors {
+			chunks[start+i].Embedding = vector
+			chunks[start+i].EmbeddingModel = model
+		}
+	}
+
+	doc := &domain.TechDocIndexedDocument{
+		Path:           docPath,
+		ContentHash:    contentHash(content),
+		EmbeddingModel: model,
+	}
+	if err := s.repo.ReplaceDocument(organizationUUID, doc, chunks); err != nil {
+		return fmt.Errorf("failed to store index of %s: %w", docPath, err)
+	}
+
+	s.log.Debugw("Indexed document", "path", docPath, "chunks", len(chunks), "model", model, "organizationUUID", organizationUUID)
+	return nil
+}
+
+// readDocs reads the markdown documents of the docs tree of the organization, by path relative
+// to its root
+func (s *TechDocsIndexService) readDocs(organizationUUID string) (map[string]string, error) {
+	root, err := organizationDocsRoot(s.docsPath, organizationUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string]string{}
+
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(d.Name(), ".") && path != root {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(root, path)
+		if err != nil || !isIndexableDoc(relPath) {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(relPath)] = string(content)
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read docs directory: %w", err)
+	}
+
+	return files, nil
+}
+
+func isIndexableDoc(docPath string) bool {
+	return strings.EqualFold(filepath.Ext(docPath), ".md")
+}
+
+func contentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// embeddingText is the text embedded for a chunk: its origin helps matching questions that name
+// the service or the section
+func embeddingText(chunk domain.TechDocChunk) string {
+	if chunk.Heading == "" {
+		return chunk.Path + "\n\n" + chunk.Content
+	}
+	return chunk.Path + " > " + chunk.Heading + "\n\n" + chunk.Content
+}
+
+// chunkMarkdown splits a markdown document in sections by heading, then splits the sections
+// longer than techDocsChunkMaxChars on paragraphs (and hard-cuts paragraphs that are still too long)
+func chunkMarkdown(docPath, content string) []domain.TechDocChunk {
+	type section struct {
+		heading string
+		body    strings.Builder
+	}
+
+	var sections []*section
+	current := &section{}
+	headings := []string{}
+	inCodeBlock := false
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inCodeBlock = !inCodeBlock
+		}
+
+		if !inCodeBlock && strings.HasPrefix(trimmed, "#") {
+			level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+			title := strings.TrimSpace(trimmed[level:])
+			if level <= 6 && title != "" {
+				sections = append(sections, current)
+				if level > len(headings) {
+					headings = append(headings, make([]string, level-len(headings))...)
+				}
+				headings = append(headings[:level-1], title)
+				current = &section{heading: strings.Join(nonEmpty(headings), " > ")}
+				continue
+			}
+		}
+
+		current.body.WriteString(line)
+		current.body.WriteString("\n")
+	}
+	sections = append(sections, current)
+
+	var chunks []domain.TechDocChunk
+	add := func(heading, text string) {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			return
+		}
+		chunks = append(chunks, domain.TechDocChunk{
+			Path:    docPath,
+			Index:   len(chunks),
+			Heading: heading,
+			Content: text,
+		})
+	}
+
+	for _, sec := range sections {
+		var buf strings.Builder
+		for _, paragraph := range strings.Split(sec.body.String(), "\n\n") {
+			if buf.Len() > 0 && buf.Len()+len(paragraph) > techDocsChunkMaxChars {
+				add(sec.heading, buf.String())
+				buf.Reset()
+			}
+			for len(paragraph) > techDocsChunkMaxChars {
+				cut := runeCut(paragraph, techDocsChunkMaxChars)
+				add(sec.heading, paragraph[:cut])
+				paragraph = paragraph[cut:]
+			}
+			buf.WriteString(paragraph)
+			buf.WriteString("\n\n")
+		}
+		add(sec.heading, buf.String())
+	}
+
+	return chunks
+}
+
+// runeCut returns the largest offset up to max bytes that does not split a UTF-8 rune of s
+// (Postgres rejects the invalid sequence a cut inside a rune leaves behind)
+func runeCut(s string, max int) int {
+	cut := max
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	if cut == 0 {
+		return max
+	}
+	return cut
+}
+
+func nonEmpty(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestChunkMarkdownKeepsRunesWhole(t *testing.T) {
+	// A paragraph of multi-byte runes, offset by one byte so that the hard cut falls inside a rune
+	paragraph := "x" + strings.Repeat("Configuração de integração ", 200)
+	content := "# Documentação\n\n" + paragraph + "\n"
+
+	chunks := chunkMarkdown("docs/guia.md", content)
+	if len(chunks) < 2 {
+		t.Fatalf("got %d chunks, want the paragraph split", len(chunks))
+	}
+
+	var joined strings.Builder
+	for _, chunk := range chunks {
+		if !utf8.ValidString(chunk.Content) {
+			t.Fatalf("chunk %d is not valid UTF-8", chunk.Index)
+		}
+		if len(chunk.Content) > techDocsChunkMaxChars {
+			t.Errorf("chunk %d has %d bytes, over the %d limit", chunk.Index, len(chunk.Content), techDocsChunkMaxChars)
+		}
+		joined.WriteString(chunk.Content)
+	}
+
+	if got, want := strings.Count(joined.String(), "ç"), strings.Count(paragraph, "ç"); got != want {
+		t.Errorf("chunks hold %d of the %d multi-byte runes", got, want)
+	}
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"io/fs"
 	"os"
@@ -11,18 +12,21 @@ import (
 
 	"github.com/PlatifyX/platifyx-core/internal/domain"
 	"github.com/PlatifyX/platifyx-core/pkg/cache"
+	"github.com/PlatifyX/platifyx-core/pkg/github"
 	"github.com/PlatifyX/platifyx-core/pkg/logger"
 	"github.com/google/uuid"
 )
 
 type TechDocsService struct {
-	docsPath       string
-	aiService      *AIService
-	diagramService *DiagramService
-	githubService  *GitHubService
-	log            *logger.Logger
-	progressStore  *cache.RedisClient
-	progressTTL    time.Duration
+	docsPath           string
+	aiService          *AIService
+	diagramService     *DiagramService
+	integrationService *IntegrationService
+	index              *TechDocsIndexService
+	store              TechDocsStore
+	log                *logger.Logger
+	progressStore      *cache.RedisClient
+	progressTTL        time.Duration
 }
 
 const (
@@ -30,25 +34,101 @@ const (
 	techDocsProgressTTL       = 2 * time.Hour
 )
 
-func NewTechDocsService(docsPath string, aiService *AIService, diagramService *DiagramService, githubService *GitHubService, progressStore *cache.RedisClient, log *logger.Logger) *TechDocsService {
+func NewTechDocsService(docsPath string, aiService *AIService, diagramService *DiagramService, integrationService *IntegrationService, index *TechDocsIndexService, store TechDocsStore, progressStore *cache.RedisClient, log *logger.Logger) *TechDocsService {
 	return &TechDocsService{
-		docsPath:       docsPath,
-		aiService:      aiService,
-		diagramService: diagramService,
-		githubService:  githubService,
-		log:            log,
-		progressStore:  progressStore,
-		progressTTL:    techDocsProgressTTL,
+		docsPath:           docsPath,
+		aiService:          aiService,
+		diagramService:     diagramService,
+		integrationService: integrationService,
+		index:              index,
+		store:              store,
+		log:                log,
+		progressStore:      progressStore,
+		progressTTL:        techDocsProgressTTL,
 	}
 }
 
-// GetDocumentTree returns the full document tree structure
-func (s *TechDocsService) GetDocumentTree() ([]domain.TechDocTreeNode, error) {
-	s.log.Info("Fetching document tree")
+// organizationDocsPath returns the root of the docs tree of an organization: each organization
+// has its own directory under docsPath and never sees the documents of the others
+func (s *TechDocsService) organizationDocsPath(organizationUUID string) (string, error) {
+	return organizationDocsRoot(s.docsPath, organizationUUID)
+}
 
-	var nodes []domain.TechDocTreeNode
+func organizationDocsRoot(docsPath, organizationUUID string) (string, error) {
+	if _, err := uuid.Parse(organizationUUID); err != nil {
+		return "", fmt.Errorf("organization UUID is required")
+	}
+	return filepath.Join(docsPath, organizationUUID), nil
+}
+
+// MigrateLegacyDocs moves the documents left in the shared docs root, from before documents were
+// scoped to organizations, into the directory of organizationUUID and commits the move. The root
+// then only holds organization directories, so later runs do nothing.
+func (s *TechDocsService) MigrateLegacyDocs(organizationUUID string) error {
+	root, err := s.organizationDocsPath(organizationUUID)
+	if err != nil {
+		return err
+	}
 
 	entries, err := os.ReadDir(s.docsPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read docs directory: %w", err)
+	}
+
+	moved := 0
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+		if _, err := uuid.Parse(name); err == nil && entry.IsDir() {
+			continue
+		}
+
+		if err := os.MkdirAll(root, 0755); err != nil {
+			return fmt.Errorf("failed to create organization docs directory: %w", err)
+		}
+		target := filepath.Join(root, name)
+		if _, err := os.Lstat(target); err == nil {
+			s.log.Warnw("Legacy document not moved, the organization already has it", "path", name, "organization", organizationUUID)
+			continue
+		}
+		if err := os.Rename(filepath.Join(s.docsPath, name), target); err != nil {
+			return fmt.Errorf("failed to move %s: %w", name, err)
+		}
+		moved++
+	}
+
+	if moved == 0 {
+		return nil
+	}
+	if err := s.store.CommitTree(fmt.Sprintf("Move legacy documents to organization %s", organizationUUID)); err != nil {
+		return err
+	}
+
+	s.log.Infow("Moved legacy documents to organization", "entries", moved, "organization", organizationUUID)
+	return nil
+}
+
+// GetDocumentTree returns the full document tree structure of the organization
+func (s *TechDocsService) GetDocumentTree(organizationUUID string) ([]domain.TechDocTreeNode, error) {
+	s.log.Infow("Fetching document tree", "organizationUUID", organizationUUID)
+
+	root, err := s.organizationDocsPath(organizationUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []domain.TechDocTreeNode
+
+	entries, err := os.ReadDir(root)
+	if os.IsNotExist(err) {
+		// No document saved yet
+		return []domain.TechDocTreeNode{}, nil
+	}
 	if err != nil {
 		s.log.Errorw("Failed to read docs directory", "error", err)
 		return nil, fmt.Errorf("failed to read docs directory: %w", err)
@@ -59,7 +139,7 @@ func (s *TechDocsService) GetDocumentTree() ([]domain.TechDocTreeNode, error) {
 			continue
 		}
 
-		node, err := s.buildTreeNode(entry.Name(), "")
+		node, err := s.buildTreeNode(root, entry.Name(), "")
 		if err != nil {
 			s.log.Errorw("Failed to build tree node", "error", err, "name", entry.Name())
 			continue
@@ -71,8 +151,8 @@ func (s *TechDocsService) GetDocumentTree() ([]domain.TechDocTreeNode, error) {
 	return nodes, nil
 }
 
-func (s *TechDocsService) buildTreeNode(name, relativePath string) (*domain.TechDocTreeNode, error) {
-	fullPath := filepath.Join(s.docsPath, relativePath, name)
+func (s *TechDocsService) buildTreeNode(root, name, relativePath string) (*domain.TechDocTreeNode, error) {
+	fullPath := filepath.Join(root, relativePath, name)
 	info, err := os.Stat(fullPath)
 	if err != nil {
 		return nil, err
@@ -95,7 +175,7 @@ func (s *TechDocsService) buildTreeNode(name, relativePath string) (*domain.Tech
 				continue
 			}
 
-			childNode, err := s.buildTreeNode(entry.Name(), filepath.Join(relativePath, name))
+			childNode, err := s.buildTreeNode(root, entry.Name(), filepath.Join(relativePath, name))
 			if err != nil {
 				s.log.Errorw("Failed to build child node", "error", err, "name", entry.Name())
 				continue
@@ -115,9 +195,36 @@ func (s *TechDocsService) buildTreeNode(name, relativePath string) (*domain.Tech
 	return node, nil
 }
 
+// DocumentExists tells whether the organization's docs tree has a document at path
+func (s *TechDocsService) DocumentExists(organizationUUID, docPath string) (bool, error) {
+	root, err := s.organizationDocsPath(organizationUUID)
+	if err != nil {
+		return false, err
+	}
+
+	cleanPath := filepath.Clean(docPath)
+	if strings.Contains(cleanPath, "..") {
+		return false, fmt.Errorf("invalid path")
+	}
+
+	info, err := os.Stat(filepath.Join(root, cleanPath))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to stat document: %w", err)
+	}
+	return !info.IsDir(), nil
+}
+
 // GetDocument retrieves a document by path
-func (s *TechDocsService) GetDocument(docPath string) (*domain.TechDoc, error) {
-	s.log.Infow("Fetching document", "path", docPath)
+func (s *TechDocsService) GetDocument(organizationUUID, docPath string) (*domain.TechDoc, error) {
+	s.log.Infow("Fetching document", "path", docPath, "organizationUUID", organizationUUID)
+
+	root, err := s.organizationDocsPath(organizationUUID)
+	if err != nil {
+		return nil, err
+	}
 
 	// Sanitize path to prevent directory traversal
 	cleanPath := filepath.Clean(docPath)
@@ -125,7 +232,7 @@ func (s *TechDocsService) GetDocument(docPath string) (*domain.TechDoc, error) {
 		return nil, fmt.Errorf("invalid path")
 	}
 
-	fullPath := filepath.Join(s.docsPath, cleanPath)
+	fullPath := filepath.Join(root, cleanPath)
 
 	info, err := os.Stat(fullPath)
 	if err != nil {
@@ -155,9 +262,20 @@ func (s *TechDocsService) GetDocument(docPath string) (*domain.TechDoc, error) {
 	return doc, nil
 }
 
-// SaveDocument saves or updates a document
-func (s *TechDocsService) SaveDocument(docPath, content string) error {
-	s.log.Infow("Saving document", "path", docPath)
+// SaveDocument saves or updates a document as author, and reindexes it in the background for the
+// chat of the organization
+func (s *TechDocsService) SaveDocument(organizationUUID, docPath, content string, author domain.TechDocAuthor) error {
+	return s.saveDocument(organizationUUID, docPath, content, "", author)
+}
+
+// saveDocument writes a document through the store; the revision message defaults to the kind of change
+func (s *TechDocsService) saveDocument(organizationUUID, docPath, content, message string, author domain.TechDocAuthor) error {
+	s.log.Infow("Saving document", "path", docPath, "organizationUUID", organizationUUID)
+
+	root, err := s.organizationDocsPath(organizationUUID)
+	if err != nil {
+		return err
+	}
 
 	// Sanitize path
 	cleanPath := filepath.Clean(docPath)
@@ -165,26 +283,36 @@ func (s *TechDocsService) SaveDocument(docPath, content string) error {
 		return fmt.Errorf("invalid path")
 	}
 
-	fullPath := filepath.Join(s.docsPath, cleanPath)
-
-	// Ensure parent directory exists
-	dir := filepath.Dir(fullPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
+	if message == "" {
+		message = "Update " + filepath.ToSlash(cleanPath)
+		if _, err := os.Stat(filepath.Join(root, cleanPath)); os.IsNotExist(err) {
+			message = "Create " + filepath.ToSlash(cleanPath)
+		}
 	}
 
-	// Write file
-	if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
-		return fmt.Errorf("failed to write document: %w", err)
+	if err := s.store.Write(filepath.Join(organizationUUID, cleanPath), []byte(content), message, author); err != nil {
+		return err
 	}
 
 	s.log.Infow("Saved document successfully", "path", docPath)
+
+	if s.index != nil {
+		go func() {
+			if err := s.index.IndexDocument(context.Background(), organizationUUID, filepath.ToSlash(cleanPath), content); err != nil {
+				s.log.Warnw("Failed to index document", "error", err, "path", docPath, "organizationUUID", organizationUUID)
+			}
+		}()
+	}
 	return nil
 }
 
-// DeleteDocument deletes a document
-func (s *TechDocsService) DeleteDocument(docPath string) error {
-	s.log.Infow("Deleting document", "path", docPath)
+// DeleteDocument deletes a document as author and drops it from the chat index of the organization
+func (s *TechDocsService) DeleteDocument(organizationUUID, docPath string, author domain.TechDocAuthor) error {
+	s.log.Infow("Deleting document", "path", docPath, "organizationUUID", organizationUUID)
+
+	if _, err := s.organizationDocsPath(organizationUUID); err != nil {
+		return err
+	}
 
 	// Sanitize path
 	cleanPath := filepath.Clean(docPath)
@@ -192,22 +320,119 @@ func (s *TechDocsService) DeleteDocument(docPath string) error {
 		return fmt.Errorf("invalid path")
 	}
 
-	fullPath := filepath.Join(s.docsPath, cleanPath)
-
-	if err := os.Remove(fullPath); err != nil {
-		if os.IsNotExist(err) {
-			return fmt.Errorf("document not found")
-		}
-		return fmt.Errorf("failed to delete document: %w", err)
+	if err := s.store.Delete(filepath.Join(organizationUUID, cleanPath), "Delete "+filepath.ToSlash(cleanPath), author); err != nil {
+		return err
 	}
 
 	s.log.Infow("Deleted document successfully", "path", docPath)
+
+	if s.index != nil {
+		if err := s.index.RemoveDocument(organizationUUID, filepath.ToSlash(cleanPath)); err != nil {
+			s.log.Warnw("Failed to remove document from index", "error", err, "path", docPath, "organizationUUID", organizationUUID)
+		}
+	}
 	return nil
 }
 
+// GetDocumentHistory lists the revisions of a document, most recent first
+func (s *TechDocsService) GetDocumentHistory(organizationUUID, docPath string, limit int) ([]domain.TechDocRevision, error) {
+	storePath, err := s.storePath(organizationUUID, docPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = defaultTechDocsHistoryLimit
+	}
+	if limit > maxTechDocsHistoryLimit {
+		limit = maxTechDocsHistoryLimit
+	}
+
+	return s.store.History(storePath, limit)
+}
+
+// GetDocumentRevision returns a document as it was at a revision
+func (s *TechDocsService) GetDocumentRevision(organizationUUID, docPath, revision string) (*domain.TechDocRevisionContent, error) {
+	storePath, err := s.storePath(organizationUUID, docPath)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := s.store.Revision(storePath, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.TechDocRevisionContent{
+		Path:     filepath.Clean(docPath),
+		Revision: revision,
+		Content:  string(content),
+	}, nil
+}
+
+// DiffDocument returns the changes of a document between two revisions (to the latest when to is empty)
+func (s *TechDocsService) DiffDocument(organizationUUID, docPath, from, to string) (*domain.TechDocDiff, error) {
+	storePath, err := s.storePath(organizationUUID, docPath)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := s.store.Diff(storePath, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.TechDocDiff{
+		Path: filepath.Clean(docPath),
+		From: from,
+		To:   to,
+		Diff: diff,
+	}, nil
+}
+
+// RestoreDocument saves the content of a previous revision as a new revision of the document
+// (recreating it when it was deleted since)
+func (s *TechDocsService) RestoreDocument(organizationUUID, docPath, revision string, author domain.TechDocAuthor) (*domain.TechDoc, error) {
+	previous, err := s.GetDocumentRevision(organizationUUID, docPath, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	short := revision
+	if len(short) > 8 {
+		short = short[:8]
+	}
+	message := fmt.Sprintf("Restore %s to %s", filepath.ToSlash(previous.Path), short)
+	if err := s.saveDocument(organizationUUID, previous.Path, previous.Content, message, author); err != nil {
+		return nil, err
+	}
+
+	s.log.Infow("Restored document", "path", previous.Path, "revision", revision, "author", author.Email)
+	return s.GetDocument(organizationUUID, previous.Path)
+}
+
+// storePath sanitizes the path of a document and returns it relative to the root of the store,
+// inside the directory of the organization
+func (s *TechDocsService) storePath(organizationUUID, docPath string) (string, error) {
+	if _, err := s.organizationDocsPath(organizationUUID); err != nil {
+		return "", err
+	}
+
+	cleanPath := filepath.Clean(docPath)
+	if strings.Contains(cleanPath, "..") || cleanPath == "." || filepath.IsAbs(cleanPath) {
+		return "", fmt.Errorf("invalid path")
+	}
+	return filepath.Join(organizationUUID, cleanPath), nil
+}
+
 // CreateFolder creates a new folder
-func (s *TechDocsService) CreateFolder(folderPath string) error {
-	s.log.Infow("Creating folder", "path", folderPath)
+func (s *TechDocsService) CreateFolder(organizationUUID, folderPath string) error {
+	s.log.Infow("Creating folder", "path", folderPath, "organizationUUID", organizationUUID)
+
+	root, err := s.organizationDocsPath(organizationUUID)
+	if err != nil {
+		return err
+	}
 
 	// Sanitize path
 	cleanPath := filepath.Clean(folderPath)
@@ -215,7 +440,7 @@ func (s *TechDocsService) CreateFolder(folderPath string) error {
 		return fmt.Errorf("invalid path")
 	}
 
-	fullPath := filepath.Join(s.docsPath, cleanPath)
+	fullPath := filepath.Join(root, cleanPath)
 
 	if err := os.MkdirAll(fullPath, 0755); err != nil {
 		return fmt.Errorf("failed to create folder: %w", err)
@@ -226,8 +451,13 @@ func (s *TechDocsService) CreateFolder(folderPath string) error {
 }
 
 // ListDocuments lists all markdown documents in a directory
-func (s *TechDocsService) ListDocuments(dirPath string) ([]domain.TechDoc, error) {
-	s.log.Infow("Listing documents", "path", dirPath)
+func (s *TechDocsService) ListDocuments(organizationUUID, dirPath string) ([]domain.TechDoc, error) {
+	s.log.Infow("Listing documents", "path", dirPath, "organizationUUID", organizationUUID)
+
+	root, err := s.organizationDocsPath(organizationUUID)
+	if err != nil {
+		return nil, err
+	}
 
 	cleanPath := filepath.Clean(dirPath)
 	if cleanPath == "." {
@@ -237,11 +467,11 @@ func (s *TechDocsService) ListDocuments(dirPath string) ([]domain.TechDoc, error
 		return nil, fmt.Errorf("invalid path")
 	}
 
-	fullPath := filepath.Join(s.docsPath, cleanPath)
+	fullPath := filepath.Join(root, cleanPath)
 
-	var docs []domain.TechDoc
+	docs := []domain.TechDoc{}
 
-	err := filepath.WalkDir(fullPath, func(path string, d fs.DirEntry, err error) error {
+	err = filepath.WalkDir(fullPath, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -264,7 +494,7 @@ func (s *TechDocsService) ListDocuments(dirPath string) ([]domain.TechDoc, error
 			return err
 		}
 
-		relPath, err := filepath.Rel(s.docsPath, path)
+		relPath, err := filepath.Rel(root, path)
 		if err != nil {
 			return err
 		}
@@ -281,7 +511,7 @@ func (s *TechDocsService) ListDocuments(dirPath string) ([]domain.TechDoc, error
 		return nil
 	})
 
-	if err != nil {
+	if err != nil && !os.IsNotExist(err) {
 		return nil, fmt.Errorf("failed to list documents: %w", err)
 	}
 
@@ -302,45 +532,32 @@ func (s *TechDocsService) getModelContextLimit(provider domain.AIProvider, model
 	switch provider {
 	case domain.AIProviderOpenAI:
 		// Always use small chunks for OpenAI to minimize costs
-		return 2000 // Conservative for gpt-3.5-turbo (cheapest option)
+		return 2000 // Conservative for the cheapest OpenAI models
 	case domain.AIProviderClaude:
 		// Use Haiku (cheapest) with conservative chunks
 		return 2500
 	case domain.AIProviderGemini:
-		// Gemini Pro with conservative chunks
+		// Gemini Flash with conservative chunks
 		return 2000
 	default:
 		return 1500 // Very conservative default
 	}
 }
 
-// getCheapestModel ALWAYS returns the cheapest model for the given provider
-// IGNORES any requested model to ensure cost minimization
-func (s *TechDocsService) getCheapestModel(provider domain.AIProvider, requestedModel string) string {
-	// ALWAYS use cheapest models, regardless of what was requested
-	// This ensures cost minimization at all times
-
-	var cheapestModel string
-	switch provider {
-	case domain.AIProviderOpenAI:
-		cheapestModel = "gpt-4.1-mini" // Cheapest OpenAI model (~90% cheaper than GPT-4)
-	case domain.AIProviderClaude:
-		cheapestModel = "claude-3-haiku-20240307" // Cheapest Claude model (~95% cheaper than Opus)
-	case domain.AIProviderGemini:
-		cheapestModel = "gemini-pro" // Standard Gemini model
-	default:
-		cheapestModel = "gpt-4.1-mini" // Default to cheapest overall
+// githubServiceFor returns the client of the organization's GitHub integration
+func (s *TechDocsService) githubServiceFor(organizationUUID string) (*GitHubService, error) {
+	if s.integrationService == nil {
+		return nil, fmt.Errorf("GitHub service not available")
 	}
 
-	// Log if we're overriding a requested model
-	if requestedModel != "" && requestedModel != cheapestModel {
-		s.log.Infow("Forcing cheapest model (cost optimization)",
-			"requested", requestedModel,
-			"forced", cheapestModel,
-			"provider", provider)
+	githubService, err := s.integrationService.GetGitHubServiceByName(organizationUUID, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load GitHub integration: %w", err)
 	}
-
-	return cheapestModel
+	if githubService == nil {
+		return nil, fmt.Errorf("GitHub integration not configured")
+	}
+	return githubService, nil
 }
 
 type repoFile struct {
@@ -359,7 +576,7 @@ func (s *TechDocsService) GenerateDocumentation(organizationUUID string, req dom
 		return nil, fmt.Errorf("progress store not configured")
 	}
 
-	progress := s.newProgressRecord(req)
+	progress := s.newProgressRecord(organizationUUID, req)
 	if err := s.persistProgress(progress); err != nil {
 		s.log.Errorw("Failed to persist progress", "error", err)
 		return nil, fmt.Errorf("failed to persist progress: %w", err)
@@ -392,8 +609,9 @@ func (s *TechDocsService) executeDocumentationGeneration(organizationUUID string
 	var files []repoFile
 
 	if req.Source == "github" && req.ReadFullRepo {
-		if s.githubService == nil {
-			return nil, fmt.Errorf("GitHub service not available")
+		githubService, err := s.githubServiceFor(organizationUUID)
+		if err != nil {
+			return nil, err
 		}
 
 		s.updateProgressMessage(progressID, "Lendo repositório do GitHub")
@@ -404,17 +622,20 @@ func (s *TechDocsService) executeDocumentationGeneration(organizationUUID string
 		}
 		owner, repo := parts[0], parts[1]
 
-		repoInfo, err := s.githubService.GetRepository(owner, repo)
+		repoInfo, err := githubService.GetRepository(owner, repo)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get repository info: %w", err)
 		}
 
-		ghFiles, err := s.githubService.GetAllRepositoryFiles(owner, repo, repoInfo.DefaultBranch)
+		// One tree call plus the blobs that changed since the last run (cached by SHA)
+		ingestion, err := githubService.IngestRepository(owner, repo, repoInfo.DefaultBranch, github.IngestOptions{
+			Cache: newGitHubBlobCache(s.progressStore, organizationUUID),
+		})
 		if err != nil {
 			return nil, fmt.Errorf("failed to read repository files: %w", err)
 		}
 
-		for _, f := range ghFiles {
+		for _, f := range ingestion.Files {
 			files = append(files, repoFile{
 				Path:    f.Path,
 				Content: f.Content,
@@ -423,18 +644,12 @@ func (s *TechDocsService) executeDocumentationGeneration(organizationUUID string
 
 		s.log.Infow("Loaded full repository", "fileCount", len(files))
 
-		req.Model = s.getCheapestModel(req.Provider, req.Model)
-		s.log.Infow("Using model", "provider", req.Provider, "model", req.Model)
-
 		contextLimit := s.getModelContextLimit(req.Provider, req.Model)
 		s.log.Infow("Context limit for model", "provider", req.Provider, "model", req.Model, "limit", contextLimit)
 
 		return s.generateDocumentationWithChunking(organizationUUID, req, files, contextLimit, progressID)
 	}
 
-	req.Model = s.getCheapestModel(req.Provider, req.Model)
-	s.log.Infow("Using model for single file", "provider", req.Provider, "model", req.Model)
-
 	prompt := s.buildGenerateDocPrompt(req)
 
 	estimatedTokens := s.estimateTokenCount(prompt)
@@ -452,14 +667,14 @@ func (s *TechDocsService) executeDocumentationGeneration(organizationUUID string
 	s.setProgressTotal(progressID, 1)
 	s.updateChunkProgress(progressID, 1, 1, "Gerando documentação")
 
-	response, err := s.aiService.GenerateCompletion(organizationUUID, req.Provider, prompt, req.Model)
+	response, err := s.aiService.GenerateCompletion(domain.AICaller{OrganizationUUID: organizationUUID, UserID: req.UserID, Feature: domain.AIFeatureTechDocs}, req.Provider, prompt, req.Model)
 	if err != nil {
 		s.log.Errorw("Failed to generate documentation", "error", err)
 		return nil, fmt.Errorf("failed to generate documentation: %w", err)
 	}
 
 	if req.SavePath != "" && response.Content != "" {
-		if err := s.SaveDocument(req.SavePath, response.Content); err != nil {
+		if err := s.SaveDocument(organizationUUID, req.SavePath, response.Content, req.Author); err != nil {
 			s.log.Errorw("Failed to auto-save documentation", "error", err, "path", req.SavePath)
 		} else {
 			s.updateProgressMessage(progressID, fmt.Sprintf("Documento salvo em %s", req.SavePath))
@@ -490,10 +705,11 @@ func (s *TechDocsService) generateDocumentationWithChunking(organizationUUID str
 		s.updateChunkProgress(progressID, 1, 1, "Gerando documentação")
 		req.Code = chunks[0]
 		prompt := s.buildGenerateDocPrompt(req)
-		return s.aiService.GenerateCompletion(organizationUUID, req.Provider, prompt, req.Model)
+		return s.aiService.GenerateCompletion(domain.AICaller{OrganizationUUID: organizationUUID, UserID: req.UserID, Feature: domain.AIFeatureTechDocs}, req.Provider, prompt, req.Model)
 	}
 
 	var chunkResults []string
+	var lastResponse *domain.AIResponse
 	for i, chunk := range chunks {
 		s.log.Infow("Processing chunk", "chunk", i+1, "total", len(chunks), "size", len(chunk))
 
@@ -505,13 +721,14 @@ func (s *TechDocsService) generateDocumentationWithChunking(organizationUUID str
 		// Modify the prompt to indicate this is part of a multi-chunk process
 		prompt := s.buildChunkDocPrompt(chunkReq, i+1, len(chunks))
 
-		response, err := s.aiService.GenerateCompletion(organizationUUID, req.Provider, prompt, req.Model)
+		response, err := s.aiService.GenerateCompletion(domain.AICaller{OrganizationUUID: organizationUUID, UserID: req.UserID, Feature: domain.AIFeatureTechDocs}, req.Provider, prompt, req.Model)
 		if err != nil {
 			s.log.Errorw("Failed to process chunk", "chunk", i+1, "error", err)
 			return nil, fmt.Errorf("failed to process chunk %d: %w", i+1, err)
 		}
 
 		chunkResults = append(chunkResults, response.Content)
+		lastResponse = response
 		bar := s.renderProgressBar(i+1, len(chunks))
 		percent := int(float64(i+1) / float64(len(chunks)) * 100)
 		s.log.Infow("Documentation progress", "chunk", i+1, "totalChunks", len(chunks), "percent", percent, "bar", bar)
@@ -520,15 +737,16 @@ func (s *TechDocsService) generateDocumentationWithChunking(organizationUUID str
 	// Combine all chunk results
 	combinedContent := s.combineChunkResults(chunkResults, req.DocType)
 
+	// The gateway may have routed the chunks to another provider than the requested one
 	response := &domain.AIResponse{
-		Provider: req.Provider,
-		Model:    req.Model,
+		Provider: lastResponse.Provider,
+		Model:    lastResponse.Model,
 		Content:  combinedContent,
 	}
 
 	// Auto-save if savePath is provided
 	if req.SavePath != "" && response.Content != "" {
-		if err := s.SaveDocument(req.SavePath, response.Content); err != nil {
+		if err := s.SaveDocument(organizationUUID, req.SavePath, response.Content, req.Author); err != nil {
 			s.log.Errorw("Failed to auto-save documentation", "error", err, "path", req.SavePath)
 		} else {
 			s.log.Infow("Documentation auto-saved successfully", "path", req.SavePath)
@@ -722,23 +940,24 @@ func (s *TechDocsService) combineChunkResults(results []string, docType string)
 	return combined.String()
 }
 
-func (s *TechDocsService) newProgressRecord(req domain.AIGenerateDocRequest) *domain.TechDocsProgress {
+func (s *TechDocsService) newProgressRecord(organizationUUID string, req domain.AIGenerateDocRequest) *domain.TechDocsProgress {
 	now := time.Now()
 	return &domain.TechDocsProgress{
-		ID:          uuid.NewString(),
-		Status:      domain.TechDocsProgressStatusQueued,
-		Percent:     0,
-		Chunk:       0,
-		TotalChunks: 0,
-		Message:     "Aguardando processamento",
-		Provider:    req.Provider,
-		Model:       req.Model,
-		DocType:     req.DocType,
-		Source:      req.Source,
-		SavePath:    req.SavePath,
-		RepoURL:     req.RepoURL,
-		StartedAt:   now,
-		UpdatedAt:   now,
+		ID:               uuid.NewString(),
+		OrganizationUUID: organizationUUID,
+		Status:           domain.TechDocsProgressStatusQueued,
+		Percent:          0,
+		Chunk:            0,
+		TotalChunks:      0,
+		Message:          "Aguardando processamento",
+		Provider:         req.Provider,
+		Model:            req.Model,
+		DocType:          req.DocType,
+		Source:           req.Source,
+		SavePath:         req.SavePath,
+		RepoURL:          req.RepoURL,
+		StartedAt:        now,
+		UpdatedAt:        now,
 	}
 }
 
@@ -759,7 +978,7 @@ func (s *TechDocsService) updateProgress(progressID string, updater func(*domain
 		return
 	}
 
-	progress, err := s.GetDocumentationProgress(progressID)
+	progress, err := s.loadProgress(progressID)
 	if err != nil {
 		s.log.Warnw("Failed to load progress", "progressId", progressID, "error", err)
 		return
@@ -846,7 +1065,20 @@ func (s *TechDocsService) markProgressCompleted(progressID string, response *dom
 	})
 }
 
-func (s *TechDocsService) GetDocumentationProgress(id string) (*domain.TechDocsProgress, error) {
+// GetDocumentationProgress returns a generation of the organization; the generations of other
+// organizations are reported as not found
+func (s *TechDocsService) GetDocumentationProgress(organizationUUID, id string) (*domain.TechDocsProgress, error) {
+	progress, err := s.loadProgress(id)
+	if err != nil {
+		return nil, err
+	}
+	if progress.OrganizationUUID != organizationUUID {
+		return nil, &domain.NotFoundError{Resource: "documentation progress", ID: id}
+	}
+	return progress, nil
+}
+
+func (s *TechDocsService) loadProgress(id string) (*domain.TechDocsProgress, error) {
 	if s.progressStore == nil {
 		return nil, fmt.Errorf("progress store not configured")
 	}
@@ -992,7 +1224,7 @@ func (s *TechDocsService) ImproveDocumentation(organizationUUID string, req doma
 
 	prompt := s.buildImproveDocPrompt(req)
 
-	response, err := s.aiService.GenerateCompletion(organizationUUID, req.Provider, prompt, req.Model)
+	response, err := s.aiService.GenerateCompletion(domain.AICaller{OrganizationUUID: organizationUUID, UserID: req.UserID, Feature: domain.AIFeatureTechDocs}, req.Provider, prompt, req.Model)
 	if err != nil {
 		s.log.Errorw("Failed to improve documentation", "error", err)
 		return nil, fmt.Errorf("failed to improve documentation: %w", err)
@@ -1045,7 +1277,8 @@ func (s *TechDocsService) buildImproveDocPrompt(req domain.AIImproveDocRequest)
 	return prompt.String()
 }
 
-// ChatAboutDocumentation provides Q&A about documentation using AI
+// ChatAboutDocumentation provides Q&A about documentation using AI, grounded on the chunks of the
+// docs tree most relevant to the question
 func (s *TechDocsService) ChatAboutDocumentation(organizationUUID string, req domain.AIChatRequest) (*domain.AIResponse, error) {
 	s.log.Infow("Processing chat about documentation", "provider", req.Provider, "organizationUUID", organizationUUID)
 
@@ -1053,14 +1286,86 @@ func (s *TechDocsService) ChatAboutDocumentation(organizationUUID string, req do
 		return nil, fmt.Errorf("AI service not available")
 	}
 
-	// Build messages with context
+	caller := domain.AICaller{OrganizationUUID: organizationUUID, UserID: req.UserID, Feature: domain.AIFeatureChat}
+	retrieved := s.retrieveDocumentation(context.Background(), caller, req.Message)
+
+	response, err := s.aiService.GenerateChat(caller, req.Provider, documentationChatMessages(req, retrieved), req.Model)
+	if err != nil {
+		s.log.Errorw("Failed to process chat", "error", err)
+		return nil, fmt.Errorf("failed to process chat: %w", err)
+	}
+	response.Sources = documentationSources(retrieved)
+
+	s.log.Infow("Chat processed successfully", "provider", req.Provider, "sources", len(response.Sources))
+	return response, nil
+}
+
+// ChatAboutDocumentationStream answers like ChatAboutDocumentation, handing the partial tokens
+// to onDelta as they are generated
+func (s *TechDocsService) ChatAboutDocumentationStream(ctx context.Context, organizationUUID string, req domain.AIChatRequest, onDelta func(delta string) error) (*domain.AIResponse, error) {
+	s.log.Infow("Streaming chat about documentation", "provider", req.Provider, "organizationUUID", organizationUUID)
+
+	if s.aiService == nil {
+		return nil, fmt.Errorf("AI service not available")
+	}
+
+	caller := domain.AICaller{OrganizationUUID: organizationUUID, UserID: req.UserID, Feature: domain.AIFeatureChat}
+	retrieved := s.retrieveDocumentation(ctx, caller, req.Message)
+
+	response, err := s.aiService.GenerateChatStream(ctx, caller, req.Provider, documentationChatMessages(req, retrieved), req.Model, onDelta)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process chat: %w", err)
+	}
+	response.Sources = documentationSources(retrieved)
+
+	s.log.Infow("Chat streamed successfully", "provider", req.Provider, "usage", response.Usage, "sources", len(response.Sources))
+	return response, nil
+}
+
+// retrieveDocumentation searches the index for the chunks relevant to the question. The chat still
+// answers (from the given context only) when retrieval is unavailable.
+func (s *TechDocsService) retrieveDocumentation(ctx context.Context, caller domain.AICaller, question string) []scoredChunk {
+	if s.index == nil || caller.OrganizationUUID == "" || strings.TrimSpace(question) == "" {
+		return nil
+	}
+
+	retrieved, err := s.index.Search(ctx, caller, question)
+	if err != nil {
+		s.log.Warnw("Failed to retrieve documentation for chat", "error", err, "organizationUUID", caller.OrganizationUUID)
+		return nil
+	}
+	return retrieved
+}
+
+// documentationChatMessages builds the conversation of a chat: the documentation context and the
+// retrieved chunks as system message, the history and the current question
+func documentationChatMessages(req domain.AIChatRequest, retrieved []scoredChunk) []domain.ChatMessage {
 	messages := []domain.ChatMessage{}
 
+	var system strings.Builder
 	if req.Context != "" {
-		// Add system message with document context
+		system.WriteString("You are a helpful assistant that answers questions about technical documentation. Here is the relevant documentation context:\n\n")
+		system.WriteString(req.Context)
+	}
+	if len(retrieved) > 0 {
+		if system.Len() == 0 {
+			system.WriteString("You are a helpful assistant that answers questions about technical documentation.")
+		}
+		system.WriteString("\n\nExcerpts of the documentation relevant to the question, each labelled with its source path:\n")
+		for _, r := range retrieved {
+			source := r.chunk.Path
+			if r.chunk.Heading != "" {
+				source += " > " + r.chunk.Heading
+			}
+			fmt.Fprintf(&system, "\n[source: %s]\n%s\n", source, r.chunk.Content)
+		}
+		system.WriteString("\nBase the answer on these excerpts and cite the source paths you used, for example (source: path/to/doc.md). Say so when they do not answer the question.")
+	}
+
+	if system.Len() > 0 {
 		messages = append(messages, domain.ChatMessage{
 			Role:    "system",
-			Content: "You are a helpful assistant that answers questions about technical documentation. Here is the relevant documentation context:\n\n" + req.Context,
+			Content: system.String(),
 		})
 	}
 
@@ -1073,14 +1378,45 @@ func (s *TechDocsService) ChatAboutDocumentation(organizationUUID string, req do
 		Content: req.Message,
 	})
 
-	response, err := s.aiService.GenerateChat(organizationUUID, req.Provider, messages, req.Model)
-	if err != nil {
-		s.log.Errorw("Failed to process chat", "error", err)
-		return nil, fmt.Errorf("failed to process chat: %w", err)
+	return messages
+}
+
+// documentationSources lists the retrieved chunks returned to the client as citations
+func documentationSources(retrieved []scoredChunk) []domain.TechDocSource {
+	if len(retrieved) == 0 {
+		return nil
 	}
 
-	s.log.Infow("Chat processed successfully", "provider", req.Provider)
-	return response, nil
+	sources := make([]domain.TechDocSource, 0, len(retrieved))
+	for _, r := range retrieved {
+		sources = append(sources, domain.TechDocSource{
+			Path:    r.chunk.Path,
+			Heading: r.chunk.Heading,
+			Score:   r.score,
+		})
+	}
+	return sources
+}
+
+// GetStoreStatus returns where documents are stored and whether their revisions reach the remote
+func (s *TechDocsService) GetStoreStatus() domain.TechDocsStoreStatus {
+	return s.store.Status()
+}
+
+// GetIndexStatus returns the state of the chat index of the organization
+func (s *TechDocsService) GetIndexStatus(organizationUUID string) (*domain.TechDocsIndexStatus, error) {
+	if s.index == nil {
+		return nil, fmt.Errorf("TechDocs index not available")
+	}
+	return s.index.Status(organizationUUID)
+}
+
+// SyncIndex reindexes the documents of the docs tree that changed since they were embedded
+func (s *TechDocsService) SyncIndex(ctx context.Context, organizationUUID string) (*domain.TechDocsIndexSyncResult, error) {
+	if s.index == nil {
+		return nil, fmt.Errorf("TechDocs index not available")
+	}
+	return s.index.Sync(ctx, organizationUUID)
 }
 
 // GenerateDiagram generates a diagram from code or description
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/PlatifyX/platifyx-core/pkg/logger"
+)
+
+func TestMigrateLegacyDocsMovesSharedRoot(t *testing.T) {
+	const orgUUID = "ebb73e53-aa9e-4a9c-bc0c-531934c519e6"
+	const otherOrgUUID = "0b8f4c1e-2d3a-4f5b-8c6d-7e8f9a0b1c2d"
+
+	docsPath := t.TempDir()
+	files := map[string]string{
+		"guia.md":                    "# Guia",
+		"arquitetura/visao-geral.md": "# Visão geral",
+		otherOrgUUID + "/privado.md": "# Outra organização",
+		orgUUID + "/existente.md":    "# Já migrado",
+		".gitignore":                 "*.tmp",
+	}
+	for name, content := range files {
+		if err := writeTechDoc(docsPath, name, []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	log := logger.NewLogger("development")
+	svc := NewTechDocsService(docsPath, nil, nil, nil, nil, &fileTechDocsStore{docsPath: docsPath}, nil, log)
+
+	for run := 1; run <= 2; run++ {
+		if err := svc.MigrateLegacyDocs(orgUUID); err != nil {
+			t.Fatalf("run %d: %v", run, err)
+		}
+	}
+
+	for _, name := range []string{
+		orgUUID + "/guia.md",
+		orgUUID + "/arquitetura/visao-geral.md",
+		orgUUID + "/existente.md",
+		otherOrgUUID + "/privado.md",
+		".gitignore",
+	} {
+		if _, err := os.Stat(filepath.Join(docsPath, name)); err != nil {
+			t.Errorf("%s is missing after the migration: %v", name, err)
+		}
+	}
+	for _, name := range []string{"guia.md", "arquitetura"} {
+		if _, err := os.Stat(filepath.Join(docsPath, name)); !os.IsNotExist(err) {
+			t.Errorf("%s is still in the shared root", name)
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/PlatifyX/platifyx-core/pkg/gitrepo"
+	"github.com/PlatifyX/platifyx-core/pkg/logger"
+)
+
+const (
+	TechDocsStoreFilesystem = "filesystem"
+	TechDocsStoreGit        = "git"
+
+	defaultTechDocsHistoryLimit = 50
+	maxTechDocsHistoryLimit     = 500
+)
+
+var (
+	// ErrTechDocsHistoryUnavailable is returned by the history operations of stores without history
+	ErrTechDocsHistoryUnavailable = errors.New("document history requires the git TechDocs store")
+	// ErrTechDocRevisionNotFound is returned for unknown revisions, or revisions without the document
+	ErrTechDocRevisionNotFound = errors.New("revision not found")
+)
+
+// TechDocsStore persists the changes to the docs tree. Documents are always read from the working
+// directory; stores with history also version every change with its author.
+type TechDocsStore interface {
+	Write(docPath string, content []byte, message string, author domain.TechDocAuthor) error
+	Delete(docPath string, message string, author domain.TechDocAuthor) error
+	History(docPath string, limit int) ([]domain.TechDocRevision, error)
+	Revision(docPath, revision string) ([]byte, error)
+	Diff(docPath, from, to string) (string, error)
+	// CommitTree records changes made to the tree outside of Write and Delete (moves at startup)
+	CommitTree(message string) error
+	Status() domain.TechDocsStoreStatus
+}
+
+// NewTechDocsStore builds the store configured for the docs tree. The git store falls back to
+// plain files when the repository cannot be opened, so the docs stay editable.
+func NewTechDocsStore(kind, docsPath string, gitOptions gitrepo.Options, log *logger.Logger) TechDocsStore {
+	if kind == TechDocsStoreGit {
+		repo, err := gitrepo.Open(docsPath, gitOptions)
+		if err == nil {
+			log.Infow("TechDocs stored in git", "path", docsPath, "remote", repo.HasRemote(), "branch", gitOptions.Branch)
+			return newGitTechDocsStore(repo, log)
+		}
+		log.Errorw("Failed to open TechDocs git repository, documents will not be versioned", "error", err, "path", docsPath)
+	}
+
+	return &fileTechDocsStore{docsPath: docsPath}
+}
+
+// fileTechDocsStore writes the documents as plain files, without history
+type fileTechDocsStore struct {
+	docsPath string
+}
+
+func (s *fileTechDocsStore) Write(docPath string, content []byte, message string, author domain.TechDocAuthor) error {
+	return writeTechDoc(s.docsPath, docPath, content)
+}
+
+func (s *fileTechDocsStore) Delete(docPath string, message string, author domain.TechDocAuthor) error {
+	return removeTechDoc(s.docsPath, docPath)
+}
+
+func (s *fileTechDocsStore) History(docPath string, limit int) ([]domain.TechDocRevision, error) {
+	return nil, ErrTechDocsHistoryUnavailable
+}
+
+func (s *fileTechDocsStore) Revision(docPath, revision string) ([]byte, error) {
+	return nil, ErrTechDocsHistoryUnavailable
+}
+
+func (s *fileTechDocsStore) Diff(docPath, from, to string) (string, error) {
+	return "", ErrTechDocsHistoryUnavailable
+}
+
+func (s *fileTechDocsStore) CommitTree(message string) error {
+	return nil
+}
+
+func (s *fileTechDocsStore) Status() domain.TechDocsStoreStatus {
+	return domain.TechDocsStoreStatus{Kind: TechDocsStoreFilesystem}
+}
+
+// gitTechDocsStore commits every change to a git repository rooted at the docs tree, and pushes
+// the commits to the configured remote in the background
+type gitTechDocsStore struct {
+	repo *gitrepo.Repository
+	log  *logger.Logger
+
+	// Buffered by one: pushes requested while one runs are coalesced in the next
+	pushes chan struct{}
+	// Writes are serialized so a change is committed alone
+	mu sync.Mutex
+}
+
+func newGitTechDocsStore(repo *gitrepo.Repository, log *logger.Logger) *gitTechDocsStore {
+	s := &gitTechDocsStore{
+		repo:   repo,
+		log:    log,
+		pushes: make(chan struct{}, 1),
+	}
+	if repo.HasRemote() {
+		go s.pushLoop()
+		s.schedulePush()
+	}
+	return s
+}
+
+func (s *gitTechDocsStore) Write(docPath string, content []byte, message string, author domain.TechDocAuthor) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.update(docPath, message, author, func() error {
+		return writeTechDoc(s.repo.Dir(), docPath, content)
+	})
+}
+
+func (s *gitTechDocsStore) Delete(docPath string, message string, author domain.TechDocAuthor) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.update(docPath, message, author, func() error {
+		return removeTechDoc(s.repo.Dir(), docPath)
+	})
+}
+
+func (s *gitTechDocsStore) History(docPath string, limit int) ([]domain.TechDocRevision, error) {
+	commits, err := s.repo.Log(docPath, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read document history: %w", err)
+	}
+
+	history := make([]domain.TechDocRevision, 0, len(commits))
+	for _, commit := range commits {
+		history = append(history, domain.TechDocRevision{
+			Revision:    commit.Hash,
+			AuthorName:  commit.AuthorName,
+			AuthorEmail: commit.AuthorEmail,
+			Message:     commit.Message,
+			CreatedAt:   commit.Date,
+		})
+	}
+	return history, nil
+}
+
+func (s *gitTechDocsStore) Revision(docPath, revision string) ([]byte, error) {
+	content, err := s.repo.Show(revision, docPath)
+	if errors.Is(err, gitrepo.ErrRevisionNotFound) {
+		return nil, ErrTechDocRevisionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read revision: %w", err)
+	}
+	return content, nil
+}
+
+func (s *gitTechDocsStore) Diff(docPath, from, to string) (string, error) {
+	diff, err := s.repo.Diff(from, to, docPath)
+	if errors.Is(err, gitrepo.ErrRevisionNotFound) {
+		return "", ErrTechDocRevisionNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to diff revisions: %w", err)
+	}
+	return diff, nil
+}
+
+func (s *gitTechDocsStore) CommitTree(message string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	revision, err := s.repo.CommitPath(".", message, gitrepo.Signature{})
+	return s.committed(".", revision, domain.TechDocAuthor{}, err)
+}
+
+func (s *gitTechDocsStore) Status() domain.TechDocsStoreStatus {
+	status := domain.TechDocsStoreStatus{
+		Kind:      TechDocsStoreGit,
+		Versioned: true,
+		Remote:    s.repo.HasRemote(),
+	}
+
+	push := s.repo.SyncStatus()
+	if !push.LastPushAt.IsZero() {
+		status.LastPushAt = &push.LastPushAt
+	}
+	if !push.LastAttemptAt.IsZero() {
+		status.LastAttemptAt = &push.LastAttemptAt
+	}
+	status.LastPushError = push.LastError
+	status.Diverged = push.Diverged
+	return status
+}
+
+// update changes the working tree and commits the document in one step of the repository, so a
+// rebase of the push loop never sees the change uncommitted
+func (s *gitTechDocsStore) update(docPath, message string, author domain.TechDocAuthor, change func() error) error {
+	revision, err := s.repo.Update(docPath, message, gitrepo.Signature{Name: author.Name, Email: author.Email}, change)
+	return s.committed(docPath, revision, author, err)
+}
+
+func (s *gitTechDocsStore) committed(docPath, revision string, author domain.TechDocAuthor, err error) error {
+	if errors.Is(err, gitrepo.ErrNothingToCommit) {
+		// Saving a document unchanged
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to commit document: %w", err)
+	}
+
+	s.log.Infow("Committed document", "path", docPath, "revision", revision, "author", author.Email)
+	s.schedulePush()
+	return nil
+}
+
+func (s *gitTechDocsStore) schedulePush() {
+	if !s.repo.HasRemote() {
+		return
+	}
+	select {
+	case s.pushes <- struct{}{}:
+	default:
+	}
+}
+
+// pushLoop pushes the commits as they are made. A failed push is retried with the next change;
+// its outcome is reported by Status.
+func (s *gitTechDocsStore) pushLoop() {
+	for range s.pushes {
+		err := s.repo.Push()
+		var diverged *gitrepo.DivergedError
+		switch {
+		case errors.As(err, &diverged):
+			s.log.Errorw("TechDocs repository diverged from its remote, revisions are not pushed", "error", err)
+		case err != nil:
+			s.log.Warnw("Failed to push TechDocs repository", "error", err)
+		}
+	}
+}
+
+func writeTechDoc(docsPath, docPath string, content []byte) error {
+	fullPath := filepath.Join(docsPath, docPath)
+
+	// Ensure parent directory exists
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	if err := os.WriteFile(fullPath, content, 0644); err != nil {
+		return fmt.Errorf("failed to write document: %w", err)
+	}
+	return nil
+}
+
+func removeTechDoc(docsPath, docPath string) error {
+	if err := os.Remove(filepath.Join(docsPath, docPath)); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("document not found")
+		}
+		return fmt.Errorf("failed to delete document: %w", err)
+	}
+	return nil
+}
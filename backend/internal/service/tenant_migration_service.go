@@ -0,0 +1,244 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"sort"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/PlatifyX/platifyx-core/internal/repository"
+	"github.com/PlatifyX/platifyx-core/pkg/database"
+	"github.com/PlatifyX/platifyx-core/pkg/logger"
+)
+
+// TenantMigrationsPath is the directory of the per-organization migration track
+const TenantMigrationsPath = "migrations/tenant"
+
+// TenantMigrationService evolves the schema of every organization in its node database.
+// Applied versions are recorded in the schema_migrations table of each organization schema.
+type TenantMigrationService struct {
+	orgRepo        *repository.OrganizationRepository
+	nodeDBs        *database.TenantDBManager
+	migrationsPath string
+	log            *logger.Logger
+}
+
+func NewTenantMigrationService(orgRepo *repository.OrganizationRepository, nodeDBs *database.TenantDBManager, migrationsPath string, log *logger.Logger) *TenantMigrationService {
+	return &TenantMigrationService{
+		orgRepo:        orgRepo,
+		nodeDBs:        nodeDBs,
+		migrationsPath: migrationsPath,
+		log:            log,
+	}
+}
+
+// Status reports the applied and pending migrations of every organization
+func (s *TenantMigrationService) Status() (*domain.TenantMigrationReport, error) {
+	migrations, organizations, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	report := newTenantMigrationReport(migrations, false)
+	for i := range organizations {
+		addTenantMigrationStatus(report, s.status(&organizations[i], migrations))
+	}
+
+	return report, nil
+}
+
+func (s *TenantMigrationService) OrganizationStatus(uuid string) (*domain.TenantMigrationStatus, error) {
+	migrations, err := database.LoadTenantMigrations(s.migrationsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := s.orgRepo.GetByUUID(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	status := s.status(org, migrations)
+	return &status, nil
+}
+
+// MigrateAll applies the pending migrations to every organization, one at a time. With
+// StopOnError the run pauses at the first organization that fails and the remaining ones
+// are reported as skipped, so the failure can be fixed before going on.
+func (s *TenantMigrationService) MigrateAll(opts domain.TenantMigrationOptions) (*domain.TenantMigrationReport, error) {
+	migrations, organizations, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	report := newTenantMigrationReport(migrations, opts.DryRun)
+	for i := range organizations {
+		org := &organizations[i]
+		if report.Stopped {
+			addTenantMigrationStatus(report, domain.TenantMigrationStatus{
+				OrganizationUUID: org.UUID,
+				OrganizationName: org.Name,
+				State:            domain.TenantMigrationSkipped,
+			})
+			continue
+		}
+
+		status := s.migrate(org, migrations, opts.DryRun)
+		addTenantMigrationStatus(report, status)
+		if status.State == domain.TenantMigrationFailed && opts.StopOnError {
+			report.Stopped = true
+			s.log.Warnw("Tenant migrations paused on first failure", "orgUUID", org.UUID, "version", status.FailedVersion)
+		}
+	}
+
+	s.log.Infow("Tenant migrations finished",
+		"dryRun", opts.DryRun,
+		"organizations", report.Total,
+		"migrated", report.Migrated,
+		"failed", report.Failed,
+		"skipped", report.Skipped,
+	)
+	return report, nil
+}
+
+// MigrateOrganization applies the pending migrations to one organization
+func (s *TenantMigrationService) MigrateOrganization(uuid string, dryRun bool) (*domain.TenantMigrationStatus, error) {
+	migrations, err := database.LoadTenantMigrations(s.migrationsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := s.orgRepo.GetByUUID(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	status := s.migrate(org, migrations, dryRun)
+	return &status, nil
+}
+
+func (s *TenantMigrationService) load() ([]database.TenantMigration, []domain.Organization, error) {
+	migrations, err := database.LoadTenantMigrations(s.migrationsPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	organizations, err := s.orgRepo.GetAll()
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Slice(organizations, func(i, j int) bool {
+		return organizations[i].CreatedAt.Before(organizations[j].CreatedAt)
+	})
+
+	return migrations, organizations, nil
+}
+
+func (s *TenantMigrationService) status(org *domain.Organization, migrations []database.TenantMigration) domain.TenantMigrationStatus {
+	status := domain.TenantMigrationStatus{
+		OrganizationUUID: org.UUID,
+		OrganizationName: org.Name,
+	}
+
+	nodeDB, err := s.nodeDBs.Reader(org)
+	if err == nil {
+		err = fillTenantMigrationStatus(&status, nodeDB, org, migrations)
+	}
+	if err != nil {
+		status.State = domain.TenantMigrationFailed
+		status.Error = err.Error()
+		return status
+	}
+
+	status.State = domain.TenantMigrationUpToDate
+	if len(status.Pending) > 0 {
+		status.State = domain.TenantMigrationPending
+	}
+	return status
+}
+
+func (s *TenantMigrationService) migrate(org *domain.Organization, migrations []database.TenantMigration, dryRun bool) domain.TenantMigrationStatus {
+	status := domain.TenantMigrationStatus{
+		OrganizationUUID: org.UUID,
+		OrganizationName: org.Name,
+	}
+
+	nodeDB, err := s.nodeDBs.Writer(org)
+	if err != nil {
+		status.State = domain.TenantMigrationFailed
+		status.Error = err.Error()
+		return status
+	}
+
+	executed, err := database.MigrateTenantSchema(nodeDB, org.UUID, migrations, dryRun)
+	status.Executed = executed
+
+	// Read back from the primary: a replica may not have the new versions yet
+	if statusErr := fillTenantMigrationStatus(&status, nodeDB, org, migrations); err == nil {
+		err = statusErr
+	}
+	if err != nil {
+		status.State = domain.TenantMigrationFailed
+		status.Error = err.Error()
+		var migrationErr *database.TenantMigrationError
+		if errors.As(err, &migrationErr) {
+			status.FailedVersion = migrationErr.Version
+		}
+		s.log.Errorw("Tenant migration failed", "error", err, "orgUUID", org.UUID, "dryRun", dryRun)
+		return status
+	}
+
+	switch {
+	case len(executed) == 0:
+		status.State = domain.TenantMigrationUpToDate
+	case dryRun:
+		status.State = domain.TenantMigrationDryRun
+	default:
+		status.State = domain.TenantMigrationMigrated
+		s.log.Infow("Tenant migrations applied", "orgUUID", org.UUID, "versions", executed)
+	}
+	return status
+}
+
+func fillTenantMigrationStatus(status *domain.TenantMigrationStatus, nodeDB *sql.DB, org *domain.Organization, migrations []database.TenantMigration) error {
+	status.Applied = []string{}
+	status.Pending = []string{}
+
+	applied, err := database.AppliedTenantMigrations(nodeDB, org.UUID)
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range migrations {
+		if applied[migration.Version] {
+			status.Applied = append(status.Applied, migration.Version)
+		} else {
+			status.Pending = append(status.Pending, migration.Version)
+		}
+	}
+	return nil
+}
+
+func newTenantMigrationReport(migrations []database.TenantMigration, dryRun bool) *domain.TenantMigrationReport {
+	report := &domain.TenantMigrationReport{
+		DryRun:        dryRun,
+		Organizations: []domain.TenantMigrationStatus{},
+	}
+	if n := len(migrations); n > 0 {
+		report.Latest = migrations[n-1].Version
+	}
+	return report
+}
+
+func addTenantMigrationStatus(report *domain.TenantMigrationReport, status domain.TenantMigrationStatus) {
+	report.Total++
+	switch status.State {
+	case domain.TenantMigrationMigrated, domain.TenantMigrationDryRun:
+		report.Migrated++
+	case domain.TenantMigrationFailed:
+		report.Failed++
+	case domain.TenantMigrationSkipped:
+		report.Skipped++
+	}
+	report.Organizations = append(report.Organizations, status)
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -39,7 +40,37 @@ func (s *TroubleshootingAssistantService) Troubleshoot(organizationUUID string,
 
 	contextData := s.gatherContext(req)
 
-	messages := []domain.ChatMessage{
+	response, err := s.aiService.GenerateChat(domain.AICaller{OrganizationUUID: organizationUUID, UserID: req.UserID, Feature: domain.AIFeatureTroubleshooting}, "", s.buildMessages(req, contextData), "")
+	if err != nil {
+		return nil, err
+	}
+
+	return s.parseTroubleshootingResponse(response.Content, contextData)
+}
+
+// TroubleshootStream analyzes like Troubleshoot, handing the partial tokens of the answer to
+// onDelta as they are generated. The usage of the completion is returned with the analysis.
+func (s *TroubleshootingAssistantService) TroubleshootStream(ctx context.Context, organizationUUID string, req domain.TroubleshootingRequest, onDelta func(delta string) error) (*domain.TroubleshootingResponse, *domain.AIUsage, error) {
+	if s.aiService == nil {
+		return nil, nil, fmt.Errorf("AI service not available")
+	}
+
+	contextData := s.gatherContext(req)
+
+	response, err := s.aiService.GenerateChatStream(ctx, domain.AICaller{OrganizationUUID: organizationUUID, UserID: req.UserID, Feature: domain.AIFeatureTroubleshooting}, "", s.buildMessages(req, contextData), "", onDelta)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, err := s.parseTroubleshootingResponse(response.Content, contextData)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, response.Usage, nil
+}
+
+func (s *TroubleshootingAssistantService) buildMessages(req domain.TroubleshootingRequest, contextData map[string]interface{}) []domain.ChatMessage {
+	return []domain.ChatMessage{
 		{
 			Role: "system",
 			Content: `Você é um assistente especializado em troubleshooting de infraestrutura DevOps e Kubernetes.
@@ -52,17 +83,10 @@ Analise o contexto fornecido e responda com:
 Seja técnico, preciso e direto.`,
 		},
 		{
-			Role: "user",
+			Role:    "user",
 			Content: s.buildPrompt(req, contextData),
 		},
 	}
-
-	response, err := s.aiService.GenerateChat(organizationUUID, domain.AIProviderClaude, messages, "")
-	if err != nil {
-		return nil, err
-	}
-
-	return s.parseTroubleshootingResponse(response.Content, contextData)
 }
 
 func (s *TroubleshootingAssistantService) gatherContext(req domain.TroubleshootingRequest) map[string]interface{} {
@@ -6,24 +6,38 @@ import (
 	"github.com/PlatifyX/platifyx-core/pkg/logger"
 )
 
+// membershipRoles maps the legacy membership role of user_organizations to the
+// global role that is bound in the organization. Other values map to the role
+// with the same name, when one exists.
+var membershipRoles = map[string]string{
+	"owner":  domain.SystemAdminRoleName,
+	"member": "developer",
+}
+
 type UserOrganizationService struct {
-	repo     *repository.UserOrganizationRepository
-	userRepo *repository.UserRepository
-	orgRepo  *repository.OrganizationRepository
-	log      *logger.Logger
+	repo        *repository.UserOrganizationRepository
+	userRepo    *repository.UserRepository
+	orgRepo     *repository.OrganizationRepository
+	roleRepo    *repository.RoleRepository
+	bindingRepo *repository.RoleBindingRepository
+	log         *logger.Logger
 }
 
 func NewUserOrganizationService(
 	repo *repository.UserOrganizationRepository,
 	userRepo *repository.UserRepository,
 	orgRepo *repository.OrganizationRepository,
+	roleRepo *repository.RoleRepository,
+	bindingRepo *repository.RoleBindingRepository,
 	log *logger.Logger,
 ) *UserOrganizationService {
 	return &UserOrganizationService{
-		repo:     repo,
-		userRepo: userRepo,
-		orgRepo:  orgRepo,
-		log:      log,
+		repo:        repo,
+		userRepo:    userRepo,
+		orgRepo:     orgRepo,
+		roleRepo:    roleRepo,
+		bindingRepo: bindingRepo,
+		log:         log,
 	}
 }
 
@@ -123,19 +137,23 @@ func (s *UserOrganizationService) AddUserToOrganization(orgUUID string, req doma
 		return nil, err
 	}
 
+	s.syncMembershipRole(orgUUID, req.UserID, "", role)
+
 	s.log.Infow("User added to organization successfully", "orgUUID", orgUUID, "userID", req.UserID)
 	return uo, nil
 }
 
-func (s *UserOrganizationService) UpdateUserRole(id, role string) error {
-	s.log.Infow("Updating user organization role", "id", id, "role", role)
+func (s *UserOrganizationService) UpdateUserRole(membership domain.UserOrganization, role string) error {
+	s.log.Infow("Updating user organization role", "id", membership.ID, "role", role)
 
-	err := s.repo.UpdateRole(id, role)
+	err := s.repo.UpdateRole(membership.ID, role)
 	if err != nil {
 		s.log.Errorw("Failed to update user organization role", "error", err)
 		return err
 	}
 
+	s.syncMembershipRole(membership.OrganizationUUID, membership.UserID, membership.Role, role)
+
 	return nil
 }
 
@@ -148,6 +166,11 @@ func (s *UserOrganizationService) RemoveUserFromOrganization(userID, orgUUID str
 		return err
 	}
 
+	if err := s.bindingRepo.DeleteByUser(orgUUID, userID); err != nil {
+		s.log.Errorw("Failed to remove organization role bindings", "error", err, "userID", userID, "orgUUID", orgUUID)
+		return err
+	}
+
 	return nil
 }
 
@@ -159,4 +182,90 @@ func (s *UserOrganizationService) HasAccess(userID, orgUUID string) (bool, error
 	return uo != nil, nil
 }
 
+// ListRoleBindings returns the role bindings of an organization
+func (s *UserOrganizationService) ListRoleBindings(orgUUID string) ([]domain.RoleBinding, error) {
+	return s.bindingRepo.List(orgUUID)
+}
+
+// BindRole grants a role to a member of the organization. The role must be
+// global or belong to the same organization.
+func (s *UserOrganizationService) BindRole(orgUUID, roleID, userID, createdBy string) (*domain.RoleBinding, error) {
+	role, err := s.roleRepo.GetByID(roleID)
+	if err != nil || (role.OrganizationUUID != nil && *role.OrganizationUUID != orgUUID) {
+		return nil, &domain.NotFoundError{Resource: "role", ID: roleID}
+	}
+
+	membership, err := s.repo.GetByUserAndOrganization(userID, orgUUID)
+	if err != nil {
+		return nil, err
+	}
+	if membership == nil {
+		return nil, &domain.ValidationError{Field: "user_id", Message: "user is not a member of this organization"}
+	}
+
+	binding := &domain.RoleBinding{
+		OrganizationUUID: orgUUID,
+		UserID:           userID,
+		RoleID:           role.ID,
+		RoleName:         role.Name,
+	}
+	if createdBy != "" {
+		binding.CreatedBy = &createdBy
+	}
+
+	if err := s.bindingRepo.Create(binding); err != nil {
+		s.log.Errorw("Failed to bind role", "error", err, "orgUUID", orgUUID, "roleID", roleID, "userID", userID)
+		return nil, err
+	}
+
+	s.log.Infow("Role bound in organization", "orgUUID", orgUUID, "role", role.Name, "userID", userID)
+	return binding, nil
+}
+
+// UnbindRole revokes a role from a member of the organization
+func (s *UserOrganizationService) UnbindRole(orgUUID, roleID, userID string) error {
+	if err := s.bindingRepo.Delete(orgUUID, roleID, userID); err != nil {
+		return &domain.NotFoundError{Resource: "role binding", ID: roleID}
+	}
+
+	s.log.Infow("Role unbound in organization", "orgUUID", orgUUID, "roleID", roleID, "userID", userID)
+	return nil
+}
+
+// syncMembershipRole keeps the role binding that mirrors the membership role
+// up to date. Membership roles without a matching global role are ignored.
+func (s *UserOrganizationService) syncMembershipRole(orgUUID, userID, previous, current string) {
+	if previous != "" && previous != current {
+		if role := s.membershipRole(previous); role != nil {
+			if err := s.bindingRepo.Delete(orgUUID, role.ID, userID); err != nil {
+				s.log.Warnw("Previous membership role was not bound", "orgUUID", orgUUID, "userID", userID, "role", role.Name)
+			}
+		}
+	}
+
+	role := s.membershipRole(current)
+	if role == nil {
+		return
+	}
+
+	binding := &domain.RoleBinding{
+		OrganizationUUID: orgUUID,
+		UserID:           userID,
+		RoleID:           role.ID,
+	}
+	if err := s.bindingRepo.Create(binding); err != nil {
+		s.log.Errorw("Failed to bind membership role", "error", err, "orgUUID", orgUUID, "userID", userID, "role", role.Name)
+	}
+}
+
+func (s *UserOrganizationService) membershipRole(name string) *domain.Role {
+	if mapped, ok := membershipRoles[name]; ok {
+		name = mapped
+	}
 
+	role, err := s.roleRepo.GetByName(name)
+	if err != nil {
+		return nil
+	}
+	return role
+}
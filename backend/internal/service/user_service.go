@@ -177,6 +177,12 @@ func (s *UserService) GetUserPermissions(userID string) (*domain.UserPermissions
 	return s.userRepo.GetUserPermissions(userID)
 }
 
+// GetUserPermissionsInOrganization retorna as permissões de um usuário em uma organização,
+// considerando só os roles vinculados na organização e o indicador de admin da plataforma
+func (s *UserService) GetUserPermissionsInOrganization(userID, organizationUUID string) (*domain.UserPermissions, error) {
+	return s.userRepo.GetUserPermissionsInOrganization(userID, organizationUUID)
+}
+
 // GetStats retorna estatísticas de usuários
 func (s *UserService) GetStats() (map[string]interface{}, error) {
 	return s.userRepo.GetStats()
@@ -0,0 +1,22 @@
+-- Migration: Organization data keys
+-- Chaves de dados por organização usadas para criptografar credenciais das integrações (envelope encryption)
+
+CREATE TABLE IF NOT EXISTS organization_data_keys (
+    id SERIAL PRIMARY KEY,
+    organization_uuid UUID NOT NULL REFERENCES organizations(uuid) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    wrapped_key BYTEA NOT NULL,
+    master_key_id VARCHAR(100) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (organization_uuid, version)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_organization_data_keys_active
+    ON organization_data_keys(organization_uuid) WHERE active = true;
+
+COMMENT ON TABLE organization_data_keys IS 'Chaves de dados por organização, criptografadas com a master key';
+COMMENT ON COLUMN organization_data_keys.version IS 'Versão da chave, referenciada nos valores criptografados';
+COMMENT ON COLUMN organization_data_keys.wrapped_key IS 'Chave de dados criptografada com a master key';
+COMMENT ON COLUMN organization_data_keys.master_key_id IS 'Identificador da master key usada para criptografar a chave de dados';
+COMMENT ON COLUMN organization_data_keys.active IS 'Indica a chave usada para novas criptografias';
@@ -0,0 +1,28 @@
+-- Migration: DORA snapshots
+-- Contadores diários de métricas DORA por organização, serviço e squad
+
+CREATE TABLE IF NOT EXISTS dora_snapshots (
+    id SERIAL PRIMARY KEY,
+    organization_uuid UUID NOT NULL REFERENCES organizations(uuid) ON DELETE CASCADE,
+    scope VARCHAR(20) NOT NULL,
+    scope_key VARCHAR(255) NOT NULL,
+    snapshot_date DATE NOT NULL,
+    deployments INTEGER NOT NULL DEFAULT 0,
+    failed_deployments INTEGER NOT NULL DEFAULT 0,
+    lead_time_minutes_total DOUBLE PRECISION NOT NULL DEFAULT 0,
+    lead_time_samples INTEGER NOT NULL DEFAULT 0,
+    restore_minutes_total DOUBLE PRECISION NOT NULL DEFAULT 0,
+    restores INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (organization_uuid, scope, scope_key, snapshot_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_dora_snapshots_lookup
+    ON dora_snapshots(organization_uuid, scope, scope_key, snapshot_date);
+
+COMMENT ON TABLE dora_snapshots IS 'Snapshots diários das métricas DORA';
+COMMENT ON COLUMN dora_snapshots.scope IS 'Escopo do snapshot: organization, service ou squad';
+COMMENT ON COLUMN dora_snapshots.scope_key IS 'Nome do serviço ou squad (UUID da organização para o escopo organization)';
+COMMENT ON COLUMN dora_snapshots.lead_time_minutes_total IS 'Soma dos lead times (commit até deploy) em minutos';
+COMMENT ON COLUMN dora_snapshots.restore_minutes_total IS 'Soma dos tempos de restauração em minutos';
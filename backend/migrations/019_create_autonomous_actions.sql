@@ -0,0 +1,80 @@
+-- Migration: Autonomous actions
+-- Ações autônomas (rollback, scale, restart) e configuração por organização, com fluxo de aprovação
+
+CREATE TABLE IF NOT EXISTS autonomous_actions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_uuid UUID NOT NULL REFERENCES organizations(uuid) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL,
+    status VARCHAR(30) NOT NULL,
+    description TEXT,
+    trigger VARCHAR(50) NOT NULL DEFAULT 'manual',
+    action JSONB NOT NULL,
+    result JSONB,
+    error TEXT,
+    before_state JSONB,
+    after_state JSONB,
+    undo_of UUID REFERENCES autonomous_actions(id) ON DELETE SET NULL,
+    requested_by VARCHAR(255) NOT NULL,
+    decided_by VARCHAR(255),
+    decided_at TIMESTAMP,
+    decision_reason TEXT,
+    executed_by VARCHAR(255),
+    executed_at TIMESTAMP,
+    undone_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_autonomous_actions_org_created
+    ON autonomous_actions(organization_uuid, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_autonomous_actions_org_status
+    ON autonomous_actions(organization_uuid, status);
+
+CREATE TABLE IF NOT EXISTS autonomous_configs (
+    organization_uuid UUID PRIMARY KEY REFERENCES organizations(uuid) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT false,
+    auto_execute BOOLEAN NOT NULL DEFAULT false,
+    require_approval BOOLEAN NOT NULL DEFAULT true,
+    allowed_actions JSONB NOT NULL DEFAULT '["rollback", "scale", "restart"]',
+    notification_channels JSONB NOT NULL DEFAULT '[]',
+    updated_by VARCHAR(255),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON TABLE autonomous_actions IS 'Ações autônomas solicitadas, aprovadas e executadas por organização';
+COMMENT ON COLUMN autonomous_actions.status IS 'pending_approval, approved, executing, completed, failed, rejected, cancelled ou undone';
+COMMENT ON COLUMN autonomous_actions.before_state IS 'Estado do alvo antes da execução, usado para desfazer a ação';
+COMMENT ON COLUMN autonomous_actions.undo_of IS 'Ação original quando este registro desfaz outra ação';
+COMMENT ON TABLE autonomous_configs IS 'Configuração das ações autônomas por organização';
+
+-- Permissões para ações autônomas
+INSERT INTO permissions (resource, action, name, display_name, description, created_at)
+VALUES
+    ('autonomous_actions', 'view', 'autonomous_actions.view', 'Visualizar Ações Autônomas', 'View autonomous actions and their configuration', NOW()),
+    ('autonomous_actions', 'execute', 'autonomous_actions.execute', 'Solicitar Ações Autônomas', 'Request, cancel and undo autonomous actions', NOW()),
+    ('autonomous_actions', 'approve', 'autonomous_actions.approve', 'Aprovar Ações Autônomas', 'Approve or reject pending autonomous actions', NOW()),
+    ('autonomous_actions', 'manage', 'autonomous_actions.manage', 'Gerenciar Ações Autônomas', 'Update the autonomous actions configuration', NOW())
+ON CONFLICT (resource, action) DO UPDATE SET
+    name = EXCLUDED.name,
+    display_name = EXCLUDED.display_name,
+    description = EXCLUDED.description;
+
+-- Admin recebe todas as permissões; Platform Engineer pode solicitar e aprovar; Viewer e Developer apenas visualizar
+DO $$
+DECLARE
+    perm RECORD;
+BEGIN
+    FOR perm IN
+        SELECT r.id AS role_id, p.id AS permission_id
+        FROM roles r
+        JOIN permissions p ON p.resource = 'autonomous_actions'
+        WHERE r.name = 'admin'
+           OR (r.name = 'platform_engineer' AND p.action IN ('view', 'execute', 'approve'))
+           OR (r.name IN ('developer', 'viewer') AND p.action = 'view')
+    LOOP
+        INSERT INTO role_permissions (role_id, permission_id)
+        VALUES (perm.role_id, perm.permission_id)
+        ON CONFLICT (role_id, permission_id) DO NOTHING;
+    END LOOP;
+END $$;
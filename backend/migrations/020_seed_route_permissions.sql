@@ -0,0 +1,84 @@
+-- Migration: permissões das rotas da API
+-- Todas as rotas de /api/v1 exigem autenticação e uma permissão (resource, action) desta tabela
+
+INSERT INTO permissions (resource, action, name, display_name, description, created_at)
+VALUES
+    -- Organization permissions
+    ('organizations', 'view', 'organizations.view', 'Visualizar Organizações', 'View organizations and their members', NOW()),
+    ('organizations', 'create', 'organizations.create', 'Criar Organizações', 'Create new organizations', NOW()),
+    ('organizations', 'update', 'organizations.update', 'Atualizar Organizações', 'Update organization information', NOW()),
+    ('organizations', 'delete', 'organizations.delete', 'Deletar Organizações', 'Delete organizations', NOW()),
+    ('organizations', 'manage_members', 'organizations.manage_members', 'Gerenciar Membros da Organização', 'Add, update or remove organization members', NOW()),
+
+    -- Integration permissions
+    ('integrations', 'view', 'integrations.view', 'Visualizar Integrações', 'View integrations and request new ones', NOW()),
+    ('integrations', 'manage', 'integrations.manage', 'Gerenciar Integrações', 'Create, update, test or delete integrations', NOW()),
+
+    -- Secret permissions
+    ('secrets', 'view', 'secrets.view', 'Visualizar Secrets', 'List secrets and their metadata', NOW()),
+    ('secrets', 'read', 'secrets.read', 'Ler Secrets', 'Read secret values', NOW()),
+    ('secrets', 'manage', 'secrets.manage', 'Gerenciar Secrets', 'Create, update or delete secrets', NOW()),
+
+    -- VPN permissions
+    ('vpn', 'view', 'vpn.view', 'Visualizar Usuários VPN', 'View VPN users', NOW()),
+    ('vpn', 'manage', 'vpn.manage', 'Gerenciar Usuários VPN', 'Create, update or delete VPN users', NOW()),
+
+    -- Observability and FinOps permissions
+    ('observability', 'view', 'observability.view', 'Visualizar Observabilidade', 'View metrics, dashboards, logs and alerts', NOW()),
+    ('finops', 'view', 'finops.view', 'Visualizar Custos', 'View cloud costs and forecasts', NOW()),
+
+    -- Documentation permissions
+    ('docs', 'view', 'docs.view', 'Visualizar Documentação', 'View and chat about documentation', NOW()),
+    ('docs', 'update', 'docs.update', 'Editar Documentação', 'Create, edit and generate documentation', NOW()),
+    ('docs', 'delete', 'docs.delete', 'Deletar Documentação', 'Delete documentation', NOW()),
+
+    -- Notification permissions
+    ('notifications', 'send', 'notifications.send', 'Enviar Notificações', 'Send Slack and Teams messages', NOW()),
+
+    -- Deployment permissions
+    ('deployments', 'delete', 'deployments.delete', 'Deletar Deployments', 'Delete deployed applications', NOW())
+ON CONFLICT (resource, action) DO UPDATE SET
+    name = EXCLUDED.name,
+    display_name = EXCLUDED.display_name,
+    description = EXCLUDED.description;
+
+-- Admin recebe todas as permissões
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.name = 'admin'
+ON CONFLICT (role_id, permission_id) DO NOTHING;
+
+-- Platform Engineer e Viewer recebem todas as permissões de visualização
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON p.action = 'view'
+WHERE r.name IN ('platform_engineer', 'viewer')
+ON CONFLICT (role_id, permission_id) DO NOTHING;
+
+-- Platform Engineer
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON
+       (p.resource = 'integrations' AND p.action = 'manage')
+    OR (p.resource = 'secrets' AND p.action = 'read')
+    OR (p.resource = 'vpn' AND p.action = 'manage')
+    OR (p.resource = 'docs' AND p.action IN ('update', 'delete'))
+    OR (p.resource = 'notifications' AND p.action = 'send')
+    OR (p.resource = 'deployments' AND p.action = 'delete')
+WHERE r.name = 'platform_engineer'
+ON CONFLICT (role_id, permission_id) DO NOTHING;
+
+-- Developer
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON
+       (p.resource IN ('organizations', 'integrations', 'observability', 'docs') AND p.action = 'view')
+    OR (p.resource = 'docs' AND p.action = 'update')
+    OR (p.resource = 'notifications' AND p.action = 'send')
+WHERE r.name = 'developer'
+ON CONFLICT (role_id, permission_id) DO NOTHING;
@@ -0,0 +1,36 @@
+-- Migration: Organization roles
+-- Roles e vínculos de roles por organização: um usuário pode ser admin em uma organização e viewer em outra
+
+-- Roles com organization_uuid pertencem a uma organização; roles sem organização (sistema) valem em todas
+ALTER TABLE roles ADD COLUMN IF NOT EXISTS organization_uuid UUID REFERENCES organizations(uuid) ON DELETE CASCADE;
+
+ALTER TABLE roles DROP CONSTRAINT IF EXISTS roles_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_roles_global_name ON roles(name) WHERE organization_uuid IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_roles_organization_name ON roles(organization_uuid, name) WHERE organization_uuid IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS organization_role_bindings (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_uuid UUID NOT NULL REFERENCES organizations(uuid) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role_id UUID NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    created_by VARCHAR(255),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_organization_role_binding UNIQUE (organization_uuid, user_id, role_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_role_bindings_user
+    ON organization_role_bindings(user_id, organization_uuid);
+
+COMMENT ON COLUMN roles.organization_uuid IS 'Organização dona do role (NULL para roles globais)';
+COMMENT ON TABLE organization_role_bindings IS 'Roles atribuídos a usuários dentro de uma organização';
+
+-- Converter o papel livre de user_organizations em vínculos com os roles do sistema
+INSERT INTO organization_role_bindings (organization_uuid, user_id, role_id, created_by)
+SELECT uo.organization_uuid, uo.user_id, r.id, 'migration'
+FROM user_organizations uo
+JOIN roles r ON r.organization_uuid IS NULL AND r.name = CASE uo.role
+    WHEN 'owner' THEN 'admin'
+    WHEN 'member' THEN 'developer'
+    ELSE uo.role
+END
+ON CONFLICT (organization_uuid, user_id, role_id) DO NOTHING;
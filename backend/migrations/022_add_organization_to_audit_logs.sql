@@ -0,0 +1,9 @@
+-- Migration: Audit logs por organização
+-- Registra a organização de cada ação auditada (sem FK: o histórico deve sobreviver à exclusão da organização)
+
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS organization_uuid UUID;
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_organization
+    ON audit_logs(organization_uuid, created_at DESC) WHERE organization_uuid IS NOT NULL;
+
+COMMENT ON COLUMN audit_logs.organization_uuid IS 'Organização em que a ação foi executada (NULL para ações globais)';
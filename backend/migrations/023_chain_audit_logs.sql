@@ -0,0 +1,38 @@
+-- Migration: Audit log encadeado (tamper-evident)
+-- Cada registro guarda o hash do registro anterior da mesma organização (cadeia global para organization_uuid NULL)
+
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS sequence BIGINT;
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS prev_hash VARCHAR(64);
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS hash VARCHAR(64);
+
+-- Registros anteriores a esta migration ficam fora da cadeia (sequence NULL)
+CREATE UNIQUE INDEX IF NOT EXISTS idx_audit_logs_chain
+    ON audit_logs(COALESCE(organization_uuid, '00000000-0000-0000-0000-000000000000'::uuid), sequence)
+    WHERE sequence IS NOT NULL;
+
+-- Último elo de cada cadeia; a linha é bloqueada durante a inserção para serializar os registros
+CREATE TABLE IF NOT EXISTS audit_log_chain_heads (
+    chain_key VARCHAR(64) PRIMARY KEY,
+    sequence BIGINT NOT NULL DEFAULT 0,
+    hash VARCHAR(64) NOT NULL DEFAULT '',
+    -- Último registro removido pela retenção: a verificação recomeça a partir dele
+    anchor_sequence BIGINT NOT NULL DEFAULT 0,
+    anchor_hash VARCHAR(64) NOT NULL DEFAULT '',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON COLUMN audit_logs.sequence IS 'Posição do registro na cadeia da organização (NULL para registros legados)';
+COMMENT ON COLUMN audit_logs.prev_hash IS 'Hash do registro anterior na cadeia';
+COMMENT ON COLUMN audit_logs.hash IS 'SHA-256 do registro encadeado com prev_hash';
+COMMENT ON TABLE audit_log_chain_heads IS 'Último registro de cada cadeia de auditoria (chain_key = organization_uuid ou global)';
+
+-- Permissão para exportar logs de auditoria
+INSERT INTO permissions (resource, action, name, display_name, description, created_at) VALUES
+    ('audit', 'export', 'audit.export', 'Exportar Auditoria', 'Export audit logs with a signed manifest', NOW())
+ON CONFLICT (resource, action) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name = 'admin' AND r.organization_uuid IS NULL AND p.name = 'audit.export'
+ON CONFLICT (role_id, permission_id) DO NOTHING;
@@ -0,0 +1,16 @@
+-- Migration: AI routing policies
+-- Provedor e modelo preferidos por funcionalidade, ordem de fallback e tentativas do gateway de IA, por organização
+
+CREATE TABLE IF NOT EXISTS ai_routing_policies (
+    organization_uuid UUID PRIMARY KEY REFERENCES organizations(uuid) ON DELETE CASCADE,
+    routes JSONB NOT NULL DEFAULT '{}',
+    fallback_order JSONB NOT NULL DEFAULT '[]',
+    max_retries INTEGER NOT NULL DEFAULT 2,
+    updated_by VARCHAR(255),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON TABLE ai_routing_policies IS 'Política de roteamento do gateway de IA por organização';
+COMMENT ON COLUMN ai_routing_policies.routes IS 'Provedor e modelo por funcionalidade (techdocs, chat, diagram, autodocs, troubleshooting, recommendations ou default)';
+COMMENT ON COLUMN ai_routing_policies.fallback_order IS 'Provedores tentados, em ordem, quando o preferido falha';
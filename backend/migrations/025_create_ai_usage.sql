@@ -0,0 +1,43 @@
+-- Migration: AI usage metering and budgets
+-- Consumo de tokens e custo estimado de cada chamada do gateway de IA, e orçamento mensal por organização
+
+CREATE TABLE IF NOT EXISTS ai_usage_records (
+    id BIGSERIAL PRIMARY KEY,
+    organization_uuid UUID NOT NULL REFERENCES organizations(uuid) ON DELETE CASCADE,
+    user_id VARCHAR(255),
+    feature VARCHAR(50) NOT NULL,
+    provider VARCHAR(50) NOT NULL,
+    model VARCHAR(100) NOT NULL,
+    input_tokens INTEGER NOT NULL DEFAULT 0,
+    output_tokens INTEGER NOT NULL DEFAULT 0,
+    total_tokens INTEGER NOT NULL DEFAULT 0,
+    tokens_estimated BOOLEAN NOT NULL DEFAULT FALSE,
+    estimated_cost_usd NUMERIC(14, 6) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_ai_usage_records_org_created
+    ON ai_usage_records(organization_uuid, created_at);
+
+COMMENT ON TABLE ai_usage_records IS 'Consumo de cada chamada do gateway de IA';
+COMMENT ON COLUMN ai_usage_records.user_id IS 'Usuário que fez a chamada (NULL para jobs em background)';
+COMMENT ON COLUMN ai_usage_records.feature IS 'Funcionalidade que chamou o gateway (techdocs, chat, diagram, autodocs, troubleshooting, recommendations)';
+COMMENT ON COLUMN ai_usage_records.tokens_estimated IS 'Tokens estimados pelo tamanho do texto, quando o provedor não informa o consumo';
+COMMENT ON COLUMN ai_usage_records.estimated_cost_usd IS 'Custo estimado em dólares pela tabela de preços do modelo';
+
+CREATE TABLE IF NOT EXISTS ai_budgets (
+    organization_uuid UUID PRIMARY KEY REFERENCES organizations(uuid) ON DELETE CASCADE,
+    monthly_limit_usd NUMERIC(12, 2) NOT NULL DEFAULT 0,
+    warning_percent INTEGER NOT NULL DEFAULT 80,
+    warning_notified_month DATE,
+    exceeded_notified_month DATE,
+    updated_by VARCHAR(255),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON TABLE ai_budgets IS 'Orçamento mensal de IA por organização';
+COMMENT ON COLUMN ai_budgets.monthly_limit_usd IS 'Limite mensal em dólares (0 = sem limite)';
+COMMENT ON COLUMN ai_budgets.warning_percent IS 'Percentual do limite que dispara o aviso no Slack/Teams';
+COMMENT ON COLUMN ai_budgets.warning_notified_month IS 'Mês em que o aviso de percentual já foi enviado';
+COMMENT ON COLUMN ai_budgets.exceeded_notified_month IS 'Mês em que o aviso de limite excedido já foi enviado';
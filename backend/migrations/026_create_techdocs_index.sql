@@ -0,0 +1,35 @@
+-- Migration: TechDocs retrieval index
+-- Trechos da documentação com embeddings, por organização, para o chat com recuperação (RAG).
+-- Os vetores ficam em REAL[] e a similaridade é calculada na aplicação, sem depender do pgvector.
+
+CREATE TABLE IF NOT EXISTS techdocs_index_documents (
+    organization_uuid UUID NOT NULL REFERENCES organizations(uuid) ON DELETE CASCADE,
+    doc_path VARCHAR(1024) NOT NULL,
+    content_hash VARCHAR(64) NOT NULL,
+    embedding_model VARCHAR(100) NOT NULL,
+    chunks INTEGER NOT NULL DEFAULT 0,
+    indexed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (organization_uuid, doc_path)
+);
+
+CREATE TABLE IF NOT EXISTS techdocs_index_chunks (
+    organization_uuid UUID NOT NULL,
+    doc_path VARCHAR(1024) NOT NULL,
+    chunk_index INTEGER NOT NULL,
+    heading TEXT,
+    content TEXT NOT NULL,
+    embedding_model VARCHAR(100) NOT NULL,
+    embedding REAL[] NOT NULL,
+    PRIMARY KEY (organization_uuid, doc_path, chunk_index),
+    FOREIGN KEY (organization_uuid, doc_path)
+        REFERENCES techdocs_index_documents(organization_uuid, doc_path) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_techdocs_index_chunks_model
+    ON techdocs_index_chunks(organization_uuid, embedding_model);
+
+COMMENT ON TABLE techdocs_index_documents IS 'Documentos do TechDocs indexados para recuperação';
+COMMENT ON COLUMN techdocs_index_documents.content_hash IS 'SHA-256 do conteúdo indexado, para reindexar apenas o que mudou';
+COMMENT ON COLUMN techdocs_index_documents.embedding_model IS 'Modelo que gerou os embeddings (vetores de modelos diferentes não são comparáveis)';
+COMMENT ON TABLE techdocs_index_chunks IS 'Trechos dos documentos do TechDocs com seus embeddings';
+COMMENT ON COLUMN techdocs_index_chunks.heading IS 'Título da seção de origem do trecho';
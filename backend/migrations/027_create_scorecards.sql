@@ -0,0 +1,34 @@
+-- Migration: Scorecards
+-- Checks de maturidade definidos por organização e resultados diários por serviço
+
+CREATE TABLE IF NOT EXISTS scorecard_definitions (
+    organization_uuid UUID PRIMARY KEY REFERENCES organizations(uuid) ON DELETE CASCADE,
+    checks JSONB NOT NULL DEFAULT '[]',
+    updated_by VARCHAR(255),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON TABLE scorecard_definitions IS 'Checks de scorecard de maturidade por organização';
+COMMENT ON COLUMN scorecard_definitions.checks IS 'Lista de checks (id, nome, categoria, tipo, peso e parâmetros do tipo)';
+
+CREATE TABLE IF NOT EXISTS scorecard_results (
+    id SERIAL PRIMARY KEY,
+    organization_uuid UUID NOT NULL REFERENCES organizations(uuid) ON DELETE CASCADE,
+    service_name VARCHAR(255) NOT NULL,
+    squad VARCHAR(255) NOT NULL DEFAULT '',
+    result_date DATE NOT NULL,
+    category_scores JSONB NOT NULL DEFAULT '{}',
+    overall_score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    checks JSONB NOT NULL DEFAULT '[]',
+    evaluated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (organization_uuid, service_name, result_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_scorecard_results_squad
+    ON scorecard_results(organization_uuid, squad, result_date);
+
+COMMENT ON TABLE scorecard_results IS 'Avaliação diária dos checks de scorecard por serviço (última do dia)';
+COMMENT ON COLUMN scorecard_results.squad IS 'Squad do serviço no momento da avaliação';
+COMMENT ON COLUMN scorecard_results.category_scores IS 'Score de 0 a 10 por categoria com checks avaliados';
+COMMENT ON COLUMN scorecard_results.checks IS 'Resultado de cada check (passed, failed ou error)';
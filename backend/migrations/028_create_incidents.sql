@@ -0,0 +1,83 @@
+-- Migration: Incidents
+-- Incidentes por organização, ligados aos serviços do catálogo, com linha do tempo
+
+CREATE TABLE IF NOT EXISTS incidents (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_uuid UUID NOT NULL REFERENCES organizations(uuid) ON DELETE CASCADE,
+    title VARCHAR(500) NOT NULL,
+    description TEXT,
+    service_name VARCHAR(255),
+    squad VARCHAR(255),
+    severity VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    source VARCHAR(30) NOT NULL DEFAULT 'manual',
+    external_id VARCHAR(255),
+    opened_by VARCHAR(255) NOT NULL,
+    opened_at TIMESTAMP NOT NULL,
+    acknowledged_by VARCHAR(255),
+    acknowledged_at TIMESTAMP,
+    resolved_by VARCHAR(255),
+    resolved_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_incidents_org_opened
+    ON incidents(organization_uuid, opened_at DESC);
+CREATE INDEX IF NOT EXISTS idx_incidents_org_resolved
+    ON incidents(organization_uuid, resolved_at);
+CREATE INDEX IF NOT EXISTS idx_incidents_org_status
+    ON incidents(organization_uuid, status);
+
+-- Um único incidente não resolvido por alerta de origem
+CREATE UNIQUE INDEX IF NOT EXISTS idx_incidents_unresolved_external
+    ON incidents(organization_uuid, source, external_id)
+    WHERE external_id IS NOT NULL AND status <> 'resolved';
+
+CREATE TABLE IF NOT EXISTS incident_timeline (
+    id SERIAL PRIMARY KEY,
+    incident_id UUID NOT NULL REFERENCES incidents(id) ON DELETE CASCADE,
+    type VARCHAR(30) NOT NULL,
+    message TEXT NOT NULL,
+    author VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_incident_timeline_incident
+    ON incident_timeline(incident_id, created_at);
+
+COMMENT ON TABLE incidents IS 'Incidentes de produção por organização';
+COMMENT ON COLUMN incidents.status IS 'open, acknowledged ou resolved';
+COMMENT ON COLUMN incidents.severity IS 'critical, high, medium ou low';
+COMMENT ON COLUMN incidents.source IS 'Origem do incidente: manual, slack, prometheus ou grafana';
+COMMENT ON COLUMN incidents.external_id IS 'Fingerprint do alerta que abriu o incidente, para deduplicação';
+COMMENT ON COLUMN incidents.squad IS 'Squad do serviço no momento da abertura';
+COMMENT ON TABLE incident_timeline IS 'Linha do tempo dos incidentes: mudanças de status, notas e alertas';
+
+-- Permissões para incidentes
+INSERT INTO permissions (resource, action, name, display_name, description, created_at)
+VALUES
+    ('incidents', 'view', 'incidents.view', 'Visualizar Incidentes', 'View incidents, their timeline and metrics', NOW()),
+    ('incidents', 'manage', 'incidents.manage', 'Gerenciar Incidentes', 'Open, update, acknowledge and resolve incidents', NOW())
+ON CONFLICT (resource, action) DO UPDATE SET
+    name = EXCLUDED.name,
+    display_name = EXCLUDED.display_name,
+    description = EXCLUDED.description;
+
+-- Admin, Platform Engineer e Developer gerenciam incidentes; Viewer apenas visualiza
+DO $$
+DECLARE
+    perm RECORD;
+BEGIN
+    FOR perm IN
+        SELECT r.id AS role_id, p.id AS permission_id
+        FROM roles r
+        JOIN permissions p ON p.resource = 'incidents'
+        WHERE r.name IN ('admin', 'platform_engineer', 'developer')
+           OR (r.name = 'viewer' AND p.action = 'view')
+    LOOP
+        INSERT INTO role_permissions (role_id, permission_id)
+        VALUES (perm.role_id, perm.permission_id)
+        ON CONFLICT (role_id, permission_id) DO NOTHING;
+    END LOOP;
+END $$;
@@ -0,0 +1,72 @@
+-- Migration: Alert webhooks
+-- Alertas recebidos do Alertmanager e do Grafana por webhook, token dos webhooks e regras de notificação
+
+CREATE TABLE IF NOT EXISTS alert_webhook_tokens (
+    organization_uuid UUID PRIMARY KEY REFERENCES organizations(uuid) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL,
+    token_prefix VARCHAR(16) NOT NULL,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON TABLE alert_webhook_tokens IS 'Token que autentica os webhooks de alertas de cada organização';
+COMMENT ON COLUMN alert_webhook_tokens.token_hash IS 'SHA-256 do token; o token só é exibido quando gerado';
+COMMENT ON COLUMN alert_webhook_tokens.token_prefix IS 'Início do token, para identificá-lo na interface';
+
+CREATE TABLE IF NOT EXISTS alerts (
+    id SERIAL PRIMARY KEY,
+    organization_uuid UUID NOT NULL REFERENCES organizations(uuid) ON DELETE CASCADE,
+    source VARCHAR(30) NOT NULL,
+    fingerprint VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    service_name VARCHAR(255),
+    squad VARCHAR(255),
+    severity VARCHAR(50),
+    summary TEXT,
+    description TEXT,
+    labels JSONB NOT NULL DEFAULT '{}',
+    annotations JSONB NOT NULL DEFAULT '{}',
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP,
+    generator_url TEXT,
+    received_count INTEGER NOT NULL DEFAULT 1,
+    last_received_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (organization_uuid, source, fingerprint, starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_alerts_org_starts
+    ON alerts(organization_uuid, starts_at DESC);
+CREATE INDEX IF NOT EXISTS idx_alerts_org_service
+    ON alerts(organization_uuid, service_name, starts_at DESC);
+
+COMMENT ON TABLE alerts IS 'Ocorrências de alertas recebidas por webhook, deduplicadas por fingerprint e início';
+COMMENT ON COLUMN alerts.source IS 'alertmanager ou grafana';
+COMMENT ON COLUMN alerts.status IS 'firing ou resolved';
+COMMENT ON COLUMN alerts.service_name IS 'Serviço do catálogo identificado pelos labels do alerta';
+COMMENT ON COLUMN alerts.received_count IS 'Quantas vezes a ocorrência foi entregue (o Alertmanager reenvia alertas ativos)';
+
+CREATE TABLE IF NOT EXISTS notification_rules (
+    id SERIAL PRIMARY KEY,
+    organization_uuid UUID NOT NULL REFERENCES organizations(uuid) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    matchers JSONB NOT NULL DEFAULT '{}',
+    services TEXT[] NOT NULL DEFAULT '{}',
+    squads TEXT[] NOT NULL DEFAULT '{}',
+    severities TEXT[] NOT NULL DEFAULT '{}',
+    channels TEXT[] NOT NULL DEFAULT '{}',
+    send_resolved BOOLEAN NOT NULL DEFAULT TRUE,
+    open_incident BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (organization_uuid, name)
+);
+
+COMMENT ON TABLE notification_rules IS 'Regras que enviam os alertas recebidos para o Slack/Teams da organização';
+COMMENT ON COLUMN notification_rules.matchers IS 'Labels que o alerta deve ter (igualdade exata)';
+COMMENT ON COLUMN notification_rules.channels IS 'Canais de envio: slack e/ou teams';
+COMMENT ON COLUMN notification_rules.open_incident IS 'Abre um incidente enquanto o alerta estiver disparando';
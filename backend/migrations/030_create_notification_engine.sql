@@ -0,0 +1,107 @@
+-- Migration: Notification engine
+-- Canais de notificação, regras por tipo de evento e fila de entregas com deduplicação e novas tentativas
+
+CREATE TABLE IF NOT EXISTS notification_channels (
+    id SERIAL PRIMARY KEY,
+    organization_uuid UUID NOT NULL REFERENCES organizations(uuid) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    config JSONB NOT NULL DEFAULT '{}',
+    title_template TEXT,
+    body_template TEXT,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (organization_uuid, name)
+);
+
+COMMENT ON TABLE notification_channels IS 'Destinos das notificações de cada organização';
+COMMENT ON COLUMN notification_channels.type IS 'slack, teams, email ou webhook';
+COMMENT ON COLUMN notification_channels.config IS 'URL do webhook (criptografada), destinatários e segredo de assinatura';
+COMMENT ON COLUMN notification_channels.title_template IS 'Template (text/template) do título; vazio usa o padrão do tipo';
+COMMENT ON COLUMN notification_channels.body_template IS 'Template (text/template) da mensagem; vazio usa o padrão do tipo';
+
+-- As regras passam a tratar qualquer tipo de evento, não só alertas
+ALTER TABLE notification_rules
+    ADD COLUMN IF NOT EXISTS event_types TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS channel_ids INTEGER[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS quiet_hours JSONB,
+    ADD COLUMN IF NOT EXISTS dedup_window_minutes INTEGER NOT NULL DEFAULT 0;
+
+-- As regras existentes só enviavam alertas (e as resoluções, se send_resolved)
+UPDATE notification_rules
+SET event_types = CASE
+    WHEN send_resolved THEN ARRAY['alert.firing', 'alert.resolved']
+    ELSE ARRAY['alert.firing']
+END;
+
+ALTER TABLE notification_rules DROP COLUMN IF EXISTS send_resolved;
+
+COMMENT ON TABLE notification_rules IS 'Regras que enviam os eventos da organização para seus canais';
+COMMENT ON COLUMN notification_rules.event_types IS 'Tipos de evento da regra; vazio aceita todos';
+COMMENT ON COLUMN notification_rules.matchers IS 'Labels que o evento deve ter (igualdade exata)';
+COMMENT ON COLUMN notification_rules.channels IS 'Integrações de envio: slack e/ou teams';
+COMMENT ON COLUMN notification_rules.channel_ids IS 'Canais de notificação da organização';
+COMMENT ON COLUMN notification_rules.quiet_hours IS 'Período diário em que as notificações aguardam (início, fim, fuso e severidades que não aguardam)';
+COMMENT ON COLUMN notification_rules.dedup_window_minutes IS 'Janela em que repetições do mesmo evento não são reenviadas';
+
+CREATE TABLE IF NOT EXISTS notification_deliveries (
+    id SERIAL PRIMARY KEY,
+    organization_uuid UUID NOT NULL REFERENCES organizations(uuid) ON DELETE CASCADE,
+    rule_id INTEGER REFERENCES notification_rules(id) ON DELETE SET NULL,
+    channel_id INTEGER REFERENCES notification_channels(id) ON DELETE CASCADE,
+    channel_type VARCHAR(20) NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    dedup_key VARCHAR(500) NOT NULL,
+    event JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT,
+    sent_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_due
+    ON notification_deliveries(next_attempt_at)
+    WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_dedup
+    ON notification_deliveries(organization_uuid, channel_type, dedup_key, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_org_created
+    ON notification_deliveries(organization_uuid, created_at DESC);
+
+COMMENT ON TABLE notification_deliveries IS 'Fila e histórico das entregas de notificações';
+COMMENT ON COLUMN notification_deliveries.channel_id IS 'Canal de notificação; vazio para as integrações Slack e Teams';
+COMMENT ON COLUMN notification_deliveries.dedup_key IS 'Identifica repetições do mesmo evento para deduplicação';
+COMMENT ON COLUMN notification_deliveries.status IS 'pending, sent ou failed';
+COMMENT ON COLUMN notification_deliveries.next_attempt_at IS 'Próxima tentativa: após falhas (backoff) ou ao fim do horário de silêncio';
+
+-- Permissões para notificações
+INSERT INTO permissions (resource, action, name, display_name, description, created_at)
+VALUES
+    ('notifications', 'view', 'notifications.view', 'Visualizar Notificações', 'View notification channels, rules and deliveries', NOW()),
+    ('notifications', 'manage', 'notifications.manage', 'Gerenciar Notificações', 'Manage notification channels and rules, and publish events', NOW())
+ON CONFLICT (resource, action) DO UPDATE SET
+    name = EXCLUDED.name,
+    display_name = EXCLUDED.display_name,
+    description = EXCLUDED.description;
+
+-- Admin e Platform Engineer gerenciam notificações; Developer e Viewer apenas visualizam
+DO $$
+DECLARE
+    perm RECORD;
+BEGIN
+    FOR perm IN
+        SELECT r.id AS role_id, p.id AS permission_id
+        FROM roles r
+        JOIN permissions p ON p.resource = 'notifications' AND p.action IN ('view', 'manage')
+        WHERE r.name IN ('admin', 'platform_engineer')
+           OR (r.name IN ('developer', 'viewer') AND p.action = 'view')
+    LOOP
+        INSERT INTO role_permissions (role_id, permission_id)
+        VALUES (perm.role_id, perm.permission_id)
+        ON CONFLICT (role_id, permission_id) DO NOTHING;
+    END LOOP;
+END $$;
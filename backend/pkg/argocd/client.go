@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/PlatifyX/platifyx-core/internal/domain"
@@ -198,10 +199,16 @@ func (c *Client) DeleteApplication(name string, cascade bool) error {
 }
 
 // RollbackApplication rolls back an application to a previous revision
+// The revision is the ID of an entry of the application history; ArgoCD expects it as "id"
 func (c *Client) RollbackApplication(name string, revision string) error {
 	rollbackReq := map[string]interface{}{
 		"revision": revision,
 	}
+	if id, err := strconv.ParseInt(revision, 10, 64); err == nil {
+		rollbackReq = map[string]interface{}{
+			"id": id,
+		}
+	}
 
 	_, err := c.doRequest("POST", fmt.Sprintf("applications/%s/rollback", name), rollbackReq)
 	if err != nil {
@@ -2,6 +2,7 @@ package claude
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -17,6 +18,7 @@ type Client struct {
 
 type MessageRequest struct {
 	Model     string    `json:"model"`
+	System    string    `json:"system,omitempty"`
 	Messages  []Message `json:"messages"`
 	MaxTokens int       `json:"max_tokens"`
 	Stream    bool      `json:"stream,omitempty"`
@@ -56,10 +58,10 @@ func NewClient(apiKey string) *Client {
 	}
 }
 
-func (c *Client) doRequest(method, path string, body io.Reader) (*http.Response, error) {
+func (c *Client) doRequest(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
 	url := fmt.Sprintf("%s%s", c.baseURL, path)
 
-	req, err := http.NewRequest(method, url, body)
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -89,26 +91,25 @@ func (c *Client) TestConnection() error {
 		},
 	}
 
-	_, err := c.CreateMessage(request)
+	_, err := c.CreateMessage(context.Background(), request)
 	return err
 }
 
-// CreateMessage creates a message using Claude
-func (c *Client) CreateMessage(request MessageRequest) (*MessageResponse, error) {
+// CreateMessage creates a message using Claude; cancelling ctx aborts the request
+func (c *Client) CreateMessage(ctx context.Context, request MessageRequest) (*MessageResponse, error) {
 	bodyBytes, err := json.Marshal(request)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	resp, err := c.doRequest("POST", "/messages", bytes.NewBuffer(bodyBytes))
+	resp, err := c.doRequest(ctx, "POST", "/messages", bytes.NewBuffer(bodyBytes))
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(resp)
 	}
 
 	var response MessageResponse
@@ -0,0 +1,43 @@
+package claude
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// APIError is returned when the API answers with an error status
+type APIError struct {
+	StatusCode int
+	Body       string
+	// RetryAfter is the delay asked by the Retry-After header (0 when absent)
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API request failed with status %d: %s", e.StatusCode, e.Body)
+}
+
+// Retryable tells whether the same request may succeed later: rate limited or server errors
+func (e *APIError) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
+// RetryDelay is the delay asked by the API before retrying (0 when it did not say)
+func (e *APIError) RetryDelay() time.Duration {
+	return e.RetryAfter
+}
+
+func newAPIError(resp *http.Response) *APIError {
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Body:       string(bodyBytes),
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		apiErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+	return apiErr
+}
@@ -0,0 +1,120 @@
+package claude
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/PlatifyX/platifyx-core/pkg/sse"
+)
+
+// StreamEvent is an event of a streamed message; only the fields used to rebuild the message are decoded
+type StreamEvent struct {
+	Type    string           `json:"type"`
+	Message *MessageResponse `json:"message,omitempty"`
+	Delta   struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"delta"`
+	Usage *Usage `json:"usage,omitempty"`
+	Error *struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// CreateMessageStream streams a message, handing each text delta to onDelta. The returned
+// response holds the whole text and the input/output token usage. Cancelling ctx (or onDelta
+// returning an error) aborts the request.
+func (c *Client) CreateMessageStream(ctx context.Context, request MessageRequest, onDelta func(delta string) error) (*MessageResponse, error) {
+	request.Stream = true
+
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/messages", bytes.NewBuffer(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("x-api-key", c.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("anthropic-version", "2023-06-01")
+	req.Header.Set("Accept", "text/event-stream")
+
+	// No client timeout: the stream lasts as long as the message, ctx bounds it
+	streamClient := &http.Client{Transport: c.httpClient.Transport}
+	resp, err := streamClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	message := &MessageResponse{Model: request.Model, Role: "assistant"}
+	var text strings.Builder
+
+	reader := sse.NewReader(resp.Body)
+	for {
+		event, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read stream: %w", err)
+		}
+
+		var streamEvent StreamEvent
+		if err := json.Unmarshal([]byte(event.Data), &streamEvent); err != nil {
+			return nil, fmt.Errorf("failed to decode event: %w", err)
+		}
+
+		switch streamEvent.Type {
+		case "message_start":
+			if streamEvent.Message != nil {
+				message.ID = streamEvent.Message.ID
+				message.Model = streamEvent.Message.Model
+				message.Usage = streamEvent.Message.Usage
+			}
+		case "content_block_delta":
+			if streamEvent.Delta.Type != "text_delta" || streamEvent.Delta.Text == "" {
+				continue
+			}
+			text.WriteString(streamEvent.Delta.Text)
+			if err := onDelta(streamEvent.Delta.Text); err != nil {
+				return nil, err
+			}
+		case "message_delta":
+			// The output count of message_delta is cumulative
+			if streamEvent.Usage != nil {
+				message.Usage.OutputTokens = streamEvent.Usage.OutputTokens
+			}
+		case "error":
+			if streamEvent.Error != nil {
+				// Overloaded mid-stream is the 529 of a regular request
+				if streamEvent.Error.Type == "overloaded_error" {
+					return nil, &APIError{StatusCode: 529, Body: streamEvent.Error.Message}
+				}
+				return nil, fmt.Errorf("stream error (%s): %s", streamEvent.Error.Type, streamEvent.Error.Message)
+			}
+			return nil, fmt.Errorf("stream error: %s", event.Data)
+		}
+
+		if streamEvent.Type == "message_stop" {
+			break
+		}
+	}
+
+	message.Content = []ContentBlock{{Type: "text", Text: text.String()}}
+	return message, nil
+}
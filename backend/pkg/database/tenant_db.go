@@ -0,0 +1,202 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+)
+
+// TenantPoolConfig sizes the connection pools opened for each organization node database
+type TenantPoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+type tenantPools struct {
+	writeAddress string
+	readAddress  string
+	write        *sql.DB
+	read         *sql.DB // same pool as write when there is no read replica
+}
+
+func (p *tenantPools) shared() bool {
+	return p.read == p.write
+}
+
+func (p *tenantPools) close() {
+	p.write.Close()
+	if !p.shared() {
+		p.read.Close()
+	}
+}
+
+// TenantDBManager keeps one connection pool per organization node database, and a second
+// one for the read replica when DatabaseAddressRead points somewhere else. Pools are opened
+// lazily and reopened when the organization addresses change.
+type TenantDBManager struct {
+	config TenantPoolConfig
+
+	mu     sync.Mutex
+	pools  map[string]*tenantPools
+	closed bool
+}
+
+func NewTenantDBManager(config TenantPoolConfig) *TenantDBManager {
+	return &TenantDBManager{
+		config: config,
+		pools:  make(map[string]*tenantPools),
+	}
+}
+
+// Writer returns the pool of the organization primary node database
+func (m *TenantDBManager) Writer(org *domain.Organization) (*sql.DB, error) {
+	pools, err := m.poolsFor(org)
+	if err != nil {
+		return nil, err
+	}
+	return pools.write, nil
+}
+
+// Reader returns the pool of the organization read replica, or the primary when no replica is configured
+func (m *TenantDBManager) Reader(org *domain.Organization) (*sql.DB, error) {
+	pools, err := m.poolsFor(org)
+	if err != nil {
+		return nil, err
+	}
+	return pools.read, nil
+}
+
+func (m *TenantDBManager) poolsFor(org *domain.Organization) (*tenantPools, error) {
+	writeAddress := org.DatabaseAddressWrite
+	readAddress := org.DatabaseAddressRead
+	if writeAddress == "" {
+		writeAddress = readAddress
+	}
+	if writeAddress == "" {
+		return nil, fmt.Errorf("no database address configured for organization")
+	}
+	if readAddress == "" {
+		readAddress = writeAddress
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return nil, fmt.Errorf("tenant database manager is closed")
+	}
+
+	if pools, ok := m.pools[org.UUID]; ok {
+		if pools.writeAddress == writeAddress && pools.readAddress == readAddress {
+			return pools, nil
+		}
+		delete(m.pools, org.UUID)
+		pools.close()
+	}
+
+	// sql.Open does not connect, so no network round trip happens under the lock
+	write, err := m.open(writeAddress)
+	if err != nil {
+		return nil, err
+	}
+	read := write
+	if readAddress != writeAddress {
+		if read, err = m.open(readAddress); err != nil {
+			write.Close()
+			return nil, err
+		}
+	}
+
+	pools := &tenantPools{
+		writeAddress: writeAddress,
+		readAddress:  readAddress,
+		write:        write,
+		read:         read,
+	}
+	m.pools[org.UUID] = pools
+	return pools, nil
+}
+
+func (m *TenantDBManager) open(address string) (*sql.DB, error) {
+	db, err := sql.Open("postgres", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	db.SetMaxOpenConns(m.config.MaxOpenConns)
+	db.SetMaxIdleConns(m.config.MaxIdleConns)
+	db.SetConnMaxLifetime(m.config.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(m.config.ConnMaxIdleTime)
+
+	return db, nil
+}
+
+// Invalidate closes the pools of an organization (e.g. after it is deleted)
+func (m *TenantDBManager) Invalidate(orgUUID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if pools, ok := m.pools[orgUUID]; ok {
+		delete(m.pools, orgUUID)
+		pools.close()
+	}
+}
+
+// Health pings the write and read pools of an organization and reports their usage
+func (m *TenantDBManager) Health(ctx context.Context, org *domain.Organization) (*domain.NodeDatabaseHealth, error) {
+	pools, err := m.poolsFor(org)
+	if err != nil {
+		return nil, err
+	}
+
+	health := &domain.NodeDatabaseHealth{
+		OrganizationUUID: org.UUID,
+		Write:            poolHealth(ctx, pools.write),
+		SharedReadWrite:  pools.shared(),
+	}
+	if pools.shared() {
+		health.Read = health.Write
+	} else {
+		health.Read = poolHealth(ctx, pools.read)
+	}
+	health.Healthy = health.Write.Healthy && health.Read.Healthy
+
+	return health, nil
+}
+
+func poolHealth(ctx context.Context, db *sql.DB) domain.NodeDatabasePoolHealth {
+	start := time.Now()
+	err := db.PingContext(ctx)
+
+	stats := db.Stats()
+	health := domain.NodeDatabasePoolHealth{
+		Healthy:         err == nil,
+		LatencyMs:       time.Since(start).Milliseconds(),
+		OpenConnections: stats.OpenConnections,
+		InUse:           stats.InUse,
+		Idle:            stats.Idle,
+		WaitCount:       stats.WaitCount,
+	}
+	if err != nil {
+		health.Error = err.Error()
+	}
+	return health
+}
+
+// Close closes every pool; later calls to Writer and Reader fail
+func (m *TenantDBManager) Close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for orgUUID, pools := range m.pools {
+		pools.close()
+		delete(m.pools, orgUUID)
+	}
+	m.closed = true
+}
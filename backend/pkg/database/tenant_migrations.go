@@ -0,0 +1,195 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// TenantMigration is a migration of the per-organization track. It runs with the search_path
+// set to the organization schema, so its statements must not qualify table names.
+type TenantMigration struct {
+	Version string
+	SQL     string
+}
+
+// LoadTenantMigrations reads the *.sql files of the tenant track, ordered by version (file name)
+func LoadTenantMigrations(migrationsPath string) ([]TenantMigration, error) {
+	files, err := filepath.Glob(filepath.Join(migrationsPath, "*.sql"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tenant migrations: %w", err)
+	}
+	sort.Strings(files)
+
+	migrations := make([]TenantMigration, 0, len(files))
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tenant migration file %s: %w", file, err)
+		}
+		migrations = append(migrations, TenantMigration{Version: filepath.Base(file), SQL: string(content)})
+	}
+
+	return migrations, nil
+}
+
+// AppliedTenantMigrations returns the versions recorded in the schema_migrations table of an
+// organization schema. A schema that does not exist yet has no applied migrations.
+func AppliedTenantMigrations(db *sql.DB, orgUUID string) (map[string]bool, error) {
+	var exists bool
+	err := db.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1 FROM information_schema.tables
+			WHERE table_schema = $1 AND table_name = 'schema_migrations'
+		)
+	`, SchemaName(orgUUID)).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check tenant migration table: %w", err)
+	}
+
+	applied := make(map[string]bool)
+	if !exists {
+		return applied, nil
+	}
+
+	rows, err := db.Query(fmt.Sprintf("SELECT version FROM %s", OrganizationTable(orgUUID, "schema_migrations")))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tenant migrations: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var version string
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+
+	return applied, rows.Err()
+}
+
+// TenantMigrationError reports the migration that failed in an organization schema
+type TenantMigrationError struct {
+	Version string
+	Err     error
+}
+
+func (e *TenantMigrationError) Error() string {
+	return fmt.Sprintf("failed to execute tenant migration %s: %v", e.Version, e.Err)
+}
+
+func (e *TenantMigrationError) Unwrap() error {
+	return e.Err
+}
+
+// MigrateTenantSchema creates the organization schema if needed and applies the pending
+// migrations in order, each one in its own transaction, stopping at the first failure.
+// With dryRun every pending migration runs in a single transaction that is rolled back.
+// It returns the versions that were executed; a failure is a *TenantMigrationError.
+func MigrateTenantSchema(db *sql.DB, orgUUID string, migrations []TenantMigration, dryRun bool) ([]string, error) {
+	if dryRun {
+		return migrateTenantSchemaDryRun(db, orgUUID, migrations)
+	}
+
+	var executed []string
+	for _, migration := range migrations {
+		tx, err := beginTenantTx(db, orgUUID)
+		if err != nil {
+			return executed, err
+		}
+
+		ran, err := applyTenantMigration(tx, migration)
+		if err != nil {
+			tx.Rollback()
+			return executed, &TenantMigrationError{Version: migration.Version, Err: err}
+		}
+		if err := tx.Commit(); err != nil {
+			return executed, &TenantMigrationError{Version: migration.Version, Err: err}
+		}
+		if ran {
+			executed = append(executed, migration.Version)
+		}
+	}
+
+	return executed, nil
+}
+
+func migrateTenantSchemaDryRun(db *sql.DB, orgUUID string, migrations []TenantMigration) ([]string, error) {
+	tx, err := beginTenantTx(db, orgUUID)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var executed []string
+	for _, migration := range migrations {
+		ran, err := applyTenantMigration(tx, migration)
+		if err != nil {
+			return executed, &TenantMigrationError{Version: migration.Version, Err: err}
+		}
+		if ran {
+			executed = append(executed, migration.Version)
+		}
+	}
+
+	return executed, nil
+}
+
+// beginTenantTx opens a transaction scoped to the organization schema. The advisory lock
+// serializes concurrent runs (e.g. several API replicas starting) on the same schema, and
+// SET LOCAL keeps the search_path from leaking to other users of the pooled connection.
+func beginTenantTx(db *sql.DB, orgUUID string) (*sql.Tx, error) {
+	schema := EscapeSchemaName(SchemaName(orgUUID))
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	if _, err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext($1))", "tenant_migrations:"+SchemaName(orgUUID)); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to lock tenant schema: %w", err)
+	}
+
+	statements := []string{
+		fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", schema),
+		fmt.Sprintf("SET LOCAL search_path TO %s, public", schema),
+		`CREATE TABLE IF NOT EXISTS schema_migrations (
+			version VARCHAR(255) PRIMARY KEY,
+			applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+	}
+	for _, statement := range statements {
+		if _, err := tx.Exec(statement); err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to prepare tenant schema: %w", err)
+		}
+	}
+
+	return tx, nil
+}
+
+// applyTenantMigration runs a migration unless it is already recorded, checking again under
+// the advisory lock
+func applyTenantMigration(tx *sql.Tx, migration TenantMigration) (bool, error) {
+	var count int
+	if err := tx.QueryRow("SELECT COUNT(*) FROM schema_migrations WHERE version = $1", migration.Version).Scan(&count); err != nil {
+		return false, fmt.Errorf("failed to check migration status: %w", err)
+	}
+	if count > 0 {
+		return false, nil
+	}
+
+	if _, err := tx.Exec(migration.SQL); err != nil {
+		return false, err
+	}
+
+	if _, err := tx.Exec("INSERT INTO schema_migrations (version) VALUES ($1)", migration.Version); err != nil {
+		return false, fmt.Errorf("failed to record migration: %w", err)
+	}
+
+	return true, nil
+}
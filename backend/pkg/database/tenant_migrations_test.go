@@ -0,0 +1,258 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeTenantDB is a database/sql driver that understands the statements of the tenant
+// migration runner. Versions inserted into schema_migrations are kept only when their
+// transaction commits; every other statement is recorded in the order it ran.
+type fakeTenantDB struct {
+	mu         sync.Mutex
+	applied    map[string]bool
+	statements []string
+	fail       string
+}
+
+func newFakeTenantDB(applied ...string) *fakeTenantDB {
+	db := &fakeTenantDB{applied: map[string]bool{}}
+	for _, version := range applied {
+		db.applied[version] = true
+	}
+	return db
+}
+
+func (db *fakeTenantDB) Connect(context.Context) (driver.Conn, error) {
+	return &fakeTenantConn{db: db}, nil
+}
+
+func (db *fakeTenantDB) Driver() driver.Driver {
+	return nil
+}
+
+type fakeTenantConn struct {
+	db       *fakeTenantDB
+	recorded []string
+}
+
+func (c *fakeTenantConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeTenantStmt{conn: c, query: strings.TrimSpace(query)}, nil
+}
+
+func (c *fakeTenantConn) Close() error {
+	return nil
+}
+
+func (c *fakeTenantConn) Begin() (driver.Tx, error) {
+	c.recorded = nil
+	return c, nil
+}
+
+func (c *fakeTenantConn) Commit() error {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	for _, version := range c.recorded {
+		c.db.applied[version] = true
+	}
+	c.recorded = nil
+	return nil
+}
+
+func (c *fakeTenantConn) Rollback() error {
+	c.recorded = nil
+	return nil
+}
+
+type fakeTenantStmt struct {
+	conn  *fakeTenantConn
+	query string
+}
+
+func (s *fakeTenantStmt) Close() error {
+	return nil
+}
+
+func (s *fakeTenantStmt) NumInput() int {
+	return -1
+}
+
+func (s *fakeTenantStmt) Exec(args []driver.Value) (driver.Result, error) {
+	db := s.conn.db
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	switch {
+	case strings.HasPrefix(s.query, "INSERT INTO schema_migrations"):
+		s.conn.recorded = append(s.conn.recorded, args[0].(string))
+	case strings.HasPrefix(s.query, "SELECT pg_advisory_xact_lock"),
+		strings.HasPrefix(s.query, "CREATE SCHEMA IF NOT EXISTS"),
+		strings.HasPrefix(s.query, "SET LOCAL search_path"),
+		strings.HasPrefix(s.query, "CREATE TABLE IF NOT EXISTS schema_migrations"):
+	case s.query == db.fail:
+		return nil, errors.New("syntax error")
+	default:
+		db.statements = append(db.statements, s.query)
+	}
+	return driver.RowsAffected(0), nil
+}
+
+func (s *fakeTenantStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if !strings.HasPrefix(s.query, "SELECT COUNT(*) FROM schema_migrations") {
+		return nil, errors.New("unexpected query: " + s.query)
+	}
+
+	s.conn.db.mu.Lock()
+	defer s.conn.db.mu.Unlock()
+	count := int64(0)
+	if s.conn.db.applied[args[0].(string)] {
+		count = 1
+	}
+	return &fakeTenantRows{values: []driver.Value{count}}, nil
+}
+
+type fakeTenantRows struct {
+	values []driver.Value
+	read   bool
+}
+
+func (r *fakeTenantRows) Columns() []string {
+	return []string{"count"}
+}
+
+func (r *fakeTenantRows) Close() error {
+	return nil
+}
+
+func (r *fakeTenantRows) Next(dest []driver.Value) error {
+	if r.read {
+		return io.EOF
+	}
+	r.read = true
+	copy(dest, r.values)
+	return nil
+}
+
+func TestLoadTenantMigrationsOrdersByVersion(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"010_add_indexes.sql", "002_add_owner.sql", "001_init.sql", "README.md"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("-- "+name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	migrations, err := LoadTenantMigrations(dir)
+	if err != nil {
+		t.Fatalf("LoadTenantMigrations: %v", err)
+	}
+
+	var versions []string
+	for _, migration := range migrations {
+		versions = append(versions, migration.Version)
+		if migration.SQL != "-- "+migration.Version {
+			t.Errorf("migration %s has SQL %q", migration.Version, migration.SQL)
+		}
+	}
+	if want := []string{"001_init.sql", "002_add_owner.sql", "010_add_indexes.sql"}; !reflect.DeepEqual(versions, want) {
+		t.Errorf("versions are %v, want %v", versions, want)
+	}
+}
+
+func TestMigrateTenantSchema(t *testing.T) {
+	migrations := []TenantMigration{
+		{Version: "001_init.sql", SQL: "CREATE TABLE services (id SERIAL)"},
+		{Version: "002_add_owner.sql", SQL: "ALTER TABLE services ADD owner TEXT"},
+		{Version: "003_add_index.sql", SQL: "CREATE INDEX services_owner ON services (owner)"},
+	}
+
+	tests := []struct {
+		name           string
+		applied        []string
+		fail           string
+		dryRun         bool
+		wantExecuted   []string
+		wantStatements []string
+		wantApplied    []string
+		wantFailed     string
+	}{
+		{
+			name:           "new schema runs every migration in order",
+			wantExecuted:   []string{"001_init.sql", "002_add_owner.sql", "003_add_index.sql"},
+			wantStatements: []string{migrations[0].SQL, migrations[1].SQL, migrations[2].SQL},
+			wantApplied:    []string{"001_init.sql", "002_add_owner.sql", "003_add_index.sql"},
+		},
+		{
+			name:           "applied migrations are skipped",
+			applied:        []string{"001_init.sql", "003_add_index.sql"},
+			wantExecuted:   []string{"002_add_owner.sql"},
+			wantStatements: []string{migrations[1].SQL},
+			wantApplied:    []string{"001_init.sql", "002_add_owner.sql", "003_add_index.sql"},
+		},
+		{
+			name:        "up to date",
+			applied:     []string{"001_init.sql", "002_add_owner.sql", "003_add_index.sql"},
+			wantApplied: []string{"001_init.sql", "002_add_owner.sql", "003_add_index.sql"},
+		},
+		{
+			name:           "stops at the first failure",
+			fail:           migrations[1].SQL,
+			wantExecuted:   []string{"001_init.sql"},
+			wantStatements: []string{migrations[0].SQL},
+			wantApplied:    []string{"001_init.sql"},
+			wantFailed:     "002_add_owner.sql",
+		},
+		{
+			name:           "dry run records nothing",
+			applied:        []string{"001_init.sql"},
+			dryRun:         true,
+			wantExecuted:   []string{"002_add_owner.sql", "003_add_index.sql"},
+			wantStatements: []string{migrations[1].SQL, migrations[2].SQL},
+			wantApplied:    []string{"001_init.sql"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeTenantDB(tt.applied...)
+			fake.fail = tt.fail
+			db := sql.OpenDB(fake)
+			defer db.Close()
+
+			executed, err := MigrateTenantSchema(db, "5f0c6f4e-1c2d-4f59-9b35-3f6c8a1d2e7b", migrations, tt.dryRun)
+			if tt.wantFailed != "" {
+				var migrationErr *TenantMigrationError
+				if !errors.As(err, &migrationErr) || migrationErr.Version != tt.wantFailed {
+					t.Errorf("got error %v, want a failure of %s", err, tt.wantFailed)
+				}
+			} else if err != nil {
+				t.Fatalf("MigrateTenantSchema: %v", err)
+			}
+
+			if !reflect.DeepEqual(executed, tt.wantExecuted) {
+				t.Errorf("executed %v, want %v", executed, tt.wantExecuted)
+			}
+			if !reflect.DeepEqual(fake.statements, tt.wantStatements) {
+				t.Errorf("ran %q, want %q", fake.statements, tt.wantStatements)
+			}
+
+			var applied []string
+			for _, migration := range migrations {
+				if fake.applied[migration.Version] {
+					applied = append(applied, migration.Version)
+				}
+			}
+			if !reflect.DeepEqual(applied, tt.wantApplied) {
+				t.Errorf("applied versions are %v, want %v", applied, tt.wantApplied)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DataKeySize is the size in bytes of generated data keys (AES-256)
+const DataKeySize = 32
+
+// KeyRing holds the master keys used to wrap per-organization data keys.
+// Only the active key wraps new data keys; the others are kept so data keys
+// wrapped before a master key rotation can still be unwrapped.
+type KeyRing struct {
+	keys     map[string][]byte
+	activeID string
+}
+
+// NewKeyRing parses a comma separated list of "id:base64key" pairs.
+// When activeID is empty the first key in the list becomes the active one.
+func NewKeyRing(spec string, activeID string) (*KeyRing, error) {
+	ring := &KeyRing{keys: make(map[string][]byte)}
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.SplitN(entry, ":", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid master key entry, expected id:base64key")
+		}
+
+		key, err := base64.StdEncoding.DecodeString(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode master key %s: %w", parts[0], err)
+		}
+		if len(key) != DataKeySize {
+			return nil, fmt.Errorf("master key %s must be %d bytes, got %d", parts[0], DataKeySize, len(key))
+		}
+
+		ring.keys[parts[0]] = key
+		if ring.activeID == "" {
+			ring.activeID = parts[0]
+		}
+	}
+
+	if len(ring.keys) == 0 {
+		return nil, fmt.Errorf("no master keys configured")
+	}
+
+	if activeID != "" {
+		if _, ok := ring.keys[activeID]; !ok {
+			return nil, fmt.Errorf("active master key %s not found in key ring", activeID)
+		}
+		ring.activeID = activeID
+	}
+
+	return ring, nil
+}
+
+// ActiveKeyID returns the id of the master key used to wrap new data keys
+func (k *KeyRing) ActiveKeyID() string {
+	return k.activeID
+}
+
+// GenerateDataKey returns a new random data key
+func GenerateDataKey() ([]byte, error) {
+	key := make([]byte, DataKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+	return key, nil
+}
+
+// WrapKey encrypts a data key with the active master key
+func (k *KeyRing) WrapKey(dataKey []byte) ([]byte, string, error) {
+	wrapped, err := Encrypt(k.keys[k.activeID], dataKey)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to wrap data key: %w", err)
+	}
+	return wrapped, k.activeID, nil
+}
+
+// UnwrapKey decrypts a data key that was wrapped with the given master key
+func (k *KeyRing) UnwrapKey(wrapped []byte, masterKeyID string) ([]byte, error) {
+	masterKey, ok := k.keys[masterKeyID]
+	if !ok {
+		return nil, fmt.Errorf("master key %s not found in key ring", masterKeyID)
+	}
+
+	dataKey, err := Decrypt(masterKey, wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	return dataKey, nil
+}
+
+// Encrypt seals plaintext with AES-GCM. The random nonce is prepended to the ciphertext.
+func Encrypt(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// Decrypt opens a ciphertext produced by Encrypt
+func Decrypt(key, ciphertext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+
+	nonce, sealed := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+	return gcm, nil
+}
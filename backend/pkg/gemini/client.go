@@ -2,6 +2,7 @@ package gemini
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -40,7 +41,14 @@ type Part struct {
 }
 
 type GenerateContentResponse struct {
-	Candidates []Candidate `json:"candidates"`
+	Candidates    []Candidate    `json:"candidates"`
+	UsageMetadata *UsageMetadata `json:"usageMetadata,omitempty"`
+}
+
+type UsageMetadata struct {
+	PromptTokenCount     int `json:"promptTokenCount"`
+	CandidatesTokenCount int `json:"candidatesTokenCount"`
+	TotalTokenCount      int `json:"totalTokenCount"`
 }
 
 type Candidate struct {
@@ -60,10 +68,10 @@ func NewClient(apiKey string) *Client {
 	}
 }
 
-func (c *Client) doRequest(method, path string, body io.Reader) (*http.Response, error) {
+func (c *Client) doRequest(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
 	url := fmt.Sprintf("%s%s?key=%s", c.baseURL, path, c.apiKey)
 
-	req, err := http.NewRequest(method, url, body)
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -80,15 +88,14 @@ func (c *Client) doRequest(method, path string, body io.Reader) (*http.Response,
 
 // ListModels retrieves the list of available models
 func (c *Client) ListModels() (*ModelsResponse, error) {
-	resp, err := c.doRequest("GET", "/models", nil)
+	resp, err := c.doRequest(context.Background(), "GET", "/models", nil)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(resp)
 	}
 
 	var models ModelsResponse
@@ -105,23 +112,22 @@ func (c *Client) TestConnection() error {
 	return err
 }
 
-// GenerateContent generates content using the specified model
-func (c *Client) GenerateContent(model string, request GenerateContentRequest) (*GenerateContentResponse, error) {
+// GenerateContent generates content using the specified model; cancelling ctx aborts the request
+func (c *Client) GenerateContent(ctx context.Context, model string, request GenerateContentRequest) (*GenerateContentResponse, error) {
 	bodyBytes, err := json.Marshal(request)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	path := fmt.Sprintf("/models/%s:generateContent", model)
-	resp, err := c.doRequest("POST", path, bytes.NewBuffer(bodyBytes))
+	resp, err := c.doRequest(ctx, "POST", path, bytes.NewBuffer(bodyBytes))
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(resp)
 	}
 
 	var response GenerateContentResponse
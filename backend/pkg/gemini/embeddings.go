@@ -0,0 +1,65 @@
+package gemini
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type EmbedContentRequest struct {
+	Model   string  `json:"model"`
+	Content Content `json:"content"`
+}
+
+type BatchEmbedContentsRequest struct {
+	Requests []EmbedContentRequest `json:"requests"`
+}
+
+type ContentEmbedding struct {
+	Values []float32 `json:"values"`
+}
+
+type BatchEmbedContentsResponse struct {
+	Embeddings []ContentEmbedding `json:"embeddings"`
+}
+
+// BatchEmbedContents embeds a batch of texts with an embedding model (e.g. text-embedding-004);
+// the vectors come back in the order of the input
+func (c *Client) BatchEmbedContents(ctx context.Context, model string, texts []string) (*BatchEmbedContentsResponse, error) {
+	request := BatchEmbedContentsRequest{Requests: make([]EmbedContentRequest, len(texts))}
+	for i, text := range texts {
+		request.Requests[i] = EmbedContentRequest{
+			Model:   "models/" + model,
+			Content: Content{Parts: []Part{{Text: text}}},
+		}
+	}
+
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	path := fmt.Sprintf("/models/%s:batchEmbedContents", model)
+	resp, err := c.doRequest(ctx, "POST", path, bytes.NewBuffer(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var response BatchEmbedContentsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if len(response.Embeddings) != len(texts) {
+		return nil, fmt.Errorf("expected %d embeddings, got %d", len(texts), len(response.Embeddings))
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,90 @@
+package gemini
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/PlatifyX/platifyx-core/pkg/sse"
+)
+
+// GenerateContentStream streams generated content, handing each text delta to onDelta. The
+// returned response holds the whole text in a single candidate and the usage of the last chunk.
+// Cancelling ctx (or onDelta returning an error) aborts the request.
+func (c *Client) GenerateContentStream(ctx context.Context, model string, request GenerateContentRequest, onDelta func(delta string) error) (*GenerateContentResponse, error) {
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/models/%s:streamGenerateContent?alt=sse&key=%s", c.baseURL, model, url.QueryEscape(c.apiKey))
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+
+	// No client timeout: the stream lasts as long as the generation, ctx bounds it
+	streamClient := &http.Client{Transport: c.httpClient.Transport}
+	resp, err := streamClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	response := &GenerateContentResponse{}
+	candidate := Candidate{Content: Content{Role: "model"}}
+	var text strings.Builder
+
+	reader := sse.NewReader(resp.Body)
+	for {
+		event, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read stream: %w", err)
+		}
+
+		var chunk GenerateContentResponse
+		if err := json.Unmarshal([]byte(event.Data), &chunk); err != nil {
+			return nil, fmt.Errorf("failed to decode chunk: %w", err)
+		}
+
+		if chunk.UsageMetadata != nil {
+			response.UsageMetadata = chunk.UsageMetadata
+		}
+		if len(chunk.Candidates) == 0 {
+			continue
+		}
+
+		if chunk.Candidates[0].FinishReason != "" {
+			candidate.FinishReason = chunk.Candidates[0].FinishReason
+		}
+		for _, part := range chunk.Candidates[0].Content.Parts {
+			if part.Text == "" {
+				continue
+			}
+			text.WriteString(part.Text)
+			if err := onDelta(part.Text); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	candidate.Content.Parts = []Part{{Text: text.String()}}
+	response.Candidates = []Candidate{candidate}
+	return response, nil
+}
@@ -0,0 +1,175 @@
+package github
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// appJWTLifetime is below the 10 minute maximum accepted by GitHub
+	appJWTLifetime = 9 * time.Minute
+	// appJWTClockSkew backdates the JWT issue time to tolerate clock drift
+	appJWTClockSkew = 60 * time.Second
+	// installationTokenRefreshMargin renews installation tokens (valid for one hour) before they expire
+	installationTokenRefreshMargin = 5 * time.Minute
+)
+
+// appAuth mints the JWT of a GitHub App and the installation access tokens used for API calls.
+// Installation tokens are cached until shortly before they expire.
+type appAuth struct {
+	appID          int64
+	installationID int64
+	key            *rsa.PrivateKey
+	keyErr         error
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+func newAppAuth(appID, installationID int64, privateKey string) *appAuth {
+	auth := &appAuth{appID: appID, installationID: installationID}
+	auth.key, auth.keyErr = jwt.ParseRSAPrivateKeyFromPEM([]byte(normalizePrivateKey(privateKey)))
+	if auth.keyErr != nil {
+		auth.keyErr = fmt.Errorf("invalid GitHub App private key: %w", auth.keyErr)
+	}
+	return auth
+}
+
+// normalizePrivateKey restores the line breaks of a PEM pasted with literal "\n" sequences
+func normalizePrivateKey(privateKey string) string {
+	privateKey = strings.TrimSpace(privateKey)
+	if !strings.Contains(privateKey, "\n") {
+		privateKey = strings.ReplaceAll(privateKey, `\n`, "\n")
+	}
+	return privateKey
+}
+
+// jwt signs the RS256 JWT that authenticates as the App itself
+func (a *appAuth) jwt() (string, error) {
+	if a.keyErr != nil {
+		return "", a.keyErr
+	}
+
+	now := time.Now()
+	claims := jwt.RegisteredClaims{
+		Issuer:    strconv.FormatInt(a.appID, 10),
+		IssuedAt:  jwt.NewNumericDate(now.Add(-appJWTClockSkew)),
+		ExpiresAt: jwt.NewNumericDate(now.Add(appJWTLifetime)),
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(a.key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign GitHub App JWT: %w", err)
+	}
+	return signed, nil
+}
+
+// cachedToken returns the installation token while it is far enough from its expiry
+func (a *appAuth) cachedToken() (string, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token == "" || time.Until(a.expiresAt) < installationTokenRefreshMargin {
+		return "", false
+	}
+	return a.token, true
+}
+
+func (a *appAuth) setToken(token string, expiresAt time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.token = token
+	a.expiresAt = expiresAt
+}
+
+// invalidate drops the cached installation token, e.g. after it was revoked
+func (a *appAuth) invalidate() {
+	a.setToken("", time.Time{})
+}
+
+// authorization returns the Authorization header of API requests: the personal access token,
+// or an installation token of the GitHub App minted (or refreshed) on demand
+func (c *Client) authorization() (string, error) {
+	if c.app == nil {
+		return fmt.Sprintf("token %s", c.token), nil
+	}
+
+	token, err := c.installationToken()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("token %s", token), nil
+}
+
+func (c *Client) installationToken() (string, error) {
+	if token, ok := c.app.cachedToken(); ok {
+		return token, nil
+	}
+
+	// Serialize refreshes so concurrent requests do not mint one token each
+	c.app.mu.Lock()
+	defer c.app.mu.Unlock()
+	if c.app.token != "" && time.Until(c.app.expiresAt) >= installationTokenRefreshMargin {
+		return c.app.token, nil
+	}
+
+	resp, err := c.doAppRequest("POST", fmt.Sprintf("/app/installations/%d/access_tokens", c.app.installationID))
+	if err != nil {
+		return "", fmt.Errorf("failed to create GitHub App installation token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode installation token: %w", err)
+	}
+	if result.Token == "" {
+		return "", fmt.Errorf("GitHub returned an empty installation token")
+	}
+
+	c.app.token = result.Token
+	c.app.expiresAt = result.ExpiresAt
+	return result.Token, nil
+}
+
+// doAppRequest sends a request authenticated with the App JWT (the /app endpoints). It does
+// not go through the rate limiter or the ETag cache: App endpoints have their own quota.
+func (c *Client) doAppRequest(method, path string) (*http.Response, error) {
+	signed, err := c.app.jwt()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+signed)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,237 @@
+package github
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testAppID          = 7
+	testInstallationID = 42
+)
+
+// testAppKey generates the RSA key of a test App and returns it with its PEM encoding
+func testAppKey(t *testing.T) (*rsa.PrivateKey, string) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}
+	return key, string(pem.EncodeToMemory(block))
+}
+
+// appServer mints installation tokens valid for tokenLifetime, after checking the App JWT, and
+// serves /repos/acme/api to requests holding the last token minted
+type appServer struct {
+	key           *rsa.PrivateKey
+	tokenLifetime time.Duration
+	emptyToken    bool
+
+	mu     sync.Mutex
+	minted int
+}
+
+func (s *appServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.URL.Path {
+	case fmt.Sprintf("/app/installations/%d/access_tokens", testInstallationID):
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if _, err := parseAppJWT(s.key, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")); err != nil {
+			http.Error(w, `{"message": "A JSON web token could not be decoded"}`, http.StatusUnauthorized)
+			return
+		}
+
+		s.minted++
+		token := fmt.Sprintf("ghs_%d", s.minted)
+		if s.emptyToken {
+			token = ""
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"token": %q, "expires_at": %q}`, token, time.Now().Add(s.tokenLifetime).UTC().Format(time.RFC3339))
+	case "/repos/acme/api":
+		if r.Header.Get("Authorization") != fmt.Sprintf("token ghs_%d", s.minted) {
+			http.Error(w, `{"message": "Bad credentials"}`, http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"id": 1, "name": "api", "full_name": "acme/api"}`)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *appServer) mints() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.minted
+}
+
+// revoke makes the server expect a token that was not minted yet
+func (s *appServer) revoke() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.minted += 100
+}
+
+func parseAppJWT(key *rsa.PrivateKey, signed string) (*jwt.RegisteredClaims, error) {
+	claims := &jwt.RegisteredClaims{}
+	_, err := jwt.ParseWithClaims(signed, claims, func(*jwt.Token) (interface{}, error) {
+		return &key.PublicKey, nil
+	}, jwt.WithValidMethods([]string{"RS256"}), jwt.WithIssuer(fmt.Sprint(testAppID)))
+	return claims, err
+}
+
+func testAppClient(t *testing.T, srv *appServer) *Client {
+	t.Helper()
+
+	// The server checks JWTs with its own key when it has one
+	key, privateKey := testAppKey(t)
+	if srv.key == nil {
+		srv.key = key
+	}
+	server := httptest.NewServer(srv)
+	t.Cleanup(server.Close)
+
+	config := domain.GitHubConfig{AppID: testAppID, InstallationID: testInstallationID, PrivateKey: privateKey, Organization: "acme"}
+	return NewClient(config, WithBaseURL(server.URL))
+}
+
+func TestAppJWT(t *testing.T) {
+	key, privateKey := testAppKey(t)
+
+	tests := []struct {
+		name       string
+		privateKey string
+		wantErr    bool
+	}{
+		{name: "PEM", privateKey: privateKey},
+		{name: "PEM with escaped line breaks", privateKey: strings.ReplaceAll(privateKey, "\n", `\n`)},
+		{name: "PEM with surrounding spaces", privateKey: "\n  " + privateKey + "  \n"},
+		{name: "invalid key", privateKey: "not a key", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now()
+			signed, err := newAppAuth(testAppID, testInstallationID, tt.privateKey).jwt()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("signed a JWT with an invalid key")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("jwt: %v", err)
+			}
+
+			claims, err := parseAppJWT(key, signed)
+			if err != nil {
+				t.Fatalf("JWT does not verify with the App public key: %v", err)
+			}
+			if issued := now.Add(-appJWTClockSkew); claims.IssuedAt.Time.Sub(issued).Abs() > 2*time.Second {
+				t.Errorf("JWT issued at %v, want %v", claims.IssuedAt.Time, issued)
+			}
+			if expires := now.Add(appJWTLifetime); claims.ExpiresAt.Time.Sub(expires).Abs() > 2*time.Second {
+				t.Errorf("JWT expires at %v, want %v", claims.ExpiresAt.Time, expires)
+			}
+			if lifetime := claims.ExpiresAt.Time.Sub(claims.IssuedAt.Time); lifetime > 10*time.Minute {
+				t.Errorf("JWT is valid for %v, above the 10 minute maximum of GitHub", lifetime)
+			}
+		})
+	}
+}
+
+func TestInstallationTokenRefresh(t *testing.T) {
+	tests := []struct {
+		name          string
+		tokenLifetime time.Duration
+		wantMints     int
+	}{
+		{name: "cached until near expiry", tokenLifetime: time.Hour, wantMints: 1},
+		{name: "refreshed within the margin", tokenLifetime: installationTokenRefreshMargin - time.Minute, wantMints: 3},
+		{name: "refreshed once expired", tokenLifetime: -time.Minute, wantMints: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &appServer{tokenLifetime: tt.tokenLifetime}
+			client := testAppClient(t, srv)
+
+			for i := 0; i < 3; i++ {
+				if _, err := client.GetRepository("acme", "api"); err != nil {
+					t.Fatalf("GetRepository %d: %v", i, err)
+				}
+			}
+			if got := srv.mints(); got != tt.wantMints {
+				t.Errorf("minted %d installation tokens, want %d", got, tt.wantMints)
+			}
+		})
+	}
+}
+
+func TestRevokedInstallationTokenIsReplaced(t *testing.T) {
+	srv := &appServer{tokenLifetime: time.Hour}
+	client := testAppClient(t, srv)
+
+	if _, err := client.GetRepository("acme", "api"); err != nil {
+		t.Fatalf("GetRepository: %v", err)
+	}
+	srv.revoke()
+
+	if _, err := client.GetRepository("acme", "api"); err != nil {
+		t.Fatalf("GetRepository after the token was revoked: %v", err)
+	}
+	if token, ok := client.app.cachedToken(); !ok || token != fmt.Sprintf("ghs_%d", srv.mints()) {
+		t.Errorf("cached token is %q, want the one minted after the revocation", token)
+	}
+}
+
+func TestInstallationTokenErrors(t *testing.T) {
+	t.Run("JWT of another key", func(t *testing.T) {
+		other, _ := testAppKey(t)
+		srv := &appServer{key: other, tokenLifetime: time.Hour}
+		client := testAppClient(t, srv)
+
+		if _, err := client.GetRepository("acme", "api"); err == nil || !strings.Contains(err.Error(), "installation token") {
+			t.Errorf("got %v, want an installation token error", err)
+		}
+	})
+
+	t.Run("empty token", func(t *testing.T) {
+		srv := &appServer{tokenLifetime: time.Hour, emptyToken: true}
+		client := testAppClient(t, srv)
+
+		if _, err := client.GetRepository("acme", "api"); err == nil || !strings.Contains(err.Error(), "empty installation token") {
+			t.Errorf("got %v, want an empty token error", err)
+		}
+		if _, ok := client.app.cachedToken(); ok {
+			t.Error("an empty token was cached")
+		}
+	})
+
+	t.Run("invalid private key", func(t *testing.T) {
+		client := NewClient(domain.GitHubConfig{AppID: testAppID, InstallationID: testInstallationID, PrivateKey: "not a key"}, WithBaseURL("http://127.0.0.1:0"))
+
+		if _, err := client.GetRepository("acme", "api"); err == nil || !strings.Contains(err.Error(), "invalid GitHub App private key") {
+			t.Errorf("got %v, want an invalid private key error", err)
+		}
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
@@ -15,48 +16,170 @@ import (
 type Client struct {
 	baseURL      string
 	token        string
+	app          *appAuth
 	organization string
 	httpClient   *http.Client
+	rateLimit    *rateLimiter
+	etags        *etagCache
 }
 
-func NewClient(config domain.GitHubConfig) *Client {
-	return &Client{
+// ClientOption customizes a Client (API URL for GitHub Enterprise or tests, HTTP client, rate limit wait)
+type ClientOption func(*Client)
+
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithMaxRateLimitWait sets how long a request may pause for the rate limit to reset;
+// 0 makes requests fail fast with a RateLimitError
+func WithMaxRateLimitWait(wait time.Duration) ClientOption {
+	return func(c *Client) {
+		c.rateLimit.maxWait = wait
+	}
+}
+
+func NewClient(config domain.GitHubConfig, opts ...ClientOption) *Client {
+	c := &Client{
 		baseURL:      "https://api.github.com",
 		token:        config.Token,
 		organization: config.Organization,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		rateLimit: &rateLimiter{maxWait: DefaultMaxRateLimitWait},
+		etags:     newETagCache(),
+	}
+	if config.UsesApp() {
+		// An invalid private key is reported by the first request
+		c.app = newAppAuth(config.AppID, config.InstallationID, config.PrivateKey)
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// UsesApp reports whether the client authenticates as a GitHub App installation
+func (c *Client) UsesApp() bool {
+	return c.app != nil
+}
+
+// RateLimit returns the quota reported by the last response
+func (c *Client) RateLimit() RateLimit {
+	current, _ := c.rateLimit.snapshot()
+	return current
 }
 
+// CloseIdleConnections releases the idle connections of the HTTP client
+func (c *Client) CloseIdleConnections() {
+	c.httpClient.CloseIdleConnections()
+}
+
+// doRequest sends an API request. path is relative to the base URL, or an absolute URL of the
+// same API (Link headers). GET requests are revalidated with their last ETag, and requests
+// pause (up to the configured wait) or fail with a RateLimitError when the quota is exhausted.
 func (c *Client) doRequest(method, path string, body io.Reader) (*http.Response, error) {
-	url := fmt.Sprintf("%s%s", c.baseURL, path)
-	req, err := http.NewRequest(method, url, body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	return c.send(method, path, body, true)
+}
+
+// send is doRequest with control over the ETag cache, which immutable content (blobs) skips
+func (c *Client) send(method, path string, body io.Reader, revalidate bool) (*http.Response, error) {
+	url := path
+	if !strings.HasPrefix(path, "http://") && !strings.HasPrefix(path, "https://") {
+		url = fmt.Sprintf("%s%s", c.baseURL, path)
+	} else if !strings.HasPrefix(path, c.baseURL+"/") {
+		// Never send the token to another host
+		return nil, fmt.Errorf("refusing to follow URL outside of the GitHub API: %s", path)
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("token %s", c.token))
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
-	req.Header.Set("Content-Type", "application/json")
+	for attempt := 0; ; attempt++ {
+		if err := c.rateLimit.wait(); err != nil {
+			return nil, err
+		}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
-	}
+		authorization, err := c.authorization()
+		if err != nil {
+			return nil, err
+		}
 
-	if resp.StatusCode >= 400 {
-		defer resp.Body.Close()
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(bodyBytes))
-	}
+		req, err := http.NewRequest(method, url, body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		req.Header.Set("Authorization", authorization)
+		req.Header.Set("Accept", "application/vnd.github.v3+json")
+		req.Header.Set("Content-Type", "application/json")
+
+		var cached *cachedResponse
+		if method == http.MethodGet && revalidate {
+			if cached = c.etags.get(url); cached != nil {
+				req.Header.Set("If-None-Match", cached.etag)
+			}
+		}
 
-	return resp, nil
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute request: %w", err)
+		}
+		c.rateLimit.update(resp.Header)
+
+		if resp.StatusCode == http.StatusNotModified && cached != nil {
+			return cached.replay(resp), nil
+		}
+
+		if reset, limited := limitedUntil(resp); limited {
+			bodyBytes, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+
+			// Only bodiless requests can be sent again
+			delay := time.Until(reset)
+			if attempt == 0 && body == nil && delay <= c.rateLimit.maxWait {
+				time.Sleep(delay)
+				continue
+			}
+			return nil, &RateLimitError{Reset: reset, Message: string(bodyBytes)}
+		}
+
+		if resp.StatusCode == http.StatusUnauthorized && c.app != nil && attempt == 0 && body == nil {
+			// The installation token may have been revoked: mint a new one and try again
+			resp.Body.Close()
+			c.app.invalidate()
+			continue
+		}
+
+		if resp.StatusCode >= 400 {
+			defer resp.Body.Close()
+			bodyBytes, _ := io.ReadAll(resp.Body)
+			return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+		}
+
+		if method == http.MethodGet && revalidate && resp.StatusCode == http.StatusOK {
+			return c.etags.remember(url, resp)
+		}
+		return resp, nil
+	}
 }
 
-// TestConnection tests the connection to GitHub
+// TestConnection tests the connection to GitHub. A GitHub App must be able to read its
+// installation and mint an installation token.
 func (c *Client) TestConnection() error {
+	if c.app != nil {
+		if _, err := c.GetInstallation(); err != nil {
+			return err
+		}
+		_, err := c.installationToken()
+		return err
+	}
+
 	resp, err := c.doRequest("GET", "/user", nil)
 	if err != nil {
 		return err
@@ -66,8 +189,17 @@ func (c *Client) TestConnection() error {
 	return nil
 }
 
-// GetAuthenticatedUser returns the authenticated user
+// GetAuthenticatedUser returns the authenticated user. Installation tokens have no user, so
+// a GitHub App returns the account (user or organization) it is installed on.
 func (c *Client) GetAuthenticatedUser() (*domain.GitHubUser, error) {
+	if c.app != nil {
+		installation, err := c.GetInstallation()
+		if err != nil {
+			return nil, err
+		}
+		return &installation.Account, nil
+	}
+
 	resp, err := c.doRequest("GET", "/user", nil)
 	if err != nil {
 		return nil, err
@@ -97,22 +229,77 @@ func (c *Client) GetAuthenticatedUser() (*domain.GitHubUser, error) {
 	}, nil
 }
 
-// ListRepositories lists repositories for the authenticated user or organization
-func (c *Client) ListRepositories() ([]domain.GitHubRepository, error) {
-	var path string
-	if c.organization != "" {
-		path = fmt.Sprintf("/orgs/%s/repos?per_page=100", c.organization)
-	} else {
-		path = "/user/repos?per_page=100"
+// GetInstallation returns the GitHub App installation the client authenticates as
+func (c *Client) GetInstallation() (*domain.GitHubInstallation, error) {
+	if c.app == nil {
+		return nil, fmt.Errorf("client is not configured with a GitHub App")
 	}
 
-	resp, err := c.doRequest("GET", path, nil)
+	resp, err := c.doAppRequest("GET", fmt.Sprintf("/app/installations/%d", c.app.installationID))
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	var rawRepos []struct {
+	var rawInstallation struct {
+		ID      int64  `json:"id"`
+		AppID   int64  `json:"app_id"`
+		AppSlug string `json:"app_slug"`
+		Account struct {
+			ID        int64  `json:"id"`
+			Login     string `json:"login"`
+			AvatarURL string `json:"avatar_url"`
+			HTMLURL   string `json:"html_url"`
+			Type      string `json:"type"`
+		} `json:"account"`
+		TargetType          string            `json:"target_type"`
+		RepositorySelection string            `json:"repository_selection"`
+		Permissions         map[string]string `json:"permissions"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&rawInstallation); err != nil {
+		return nil, fmt.Errorf("failed to decode installation: %w", err)
+	}
+
+	return &domain.GitHubInstallation{
+		ID:      rawInstallation.ID,
+		AppID:   rawInstallation.AppID,
+		AppSlug: rawInstallation.AppSlug,
+		Account: domain.GitHubUser{
+			ID:        rawInstallation.Account.ID,
+			Login:     rawInstallation.Account.Login,
+			AvatarURL: rawInstallation.Account.AvatarURL,
+			HTMLURL:   rawInstallation.Account.HTMLURL,
+			Type:      rawInstallation.Account.Type,
+		},
+		TargetType:          rawInstallation.TargetType,
+		RepositorySelection: rawInstallation.RepositorySelection,
+		Permissions:         rawInstallation.Permissions,
+	}, nil
+}
+
+// ListRepositories lists repositories for the authenticated user or organization (for a
+// GitHub App without organization, the repositories of the installation), following the
+// Link header up to opts.MaxPages
+func (c *Client) ListRepositories(opts ListOptions) ([]domain.GitHubRepository, error) {
+	repos, _, err := c.ListRepositoriesPage(opts)
+	return repos, err
+}
+
+// ListRepositoriesPage is ListRepositories that also tells whether the page cap stopped the
+// listing, and the cursor to resume it from
+func (c *Client) ListRepositoriesPage(opts ListOptions) ([]domain.GitHubRepository, ListPage, error) {
+	var path string
+	switch {
+	case c.organization != "":
+		path = fmt.Sprintf("/orgs/%s/repos", c.organization)
+	case c.app != nil:
+		path = "/installation/repositories"
+	default:
+		path = "/user/repos"
+	}
+
+	type rawRepository struct {
 		ID              int64  `json:"id"`
 		Name            string `json:"name"`
 		FullName        string `json:"full_name"`
@@ -142,8 +329,25 @@ func (c *Client) ListRepositories() ([]domain.GitHubRepository, error) {
 		PushedAt  time.Time `json:"pushed_at"`
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&rawRepos); err != nil {
-		return nil, fmt.Errorf("failed to decode repositories: %w", err)
+	var rawRepos []rawRepository
+	listPage, err := c.paginate(path, opts, func(body io.Reader) error {
+		var page []rawRepository
+		if path == "/installation/repositories" {
+			var wrapped struct {
+				Repositories []rawRepository `json:"repositories"`
+			}
+			if err := json.NewDecoder(body).Decode(&wrapped); err != nil {
+				return fmt.Errorf("failed to decode repositories: %w", err)
+			}
+			page = wrapped.Repositories
+		} else if err := json.NewDecoder(body).Decode(&page); err != nil {
+			return fmt.Errorf("failed to decode repositories: %w", err)
+		}
+		rawRepos = append(rawRepos, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, ListPage{}, err
 	}
 
 	repos := make([]domain.GitHubRepository, 0, len(rawRepos))
@@ -179,7 +383,7 @@ func (c *Client) ListRepositories() ([]domain.GitHubRepository, error) {
 		})
 	}
 
-	return repos, nil
+	return repos, listPage, nil
 }
 
 // GetRepository gets a specific repository
@@ -258,19 +462,13 @@ func (c *Client) GetRepository(owner, repo string) (*domain.GitHubRepository, er
 }
 
 // ListCommits lists commits for a repository
-func (c *Client) ListCommits(owner, repo string, branch string) ([]domain.GitHubCommit, error) {
-	path := fmt.Sprintf("/repos/%s/%s/commits?per_page=50", owner, repo)
+func (c *Client) ListCommits(owner, repo string, branch string, opts ListOptions) ([]domain.GitHubCommit, ListPage, error) {
+	path := fmt.Sprintf("/repos/%s/%s/commits", owner, repo)
 	if branch != "" {
-		path += fmt.Sprintf("&sha=%s", branch)
-	}
-
-	resp, err := c.doRequest("GET", path, nil)
-	if err != nil {
-		return nil, err
+		path += "?sha=" + url.QueryEscape(branch)
 	}
-	defer resp.Body.Close()
 
-	var rawCommits []struct {
+	type rawCommit struct {
 		SHA    string `json:"sha"`
 		Commit struct {
 			Message string `json:"message"`
@@ -288,8 +486,17 @@ func (c *Client) ListCommits(owner, repo string, branch string) ([]domain.GitHub
 		HTMLURL string `json:"html_url"`
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&rawCommits); err != nil {
-		return nil, fmt.Errorf("failed to decode commits: %w", err)
+	var rawCommits []rawCommit
+	listPage, err := c.paginate(path, opts, func(body io.Reader) error {
+		var page []rawCommit
+		if err := json.NewDecoder(body).Decode(&page); err != nil {
+			return fmt.Errorf("failed to decode commits: %w", err)
+		}
+		rawCommits = append(rawCommits, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, ListPage{}, err
 	}
 
 	commits := make([]domain.GitHubCommit, 0, len(rawCommits))
@@ -311,20 +518,14 @@ func (c *Client) ListCommits(owner, repo string, branch string) ([]domain.GitHub
 		})
 	}
 
-	return commits, nil
+	return commits, listPage, nil
 }
 
 // ListPullRequests lists pull requests for a repository
-func (c *Client) ListPullRequests(owner, repo, state string) ([]domain.GitHubPullRequest, error) {
-	path := fmt.Sprintf("/repos/%s/%s/pulls?state=%s&per_page=50", owner, repo, state)
-
-	resp, err := c.doRequest("GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+func (c *Client) ListPullRequests(owner, repo, state string, opts ListOptions) ([]domain.GitHubPullRequest, ListPage, error) {
+	path := fmt.Sprintf("/repos/%s/%s/pulls?state=%s", owner, repo, state)
 
-	var rawPRs []struct {
+	type rawPullRequest struct {
 		ID        int64  `json:"id"`
 		Number    int    `json:"number"`
 		State     string `json:"state"`
@@ -353,8 +554,17 @@ func (c *Client) ListPullRequests(owner, repo, state string) ([]domain.GitHubPul
 		ChangedFiles int        `json:"changed_files"`
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&rawPRs); err != nil {
-		return nil, fmt.Errorf("failed to decode pull requests: %w", err)
+	var rawPRs []rawPullRequest
+	listPage, err := c.paginate(path, opts, func(body io.Reader) error {
+		var page []rawPullRequest
+		if err := json.NewDecoder(body).Decode(&page); err != nil {
+			return fmt.Errorf("failed to decode pull requests: %w", err)
+		}
+		rawPRs = append(rawPRs, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, ListPage{}, err
 	}
 
 	prs := make([]domain.GitHubPullRequest, 0, len(rawPRs))
@@ -389,20 +599,14 @@ func (c *Client) ListPullRequests(owner, repo, state string) ([]domain.GitHubPul
 		})
 	}
 
-	return prs, nil
+	return prs, listPage, nil
 }
 
 // ListIssues lists issues for a repository
-func (c *Client) ListIssues(owner, repo, state string) ([]domain.GitHubIssue, error) {
-	path := fmt.Sprintf("/repos/%s/%s/issues?state=%s&per_page=50", owner, repo, state)
-
-	resp, err := c.doRequest("GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+func (c *Client) ListIssues(owner, repo, state string, opts ListOptions) ([]domain.GitHubIssue, ListPage, error) {
+	path := fmt.Sprintf("/repos/%s/%s/issues?state=%s", owner, repo, state)
 
-	var rawIssues []struct {
+	type rawIssue struct {
 		ID     int64  `json:"id"`
 		Number int    `json:"number"`
 		State  string `json:"state"`
@@ -429,8 +633,17 @@ func (c *Client) ListIssues(owner, repo, state string) ([]domain.GitHubIssue, er
 		ClosedAt  *time.Time `json:"closed_at"`
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&rawIssues); err != nil {
-		return nil, fmt.Errorf("failed to decode issues: %w", err)
+	var rawIssues []rawIssue
+	listPage, err := c.paginate(path, opts, func(body io.Reader) error {
+		var page []rawIssue
+		if err := json.NewDecoder(body).Decode(&page); err != nil {
+			return fmt.Errorf("failed to decode issues: %w", err)
+		}
+		rawIssues = append(rawIssues, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, ListPage{}, err
 	}
 
 	issues := make([]domain.GitHubIssue, 0, len(rawIssues))
@@ -468,20 +681,14 @@ func (c *Client) ListIssues(owner, repo, state string) ([]domain.GitHubIssue, er
 		})
 	}
 
-	return issues, nil
+	return issues, listPage, nil
 }
 
 // ListBranches lists branches for a repository
-func (c *Client) ListBranches(owner, repo string) ([]domain.GitHubBranch, error) {
-	path := fmt.Sprintf("/repos/%s/%s/branches?per_page=100", owner, repo)
-
-	resp, err := c.doRequest("GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+func (c *Client) ListBranches(owner, repo string, opts ListOptions) ([]domain.GitHubBranch, ListPage, error) {
+	path := fmt.Sprintf("/repos/%s/%s/branches", owner, repo)
 
-	var rawBranches []struct {
+	type rawBranch struct {
 		Name      string `json:"name"`
 		Protected bool   `json:"protected"`
 		Commit    struct {
@@ -489,8 +696,17 @@ func (c *Client) ListBranches(owner, repo string) ([]domain.GitHubBranch, error)
 		} `json:"commit"`
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&rawBranches); err != nil {
-		return nil, fmt.Errorf("failed to decode branches: %w", err)
+	var rawBranches []rawBranch
+	listPage, err := c.paginate(path, opts, func(body io.Reader) error {
+		var page []rawBranch
+		if err := json.NewDecoder(body).Decode(&page); err != nil {
+			return fmt.Errorf("failed to decode branches: %w", err)
+		}
+		rawBranches = append(rawBranches, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, ListPage{}, err
 	}
 
 	branches := make([]domain.GitHubBranch, 0, len(rawBranches))
@@ -504,20 +720,14 @@ func (c *Client) ListBranches(owner, repo string) ([]domain.GitHubBranch, error)
 		})
 	}
 
-	return branches, nil
+	return branches, listPage, nil
 }
 
 // ListWorkflowRuns lists workflow runs for a repository
-func (c *Client) ListWorkflowRuns(owner, repo string) ([]domain.GitHubWorkflowRun, error) {
-	path := fmt.Sprintf("/repos/%s/%s/actions/runs?per_page=50", owner, repo)
-
-	resp, err := c.doRequest("GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+func (c *Client) ListWorkflowRuns(owner, repo string, opts ListOptions) ([]domain.GitHubWorkflowRun, ListPage, error) {
+	path := fmt.Sprintf("/repos/%s/%s/actions/runs", owner, repo)
 
-	var result struct {
+	type rawWorkflowRuns struct {
 		WorkflowRuns []struct {
 			ID           int64      `json:"id"`
 			Name         string     `json:"name"`
@@ -535,8 +745,17 @@ func (c *Client) ListWorkflowRuns(owner, repo string) ([]domain.GitHubWorkflowRu
 		} `json:"workflow_runs"`
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode workflow runs: %w", err)
+	var result rawWorkflowRuns
+	listPage, err := c.paginate(path, opts, func(body io.Reader) error {
+		var page rawWorkflowRuns
+		if err := json.NewDecoder(body).Decode(&page); err != nil {
+			return fmt.Errorf("failed to decode workflow runs: %w", err)
+		}
+		result.WorkflowRuns = append(result.WorkflowRuns, page.WorkflowRuns...)
+		return nil
+	})
+	if err != nil {
+		return nil, ListPage{}, err
 	}
 
 	runs := make([]domain.GitHubWorkflowRun, 0, len(result.WorkflowRuns))
@@ -558,7 +777,7 @@ func (c *Client) ListWorkflowRuns(owner, repo string) ([]domain.GitHubWorkflowRu
 		})
 	}
 
-	return runs, nil
+	return runs, listPage, nil
 }
 
 // GetOrganization gets organization details
@@ -691,54 +910,20 @@ func (c *Client) ListDirectoryContents(owner, repo, path, ref string) ([]GitHubF
 // GitHubRepositoryFile represents a file with its content
 type GitHubRepositoryFile struct {
 	Path    string
+	SHA     string
+	Size    int64
 	Content string
 }
 
-// GetAllRepositoryFiles gets all files from a repository recursively
+// GetAllRepositoryFiles gets the source files of a repository with the default ingestion
+// options (see IngestRepository)
 func (c *Client) GetAllRepositoryFiles(owner, repo, ref string) ([]GitHubRepositoryFile, error) {
-	var allFiles []GitHubRepositoryFile
-
-	// Start from root directory
-	if err := c.walkDirectory(owner, repo, "", ref, &allFiles); err != nil {
-		return nil, err
-	}
-
-	return allFiles, nil
-}
-
-// walkDirectory recursively walks through repository directories
-func (c *Client) walkDirectory(owner, repo, path, ref string, files *[]GitHubRepositoryFile) error {
-	contents, err := c.ListDirectoryContents(owner, repo, path, ref)
+	ingestion, err := c.IngestRepository(owner, repo, ref, IngestOptions{})
 	if err != nil {
-		return err
-	}
-
-	for _, item := range contents {
-		if item.Type == "file" {
-			// Only include source code files, ignore binaries and large files
-			if c.shouldIncludeFile(item.Path) {
-				content, err := c.GetFileContent(owner, repo, item.Path, ref)
-				if err != nil {
-					// Log error but continue with other files
-					continue
-				}
-				*files = append(*files, GitHubRepositoryFile{
-					Path:    item.Path,
-					Content: content,
-				})
-			}
-		} else if item.Type == "dir" {
-			// Recursively process subdirectories, but skip common directories
-			if !c.shouldSkipDirectory(item.Name) {
-				if err := c.walkDirectory(owner, repo, item.Path, ref, files); err != nil {
-					// Log error but continue with other directories
-					continue
-				}
-			}
-		}
+		return nil, err
 	}
 
-	return nil
+	return ingestion.Files, nil
 }
 
 // shouldIncludeFile checks if a file should be included in the documentation
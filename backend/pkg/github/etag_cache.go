@@ -0,0 +1,83 @@
+package github
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+)
+
+const (
+	// maxETagEntries bounds how many GET responses are kept for conditional requests
+	maxETagEntries = 512
+	// maxETagBodySize is the largest response body kept for conditional requests
+	maxETagBodySize = 2 << 20
+)
+
+type cachedResponse struct {
+	etag string
+	body []byte
+}
+
+// etagCache keeps the last ETag and body of GET responses so they can be revalidated with
+// If-None-Match: a 304 reply does not count against the rate limit
+type etagCache struct {
+	mu      sync.Mutex
+	entries map[string]*cachedResponse
+}
+
+func newETagCache() *etagCache {
+	return &etagCache{entries: make(map[string]*cachedResponse)}
+}
+
+func (c *etagCache) get(url string) *cachedResponse {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries[url]
+}
+
+func (c *etagCache) put(url string, entry *cachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[url]; !ok && len(c.entries) >= maxETagEntries {
+		// Evict an arbitrary entry; revalidation is an optimization, not a guarantee
+		for key := range c.entries {
+			delete(c.entries, key)
+			break
+		}
+	}
+	c.entries[url] = entry
+}
+
+// remember buffers a 200 response that carries an ETag and returns it with a readable body
+func (c *etagCache) remember(url string, resp *http.Response) (*http.Response, error) {
+	etag := resp.Header.Get("ETag")
+	if etag == "" || resp.ContentLength > maxETagBodySize {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxETagBodySize+1))
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(body) <= maxETagBodySize {
+		c.put(url, &cachedResponse{etag: etag, body: body})
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// replay turns a 304 reply into the cached 200 response, keeping the fresh headers (Link, rate limit)
+func (e *cachedResponse) replay(notModified *http.Response) *http.Response {
+	notModified.Body.Close()
+
+	resp := *notModified
+	resp.StatusCode = http.StatusOK
+	resp.Status = "200 OK"
+	resp.Body = io.NopCloser(bytes.NewReader(e.body))
+	resp.ContentLength = int64(len(e.body))
+	return &resp
+}
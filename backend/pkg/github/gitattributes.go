@@ -0,0 +1,133 @@
+package github
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// attributeRule is a line of a .gitattributes file that sets or unsets the linguist-generated
+// or linguist-vendored attributes
+type attributeRule struct {
+	dir       string
+	pattern   string
+	generated *bool
+	vendored  *bool
+}
+
+// gitAttributes holds the linguist rules of every .gitattributes file of a tree, ordered so
+// that a deeper file (and a later line) takes precedence, as in git
+type gitAttributes struct {
+	rules []attributeRule
+}
+
+// add parses the .gitattributes file at filePath
+func (a *gitAttributes) add(filePath string, content string) {
+	dir := path.Dir(filePath)
+	if dir == "." {
+		dir = ""
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		rule := attributeRule{dir: dir, pattern: fields[0]}
+		for _, attr := range fields[1:] {
+			name, value := parseAttribute(attr)
+			switch name {
+			case "linguist-generated":
+				rule.generated = &value
+			case "linguist-vendored":
+				rule.vendored = &value
+			}
+		}
+		if rule.generated != nil || rule.vendored != nil {
+			a.rules = append(a.rules, rule)
+		}
+	}
+
+	sort.SliceStable(a.rules, func(i, j int) bool {
+		return pathDepth(a.rules[i].dir) < pathDepth(a.rules[j].dir)
+	})
+}
+
+// excluded reports whether a file is marked as generated or vendored code
+func (a *gitAttributes) excluded(filePath string) bool {
+	generated, vendored := false, false
+	for _, rule := range a.rules {
+		if !rule.matches(filePath) {
+			continue
+		}
+		if rule.generated != nil {
+			generated = *rule.generated
+		}
+		if rule.vendored != nil {
+			vendored = *rule.vendored
+		}
+	}
+	return generated || vendored
+}
+
+func (r attributeRule) matches(filePath string) bool {
+	rel := filePath
+	if r.dir != "" {
+		if !strings.HasPrefix(filePath, r.dir+"/") {
+			return false
+		}
+		rel = strings.TrimPrefix(filePath, r.dir+"/")
+	}
+
+	// A pattern without a slash matches the file name at any depth
+	if !strings.Contains(r.pattern, "/") {
+		matched, _ := path.Match(r.pattern, path.Base(rel))
+		return matched
+	}
+
+	return matchGlob(strings.Split(strings.TrimPrefix(r.pattern, "/"), "/"), strings.Split(rel, "/"))
+}
+
+// parseAttribute reads "attr", "attr=true", "attr=false", "-attr" and "!attr"
+func parseAttribute(attr string) (string, bool) {
+	switch {
+	case strings.HasPrefix(attr, "-"), strings.HasPrefix(attr, "!"):
+		return attr[1:], false
+	case strings.Contains(attr, "="):
+		name, value, _ := strings.Cut(attr, "=")
+		return name, value != "false"
+	default:
+		return attr, true
+	}
+}
+
+// matchGlob matches path segments against pattern segments, where "**" matches any number
+// of segments
+func matchGlob(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchGlob(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(segments) == 0 {
+		return false
+	}
+	matched, _ := path.Match(pattern[0], segments[0])
+	return matched && matchGlob(pattern[1:], segments[1:])
+}
+
+func pathDepth(dir string) int {
+	if dir == "" {
+		return 0
+	}
+	return strings.Count(dir, "/") + 1
+}
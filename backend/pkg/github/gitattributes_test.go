@@ -0,0 +1,95 @@
+package github
+
+import "testing"
+
+func TestGitAttributesExcluded(t *testing.T) {
+	attributes := &gitAttributes{}
+	// Added deepest first: the rules of deeper files must still take precedence
+	attributes.add("sub/.gitattributes", "*.pb.go linguist-generated=false\n")
+	attributes.add("web/.gitattributes", "dist/* linguist-generated\n*.min.js linguist-vendored\nkeep.min.js !linguist-vendored\n")
+	attributes.add(".gitattributes", `# Generated code
+*.pb.go linguist-generated
+*.go
+docs/** linguist-vendored
+/third_party/**/*.js linguist-vendored=true
+*.lock linguist-generated text eol=lf
+*.snap -linguist-generated
+`)
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "service.pb.go", want: true},
+		{path: "api/v1/service.pb.go", want: true},
+		{path: "api/v1/service.go"},
+		{path: "docs/intro.md", want: true},
+		{path: "docs/guide/intro.md", want: true},
+		{path: "src/docs/intro.md"},
+		{path: "third_party/lib.js", want: true},
+		{path: "third_party/a/b/lib.js", want: true},
+		{path: "third_party/lib.ts"},
+		{path: "web/dist/app.js", want: true},
+		{path: "web/dist/js/app.js"},
+		{path: "dist/app.js"},
+		{path: "web/src/app.min.js", want: true},
+		{path: "web/keep.min.js"},
+		{path: "app.min.js"},
+		{path: "yarn.lock", want: true},
+		{path: "ui/__snapshots__/button.snap"},
+		{path: "sub/service.pb.go"},
+		{path: "sub/deep/service.pb.go"},
+		{path: "subway/service.pb.go", want: true},
+	}
+
+	for _, tt := range tests {
+		if got := attributes.excluded(tt.path); got != tt.want {
+			t.Errorf("excluded(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestParseAttribute(t *testing.T) {
+	tests := []struct {
+		attr      string
+		wantName  string
+		wantValue bool
+	}{
+		{attr: "linguist-generated", wantName: "linguist-generated", wantValue: true},
+		{attr: "-linguist-generated", wantName: "linguist-generated"},
+		{attr: "!linguist-vendored", wantName: "linguist-vendored"},
+		{attr: "linguist-vendored=true", wantName: "linguist-vendored", wantValue: true},
+		{attr: "linguist-vendored=false", wantName: "linguist-vendored"},
+		{attr: "eol=lf", wantName: "eol", wantValue: true},
+	}
+
+	for _, tt := range tests {
+		name, value := parseAttribute(tt.attr)
+		if name != tt.wantName || value != tt.wantValue {
+			t.Errorf("parseAttribute(%q) = %q, %v; want %q, %v", tt.attr, name, value, tt.wantName, tt.wantValue)
+		}
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern []string
+		path    []string
+		want    bool
+	}{
+		{pattern: []string{"a", "*.go"}, path: []string{"a", "b.go"}, want: true},
+		{pattern: []string{"a", "*.go"}, path: []string{"a", "b", "c.go"}},
+		{pattern: []string{"**", "*.go"}, path: []string{"c.go"}, want: true},
+		{pattern: []string{"**", "*.go"}, path: []string{"a", "b", "c.go"}, want: true},
+		{pattern: []string{"a", "**"}, path: []string{"a"}, want: true},
+		{pattern: []string{"a", "**", "z"}, path: []string{"a", "b", "c"}},
+		{pattern: []string{"a"}, path: []string{"a", "b"}},
+		{pattern: []string{"a", "b"}, path: []string{"a"}},
+	}
+
+	for _, tt := range tests {
+		if got := matchGlob(tt.pattern, tt.path); got != tt.want {
+			t.Errorf("matchGlob(%v, %v) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
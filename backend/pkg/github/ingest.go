@@ -0,0 +1,295 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// DefaultIngestConcurrency is how many blobs are fetched in parallel
+	DefaultIngestConcurrency = 8
+	// DefaultMaxFileSize skips files larger than this (bytes)
+	DefaultMaxFileSize = 512 << 10
+	// DefaultMaxTotalSize is the size budget of an ingestion (bytes)
+	DefaultMaxTotalSize = 8 << 20
+)
+
+// BlobCache keeps blob contents by SHA between ingestions, so regenerating only fetches the
+// files that changed
+type BlobCache interface {
+	Get(sha string) ([]byte, bool)
+	Set(sha string, content []byte)
+}
+
+// IngestOptions controls IngestRepository. Zero values use the defaults; Cache is optional.
+type IngestOptions struct {
+	Concurrency  int
+	MaxFileSize  int64
+	MaxTotalSize int64
+	Cache        BlobCache
+}
+
+// IngestStats summarizes what an ingestion read and skipped
+type IngestStats struct {
+	Entries           int   `json:"entries"`
+	Files             int   `json:"files"`
+	TotalSize         int64 `json:"totalSize"`
+	CachedBlobs       int   `json:"cachedBlobs"`
+	FetchedBlobs      int   `json:"fetchedBlobs"`
+	FailedBlobs       int   `json:"failedBlobs"`
+	SkippedFiltered   int   `json:"skippedFiltered"`
+	SkippedGenerated  int   `json:"skippedGenerated"`
+	SkippedTooLarge   int   `json:"skippedTooLarge"`
+	SkippedOverBudget int   `json:"skippedOverBudget"`
+	Truncated         bool  `json:"truncated"`
+}
+
+// RepositoryIngestion is the content of a repository at a ref
+type RepositoryIngestion struct {
+	TreeSHA string
+	Files   []GitHubRepositoryFile
+	Stats   IngestStats
+}
+
+// IngestRepository reads the source files of a repository: the recursive tree comes in one
+// call, files marked linguist-generated or linguist-vendored in .gitattributes are skipped,
+// and the blobs that fit the size budget are fetched by a bounded worker pool (or read from
+// opts.Cache). Shallow files get the budget first.
+func (c *Client) IngestRepository(owner, repo, ref string, opts IngestOptions) (*RepositoryIngestion, error) {
+	opts = opts.withDefaults()
+
+	tree, err := c.GetTree(owner, repo, ref, true)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := tree.Entries
+	if tree.Truncated {
+		// Too large for one call: list the directories one by one, pruning skipped ones
+		entries, err = c.walkTree(owner, repo, tree.SHA, "")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ingestion := &RepositoryIngestion{TreeSHA: tree.SHA}
+	ingestion.Stats.Entries = len(entries)
+	ingestion.Stats.Truncated = tree.Truncated
+
+	attributes, err := c.loadGitAttributes(owner, repo, entries, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []TreeEntry
+	for _, entry := range entries {
+		if entry.Type != "blob" {
+			continue
+		}
+		switch {
+		case !c.includeTreeFile(entry):
+			ingestion.Stats.SkippedFiltered++
+		case attributes.excluded(entry.Path):
+			ingestion.Stats.SkippedGenerated++
+		case entry.Size > opts.MaxFileSize:
+			ingestion.Stats.SkippedTooLarge++
+		default:
+			candidates = append(candidates, entry)
+		}
+	}
+
+	selected := selectWithinBudget(candidates, opts.MaxTotalSize, &ingestion.Stats)
+
+	results, err := c.fetchBlobs(owner, repo, selected, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, result := range results {
+		switch {
+		case result.err != nil:
+			ingestion.Stats.FailedBlobs++
+			continue
+		case result.cached:
+			ingestion.Stats.CachedBlobs++
+		default:
+			ingestion.Stats.FetchedBlobs++
+		}
+
+		ingestion.Files = append(ingestion.Files, GitHubRepositoryFile{
+			Path:    selected[i].Path,
+			SHA:     selected[i].SHA,
+			Size:    int64(len(result.content)),
+			Content: string(result.content),
+		})
+		ingestion.Stats.TotalSize += int64(len(result.content))
+	}
+	ingestion.Stats.Files = len(ingestion.Files)
+
+	return ingestion, nil
+}
+
+func (o IngestOptions) withDefaults() IngestOptions {
+	if o.Concurrency <= 0 {
+		o.Concurrency = DefaultIngestConcurrency
+	}
+	if o.MaxFileSize <= 0 {
+		o.MaxFileSize = DefaultMaxFileSize
+	}
+	if o.MaxTotalSize <= 0 {
+		o.MaxTotalSize = DefaultMaxTotalSize
+	}
+	return o
+}
+
+// walkTree lists a tree one level at a time, for repositories whose recursive tree is truncated
+func (c *Client) walkTree(owner, repo, sha, prefix string) ([]TreeEntry, error) {
+	tree, err := c.GetTree(owner, repo, sha, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []TreeEntry
+	for _, entry := range tree.Entries {
+		entry.Path = path.Join(prefix, entry.Path)
+		entries = append(entries, entry)
+
+		if entry.Type == "tree" && !c.shouldSkipDirectory(path.Base(entry.Path)) {
+			children, err := c.walkTree(owner, repo, entry.SHA, entry.Path)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, children...)
+		}
+	}
+
+	return entries, nil
+}
+
+// includeTreeFile applies the source file filters: extension, skipped directories and
+// regular files only (no symlinks)
+func (c *Client) includeTreeFile(entry TreeEntry) bool {
+	return entry.Mode != "120000" && c.shouldIncludeFile(entry.Path) && !c.inSkippedDirectory(entry.Path)
+}
+
+func (c *Client) inSkippedDirectory(filePath string) bool {
+	for _, dir := range strings.Split(path.Dir(filePath), "/") {
+		if dir != "." && c.shouldSkipDirectory(dir) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Client) loadGitAttributes(owner, repo string, entries []TreeEntry, opts IngestOptions) (*gitAttributes, error) {
+	var files []TreeEntry
+	for _, entry := range entries {
+		if entry.Type == "blob" && path.Base(entry.Path) == ".gitattributes" && !c.inSkippedDirectory(entry.Path) {
+			files = append(files, entry)
+		}
+	}
+
+	attributes := &gitAttributes{}
+	results, err := c.fetchBlobs(owner, repo, files, opts)
+	if err != nil {
+		return nil, err
+	}
+	for i, result := range results {
+		if result.err == nil {
+			attributes.add(files[i].Path, string(result.content))
+		}
+	}
+
+	return attributes, nil
+}
+
+// selectWithinBudget spends the size budget on shallow files first (README, manifests and
+// entry points are usually at the top) and keeps the tree order of the selection
+func selectWithinBudget(candidates []TreeEntry, budget int64, stats *IngestStats) []TreeEntry {
+	order := make([]int, len(candidates))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return strings.Count(candidates[order[i]].Path, "/") < strings.Count(candidates[order[j]].Path, "/")
+	})
+
+	keep := make([]bool, len(candidates))
+	var total int64
+	for _, i := range order {
+		if total+candidates[i].Size > budget {
+			stats.SkippedOverBudget++
+			continue
+		}
+		total += candidates[i].Size
+		keep[i] = true
+	}
+
+	selected := make([]TreeEntry, 0, len(candidates))
+	for i, entry := range candidates {
+		if keep[i] {
+			selected = append(selected, entry)
+		}
+	}
+	return selected
+}
+
+type blobResult struct {
+	content []byte
+	cached  bool
+	err     error
+}
+
+// fetchBlobs reads the blobs of entries with opts.Concurrency workers, from the cache when
+// possible. A rate limit aborts the whole fetch; other failures are reported per blob.
+func (c *Client) fetchBlobs(owner, repo string, entries []TreeEntry, opts IngestOptions) ([]blobResult, error) {
+	results := make([]blobResult, len(entries))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < opts.Concurrency && w < len(entries); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = c.fetchBlob(owner, repo, entries[i].SHA, opts.Cache)
+			}
+		}()
+	}
+
+	for i := range entries {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, result := range results {
+		var rateLimitErr *RateLimitError
+		if errors.As(result.err, &rateLimitErr) {
+			return nil, result.err
+		}
+	}
+	return results, nil
+}
+
+func (c *Client) fetchBlob(owner, repo, sha string, cache BlobCache) blobResult {
+	if cache != nil {
+		if content, ok := cache.Get(sha); ok {
+			return blobResult{content: content, cached: true}
+		}
+	}
+
+	content, err := c.GetBlob(owner, repo, sha)
+	if err != nil {
+		return blobResult{err: fmt.Errorf("failed to fetch blob %s: %w", sha, err)}
+	}
+
+	if cache != nil {
+		cache.Set(sha, content)
+	}
+	return blobResult{content: content}
+}
@@ -0,0 +1,187 @@
+package github
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSelectWithinBudget(t *testing.T) {
+	tests := []struct {
+		name        string
+		candidates  []TreeEntry
+		budget      int64
+		want        []string
+		wantSkipped int
+	}{
+		{
+			name:       "everything fits",
+			candidates: []TreeEntry{{Path: "main.go", Size: 40}, {Path: "src/util.go", Size: 60}},
+			budget:     100,
+			want:       []string{"main.go", "src/util.go"},
+		},
+		{
+			name: "shallow files first, in tree order",
+			candidates: []TreeEntry{
+				{Path: "src/a/deep.go", Size: 60},
+				{Path: "README.md", Size: 30},
+				{Path: "src/b.go", Size: 30},
+				{Path: "main.go", Size: 30},
+			},
+			budget:      100,
+			want:        []string{"README.md", "src/b.go", "main.go"},
+			wantSkipped: 1,
+		},
+		{
+			name:        "smaller files still fit after a large one",
+			candidates:  []TreeEntry{{Path: "big.go", Size: 80}, {Path: "small.go", Size: 10}, {Path: "other.go", Size: 20}},
+			budget:      50,
+			want:        []string{"small.go", "other.go"},
+			wantSkipped: 1,
+		},
+		{
+			name:        "nothing fits",
+			candidates:  []TreeEntry{{Path: "big.go", Size: 80}},
+			budget:      50,
+			want:        []string{},
+			wantSkipped: 1,
+		},
+		{
+			name:   "no candidates",
+			budget: 50,
+			want:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stats IngestStats
+			got := []string{}
+			for _, entry := range selectWithinBudget(tt.candidates, tt.budget, &stats) {
+				got = append(got, entry.Path)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("selected %v, want %v", got, tt.want)
+			}
+			if stats.SkippedOverBudget != tt.wantSkipped {
+				t.Errorf("skipped %d files over budget, want %d", stats.SkippedOverBudget, tt.wantSkipped)
+			}
+		})
+	}
+}
+
+type mapBlobCache struct {
+	mu    sync.Mutex
+	blobs map[string][]byte
+}
+
+func (c *mapBlobCache) Get(sha string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	content, ok := c.blobs[sha]
+	return content, ok
+}
+
+func (c *mapBlobCache) Set(sha string, content []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.blobs[sha] = content
+}
+
+// ingestServer serves the recursive tree of acme/api at feature/x#2 and its blobs, whose
+// content is "content of <sha>"; the blob "missing" does not exist
+func ingestServer(t *testing.T, tree string, fetched *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.EscapedPath() == "/repos/acme/api/git/trees/feature/x%232" && r.URL.Query().Get("recursive") == "1":
+			fmt.Fprint(w, tree)
+		case strings.HasPrefix(r.URL.Path, "/repos/acme/api/git/blobs/") && !strings.HasSuffix(r.URL.Path, "/missing"):
+			fetched.Add(1)
+			sha := strings.TrimPrefix(r.URL.Path, "/repos/acme/api/git/blobs/")
+			content := "content of " + sha
+			if sha == "attributes" {
+				content = "gen/** linguist-generated\n"
+			}
+			fmt.Fprintf(w, `{"content": %q, "encoding": "base64"}`, base64.StdEncoding.EncodeToString([]byte(content)))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestIngestRepository(t *testing.T) {
+	tree := `{"sha": "root", "truncated": false, "tree": [
+		{"path": ".gitattributes", "mode": "100644", "type": "blob", "sha": "attributes", "size": 26},
+		{"path": "README.md", "mode": "100644", "type": "blob", "sha": "readme", "size": 10},
+		{"path": "main.go", "mode": "100644", "type": "blob", "sha": "main", "size": 20},
+		{"path": "link.go", "mode": "120000", "type": "blob", "sha": "link", "size": 7},
+		{"path": "logo.png", "mode": "100644", "type": "blob", "sha": "logo", "size": 5},
+		{"path": "assets", "mode": "040000", "type": "tree", "sha": "assets-tree"},
+		{"path": "assets/schema.json", "mode": "100644", "type": "blob", "sha": "schema", "size": 2000},
+		{"path": "gen/api.pb.go", "mode": "100644", "type": "blob", "sha": "generated", "size": 30},
+		{"path": "vendor/lib/lib.go", "mode": "100644", "type": "blob", "sha": "vendored", "size": 30},
+		{"path": "src/util.go", "mode": "100644", "type": "blob", "sha": "util", "size": 50},
+		{"path": "src/broken.go", "mode": "100644", "type": "blob", "sha": "missing", "size": 5},
+		{"path": "src/deep/handler.go", "mode": "100644", "type": "blob", "sha": "handler", "size": 100}
+	]}`
+
+	var fetched atomic.Int32
+	srv := ingestServer(t, tree, &fetched)
+	cache := &mapBlobCache{blobs: map[string][]byte{"util": []byte("cached util")}}
+
+	ingestion, err := testClient(srv).IngestRepository("acme", "api", "feature/x#2", IngestOptions{
+		Concurrency:  2,
+		MaxFileSize:  1000,
+		MaxTotalSize: 100,
+		Cache:        cache,
+	})
+	if err != nil {
+		t.Fatalf("IngestRepository: %v", err)
+	}
+
+	var paths []string
+	for _, file := range ingestion.Files {
+		paths = append(paths, file.Path)
+	}
+	if want := []string{"README.md", "main.go", "src/util.go"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("ingested %v, want %v", paths, want)
+	}
+	if ingestion.Files[2].Content != "cached util" {
+		t.Errorf("cached file content is %q", ingestion.Files[2].Content)
+	}
+
+	want := IngestStats{
+		Entries:           12,
+		Files:             3,
+		TotalSize:         int64(len("content of readme") + len("content of main") + len("cached util")),
+		CachedBlobs:       1,
+		FetchedBlobs:      2,
+		FailedBlobs:       1,
+		SkippedFiltered:   4,
+		SkippedGenerated:  1,
+		SkippedTooLarge:   1,
+		SkippedOverBudget: 1,
+	}
+	if ingestion.Stats != want {
+		t.Errorf("stats are %+v, want %+v", ingestion.Stats, want)
+	}
+
+	// .gitattributes, README.md and main.go
+	if got := fetched.Load(); got != 3 {
+		t.Errorf("fetched %d blobs, want 3", got)
+	}
+	if _, ok := cache.Get("main"); !ok {
+		t.Error("fetched blob was not cached")
+	}
+}
@@ -0,0 +1,109 @@
+package github
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+
+	"github.com/PlatifyX/platifyx-core/pkg/pagination"
+)
+
+const (
+	// DefaultPerPage is the page size of list calls (the GitHub maximum)
+	DefaultPerPage = 100
+	// DefaultMaxPages caps how many pages a list call follows when ListOptions.MaxPages is 0
+	DefaultMaxPages = 10
+)
+
+// ListOptions controls the pagination of list calls
+type ListOptions struct {
+	// PerPage is the page size requested from GitHub (1-100)
+	PerPage int
+	// MaxPages caps how many pages are followed: 0 uses DefaultMaxPages, a negative value follows every page
+	MaxPages int
+	// Cursor resumes a list call where a previous one stopped (ListPage.NextCursor)
+	Cursor string
+}
+
+// ListPage tells where a list call stopped
+type ListPage struct {
+	// NextCursor resumes the listing (ListOptions.Cursor) when the page cap stopped it before
+	// the last page; empty when every page was read
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// Truncated reports whether the page cap stopped the listing before the last page
+func (p ListPage) Truncated() bool {
+	return p.NextCursor != ""
+}
+
+func (o ListOptions) perPage() int {
+	if o.PerPage <= 0 || o.PerPage > DefaultPerPage {
+		return DefaultPerPage
+	}
+	return o.PerPage
+}
+
+func (o ListOptions) maxPages() int {
+	if o.MaxPages == 0 {
+		return DefaultMaxPages
+	}
+	return o.MaxPages
+}
+
+// paginate requests path and follows the Link rel="next" header, handing each page body to
+// decode, until the last page or the page cap of opts. When the cap stops it early, it returns
+// the cursor of the next page: the query string of its URL, so that a cursor can only resume
+// the same listing.
+func (c *Client) paginate(path string, opts ListOptions, decode func(body io.Reader) error) (ListPage, error) {
+	next, err := pagination.WithQueryParam(path, "per_page", strconv.Itoa(opts.perPage()))
+	if err != nil {
+		return ListPage{}, err
+	}
+	if opts.Cursor != "" {
+		if next, err = withCursor(path, opts.Cursor); err != nil {
+			return ListPage{}, err
+		}
+	}
+
+	maxPages := opts.maxPages()
+	for page := 0; next != "" && (maxPages < 0 || page < maxPages); page++ {
+		resp, err := c.doRequest("GET", next, nil)
+		if err != nil {
+			return ListPage{}, err
+		}
+
+		err = decode(resp.Body)
+		link := resp.Header.Get("Link")
+		resp.Body.Close()
+		if err != nil {
+			return ListPage{}, err
+		}
+
+		next = pagination.NextPageURL(link)
+	}
+
+	if next == "" {
+		return ListPage{}, nil
+	}
+	u, err := url.Parse(next)
+	if err != nil {
+		return ListPage{}, err
+	}
+	return ListPage{NextCursor: u.RawQuery}, nil
+}
+
+// withCursor replaces the query of path with a cursor returned by paginate
+func withCursor(path, cursor string) (string, error) {
+	if _, err := url.ParseQuery(cursor); err != nil {
+		return "", fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	u, err := url.Parse(path)
+	if err != nil {
+		return "", err
+	}
+	u.RawQuery = cursor
+	return u.String(), nil
+}
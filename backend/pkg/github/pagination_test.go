@@ -0,0 +1,179 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+)
+
+// repoPages serves /orgs/acme/repos as pages of one repository each, linking to the next page
+func repoPages(t *testing.T, pages int) *httptest.Server {
+	t.Helper()
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/orgs/acme/repos" {
+			http.NotFound(w, r)
+			return
+		}
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		if page < pages {
+			next := fmt.Sprintf("%s/orgs/acme/repos?page=%d&per_page=%s", srv.URL, page+1, r.URL.Query().Get("per_page"))
+			last := fmt.Sprintf("%s/orgs/acme/repos?page=%d&per_page=%s", srv.URL, pages, r.URL.Query().Get("per_page"))
+			w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next", <%s>; rel="last"`, next, last))
+		}
+		fmt.Fprintf(w, `[{"id": %d, "name": "repo-%d"}]`, page, page)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func testClient(srv *httptest.Server, opts ...ClientOption) *Client {
+	opts = append([]ClientOption{WithBaseURL(srv.URL)}, opts...)
+	return NewClient(domain.GitHubConfig{Token: "token", Organization: "acme"}, opts...)
+}
+
+func TestListRepositoriesFollowsNextLink(t *testing.T) {
+	srv := repoPages(t, 3)
+
+	repos, page, err := testClient(srv).ListRepositoriesPage(ListOptions{})
+	if err != nil {
+		t.Fatalf("ListRepositoriesPage: %v", err)
+	}
+	if len(repos) != 3 {
+		t.Fatalf("got %d repositories, want 3", len(repos))
+	}
+	for i, repo := range repos {
+		if want := fmt.Sprintf("repo-%d", i+1); repo.Name != want {
+			t.Errorf("repository %d is %q, want %q", i, repo.Name, want)
+		}
+	}
+	if page.Truncated() {
+		t.Errorf("listing reported truncated with cursor %q", page.NextCursor)
+	}
+}
+
+func TestListRepositoriesPageCapReturnsCursor(t *testing.T) {
+	srv := repoPages(t, 3)
+	client := testClient(srv)
+
+	repos, page, err := client.ListRepositoriesPage(ListOptions{MaxPages: 2})
+	if err != nil {
+		t.Fatalf("ListRepositoriesPage: %v", err)
+	}
+	if len(repos) != 2 {
+		t.Fatalf("got %d repositories, want 2", len(repos))
+	}
+	if !page.Truncated() {
+		t.Fatal("capped listing was not reported truncated")
+	}
+
+	repos, page, err = client.ListRepositoriesPage(ListOptions{MaxPages: 2, Cursor: page.NextCursor})
+	if err != nil {
+		t.Fatalf("resuming from cursor: %v", err)
+	}
+	if len(repos) != 1 || repos[0].Name != "repo-3" {
+		t.Fatalf("resumed listing returned %+v, want repo-3", repos)
+	}
+	if page.Truncated() {
+		t.Errorf("last page reported truncated with cursor %q", page.NextCursor)
+	}
+}
+
+func TestRateLimitPausesUntilReset(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			// Exhausted quota that resets right away
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Unix(), 10))
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		fmt.Fprint(w, `[{"id": 1, "name": "repo-1"}]`)
+	}))
+	defer srv.Close()
+
+	repos, err := testClient(srv, WithMaxRateLimitWait(time.Second)).ListRepositories(ListOptions{})
+	if err != nil {
+		t.Fatalf("ListRepositories: %v", err)
+	}
+	if len(repos) != 1 {
+		t.Fatalf("got %d repositories, want 1", len(repos))
+	}
+	if got := atomic.LoadInt32(&requests); got != 2 {
+		t.Errorf("got %d requests, want 2 (limited then retried)", got)
+	}
+}
+
+func TestRateLimitFailsFastBeyondMaxWait(t *testing.T) {
+	var requests int32
+	reset := time.Now().Add(time.Hour).Unix()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset, 10))
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	client := testClient(srv, WithMaxRateLimitWait(0))
+
+	_, err := client.ListRepositories(ListOptions{})
+	var rateLimitErr *RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		t.Fatalf("got error %v, want a RateLimitError", err)
+	}
+	if rateLimitErr.Reset.Unix() != reset {
+		t.Errorf("reset is %v, want %v", rateLimitErr.Reset.Unix(), reset)
+	}
+
+	// The exhausted quota is remembered: the next call fails without a request
+	if _, err := client.ListRepositories(ListOptions{}); !errors.As(err, &rateLimitErr) {
+		t.Fatalf("second call returned %v, want a RateLimitError", err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("got %d requests, want 1", got)
+	}
+}
+
+func TestNotModifiedReplaysCachedResponse(t *testing.T) {
+	var revalidated int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&revalidated, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `[{"id": 1, "name": "repo-1"}]`)
+	}))
+	defer srv.Close()
+
+	client := testClient(srv)
+	for i := 0; i < 2; i++ {
+		repos, err := client.ListRepositories(ListOptions{})
+		if err != nil {
+			t.Fatalf("call %d: %v", i+1, err)
+		}
+		if len(repos) != 1 || repos[0].Name != "repo-1" {
+			t.Fatalf("call %d returned %+v, want repo-1", i+1, repos)
+		}
+	}
+
+	if got := atomic.LoadInt32(&revalidated); got != 1 {
+		t.Errorf("got %d conditional requests answered with 304, want 1", got)
+	}
+}
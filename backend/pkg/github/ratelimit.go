@@ -0,0 +1,103 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultMaxRateLimitWait is how long a request may pause for the rate limit to reset
+// before failing with a RateLimitError
+const DefaultMaxRateLimitWait = 30 * time.Second
+
+// RateLimit is the quota reported by the X-RateLimit-* headers of the last response
+type RateLimit struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset"`
+}
+
+// RateLimitError is returned when the quota is exhausted (primary or secondary rate limit)
+// and the reset is further away than the client is allowed to wait
+type RateLimitError struct {
+	Reset   time.Time
+	Message string
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("GitHub rate limit exceeded until %s: %s", e.Reset.Format(time.RFC3339), e.Message)
+}
+
+type rateLimiter struct {
+	mu      sync.Mutex
+	current RateLimit
+	known   bool
+	maxWait time.Duration
+}
+
+func (r *rateLimiter) snapshot() (RateLimit, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.current, r.known
+}
+
+func (r *rateLimiter) update(header http.Header) {
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+
+	limit, _ := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	reset, _ := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.current = RateLimit{Limit: limit, Remaining: remaining, Reset: time.Unix(reset, 0)}
+	r.known = true
+}
+
+// wait pauses until the reset when the last response exhausted the quota, or fails fast
+// when the reset is too far away
+func (r *rateLimiter) wait() error {
+	current, known := r.snapshot()
+	if !known || current.Remaining > 0 {
+		return nil
+	}
+
+	delay := time.Until(current.Reset)
+	if delay <= 0 {
+		return nil
+	}
+	if delay > r.maxWait {
+		return &RateLimitError{Reset: current.Reset, Message: "no requests remaining"}
+	}
+
+	time.Sleep(delay)
+	return nil
+}
+
+// limitedUntil tells whether a response was rejected by a rate limit and when it resets.
+// Secondary rate limits come with Retry-After; primary ones with X-RateLimit-Remaining: 0.
+func limitedUntil(resp *http.Response) (time.Time, bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return time.Time{}, false
+	}
+
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		return time.Now().Add(time.Duration(seconds) * time.Second), true
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+		if err == nil {
+			return time.Unix(reset, 0), true
+		}
+		return time.Now().Add(time.Minute), true
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return time.Now().Add(time.Minute), true
+	}
+
+	return time.Time{}, false
+}
@@ -0,0 +1,86 @@
+package github
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// TreeEntry is an entry of a Git tree: a blob (file), a tree (directory) or a commit (submodule)
+type TreeEntry struct {
+	Path string `json:"path"`
+	Mode string `json:"mode"`
+	Type string `json:"type"`
+	SHA  string `json:"sha"`
+	Size int64  `json:"size,omitempty"`
+}
+
+// Tree is a Git tree. Truncated is set when GitHub did not return every entry of a recursive
+// listing (over 100,000 entries or 7 MB).
+type Tree struct {
+	SHA       string      `json:"sha"`
+	Entries   []TreeEntry `json:"tree"`
+	Truncated bool        `json:"truncated"`
+}
+
+// GetTree returns the tree of a ref (branch, tag or commit SHA) or tree SHA. With recursive
+// every entry of the repository comes in a single call.
+func (c *Client) GetTree(owner, repo, ref string, recursive bool) (*Tree, error) {
+	path := fmt.Sprintf("/repos/%s/%s/git/trees/%s", owner, repo, escapeRef(ref))
+	if recursive {
+		path += "?recursive=1"
+	}
+
+	resp, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var tree Tree
+	if err := json.NewDecoder(resp.Body).Decode(&tree); err != nil {
+		return nil, fmt.Errorf("failed to decode tree: %w", err)
+	}
+
+	return &tree, nil
+}
+
+// escapeRef escapes each segment of a ref, keeping the slashes of names like feature/x that
+// GitHub expects as is
+func escapeRef(ref string) string {
+	segments := strings.Split(ref, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// GetBlob returns the content of a blob. Blobs are immutable, so they skip the ETag cache:
+// callers cache them by SHA.
+func (c *Client) GetBlob(owner, repo, sha string) ([]byte, error) {
+	resp, err := c.send("GET", fmt.Sprintf("/repos/%s/%s/git/blobs/%s", owner, repo, sha), nil, false)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var raw struct {
+		Content  string `json:"content"`
+		Encoding string `json:"encoding"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to decode blob: %w", err)
+	}
+
+	if raw.Encoding != "base64" {
+		return []byte(raw.Content), nil
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(raw.Content, "\n", ""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode base64 blob: %w", err)
+	}
+	return decoded, nil
+}
@@ -0,0 +1,464 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+)
+
+// DefaultURL is used when the integration does not point to a self-managed instance
+const DefaultURL = "https://gitlab.com"
+
+type Client struct {
+	baseURL    string
+	webURL     string
+	token      string
+	group      string
+	httpClient *http.Client
+}
+
+// ClientOption customizes a Client (HTTP client for tests or custom transports)
+type ClientOption func(*Client)
+
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// NewClient creates a client for GitLab.com or a self-managed instance. config.URL is the
+// instance URL, with or without the /api/v4 suffix.
+func NewClient(config domain.GitLabConfig, opts ...ClientOption) *Client {
+	webURL := strings.TrimSuffix(strings.TrimSpace(config.URL), "/")
+	if webURL == "" {
+		webURL = DefaultURL
+	}
+	webURL = strings.TrimSuffix(webURL, "/api/v4")
+
+	c := &Client{
+		baseURL: webURL + "/api/v4",
+		webURL:  webURL,
+		token:   config.Token,
+		group:   config.Group,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// RateLimitError is returned when GitLab answers 429 Too Many Requests
+type RateLimitError struct {
+	Reset   time.Time
+	Message string
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("GitLab rate limit exceeded until %s: %s", e.Reset.Format(time.RFC3339), e.Message)
+}
+
+// CloseIdleConnections releases the idle connections of the HTTP client
+func (c *Client) CloseIdleConnections() {
+	c.httpClient.CloseIdleConnections()
+}
+
+// doRequest sends an API request. path is relative to the API URL, or an absolute URL of the
+// same API (Link headers).
+func (c *Client) doRequest(method, path string) (*http.Response, error) {
+	url := path
+	if !strings.HasPrefix(path, "http://") && !strings.HasPrefix(path, "https://") {
+		url = c.baseURL + path
+	} else if !strings.HasPrefix(path, c.baseURL+"/") {
+		// Never send the token to another host
+		return nil, fmt.Errorf("refusing to follow URL outside of the GitLab API: %s", path)
+	}
+
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("PRIVATE-TOKEN", c.token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		defer resp.Body.Close()
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, &RateLimitError{Reset: rateLimitReset(resp.Header), Message: string(bodyBytes)}
+	}
+
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	return resp, nil
+}
+
+func rateLimitReset(header http.Header) time.Time {
+	if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil {
+		return time.Now().Add(time.Duration(seconds) * time.Second)
+	}
+	if reset, err := strconv.ParseInt(header.Get("RateLimit-Reset"), 10, 64); err == nil {
+		return time.Unix(reset, 0)
+	}
+	return time.Now().Add(time.Minute)
+}
+
+// projectPath escapes a project ID or full path ("group/subgroup/project") for the URL
+func projectPath(project string) string {
+	return url.PathEscape(project)
+}
+
+// TestConnection tests the connection to GitLab
+func (c *Client) TestConnection() error {
+	resp, err := c.doRequest("GET", "/user")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// GetAuthenticatedUser returns the user that owns the token
+func (c *Client) GetAuthenticatedUser() (*domain.GitLabUser, error) {
+	resp, err := c.doRequest("GET", "/user")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var raw rawUser
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to decode user: %w", err)
+	}
+
+	user := raw.toDomain()
+	return &user, nil
+}
+
+// ListProjects lists the projects of the configured group (including subgroups), or the
+// projects the token is a member of, following the Link header up to opts.MaxPages
+func (c *Client) ListProjects(opts ListOptions) ([]domain.GitLabProject, error) {
+	path := "/projects?membership=true&order_by=last_activity_at&archived=false"
+	if c.group != "" {
+		path = fmt.Sprintf("/groups/%s/projects?include_subgroups=true&order_by=last_activity_at&archived=false", projectPath(c.group))
+	}
+
+	var rawProjects []rawProject
+	err := c.paginate(path, opts, func(body io.Reader) error {
+		var page []rawProject
+		if err := json.NewDecoder(body).Decode(&page); err != nil {
+			return fmt.Errorf("failed to decode projects: %w", err)
+		}
+		rawProjects = append(rawProjects, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	projects := make([]domain.GitLabProject, 0, len(rawProjects))
+	for _, raw := range rawProjects {
+		projects = append(projects, raw.toDomain())
+	}
+
+	return projects, nil
+}
+
+// GetProject returns a project by ID or full path
+func (c *Client) GetProject(project string) (*domain.GitLabProject, error) {
+	resp, err := c.doRequest("GET", fmt.Sprintf("/projects/%s", projectPath(project)))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var raw rawProject
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to decode project: %w", err)
+	}
+
+	result := raw.toDomain()
+	return &result, nil
+}
+
+// ListCommits lists commits of a project, optionally on a ref
+func (c *Client) ListCommits(project, ref string, opts ListOptions) ([]domain.GitLabCommit, error) {
+	path := fmt.Sprintf("/projects/%s/repository/commits", projectPath(project))
+	if ref != "" {
+		path = fmt.Sprintf("%s?ref_name=%s", path, url.QueryEscape(ref))
+	}
+
+	var rawCommits []rawCommit
+	err := c.paginate(path, opts, func(body io.Reader) error {
+		var page []rawCommit
+		if err := json.NewDecoder(body).Decode(&page); err != nil {
+			return fmt.Errorf("failed to decode commits: %w", err)
+		}
+		rawCommits = append(rawCommits, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	commits := make([]domain.GitLabCommit, 0, len(rawCommits))
+	for _, raw := range rawCommits {
+		commits = append(commits, raw.toDomain())
+	}
+
+	return commits, nil
+}
+
+// ListMergeRequests lists merge requests of a project. state is opened, closed, merged or
+// all; "open" is accepted for opened.
+func (c *Client) ListMergeRequests(project, state string, opts ListOptions) ([]domain.GitLabMergeRequest, error) {
+	path := fmt.Sprintf("/projects/%s/merge_requests?state=%s", projectPath(project), listState(state))
+	return c.listMergeRequests(path, opts)
+}
+
+// ListGroupMergeRequests lists the merge requests of the configured group, or the ones
+// assigned to the token owner without a group
+func (c *Client) ListGroupMergeRequests(state string, opts ListOptions) ([]domain.GitLabMergeRequest, error) {
+	path := fmt.Sprintf("/merge_requests?scope=assigned_to_me&state=%s", listState(state))
+	if c.group != "" {
+		path = fmt.Sprintf("/groups/%s/merge_requests?state=%s", projectPath(c.group), listState(state))
+	}
+	return c.listMergeRequests(path, opts)
+}
+
+func (c *Client) listMergeRequests(path string, opts ListOptions) ([]domain.GitLabMergeRequest, error) {
+	var rawMRs []rawMergeRequest
+	err := c.paginate(path, opts, func(body io.Reader) error {
+		var page []rawMergeRequest
+		if err := json.NewDecoder(body).Decode(&page); err != nil {
+			return fmt.Errorf("failed to decode merge requests: %w", err)
+		}
+		rawMRs = append(rawMRs, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mrs := make([]domain.GitLabMergeRequest, 0, len(rawMRs))
+	for _, raw := range rawMRs {
+		mrs = append(mrs, raw.toDomain())
+	}
+
+	return mrs, nil
+}
+
+// ListIssues lists issues of a project. state is opened, closed or all; "open" is accepted
+// for opened.
+func (c *Client) ListIssues(project, state string, opts ListOptions) ([]domain.GitLabIssue, error) {
+	path := fmt.Sprintf("/projects/%s/issues?state=%s", projectPath(project), listState(state))
+	return c.listIssues(path, opts)
+}
+
+// ListGroupIssues lists the issues of the configured group, or the ones assigned to the
+// token owner without a group
+func (c *Client) ListGroupIssues(state string, opts ListOptions) ([]domain.GitLabIssue, error) {
+	path := fmt.Sprintf("/issues?scope=assigned_to_me&state=%s", listState(state))
+	if c.group != "" {
+		path = fmt.Sprintf("/groups/%s/issues?state=%s", projectPath(c.group), listState(state))
+	}
+	return c.listIssues(path, opts)
+}
+
+func (c *Client) listIssues(path string, opts ListOptions) ([]domain.GitLabIssue, error) {
+	var rawIssues []rawIssue
+	err := c.paginate(path, opts, func(body io.Reader) error {
+		var page []rawIssue
+		if err := json.NewDecoder(body).Decode(&page); err != nil {
+			return fmt.Errorf("failed to decode issues: %w", err)
+		}
+		rawIssues = append(rawIssues, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	issues := make([]domain.GitLabIssue, 0, len(rawIssues))
+	for _, raw := range rawIssues {
+		issues = append(issues, raw.toDomain())
+	}
+
+	return issues, nil
+}
+
+// ListBranches lists branches of a project
+func (c *Client) ListBranches(project string, opts ListOptions) ([]domain.GitLabBranch, error) {
+	var rawBranches []rawBranch
+	err := c.paginate(fmt.Sprintf("/projects/%s/repository/branches", projectPath(project)), opts, func(body io.Reader) error {
+		var page []rawBranch
+		if err := json.NewDecoder(body).Decode(&page); err != nil {
+			return fmt.Errorf("failed to decode branches: %w", err)
+		}
+		rawBranches = append(rawBranches, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	branches := make([]domain.GitLabBranch, 0, len(rawBranches))
+	for _, raw := range rawBranches {
+		branches = append(branches, domain.GitLabBranch{
+			Name:      raw.Name,
+			Commit:    raw.Commit.toDomain(),
+			Protected: raw.Protected,
+			Default:   raw.Default,
+			Merged:    raw.Merged,
+			WebURL:    raw.WebURL,
+		})
+	}
+
+	return branches, nil
+}
+
+// ListPipelines lists CI/CD pipelines of a project, newest first
+func (c *Client) ListPipelines(project string, opts ListOptions) ([]domain.GitLabPipeline, error) {
+	var rawPipelines []rawPipeline
+	path := fmt.Sprintf("/projects/%s/pipelines?order_by=id&sort=desc", projectPath(project))
+	err := c.paginate(path, opts, func(body io.Reader) error {
+		var page []rawPipeline
+		if err := json.NewDecoder(body).Decode(&page); err != nil {
+			return fmt.Errorf("failed to decode pipelines: %w", err)
+		}
+		rawPipelines = append(rawPipelines, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pipelines := make([]domain.GitLabPipeline, 0, len(rawPipelines))
+	for _, raw := range rawPipelines {
+		pipelines = append(pipelines, raw.toDomain())
+	}
+
+	return pipelines, nil
+}
+
+// GetGroup returns a group by ID or full path
+func (c *Client) GetGroup(group string) (*domain.GitLabGroup, error) {
+	resp, err := c.doRequest("GET", fmt.Sprintf("/groups/%s?with_projects=false", projectPath(group)))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var raw struct {
+		ID          int64  `json:"id"`
+		Name        string `json:"name"`
+		Path        string `json:"path"`
+		FullPath    string `json:"full_path"`
+		Description string `json:"description"`
+		Visibility  string `json:"visibility"`
+		AvatarURL   string `json:"avatar_url"`
+		WebURL      string `json:"web_url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to decode group: %w", err)
+	}
+
+	return &domain.GitLabGroup{
+		ID:          raw.ID,
+		Name:        raw.Name,
+		Path:        raw.Path,
+		FullPath:    raw.FullPath,
+		Description: raw.Description,
+		Visibility:  raw.Visibility,
+		AvatarURL:   raw.AvatarURL,
+		WebURL:      raw.WebURL,
+	}, nil
+}
+
+// GetFileContent gets the raw content of a file at a ref (branch, tag or commit)
+func (c *Client) GetFileContent(project, filePath, ref string) (string, error) {
+	path := fmt.Sprintf("/projects/%s/repository/files/%s/raw", projectPath(project), url.PathEscape(filePath))
+	if ref != "" {
+		path = fmt.Sprintf("%s?ref=%s", path, url.QueryEscape(ref))
+	}
+
+	resp, err := c.doRequest("GET", path)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file content: %w", err)
+	}
+
+	return string(content), nil
+}
+
+// GetRepositoryURL returns the web URL of a project given its full path
+func (c *Client) GetRepositoryURL(project string) string {
+	return fmt.Sprintf("%s/%s", c.webURL, project)
+}
+
+// TreeEntry is an entry of a repository tree: a blob (file), a tree (directory) or a commit (submodule)
+type TreeEntry struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Path string `json:"path"`
+	Mode string `json:"mode"`
+}
+
+// GetTree lists every entry of the repository tree at a ref, following the Link header up to
+// opts.MaxPages
+func (c *Client) GetTree(project, ref string, opts ListOptions) ([]TreeEntry, error) {
+	path := fmt.Sprintf("/projects/%s/repository/tree?recursive=true", projectPath(project))
+	if ref != "" {
+		path = fmt.Sprintf("%s&ref=%s", path, url.QueryEscape(ref))
+	}
+
+	var entries []TreeEntry
+	err := c.paginate(path, opts, func(body io.Reader) error {
+		var page []TreeEntry
+		if err := json.NewDecoder(body).Decode(&page); err != nil {
+			return fmt.Errorf("failed to decode tree: %w", err)
+		}
+		entries = append(entries, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// listState maps "open" (the GitHub wording used by callers) to GitLab's "opened"
+func listState(state string) string {
+	switch state {
+	case "", "open":
+		return "opened"
+	default:
+		return url.QueryEscape(state)
+	}
+}
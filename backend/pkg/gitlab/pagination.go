@@ -0,0 +1,81 @@
+package gitlab
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/PlatifyX/platifyx-core/pkg/pagination"
+)
+
+const (
+	// DefaultPerPage is the page size of list calls (the GitLab maximum)
+	DefaultPerPage = 100
+	// DefaultMaxPages caps how many pages a list call follows when ListOptions.MaxPages is 0
+	DefaultMaxPages = 10
+)
+
+// ListOptions controls the pagination of list calls
+type ListOptions struct {
+	// PerPage is the page size requested from GitLab (1-100)
+	PerPage int
+	// MaxPages caps how many pages are followed: 0 uses DefaultMaxPages, a negative value follows every page
+	MaxPages int
+}
+
+func (o ListOptions) perPage() int {
+	if o.PerPage <= 0 || o.PerPage > DefaultPerPage {
+		return DefaultPerPage
+	}
+	return o.PerPage
+}
+
+func (o ListOptions) maxPages() int {
+	if o.MaxPages == 0 {
+		return DefaultMaxPages
+	}
+	return o.MaxPages
+}
+
+// paginate requests path and follows the Link rel="next" header (or X-Next-Page when a proxy
+// dropped the Link header), handing each page body to decode, until the last page or the page
+// cap of opts
+func (c *Client) paginate(path string, opts ListOptions, decode func(body io.Reader) error) error {
+	next, err := pagination.WithQueryParam(path, "per_page", strconv.Itoa(opts.perPage()))
+	if err != nil {
+		return err
+	}
+
+	maxPages := opts.maxPages()
+	for page := 0; next != "" && (maxPages < 0 || page < maxPages); page++ {
+		resp, err := c.doRequest("GET", next)
+		if err != nil {
+			return err
+		}
+
+		err = decode(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		if next, err = nextPage(next, resp.Header); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// nextPage returns the URL of the page after current, or "" on the last page
+func nextPage(current string, header http.Header) (string, error) {
+	if next := pagination.NextPageURL(header.Get("Link")); next != "" {
+		return next, nil
+	}
+
+	page := header.Get("X-Next-Page")
+	if page == "" {
+		return "", nil
+	}
+	return pagination.WithQueryParam(current, "page", page)
+}
@@ -0,0 +1,97 @@
+package gitlab
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+)
+
+// projectPages serves /api/v4/projects as pages of one project each, announcing the next page
+// with a Link header, an X-Next-Page header or both
+func projectPages(t *testing.T, pages int, link, nextPage bool) *httptest.Server {
+	t.Helper()
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/projects" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Query().Get("membership") != "true" || r.URL.Query().Get("per_page") == "" {
+			http.Error(w, "query lost: "+r.URL.RawQuery, http.StatusBadRequest)
+			return
+		}
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		if page < pages {
+			if link {
+				next := fmt.Sprintf("%s/api/v4/projects?membership=true&page=%d&per_page=%s", srv.URL, page+1, r.URL.Query().Get("per_page"))
+				w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next))
+			}
+			if nextPage {
+				w.Header().Set("X-Next-Page", strconv.Itoa(page+1))
+			}
+		}
+		fmt.Fprintf(w, `[{"id": %d, "name": "project-%d"}]`, page, page)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestListProjectsFollowsNextPage(t *testing.T) {
+	tests := []struct {
+		name     string
+		link     bool
+		nextPage bool
+		opts     ListOptions
+		want     int
+	}{
+		{name: "Link header", link: true, want: 3},
+		{name: "X-Next-Page header", nextPage: true, want: 3},
+		{name: "both headers", link: true, nextPage: true, want: 3},
+		{name: "no next page header", want: 1},
+		{name: "page cap", link: true, opts: ListOptions{MaxPages: 2}, want: 2},
+		{name: "page cap with X-Next-Page", nextPage: true, opts: ListOptions{MaxPages: 2}, want: 2},
+		{name: "every page", nextPage: true, opts: ListOptions{MaxPages: -1}, want: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := projectPages(t, 3, tt.link, tt.nextPage)
+			client := NewClient(domain.GitLabConfig{URL: srv.URL, Token: "token"})
+
+			projects, err := client.ListProjects(tt.opts)
+			if err != nil {
+				t.Fatalf("ListProjects: %v", err)
+			}
+			if len(projects) != tt.want {
+				t.Fatalf("got %d projects, want %d", len(projects), tt.want)
+			}
+			for i, project := range projects {
+				if want := fmt.Sprintf("project-%d", i+1); project.Name != want {
+					t.Errorf("project %d is %q, want %q", i, project.Name, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPaginateRefusesLinkToAnotherHost(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", `<https://attacker.example/api/v4/projects?page=2>; rel="next"`)
+		fmt.Fprint(w, `[{"id": 1, "name": "project-1"}]`)
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewClient(domain.GitLabConfig{URL: srv.URL, Token: "token"})
+	if _, err := client.ListProjects(ListOptions{}); err == nil {
+		t.Error("followed a Link header to another host")
+	}
+}
@@ -0,0 +1,237 @@
+package gitlab
+
+import (
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+)
+
+// Raw API payloads (snake_case) shared by several endpoints
+
+type rawUser struct {
+	ID        int64  `json:"id"`
+	Username  string `json:"username"`
+	Name      string `json:"name"`
+	State     string `json:"state"`
+	AvatarURL string `json:"avatar_url"`
+	WebURL    string `json:"web_url"`
+}
+
+func (r rawUser) toDomain() domain.GitLabUser {
+	return domain.GitLabUser{
+		ID:        r.ID,
+		Username:  r.Username,
+		Name:      r.Name,
+		State:     r.State,
+		AvatarURL: r.AvatarURL,
+		WebURL:    r.WebURL,
+	}
+}
+
+type rawProject struct {
+	ID                int64     `json:"id"`
+	Name              string    `json:"name"`
+	Path              string    `json:"path"`
+	PathWithNamespace string    `json:"path_with_namespace"`
+	Description       string    `json:"description"`
+	Visibility        string    `json:"visibility"`
+	WebURL            string    `json:"web_url"`
+	HTTPURLToRepo     string    `json:"http_url_to_repo"`
+	SSHURLToRepo      string    `json:"ssh_url_to_repo"`
+	DefaultBranch     string    `json:"default_branch"`
+	StarCount         int       `json:"star_count"`
+	ForksCount        int       `json:"forks_count"`
+	OpenIssuesCount   int       `json:"open_issues_count"`
+	Archived          bool      `json:"archived"`
+	Topics            []string  `json:"topics"`
+	CreatedAt         time.Time `json:"created_at"`
+	LastActivityAt    time.Time `json:"last_activity_at"`
+	Namespace         struct {
+		ID       int64  `json:"id"`
+		Name     string `json:"name"`
+		Path     string `json:"path"`
+		Kind     string `json:"kind"`
+		FullPath string `json:"full_path"`
+	} `json:"namespace"`
+}
+
+func (r rawProject) toDomain() domain.GitLabProject {
+	return domain.GitLabProject{
+		ID:                r.ID,
+		Name:              r.Name,
+		Path:              r.Path,
+		PathWithNamespace: r.PathWithNamespace,
+		Description:       r.Description,
+		Visibility:        r.Visibility,
+		WebURL:            r.WebURL,
+		HTTPURLToRepo:     r.HTTPURLToRepo,
+		SSHURLToRepo:      r.SSHURLToRepo,
+		DefaultBranch:     r.DefaultBranch,
+		StarCount:         r.StarCount,
+		ForksCount:        r.ForksCount,
+		OpenIssuesCount:   r.OpenIssuesCount,
+		Archived:          r.Archived,
+		Topics:            r.Topics,
+		Namespace: domain.GitLabNamespace{
+			ID:       r.Namespace.ID,
+			Name:     r.Namespace.Name,
+			Path:     r.Namespace.Path,
+			Kind:     r.Namespace.Kind,
+			FullPath: r.Namespace.FullPath,
+		},
+		CreatedAt:      r.CreatedAt,
+		LastActivityAt: r.LastActivityAt,
+	}
+}
+
+type rawCommit struct {
+	ID             string    `json:"id"`
+	ShortID        string    `json:"short_id"`
+	Title          string    `json:"title"`
+	Message        string    `json:"message"`
+	AuthorName     string    `json:"author_name"`
+	AuthorEmail    string    `json:"author_email"`
+	AuthoredDate   time.Time `json:"authored_date"`
+	CommitterName  string    `json:"committer_name"`
+	CommitterEmail string    `json:"committer_email"`
+	CommittedDate  time.Time `json:"committed_date"`
+	WebURL         string    `json:"web_url"`
+}
+
+func (r rawCommit) toDomain() domain.GitLabCommit {
+	return domain.GitLabCommit{
+		ID:             r.ID,
+		ShortID:        r.ShortID,
+		Title:          r.Title,
+		Message:        r.Message,
+		AuthorName:     r.AuthorName,
+		AuthorEmail:    r.AuthorEmail,
+		AuthoredDate:   r.AuthoredDate,
+		CommitterName:  r.CommitterName,
+		CommitterEmail: r.CommitterEmail,
+		CommittedDate:  r.CommittedDate,
+		WebURL:         r.WebURL,
+	}
+}
+
+type rawMergeRequest struct {
+	ID           int64      `json:"id"`
+	IID          int64      `json:"iid"`
+	ProjectID    int64      `json:"project_id"`
+	Title        string     `json:"title"`
+	Description  string     `json:"description"`
+	State        string     `json:"state"`
+	Draft        bool       `json:"draft"`
+	SourceBranch string     `json:"source_branch"`
+	TargetBranch string     `json:"target_branch"`
+	Author       rawUser    `json:"author"`
+	Labels       []string   `json:"labels"`
+	WebURL       string     `json:"web_url"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	MergedAt     *time.Time `json:"merged_at"`
+	ClosedAt     *time.Time `json:"closed_at"`
+}
+
+func (r rawMergeRequest) toDomain() domain.GitLabMergeRequest {
+	return domain.GitLabMergeRequest{
+		ID:           r.ID,
+		IID:          r.IID,
+		ProjectID:    r.ProjectID,
+		Title:        r.Title,
+		Description:  r.Description,
+		State:        r.State,
+		Draft:        r.Draft,
+		SourceBranch: r.SourceBranch,
+		TargetBranch: r.TargetBranch,
+		Author:       r.Author.toDomain(),
+		Labels:       r.Labels,
+		WebURL:       r.WebURL,
+		CreatedAt:    r.CreatedAt,
+		UpdatedAt:    r.UpdatedAt,
+		MergedAt:     r.MergedAt,
+		ClosedAt:     r.ClosedAt,
+	}
+}
+
+type rawIssue struct {
+	ID          int64      `json:"id"`
+	IID         int64      `json:"iid"`
+	ProjectID   int64      `json:"project_id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	State       string     `json:"state"`
+	Author      rawUser    `json:"author"`
+	Assignees   []rawUser  `json:"assignees"`
+	Labels      []string   `json:"labels"`
+	WebURL      string     `json:"web_url"`
+	DueDate     string     `json:"due_date"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	ClosedAt    *time.Time `json:"closed_at"`
+}
+
+func (r rawIssue) toDomain() domain.GitLabIssue {
+	assignees := make([]domain.GitLabUser, 0, len(r.Assignees))
+	for _, assignee := range r.Assignees {
+		assignees = append(assignees, assignee.toDomain())
+	}
+
+	return domain.GitLabIssue{
+		ID:          r.ID,
+		IID:         r.IID,
+		ProjectID:   r.ProjectID,
+		Title:       r.Title,
+		Description: r.Description,
+		State:       r.State,
+		Author:      r.Author.toDomain(),
+		Assignees:   assignees,
+		Labels:      r.Labels,
+		WebURL:      r.WebURL,
+		DueDate:     r.DueDate,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+		ClosedAt:    r.ClosedAt,
+	}
+}
+
+type rawBranch struct {
+	Name      string    `json:"name"`
+	Commit    rawCommit `json:"commit"`
+	Protected bool      `json:"protected"`
+	Default   bool      `json:"default"`
+	Merged    bool      `json:"merged"`
+	WebURL    string    `json:"web_url"`
+}
+
+type rawPipeline struct {
+	ID         int64      `json:"id"`
+	IID        int64      `json:"iid"`
+	ProjectID  int64      `json:"project_id"`
+	Status     string     `json:"status"`
+	Source     string     `json:"source"`
+	Ref        string     `json:"ref"`
+	SHA        string     `json:"sha"`
+	WebURL     string     `json:"web_url"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	StartedAt  *time.Time `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+}
+
+func (r rawPipeline) toDomain() domain.GitLabPipeline {
+	return domain.GitLabPipeline{
+		ID:         r.ID,
+		IID:        r.IID,
+		ProjectID:  r.ProjectID,
+		Status:     r.Status,
+		Source:     r.Source,
+		Ref:        r.Ref,
+		SHA:        r.SHA,
+		WebURL:     r.WebURL,
+		CreatedAt:  r.CreatedAt,
+		UpdatedAt:  r.UpdatedAt,
+		StartedAt:  r.StartedAt,
+		FinishedAt: r.FinishedAt,
+	}
+}
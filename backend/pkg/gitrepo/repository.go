@@ -0,0 +1,471 @@
+package gitrepo
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	localCommandTimeout  = 30 * time.Second
+	remoteCommandTimeout = 2 * time.Minute
+
+	// Committer of every commit; the author is the user who made the change
+	committerName  = "PlatifyX"
+	committerEmail = "techdocs@platifyx.local"
+
+	// Separators of the fields and records of git log output
+	fieldSeparator  = "\x1f"
+	recordSeparator = "\x1e"
+)
+
+var (
+	// ErrRevisionNotFound is returned when a revision (or the file at that revision) does not exist
+	ErrRevisionNotFound = errors.New("revision not found")
+	// ErrNothingToCommit is returned when a commit would not change anything
+	ErrNothingToCommit = errors.New("nothing to commit")
+
+	// Revisions are abbreviated or full commit hashes, never options or ref expressions
+	revisionPattern = regexp.MustCompile(`^[0-9a-f]{4,40}$`)
+)
+
+// Options configures a repository. RemoteURL is optional: without it changes are only committed
+// locally. Token, when set, authenticates HTTPS pushes and fetches (GitHub, GitLab and Azure
+// DevOps accept a token as password). It is handed to git through the environment, never on the
+// command line nor in the repository config.
+type Options struct {
+	RemoteURL string
+	Branch    string
+	Token     string
+}
+
+// Signature identifies the author of a commit
+type Signature struct {
+	Name  string
+	Email string
+}
+
+// Commit is an entry of the history of a file
+type Commit struct {
+	Hash        string
+	AuthorName  string
+	AuthorEmail string
+	Message     string
+	Date        time.Time
+}
+
+// SyncStatus is the outcome of the last push to the remote
+type SyncStatus struct {
+	LastPushAt    time.Time
+	LastAttemptAt time.Time
+	LastError     string
+	// Diverged is set when the local commits could not be rebased on the remote branch: they stay
+	// local until the conflict is resolved in the repository
+	Diverged bool
+}
+
+// Repository is a working tree driven through the git command line. Local operations are
+// serialized: every change is committed before the next one starts. Pushes do not hold the
+// working tree while they talk to the remote.
+type Repository struct {
+	dir     string
+	options Options
+	mu      sync.Mutex
+
+	// pushMu serializes pushes; statusMu guards status
+	pushMu   sync.Mutex
+	statusMu sync.Mutex
+	status   SyncStatus
+}
+
+// Open opens the repository at dir, initializing it when needed. With a remote, the branch of the
+// remote is checked out first (so documents survive restarts) and local files it does not have
+// are committed on top.
+func Open(dir string, options Options) (*Repository, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, fmt.Errorf("git not available: %w", err)
+	}
+	if options.Branch == "" {
+		options.Branch = "main"
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create repository directory: %w", err)
+	}
+
+	r := &Repository{dir: dir, options: options}
+
+	if _, err := os.Stat(filepath.Join(dir, ".git")); os.IsNotExist(err) {
+		if _, err := r.run(localCommandTimeout, "init", "--quiet", "--initial-branch="+options.Branch); err != nil {
+			return nil, err
+		}
+	}
+
+	if options.RemoteURL != "" {
+		if err := r.fetch(); err != nil {
+			return nil, err
+		}
+		if r.hasCommits() {
+			if _, err := r.run(localCommandTimeout, "merge", "--ff-only", "--quiet", r.remoteRef()); err != nil {
+				return nil, fmt.Errorf("local history diverged from %s: %w", options.Branch, err)
+			}
+		} else if r.remoteRefExists() {
+			// Remote versions win over the files already on disk
+			if _, err := r.run(localCommandTimeout, "checkout", "--quiet", "-f", "-B", options.Branch, r.remoteRef()); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Files changed outside the repository (or present before it existed) become a first commit
+	if _, err := r.commit(".", "Import existing documents", Signature{Name: committerName, Email: committerEmail}); err != nil && !errors.Is(err, ErrNothingToCommit) {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Dir returns the working tree directory
+func (r *Repository) Dir() string {
+	return r.dir
+}
+
+// HasRemote tells whether changes are pushed to a remote
+func (r *Repository) HasRemote() bool {
+	return r.options.RemoteURL != ""
+}
+
+// CommitPath commits the current state of a path of the working tree (a written or a removed file)
+// and returns the hash of the commit
+func (r *Repository) CommitPath(path, message string, author Signature) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.commit(path, message, author)
+}
+
+// Update applies change to the working tree (writing or removing the file at path) and commits
+// path, with no other operation in between
+func (r *Repository) Update(path, message string, author Signature, change func() error) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := change(); err != nil {
+		return "", err
+	}
+	return r.commit(path, message, author)
+}
+
+// SyncStatus returns the outcome of the last push
+func (r *Repository) SyncStatus() SyncStatus {
+	r.statusMu.Lock()
+	defer r.statusMu.Unlock()
+
+	return r.status
+}
+
+// Log returns the commits that changed path, most recent first
+func (r *Repository) Log(path string, limit int) ([]Commit, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.hasCommits() {
+		return []Commit{}, nil
+	}
+
+	args := []string{
+		"log",
+		"--format=%H" + fieldSeparator + "%an" + fieldSeparator + "%ae" + fieldSeparator + "%aI" + fieldSeparator + "%s" + recordSeparator,
+	}
+	if limit > 0 {
+		args = append(args, "-n", strconv.Itoa(limit))
+	}
+	args = append(args, "--", path)
+
+	out, err := r.run(localCommandTimeout, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	commits := []Commit{}
+	for _, record := range strings.Split(out, recordSeparator) {
+		fields := strings.Split(strings.TrimSpace(record), fieldSeparator)
+		if len(fields) != 5 {
+			continue
+		}
+		date, _ := time.Parse(time.RFC3339, fields[3])
+		commits = append(commits, Commit{
+			Hash:        fields[0],
+			AuthorName:  fields[1],
+			AuthorEmail: fields[2],
+			Date:        date,
+			Message:     fields[4],
+		})
+	}
+
+	return commits, nil
+}
+
+// Show returns the content of path at a revision
+func (r *Repository) Show(revision, path string) ([]byte, error) {
+	if !revisionPattern.MatchString(revision) {
+		return nil, ErrRevisionNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out, err := r.run(localCommandTimeout, "show", revision+":"+filepath.ToSlash(path))
+	if err != nil {
+		return nil, revisionError(err)
+	}
+	return []byte(out), nil
+}
+
+// Diff returns the unified diff of path between two revisions; an empty to means the latest commit
+func (r *Repository) Diff(from, to, path string) (string, error) {
+	if !revisionPattern.MatchString(from) || (to != "" && !revisionPattern.MatchString(to)) {
+		return "", ErrRevisionNotFound
+	}
+	if to == "" {
+		to = "HEAD"
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out, err := r.run(localCommandTimeout, "diff", "--no-color", from, to, "--", path)
+	if err != nil {
+		return "", revisionError(err)
+	}
+	return out, nil
+}
+
+// Push sends the commits to the remote, rebasing on changes pushed there meanwhile. The working
+// tree is only locked to read HEAD and to rebase: the pushes and the fetch send a snapshot of it.
+func (r *Repository) Push() error {
+	if r.options.RemoteURL == "" {
+		return nil
+	}
+
+	r.pushMu.Lock()
+	defer r.pushMu.Unlock()
+
+	err := r.push()
+	r.recordPush(err)
+	return err
+}
+
+func (r *Repository) push() error {
+	head, err := r.head()
+	if err != nil {
+		return err
+	}
+	if head == "" {
+		return nil
+	}
+	if err := r.pushRevision(head); err == nil {
+		return nil
+	}
+
+	// Rejected (most likely non fast-forward): replay the local commits on the remote branch once
+	if err := r.fetch(); err != nil {
+		return err
+	}
+	if head, err = r.rebase(); err != nil {
+		return err
+	}
+	return r.pushRevision(head)
+}
+
+// head returns the commit checked out, or "" when there is none yet
+func (r *Repository) head() (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.hasCommits() {
+		return "", nil
+	}
+	hash, err := r.run(localCommandTimeout, "rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(hash), nil
+}
+
+// rebase replays the local commits on the fetched remote branch and returns the new HEAD. A
+// conflicting rebase is aborted, leaving the local history as it was.
+func (r *Repository) rebase() (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.run(localCommandTimeout, "rebase", "--quiet", r.remoteRef()); err != nil {
+		r.run(localCommandTimeout, "rebase", "--abort")
+		return "", &DivergedError{Branch: r.options.Branch, err: err}
+	}
+
+	hash, err := r.run(localCommandTimeout, "rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(hash), nil
+}
+
+func (r *Repository) pushRevision(revision string) error {
+	_, err := r.runEnv(remoteCommandTimeout, r.authEnv(), "push", "--quiet", r.options.RemoteURL, revision+":refs/heads/"+r.options.Branch)
+	return err
+}
+
+func (r *Repository) recordPush(err error) {
+	r.statusMu.Lock()
+	defer r.statusMu.Unlock()
+
+	r.status.LastAttemptAt = time.Now()
+	if err != nil {
+		var diverged *DivergedError
+		r.status.LastError = err.Error()
+		r.status.Diverged = errors.As(err, &diverged)
+		return
+	}
+	r.status.LastPushAt = r.status.LastAttemptAt
+	r.status.LastError = ""
+	r.status.Diverged = false
+}
+
+// DivergedError is returned by Push when the local commits conflict with the remote branch
+type DivergedError struct {
+	Branch string
+	err    error
+}
+
+func (e *DivergedError) Error() string {
+	return fmt.Sprintf("failed to rebase on %s: %v", e.Branch, e.err)
+}
+
+func (e *DivergedError) Unwrap() error {
+	return e.err
+}
+
+func (r *Repository) commit(path, message string, author Signature) (string, error) {
+	if _, err := r.run(localCommandTimeout, "add", "--all", "--", path); err != nil {
+		return "", err
+	}
+
+	// Exit status 1 means there are staged changes
+	if _, err := r.run(localCommandTimeout, "diff", "--cached", "--quiet", "--", path); err == nil {
+		return "", ErrNothingToCommit
+	}
+
+	if author.Name == "" {
+		author = Signature{Name: committerName, Email: committerEmail}
+	}
+	if _, err := r.runEnv(localCommandTimeout, []string{
+		"GIT_AUTHOR_NAME=" + author.Name,
+		"GIT_AUTHOR_EMAIL=" + author.Email,
+	}, "commit", "--quiet", "--no-verify", "-m", message, "--", path); err != nil {
+		return "", err
+	}
+
+	hash, err := r.run(localCommandTimeout, "rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(hash), nil
+}
+
+func (r *Repository) fetch() error {
+	_, err := r.runEnv(remoteCommandTimeout, r.authEnv(), "fetch", "--quiet", r.options.RemoteURL, "+refs/heads/"+r.options.Branch+":"+r.remoteRef())
+	if err != nil && strings.Contains(err.Error(), "couldn't find remote ref") {
+		// Empty remote: the first push creates the branch
+		return nil
+	}
+	return err
+}
+
+func (r *Repository) remoteRef() string {
+	return "refs/remotes/origin/" + r.options.Branch
+}
+
+func (r *Repository) remoteRefExists() bool {
+	_, err := r.run(localCommandTimeout, "rev-parse", "--verify", "--quiet", r.remoteRef())
+	return err == nil
+}
+
+func (r *Repository) hasCommits() bool {
+	_, err := r.run(localCommandTimeout, "rev-parse", "--verify", "--quiet", "HEAD")
+	return err == nil
+}
+
+// authEnv passes the token to git as an HTTP Authorization header set through environment
+// config (GIT_CONFIG_COUNT), so that it appears neither in the arguments nor in .git/config
+func (r *Repository) authEnv() []string {
+	if r.options.Token == "" {
+		return nil
+	}
+
+	u, err := url.Parse(r.options.RemoteURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+		return nil
+	}
+	credentials := base64.StdEncoding.EncodeToString([]byte("x-access-token:" + r.options.Token))
+	return []string{
+		"GIT_CONFIG_COUNT=1",
+		"GIT_CONFIG_KEY_0=http.extraHeader",
+		"GIT_CONFIG_VALUE_0=Authorization: Basic " + credentials,
+	}
+}
+
+func (r *Repository) run(timeout time.Duration, args ...string) (string, error) {
+	return r.runEnv(timeout, nil, args...)
+}
+
+func (r *Repository) runEnv(timeout time.Duration, env []string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = r.dir
+	cmd.Env = append(os.Environ(),
+		"GIT_TERMINAL_PROMPT=0",
+		"GIT_COMMITTER_NAME="+committerName,
+		"GIT_COMMITTER_EMAIL="+committerEmail,
+		"GIT_AUTHOR_NAME="+committerName,
+		"GIT_AUTHOR_EMAIL="+committerEmail,
+	)
+	cmd.Env = append(cmd.Env, env...)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if r.options.Token != "" {
+			message = strings.ReplaceAll(message, r.options.Token, "***")
+		}
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, message)
+	}
+
+	return stdout.String(), nil
+}
+
+// revisionError maps the errors of an unknown revision or path to ErrRevisionNotFound
+func revisionError(err error) error {
+	message := err.Error()
+	for _, marker := range []string{"unknown revision", "bad revision", "invalid object name", "does not exist", "exists on disk, but not in"} {
+		if strings.Contains(message, marker) {
+			return ErrRevisionNotFound
+		}
+	}
+	return err
+}
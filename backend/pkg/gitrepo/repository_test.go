@@ -0,0 +1,213 @@
+package gitrepo
+
+import (
+	"encoding/base64"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func requireGit(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+}
+
+// bareRemote creates an empty bare repository to push to
+func bareRemote(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	if out, err := exec.Command("git", "init", "--quiet", "--bare", "--initial-branch=main", dir).CombinedOutput(); err != nil {
+		t.Fatalf("git init --bare: %v: %s", err, out)
+	}
+	return dir
+}
+
+// remoteFile returns the content of path on the main branch of a bare remote
+func remoteFile(t *testing.T, remote, path string) string {
+	t.Helper()
+
+	out, err := exec.Command("git", "--git-dir", remote, "show", "main:"+path).CombinedOutput()
+	if err != nil {
+		t.Fatalf("reading %s from the remote: %v: %s", path, err, out)
+	}
+	return string(out)
+}
+
+func writeFile(t *testing.T, r *Repository, path, content, message string) string {
+	t.Helper()
+
+	hash, err := r.Update(path, message, Signature{Name: "Ada", Email: "ada@example.com"}, func() error {
+		return os.WriteFile(filepath.Join(r.Dir(), path), []byte(content), 0644)
+	})
+	if err != nil {
+		t.Fatalf("committing %s: %v", path, err)
+	}
+	return hash
+}
+
+func TestCommitHistoryAndDiff(t *testing.T) {
+	requireGit(t)
+
+	r, err := Open(t.TempDir(), Options{})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	first := writeFile(t, r, "doc.md", "first line\n", "Create doc")
+	second := writeFile(t, r, "doc.md", "second line\n", "Update doc")
+
+	history, err := r.Log("doc.md", 0)
+	if err != nil {
+		t.Fatalf("Log: %v", err)
+	}
+	if len(history) != 2 || history[0].Hash != second || history[1].Hash != first {
+		t.Fatalf("history is %+v, want the update then the creation", history)
+	}
+	if history[0].AuthorEmail != "ada@example.com" || history[0].Message != "Update doc" {
+		t.Errorf("latest commit is %+v, want Ada's update", history[0])
+	}
+
+	content, err := r.Show(first, "doc.md")
+	if err != nil {
+		t.Fatalf("Show: %v", err)
+	}
+	if string(content) != "first line\n" {
+		t.Errorf("first revision is %q", content)
+	}
+
+	diff, err := r.Diff(first, "", "doc.md")
+	if err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+	if !strings.Contains(diff, "-first line") || !strings.Contains(diff, "+second line") {
+		t.Errorf("diff does not show the change:\n%s", diff)
+	}
+
+	if _, err := r.Show("0000000", "doc.md"); !errors.Is(err, ErrRevisionNotFound) {
+		t.Errorf("unknown revision returned %v, want ErrRevisionNotFound", err)
+	}
+	if _, err := r.Show("HEAD~1", "doc.md"); !errors.Is(err, ErrRevisionNotFound) {
+		t.Errorf("ref expression returned %v, want ErrRevisionNotFound", err)
+	}
+
+	_, err = r.Update("doc.md", "Unchanged", Signature{}, func() error {
+		return os.WriteFile(filepath.Join(r.Dir(), "doc.md"), []byte("second line\n"), 0644)
+	})
+	if !errors.Is(err, ErrNothingToCommit) {
+		t.Errorf("unchanged document returned %v, want ErrNothingToCommit", err)
+	}
+}
+
+func TestPushRebasesOnRemoteChanges(t *testing.T) {
+	requireGit(t)
+	remote := bareRemote(t)
+	options := Options{RemoteURL: remote, Branch: "main"}
+
+	alice, err := Open(t.TempDir(), options)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	writeFile(t, alice, "a.md", "a\n", "Add a")
+	if err := alice.Push(); err != nil {
+		t.Fatalf("first push: %v", err)
+	}
+
+	// A second replica starts from the remote and pushes first
+	bob, err := Open(t.TempDir(), options)
+	if err != nil {
+		t.Fatalf("Open second replica: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(bob.Dir(), "a.md")); err != nil {
+		t.Fatalf("second replica did not check out the remote: %v", err)
+	}
+	writeFile(t, bob, "b.md", "b\n", "Add b")
+	if err := bob.Push(); err != nil {
+		t.Fatalf("second replica push: %v", err)
+	}
+
+	// Rejected as non fast-forward, then rebased on b and pushed
+	writeFile(t, alice, "c.md", "c\n", "Add c")
+	if err := alice.Push(); err != nil {
+		t.Fatalf("push after the remote moved: %v", err)
+	}
+
+	for path, want := range map[string]string{"a.md": "a\n", "b.md": "b\n", "c.md": "c\n"} {
+		if got := remoteFile(t, remote, path); got != want {
+			t.Errorf("remote %s is %q, want %q", path, got, want)
+		}
+	}
+
+	status := alice.SyncStatus()
+	if status.LastPushAt.IsZero() || status.LastError != "" || status.Diverged {
+		t.Errorf("status after a successful push is %+v", status)
+	}
+}
+
+func TestPushReportsDivergence(t *testing.T) {
+	requireGit(t)
+	remote := bareRemote(t)
+	options := Options{RemoteURL: remote, Branch: "main"}
+
+	alice, err := Open(t.TempDir(), options)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	writeFile(t, alice, "doc.md", "base\n", "Create doc")
+	if err := alice.Push(); err != nil {
+		t.Fatalf("first push: %v", err)
+	}
+
+	bob, err := Open(t.TempDir(), options)
+	if err != nil {
+		t.Fatalf("Open second replica: %v", err)
+	}
+	writeFile(t, bob, "doc.md", "bob\n", "Bob's version")
+	if err := bob.Push(); err != nil {
+		t.Fatalf("second replica push: %v", err)
+	}
+
+	local := writeFile(t, alice, "doc.md", "alice\n", "Alice's version")
+	err = alice.Push()
+	var diverged *DivergedError
+	if !errors.As(err, &diverged) {
+		t.Fatalf("conflicting push returned %v, want a DivergedError", err)
+	}
+
+	status := alice.SyncStatus()
+	if !status.Diverged || status.LastError == "" {
+		t.Errorf("status after a conflict is %+v, want diverged with an error", status)
+	}
+
+	// The aborted rebase leaves the local history and working tree untouched
+	history, err := alice.Log("doc.md", 1)
+	if err != nil || len(history) != 1 || history[0].Hash != local {
+		t.Errorf("local history is %+v (%v), want the unpushed commit %s", history, err, local)
+	}
+	if content, _ := os.ReadFile(filepath.Join(alice.Dir(), "doc.md")); string(content) != "alice\n" {
+		t.Errorf("working tree holds %q after the aborted rebase", content)
+	}
+	if got := remoteFile(t, remote, "doc.md"); got != "bob\n" {
+		t.Errorf("remote holds %q, want bob's version", got)
+	}
+}
+
+func TestTokenIsPassedThroughTheEnvironment(t *testing.T) {
+	r := &Repository{options: Options{RemoteURL: "https://git.example.com/docs.git", Token: "s3cr3t"}}
+
+	header := "Authorization: Basic " + base64.StdEncoding.EncodeToString([]byte("x-access-token:s3cr3t"))
+	env := strings.Join(r.authEnv(), "\n")
+	if !strings.Contains(env, "GIT_CONFIG_KEY_0=http.extraHeader") || !strings.Contains(env, "GIT_CONFIG_VALUE_0="+header) {
+		t.Errorf("environment does not set the authorization header:\n%s", env)
+	}
+
+	r.options.RemoteURL = "git@git.example.com:docs.git"
+	if env := r.authEnv(); env != nil {
+		t.Errorf("SSH remote got HTTP credentials: %v", env)
+	}
+}
@@ -17,7 +17,7 @@ import (
 )
 
 type Client struct {
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 	config    *rest.Config
 	context   string
 }
@@ -50,6 +50,14 @@ func NewClient(cfg domain.KubernetesConfig) (*Client, error) {
 	}, nil
 }
 
+// NewClientFromClientset wraps an existing clientset, such as the fake clientset from client-go
+func NewClientFromClientset(clientset kubernetes.Interface, context string) *Client {
+	return &Client{
+		clientset: clientset,
+		context:   context,
+	}
+}
+
 func (c *Client) GetClusterInfo() (*domain.KubernetesCluster, error) {
 	ctx := context.Background()
 
@@ -306,7 +314,7 @@ func (c *Client) ListNodes() ([]domain.KubernetesNode, error) {
 }
 
 // GetClientset returns the Kubernetes clientset
-func (c *Client) GetClientset() *kubernetes.Clientset {
+func (c *Client) GetClientset() kubernetes.Interface {
 	return c.clientset
 }
 
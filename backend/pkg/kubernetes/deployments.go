@@ -0,0 +1,211 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/PlatifyX/platifyx-core/internal/domain"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// RevisionAnnotation is set by the deployment controller on deployments and their ReplicaSets
+	RevisionAnnotation = "deployment.kubernetes.io/revision"
+	// RestartedAtAnnotation is the pod template annotation used by `kubectl rollout restart`
+	RestartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+)
+
+// GetDeploymentState returns the replicas, revision and pod template details of a deployment
+func (c *Client) GetDeploymentState(namespace, name string) (*domain.KubernetesDeploymentState, error) {
+	deployment, err := c.clientset.AppsV1().Deployments(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployment %s/%s: %w", namespace, name, err)
+	}
+
+	return deploymentState(deployment), nil
+}
+
+// ScaleDeployment patches the number of replicas of a deployment; 0 scales it down entirely
+func (c *Client) ScaleDeployment(namespace, name string, replicas int32) error {
+	if replicas < 0 {
+		return fmt.Errorf("invalid replica count %d for deployment %s/%s", replicas, namespace, name)
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"replicas": replicas,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.clientset.AppsV1().Deployments(namespace).Patch(context.Background(), name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to scale deployment %s/%s: %w", namespace, name, err)
+	}
+
+	return nil
+}
+
+// RestartDeployment triggers a rolling restart the same way `kubectl rollout restart` does
+// and returns the value written to the restart annotation
+func (c *Client) RestartDeployment(namespace, name string) (string, error) {
+	restartedAt := time.Now().UTC().Format(time.RFC3339)
+	if err := c.SetRestartedAt(namespace, name, restartedAt); err != nil {
+		return "", err
+	}
+	return restartedAt, nil
+}
+
+// SetRestartedAt sets the restart annotation of the pod template. An empty value removes it,
+// which restores the template of a deployment that had never been restarted.
+func (c *Client) SetRestartedAt(namespace, name, restartedAt string) error {
+	var value interface{}
+	if restartedAt != "" {
+		value = restartedAt
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]interface{}{
+						RestartedAtAnnotation: value,
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.clientset.AppsV1().Deployments(namespace).Patch(context.Background(), name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to restart deployment %s/%s: %w", namespace, name, err)
+	}
+
+	return nil
+}
+
+// RollbackDeployment replaces the pod template of a deployment with the one of a previous
+// ReplicaSet, like `kubectl rollout undo`. When toRevision is 0 the revision right before the
+// current one is used. It returns the revision that was rolled back to.
+func (c *Client) RollbackDeployment(namespace, name string, toRevision int64) (int64, error) {
+	ctx := context.Background()
+
+	deployment, err := c.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get deployment %s/%s: %w", namespace, name, err)
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return 0, fmt.Errorf("invalid selector on deployment %s/%s: %w", namespace, name, err)
+	}
+
+	replicaSets, err := c.clientset.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list replicasets of deployment %s/%s: %w", namespace, name, err)
+	}
+
+	current := revisionOf(deployment.Annotations)
+	if toRevision != 0 && toRevision == current {
+		return 0, fmt.Errorf("deployment %s/%s is already at revision %d", namespace, name, current)
+	}
+
+	var target *appsv1.ReplicaSet
+	var targetRevision int64
+	for i := range replicaSets.Items {
+		replicaSet := &replicaSets.Items[i]
+		if !metav1.IsControlledBy(replicaSet, deployment) {
+			continue
+		}
+
+		revision := revisionOf(replicaSet.Annotations)
+		if revision == 0 || revision == current {
+			continue
+		}
+
+		if toRevision != 0 {
+			if revision == toRevision {
+				target = replicaSet
+				targetRevision = revision
+				break
+			}
+			continue
+		}
+
+		if revision < current && revision > targetRevision {
+			target = replicaSet
+			targetRevision = revision
+		}
+	}
+
+	if target == nil {
+		if toRevision != 0 {
+			return 0, fmt.Errorf("revision %d not found for deployment %s/%s", toRevision, namespace, name)
+		}
+		return 0, fmt.Errorf("no previous revision found for deployment %s/%s", namespace, name)
+	}
+
+	template := target.Spec.Template.DeepCopy()
+	delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+
+	patch, err := json.Marshal([]map[string]interface{}{
+		{
+			"op":    "replace",
+			"path":  "/spec/template",
+			"value": template,
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = c.clientset.AppsV1().Deployments(namespace).Patch(ctx, name, types.JSONPatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to rollback deployment %s/%s: %w", namespace, name, err)
+	}
+
+	return targetRevision, nil
+}
+
+func deploymentState(deployment *appsv1.Deployment) *domain.KubernetesDeploymentState {
+	state := &domain.KubernetesDeploymentState{
+		Name:        deployment.Name,
+		Namespace:   deployment.Namespace,
+		Revision:    revisionOf(deployment.Annotations),
+		RestartedAt: deployment.Spec.Template.Annotations[RestartedAtAnnotation],
+		Labels:      deployment.Labels,
+		Annotations: deployment.Annotations,
+	}
+
+	if deployment.Spec.Replicas != nil {
+		state.Replicas = *deployment.Spec.Replicas
+	} else {
+		state.Replicas = 1
+	}
+
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		state.Images = append(state.Images, container.Image)
+	}
+
+	return state
+}
+
+func revisionOf(annotations map[string]string) int64 {
+	revision, err := strconv.ParseInt(annotations[RevisionAnnotation], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return revision
+}
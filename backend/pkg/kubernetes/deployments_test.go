@@ -0,0 +1,221 @@
+package kubernetes
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const testNamespace = "shop"
+
+func int32Ptr(n int32) *int32 {
+	return &n
+}
+
+// testDeployment is the web deployment at revision current, with its image at that revision
+func testDeployment(current int64) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web",
+			Namespace:   testNamespace,
+			UID:         types.UID("web-uid"),
+			Annotations: map[string]string{RevisionAnnotation: strconv.FormatInt(current, 10)},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: int32Ptr(2),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			Template: podTemplate(current),
+		},
+	}
+}
+
+func podTemplate(revision int64) corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				"app":                                  "web",
+				appsv1.DefaultDeploymentUniqueLabelKey: "hash-" + strconv.FormatInt(revision, 10),
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "web", Image: imageAt(revision)}},
+		},
+	}
+}
+
+func imageAt(revision int64) string {
+	return "registry.example.com/web:v" + strconv.FormatInt(revision, 10)
+}
+
+// replicaSet is the ReplicaSet of a revision, controlled by owner when it is set
+func replicaSet(revision int64, owner *appsv1.Deployment) *appsv1.ReplicaSet {
+	rs := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web-" + strconv.FormatInt(revision, 10),
+			Namespace:   testNamespace,
+			Labels:      map[string]string{"app": "web"},
+			Annotations: map[string]string{RevisionAnnotation: strconv.FormatInt(revision, 10)},
+		},
+		Spec: appsv1.ReplicaSetSpec{Template: podTemplate(revision)},
+	}
+	if owner != nil {
+		rs.OwnerReferences = []metav1.OwnerReference{
+			*metav1.NewControllerRef(owner, appsv1.SchemeGroupVersion.WithKind("Deployment")),
+		}
+	}
+	return rs
+}
+
+func testClient(objects ...runtime.Object) *Client {
+	return NewClientFromClientset(fake.NewClientset(objects...), "test")
+}
+
+func getDeployment(t *testing.T, c *Client) *appsv1.Deployment {
+	t.Helper()
+
+	deployment, err := c.clientset.AppsV1().Deployments(testNamespace).Get(context.Background(), "web", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("getting deployment: %v", err)
+	}
+	return deployment
+}
+
+func TestRollbackDeployment(t *testing.T) {
+	deployment := testDeployment(3)
+	// Revision 4 matches the selector but belongs to another owner
+	objects := []runtime.Object{
+		deployment,
+		replicaSet(1, deployment),
+		replicaSet(2, deployment),
+		replicaSet(3, deployment),
+		replicaSet(4, nil),
+	}
+
+	tests := []struct {
+		name       string
+		toRevision int64
+		want       int64
+		wantErr    bool
+	}{
+		{name: "previous revision", toRevision: 0, want: 2},
+		{name: "explicit revision", toRevision: 1, want: 1},
+		{name: "current revision", toRevision: 3, wantErr: true},
+		{name: "unknown revision", toRevision: 9, wantErr: true},
+		{name: "revision of another owner", toRevision: 4, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testClient(objects...)
+
+			got, err := c.RollbackDeployment(testNamespace, "web", tt.toRevision)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("rolled back to %d, want an error", got)
+				}
+				if image := getDeployment(t, c).Spec.Template.Spec.Containers[0].Image; image != imageAt(3) {
+					t.Errorf("failed rollback changed the image to %s", image)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RollbackDeployment: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("rolled back to revision %d, want %d", got, tt.want)
+			}
+
+			template := getDeployment(t, c).Spec.Template
+			if image := template.Spec.Containers[0].Image; image != imageAt(tt.want) {
+				t.Errorf("image is %s, want %s", image, imageAt(tt.want))
+			}
+			if _, ok := template.Labels[appsv1.DefaultDeploymentUniqueLabelKey]; ok {
+				t.Errorf("pod-template-hash label was copied from the ReplicaSet: %v", template.Labels)
+			}
+			if template.Labels["app"] != "web" {
+				t.Errorf("selector label lost: %v", template.Labels)
+			}
+		})
+	}
+}
+
+func TestRollbackWithoutPreviousRevision(t *testing.T) {
+	deployment := testDeployment(1)
+	c := testClient(deployment, replicaSet(1, deployment))
+
+	if _, err := c.RollbackDeployment(testNamespace, "web", 0); err == nil {
+		t.Fatal("rollback of the first revision succeeded")
+	}
+}
+
+func TestScaleDeployment(t *testing.T) {
+	tests := []struct {
+		name     string
+		replicas int32
+		want     int32
+		wantErr  bool
+	}{
+		{name: "scale up", replicas: 5, want: 5},
+		{name: "scale to zero", replicas: 0, want: 0},
+		{name: "negative", replicas: -1, want: 2, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testClient(testDeployment(1))
+
+			err := c.ScaleDeployment(testNamespace, "web", tt.replicas)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ScaleDeployment(%d) returned %v, want error %v", tt.replicas, err, tt.wantErr)
+			}
+
+			state, err := c.GetDeploymentState(testNamespace, "web")
+			if err != nil {
+				t.Fatalf("GetDeploymentState: %v", err)
+			}
+			if state.Replicas != tt.want {
+				t.Errorf("deployment has %d replicas, want %d", state.Replicas, tt.want)
+			}
+		})
+	}
+
+	if err := testClient().ScaleDeployment(testNamespace, "missing", 1); err == nil {
+		t.Error("scaling a missing deployment succeeded")
+	}
+}
+
+func TestRestartDeploymentAnnotation(t *testing.T) {
+	c := testClient(testDeployment(1))
+
+	restartedAt, err := c.RestartDeployment(testNamespace, "web")
+	if err != nil {
+		t.Fatalf("RestartDeployment: %v", err)
+	}
+	if restartedAt == "" {
+		t.Fatal("RestartDeployment returned no timestamp")
+	}
+
+	state, err := c.GetDeploymentState(testNamespace, "web")
+	if err != nil {
+		t.Fatalf("GetDeploymentState: %v", err)
+	}
+	if state.RestartedAt != restartedAt {
+		t.Errorf("restart annotation is %q, want %q", state.RestartedAt, restartedAt)
+	}
+
+	// Undoing the restart of a deployment never restarted before removes the annotation
+	if err := c.SetRestartedAt(testNamespace, "web", ""); err != nil {
+		t.Fatalf("SetRestartedAt: %v", err)
+	}
+	annotations := getDeployment(t, c).Spec.Template.Annotations
+	if _, ok := annotations[RestartedAtAnnotation]; ok {
+		t.Errorf("restart annotation still set: %v", annotations)
+	}
+}
@@ -2,6 +2,7 @@ package openai
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -29,9 +30,15 @@ type ModelsResponse struct {
 }
 
 type ChatCompletionRequest struct {
-	Model    string          `json:"model"`
-	Messages []ChatMessage   `json:"messages"`
-	Stream   bool            `json:"stream,omitempty"`
+	Model         string         `json:"model"`
+	Messages      []ChatMessage  `json:"messages"`
+	Stream        bool           `json:"stream,omitempty"`
+	StreamOptions *StreamOptions `json:"stream_options,omitempty"`
+}
+
+// StreamOptions asks for a final chunk with the token usage of a streamed completion
+type StreamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
 }
 
 type ChatMessage struct {
@@ -45,6 +52,13 @@ type ChatCompletionResponse struct {
 	Created int64    `json:"created"`
 	Model   string   `json:"model"`
 	Choices []Choice `json:"choices"`
+	Usage   *Usage   `json:"usage,omitempty"`
+}
+
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
 }
 
 type Choice struct {
@@ -63,10 +77,10 @@ func NewClient(apiKey, organization string) *Client {
 	}
 }
 
-func (c *Client) doRequest(method, path string, body io.Reader) (*http.Response, error) {
+func (c *Client) doRequest(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
 	url := fmt.Sprintf("%s%s", c.baseURL, path)
 
-	req, err := http.NewRequest(method, url, body)
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -88,15 +102,14 @@ func (c *Client) doRequest(method, path string, body io.Reader) (*http.Response,
 
 // ListModels retrieves the list of available models
 func (c *Client) ListModels() (*ModelsResponse, error) {
-	resp, err := c.doRequest("GET", "/models", nil)
+	resp, err := c.doRequest(context.Background(), "GET", "/models", nil)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(resp)
 	}
 
 	var models ModelsResponse
@@ -113,22 +126,21 @@ func (c *Client) TestConnection() error {
 	return err
 }
 
-// CreateChatCompletion creates a chat completion
-func (c *Client) CreateChatCompletion(request ChatCompletionRequest) (*ChatCompletionResponse, error) {
+// CreateChatCompletion creates a chat completion; cancelling ctx aborts the request
+func (c *Client) CreateChatCompletion(ctx context.Context, request ChatCompletionRequest) (*ChatCompletionResponse, error) {
 	bodyBytes, err := json.Marshal(request)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	resp, err := c.doRequest("POST", "/chat/completions", bytes.NewBuffer(bodyBytes))
+	resp, err := c.doRequest(ctx, "POST", "/chat/completions", bytes.NewBuffer(bodyBytes))
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(resp)
 	}
 
 	var completion ChatCompletionResponse
@@ -0,0 +1,63 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type EmbeddingRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type Embedding struct {
+	Index     int       `json:"index"`
+	Embedding []float32 `json:"embedding"`
+}
+
+type EmbeddingResponse struct {
+	Model string      `json:"model"`
+	Data  []Embedding `json:"data"`
+	Usage *Usage      `json:"usage,omitempty"`
+}
+
+// CreateEmbeddings embeds a batch of texts; the vectors come back in the order of the input
+func (c *Client) CreateEmbeddings(ctx context.Context, request EmbeddingRequest) (*EmbeddingResponse, error) {
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	resp, err := c.doRequest(ctx, "POST", "/embeddings", bytes.NewBuffer(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var response EmbeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if len(response.Data) != len(request.Input) {
+		return nil, fmt.Errorf("expected %d embeddings, got %d", len(request.Input), len(response.Data))
+	}
+
+	ordered := make([]Embedding, len(response.Data))
+	for _, embedding := range response.Data {
+		if embedding.Index < 0 || embedding.Index >= len(ordered) {
+			return nil, fmt.Errorf("embedding index %d out of range", embedding.Index)
+		}
+		ordered[embedding.Index] = embedding
+	}
+	response.Data = ordered
+
+	return &response, nil
+}
@@ -0,0 +1,45 @@
+// Package pagination holds the URL helpers shared by the API clients that page through
+// list endpoints with Link headers (GitHub, GitLab)
+package pagination
+
+import (
+	"net/url"
+	"strings"
+)
+
+// WithQueryParam sets a query parameter of a path or URL, replacing its previous value
+func WithQueryParam(path, key, value string) (string, error) {
+	u, err := url.Parse(path)
+	if err != nil {
+		return "", err
+	}
+
+	query := u.Query()
+	query.Set(key, value)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// NextPageURL extracts the rel="next" URL of a Link header:
+// <https://api.github.com/...&page=2>; rel="next", <https://api.github.com/...&page=5>; rel="last"
+func NextPageURL(link string) string {
+	for _, part := range strings.Split(link, ",") {
+		sections := strings.Split(part, ";")
+		if len(sections) < 2 {
+			continue
+		}
+
+		target := strings.TrimSpace(sections[0])
+		if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
+
+		for _, param := range sections[1:] {
+			if strings.TrimSpace(param) == `rel="next"` {
+				return target[1 : len(target)-1]
+			}
+		}
+	}
+
+	return ""
+}
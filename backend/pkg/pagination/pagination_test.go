@@ -0,0 +1,61 @@
+package pagination
+
+import "testing"
+
+func TestNextPageURL(t *testing.T) {
+	tests := []struct {
+		name string
+		link string
+		want string
+	}{
+		{
+			name: "next and last",
+			link: `<https://api.github.com/orgs/acme/repos?page=2>; rel="next", <https://api.github.com/orgs/acme/repos?page=5>; rel="last"`,
+			want: "https://api.github.com/orgs/acme/repos?page=2",
+		},
+		{
+			name: "next after first and prev",
+			link: `<https://gitlab.com/api/v4/projects?page=1>; rel="first", <https://gitlab.com/api/v4/projects?page=2>; rel="prev", <https://gitlab.com/api/v4/projects?page=4>; rel="next"`,
+			want: "https://gitlab.com/api/v4/projects?page=4",
+		},
+		{name: "last page", link: `<https://api.github.com/orgs/acme/repos?page=1>; rel="first", <https://api.github.com/orgs/acme/repos?page=4>; rel="prev"`},
+		{name: "no header"},
+		{name: "target without brackets", link: `https://api.github.com/orgs/acme/repos?page=2; rel="next"`},
+		{name: "no rel", link: `<https://api.github.com/orgs/acme/repos?page=2>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NextPageURL(tt.link); got != tt.want {
+				t.Errorf("NextPageURL = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithQueryParam(t *testing.T) {
+	tests := []struct {
+		path  string
+		key   string
+		value string
+		want  string
+	}{
+		{path: "/projects", key: "per_page", value: "100", want: "/projects?per_page=100"},
+		{path: "/projects?membership=true", key: "per_page", value: "20", want: "/projects?membership=true&per_page=20"},
+		{path: "https://gitlab.com/api/v4/projects?page=2&per_page=20", key: "page", value: "3", want: "https://gitlab.com/api/v4/projects?page=3&per_page=20"},
+	}
+
+	for _, tt := range tests {
+		got, err := WithQueryParam(tt.path, tt.key, tt.value)
+		if err != nil {
+			t.Fatalf("WithQueryParam(%q): %v", tt.path, err)
+		}
+		if got != tt.want {
+			t.Errorf("WithQueryParam(%q, %q, %q) = %q, want %q", tt.path, tt.key, tt.value, got, tt.want)
+		}
+	}
+
+	if _, err := WithQueryParam("%zz", "page", "1"); err == nil {
+		t.Error("an invalid path was accepted")
+	}
+}
@@ -0,0 +1,74 @@
+package sse
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// maxEventSize bounds a single line of the stream (providers send one JSON chunk per line)
+const maxEventSize = 1024 * 1024
+
+// Event is a Server-Sent Event: its name (empty when the stream omits "event:") and its data
+// lines joined by "\n"
+type Event struct {
+	Name string
+	Data string
+}
+
+// Reader decodes a text/event-stream body
+type Reader struct {
+	scanner *bufio.Scanner
+}
+
+func NewReader(r io.Reader) *Reader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxEventSize)
+	return &Reader{scanner: scanner}
+}
+
+// Next returns the next event with data, or io.EOF at the end of the stream
+func (r *Reader) Next() (*Event, error) {
+	event := &Event{}
+	var data []string
+
+	for r.scanner.Scan() {
+		line := strings.TrimSuffix(r.scanner.Text(), "\r")
+
+		if line == "" {
+			if len(data) > 0 {
+				event.Data = strings.Join(data, "\n")
+				return event, nil
+			}
+			event = &Event{}
+			continue
+		}
+
+		// Comments (keep-alives)
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "event":
+			event.Name = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// Stream closed without the blank line of the last event
+	if len(data) > 0 {
+		event.Data = strings.Join(data, "\n")
+		return event, nil
+	}
+
+	return nil, io.EOF
+}
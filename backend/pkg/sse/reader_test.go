@@ -0,0 +1,111 @@
+package sse
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// readAll reads every event of a stream
+func readAll(r *Reader) ([]Event, error) {
+	var events []Event
+	for {
+		event, err := r.Next()
+		if err == io.EOF {
+			return events, nil
+		}
+		if err != nil {
+			return events, err
+		}
+		events = append(events, *event)
+	}
+}
+
+func TestReaderNext(t *testing.T) {
+	tests := []struct {
+		name   string
+		stream string
+		want   []Event
+	}{
+		{
+			name:   "named events",
+			stream: "event: delta\ndata: {\"content\":\"Hel\"}\n\nevent: done\ndata: {}\n\n",
+			want:   []Event{{Name: "delta", Data: `{"content":"Hel"}`}, {Name: "done", Data: "{}"}},
+		},
+		{
+			name:   "unnamed event",
+			stream: "data: {\"choices\":[]}\n\ndata: [DONE]\n\n",
+			want:   []Event{{Data: `{"choices":[]}`}, {Data: "[DONE]"}},
+		},
+		{
+			name:   "multi-line data",
+			stream: "event: message\ndata: first line\ndata: second line\ndata:\ndata: fourth line\n\n",
+			want:   []Event{{Name: "message", Data: "first line\nsecond line\n\nfourth line"}},
+		},
+		{
+			name:   "CRLF line endings",
+			stream: "event: delta\r\ndata: a\r\ndata: b\r\n\r\n",
+			want:   []Event{{Name: "delta", Data: "a\nb"}},
+		},
+		{
+			name:   "value without a space",
+			stream: "event:delta\ndata:x: y\n\n",
+			want:   []Event{{Name: "delta", Data: "x: y"}},
+		},
+		{
+			name:   "comments and unknown fields",
+			stream: ": keep-alive\nid: 7\nretry: 1000\ndata: a\n\n: keep-alive\n\n",
+			want:   []Event{{Data: "a"}},
+		},
+		{
+			name:   "event without data is dropped with its name",
+			stream: "event: ping\n\ndata: a\n\n",
+			want:   []Event{{Data: "a"}},
+		},
+		{
+			name:   "last event without a blank line",
+			stream: "data: a\n\nevent: done\ndata: b",
+			want:   []Event{{Data: "a"}, {Name: "done", Data: "b"}},
+		},
+		{name: "empty stream"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readAll(NewReader(strings.NewReader(tt.stream)))
+			if err != nil {
+				t.Fatalf("Next: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("events are %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReaderReportsBrokenStreams(t *testing.T) {
+	t.Run("connection lost", func(t *testing.T) {
+		pr, pw := io.Pipe()
+		go func() {
+			io.WriteString(pw, "data: a\n\ndata: b\n")
+			pw.CloseWithError(io.ErrUnexpectedEOF)
+		}()
+
+		events, err := readAll(NewReader(pr))
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("got error %v, want %v", err, io.ErrUnexpectedEOF)
+		}
+		if want := []Event{{Data: "a"}}; !reflect.DeepEqual(events, want) {
+			t.Errorf("events before the failure are %+v, want %+v", events, want)
+		}
+	})
+
+	t.Run("line over the size limit", func(t *testing.T) {
+		stream := "data: " + strings.Repeat("x", maxEventSize) + "\n\n"
+		if _, err := NewReader(strings.NewReader(stream)).Next(); err == nil {
+			t.Error("an oversized line was accepted")
+		}
+	})
+}
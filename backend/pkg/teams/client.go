@@ -16,13 +16,28 @@ type Client struct {
 	httpClient *http.Client
 }
 
-func NewClient(config domain.TeamsConfig) *Client {
-	return &Client{
+// ClientOption customizes a Client
+type ClientOption func(*Client)
+
+// WithHTTPClient replaces the HTTP client used to post to the webhook, e.g. one that refuses
+// internal destinations
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+func NewClient(config domain.TeamsConfig, opts ...ClientOption) *Client {
+	c := &Client{
 		webhookURL: config.WebhookURL,
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 // SendMessage sends a message to Microsoft Teams via webhook
@@ -57,9 +72,11 @@ func (c *Client) SendMessage(message domain.TeamsMessage) error {
 	}
 	defer resp.Body.Close()
 
+	// Only the status is reported: the error ends up in delivery logs, which must not echo
+	// what the destination answered
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
 	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return fmt.Errorf("API request failed with status %d", resp.StatusCode)
 	}
 
 	return nil
@@ -21,6 +21,7 @@ function SlackModal({ integration, isCreating, onSave, onClose }: SlackModalProp
   const [name, setName] = useState(integration?.name || '')
   const [webhookUrl, setWebhookUrl] = useState(integration?.config?.webhookUrl || '')
   const [botToken, setBotToken] = useState(integration?.config?.botToken || '')
+  const [signingSecret, setSigningSecret] = useState(integration?.config?.signingSecret || '')
   const [saving, setSaving] = useState(false)
   const [testing, setTesting] = useState(false)
   const [testResult, setTestResult] = useState<{ success: boolean; message: string } | null>(null)
@@ -93,6 +94,7 @@ function SlackModal({ integration, isCreating, onSave, onClose }: SlackModalProp
         config: {
           webhookUrl,
           botToken: botToken || undefined,
+          signingSecret: signingSecret || undefined,
         },
       })
     } catch (err) {
@@ -170,6 +172,24 @@ function SlackModal({ integration, isCreating, onSave, onClose }: SlackModalProp
             </p>
           </div>
 
+          <div className="mb-6">
+            <label htmlFor="signingSecret" className="block text-sm font-medium text-text mb-2">
+              Signing Secret (opcional)
+            </label>
+            <input
+              id="signingSecret"
+              type="password"
+              className="w-full px-3 py-2.5 text-sm text-text bg-surface border border-border rounded-md transition-all duration-200 focus:outline-none focus:border-primary focus:shadow-[0_0_0_3px_rgba(99,102,241,0.1)]"
+              value={signingSecret}
+              onChange={(e) => setSigningSecret(e.target.value)}
+              placeholder="Signing Secret do app Slack"
+            />
+            <p className="mt-1.5 text-xs text-text-secondary leading-snug">
+              Necessário para o comando <strong>/incident</strong>. Configure o Slash Command com a URL
+              <strong> /api/v1/webhooks/slack/&lt;organização&gt;/commands</strong>
+            </p>
+          </div>
+
           <div className="my-6 p-5 bg-surface border border-border rounded-lg">
             <button
               type="button"
@@ -11,9 +11,10 @@ import {
   Shield,
   DollarSign,
   FileText,
-  Activity
+  Activity,
+  Rocket
 } from 'lucide-react'
-import { buildApiUrl } from '../config/api'
+import { apiFetch } from '../config/api'
 
 interface MaturityScore {
   category: string
@@ -53,7 +54,8 @@ const categoryIcons: Record<string, any> = {
   incident_response: AlertCircle,
   finops: DollarSign,
   security: Shield,
-  documentation: FileText
+  documentation: FileText,
+  delivery: Rocket
 }
 
 const categoryLabels: Record<string, string> = {
@@ -62,7 +64,8 @@ const categoryLabels: Record<string, string> = {
   incident_response: 'Resposta a Incidentes',
   finops: 'FinOps',
   security: 'Segurança',
-  documentation: 'Documentação'
+  documentation: 'Documentação',
+  delivery: 'Entrega'
 }
 
 const levelColors: Record<string, string> = {
@@ -83,11 +86,7 @@ function MaturityPage() {
 
     try {
       setLoading(true)
-      const response = await fetch(buildApiUrl(`maturity/team/${teamName}/scorecard`), {
-        headers: {
-          'Authorization': `Bearer ${localStorage.getItem('token')}`
-        }
-      })
+      const response = await apiFetch(`maturity/team/${encodeURIComponent(teamName)}/scorecard`)
 
       if (response.ok) {
         const data = await response.json()
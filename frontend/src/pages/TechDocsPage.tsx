@@ -5,7 +5,7 @@ import remarkGfm from 'remark-gfm'
 import rehypeRaw from 'rehype-raw'
 import rehypeSanitize from 'rehype-sanitize'
 import AIAssistant from '../components/TechDocs/AIAssistant'
-import { buildApiUrl } from '../config/api'
+import { apiFetch } from '../config/api'
 
 interface TreeNode {
   path: string
@@ -41,7 +41,7 @@ function TechDocsPage() {
 
   const fetchTree = async () => {
     try {
-      const response = await fetch(buildApiUrl('techdocs/tree'))
+      const response = await apiFetch('techdocs/tree')
       if (!response.ok) throw new Error('Failed to fetch document tree')
       const data = await response.json()
       setTree(data.tree || [])
@@ -54,7 +54,7 @@ function TechDocsPage() {
 
   const fetchDocument = async (path: string) => {
     try {
-      const response = await fetch(buildApiUrl(`techdocs/document?path=${encodeURIComponent(path)}`))
+      const response = await apiFetch(`techdocs/document?path=${encodeURIComponent(path)}`)
       if (!response.ok) throw new Error('Failed to fetch document')
       const data = await response.json()
       setSelectedDoc(data)
@@ -70,7 +70,7 @@ function TechDocsPage() {
     if (!selectedDoc) return
 
     try {
-      const response = await fetch(buildApiUrl('techdocs/document'), {
+      const response = await apiFetch('techdocs/document', {
         method: 'POST',
         headers: {
           'Content-Type': 'application/json',
@@ -100,7 +100,7 @@ function TechDocsPage() {
     if (!confirmed) return
 
     try {
-      const response = await fetch(buildApiUrl(`techdocs/document?path=${encodeURIComponent(selectedDoc.path)}`), {
+      const response = await apiFetch(`techdocs/document?path=${encodeURIComponent(selectedDoc.path)}`, {
         method: 'DELETE',
       })
 
@@ -122,7 +122,7 @@ function TechDocsPage() {
     }
 
     try {
-      const response = await fetch(buildApiUrl('techdocs/document'), {
+      const response = await apiFetch('techdocs/document', {
         method: 'POST',
         headers: {
           'Content-Type': 'application/json',
@@ -153,7 +153,7 @@ function TechDocsPage() {
     }
 
     try {
-      const response = await fetch(buildApiUrl('techdocs/folder'), {
+      const response = await apiFetch('techdocs/folder', {
         method: 'POST',
         headers: {
           'Content-Type': 'application/json',
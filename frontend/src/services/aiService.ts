@@ -154,7 +154,7 @@ export const aiService = {
   },
 
   async generateDocumentation(request: GenerateDocRequest): Promise<TechDocsProgress> {
-    const response = await fetch(buildApiUrl('techdocs/generate'), {
+    const response = await apiFetch('techdocs/generate', {
       method: 'POST',
       headers: {
         'Content-Type': 'application/json',
@@ -170,7 +170,7 @@ export const aiService = {
   },
 
   async getDocumentationProgress(progressId: string): Promise<TechDocsProgress> {
-    const response = await fetch(buildApiUrl(`techdocs/progress/${progressId}`))
+    const response = await apiFetch(`techdocs/progress/${progressId}`)
     if (!response.ok) {
       const error = await response.json().catch(() => ({}))
       throw new Error(error.error || 'Failed to fetch documentation progress')
@@ -180,7 +180,7 @@ export const aiService = {
   },
 
   async improveDocumentation(request: ImproveDocRequest): Promise<AIResponse> {
-    const response = await fetch(buildApiUrl('techdocs/improve'), {
+    const response = await apiFetch('techdocs/improve', {
       method: 'POST',
       headers: {
         'Content-Type': 'application/json',
@@ -195,7 +195,7 @@ export const aiService = {
   },
 
   async chat(request: ChatRequest): Promise<AIResponse> {
-    const response = await fetch(buildApiUrl('techdocs/chat'), {
+    const response = await apiFetch('techdocs/chat', {
       method: 'POST',
       headers: {
         'Content-Type': 'application/json',
@@ -210,7 +210,7 @@ export const aiService = {
   },
 
   async generateDiagram(request: GenerateDiagramRequest): Promise<DiagramResponse> {
-    const response = await fetch(buildApiUrl('techdocs/diagram'), {
+    const response = await apiFetch('techdocs/diagram', {
       method: 'POST',
       headers: {
         'Content-Type': 'application/json',